@@ -167,6 +167,10 @@ const (
 	AgentServiceName  = "AGENT_SERVICE_NAME"
 	MongoOplogSize    = "MONGO_OPLOG_SIZE"
 	NUMACtlPreference = "NUMA_CTL_PREFERENCE"
+
+	// PreferredAddressFamily holds the address family, "ipv4" or
+	// "ipv6", preferred by the agent when selecting addresses.
+	PreferredAddressFamily = "PREFERRED_ADDRESS_FAMILY"
 )
 
 // The Config interface is the sole way that the agent gets access to the
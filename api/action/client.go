@@ -5,9 +5,12 @@ package action
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
 )
 
 // Client provides access to the action facade.
@@ -43,11 +46,56 @@ func (c *Client) FindActionTagsByPrefix(arg params.FindTags) (params.FindTagsRes
 // queued Action, or an error if there was a problem queueing up the
 // Action.
 func (c *Client) Enqueue(arg params.Actions) (params.ActionResults, error) {
+	if err := c.checkTimeoutsSupported(arg); err != nil {
+		return params.ActionResults{}, err
+	}
 	results := params.ActionResults{}
 	err := c.facade.FacadeCall("Enqueue", arg, &results)
 	return results, err
 }
 
+// EnqueueOperation takes a list of Actions and queues them up as a
+// single operation, returning the id of the operation and the
+// params.Action queued on each unit. The receiver of an Action may be a
+// unit tag, an application tag, to queue the Action on all of the
+// application's units, or "<application>/leader".
+func (c *Client) EnqueueOperation(arg params.Actions) (params.OperationResult, error) {
+	if c.BestAPIVersion() < 5 {
+		return params.OperationResult{}, errors.NotSupportedf("enqueueing operations")
+	}
+	if err := c.checkTimeoutsSupported(arg); err != nil {
+		return params.OperationResult{}, err
+	}
+	result := params.OperationResult{}
+	err := c.facade.FacadeCall("EnqueueOperation", arg, &result)
+	return result, err
+}
+
+// checkTimeoutsSupported returns an error if any of the Actions has a
+// timeout and the controller does not support action timeouts.
+func (c *Client) checkTimeoutsSupported(arg params.Actions) error {
+	if c.BestAPIVersion() >= 6 {
+		return nil
+	}
+	for _, action := range arg.Actions {
+		if action.Timeout != 0 {
+			return errors.NotSupportedf("action timeouts")
+		}
+	}
+	return nil
+}
+
+// Operations takes a list of operation ids and returns the Actions
+// queued as part of each operation.
+func (c *Client) Operations(arg params.OperationQueryArgs) (params.OperationResults, error) {
+	if c.BestAPIVersion() < 5 {
+		return params.OperationResults{}, errors.NotSupportedf("operations")
+	}
+	results := params.OperationResults{}
+	err := c.facade.FacadeCall("Operations", arg, &results)
+	return results, err
+}
+
 // FindActionsByNames takes a list of action names and returns actions for
 // every name.
 func (c *Client) FindActionsByNames(arg params.FindActionsByNames) (params.ActionsByNames, error) {
@@ -90,6 +138,33 @@ func (c *Client) Cancel(arg params.Entities) (params.ActionResults, error) {
 	return results, err
 }
 
+// WatchActionProgress returns a StringsWatcher for observing the
+// progress messages logged by the Action with the given tag. Each
+// change holds JSON-encoded params.ActionMessages; the initial event
+// holds the messages logged so far.
+func (c *Client) WatchActionProgress(tag names.ActionTag) (watcher.StringsWatcher, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("watching action progress")
+	}
+	var results params.StringsWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	err := c.facade.FacadeCall("WatchActionsProgress", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // applicationsCharmActions is a batched query for the charm.Actions for a slice
 // of services by Entity.
 func (c *Client) applicationsCharmActions(arg params.Entities) (params.ApplicationsCharmActionsResults, error) {
@@ -4,14 +4,19 @@
 package action_test
 
 import (
+	"encoding/json"
 	"errors"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
+	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/api/action"
+	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type actionSuite struct {
@@ -109,6 +114,125 @@ func (s *actionSuite) TestApplicationCharmActions(c *gc.C) {
 	}
 }
 
+func (s *actionSuite) TestWatchActionProgress(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	a, err := s.State.EnqueueAction(unit.Tag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("one")
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := s.client.WatchActionProgress(a.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		c.Assert(worker.Stop(w), jc.ErrorIsNil)
+	}()
+
+	expectMessage := func(expected string) {
+		s.BackingState.StartSync()
+		select {
+		case changes, ok := <-w.Changes():
+			c.Assert(ok, jc.IsTrue)
+			c.Assert(changes, gc.HasLen, 1)
+			var message params.ActionMessage
+			err := json.Unmarshal([]byte(changes[0]), &message)
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(message.Message, gc.Equals, expected)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for action messages")
+		}
+	}
+	expectMessage("one")
+	err = a.Log("two")
+	c.Assert(err, jc.ErrorIsNil)
+	expectMessage("two")
+}
+
+func (s *actionSuite) TestWatchActionProgressNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 3,
+	}
+	client := action.NewClient(apiCaller)
+	_, err := client.WatchActionProgress(names.NewActionTag("feedface-0123-4567-8901-2345deadbeef"))
+	c.Assert(err, gc.ErrorMatches, "watching action progress not supported")
+}
+
+func (s *actionSuite) TestEnqueueOperation(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+
+	enqueued, err := s.client.EnqueueOperation(params.Actions{
+		Actions: []params.Action{{
+			Receiver: names.NewApplicationTag(unit.ApplicationName()).String(),
+			Name:     "fakeaction",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(enqueued.Actions, gc.HasLen, 1)
+	c.Assert(enqueued.Actions[0].Error, gc.IsNil)
+	c.Check(enqueued.Actions[0].Action.Receiver, gc.Equals, unit.Tag().String())
+
+	results, err := s.client.Operations(params.OperationQueryArgs{
+		Operations: []string{enqueued.OperationId},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Actions, gc.HasLen, 1)
+	c.Check(results.Results[0].Actions[0].Action.Tag, gc.Equals, enqueued.Actions[0].Action.Tag)
+}
+
+func (s *actionSuite) TestOperationsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := action.NewClient(apiCaller)
+	_, err := client.EnqueueOperation(params.Actions{})
+	c.Assert(err, gc.ErrorMatches, "enqueueing operations not supported")
+	_, err = client.Operations(params.OperationQueryArgs{})
+	c.Assert(err, gc.ErrorMatches, "operations not supported")
+}
+
+func (s *actionSuite) TestTimeoutsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := action.NewClient(apiCaller)
+	arg := params.Actions{
+		Actions: []params.Action{{Receiver: "unit-mysql-0", Name: "backup", Timeout: time.Minute}},
+	}
+	_, err := client.Enqueue(arg)
+	c.Assert(err, gc.ErrorMatches, "action timeouts not supported")
+	_, err = client.EnqueueOperation(arg)
+	c.Assert(err, gc.ErrorMatches, "action timeouts not supported")
+}
+
+func (s *actionSuite) TestRunSelectorsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 6,
+	}
+	client := action.NewClient(apiCaller)
+	_, err := client.Run(params.RunParams{Commands: "hostname", Applications: []string{"mysql"}, LeaderOnly: true})
+	c.Assert(err, gc.ErrorMatches, "run target selection not supported")
+	_, err = client.RunOnSelectedMachines(params.RunParams{Commands: "hostname", Series: "xenial"})
+	c.Assert(err, gc.ErrorMatches, "run target selection not supported")
+}
+
 // replace sCharmActions" facade call with required results and error
 // if desired
 func patchApplicationCharmActions(c *gc.C, apiCli *action.Client, patchResults []params.ApplicationCharmActionsResult, err string) func() {
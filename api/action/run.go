@@ -6,6 +6,8 @@ package action
 import (
 	"time"
 
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/params"
 )
 
@@ -18,10 +20,50 @@ func (c *Client) RunOnAllMachines(commands string, timeout time.Duration) ([]par
 	return results.Results, err
 }
 
+// RunOnSelectedMachines runs the commands on those of the machines
+// that match the selectors of run.
+func (c *Client) RunOnSelectedMachines(run params.RunParams) ([]params.ActionResult, error) {
+	if err := c.checkRunSelectorsSupported(run); err != nil {
+		return nil, err
+	}
+	var results params.ActionResults
+	err := c.facade.FacadeCall("RunOnAllMachines", run, &results)
+	return results.Results, err
+}
+
 // Run the Commands specified on the machines identified through the ids
 // provided in the machines, services and units slices.
 func (c *Client) Run(run params.RunParams) ([]params.ActionResult, error) {
+	if err := c.checkRunSelectorsSupported(run); err != nil {
+		return nil, err
+	}
 	var results params.ActionResults
 	err := c.facade.FacadeCall("Run", run, &results)
 	return results.Results, err
 }
+
+// CheckNetwork queues an action on the units of the specified
+// applications, or of all applications if none are specified, which
+// probes the ingress addresses of their related units. Each probe
+// times out after the specified duration.
+func (c *Client) CheckNetwork(applications []string, timeout time.Duration) ([]params.ActionResult, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("check-network")
+	}
+	var results params.ActionResults
+	args := params.CheckNetworkParams{Applications: applications, Timeout: timeout}
+	err := c.facade.FacadeCall("CheckNetwork", args, &results)
+	return results.Results, err
+}
+
+// checkRunSelectorsSupported returns an error if the run selects its
+// targets and the controller does not support run target selection.
+func (c *Client) checkRunSelectorsSupported(run params.RunParams) error {
+	if c.BestAPIVersion() >= 7 {
+		return nil
+	}
+	if run.LeaderOnly || run.Status != "" || run.Series != "" {
+		return errors.NotSupportedf("run target selection")
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionpruner
+
+import (
+	"time"
+
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const apiName = "ActionPruner"
+
+// Facade allows calls to "ActionPruner" endpoints.
+type Facade struct {
+	facade base.FacadeCaller
+	*common.ModelWatcher
+}
+
+// NewFacade returns an "ActionPruner" Facade.
+func NewFacade(caller base.APICaller) *Facade {
+	facadeCaller := base.NewFacadeCaller(caller, apiName)
+	return &Facade{facade: facadeCaller, ModelWatcher: common.NewModelWatcher(facadeCaller)}
+}
+
+// ActionsToPrune calls "ActionPruner.ActionsToPrune".
+func (s *Facade) ActionsToPrune(maxHistoryTime time.Duration, maxHistoryMB int) ([]params.ActionResult, error) {
+	p := params.ActionPruneArgs{
+		MaxHistoryTime: maxHistoryTime,
+		MaxHistoryMB:   maxHistoryMB,
+	}
+	var results params.ActionResults
+	if err := s.facade.FacadeCall("ActionsToPrune", p, &results); err != nil {
+		return nil, err
+	}
+	return results.Results, nil
+}
+
+// RemoveActions calls "ActionPruner.RemoveActions".
+func (s *Facade) RemoveActions(actions []names.ActionTag) error {
+	args := params.Entities{Entities: make([]params.Entity, len(actions))}
+	for i, tag := range actions {
+		args.Entities[i].Tag = tag.String()
+	}
+	return s.facade.FacadeCall("RemoveActions", args, nil)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
 )
 
@@ -96,12 +97,20 @@ type DeployArgs struct {
 	// value being the unique ID of a pre-uploaded resources in
 	// storage.
 	Resources map[string]string
+
+	// IngressAddresses holds the addresses, and optionally ports,
+	// advertised to related units on the application's endpoints
+	// in place of those of its units, keyed by endpoint name.
+	IngressAddresses map[string]string
 }
 
 // Deploy obtains the charm, either locally or from the charm store, and deploys
 // it. Placement directives, if provided, specify the machine on which the charm
 // is deployed.
 func (c *Client) Deploy(args DeployArgs) error {
+	if len(args.IngressAddresses) > 0 && c.BestAPIVersion() < 7 {
+		return errors.NotSupportedf("deploying with ingress addresses")
+	}
 	deployArgs := params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			ApplicationName:  args.ApplicationName,
@@ -115,6 +124,7 @@ func (c *Client) Deploy(args DeployArgs) error {
 			Storage:          args.Storage,
 			EndpointBindings: args.EndpointBindings,
 			Resources:        args.Resources,
+			IngressAddresses: args.IngressAddresses,
 		}},
 	}
 	var results params.ErrorResults
@@ -359,6 +369,178 @@ func (c *Client) Unexpose(application string) error {
 	return c.facade.FacadeCall("Unexpose", params, nil)
 }
 
+// ExposeEndpoints changes the juju-managed firewall to expose any ports
+// that were also explicitly marked by units as open, merging the
+// specified expose settings, keyed by endpoint name, into the
+// application's existing settings.
+func (c *Client) ExposeEndpoints(application string, exposed map[string]params.ExposedEndpoint) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("exposing endpoints")
+	}
+	args := params.ApplicationExpose{
+		ApplicationName:  application,
+		ExposedEndpoints: exposed,
+	}
+	return c.facade.FacadeCall("Expose", args, nil)
+}
+
+// UnexposeEndpoints removes the expose settings for the specified
+// endpoints of the application. If no expose settings remain, the
+// application is unexposed.
+func (c *Client) UnexposeEndpoints(application string, endpoints []string) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("unexposing endpoints")
+	}
+	args := params.ApplicationUnexpose{
+		ApplicationName:  application,
+		ExposedEndpoints: endpoints,
+	}
+	return c.facade.FacadeCall("Unexpose", args, nil)
+}
+
+// SetEgressRules replaces the egress policy of the application with
+// the specified rules. If no rules are specified, outgoing traffic
+// from the application's units is unrestricted.
+func (c *Client) SetEgressRules(application string, rules []network.EgressRule) error {
+	if c.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("egress rules")
+	}
+	args := params.ApplicationSetEgressRules{
+		ApplicationName: application,
+		Rules:           params.FromNetworkEgressRules(rules),
+	}
+	return c.facade.FacadeCall("SetEgressRules", args, nil)
+}
+
+// EgressRules returns the egress policy of the application. If the
+// application has no egress policy, nil is returned.
+func (c *Client) EgressRules(application string) ([]network.EgressRule, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("egress rules")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{names.NewApplicationTag(application).String()}},
+	}
+	var results params.EgressRulesResults
+	if err := c.facade.FacadeCall("GetEgressRules", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return params.NetworkEgressRules(results.Results[0].Rules), nil
+}
+
+// SetIngressAddresses merges the specified ingress address overrides,
+// keyed by endpoint name, into those of the application. An empty
+// address removes the override of the endpoint.
+func (c *Client) SetIngressAddresses(application string, addresses map[string]string) error {
+	if c.BestAPIVersion() < 7 {
+		return errors.NotSupportedf("ingress addresses")
+	}
+	args := params.ApplicationSetIngressAddresses{
+		ApplicationName:  application,
+		IngressAddresses: addresses,
+	}
+	return c.facade.FacadeCall("SetIngressAddresses", args, nil)
+}
+
+// IngressAddresses returns the ingress address overrides of the
+// application, keyed by endpoint name.
+func (c *Client) IngressAddresses(application string) (map[string]string, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("ingress addresses")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{names.NewApplicationTag(application).String()}},
+	}
+	var results params.IngressAddressesResults
+	if err := c.facade.FacadeCall("GetIngressAddresses", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results[0].IngressAddresses, nil
+}
+
+// SetActionConcurrency sets the maximum number of actions that may run
+// on the units of the application at once; zero removes the limit.
+func (c *Client) SetActionConcurrency(application string, limit int) error {
+	if c.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("action concurrency")
+	}
+	args := params.ApplicationSetActionConcurrency{
+		ApplicationName: application,
+		Limit:           limit,
+	}
+	return c.facade.FacadeCall("SetActionConcurrency", args, nil)
+}
+
+// ActionConcurrency returns the maximum number of actions that may run
+// on the units of the application at once, or zero if there is no
+// limit.
+func (c *Client) ActionConcurrency(application string) (int, error) {
+	if c.BestAPIVersion() < 8 {
+		return 0, errors.NotSupportedf("action concurrency")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{names.NewApplicationTag(application).String()}},
+	}
+	var results params.ActionConcurrencyResults
+	if err := c.facade.FacadeCall("GetActionConcurrency", args, &results); err != nil {
+		return 0, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return 0, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return 0, errors.Trace(err)
+	}
+	return results.Results[0].Limit, nil
+}
+
+// ConfigHistory returns the changes made to the charm config of the
+// application, oldest first.
+func (c *Client) ConfigHistory(application string) ([]params.ConfigRevision, error) {
+	if c.BestAPIVersion() < 9 {
+		return nil, errors.NotSupportedf("application config history")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{names.NewApplicationTag(application).String()}},
+	}
+	var results params.ApplicationConfigHistoryResults
+	if err := c.facade.FacadeCall("GetConfigHistory", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results[0].Revisions, nil
+}
+
+// RevertConfig returns the charm config of the application to the
+// values it had immediately after the given revision was made.
+func (c *Client) RevertConfig(application string, revision int) error {
+	if c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("reverting application config")
+	}
+	args := params.ApplicationConfigRevert{
+		ApplicationName: application,
+		Revision:        revision,
+	}
+	return c.facade.FacadeCall("RevertConfig", args, nil)
+}
+
 // Get returns the configuration for the named application.
 func (c *Client) Get(application string) (*params.ApplicationGetResults, error) {
 	var results params.ApplicationGetResults
@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"time"
+
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -16,6 +18,7 @@ import (
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
 )
 
@@ -332,3 +335,318 @@ func (s *applicationSuite) TestDestroyUnitsInvalidIds(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
+
+func (s *applicationSuite) TestExposeEndpoints(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "Expose")
+			c.Assert(a, jc.DeepEquals, params.ApplicationExpose{
+				ApplicationName: "foo",
+				ExposedEndpoints: map[string]params.ExposedEndpoint{
+					"admin": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+				},
+			})
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := application.NewClient(apiCaller)
+	err := client.ExposeEndpoints("foo", map[string]params.ExposedEndpoint{
+		"admin": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestExposeEndpointsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := application.NewClient(apiCaller)
+	err := client.ExposeEndpoints("foo", map[string]params.ExposedEndpoint{"admin": {}})
+	c.Assert(err, gc.ErrorMatches, "exposing endpoints not supported")
+	err = client.UnexposeEndpoints("foo", []string{"admin"})
+	c.Assert(err, gc.ErrorMatches, "unexposing endpoints not supported")
+}
+
+func (s *applicationSuite) TestSetEgressRules(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "SetEgressRules")
+			c.Assert(a, jc.DeepEquals, params.ApplicationSetEgressRules{
+				ApplicationName: "foo",
+				Rules: []params.EgressRule{{
+					PortRange:        params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+					DestinationCIDRs: []string{"10.0.0.0/8"},
+				}},
+			})
+			return nil
+		},
+		BestVersion: 6,
+	}
+	client := application.NewClient(apiCaller)
+	err := client.SetEgressRules("foo", []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestEgressRules(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "GetEgressRules")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{"application-foo"}},
+			})
+			*(response.(*params.EgressRulesResults)) = params.EgressRulesResults{
+				Results: []params.EgressRulesResult{{
+					Rules: []params.EgressRule{{
+						PortRange:        params.PortRange{FromPort: 53, ToPort: 53, Protocol: "udp"},
+						DestinationCIDRs: []string{"10.0.0.2/32"},
+					}},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 6,
+	}
+	client := application.NewClient(apiCaller)
+	rules, err := client.EgressRules("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+	})
+}
+
+func (s *applicationSuite) TestEgressRulesNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := application.NewClient(apiCaller)
+	err := client.SetEgressRules("foo", nil)
+	c.Assert(err, gc.ErrorMatches, "egress rules not supported")
+	_, err = client.EgressRules("foo")
+	c.Assert(err, gc.ErrorMatches, "egress rules not supported")
+}
+
+func (s *applicationSuite) TestSetIngressAddresses(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "SetIngressAddresses")
+			c.Assert(a, jc.DeepEquals, params.ApplicationSetIngressAddresses{
+				ApplicationName:  "foo",
+				IngressAddresses: map[string]string{"db": "10.0.0.100:3306", "": ""},
+			})
+			return nil
+		},
+		BestVersion: 7,
+	}
+	client := application.NewClient(apiCaller)
+	err := client.SetIngressAddresses("foo", map[string]string{"db": "10.0.0.100:3306", "": ""})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestIngressAddresses(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "GetIngressAddresses")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{"application-foo"}},
+			})
+			*(response.(*params.IngressAddressesResults)) = params.IngressAddressesResults{
+				Results: []params.IngressAddressesResult{{
+					IngressAddresses: map[string]string{"db": "10.0.0.100:3306"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 7,
+	}
+	client := application.NewClient(apiCaller)
+	addresses, err := client.IngressAddresses("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addresses, jc.DeepEquals, map[string]string{"db": "10.0.0.100:3306"})
+}
+
+func (s *applicationSuite) TestIngressAddressesNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 6,
+	}
+	client := application.NewClient(apiCaller)
+	err := client.SetIngressAddresses("foo", nil)
+	c.Assert(err, gc.ErrorMatches, "ingress addresses not supported")
+	_, err = client.IngressAddresses("foo")
+	c.Assert(err, gc.ErrorMatches, "ingress addresses not supported")
+	err = client.Deploy(application.DeployArgs{
+		ApplicationName:  "foo",
+		IngressAddresses: map[string]string{"db": "10.0.0.100"},
+	})
+	c.Assert(err, gc.ErrorMatches, "deploying with ingress addresses not supported")
+}
+
+func (s *applicationSuite) TestSetActionConcurrency(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "SetActionConcurrency")
+			c.Assert(a, jc.DeepEquals, params.ApplicationSetActionConcurrency{
+				ApplicationName: "foo",
+				Limit:           2,
+			})
+			return nil
+		},
+		BestVersion: 8,
+	}
+	client := application.NewClient(apiCaller)
+	err := client.SetActionConcurrency("foo", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestActionConcurrency(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "GetActionConcurrency")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{"application-foo"}},
+			})
+			*(response.(*params.ActionConcurrencyResults)) = params.ActionConcurrencyResults{
+				Results: []params.ActionConcurrencyResult{{Limit: 2}},
+			}
+			return nil
+		},
+		BestVersion: 8,
+	}
+	client := application.NewClient(apiCaller)
+	limit, err := client.ActionConcurrency("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(limit, gc.Equals, 2)
+}
+
+func (s *applicationSuite) TestActionConcurrencyNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 7,
+	}
+	client := application.NewClient(apiCaller)
+	err := client.SetActionConcurrency("foo", 2)
+	c.Assert(err, gc.ErrorMatches, "action concurrency not supported")
+	_, err = client.ActionConcurrency("foo")
+	c.Assert(err, gc.ErrorMatches, "action concurrency not supported")
+}
+
+func (s *applicationSuite) TestConfigHistory(c *gc.C) {
+	now := time.Now().UTC()
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "GetConfigHistory")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{"application-foo"}},
+			})
+			*(response.(*params.ApplicationConfigHistoryResults)) = params.ApplicationConfigHistoryResults{
+				Results: []params.ApplicationConfigHistoryResult{{
+					Revisions: []params.ConfigRevision{{
+						Revision:  1,
+						Author:    "user-bob",
+						Timestamp: now,
+						Changes: []params.ConfigChange{
+							{Type: "added", Key: "title", NewValue: "sir"},
+						},
+					}},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 9,
+	}
+	client := application.NewClient(apiCaller)
+	history, err := client.ConfigHistory("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []params.ConfigRevision{{
+		Revision:  1,
+		Author:    "user-bob",
+		Timestamp: now,
+		Changes: []params.ConfigChange{
+			{Type: "added", Key: "title", NewValue: "sir"},
+		},
+	}})
+}
+
+func (s *applicationSuite) TestRevertConfig(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "RevertConfig")
+			c.Assert(a, jc.DeepEquals, params.ApplicationConfigRevert{
+				ApplicationName: "foo",
+				Revision:        3,
+			})
+			return nil
+		},
+		BestVersion: 9,
+	}
+	client := application.NewClient(apiCaller)
+	err := client.RevertConfig("foo", 3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestConfigHistoryNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 8,
+	}
+	client := application.NewClient(apiCaller)
+	_, err := client.ConfigHistory("foo")
+	c.Assert(err, gc.ErrorMatches, "application config history not supported")
+	err = client.RevertConfig("foo", 3)
+	c.Assert(err, gc.ErrorMatches, "reverting application config not supported")
+}
+
+func (s *applicationSuite) TestUnexposeEndpoints(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "Unexpose")
+			c.Assert(a, jc.DeepEquals, params.ApplicationUnexpose{
+				ApplicationName:  "foo",
+				ExposedEndpoints: []string{"admin"},
+			})
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := application.NewClient(apiCaller)
+	err := client.UnexposeEndpoints("foo", []string{"admin"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
@@ -53,6 +53,18 @@ type DebugLogParams struct {
 	// StartTime should be a time in the past - only records with a
 	// log time on or after StartTime will be returned.
 	StartTime time.Time
+	// EndTime, if set, means that only records with a log time on or
+	// before EndTime will be returned.
+	EndTime time.Time
+	// Message, if set, is a regular expression that the message of each
+	// record must match for the record to be returned.
+	Message string
+	// IncludeLabel lists labels to include in the response. If none are
+	// set, records are included regardless of their labels.
+	IncludeLabel []string
+	// ExcludeLabel lists labels to exclude from the response. Records
+	// carrying any of these labels are excluded.
+	ExcludeLabel []string
 }
 
 func (args DebugLogParams) URLQuery() url.Values {
@@ -61,6 +73,8 @@ func (args DebugLogParams) URLQuery() url.Values {
 		"includeModule": args.IncludeModule,
 		"excludeEntity": args.ExcludeEntity,
 		"excludeModule": args.ExcludeModule,
+		"includeLabel":  args.IncludeLabel,
+		"excludeLabel":  args.ExcludeLabel,
 	}
 	if args.Replay {
 		attrs.Set("replay", fmt.Sprint(args.Replay))
@@ -80,6 +94,12 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if !args.StartTime.IsZero() {
 		attrs.Set("startTime", args.StartTime.Format(time.RFC3339Nano))
 	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.Format(time.RFC3339Nano))
+	}
+	if args.Message != "" {
+		attrs.Set("message", args.Message)
+	}
 	return attrs
 }
 
@@ -91,6 +111,7 @@ type LogMessage struct {
 	Module    string
 	Location  string
 	Message   string
+	Labels    []string
 }
 
 // StreamDebugLog requests the specified debug log records from the
@@ -128,6 +149,7 @@ func StreamDebugLog(source base.StreamConnector, args DebugLogParams) (<-chan Lo
 				Module:    msg.Module,
 				Location:  msg.Location,
 				Message:   msg.Message,
+				Labels:    msg.Labels,
 			}
 		}
 	}()
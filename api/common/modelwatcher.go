@@ -8,6 +8,7 @@ import (
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs/config"
+	logfwdhttp "github.com/juju/juju/logfwd/http"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/watcher"
 )
@@ -68,3 +69,13 @@ func (e *ModelWatcher) LogForwardConfig() (*syslog.RawConfig, bool, error) {
 	cfg, ok := modelConfig.LogFwdSyslog()
 	return cfg, ok, nil
 }
+
+// LogForwardHTTPConfig returns the current HTTP log forward configuration.
+func (e *ModelWatcher) LogForwardHTTPConfig() (*logfwdhttp.RawConfig, bool, error) {
+	modelConfig, err := e.ModelConfig()
+	if err != nil {
+		return nil, false, err
+	}
+	cfg, ok := modelConfig.LogFwdHTTP()
+	return cfg, ok, nil
+}
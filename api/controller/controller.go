@@ -158,6 +158,28 @@ func (c *Client) WatchAllModels() (*api.AllWatcher, error) {
 	return api.NewAllModelWatcher(c.facade.RawAPICaller(), &info.AllWatcherId), nil
 }
 
+// ConfigSet changes the value of the specified controller
+// configuration attributes. Only attributes that may be changed
+// after bootstrap are accepted by the controller.
+func (c *Client) ConfigSet(values map[string]interface{}) error {
+	if c.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("changing controller config")
+	}
+	args := params.ControllerConfigSet{Config: values}
+	return errors.Trace(c.facade.FacadeCall("ControllerConfigSet", args, nil))
+}
+
+// BackupStatus returns the outcome of the most recent scheduled
+// controller backups.
+func (c *Client) BackupStatus() (params.BackupStatus, error) {
+	var result params.BackupStatus
+	if c.BestAPIVersion() < 5 {
+		return result, errors.NotSupportedf("backup status")
+	}
+	err := c.facade.FacadeCall("BackupStatus", nil, &result)
+	return result, errors.Trace(err)
+}
+
 // GrantController grants a user access to the controller.
 func (c *Client) GrantController(user, access string) error {
 	return c.modifyControllerUser(params.GrantControllerAccess, user, access)
@@ -6,6 +6,7 @@ package controller_test
 import (
 	"encoding/json"
 	"errors"
+	"time"
 
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -186,6 +187,76 @@ func (s *Suite) TestHostedModelConfigs_FormatResults(c *gc.C) {
 	c.Assert(third.Error.Error(), gc.Equals, "validating CloudSpec: empty Type not valid")
 }
 
+func (s *Suite) TestConfigSet(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			return stub.NextErr()
+		},
+		BestVersion: 4,
+	}
+	client := controller.NewClient(apiCaller)
+	err := client.ConfigSet(map[string]interface{}{"max-logs-age": "12h"})
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{{
+		"Controller.ControllerConfigSet",
+		[]interface{}{params.ControllerConfigSet{
+			Config: map[string]interface{}{"max-logs-age": "12h"},
+		}},
+	}})
+}
+
+func (s *Suite) TestConfigSetNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 3,
+	}
+	client := controller.NewClient(apiCaller)
+	err := client.ConfigSet(map[string]interface{}{"max-logs-age": "12h"})
+	c.Assert(err, gc.ErrorMatches, "changing controller config not supported")
+}
+
+func (s *Suite) TestBackupStatus(c *gc.C) {
+	lastSuccess := time.Date(2017, 3, 15, 3, 0, 0, 0, time.UTC)
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.BackupStatus)) = params.BackupStatus{
+				LastSuccess:   &lastSuccess,
+				LastSuccessID: "backup-1",
+			}
+			return stub.NextErr()
+		},
+		BestVersion: 5,
+	}
+	client := controller.NewClient(apiCaller)
+	status, err := client.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, params.BackupStatus{
+		LastSuccess:   &lastSuccess,
+		LastSuccessID: "backup-1",
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{{"Controller.BackupStatus", []interface{}{nil}}})
+}
+
+func (s *Suite) TestBackupStatusNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.BackupStatus()
+	c.Assert(err, gc.ErrorMatches, "backup status not supported")
+}
+
 func makeClient(results params.InitiateMigrationResults) (
 	*controller.Client, *jujutesting.Stub,
 ) {
@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package egressfirewaller
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/watcher"
+)
+
+const egressFirewallerFacade = "EgressFirewaller"
+
+// API provides access to the EgressFirewaller API facade.
+type API struct {
+	tag    names.MachineTag
+	facade base.FacadeCaller
+}
+
+// NewAPI returns a new api client facade instance.
+func NewAPI(caller base.APICaller, tag names.MachineTag) *API {
+	return &API{
+		facade: base.NewFacadeCaller(caller, egressFirewallerFacade),
+		tag:    tag,
+	}
+}
+
+// WatchEgressRules returns a NotifyWatcher that notifies of changes
+// to the egress rules to be enforced on the machine.
+func (api *API) WatchEgressRules() (watcher.NotifyWatcher, error) {
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: api.tag.String()}},
+	}
+	err := api.facade.FacadeCall("WatchEgressRules", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return newNotifyWatcher(api.facade.RawAPICaller(), result), nil
+}
+
+var newNotifyWatcher = apiwatcher.NewNotifyWatcher
+
+// EgressRules returns the egress rules to be enforced on the machine,
+// and whether they are enforced by the provider instead. If outgoing
+// traffic from the machine is unrestricted, no rules are returned.
+func (api *API) EgressRules() (rules []network.EgressRule, providerManaged bool, err error) {
+	var results params.MachineEgressRulesResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: api.tag.String()}},
+	}
+	err = api.facade.FacadeCall("EgressRules", args, &results)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(results.Results) != 1 {
+		return nil, false, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, false, result.Error
+	}
+	return params.NetworkEgressRules(result.Rules), result.ProviderManaged, nil
+}
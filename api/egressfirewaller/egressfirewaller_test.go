@@ -0,0 +1,114 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package egressfirewaller_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/egressfirewaller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+)
+
+type EgressFirewallerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&EgressFirewallerSuite{})
+
+func newAPI(c *gc.C, args ...apitesting.APICall) (*int, *egressfirewaller.API) {
+	apiCaller := apitesting.APICallChecker(c, args...)
+	api := egressfirewaller.NewAPI(apiCaller, names.NewMachineTag("1"))
+	c.Assert(apiCaller.CallCount, gc.Equals, 0)
+	return &apiCaller.CallCount, api
+}
+
+func (s *EgressFirewallerSuite) TestWatchEgressRules(c *gc.C) {
+	res := params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{{
+			NotifyWatcherId: "4242",
+		}},
+	}
+	fake := &struct {
+		watcher.NotifyWatcher
+	}{}
+	s.PatchValue(egressfirewaller.NewNotifyWatcher, func(caller base.APICaller, result params.NotifyWatchResult) watcher.NotifyWatcher {
+		c.Assert(result, gc.DeepEquals, res.Results[0])
+		return fake
+	})
+
+	called, api := newAPI(c, apitesting.APICall{
+		Facade: "EgressFirewaller",
+		Method: "WatchEgressRules",
+		Args: params.Entities{
+			Entities: []params.Entity{{Tag: "machine-1"}},
+		},
+		Results: res,
+	})
+
+	w, err := api.WatchEgressRules()
+	c.Check(*called, gc.Equals, 1)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(w, gc.Equals, fake)
+}
+
+func (s *EgressFirewallerSuite) TestWatchEgressRulesError(c *gc.C) {
+	_, api := newAPI(c, apitesting.APICall{
+		Facade: "EgressFirewaller",
+		Method: "WatchEgressRules",
+		Results: params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{
+				Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+			}},
+		},
+	})
+	_, err := api.WatchEgressRules()
+	c.Check(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *EgressFirewallerSuite) TestEgressRules(c *gc.C) {
+	rules := []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53),
+	}
+	called, api := newAPI(c, apitesting.APICall{
+		Facade: "EgressFirewaller",
+		Method: "EgressRules",
+		Args: params.Entities{
+			Entities: []params.Entity{{Tag: "machine-1"}},
+		},
+		Results: params.MachineEgressRulesResults{
+			Results: []params.MachineEgressRulesResult{{
+				Rules:           params.FromNetworkEgressRules(rules),
+				ProviderManaged: true,
+			}},
+		},
+	})
+
+	result, providerManaged, err := api.EgressRules()
+	c.Check(*called, gc.Equals, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, rules)
+	c.Check(providerManaged, jc.IsTrue)
+}
+
+func (s *EgressFirewallerSuite) TestEgressRulesError(c *gc.C) {
+	_, api := newAPI(c, apitesting.APICall{
+		Facade: "EgressFirewaller",
+		Method: "EgressRules",
+		Results: params.MachineEgressRulesResults{
+			Results: []params.MachineEgressRulesResult{{
+				Error: &params.Error{Message: "machine 1 not found", Code: params.CodeNotFound},
+			}},
+		},
+	})
+	_, _, err := api.EgressRules()
+	c.Check(err, gc.ErrorMatches, "machine 1 not found")
+}
@@ -6,6 +6,7 @@ package firewaller
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	apiwatcher "github.com/juju/juju/api/watcher"
@@ -49,6 +50,56 @@ func (m *Machine) WatchUnits() (watcher.StringsWatcher, error) {
 	return w, nil
 }
 
+// WatchEgressRules starts a NotifyWatcher to watch the egress
+// rules to be enforced on the machine.
+func (m *Machine) WatchEgressRules() (watcher.NotifyWatcher, error) {
+	if m.st.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("egress rules")
+	}
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: m.tag.String()}},
+	}
+	err := m.st.facade.FacadeCall("WatchEgressRules", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(m.st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// EgressRules returns the egress rules to be enforced on the
+// machine. If outgoing traffic from the machine is unrestricted,
+// nil is returned.
+func (m *Machine) EgressRules() ([]network.EgressRule, error) {
+	if m.st.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("egress rules")
+	}
+	var results params.EgressRulesResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: m.tag.String()}},
+	}
+	err := m.st.facade.FacadeCall("GetMachineEgressRules", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return params.NetworkEgressRules(result.Rules), nil
+}
+
 // InstanceId returns the provider specific instance id for this
 // machine, or a CodeNotProvisioned error, if not set.
 func (m *Machine) InstanceId() (instance.Id, error) {
@@ -111,6 +162,28 @@ func (m *Machine) ActiveSubnets() ([]names.SubnetTag, error) {
 // OpenedPorts returns a map of network.PortRange to unit tag for all opened
 // port ranges on the machine for the subnet matching given subnetTag.
 func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]names.UnitTag, error) {
+	openedPorts, err := m.OpenedPortRanges(subnetTag)
+	if err != nil {
+		return nil, err
+	}
+	endResult := make(map[network.PortRange]names.UnitTag)
+	for portRange, opened := range openedPorts {
+		endResult[portRange] = opened.Unit
+	}
+	return endResult, nil
+}
+
+// OpenedPortRange holds the unit that opened a port range, and the
+// endpoint, if any, the port range is opened for.
+type OpenedPortRange struct {
+	Unit     names.UnitTag
+	Endpoint string
+}
+
+// OpenedPortRanges returns a map of network.PortRange to OpenedPortRange
+// for all opened port ranges on the machine for the subnet matching given
+// subnetTag.
+func (m *Machine) OpenedPortRanges(subnetTag names.SubnetTag) (map[network.PortRange]OpenedPortRange, error) {
 	var results params.MachinePortsResults
 	var subnetTagAsString string
 	if subnetTag.Id() != "" {
@@ -133,13 +206,16 @@ func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]
 		return nil, result.Error
 	}
 	// Convert string tags to names.UnitTag before returning.
-	endResult := make(map[network.PortRange]names.UnitTag)
+	endResult := make(map[network.PortRange]OpenedPortRange)
 	for _, ports := range result.Ports {
 		unitTag, err := names.ParseUnitTag(ports.UnitTag)
 		if err != nil {
 			return nil, err
 		}
-		endResult[ports.PortRange.NetworkPortRange()] = unitTag
+		endResult[ports.PortRange.NetworkPortRange()] = OpenedPortRange{
+			Unit:     unitTag,
+			Endpoint: ports.Endpoint,
+		}
 	}
 	return endResult, nil
 }
@@ -94,6 +94,43 @@ func (s *machineSuite) TestWatchUnits(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *machineSuite) TestEgressRules(c *gc.C) {
+	rules, err := s.apiMachine.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.IsNil)
+
+	err = s.State.SetAPIHostPorts([][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.1"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "192.168.1.0/24"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = s.apiMachine.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "192.168.1.0/24"),
+		network.MustNewEgressRule("tcp", 17070, 17070, "10.0.0.1/32"),
+	})
+}
+
+func (s *machineSuite) TestWatchEgressRules(c *gc.C) {
+	w, err := s.apiMachine.WatchEgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	err = s.application.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *machineSuite) TestActiveSubnets(c *gc.C) {
 	// No ports opened at first, no active subnets.
 	subnets, err := s.apiMachine.ActiveSubnets()
@@ -134,3 +171,18 @@ func (s *machineSuite) TestOpenedPorts(c *gc.C) {
 		network.PortRange{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}: unitTag,
 	})
 }
+
+func (s *machineSuite) TestOpenedPortRanges(c *gc.C) {
+	unitTag := s.units[0].Tag().(names.UnitTag)
+
+	err := s.units[0].OpenEndpointPorts("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[0].OpenPort("tcp", 1234)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err := s.apiMachine.OpenedPortRanges(names.SubnetTag{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, map[network.PortRange]firewaller.OpenedPortRange{
+		network.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"}:     {Unit: unitTag, Endpoint: "url"},
+		network.PortRange{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}: {Unit: unitTag},
+	})
+}
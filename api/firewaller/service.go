@@ -75,3 +75,32 @@ func (s *Application) IsExposed() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// ExposeInfo returns whether this application is exposed, and its
+// expose settings keyed by endpoint name. If the application is
+// exposed without any expose settings, its opened ports may be
+// accessed from anywhere.
+func (s *Application) ExposeInfo() (bool, map[string]params.ExposedEndpoint, error) {
+	if s.st.BestAPIVersion() < 4 {
+		// Expose settings are not supported, so the application
+		// is exposed to all sources if it is exposed at all.
+		exposed, err := s.IsExposed()
+		return exposed, nil, err
+	}
+	var results params.ExposeInfoResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetExposeInfo", args, &results)
+	if err != nil {
+		return false, nil, err
+	}
+	if len(results.Results) != 1 {
+		return false, nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return false, nil, result.Error
+	}
+	return result.Exposed, result.ExposedEndpoints, nil
+}
@@ -541,6 +541,8 @@ func (s *ClientSuite) TestStreamModelLogs(c *gc.C) {
 		"includeModule": nil,
 		"excludeEntity": nil,
 		"excludeModule": nil,
+		"includeLabel":  nil,
+		"excludeLabel":  nil,
 	})
 }
 
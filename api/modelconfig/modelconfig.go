@@ -69,6 +69,29 @@ func (c *Client) ModelUnset(keys ...string) error {
 	return c.facade.FacadeCall("ModelUnset", args, nil)
 }
 
+// ModelGetHistory returns the changes made to the model config, oldest
+// first.
+func (c *Client) ModelGetHistory() ([]params.ConfigRevision, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("model config history")
+	}
+	var result params.ModelConfigHistoryResult
+	if err := c.facade.FacadeCall("ModelGetHistory", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Revisions, nil
+}
+
+// ModelRevert returns the model config to the values it had immediately
+// after the given revision was made.
+func (c *Client) ModelRevert(revision int) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("reverting model config")
+	}
+	args := params.ModelConfigRevert{Revision: revision}
+	return errors.Trace(c.facade.FacadeCall("ModelRevert", args, nil))
+}
+
 // SetSLALevel sets the support level for the given model.
 func (c *Client) SetSLALevel(level, owner string, creds []byte) error {
 	args := params.ModelSLA{
@@ -180,3 +180,64 @@ func (s *modelconfigSuite) TestGetSupport(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 	c.Assert(level, gc.Equals, "level")
 }
+
+func (s *modelconfigSuite) TestModelGetHistory(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelConfig")
+			c.Check(request, gc.Equals, "ModelGetHistory")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ModelConfigHistoryResult{})
+			*(result.(*params.ModelConfigHistoryResult)) = params.ModelConfigHistoryResult{
+				Revisions: []params.ConfigRevision{{
+					Revision: 1,
+					Author:   "user-fred",
+					Changes:  []params.ConfigChange{{Type: "added", Key: "foo", NewValue: "bar"}},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := modelconfig.NewClient(apiCaller)
+	revisions, err := client.ModelGetHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revisions, jc.DeepEquals, []params.ConfigRevision{{
+		Revision: 1,
+		Author:   "user-fred",
+		Changes:  []params.ConfigChange{{Type: "added", Key: "foo", NewValue: "bar"}},
+	}})
+}
+
+func (s *modelconfigSuite) TestModelRevert(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelConfig")
+			c.Check(request, gc.Equals, "ModelRevert")
+			c.Check(a, jc.DeepEquals, params.ModelConfigRevert{Revision: 3})
+			called = true
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := modelconfig.NewClient(apiCaller)
+	err := client.ModelRevert(3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelconfigSuite) TestHistoryNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 1,
+	}
+	client := modelconfig.NewClient(apiCaller)
+	_, err := client.ModelGetHistory()
+	c.Assert(err, gc.ErrorMatches, "model config history not supported")
+	err = client.ModelRevert(1)
+	c.Assert(err, gc.ErrorMatches, "reverting model config not supported")
+}
@@ -139,6 +139,13 @@ func (c *Client) ListFilesystems(machines []string) ([]params.FilesystemDetailsL
 
 // AddToUnit adds specified storage to desired units.
 func (c *Client) AddToUnit(storages []params.StorageAddParams) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 4 {
+		for _, one := range storages {
+			if one.FromSnapshot != "" {
+				return nil, errors.NotSupportedf("adding storage from a snapshot")
+			}
+		}
+	}
 	out := params.ErrorResults{}
 	in := params.StoragesAddParams{Storages: storages}
 	err := c.facade.FacadeCall("AddToUnit", in, &out)
@@ -231,3 +238,119 @@ func (c *Client) Detach(storageIds []string) ([]params.ErrorResult, error) {
 	}
 	return results.Results, nil
 }
+
+// CreateSnapshots creates snapshots of the volumes backing the
+// storage instances with the specified IDs.
+func (c *Client) CreateSnapshots(storageIds []string) ([]params.VolumeSnapshotResult, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("volume snapshots")
+	}
+	entities := make([]params.Entity, len(storageIds))
+	for i, id := range storageIds {
+		if !names.IsValidStorage(id) {
+			return nil, errors.NotValidf("storage ID %q", id)
+		}
+		entities[i] = params.Entity{Tag: names.NewStorageTag(id).String()}
+	}
+	var results params.VolumeSnapshotResults
+	if err := c.facade.FacadeCall(
+		"CreateSnapshots",
+		params.Entities{Entities: entities},
+		&results,
+	); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(storageIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(storageIds), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// ListSnapshots returns the details of all volume snapshots in the model.
+func (c *Client) ListSnapshots() ([]params.VolumeSnapshotDetails, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("volume snapshots")
+	}
+	var result params.VolumeSnapshotListResult
+	if err := c.facade.FacadeCall("ListSnapshots", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Snapshots, nil
+}
+
+// DestroySnapshots deletes the volume snapshots with the specified IDs.
+func (c *Client) DestroySnapshots(ids []string) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("volume snapshots")
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(
+		"DestroySnapshots",
+		params.VolumeSnapshotIds{Ids: ids},
+		&results,
+	); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(ids), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// ResizeStorage requests that the storage instance with the specified
+// ID be grown to the given size in MiB.
+func (c *Client) ResizeStorage(storageId string, size uint64) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("resizing storage")
+	}
+	if !names.IsValidStorage(storageId) {
+		return errors.NotValidf("storage ID %q", storageId)
+	}
+	args := params.StoragesResizeParams{
+		Storages: []params.StorageResizeParams{{
+			StorageTag: names.NewStorageTag(storageId).String(),
+			Size:       size,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ResizeStorage", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// Import imports existing storage into the model, returning the tag of the
+// new storage instance. The storage can then be attached to a unit with
+// Attach. If force is true, storage tagged as belonging to another model
+// is imported anyway.
+func (c *Client) Import(kind params.StorageKind, pool, providerId, storageName string, force bool) (names.StorageTag, error) {
+	if c.BestAPIVersion() < 6 {
+		return names.StorageTag{}, errors.NotSupportedf("importing storage")
+	}
+	args := params.BulkImportStorageParams{
+		Storage: []params.ImportStorageParams{{
+			Kind:        kind,
+			Pool:        pool,
+			ProviderId:  providerId,
+			StorageName: storageName,
+			Force:       force,
+		}},
+	}
+	var results params.ImportStorageResults
+	if err := c.facade.FacadeCall("Import", args, &results); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return names.StorageTag{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return names.StorageTag{}, err
+	}
+	return names.ParseStorageTag(results.Results[0].Result.StorageTag)
+}
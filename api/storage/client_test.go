@@ -718,3 +718,212 @@ func (s *storageMockSuite) TestAttachArityMismatch(c *gc.C) {
 	_, err := client.Attach("foo/0", []string{"bar/1", "baz/2"})
 	c.Check(err, gc.ErrorMatches, `expected 2 result\(s\), got 3`)
 }
+
+func (s *storageMockSuite) TestAddToUnitFromSnapshotNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 3,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.AddToUnit([]params.StorageAddParams{{
+		UnitTag:      "unit-foo-0",
+		StorageName:  "data",
+		FromSnapshot: "0",
+	}})
+	c.Assert(err, gc.ErrorMatches, "adding storage from a snapshot not supported")
+}
+
+func (s *storageMockSuite) TestCreateSnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "CreateSnapshots")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "storage-data-0"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotResults{})
+			results := result.(*params.VolumeSnapshotResults)
+			results.Results = []params.VolumeSnapshotResult{{
+				Result: &params.VolumeSnapshotDetails{Id: "0", SnapshotId: "snap-0"},
+			}}
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.CreateSnapshots([]string{"data/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.VolumeSnapshotResult{{
+		Result: &params.VolumeSnapshotDetails{Id: "0", SnapshotId: "snap-0"},
+	}})
+}
+
+func (s *storageMockSuite) TestCreateSnapshotsInvalidStorageId(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.CreateSnapshots([]string{"data"})
+	c.Assert(err, gc.ErrorMatches, `storage ID "data" not valid`)
+}
+
+func (s *storageMockSuite) TestCreateSnapshotsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 3,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.CreateSnapshots([]string{"data/0"})
+	c.Assert(err, gc.ErrorMatches, "volume snapshots not supported")
+}
+
+func (s *storageMockSuite) TestListSnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(request, gc.Equals, "ListSnapshots")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotListResult{})
+			result.(*params.VolumeSnapshotListResult).Snapshots = []params.VolumeSnapshotDetails{{
+				Id:         "0",
+				VolumeTag:  "volume-0",
+				SnapshotId: "snap-0",
+			}}
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := storage.NewClient(apiCaller)
+	snapshots, err := client.ListSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, jc.DeepEquals, []params.VolumeSnapshotDetails{{
+		Id:         "0",
+		VolumeTag:  "volume-0",
+		SnapshotId: "snap-0",
+	}})
+}
+
+func (s *storageMockSuite) TestDestroySnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(request, gc.Equals, "DestroySnapshots")
+			c.Check(a, jc.DeepEquals, params.VolumeSnapshotIds{Ids: []string{"0", "1"}})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			result.(*params.ErrorResults).Results = []params.ErrorResult{
+				{},
+				{Error: &params.Error{Message: "qux"}},
+			}
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.DestroySnapshots([]string{"0", "1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "qux"}},
+	})
+}
+
+func (s *storageMockSuite) TestResizeStorage(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(request, gc.Equals, "ResizeStorage")
+			c.Check(a, jc.DeepEquals, params.StoragesResizeParams{
+				Storages: []params.StorageResizeParams{{StorageTag: "storage-data-0", Size: 2048}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			result.(*params.ErrorResults).Results = []params.ErrorResult{
+				{Error: &params.Error{Message: "qux"}},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.ResizeStorage("data/0", 2048)
+	c.Assert(err, gc.ErrorMatches, "qux")
+}
+
+func (s *storageMockSuite) TestResizeStorageNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.ResizeStorage("data/0", 2048)
+	c.Assert(err, gc.ErrorMatches, "resizing storage not supported")
+}
+
+func (s *storageMockSuite) TestImport(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(request, gc.Equals, "Import")
+			c.Check(a, jc.DeepEquals, params.BulkImportStorageParams{
+				Storage: []params.ImportStorageParams{{
+					Kind:        params.StorageKindBlock,
+					Pool:        "ebs",
+					ProviderId:  "vol-1234",
+					StorageName: "pgdata",
+					Force:       true,
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ImportStorageResults{})
+			result.(*params.ImportStorageResults).Results = []params.ImportStorageResult{{
+				Result: &params.ImportStorageDetails{StorageTag: "storage-pgdata-0"},
+			}}
+			return nil
+		},
+		BestVersion: 6,
+	}
+	client := storage.NewClient(apiCaller)
+	tag, err := client.Import(params.StorageKindBlock, "ebs", "vol-1234", "pgdata", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tag, gc.Equals, names.NewStorageTag("pgdata/0"))
+}
+
+func (s *storageMockSuite) TestImportError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			result.(*params.ImportStorageResults).Results = []params.ImportStorageResult{{
+				Error: &params.Error{Message: "qux"},
+			}}
+			return nil
+		},
+		BestVersion: 6,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.Import(params.StorageKindBlock, "ebs", "vol-1234", "pgdata", false)
+	c.Assert(err, gc.ErrorMatches, "qux")
+}
+
+func (s *storageMockSuite) TestImportNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.Import(params.StorageKindBlock, "ebs", "vol-1234", "pgdata", false)
+	c.Assert(err, gc.ErrorMatches, "importing storage not supported")
+}
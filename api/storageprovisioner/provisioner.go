@@ -107,6 +107,18 @@ func (st *State) watchStorageEntities(method string) (watcher.StringsWatcher, er
 	return w, nil
 }
 
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entity with the tag passed to NewState, so that pending resizes may
+// be identified with VolumeResizeParams. If the controller does not
+// support resizing volumes, an error satisfying errors.IsNotSupported
+// is returned.
+func (st *State) WatchVolumeResizes() (watcher.StringsWatcher, error) {
+	if st.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("resizing volumes")
+	}
+	return st.watchStorageEntities("WatchVolumeResizes")
+}
+
 // WatchVolumeAttachments watches for changes to volume attachments
 // scoped to the entity with the tag passed to NewState.
 func (st *State) WatchVolumeAttachments() (watcher.MachineStorageIdsWatcher, error) {
@@ -243,6 +255,29 @@ func (st *State) VolumeParams(tags []names.VolumeTag) ([]params.VolumeParamsResu
 	return results.Results, nil
 }
 
+// VolumeResizeParams returns the parameters for growing the volumes
+// with the specified tags.
+func (st *State) VolumeResizeParams(tags []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	if st.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("resizing volumes")
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.VolumeResizeParamsResults
+	err := st.facade.FacadeCall("VolumeResizeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (st *State) FilesystemParams(tags []names.FilesystemTag) ([]params.FilesystemParamsResult, error) {
@@ -398,6 +398,60 @@ func (s *provisionerSuite) TestVolumeParams(c *gc.C) {
 	}})
 }
 
+func (s *provisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	var callCount int
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "StorageProvisioner")
+			c.Check(version, gc.Equals, 4)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "VolumeResizeParams")
+			c.Check(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{"volume-100"}}})
+			c.Assert(result, gc.FitsTypeOf, &params.VolumeResizeParamsResults{})
+			*(result.(*params.VolumeResizeParamsResults)) = params.VolumeResizeParamsResults{
+				Results: []params.VolumeResizeParamsResult{{
+					Result: params.VolumeResizeParams{
+						VolumeTag: "volume-100",
+						VolumeId:  "vol-100",
+						Size:      2048,
+						Provider:  "loop",
+					},
+				}},
+			}
+			callCount++
+			return nil
+		},
+		BestVersion: 4,
+	}
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	resizeParams, err := st.VolumeResizeParams([]names.VolumeTag{names.NewVolumeTag("100")})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(resizeParams, jc.DeepEquals, []params.VolumeResizeParamsResult{{
+		Result: params.VolumeResizeParams{
+			VolumeTag: "volume-100", VolumeId: "vol-100", Size: 2048, Provider: "loop",
+		},
+	}})
+}
+
+func (s *provisionerSuite) TestVolumeResizesNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 3,
+	}
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.WatchVolumeResizes()
+	c.Assert(err, gc.ErrorMatches, "resizing volumes not supported")
+	_, err = st.VolumeResizeParams([]names.VolumeTag{names.NewVolumeTag("100")})
+	c.Assert(err, gc.ErrorMatches, "resizing volumes not supported")
+}
+
 func (s *provisionerSuite) TestFilesystemParams(c *gc.C) {
 	var callCount int
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
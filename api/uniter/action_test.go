@@ -4,10 +4,13 @@
 package uniter_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
@@ -64,6 +67,17 @@ func (s *actionSuite) TestAction(c *gc.C) {
 	}
 }
 
+func (s *actionSuite) TestActionTimeout(c *gc.C) {
+	a, err := s.uniterSuite.wordpressUnit.AddActionWithOptions("fakeaction", basicParams, state.ActionOptions{
+		Timeout: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	retrievedAction, err := s.uniter.Action(names.NewActionTag(a.Id()))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(retrievedAction.Timeout(), gc.Equals, time.Minute)
+}
+
 func (s *actionSuite) TestActionNotFound(c *gc.C) {
 	_, err := s.uniter.Action(names.NewActionTag("feedface-0123-4567-8901-2345deadbeef"))
 	c.Assert(err, gc.NotNil)
@@ -122,3 +136,37 @@ func (s *actionSuite) TestActionFail(c *gc.C) {
 	c.Assert(res, gc.DeepEquals, map[string]interface{}{})
 	c.Assert(completed[0].Name(), gc.Equals, "fakeaction")
 }
+
+func (s *actionSuite) TestLogActionMessage(c *gc.C) {
+	action, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.uniter.ActionBegin(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.uniter.LogActionMessage(action.ActionTag(), "halfway there")
+	c.Assert(err, jc.ErrorIsNil)
+
+	action, err = s.State.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message(), gc.Equals, "halfway there")
+}
+
+func (s *actionSuite) TestLogActionMessageNotRunning(c *gc.C) {
+	action, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.uniter.LogActionMessage(action.ActionTag(), "halfway there")
+	c.Assert(err, gc.ErrorMatches, `cannot log message to action ".*": action is pending`)
+}
+
+func (s *actionSuite) TestLogActionMessageOldFacadeVersion(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	st := uniter.NewState(apiCaller, names.NewUnitTag("wordpress/0"))
+	err := st.LogActionMessage(names.NewActionTag("feedface-0123-4567-8901-2345deadbeef"), "halfway there")
+	c.Assert(err, gc.ErrorMatches, "logging action messages not supported")
+}
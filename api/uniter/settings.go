@@ -16,17 +16,26 @@ type Settings struct {
 	relationTag string
 	unitTag     string
 	settings    params.Settings
+
+	// original holds the settings as they were read, so that only
+	// changes made to them are written back.
+	original params.Settings
 }
 
 func newSettings(st *State, relationTag, unitTag string, settings params.Settings) *Settings {
 	if settings == nil {
 		settings = make(params.Settings)
 	}
+	original := make(params.Settings)
+	for k, v := range settings {
+		original[k] = v
+	}
 	return &Settings{
 		st:          st,
 		relationTag: relationTag,
 		unitTag:     unitTag,
 		settings:    settings,
+		original:    original,
 	}
 }
 
@@ -66,17 +75,17 @@ func (s *Settings) Delete(key string) {
 // empty values will be deleted, others will be updated to the new
 // value.
 //
-// TODO(dimitern): 2013-09-06 bug 1221798
-// Once the machine addressability changes lands, we may need to
-// revise the logic here to take into account that the
-// "private-address" setting for a unit can be changed outside of the
-// uniter's control. So we may need to send diffs of what has changed
-// to make sure we update the address (and other settings) correctly,
-// without overwritting.
+// Only the keys changed since the settings were read are written, as
+// the address settings of a unit may be changed by the controller
+// outside of the uniter's control; e.g. when the application's
+// ingress addresses change.
 func (s *Settings) Write() error {
-	// First make a copy of the map, including deleted keys.
+	// First make a copy of the changes, including deleted keys.
 	settingsCopy := make(params.Settings)
 	for k, v := range s.settings {
+		if original, ok := s.original[k]; ok && original == v {
+			continue
+		}
 		settingsCopy[k] = v
 	}
 
@@ -92,5 +101,11 @@ func (s *Settings) Write() error {
 	if err != nil {
 		return err
 	}
-	return result.OneError()
+	if err := result.OneError(); err != nil {
+		return err
+	}
+	for k, v := range settingsCopy {
+		s.original[k] = v
+	}
+	return nil
 }
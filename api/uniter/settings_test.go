@@ -133,3 +133,40 @@ func (s *settingsSuite) TestWrite(c *gc.C) {
 		"other": "days",
 	})
 }
+
+func (s *settingsSuite) TestWriteOnlyChanges(c *gc.C) {
+	wpRelUnit, err := s.stateRelation.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = wpRelUnit.EnterScope(map[string]interface{}{
+		"private-address": "1.2.3.4",
+		"some":            "stuff",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	apiUnit, err := s.uniter.Unit(s.wordpressUnit.Tag().(names.UnitTag))
+	c.Assert(err, jc.ErrorIsNil)
+	apiRelation, err := s.uniter.Relation(s.stateRelation.Tag().(names.RelationTag))
+	c.Assert(err, jc.ErrorIsNil)
+	apiRelUnit, err := apiRelation.Unit(apiUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := apiRelUnit.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Change the address behind the uniter's back, as the
+	// controller does when the ingress address changes.
+	stateSettings, err := wpRelUnit.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	stateSettings.Set("private-address", "10.0.0.100")
+	_, err = stateSettings.Write()
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings.Set("some", "things")
+	err = settings.Write()
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err = apiRelUnit.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings.Map(), gc.DeepEquals, params.Settings{
+		"private-address": "10.0.0.100",
+		"some":            "things",
+	})
+}
@@ -448,6 +448,29 @@ func (u *Unit) OpenPorts(protocol string, fromPort, toPort int) error {
 	return result.OneError()
 }
 
+// OpenEndpointPorts sets the policy of the port range with protocol to
+// be opened for the named endpoint.
+func (u *Unit) OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	if u.st.facade.BestAPIVersion() < 7 {
+		return errors.NotSupportedf("opening ports for an endpoint")
+	}
+	var result params.ErrorResults
+	args := params.EntitiesPortRanges{
+		Entities: []params.EntityPortRange{{
+			Tag:      u.tag.String(),
+			Protocol: protocol,
+			FromPort: fromPort,
+			ToPort:   toPort,
+			Endpoint: endpoint,
+		}},
+	}
+	err := u.st.facade.FacadeCall("OpenPorts", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // ClosePorts sets the policy of the port range with protocol to be
 // closed.
 func (u *Unit) ClosePorts(protocol string, fromPort, toPort int) error {
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
 )
 
 // ActionAPI implements the client API for interacting with Actions
@@ -173,7 +174,7 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		enqueued, err := receiver.AddAction(action.Name, action.Parameters)
+		enqueued, err := addAction(receiver, action)
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
@@ -184,6 +185,22 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 	return response, nil
 }
 
+// addAction queues the action on the receiver. Only units enforce
+// action timeouts, so an action with a timeout may only be queued on a
+// unit.
+func addAction(receiver state.ActionReceiver, action params.Action) (state.Action, error) {
+	if action.Timeout == 0 {
+		return receiver.AddAction(action.Name, action.Parameters)
+	}
+	unit, ok := receiver.(*state.Unit)
+	if !ok {
+		return nil, errors.NotSupportedf("action timeout for %s", names.ReadableString(receiver.Tag()))
+	}
+	return unit.AddActionWithOptions(action.Name, action.Parameters, state.ActionOptions{
+		Timeout: action.Timeout,
+	})
+}
+
 // ListAll takes a list of Entities representing ActionReceivers and
 // returns all of the Actions that have been enqueued or run by each of
 // those Entities.
@@ -274,6 +291,39 @@ func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 	return response, nil
 }
 
+// WatchActionsProgress returns a StringsWatcher for observing the
+// progress messages logged by each of the given Actions. Each change
+// holds JSON-encoded params.ActionMessages; the initial event holds the
+// messages logged so far.
+func (a *ActionAPI) WatchActionsProgress(arg params.Entities) (params.StringsWatchResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.StringsWatchResults{}, errors.Trace(err)
+	}
+
+	results := params.StringsWatchResults{Results: make([]params.StringsWatchResult, len(arg.Entities))}
+	for i, entity := range arg.Entities {
+		result := &results.Results[i]
+		actionTag, err := names.ParseActionTag(entity.Tag)
+		if err != nil {
+			result.Error = common.ServerError(common.ErrBadId)
+			continue
+		}
+		if _, err := a.state.ActionByTag(actionTag); err != nil {
+			result.Error = common.ServerError(err)
+			continue
+		}
+		w := a.state.WatchActionMessages(actionTag.Id())
+		// Consume the initial event.
+		if changes, ok := <-w.Changes(); ok {
+			result.StringsWatcherId = a.resources.Register(w)
+			result.Changes = changes
+		} else {
+			result.Error = common.ServerError(watcher.EnsureErr(w))
+		}
+	}
+	return results, nil
+}
+
 // ApplicationsCharmsActions returns a slice of charm Actions for a slice of
 // services.
 func (a *ActionAPI) ApplicationsCharmsActions(args params.Entities) (params.ApplicationsCharmActionsResults, error) {
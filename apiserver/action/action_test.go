@@ -4,8 +4,10 @@
 package action_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -630,6 +632,74 @@ func (s *actionSuite) TestCancel(c *gc.C) {
 	c.Assert(myActions[1].Status, gc.Equals, params.ActionCancelled)
 }
 
+func (s *actionSuite) TestActionsLog(c *gc.C) {
+	a, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("one")
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.action.Actions(params.Entities{
+		Entities: []params.Entity{{Tag: a.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	log := results.Results[0].Log
+	c.Assert(log, gc.HasLen, 1)
+	c.Assert(log[0].Message, gc.Equals, "one")
+	c.Assert(log[0].Timestamp.IsZero(), jc.IsFalse)
+}
+
+func (s *actionSuite) TestWatchActionsProgress(c *gc.C) {
+	api, err := action.NewActionAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("one")
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := api.WatchActionsProgress(params.Entities{
+		Entities: []params.Entity{
+			{Tag: a.Tag().String()},
+			{Tag: "unit-wordpress-0"},
+			{Tag: names.NewActionTag("feedface-0123-4567-8901-2345deadbeef").String()},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, common.ErrBadId.Error())
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `action "feedface-0123-4567-8901-2345deadbeef" not found`)
+
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.StringsWatcherId, gc.Equals, "1")
+	c.Assert(result.Changes, gc.HasLen, 1)
+	var message params.ActionMessage
+	err = json.Unmarshal([]byte(result.Changes[0]), &message)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(message.Message, gc.Equals, "one")
+
+	// The watcher reports the messages logged from now on.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	w := s.resources.Get("1").(state.StringsWatcher)
+	err = a.Log("two")
+	c.Assert(err, jc.ErrorIsNil)
+	s.State.StartSync()
+	select {
+	case changes := <-w.Changes():
+		c.Assert(changes, gc.HasLen, 1)
+		err = json.Unmarshal([]byte(changes[0]), &message)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(message.Message, gc.Equals, "two")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for action messages")
+	}
+}
+
 func (s *actionSuite) TestApplicationsCharmsActions(c *gc.C) {
 	actionSchemas := map[string]map[string]interface{}{
 		"snapshot": {
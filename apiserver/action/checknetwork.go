@@ -0,0 +1,182 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.action")
+
+// CheckNetwork queues an action on each unit of the specified
+// applications, or of all applications if none are specified, which
+// probes the ingress addresses of the unit's related units on the
+// spaces the relations' endpoints are bound to.
+func (a *ActionAPI) CheckNetwork(args params.CheckNetworkParams) (params.ActionResults, error) {
+	if err := a.checkCanAdmin(); err != nil {
+		return params.ActionResults{}, err
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ActionResults{}, errors.Trace(err)
+	}
+
+	var applications []*state.Application
+	if len(args.Applications) == 0 {
+		all, err := a.state.AllApplications()
+		if err != nil {
+			return params.ActionResults{}, errors.Trace(err)
+		}
+		applications = all
+	}
+	for _, name := range args.Applications {
+		application, err := a.state.Application(name)
+		if err != nil {
+			return params.ActionResults{}, errors.Trace(err)
+		}
+		applications = append(applications, application)
+	}
+
+	addresses := make(ingressAddresses)
+	actionParams := params.Actions{Actions: []params.Action{}}
+	for _, application := range applications {
+		units, err := application.AllUnits()
+		if err != nil {
+			return params.ActionResults{}, errors.Trace(err)
+		}
+		relations, err := application.Relations()
+		if err != nil {
+			return params.ActionResults{}, errors.Trace(err)
+		}
+		for _, unit := range units {
+			targets, err := a.checkNetworkTargets(unit, relations, addresses)
+			if err != nil {
+				return params.ActionResults{}, errors.Trace(err)
+			}
+			if len(targets) == 0 {
+				continue
+			}
+			actionParams.Actions = append(actionParams.Actions, params.Action{
+				Receiver: unit.Tag().String(),
+				Name:     actions.JujuCheckNetworkActionName,
+				Parameters: map[string]interface{}{
+					"targets": targets,
+					"timeout": args.Timeout.Nanoseconds(),
+				},
+			})
+		}
+	}
+	return queueActions(a, actionParams)
+}
+
+// checkNetworkTargets returns the related units the unit should probe,
+// with their ingress addresses. Units of remote applications, units in
+// container-scoped relations, and units without an address on the
+// bound space, are not probed.
+func (a *ActionAPI) checkNetworkTargets(
+	unit *state.Unit, relations []*state.Relation, addresses ingressAddresses,
+) ([]interface{}, error) {
+	var targets []interface{}
+	for _, relation := range relations {
+		endpoint, err := relation.Endpoint(unit.ApplicationName())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if endpoint.Scope == charm.ScopeContainer {
+			continue
+		}
+		related, err := relation.RelatedEndpoints(unit.ApplicationName())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, ep := range related {
+			application, err := a.state.Application(ep.ApplicationName)
+			if errors.IsNotFound(err) {
+				logger.Debugf("not probing units of remote application %q", ep.ApplicationName)
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			units, err := application.AllUnits()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, relatedUnit := range units {
+				if relatedUnit.Name() == unit.Name() {
+					continue
+				}
+				address, err := addresses.get(a.state, relatedUnit, ep.Name)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				if address == "" {
+					logger.Debugf("not probing %q: no ingress address", relatedUnit.Name())
+					continue
+				}
+				targets = append(targets, map[string]interface{}{
+					"unit":     relatedUnit.Name(),
+					"relation": relation.String(),
+					"address":  address,
+				})
+			}
+		}
+	}
+	return targets, nil
+}
+
+// ingressAddresses caches the ingress addresses of units,
+// keyed by unit name and endpoint name.
+type ingressAddresses map[[2]string]string
+
+// get returns the ingress address of the unit on the space its
+// endpoint is bound to, as reported by network-get, or the ingress
+// address of the endpoint if the application overrides it. If the
+// unit has no such address, an empty string is returned.
+func (addresses ingressAddresses) get(st *state.State, unit *state.Unit, endpoint string) (string, error) {
+	key := [2]string{unit.Name(), endpoint}
+	if address, ok := addresses[key]; ok {
+		return address, nil
+	}
+	addresses[key] = ""
+	application, err := unit.Application()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if address, _, ok := application.IngressAddress(endpoint); ok {
+		addresses[key] = address.Value
+		return address.Value, nil
+	}
+	machineId, err := unit.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	machine, err := st.Machine(machineId)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	space, err := unit.GetSpaceForBinding(endpoint)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	result := machine.GetNetworkInfoForSpaces(set.NewStrings(space))[space]
+	if result.Error != nil {
+		logger.Debugf("cannot get network info of %q: %v", unit.Name(), *result.Error)
+		return "", nil
+	}
+	for _, info := range result.NetworkInfos {
+		for _, address := range info.Addresses {
+			addresses[key] = address.Address
+			return address.Address, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,211 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/action"
+	"github.com/juju/juju/apiserver/common"
+	commontesting "github.com/juju/juju/apiserver/common/testing"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+type checkNetworkSuite struct {
+	jujutesting.JujuConnSuite
+	commontesting.BlockHelper
+
+	client *action.ActionAPI
+}
+
+var _ = gc.Suite(&checkNetworkSuite{})
+
+func (s *checkNetworkSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.BlockHelper = commontesting.NewBlockHelper(s.APIState)
+	s.AddCleanup(func(*gc.C) { s.BlockHelper.Close() })
+
+	var err error
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	}
+	s.client, err = action.NewActionAPI(s.State, nil, auth)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *checkNetworkSuite) addUnit(c *gc.C, application *state.Application, address string) *state.Unit {
+	unit, err := application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	if address == "" {
+		return unit
+	}
+	err = unit.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProviderAddresses(network.NewScopedAddress(address, network.ScopeCloudLocal))
+	c.Assert(err, jc.ErrorIsNil)
+	return unit
+}
+
+func (s *checkNetworkSuite) TestCheckNetwork(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.AddTestingService(c, "unrelated", s.AddTestingCharm(c, "dummy"))
+	s.addUnit(c, wordpress, "10.0.0.1")
+	s.addUnit(c, mysql, "10.0.0.2")
+	// Units which are not assigned to a machine are not probed.
+	s.addUnit(c, mysql, "")
+
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	expectedArgs := params.Actions{
+		Actions: []params.Action{{
+			Receiver: "unit-wordpress-0",
+			Name:     "juju-check-network",
+			Parameters: map[string]interface{}{
+				"targets": []interface{}{
+					map[string]interface{}{
+						"unit":     "mysql/0",
+						"relation": "wordpress:db mysql:server",
+						"address":  "10.0.0.2",
+					},
+				},
+				"timeout": (5 * time.Second).Nanoseconds(),
+			},
+		}, {
+			Receiver: "unit-mysql-0",
+			Name:     "juju-check-network",
+			Parameters: map[string]interface{}{
+				"targets": []interface{}{
+					map[string]interface{}{
+						"unit":     "wordpress/0",
+						"relation": "wordpress:db mysql:server",
+						"address":  "10.0.0.1",
+					},
+				},
+				"timeout": (5 * time.Second).Nanoseconds(),
+			},
+		}, {
+			Receiver: "unit-mysql-1",
+			Name:     "juju-check-network",
+			Parameters: map[string]interface{}{
+				"targets": []interface{}{
+					map[string]interface{}{
+						"unit":     "wordpress/0",
+						"relation": "wordpress:db mysql:server",
+						"address":  "10.0.0.1",
+					},
+				},
+				"timeout": (5 * time.Second).Nanoseconds(),
+			},
+		}},
+	}
+	called := false
+	s.PatchValue(action.QueueActions, func(client *action.ActionAPI, args params.Actions) (params.ActionResults, error) {
+		called = true
+		c.Assert(args, jc.DeepEquals, expectedArgs)
+		return params.ActionResults{}, nil
+	})
+
+	_, err = s.client.CheckNetwork(params.CheckNetworkParams{
+		Applications: []string{"wordpress", "mysql", "unrelated"},
+		Timeout:      5 * time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *checkNetworkSuite) TestCheckNetworkIngressAddress(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.addUnit(c, wordpress, "10.0.0.1")
+	s.addUnit(c, mysql, "10.0.0.2")
+	err := mysql.SetIngressAddresses(map[string]string{"server": "10.0.0.100:3306"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var targets []interface{}
+	s.PatchValue(action.QueueActions, func(client *action.ActionAPI, args params.Actions) (params.ActionResults, error) {
+		c.Assert(args.Actions, gc.HasLen, 1)
+		c.Assert(args.Actions[0].Receiver, gc.Equals, "unit-wordpress-0")
+		targets = args.Actions[0].Parameters["targets"].([]interface{})
+		return params.ActionResults{}, nil
+	})
+
+	_, err = s.client.CheckNetwork(params.CheckNetworkParams{
+		Applications: []string{"wordpress"},
+		Timeout:      5 * time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(targets, jc.DeepEquals, []interface{}{
+		map[string]interface{}{
+			"unit":     "mysql/0",
+			"relation": "wordpress:db mysql:server",
+			"address":  "10.0.0.100",
+		},
+	})
+}
+
+func (s *checkNetworkSuite) TestCheckNetworkQueuesActions(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.addUnit(c, wordpress, "10.0.0.1")
+	s.addUnit(c, mysql, "10.0.0.2")
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.client.CheckNetwork(params.CheckNetworkParams{Timeout: time.Second})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	for _, result := range results.Results {
+		c.Check(result.Error, gc.IsNil)
+		c.Check(result.Action.Name, gc.Equals, "juju-check-network")
+	}
+}
+
+func (s *checkNetworkSuite) TestCheckNetworkApplicationNotFound(c *gc.C) {
+	_, err := s.client.CheckNetwork(params.CheckNetworkParams{
+		Applications: []string{"foo"},
+	})
+	c.Assert(err, gc.ErrorMatches, `application "foo" not found`)
+}
+
+func (s *checkNetworkSuite) TestBlockCheckNetwork(c *gc.C) {
+	s.BlockAllChanges(c, "TestBlockCheckNetwork")
+	_, err := s.client.CheckNetwork(params.CheckNetworkParams{})
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue, gc.Commentf("error: %#v", err))
+}
+
+func (s *checkNetworkSuite) TestCheckNetworkRequiresAdmin(c *gc.C) {
+	alpha := names.NewUserTag("alpha@bravo")
+	auth := apiservertesting.FakeAuthorizer{
+		Tag:         alpha,
+		HasWriteTag: alpha,
+	}
+	client, err := action.NewActionAPI(s.State, nil, auth)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = client.CheckNetwork(params.CheckNetworkParams{})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+}
@@ -0,0 +1,169 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// leaderSuffix marks an action receiver as the current leader of the
+// named application, as in "mysql/leader".
+const leaderSuffix = "/leader"
+
+// EnqueueOperation queues up the Actions as a single operation,
+// returning the id of the operation and the params.Action enqueued on
+// each unit, or an error if there was a problem enqueueing an Action.
+// The receiver of an Action may be a unit tag; an application tag, in
+// which case the Action is enqueued on all of the application's units;
+// or "<application>/leader", which is resolved to the application's
+// current leader.
+func (a *ActionAPI) EnqueueOperation(arg params.Actions) (params.OperationResult, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.OperationResult{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.OperationResult{}, errors.Trace(err)
+	}
+
+	operationId, err := utils.NewUUID()
+	if err != nil {
+		return params.OperationResult{}, errors.Trace(err)
+	}
+	result := params.OperationResult{OperationId: operationId.String()}
+	leaders := &applicationLeaders{st: a.state}
+	for _, action := range arg.Actions {
+		units, err := a.operationUnits(action.Receiver, leaders)
+		if err != nil {
+			result.Actions = append(result.Actions, params.ActionResult{
+				Action: &params.Action{Receiver: action.Receiver, Name: action.Name},
+				Error:  common.ServerError(err),
+			})
+			continue
+		}
+		for _, unit := range units {
+			enqueued, err := unit.AddActionWithOptions(action.Name, action.Parameters, state.ActionOptions{
+				Operation: result.OperationId,
+				Timeout:   action.Timeout,
+			})
+			if err != nil {
+				result.Actions = append(result.Actions, params.ActionResult{
+					Action: &params.Action{Receiver: unit.Tag().String(), Name: action.Name},
+					Error:  common.ServerError(err),
+				})
+				continue
+			}
+			result.Actions = append(result.Actions, common.MakeActionResult(unit.Tag(), enqueued))
+		}
+	}
+	return result, nil
+}
+
+// Operations returns the Actions enqueued as part of each of the
+// specified operations.
+func (a *ActionAPI) Operations(arg params.OperationQueryArgs) (params.OperationResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.OperationResults{}, errors.Trace(err)
+	}
+
+	response := params.OperationResults{Results: make([]params.OperationResult, len(arg.Operations))}
+	for i, operationId := range arg.Operations {
+		currentResult := &response.Results[i]
+		currentResult.OperationId = operationId
+		actions, err := a.state.FindActionsByOperation(operationId)
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		if len(actions) == 0 {
+			currentResult.Error = common.ServerError(errors.NotFoundf("operation %q", operationId))
+			continue
+		}
+		for _, action := range actions {
+			receiverTag := names.NewUnitTag(action.Receiver())
+			currentResult.Actions = append(currentResult.Actions, common.MakeActionResult(receiverTag, action))
+		}
+	}
+	return response, nil
+}
+
+// operationUnits returns the units an operation's action receiver
+// refers to.
+func (a *ActionAPI) operationUnits(receiver string, leaders *applicationLeaders) ([]*state.Unit, error) {
+	if strings.HasSuffix(receiver, leaderSuffix) {
+		applicationName := strings.TrimSuffix(receiver, leaderSuffix)
+		if !names.IsValidApplication(applicationName) {
+			return nil, errors.NotValidf("action receiver %q", receiver)
+		}
+		leader, err := leaders.get(applicationName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		unit, err := a.state.Unit(leader)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []*state.Unit{unit}, nil
+	}
+
+	tag, err := names.ParseTag(receiver)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch tag := tag.(type) {
+	case names.UnitTag:
+		unit, err := a.state.Unit(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []*state.Unit{unit}, nil
+	case names.ApplicationTag:
+		application, err := a.state.Application(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		units, err := application.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(units) == 0 {
+			return nil, errors.Errorf("application %q has no units", tag.Id())
+		}
+		return units, nil
+	}
+	return nil, errors.NotValidf("action receiver %q", receiver)
+}
+
+// applicationLeaders reads the current leaders of the model's
+// applications from the leadership leases the first time one is
+// needed, so that all of an operation's leader receivers are resolved
+// against the same leases.
+type applicationLeaders struct {
+	st      *state.State
+	leaders map[string]string
+}
+
+// get returns the name of the unit that is the current leader of the
+// application.
+func (l *applicationLeaders) get(applicationName string) (string, error) {
+	if l.leaders == nil {
+		leaders, err := l.st.ApplicationLeaders()
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		l.leaders = leaders
+	}
+	leader, ok := l.leaders[applicationName]
+	if !ok {
+		return "", errors.NotFoundf("leader of application %q", applicationName)
+	}
+	return leader, nil
+}
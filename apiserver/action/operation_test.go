@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	jujuFactory "github.com/juju/juju/testing/factory"
+)
+
+func (s *actionSuite) TestEnqueueOperation(c *gc.C) {
+	factory := jujuFactory.NewFactory(s.State)
+	wordpressUnit2 := factory.MakeUnit(c, &jujuFactory.UnitParams{
+		Application: s.wordpress,
+		Machine:     s.machine1,
+	})
+	err := s.State.LeadershipClaimer().ClaimLeadership("mysql", s.mysqlUnit.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	parameters := map[string]interface{}{"flush": "all"}
+	arg := params.Actions{
+		Actions: []params.Action{
+			// All units of the application.
+			{Receiver: s.wordpress.Tag().String(), Name: "fakeaction", Parameters: parameters},
+			// The current leader of the application.
+			{Receiver: "mysql/leader", Name: "fakeaction", Parameters: parameters},
+			// An application without a leader.
+			{Receiver: "dummy/leader", Name: "fakeaction"},
+			// A machine is not a valid receiver.
+			{Receiver: s.machine0.Tag().String(), Name: "fakeaction"},
+		},
+	}
+	result, err := s.action.EnqueueOperation(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OperationId, gc.Not(gc.Equals), "")
+	c.Assert(result.Actions, gc.HasLen, 5)
+
+	receivers := make([]string, 3)
+	for i, actionResult := range result.Actions[:3] {
+		c.Assert(actionResult.Error, gc.IsNil)
+		c.Assert(actionResult.Action, gc.NotNil)
+		c.Check(actionResult.Action.Name, gc.Equals, "fakeaction")
+		receivers[i] = actionResult.Action.Receiver
+	}
+	c.Check(receivers, jc.SameContents, []string{
+		s.wordpressUnit.Tag().String(),
+		wordpressUnit2.Tag().String(),
+		s.mysqlUnit.Tag().String(),
+	})
+	c.Check(result.Actions[3].Error, gc.ErrorMatches, `leader of application "dummy" not found`)
+	c.Check(result.Actions[3].Action.Receiver, gc.Equals, "dummy/leader")
+	c.Check(result.Actions[4].Error, gc.ErrorMatches, `action receiver "machine-0" not valid`)
+
+	actions, err := s.State.FindActionsByOperation(result.OperationId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 3)
+	for _, action := range actions {
+		c.Check(action.Parameters(), jc.DeepEquals, parameters)
+	}
+}
+
+func (s *actionSuite) TestBlockEnqueueOperation(c *gc.C) {
+	s.BlockAllChanges(c, "EnqueueOperation")
+	_, err := s.action.EnqueueOperation(params.Actions{})
+	s.AssertBlocked(c, err, "EnqueueOperation")
+}
+
+func (s *actionSuite) TestOperations(c *gc.C) {
+	enqueued, err := s.action.EnqueueOperation(params.Actions{
+		Actions: []params.Action{{Receiver: s.wordpress.Tag().String(), Name: "fakeaction"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(enqueued.Actions, gc.HasLen, 1)
+	c.Assert(enqueued.Actions[0].Error, gc.IsNil)
+	_, err = s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.action.Operations(params.OperationQueryArgs{
+		Operations: []string{enqueued.OperationId, "missing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Check(result.OperationId, gc.Equals, enqueued.OperationId)
+	c.Assert(result.Actions, gc.HasLen, 1)
+	c.Check(result.Actions[0].Action.Tag, gc.Equals, enqueued.Actions[0].Action.Tag)
+	c.Check(result.Actions[0].Action.Receiver, gc.Equals, s.wordpressUnit.Tag().String())
+	c.Check(result.Actions[0].Status, gc.Equals, params.ActionPending)
+
+	c.Check(results.Results[1].OperationId, gc.Equals, "missing")
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `operation "missing" not found`)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
 )
 
 // getAllUnitNames returns a sequence of valid Unit objects from state. If any
@@ -64,7 +65,11 @@ func (a *ActionAPI) Run(run params.RunParams) (results params.ActionResults, err
 		machines[i] = names.NewMachineTag(machineId)
 	}
 
-	actionParams := a.createActionsParams(append(units, machines...), run.Commands, run.Timeout)
+	targets, err := a.selectRunTargets(run, append(units, machines...))
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	actionParams := a.createActionsParams(targets, run.Commands, run.Timeout)
 
 	return queueActions(a, actionParams)
 }
@@ -88,11 +93,108 @@ func (a *ActionAPI) RunOnAllMachines(run params.RunParams) (results params.Actio
 		machineTags[i] = machine.Tag()
 	}
 
-	actionParams := a.createActionsParams(machineTags, run.Commands, run.Timeout)
+	targets, err := a.selectRunTargets(run, machineTags)
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	actionParams := a.createActionsParams(targets, run.Commands, run.Timeout)
 
 	return queueActions(a, actionParams)
 }
 
+// selectRunTargets returns those of the targets that match the
+// selectors of the run; without selectors, all of them are returned.
+func (a *ActionAPI) selectRunTargets(run params.RunParams, targets []names.Tag) ([]names.Tag, error) {
+	if !run.LeaderOnly && run.Status == "" && run.Series == "" {
+		return targets, nil
+	}
+	leaders := &applicationLeaders{st: a.state}
+	var selected []names.Tag
+	for _, tag := range targets {
+		ok, err := a.runTargetSelected(run, tag, leaders)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if ok {
+			selected = append(selected, tag)
+		}
+	}
+	return selected, nil
+}
+
+// runTargetSelected reports whether the unit or machine matches the
+// selectors of the run. Machines are never leaders, and units that
+// are not assigned to a machine have no series.
+func (a *ActionAPI) runTargetSelected(run params.RunParams, tag names.Tag, leaders *applicationLeaders) (bool, error) {
+	var (
+		targetStatus func() (status.StatusInfo, error)
+		machineId    string
+	)
+	switch tag := tag.(type) {
+	case names.UnitTag:
+		if run.LeaderOnly {
+			applicationName, err := names.UnitApplication(tag.Id())
+			if err != nil {
+				return false, errors.Trace(err)
+			}
+			leader, err := leaders.get(applicationName)
+			if errors.IsNotFound(err) {
+				return false, nil
+			} else if err != nil {
+				return false, errors.Trace(err)
+			}
+			if leader != tag.Id() {
+				return false, nil
+			}
+		}
+		unit, err := a.state.Unit(tag.Id())
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		targetStatus = unit.Status
+		if run.Series != "" {
+			machineId, err = unit.AssignedMachineId()
+			if errors.IsNotAssigned(err) {
+				return false, nil
+			} else if err != nil {
+				return false, errors.Trace(err)
+			}
+		}
+	case names.MachineTag:
+		if run.LeaderOnly {
+			return false, nil
+		}
+		machine, err := a.state.Machine(tag.Id())
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		targetStatus = machine.Status
+		machineId = tag.Id()
+	default:
+		return false, errors.NotValidf("run target %q", tag)
+	}
+
+	if run.Status != "" {
+		info, err := targetStatus()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if info.Status != status.Status(run.Status) {
+			return false, nil
+		}
+	}
+	if run.Series != "" {
+		machine, err := a.state.Machine(machineId)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if machine.Series() != run.Series {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 func (a *ActionAPI) createActionsParams(actionReceiverTags []names.Tag, quotedCommands string, timeout time.Duration) params.Actions {
 
 	apiActionParams := params.Actions{Actions: []params.Action{}}
@@ -4,6 +4,8 @@
 package action_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -16,6 +18,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/testing"
 )
 
@@ -243,6 +246,73 @@ func (s *runSuite) TestRunOnAllMachines(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *runSuite) TestRunSelectors(c *gc.C) {
+	var receivers []string
+	s.PatchValue(action.QueueActions, func(client *action.ActionAPI, args params.Actions) (params.ActionResults, error) {
+		receivers = nil
+		for _, arg := range args.Actions {
+			receivers = append(receivers, arg.Receiver)
+		}
+		return params.ActionResults{}, nil
+	})
+
+	s.addMachine(c)
+	_, err := s.State.AddMachine("trusty", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	charm := s.AddTestingCharm(c, "dummy")
+	magic, err := s.State.AddApplication(state.AddApplicationArgs{Name: "magic", Charm: charm})
+	c.Assert(err, jc.ErrorIsNil)
+	s.addUnit(c, magic)
+	magic1 := s.addUnit(c, magic)
+	now := time.Now()
+	err = magic1.SetStatus(status.StatusInfo{
+		Status:  status.Blocked,
+		Message: "waiting for database",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.LeadershipClaimer().ClaimLeadership("magic", "magic/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i, test := range []struct {
+		message  string
+		run      params.RunParams
+		all      bool
+		expected []string
+	}{{
+		message:  "leader only",
+		run:      params.RunParams{Applications: []string{"magic"}, Machines: []string{"0"}, LeaderOnly: true},
+		expected: []string{"unit-magic-0"},
+	}, {
+		message:  "units with a workload status",
+		run:      params.RunParams{Applications: []string{"magic"}, Status: "blocked"},
+		expected: []string{"unit-magic-1"},
+	}, {
+		message: "leader with a workload status",
+		run:     params.RunParams{Units: []string{"magic/0"}, LeaderOnly: true, Status: "blocked"},
+	}, {
+		message:  "units on machines of a series",
+		run:      params.RunParams{Applications: []string{"magic"}, Series: "quantal"},
+		expected: []string{"unit-magic-0", "unit-magic-1"},
+	}, {
+		message:  "all machines of a series",
+		run:      params.RunParams{Series: "trusty"},
+		all:      true,
+		expected: []string{"machine-1"},
+	}} {
+		c.Logf("%d: %s", i, test.message)
+		test.run.Commands = "hostname"
+		if test.all {
+			_, err = s.client.RunOnAllMachines(test.run)
+		} else {
+			_, err = s.client.Run(test.run)
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(receivers, jc.SameContents, test.expected)
+	}
+}
+
 func (s *runSuite) TestRunRequiresAdmin(c *gc.C) {
 	alpha := names.NewUserTag("alpha@bravo")
 	auth := apiservertesting.FakeAuthorizer{
@@ -0,0 +1,72 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionpruner
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// API is the concrete implementation of the ActionPruner endpoint.
+type API struct {
+	*common.ModelWatcher
+	st         *state.State
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns an API Instance.
+func NewAPI(st *state.State, r facade.Resources, auth facade.Authorizer) (*API, error) {
+	if !auth.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		ModelWatcher: common.NewModelWatcher(st, r, auth),
+		st:           st,
+		authorizer:   auth,
+	}, nil
+}
+
+// ActionsToPrune returns the completed, failed and cancelled actions
+// that were finished before now - p.MaxHistoryTime, or that must be
+// removed for the actions to be smaller than p.MaxHistoryMB, oldest
+// first.
+func (api *API) ActionsToPrune(p params.ActionPruneArgs) (params.ActionResults, error) {
+	actions, err := state.ActionsToPrune(api.st, p.MaxHistoryTime, p.MaxHistoryMB)
+	if err != nil {
+		return params.ActionResults{}, errors.Trace(err)
+	}
+	results := make([]params.ActionResult, len(actions))
+	for i, action := range actions {
+		results[i] = common.MakeActionResult(receiverTag(action.Receiver()), action)
+	}
+	return params.ActionResults{Results: results}, nil
+}
+
+// RemoveActions removes the specified actions, provided they have
+// finished.
+func (api *API) RemoveActions(args params.Entities) error {
+	ids := make([]string, len(args.Entities))
+	for i, entity := range args.Entities {
+		tag, err := names.ParseActionTag(entity.Tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ids[i] = tag.Id()
+	}
+	return state.RemoveActions(api.st, ids)
+}
+
+// receiverTag returns the tag of the receiver of an action, which is
+// either a unit or a machine.
+func receiverTag(receiver string) names.Tag {
+	if names.IsValidUnit(receiver) {
+		return names.NewUnitTag(receiver)
+	}
+	return names.NewMachineTag(receiver)
+}
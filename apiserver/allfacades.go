@@ -11,6 +11,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/action"
+	"github.com/juju/juju/apiserver/actionpruner"
 	"github.com/juju/juju/apiserver/agent" // ModelUser Write
 	"github.com/juju/juju/apiserver/agenttools"
 	"github.com/juju/juju/apiserver/annotations" // ModelUser Write
@@ -30,6 +31,7 @@ import (
 	"github.com/juju/juju/apiserver/deployer"
 	"github.com/juju/juju/apiserver/discoverspaces"
 	"github.com/juju/juju/apiserver/diskmanager"
+	"github.com/juju/juju/apiserver/egressfirewaller"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/firewaller"
 	"github.com/juju/juju/apiserver/highavailability" // ModelUser Write
@@ -113,6 +115,12 @@ func AllFacades() *facade.Registry {
 	}
 
 	reg("Action", 2, action.NewActionAPI)
+	reg("Action", 3, action.NewActionAPI) // v3 adds CheckNetwork.
+	reg("Action", 4, action.NewActionAPI) // v4 adds WatchActionsProgress.
+	reg("Action", 5, action.NewActionAPI) // v5 adds EnqueueOperation and Operations.
+	reg("Action", 6, action.NewActionAPI) // v6 adds action timeouts.
+	reg("Action", 7, action.NewActionAPI) // v7 adds run target selection.
+	reg("ActionPruner", 1, actionpruner.NewAPI)
 	reg("Agent", 2, agent.NewAgentAPIV2)
 	reg("AgentTools", 1, agenttools.NewFacade)
 	reg("Annotations", 2, annotations.NewAPI)
@@ -121,21 +129,32 @@ func AllFacades() *facade.Registry {
 	reg("Application", 2, application.NewFacade)
 	reg("Application", 3, application.NewFacade)
 	reg("Application", 4, application.NewFacade)
+	reg("Application", 5, application.NewFacade) // v5 adds expose settings to Expose and Unexpose.
+	reg("Application", 6, application.NewFacade) // v6 adds SetEgressRules and GetEgressRules.
+	reg("Application", 7, application.NewFacade) // v7 adds SetIngressAddresses, GetIngressAddresses and ingress addresses to Deploy.
+	reg("Application", 8, application.NewFacade) // v8 adds SetActionConcurrency and GetActionConcurrency.
+	reg("Application", 9, application.NewFacade) // v9 adds GetConfigHistory and RevertConfig.
 
 	reg("ApplicationScaler", 1, applicationscaler.NewAPI)
 	reg("Backups", 1, backups.NewFacade)
 	reg("Block", 2, block.NewAPI)
 	reg("Bundle", 1, bundle.NewFacade)
+	reg("Bundle", 2, bundle.NewFacade) // v2 adds ExportBundle.
 	reg("CharmRevisionUpdater", 2, charmrevisionupdater.NewCharmRevisionUpdaterAPI)
 	reg("Charms", 2, charms.NewFacade)
 	reg("Cleaner", 2, cleaner.NewCleanerAPI)
 	reg("Client", 1, client.NewFacade)
 	reg("Cloud", 1, cloud.NewFacade)
 	reg("Controller", 3, controller.NewControllerAPI)
+	reg("Controller", 4, controller.NewControllerAPI) // v4 adds ControllerConfigSet.
+	reg("Controller", 5, controller.NewControllerAPI) // v5 adds BackupStatus.
 	reg("Deployer", 1, deployer.NewDeployerAPI)
 	reg("DiscoverSpaces", 2, discoverspaces.NewAPI)
 	reg("DiskManager", 2, diskmanager.NewDiskManagerAPI)
+	reg("EgressFirewaller", 1, egressfirewaller.NewAPI)
 	reg("Firewaller", 3, firewaller.NewFirewallerAPI)
+	reg("Firewaller", 4, firewaller.NewFirewallerAPI) // v4 adds GetExposeInfo.
+	reg("Firewaller", 5, firewaller.NewFirewallerAPI) // v5 adds WatchEgressRules and GetMachineEgressRules.
 	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPI)
 	reg("HostKeyReporter", 1, hostkeyreporter.NewFacade)
 	reg("ImageManager", 2, imagemanager.NewImageManagerAPI)
@@ -166,6 +185,7 @@ func AllFacades() *facade.Registry {
 	reg("MigrationTarget", 1, migrationtarget.NewFacade)
 
 	reg("ModelConfig", 1, modelconfig.NewFacade)
+	reg("ModelConfig", 2, modelconfig.NewFacade) // v2 adds ModelGetHistory and ModelRevert.
 	reg("ModelManager", 2, modelmanager.NewFacade)
 
 	reg("Payloads", 1, payloads.NewFacade)
@@ -197,13 +217,19 @@ func AllFacades() *facade.Registry {
 	reg("Spaces", 2, spaces.NewAPI)
 	reg("StatusHistory", 2, statushistory.NewAPI)
 	reg("Storage", 3, storage.NewFacade)
+	reg("Storage", 4, storage.NewFacade) // v4 adds CreateSnapshots, ListSnapshots, DestroySnapshots and adding storage from snapshots.
+	reg("Storage", 5, storage.NewFacade) // v5 adds ResizeStorage.
+	reg("Storage", 6, storage.NewFacade) // v6 adds Import, and implements Attach.
 	reg("StorageProvisioner", 3, storageprovisioner.NewFacade)
+	reg("StorageProvisioner", 4, storageprovisioner.NewFacade) // v4 adds WatchVolumeResizes and VolumeResizeParams.
 	reg("Subnets", 2, subnets.NewAPI)
 	reg("Undertaker", 1, undertaker.NewUndertakerAPI)
 	reg("UnitAssigner", 1, unitassigner.New)
 
 	reg("Uniter", 4, uniter.NewUniterAPI)
 	reg("Uniter", 5, uniter.NewUniterAPI)
+	reg("Uniter", 6, uniter.NewUniterAPI) // v6 adds LogActionsMessages.
+	reg("Uniter", 7, uniter.NewUniterAPI) // v7 adds endpoints to OpenPorts.
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
 	reg("UserManager", 1, usermanager.NewUserManagerAPI)
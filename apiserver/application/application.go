@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/instance"
 	jjj "github.com/juju/juju/juju"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
@@ -116,6 +117,12 @@ func (api *API) checkPermission(tag names.Tag, perm permission.Access) error {
 	return nil
 }
 
+// authorTag returns the tag of the authenticated entity, as recorded
+// in the history of any config changes it makes.
+func (api *API) authorTag() string {
+	return api.authorizer.GetAuthTag().String()
+}
+
 func (api *API) checkCanRead() error {
 	return api.checkPermission(api.backend.ModelTag(), permission.ReadAccess)
 }
@@ -229,12 +236,14 @@ func deployApplication(
 		Storage:          args.Storage,
 		EndpointBindings: args.EndpointBindings,
 		Resources:        args.Resources,
+		IngressAddresses: args.IngressAddresses,
 	}))
 }
 
 // ApplicationSetSettingsStrings updates the settings for the given application,
 // taking the configuration from a map of strings.
-func ApplicationSetSettingsStrings(application Application, settings map[string]string) error {
+// The author is recorded in the application's config history.
+func ApplicationSetSettingsStrings(application Application, settings map[string]string, author string) error {
 	ch, _, err := application.Charm()
 	if err != nil {
 		return errors.Trace(err)
@@ -244,7 +253,7 @@ func ApplicationSetSettingsStrings(application Application, settings map[string]
 	if err != nil {
 		return errors.Trace(err)
 	}
-	return application.UpdateConfigSettings(changes)
+	return application.UpdateConfigSettingsAs(author, changes)
 }
 
 // parseSettingsCompatible parses setting strings in a way that is
@@ -324,11 +333,11 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 	}
 	// Set up application's settings.
 	if args.SettingsYAML != "" {
-		if err = applicationSetSettingsYAML(args.ApplicationName, app, args.SettingsYAML); err != nil {
+		if err = applicationSetSettingsYAML(args.ApplicationName, app, args.SettingsYAML, api.authorTag()); err != nil {
 			return errors.Annotate(err, "setting configuration from YAML")
 		}
 	} else if len(args.SettingsStrings) > 0 {
-		if err = ApplicationSetSettingsStrings(app, args.SettingsStrings); err != nil {
+		if err = ApplicationSetSettingsStrings(app, args.SettingsStrings, api.authorTag()); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -455,7 +464,7 @@ func settingsFromGetYaml(yamlContents map[string]interface{}) (charm.Settings, e
 
 // applicationSetSettingsYAML updates the settings for the given application,
 // taking the configuration from a YAML string.
-func applicationSetSettingsYAML(appName string, application Application, settings string) error {
+func applicationSetSettingsYAML(appName string, application Application, settings, author string) error {
 	b := []byte(settings)
 	var all map[string]interface{}
 	if err := goyaml.Unmarshal(b, &all); err != nil {
@@ -467,7 +476,7 @@ func applicationSetSettingsYAML(appName string, application Application, setting
 		if err != nil {
 			return errors.Annotate(err, "processing YAML generated by get")
 		}
-		return errors.Annotate(application.UpdateConfigSettings(changes), "updating settings with application YAML")
+		return errors.Annotate(application.UpdateConfigSettingsAs(author, changes), "updating settings with application YAML")
 	}
 
 	ch, _, err := application.Charm()
@@ -479,7 +488,7 @@ func applicationSetSettingsYAML(appName string, application Application, setting
 	if err != nil {
 		return errors.Annotate(err, "creating config from YAML")
 	}
-	return errors.Annotate(application.UpdateConfigSettings(changes), "updating settings")
+	return errors.Annotate(application.UpdateConfigSettingsAs(author, changes), "updating settings")
 }
 
 // GetCharmURL returns the charm URL the given application is
@@ -520,7 +529,7 @@ func (api *API) Set(p params.ApplicationSet) error {
 		return err
 	}
 
-	return app.UpdateConfigSettings(changes)
+	return app.UpdateConfigSettingsAs(api.authorTag(), changes)
 
 }
 
@@ -540,7 +549,7 @@ func (api *API) Unset(p params.ApplicationUnset) error {
 	for _, option := range p.Options {
 		settings[option] = nil
 	}
-	return app.UpdateConfigSettings(settings)
+	return app.UpdateConfigSettingsAs(api.authorTag(), settings)
 }
 
 // CharmRelations implements the server side of Application.CharmRelations.
@@ -566,7 +575,8 @@ func (api *API) CharmRelations(p params.ApplicationCharmRelations) (params.Appli
 }
 
 // Expose changes the juju-managed firewall to expose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open. If expose settings are
+// specified, the ports are only exposed to the specified sources.
 func (api *API) Expose(args params.ApplicationExpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
@@ -578,11 +588,21 @@ func (api *API) Expose(args params.ApplicationExpose) error {
 	if err != nil {
 		return err
 	}
-	return app.SetExposed()
+	if len(args.ExposedEndpoints) == 0 {
+		return app.SetExposed()
+	}
+	exposed := make(map[string]state.ExposedEndpoint, len(args.ExposedEndpoints))
+	for endpoint, settings := range args.ExposedEndpoints {
+		exposed[endpoint] = state.ExposedEndpoint{
+			ExposeToCIDRs: settings.ExposeToCIDRs,
+		}
+	}
+	return app.MergeExposeSettings(exposed)
 }
 
 // Unexpose changes the juju-managed firewall to unexpose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open. If endpoints are
+// specified, only the expose settings for those endpoints are removed.
 func (api *API) Unexpose(args params.ApplicationUnexpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
@@ -594,7 +614,196 @@ func (api *API) Unexpose(args params.ApplicationUnexpose) error {
 	if err != nil {
 		return err
 	}
-	return app.ClearExposed()
+	if len(args.ExposedEndpoints) == 0 {
+		return app.ClearExposed()
+	}
+	return app.UnsetExposeSettings(args.ExposedEndpoints)
+}
+
+// SetEgressRules replaces the egress policy of an application: the
+// destinations to which the application's units may send outgoing
+// traffic. If no rules are specified, outgoing traffic is unrestricted.
+func (api *API) SetEgressRules(args params.ApplicationSetEgressRules) error {
+	if err := api.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	return app.SetEgressRules(params.NetworkEgressRules(args.Rules))
+}
+
+// GetEgressRules returns the egress policy of each of the specified
+// applications.
+func (api *API) GetEgressRules(args params.Entities) (params.EgressRulesResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.EgressRulesResults{}, errors.Trace(err)
+	}
+	results := make([]params.EgressRulesResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		rules, err := api.egressRules(entity)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Rules = params.FromNetworkEgressRules(rules)
+	}
+	return params.EgressRulesResults{results}, nil
+}
+
+func (api *API) egressRules(entity params.Entity) ([]network.EgressRule, error) {
+	tag, err := names.ParseApplicationTag(entity.Tag)
+	if err != nil {
+		return nil, err
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return nil, err
+	}
+	return app.EgressRules(), nil
+}
+
+// SetIngressAddresses merges the specified ingress address overrides
+// into those of an application: the addresses advertised to related
+// units on the application's endpoints in place of those of its units.
+func (api *API) SetIngressAddresses(args params.ApplicationSetIngressAddresses) error {
+	if err := api.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	return app.SetIngressAddresses(args.IngressAddresses)
+}
+
+// GetIngressAddresses returns the ingress address overrides of each
+// of the specified applications.
+func (api *API) GetIngressAddresses(args params.Entities) (params.IngressAddressesResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.IngressAddressesResults{}, errors.Trace(err)
+	}
+	results := make([]params.IngressAddressesResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		addresses, err := api.ingressAddresses(entity)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].IngressAddresses = addresses
+	}
+	return params.IngressAddressesResults{results}, nil
+}
+
+func (api *API) ingressAddresses(entity params.Entity) (map[string]string, error) {
+	tag, err := names.ParseApplicationTag(entity.Tag)
+	if err != nil {
+		return nil, err
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return nil, err
+	}
+	return app.IngressAddresses(), nil
+}
+
+// SetActionConcurrency sets the maximum number of actions that may run
+// on the units of an application at once.
+func (api *API) SetActionConcurrency(args params.ApplicationSetActionConcurrency) error {
+	if err := api.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	return app.SetActionConcurrency(args.Limit)
+}
+
+// GetActionConcurrency returns the action concurrency limit of each of
+// the specified applications.
+func (api *API) GetActionConcurrency(args params.Entities) (params.ActionConcurrencyResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ActionConcurrencyResults{}, errors.Trace(err)
+	}
+	results := make([]params.ActionConcurrencyResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		limit, err := api.actionConcurrency(entity)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Limit = limit
+	}
+	return params.ActionConcurrencyResults{results}, nil
+}
+
+func (api *API) actionConcurrency(entity params.Entity) (int, error) {
+	tag, err := names.ParseApplicationTag(entity.Tag)
+	if err != nil {
+		return 0, err
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return 0, err
+	}
+	return app.ActionConcurrency(), nil
+}
+
+// GetConfigHistory returns the changes made to the charm config of each
+// of the specified applications, oldest first.
+func (api *API) GetConfigHistory(args params.Entities) (params.ApplicationConfigHistoryResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ApplicationConfigHistoryResults{}, errors.Trace(err)
+	}
+	results := make([]params.ApplicationConfigHistoryResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		history, err := api.configHistory(entity)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Revisions = common.ConfigRevisions(history)
+	}
+	return params.ApplicationConfigHistoryResults{results}, nil
+}
+
+func (api *API) configHistory(entity params.Entity) ([]state.SettingsRevision, error) {
+	tag, err := names.ParseApplicationTag(entity.Tag)
+	if err != nil {
+		return nil, err
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return nil, err
+	}
+	return app.ConfigSettingsHistory()
+}
+
+// RevertConfig returns an application's charm config to the values it
+// had immediately after the given revision was made.
+func (api *API) RevertConfig(args params.ApplicationConfigRevert) error {
+	if err := api.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	return app.RevertConfigSettings(args.Revision, api.authorTag())
 }
 
 // addApplicationUnits adds a given number of units to an application.
@@ -371,6 +371,33 @@ func (s *applicationSuite) TestApplicationDeploy(c *gc.C) {
 	c.Assert(files, gc.HasLen, 0)
 }
 
+func (s *applicationSuite) TestApplicationDeployWithIngressAddresses(c *gc.C) {
+	curl, _ := s.UploadCharm(c, "precise/mysql-1", "mysql")
+	err := application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
+		URL: curl.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName:  "mysql",
+			CharmURL:         curl.String(),
+			IngressAddresses: map[string]string{"server": "10.0.0.100:3306"},
+		}, {
+			ApplicationName:  "mysql2",
+			CharmURL:         curl.String(),
+			IngressAddresses: map[string]string{"server": "10.0.0.100:mysql"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `cannot add application "mysql2": ingress address for endpoint "server": port in "10.0.0.100:mysql" not valid`)
+
+	app, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IngressAddresses(), jc.DeepEquals, map[string]string{"server": "10.0.0.100:3306"})
+}
+
 func (s *applicationSuite) TestApplicationDeployWithInvalidPlacement(c *gc.C) {
 	curl, _ := s.UploadCharm(c, "precise/dummy-42", "dummy")
 	err := application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
@@ -1857,7 +1884,7 @@ func (s *applicationSuite) TestApplicationExpose(c *gc.C) {
 	c.Assert(apps[1].IsExposed(), jc.IsTrue)
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err = s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1869,6 +1896,271 @@ func (s *applicationSuite) TestApplicationExpose(c *gc.C) {
 	}
 }
 
+func (s *applicationSuite) TestApplicationExposeEndpoints(c *gc.C) {
+	app := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	err := s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "mysql",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"":             {},
+			"server-admin": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsExposed(), jc.IsTrue)
+	c.Assert(app.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"":             {},
+		"server-admin": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+
+	err = s.applicationAPI.Unexpose(params.ApplicationUnexpose{
+		ApplicationName:  "mysql",
+		ExposedEndpoints: []string{""},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsExposed(), jc.IsTrue)
+	c.Assert(app.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"server-admin": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+}
+
+func (s *applicationSuite) TestApplicationExposeUnknownEndpoint(c *gc.C) {
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	err := s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "mysql",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"foo": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "mysql": endpoint "foo" not found`)
+}
+
+func (s *applicationSuite) TestApplicationSetEgressRules(c *gc.C) {
+	app := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	err := s.applicationAPI.SetEgressRules(params.ApplicationSetEgressRules{
+		ApplicationName: "mysql",
+		Rules: []params.EgressRule{{
+			PortRange:        params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+			DestinationCIDRs: []string{"10.0.0.0/8"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.EgressRules(), jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+
+	results, err := s.applicationAPI.GetEgressRules(params.Entities{
+		Entities: []params.Entity{{"application-mysql"}, {"application-foo"}, {"unit-mysql-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.EgressRulesResults{
+		Results: []params.EgressRulesResult{{
+			Rules: []params.EgressRule{{
+				PortRange:        params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+				DestinationCIDRs: []string{"10.0.0.0/8"},
+			}},
+		}, {
+			Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `application "foo" not found`,
+			},
+		}, {
+			Error: &params.Error{
+				Message: `"unit-mysql-0" is not a valid application tag`,
+			},
+		}},
+	})
+
+	err = s.applicationAPI.SetEgressRules(params.ApplicationSetEgressRules{
+		ApplicationName: "mysql",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.EgressRules(), gc.HasLen, 0)
+}
+
+func (s *applicationSuite) TestApplicationSetEgressRulesInvalid(c *gc.C) {
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	err := s.applicationAPI.SetEgressRules(params.ApplicationSetEgressRules{
+		ApplicationName: "mysql",
+		Rules: []params.EgressRule{{
+			PortRange: params.PortRange{FromPort: 443, ToPort: 80, Protocol: "tcp"},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set egress rules for application "mysql": egress rule "443-80/tcp": invalid port range 443-80/tcp`)
+}
+
+func (s *applicationSuite) TestBlockSetEgressRules(c *gc.C) {
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.BlockAllChanges(c, "TestBlockSetEgressRules")
+	err := s.applicationAPI.SetEgressRules(params.ApplicationSetEgressRules{
+		ApplicationName: "mysql",
+	})
+	s.AssertBlocked(c, err, "TestBlockSetEgressRules")
+}
+
+func (s *applicationSuite) TestApplicationSetIngressAddresses(c *gc.C) {
+	app := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	err := s.applicationAPI.SetIngressAddresses(params.ApplicationSetIngressAddresses{
+		ApplicationName:  "mysql",
+		IngressAddresses: map[string]string{"server": "10.0.0.100:3306"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IngressAddresses(), jc.DeepEquals, map[string]string{"server": "10.0.0.100:3306"})
+
+	results, err := s.applicationAPI.GetIngressAddresses(params.Entities{
+		Entities: []params.Entity{{"application-mysql"}, {"application-foo"}, {"unit-mysql-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.IngressAddressesResults{
+		Results: []params.IngressAddressesResult{{
+			IngressAddresses: map[string]string{"server": "10.0.0.100:3306"},
+		}, {
+			Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `application "foo" not found`,
+			},
+		}, {
+			Error: &params.Error{
+				Message: `"unit-mysql-0" is not a valid application tag`,
+			},
+		}},
+	})
+
+	err = s.applicationAPI.SetIngressAddresses(params.ApplicationSetIngressAddresses{
+		ApplicationName:  "mysql",
+		IngressAddresses: map[string]string{"foo": "10.0.0.100"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set ingress addresses for application "mysql": endpoint "foo" not found`)
+}
+
+func (s *applicationSuite) TestBlockSetIngressAddresses(c *gc.C) {
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.BlockAllChanges(c, "TestBlockSetIngressAddresses")
+	err := s.applicationAPI.SetIngressAddresses(params.ApplicationSetIngressAddresses{
+		ApplicationName: "mysql",
+	})
+	s.AssertBlocked(c, err, "TestBlockSetIngressAddresses")
+}
+
+func (s *applicationSuite) TestApplicationSetActionConcurrency(c *gc.C) {
+	app := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	err := s.applicationAPI.SetActionConcurrency(params.ApplicationSetActionConcurrency{
+		ApplicationName: "dummy",
+		Limit:           2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.ActionConcurrency(), gc.Equals, 2)
+
+	results, err := s.applicationAPI.GetActionConcurrency(params.Entities{
+		Entities: []params.Entity{{"application-dummy"}, {"application-foo"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ActionConcurrencyResults{
+		Results: []params.ActionConcurrencyResult{{
+			Limit: 2,
+		}, {
+			Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `application "foo" not found`,
+			},
+		}},
+	})
+
+	err = s.applicationAPI.SetActionConcurrency(params.ApplicationSetActionConcurrency{
+		ApplicationName: "dummy",
+		Limit:           -1,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set action concurrency for application "dummy": action concurrency cannot be negative`)
+}
+
+func (s *applicationSuite) TestBlockSetActionConcurrency(c *gc.C) {
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	s.BlockAllChanges(c, "TestBlockSetActionConcurrency")
+	err := s.applicationAPI.SetActionConcurrency(params.ApplicationSetActionConcurrency{
+		ApplicationName: "dummy",
+		Limit:           2,
+	})
+	s.AssertBlocked(c, err, "TestBlockSetActionConcurrency")
+}
+
+func (s *applicationSuite) TestApplicationConfigHistory(c *gc.C) {
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	err := s.applicationAPI.Set(params.ApplicationSet{
+		ApplicationName: "dummy",
+		Options:         map[string]string{"title": "sir"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.applicationAPI.GetConfigHistory(params.Entities{
+		Entities: []params.Entity{{"application-dummy"}, {"application-foo"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	revisions := results.Results[0].Revisions
+	c.Assert(revisions, gc.HasLen, 1)
+	c.Assert(revisions[0].Revision, gc.Equals, 1)
+	c.Assert(revisions[0].Author, gc.Equals, s.AdminUserTag(c).String())
+	c.Assert(revisions[0].Changes, jc.DeepEquals, []params.ConfigChange{
+		{Type: "added", Key: "title", NewValue: "sir"},
+	})
+	c.Assert(results.Results[1].Error, jc.DeepEquals, &params.Error{
+		Code:    params.CodeNotFound,
+		Message: `application "foo" not found`,
+	})
+}
+
+func (s *applicationSuite) TestApplicationRevertConfig(c *gc.C) {
+	app := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	err := app.UpdateConfigSettings(charm.Settings{"title": "sir"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.UpdateConfigSettings(charm.Settings{"title": "madam", "outlook": "positive"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.applicationAPI.RevertConfig(params.ApplicationConfigRevert{
+		ApplicationName: "dummy",
+		Revision:        1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := app.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"title": "sir"})
+
+	history, err := app.ConfigSettingsHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 3)
+	c.Assert(history[2].Author, gc.Equals, s.AdminUserTag(c).String())
+
+	err = s.applicationAPI.RevertConfig(params.ApplicationConfigRevert{
+		ApplicationName: "dummy",
+		Revision:        42,
+	})
+	c.Assert(err, gc.ErrorMatches, "revision 42 not found")
+}
+
+func (s *applicationSuite) TestBlockRevertConfig(c *gc.C) {
+	app := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	err := app.UpdateConfigSettings(charm.Settings{"title": "sir"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.BlockAllChanges(c, "TestBlockRevertConfig")
+	err = s.applicationAPI.RevertConfig(params.ApplicationConfigRevert{
+		ApplicationName: "dummy",
+		Revision:        1,
+	})
+	s.AssertBlocked(c, err, "TestBlockRevertConfig")
+}
+
 func (s *applicationSuite) setupApplicationExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	applicationNames := []string{"dummy-application", "exposed-application"}
@@ -1909,7 +2201,7 @@ var applicationExposeTests = []struct {
 func (s *applicationSuite) assertApplicationExpose(c *gc.C) {
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1924,7 +2216,7 @@ func (s *applicationSuite) assertApplicationExpose(c *gc.C) {
 func (s *applicationSuite) assertApplicationExposeBlocked(c *gc.C, msg string) {
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		s.AssertBlocked(c, err, msg)
 	}
 }
@@ -1982,7 +2274,7 @@ func (s *applicationSuite) TestApplicationUnexpose(c *gc.C) {
 			app.SetExposed()
 		}
 		c.Assert(app.IsExposed(), gc.Equals, t.initial)
-		err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{t.application})
+		err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{ApplicationName: t.application})
 		if t.err == "" {
 			c.Assert(err, jc.ErrorIsNil)
 			app.Refresh()
@@ -2004,7 +2296,7 @@ func (s *applicationSuite) setupApplicationUnexpose(c *gc.C) *state.Application
 }
 
 func (s *applicationSuite) assertApplicationUnexpose(c *gc.C, app *state.Application) {
-	err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{"dummy-application"})
+	err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{ApplicationName: "dummy-application"})
 	c.Assert(err, jc.ErrorIsNil)
 	app.Refresh()
 	c.Assert(app.IsExposed(), gc.Equals, false)
@@ -2013,7 +2305,7 @@ func (s *applicationSuite) assertApplicationUnexpose(c *gc.C, app *state.Applica
 }
 
 func (s *applicationSuite) assertApplicationUnexposeBlocked(c *gc.C, app *state.Application, msg string) {
-	err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{"dummy-application"})
+	err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{ApplicationName: "dummy-application"})
 	s.AssertBlocked(c, err, msg)
 	err = app.Destroy()
 	c.Assert(err, jc.ErrorIsNil)
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
@@ -52,6 +53,7 @@ type BlockChecker interface {
 // details on the methods, see the methods on state.Application with
 // the same names.
 type Application interface {
+	ActionConcurrency() int
 	AddUnit() (*state.Unit, error)
 	AllUnits() ([]Unit, error)
 	Charm() (Charm, bool, error)
@@ -59,17 +61,26 @@ type Application interface {
 	Channel() csparams.Channel
 	ClearExposed() error
 	ConfigSettings() (charm.Settings, error)
+	ConfigSettingsHistory() ([]state.SettingsRevision, error)
 	Constraints() (constraints.Value, error)
 	Destroy() error
+	EgressRules() []network.EgressRule
 	Endpoints() ([]state.Endpoint, error)
+	IngressAddresses() map[string]string
 	IsPrincipal() bool
+	MergeExposeSettings(map[string]state.ExposedEndpoint) error
+	RevertConfigSettings(int, string) error
 	Series() string
+	SetActionConcurrency(int) error
 	SetCharm(state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
+	SetEgressRules([]network.EgressRule) error
 	SetExposed() error
+	SetIngressAddresses(map[string]string) error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
-	UpdateConfigSettings(charm.Settings) error
+	UnsetExposeSettings([]string) error
+	UpdateConfigSettingsAs(string, charm.Settings) error
 }
 
 // Charm defines a subset of the functionality provided by the
@@ -0,0 +1,124 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the bundle
+// facade. For details on the methods, see the methods on state.State
+// with the same names.
+type Backend interface {
+	ModelTag() names.ModelTag
+	AllApplications() ([]Application, error)
+	AllMachines() ([]Machine, error)
+	AllRelations() ([]Relation, error)
+}
+
+// Application defines a subset of the functionality provided by the
+// state.Application type, as required by the bundle facade. For
+// details on the methods, see the methods on state.Application with
+// the same names.
+type Application interface {
+	Name() string
+	CharmURL() (*charm.URL, bool)
+	Series() string
+	IsExposed() bool
+	IsPrincipal() bool
+	ConfigSettings() (charm.Settings, error)
+	Constraints() (constraints.Value, error)
+	EndpointBindings() (map[string]string, error)
+	StorageConstraints() (map[string]state.StorageConstraints, error)
+	AllUnits() ([]Unit, error)
+}
+
+// Unit defines a subset of the functionality provided by the
+// state.Unit type, as required by the bundle facade. For details
+// on the methods, see the methods on state.Unit with the same names.
+type Unit interface {
+	Name() string
+	AssignedMachineId() (string, error)
+}
+
+// Machine defines a subset of the functionality provided by the
+// state.Machine type, as required by the bundle facade. For details
+// on the methods, see the methods on state.Machine with the same names.
+type Machine interface {
+	Id() string
+	Series() string
+	Constraints() (constraints.Value, error)
+}
+
+// Relation defines a subset of the functionality provided by the
+// state.Relation type, as required by the bundle facade. For details
+// on the methods, see the methods on state.Relation with the same names.
+type Relation interface {
+	Endpoints() []state.Endpoint
+}
+
+type stateShim struct {
+	*state.State
+}
+
+// NewStateBackend converts a state.State into a Backend.
+func NewStateBackend(st *state.State) Backend {
+	return stateShim{st}
+}
+
+func (s stateShim) AllApplications() ([]Application, error) {
+	applications, err := s.State.AllApplications()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Application, len(applications))
+	for i, a := range applications {
+		result[i] = stateApplicationShim{a}
+	}
+	return result, nil
+}
+
+func (s stateShim) AllMachines() ([]Machine, error) {
+	machines, err := s.State.AllMachines()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Machine, len(machines))
+	for i, m := range machines {
+		result[i] = m
+	}
+	return result, nil
+}
+
+func (s stateShim) AllRelations() ([]Relation, error) {
+	relations, err := s.State.AllRelations()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Relation, len(relations))
+	for i, r := range relations {
+		result[i] = r
+	}
+	return result, nil
+}
+
+type stateApplicationShim struct {
+	*state.Application
+}
+
+func (a stateApplicationShim) AllUnits() ([]Unit, error) {
+	units, err := a.Application.AllUnits()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Unit, len(units))
+	for i, u := range units {
+		result[i] = u
+	}
+	return result, nil
+}
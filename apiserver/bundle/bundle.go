@@ -5,31 +5,39 @@
 package bundle
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/juju/bundlechanges"
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 )
 
 // NewFacade provides the required signature for facade registration.
-func NewFacade(_ *state.State, _ facade.Resources, auth facade.Authorizer) (Bundle, error) {
-	return NewBundle(auth)
+func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (Bundle, error) {
+	return NewBundle(NewStateBackend(st), auth)
 }
 
 // NewBundle creates and returns a new Bundle API facade.
-func NewBundle(auth facade.Authorizer) (Bundle, error) {
+func NewBundle(backend Backend, auth facade.Authorizer) (Bundle, error) {
 	if !auth.AuthClient() {
 		return nil, common.ErrPerm
 	}
-	return &bundleAPI{}, nil
+	return &bundleAPI{
+		backend:    backend,
+		authorizer: auth,
+	}, nil
 }
 
 // Bundle defines the API endpoint used to retrieve bundle changes.
@@ -37,11 +45,18 @@ type Bundle interface {
 	// GetChanges returns the list of changes required to deploy the given
 	// bundle data.
 	GetChanges(params.BundleChangesParams) (params.BundleChangesResults, error)
+
+	// ExportBundle returns the current model as a bundle YAML document
+	// that can be deployed with "juju deploy".
+	ExportBundle() (params.StringResult, error)
 }
 
 // bundleAPI implements the Bundle interface and is the concrete implementation
 // of the API end point.
-type bundleAPI struct{}
+type bundleAPI struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
 
 // GetChanges returns the list of changes required to deploy the given bundle
 // data. The changes are sorted by requirements, so that they can be applied in
@@ -83,3 +98,226 @@ func (b *bundleAPI) GetChanges(args params.BundleChangesParams) (params.BundleCh
 	}
 	return results, nil
 }
+
+// ExportBundle returns the current model as a bundle YAML document. The
+// bundle describes the model's applications together with their charm
+// URLs, options, constraints, endpoint bindings, storage directives and
+// unit placements, the machines hosting those units, and the relations
+// between the applications.
+func (b *bundleAPI) ExportBundle() (params.StringResult, error) {
+	var result params.StringResult
+	if err := b.checkCanRead(); err != nil {
+		return result, errors.Trace(err)
+	}
+	data, err := b.bundleData()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	bytes, err := yaml.Marshal(data)
+	if err != nil {
+		return result, errors.Annotate(err, "cannot marshal bundle YAML")
+	}
+	result.Result = string(bytes)
+	return result, nil
+}
+
+func (b *bundleAPI) checkCanRead() error {
+	allowed, err := b.authorizer.HasPermission(permission.ReadAccess, b.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// bundleData walks the model and builds the bundle data describing it.
+func (b *bundleAPI) bundleData() (*charm.BundleData, error) {
+	applications, err := b.backend.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(applications) == 0 {
+		return nil, errors.NotFoundf("applications in the model")
+	}
+	data := &charm.BundleData{
+		Applications: make(map[string]*charm.ApplicationSpec, len(applications)),
+		Machines:     make(map[string]*charm.MachineSpec),
+	}
+	// usedMachines holds the ids of the top level machines
+	// that host units of the exported applications.
+	usedMachines := make(map[string]bool)
+	for _, application := range applications {
+		spec, err := applicationSpec(application, usedMachines)
+		if err != nil {
+			return nil, errors.Annotatef(err, "exporting application %q", application.Name())
+		}
+		data.Applications[application.Name()] = spec
+	}
+
+	machines, err := b.backend.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, machine := range machines {
+		if !usedMachines[machine.Id()] {
+			continue
+		}
+		cons, err := machine.Constraints()
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Annotatef(err, "exporting machine %q", machine.Id())
+		}
+		data.Machines[machine.Id()] = &charm.MachineSpec{
+			Series:      machine.Series(),
+			Constraints: cons.String(),
+		}
+	}
+
+	relations, err := b.backend.AllRelations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, relation := range relations {
+		endpoints := relation.Endpoints()
+		// Peer relations are established implicitly when
+		// the application is deployed.
+		if len(endpoints) != 2 {
+			continue
+		}
+		var pair []string
+		for _, ep := range endpoints {
+			// Relations to remote applications cannot be
+			// expressed in a bundle.
+			if _, ok := data.Applications[ep.ApplicationName]; !ok {
+				break
+			}
+			pair = append(pair, ep.ApplicationName+":"+ep.Name)
+		}
+		if len(pair) == 2 {
+			data.Relations = append(data.Relations, pair)
+		}
+	}
+	sort.Sort(relationsByName(data.Relations))
+	return data, nil
+}
+
+// applicationSpec returns the bundle application specification for the
+// given application, recording the top level machines hosting its units
+// in usedMachines.
+func applicationSpec(application Application, usedMachines map[string]bool) (*charm.ApplicationSpec, error) {
+	curl, _ := application.CharmURL()
+	settings, err := application.ConfigSettings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cons, err := application.Constraints()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	bindings, err := application.EndpointBindings()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	storageCons, err := application.StorageConstraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spec := &charm.ApplicationSpec{
+		Charm:       curl.String(),
+		Series:      application.Series(),
+		Expose:      application.IsExposed(),
+		Constraints: cons.String(),
+	}
+	if len(settings) > 0 {
+		spec.Options = make(map[string]interface{}, len(settings))
+		for name, value := range settings {
+			spec.Options[name] = value
+		}
+	}
+	for endpoint, space := range bindings {
+		if space == "" {
+			continue
+		}
+		if spec.EndpointBindings == nil {
+			spec.EndpointBindings = make(map[string]string)
+		}
+		spec.EndpointBindings[endpoint] = space
+	}
+	for name, sc := range storageCons {
+		if spec.Storage == nil {
+			spec.Storage = make(map[string]string)
+		}
+		spec.Storage[name] = storageString(sc)
+	}
+
+	// Subordinate units are placed with their principals,
+	// so there is no placement to record for them.
+	if !application.IsPrincipal() {
+		return spec, nil
+	}
+	units, err := application.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Sort(unitsByNumber(units))
+	spec.NumUnits = len(units)
+	for _, unit := range units {
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "getting machine for unit %q", unit.Name())
+		}
+		placement := machineId
+		if containerType := state.ContainerTypeFromId(machineId); containerType != "" {
+			machineId = state.TopParentId(machineId)
+			placement = fmt.Sprintf("%s:%s", containerType, machineId)
+		}
+		usedMachines[machineId] = true
+		spec.To = append(spec.To, placement)
+	}
+	return spec, nil
+}
+
+// storageString returns the bundle storage directive for the
+// constraints, omitting any fields that are not set.
+func storageString(cons state.StorageConstraints) string {
+	var parts []string
+	if cons.Pool != "" {
+		parts = append(parts, cons.Pool)
+	}
+	if cons.Count > 0 {
+		parts = append(parts, fmt.Sprint(cons.Count))
+	}
+	if cons.Size > 0 {
+		parts = append(parts, fmt.Sprintf("%dM", cons.Size))
+	}
+	return strings.Join(parts, ",")
+}
+
+// unitsByNumber sorts units by their unit number.
+type unitsByNumber []Unit
+
+func (u unitsByNumber) Len() int      { return len(u) }
+func (u unitsByNumber) Swap(i, j int) { u[i], u[j] = u[j], u[i] }
+func (u unitsByNumber) Less(i, j int) bool {
+	return unitNumber(u[i].Name()) < unitNumber(u[j].Name())
+}
+
+func unitNumber(name string) int {
+	n, _ := strconv.Atoi(name[strings.LastIndex(name, "/")+1:])
+	return n
+}
+
+// relationsByName sorts relation endpoint pairs lexically.
+type relationsByName [][]string
+
+func (r relationsByName) Len() int      { return len(r) }
+func (r relationsByName) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r relationsByName) Less(i, j int) bool {
+	if r[i][0] != r[j][0] {
+		return r[i][0] < r[j][0]
+	}
+	return r[i][1] < r[j][1]
+}
@@ -4,29 +4,36 @@
 package bundle_test
 
 import (
+	"strings"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/bundle"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
 
 type bundleSuite struct {
 	coretesting.BaseSuite
-	facade bundle.Bundle
+	backend *mockBackend
+	facade  bundle.Bundle
 }
 
 var _ = gc.Suite(&bundleSuite{})
 
 func (s *bundleSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{}
 	auth := apiservertesting.FakeAuthorizer{
-		Tag: names.NewUserTag("who"),
+		Tag: names.NewUserTag("read"),
 	}
-	facade, err := bundle.NewBundle(auth)
+	facade, err := bundle.NewBundle(s.backend, auth)
 	c.Assert(err, jc.ErrorIsNil)
 	s.facade = facade
 }
@@ -198,3 +205,119 @@ func (s *bundleSuite) TestGetChangesBundleEndpointBindingsSuccess(c *gc.C) {
 		}
 	}
 }
+
+func (s *bundleSuite) TestExportBundlePermissionDenied(c *gc.C) {
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("who"),
+	}
+	facade, err := bundle.NewBundle(s.backend, auth)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = facade.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *bundleSuite) TestExportBundleNoApplications(c *gc.C) {
+	_, err := s.facade.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "applications in the model not found")
+}
+
+func (s *bundleSuite) TestExportBundle(c *gc.C) {
+	s.backend.applications = []bundle.Application{
+		&mockApplication{
+			name:     "mysql",
+			curl:     charm.MustParseURL("cs:xenial/mysql-57"),
+			series:   "xenial",
+			settings: charm.Settings{"dataset-size": "60%"},
+			cons:     constraints.MustParse("mem=4G"),
+			bindings: map[string]string{"db": "internal", "juju-info": ""},
+			storage: map[string]state.StorageConstraints{
+				"data": {Pool: "ebs", Count: 1, Size: 10240},
+				"logs": {Count: 1, Size: 1024},
+			},
+			units: []bundle.Unit{
+				&mockUnit{name: "mysql/0", machineId: "0"},
+			},
+		},
+		&mockApplication{
+			name:    "wordpress",
+			curl:    charm.MustParseURL("cs:xenial/wordpress-4"),
+			series:  "xenial",
+			exposed: true,
+			units: []bundle.Unit{
+				&mockUnit{name: "wordpress/10", machineId: "1"},
+				&mockUnit{name: "wordpress/2", machineId: "0/lxd/0"},
+				&mockUnit{name: "wordpress/3"},
+			},
+		},
+		&mockApplication{
+			name:        "telegraf",
+			curl:        charm.MustParseURL("cs:telegraf-2"),
+			series:      "xenial",
+			subordinate: true,
+			units: []bundle.Unit{
+				&mockUnit{name: "telegraf/0", machineId: "0"},
+			},
+		},
+	}
+	s.backend.machines = []bundle.Machine{
+		&mockMachine{id: "0", series: "xenial", cons: constraints.MustParse("cores=2")},
+		&mockMachine{id: "0/lxd/0", series: "xenial"},
+		&mockMachine{id: "1", series: "trusty"},
+		&mockMachine{id: "2", series: "xenial"},
+	}
+	s.backend.relations = []bundle.Relation{
+		&mockRelation{endpoints: []state.Endpoint{
+			{ApplicationName: "wordpress", Relation: charm.Relation{Name: "db"}},
+			{ApplicationName: "mysql", Relation: charm.Relation{Name: "db"}},
+		}},
+		&mockRelation{endpoints: []state.Endpoint{
+			{ApplicationName: "mysql", Relation: charm.Relation{Name: "cluster"}},
+		}},
+		&mockRelation{endpoints: []state.Endpoint{
+			{ApplicationName: "telegraf", Relation: charm.Relation{Name: "juju-info"}},
+			{ApplicationName: "mysql", Relation: charm.Relation{Name: "juju-info"}},
+		}},
+		&mockRelation{endpoints: []state.Endpoint{
+			{ApplicationName: "wordpress", Relation: charm.Relation{Name: "cache"}},
+			{ApplicationName: "memcached", Relation: charm.Relation{Name: "cache"}},
+		}},
+	}
+
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	data, err := charm.ReadBundleData(strings.NewReader(result.Result))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.Applications, jc.DeepEquals, map[string]*charm.ApplicationSpec{
+		"mysql": {
+			Charm:            "cs:xenial/mysql-57",
+			Series:           "xenial",
+			NumUnits:         1,
+			To:               []string{"0"},
+			Options:          map[string]interface{}{"dataset-size": "60%"},
+			Constraints:      "mem=4096M",
+			Storage:          map[string]string{"data": "ebs,1,10240M", "logs": "1,1024M"},
+			EndpointBindings: map[string]string{"db": "internal"},
+		},
+		"wordpress": {
+			Charm:    "cs:xenial/wordpress-4",
+			Series:   "xenial",
+			NumUnits: 3,
+			To:       []string{"lxd:0", "1"},
+			Expose:   true,
+		},
+		"telegraf": {
+			Charm:  "cs:telegraf-2",
+			Series: "xenial",
+		},
+	})
+	c.Assert(data.Machines, jc.DeepEquals, map[string]*charm.MachineSpec{
+		"0": {Series: "xenial", Constraints: "cores=2"},
+		"1": {Series: "trusty"},
+	})
+	c.Assert(data.Relations, jc.DeepEquals, [][]string{
+		{"telegraf:juju-info", "mysql:juju-info"},
+		{"wordpress:db", "mysql:db"},
+	})
+}
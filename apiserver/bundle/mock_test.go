@@ -0,0 +1,136 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/bundle"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockBackend struct {
+	bundle.Backend
+
+	applications []bundle.Application
+	machines     []bundle.Machine
+	relations    []bundle.Relation
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) AllApplications() ([]bundle.Application, error) {
+	return b.applications, nil
+}
+
+func (b *mockBackend) AllMachines() ([]bundle.Machine, error) {
+	return b.machines, nil
+}
+
+func (b *mockBackend) AllRelations() ([]bundle.Relation, error) {
+	return b.relations, nil
+}
+
+type mockApplication struct {
+	bundle.Application
+
+	name        string
+	curl        *charm.URL
+	series      string
+	exposed     bool
+	subordinate bool
+	settings    charm.Settings
+	cons        constraints.Value
+	bindings    map[string]string
+	storage     map[string]state.StorageConstraints
+	units       []bundle.Unit
+}
+
+func (a *mockApplication) Name() string {
+	return a.name
+}
+
+func (a *mockApplication) CharmURL() (*charm.URL, bool) {
+	return a.curl, false
+}
+
+func (a *mockApplication) Series() string {
+	return a.series
+}
+
+func (a *mockApplication) IsExposed() bool {
+	return a.exposed
+}
+
+func (a *mockApplication) IsPrincipal() bool {
+	return !a.subordinate
+}
+
+func (a *mockApplication) ConfigSettings() (charm.Settings, error) {
+	return a.settings, nil
+}
+
+func (a *mockApplication) Constraints() (constraints.Value, error) {
+	return a.cons, nil
+}
+
+func (a *mockApplication) EndpointBindings() (map[string]string, error) {
+	return a.bindings, nil
+}
+
+func (a *mockApplication) StorageConstraints() (map[string]state.StorageConstraints, error) {
+	return a.storage, nil
+}
+
+func (a *mockApplication) AllUnits() ([]bundle.Unit, error) {
+	return a.units, nil
+}
+
+type mockUnit struct {
+	name      string
+	machineId string
+}
+
+func (u *mockUnit) Name() string {
+	return u.name
+}
+
+func (u *mockUnit) AssignedMachineId() (string, error) {
+	if u.machineId == "" {
+		return "", errors.NotAssignedf("unit %q", u.name)
+	}
+	return u.machineId, nil
+}
+
+type mockMachine struct {
+	id     string
+	series string
+	cons   constraints.Value
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) Series() string {
+	return m.series
+}
+
+func (m *mockMachine) Constraints() (constraints.Value, error) {
+	return m.cons, nil
+}
+
+type mockRelation struct {
+	endpoints []state.Endpoint
+}
+
+func (r *mockRelation) Endpoints() []state.Endpoint {
+	return r.endpoints
+}
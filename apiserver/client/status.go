@@ -817,6 +817,14 @@ func (context *statusContext) processApplication(application *state.Application)
 		Exposed: application.IsExposed(),
 		Life:    processLife(application),
 	}
+	for endpoint, settings := range application.ExposedEndpoints() {
+		if processedStatus.ExposedEndpoints == nil {
+			processedStatus.ExposedEndpoints = make(map[string]params.ExposedEndpoint)
+		}
+		processedStatus.ExposedEndpoints[endpoint] = params.ExposedEndpoint{
+			ExposeToCIDRs: settings.ExposeToCIDRs,
+		}
+	}
 
 	if latestCharm, ok := context.latestCharms[*applicationCharm.URL().WithRevision(-1)]; ok && latestCharm != nil {
 		if latestCharm.Revision() > applicationCharm.URL().Revision {
@@ -131,6 +131,30 @@ func FinishActions(args params.ActionExecutionResults, actionFn func(string) (st
 	return results
 }
 
+// LogActionsMessages records the progress messages logged by running
+// Actions.
+// It's a helper function currently used by the uniter.
+// It needs an actionFn that can fetch an action from state using it's id that's usually created by AuthAndActionFromTagFn
+func LogActionsMessages(args params.ActionMessageParams, actionFn func(string) (state.Action, error)) params.ErrorResults {
+	results := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Messages))}
+
+	for i, arg := range args.Messages {
+		action, err := actionFn(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = ServerError(err)
+			continue
+		}
+
+		err = action.Log(arg.Value)
+		if err != nil {
+			results.Results[i].Error = ServerError(err)
+			continue
+		}
+	}
+
+	return results
+}
+
 // Actions returns the Actions by Tags passed in and ensures that the receiver asking for
 // them is the same one that has the action.
 // It's a helper function currently used by the uniter and by machineactions.
@@ -153,6 +177,7 @@ func Actions(args params.Entities, actionFn func(string) (state.Action, error))
 		results.Results[i].Action = &params.Action{
 			Name:       action.Name(),
 			Parameters: action.Parameters(),
+			Timeout:    action.Timeout(),
 		}
 	}
 
@@ -239,12 +264,30 @@ func MakeActionResult(actionReceiverTag names.Tag, action state.Action) params.A
 			Tag:        action.ActionTag().String(),
 			Name:       action.Name(),
 			Parameters: action.Parameters(),
+			Timeout:    action.Timeout(),
 		},
 		Status:    string(action.Status()),
 		Message:   message,
 		Output:    output,
+		Log:       MakeActionMessages(action.Messages()),
 		Enqueued:  action.Enqueued(),
 		Started:   action.Started(),
 		Completed: action.Completed(),
 	}
 }
+
+// MakeActionMessages converts the progress messages of a state.Action
+// to params.ActionMessages.
+func MakeActionMessages(messages []state.ActionMessage) []params.ActionMessage {
+	if len(messages) == 0 {
+		return nil
+	}
+	result := make([]params.ActionMessage, len(messages))
+	for i, message := range messages {
+		result[i] = params.ActionMessage{
+			Timestamp: message.Timestamp(),
+			Message:   message.Message(),
+		}
+	}
+	return result
+}
@@ -6,6 +6,7 @@ package common
 import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
@@ -19,6 +20,7 @@ type AddressAndCertGetter interface {
 	ModelUUID() string
 	APIHostPorts() ([][]network.HostPort, error)
 	WatchAPIHostPorts() state.NotifyWatcher
+	ModelConfig() (*config.Config, error)
 }
 
 // APIAddresser implements the APIAddresses method
@@ -60,7 +62,11 @@ func (api *APIAddresser) WatchAPIHostPorts() (params.NotifyWatchResult, error) {
 
 // APIAddresses returns the list of addresses used to connect to the API.
 func (api *APIAddresser) APIAddresses() (params.StringsResult, error) {
-	addrs, err := apiAddresses(api.getter)
+	preferred, err := preferredAddressType(api.getter)
+	if err != nil {
+		return params.StringsResult{}, err
+	}
+	addrs, err := apiAddresses(api.getter, preferred)
 	if err != nil {
 		return params.StringsResult{}, err
 	}
@@ -69,14 +75,16 @@ func (api *APIAddresser) APIAddresses() (params.StringsResult, error) {
 	}, nil
 }
 
-func apiAddresses(getter APIHostPortsGetter) ([]string, error) {
+// apiAddresses returns the API server addresses, ordered for use by
+// the agents of a model that prefers addresses of the given type.
+func apiAddresses(getter APIHostPortsGetter, preferred network.AddressType) ([]string, error) {
 	apiHostPorts, err := getter.APIHostPorts()
 	if err != nil {
 		return nil, err
 	}
 	var addrs = make([]string, 0, len(apiHostPorts))
 	for _, hostPorts := range apiHostPorts {
-		ordered := network.PrioritizeInternalHostPorts(hostPorts, false)
+		ordered := network.PrioritizeInternalHostPortsPreferring(hostPorts, false, preferred)
 		for _, addr := range ordered {
 			if addr != "" {
 				addrs = append(addrs, addr)
@@ -86,6 +94,25 @@ func apiAddresses(getter APIHostPortsGetter) ([]string, error) {
 	return addrs, nil
 }
 
+// modelConfigGetter is an interface providing the ModelConfig method.
+type modelConfigGetter interface {
+	ModelConfig() (*config.Config, error)
+}
+
+// preferredAddressType returns the address type preferred by the
+// model's config. Addresses served to a model's agents follow the
+// model's preference rather than that of the controller's own agent.
+func preferredAddressType(getter modelConfigGetter) (network.AddressType, error) {
+	cfg, err := getter.ModelConfig()
+	if err != nil {
+		return "", err
+	}
+	if cfg.PreferredAddressFamily() == string(network.IPv6Address) {
+		return network.IPv6Address, nil
+	}
+	return network.IPv4Address, nil
+}
+
 // CACert returns the certificate used to validate the state connection.
 func (a *APIAddresser) CACert() params.BytesResult {
 	return params.BytesResult{
@@ -8,8 +8,10 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type stateAddresserSuite struct {
@@ -88,6 +90,24 @@ func (s *apiAddresserSuite) TestAPIAddressesPrivateFirst(c *gc.C) {
 	})
 }
 
+func (s *apiAddresserSuite) TestAPIAddressesPreferIPv6(c *gc.C) {
+	cfg, err := config.New(config.UseDefaults, coretesting.FakeConfig().Merge(coretesting.Attrs{
+		config.PreferredAddressFamilyKey: "ipv6",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.config = cfg
+	s.fake.hostPorts = [][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.2.1", "fc00::1"),
+	}
+
+	// The model's preference is used, regardless of the preference
+	// of the process serving the request.
+	c.Assert(network.PreferIPv6(), jc.IsFalse)
+	result, err := s.addresser.APIAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Result, gc.DeepEquals, []string{"[fc00::1]:17070", "10.0.2.1:17070"})
+}
+
 func (s *apiAddresserSuite) TestCACert(c *gc.C) {
 	result := s.addresser.CACert()
 	c.Assert(string(result.Result), gc.Equals, "a cert")
@@ -102,6 +122,7 @@ var _ common.AddressAndCertGetter = fakeAddresses{}
 
 type fakeAddresses struct {
 	hostPorts [][]network.HostPort
+	config    *config.Config
 }
 
 func (fakeAddresses) Addresses() ([]string, error) {
@@ -123,3 +144,10 @@ func (f fakeAddresses) APIHostPorts() ([][]network.HostPort, error) {
 func (fakeAddresses) WatchAPIHostPorts() state.NotifyWatcher {
 	panic("should never be called")
 }
+
+func (f fakeAddresses) ModelConfig() (*config.Config, error) {
+	if f.config == nil {
+		return config.New(config.UseDefaults, coretesting.FakeConfig())
+	}
+	return f.config, nil
+}
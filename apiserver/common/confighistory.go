@@ -0,0 +1,51 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ConfigRevisions converts the recorded history of a settings node into
+// its API representation. Changes to any of the omitted keys are left
+// out of the result.
+func ConfigRevisions(history []state.SettingsRevision, omitKeys ...string) []params.ConfigRevision {
+	omit := make(map[string]bool)
+	for _, key := range omitKeys {
+		omit[key] = true
+	}
+	result := make([]params.ConfigRevision, len(history))
+	for i, rev := range history {
+		revision := params.ConfigRevision{
+			Revision:  rev.Revision,
+			Author:    rev.Author,
+			Timestamp: rev.Timestamp,
+		}
+		for _, change := range rev.Changes {
+			if omit[change.Key] {
+				continue
+			}
+			revision.Changes = append(revision.Changes, params.ConfigChange{
+				Type:     configChangeType(change.Type),
+				Key:      change.Key,
+				OldValue: change.OldValue,
+				NewValue: change.NewValue,
+			})
+		}
+		result[i] = revision
+	}
+	return result
+}
+
+func configChangeType(t int) string {
+	switch t {
+	case state.ItemAdded:
+		return "added"
+	case state.ItemDeleted:
+		return "deleted"
+	default:
+		return "modified"
+	}
+}
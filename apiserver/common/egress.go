@@ -0,0 +1,79 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+// EgressRulesBackend provides the controller addresses that
+// machines with restricted outgoing traffic must still reach.
+type EgressRulesBackend interface {
+	APIHostPorts() ([][]network.HostPort, error)
+	WatchAPIHostPorts() state.NotifyWatcher
+}
+
+// EgressRulesMachine provides the egress rules of a machine.
+// It is implemented by *state.Machine.
+type EgressRulesMachine interface {
+	EgressRules() ([]network.EgressRule, error)
+	WatchEgressRules() state.NotifyWatcher
+}
+
+// MachineEgressRules returns the egress rules to be enforced on the
+// machine. If outgoing traffic from the machine is restricted, rules
+// allowing access to the controllers' API servers are included; if
+// it is unrestricted, nil is returned.
+func MachineEgressRules(backend EgressRulesBackend, m EgressRulesMachine) ([]network.EgressRule, error) {
+	rules, err := m.EgressRules()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	servers, err := backend.APIHostPorts()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	seen := set.NewStrings()
+	for _, rule := range rules {
+		seen.Add(rule.String())
+	}
+	for _, hostPorts := range servers {
+		for _, hp := range hostPorts {
+			ip := net.ParseIP(hp.Value)
+			if ip == nil {
+				// Host names cannot be used in rules.
+				continue
+			}
+			cidr := ip.String() + "/32"
+			if ip.To4() == nil {
+				cidr = ip.String() + "/128"
+			}
+			rule, err := network.NewEgressRule("tcp", hp.Port, hp.Port, cidr)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if key := rule.String(); !seen.Contains(key) {
+				seen.Add(key)
+				rules = append(rules, rule)
+			}
+		}
+	}
+	network.SortEgressRules(rules)
+	return rules, nil
+}
+
+// WatchMachineEgressRules returns a NotifyWatcher that notifies of
+// changes to the egress rules returned by MachineEgressRules.
+func WatchMachineEgressRules(backend EgressRulesBackend, m EgressRulesMachine) state.NotifyWatcher {
+	return NewMultiNotifyWatcher(m.WatchEgressRules(), backend.WatchAPIHostPorts())
+}
@@ -0,0 +1,60 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+type egressSuite struct{}
+
+var _ = gc.Suite(&egressSuite{})
+
+type fakeEgressMachine struct {
+	rules []network.EgressRule
+}
+
+func (m fakeEgressMachine) EgressRules() ([]network.EgressRule, error) {
+	return m.rules, nil
+}
+
+func (fakeEgressMachine) WatchEgressRules() state.NotifyWatcher {
+	panic("should never be called")
+}
+
+func (s *egressSuite) TestMachineEgressRulesUnrestricted(c *gc.C) {
+	backend := fakeAddresses{
+		hostPorts: [][]network.HostPort{
+			network.NewHostPorts(17070, "10.0.0.1"),
+		},
+	}
+	rules, err := common.MachineEgressRules(backend, fakeEgressMachine{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.IsNil)
+}
+
+func (s *egressSuite) TestMachineEgressRulesAddsControllers(c *gc.C) {
+	backend := fakeAddresses{
+		hostPorts: [][]network.HostPort{
+			network.NewHostPorts(17070, "10.0.0.1", "2001:db8::1", "controller.example.com"),
+			network.NewHostPorts(17070, "10.0.0.2", "10.0.0.1"),
+		},
+	}
+	m := fakeEgressMachine{rules: []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "192.168.1.0/24"),
+	}}
+	rules, err := common.MachineEgressRules(backend, m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "192.168.1.0/24"),
+		network.MustNewEgressRule("tcp", 17070, 17070, "10.0.0.1/32"),
+		network.MustNewEgressRule("tcp", 17070, 17070, "10.0.0.2/32"),
+		network.MustNewEgressRule("tcp", 17070, 17070, "2001:db8::1/128"),
+	})
+}
@@ -100,21 +100,56 @@ func volumeStorageAttachmentInfo(
 	} else if err != nil {
 		return nil, errors.Annotate(err, "getting volume")
 	}
+	volumeInfo, volumeAttachmentInfo, blockDevice, err := volumeBlockDevice(st, volume, machineTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if blockDevice == nil {
+		// We must not say that a block-kind storage attachment is
+		// provisioned until its block device has shown up on the
+		// machine, otherwise the charm may attempt to use it and
+		// fail.
+		return nil, errors.NotProvisionedf("%v", names.ReadableString(storageTag))
+	}
+	devicePath, err := volumeAttachmentDevicePath(
+		volumeInfo,
+		volumeAttachmentInfo,
+		*blockDevice,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.StorageAttachmentInfo{
+		Kind:     storage.StorageKindBlock,
+		Location: devicePath,
+		Size:     blockDevice.Size,
+	}, nil
+}
+
+// volumeBlockDevice returns the volume info and volume attachment
+// info for the specified volume's attachment to the machine, along
+// with the block device published for it. If the block device has not
+// yet shown up on the machine, the returned block device will be nil.
+func volumeBlockDevice(
+	st StorageInterface,
+	volume state.Volume,
+	machineTag names.MachineTag,
+) (state.VolumeInfo, state.VolumeAttachmentInfo, *state.BlockDeviceInfo, error) {
 	volumeInfo, err := volume.Info()
 	if err != nil {
-		return nil, errors.Annotate(err, "getting volume info")
+		return state.VolumeInfo{}, state.VolumeAttachmentInfo{}, nil, errors.Annotate(err, "getting volume info")
 	}
 	volumeAttachment, err := st.VolumeAttachment(machineTag, volume.VolumeTag())
 	if err != nil {
-		return nil, errors.Annotate(err, "getting volume attachment")
+		return state.VolumeInfo{}, state.VolumeAttachmentInfo{}, nil, errors.Annotate(err, "getting volume attachment")
 	}
 	volumeAttachmentInfo, err := volumeAttachment.Info()
 	if err != nil {
-		return nil, errors.Annotate(err, "getting volume attachment info")
+		return state.VolumeInfo{}, state.VolumeAttachmentInfo{}, nil, errors.Annotate(err, "getting volume attachment info")
 	}
 	blockDevices, err := st.BlockDevices(machineTag)
 	if err != nil {
-		return nil, errors.Annotate(err, "getting block devices")
+		return state.VolumeInfo{}, state.VolumeAttachmentInfo{}, nil, errors.Annotate(err, "getting block devices")
 	}
 	blockDevice, ok := MatchingBlockDevice(
 		blockDevices,
@@ -122,24 +157,9 @@ func volumeStorageAttachmentInfo(
 		volumeAttachmentInfo,
 	)
 	if !ok {
-		// We must not say that a block-kind storage attachment is
-		// provisioned until its block device has shown up on the
-		// machine, otherwise the charm may attempt to use it and
-		// fail.
-		return nil, errors.NotProvisionedf("%v", names.ReadableString(storageTag))
-	}
-	devicePath, err := volumeAttachmentDevicePath(
-		volumeInfo,
-		volumeAttachmentInfo,
-		*blockDevice,
-	)
-	if err != nil {
-		return nil, errors.Trace(err)
+		blockDevice = nil
 	}
-	return &storage.StorageAttachmentInfo{
-		storage.StorageKindBlock,
-		devicePath,
-	}, nil
+	return volumeInfo, volumeAttachmentInfo, blockDevice, nil
 }
 
 func filesystemStorageAttachmentInfo(
@@ -166,9 +186,34 @@ func filesystemStorageAttachmentInfo(
 	if err != nil {
 		return nil, errors.Annotate(err, "getting filesystem attachment info")
 	}
+	filesystemInfo, err := filesystem.Info()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting filesystem info")
+	}
+	size := filesystemInfo.Size
+	if _, err := filesystem.Volume(); err == nil {
+		// The filesystem is backed by a volume, which may have been
+		// resized since the filesystem was created. Report the size
+		// of the volume's block device, so the charm can tell that
+		// the filesystem may be grown.
+		volume, err := st.StorageInstanceVolume(storageTag)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting backing volume")
+		}
+		_, _, blockDevice, err := volumeBlockDevice(st, volume, machineTag)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting backing volume block device")
+		}
+		if blockDevice != nil && blockDevice.Size > size {
+			size = blockDevice.Size
+		}
+	} else if errors.Cause(err) != state.ErrNoBackingVolume {
+		return nil, errors.Annotate(err, "getting backing volume")
+	}
 	return &storage.StorageAttachmentInfo{
-		storage.StorageKindFilesystem,
-		filesystemAttachmentInfo.MountPoint,
+		Kind:     storage.StorageKindFilesystem,
+		Location: filesystemAttachmentInfo.MountPoint,
+		Size:     size,
 	}, nil
 }
 
@@ -214,6 +259,12 @@ func WatchStorageAttachment(
 		watchers = []state.NotifyWatcher{
 			st.WatchFilesystemAttachment(machineTag, filesystem.FilesystemTag()),
 		}
+		if _, err := filesystem.Volume(); err == nil {
+			// The size reported for the storage attachment is
+			// that of the backing volume's block device, so we
+			// must watch the machine's block devices too.
+			watchers = append(watchers, st.WatchBlockDevices(machineTag))
+		}
 	default:
 		return nil, errors.Errorf("invalid storage kind %v", storageInstance.Kind())
 	}
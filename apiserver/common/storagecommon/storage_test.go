@@ -96,6 +96,20 @@ func (s *storageAttachmentInfoSuite) TestStorageAttachmentInfoMissingBlockDevice
 	s.st.CheckCallNames(c, "StorageInstance", "StorageInstanceVolume", "VolumeAttachment", "BlockDevices")
 }
 
+func (s *storageAttachmentInfoSuite) TestStorageAttachmentInfoSize(c *gc.C) {
+	// The size reported is that of the block device as seen by the
+	// machine, which changes when the volume is resized.
+	s.volumeAttachment.info.DeviceName = "sda"
+	s.blockDevices[0].Size = 2048
+	info, err := storagecommon.StorageAttachmentInfo(s.st, s.storageAttachment, s.machineTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
+		Kind:     storage.StorageKindBlock,
+		Location: "/dev/sda",
+		Size:     2048,
+	})
+}
+
 func (s *storageAttachmentInfoSuite) TestStorageAttachmentInfoPersistentDeviceLink(c *gc.C) {
 	s.volumeAttachment.info.DeviceLink = "/dev/disk/by-id/verbatim"
 	info, err := storagecommon.StorageAttachmentInfo(s.st, s.storageAttachment, s.machineTag)
@@ -345,7 +345,14 @@ func NewToolsURLGetter(modelUUID string, a APIHostPortsGetter) *toolsURLGetter {
 }
 
 func (t *toolsURLGetter) ToolsURLs(v version.Binary) ([]string, error) {
-	addrs, err := apiAddresses(t.apiHostPortsGetter)
+	preferred := network.IPv4Address
+	if getter, ok := t.apiHostPortsGetter.(modelConfigGetter); ok {
+		var err error
+		if preferred, err = preferredAddressType(getter); err != nil {
+			return nil, err
+		}
+	}
+	addrs, err := apiAddresses(t.apiHostPortsGetter, preferred)
 	if err != nil {
 		return nil, err
 	}
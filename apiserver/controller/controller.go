@@ -39,6 +39,8 @@ type Controller interface {
 	HostedModelConfigs() (params.HostedModelConfigsResults, error)
 	GetControllerAccess(params.Entities) (params.UserAccessResults, error)
 	ControllerConfig() (params.ControllerConfigResult, error)
+	ControllerConfigSet(params.ControllerConfigSet) error
+	BackupStatus() (params.BackupStatus, error)
 	ListBlockedModels() (params.ModelBlockInfoList, error)
 	RemoveBlocks(args params.RemoveBlocksArgs) error
 	WatchAllModels() (params.AllWatcherId, error)
@@ -444,6 +446,42 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 	return mig.Id(), nil
 }
 
+// ControllerConfigSet changes the value of the specified controller
+// configuration attributes. Only controller administrators may change
+// the configuration, and only attributes that may be changed at runtime
+// are accepted.
+func (s *ControllerAPI) ControllerConfigSet(args params.ControllerConfigSet) error {
+	if err := s.checkHasAdmin(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.state.UpdateControllerConfig(args.Config, nil); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// BackupStatus returns the outcome of the most recent scheduled
+// controller backups. Only controller administrators may see it.
+func (s *ControllerAPI) BackupStatus() (params.BackupStatus, error) {
+	var result params.BackupStatus
+	if err := s.checkHasAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	status, err := s.state.BackupStatus()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !status.LastSuccess.IsZero() {
+		result.LastSuccess = &status.LastSuccess
+		result.LastSuccessID = status.LastSuccessID
+	}
+	if !status.LastFailure.IsZero() {
+		result.LastFailure = &status.LastFailure
+		result.LastFailureError = status.LastFailureError
+	}
+	return result, nil
+}
+
 // ModifyControllerAccess changes the model access granted to users.
 func (c *ControllerAPI) ModifyControllerAccess(args params.ModifyControllerAccessRequest) (params.ErrorResults, error) {
 	result := params.ErrorResults{
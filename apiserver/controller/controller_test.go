@@ -295,6 +295,86 @@ func (s *controllerSuite) TestControllerConfigFromNonController(c *gc.C) {
 	c.Assert(cfg.Config["api-port"], gc.Equals, cfgFromDB.APIPort())
 }
 
+func (s *controllerSuite) TestControllerConfigSet(c *gc.C) {
+	err := s.controller.ControllerConfigSet(params.ControllerConfigSet{
+		Config: map[string]interface{}{
+			"max-logs-age":     "12h",
+			"auditing-enabled": true,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MaxLogsAge(), gc.Equals, 12*time.Hour)
+	c.Assert(cfg.AuditingEnabled(), jc.IsTrue)
+}
+
+func (s *controllerSuite) TestControllerConfigSetImmutableAttribute(c *gc.C) {
+	err := s.controller.ControllerConfigSet(params.ControllerConfigSet{
+		Config: map[string]interface{}{"api-port": 1234},
+	})
+	c.Assert(err, gc.ErrorMatches, `can't change "api-port" after bootstrap`)
+}
+
+func (s *controllerSuite) TestControllerConfigSetRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.NewControllerAPI(
+		facadetest.Context{
+			State_:     s.State,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = endpoint.ControllerConfigSet(params.ControllerConfigSet{
+		Config: map[string]interface{}{"max-logs-age": "12h"},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerSuite) TestBackupStatus(c *gc.C) {
+	result, err := s.controller.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.BackupStatus{})
+
+	success := time.Date(2017, 3, 15, 3, 0, 0, 0, time.UTC)
+	err = s.State.RecordBackupSuccess("backup-1", success)
+	c.Assert(err, jc.ErrorIsNil)
+	failure := success.Add(24 * time.Hour)
+	err = s.State.RecordBackupFailure("disk full", failure)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err = s.controller.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.BackupStatus{
+		LastSuccess:      &success,
+		LastSuccessID:    "backup-1",
+		LastFailure:      &failure,
+		LastFailureError: "disk full",
+	})
+}
+
+func (s *controllerSuite) TestBackupStatusRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.NewControllerAPI(
+		facadetest.Context{
+			State_:     s.State,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.BackupStatus()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *controllerSuite) TestRemoveBlocks(c *gc.C) {
 	st := s.Factory.MakeModel(c, &factory.ModelParams{
 		Name: "test"})
@@ -7,6 +7,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strconv"
 	"syscall"
 	"time"
@@ -67,6 +68,11 @@ func newDebugLogHandler(
 //   replay -> string - one of [true, false], if true, start the file from the start
 //   noTail -> string - one of [true, false], if true, existing logs are sent back,
 //      - but the command does not wait for new ones.
+//   startTime -> string - only send lines logged at or after this RFC3339 time
+//   endTime -> string - only send lines logged at or before this RFC3339 time
+//   message -> string - a regular expression that the message must match
+//   includeLabel -> []string - lists labels to include in the response
+//   excludeLabel -> []string - lists labels to exclude from the response
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	handler := func(conn *websocket.Conn) {
 		socket := &debugLogSocketImpl{conn}
@@ -145,6 +151,7 @@ func (s *debugLogSocketImpl) sendLogRecord(record *params.LogMessage) error {
 // debugLogParams contains the parsed debuglog API request parameters.
 type debugLogParams struct {
 	startTime     time.Time
+	endTime       time.Time
 	maxLines      uint
 	fromTheStart  bool
 	noTail        bool
@@ -154,6 +161,9 @@ type debugLogParams struct {
 	excludeEntity []string
 	includeModule []string
 	excludeModule []string
+	includeLabel  []string
+	excludeLabel  []string
+	message       string
 }
 
 func readDebugLogParams(queryMap url.Values) (*debugLogParams, error) {
@@ -209,10 +219,30 @@ func readDebugLogParams(queryMap url.Values) (*debugLogParams, error) {
 		params.startTime = startTime
 	}
 
+	if value := queryMap.Get("endTime"); value != "" {
+		endTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, errors.Errorf("end time %q is not a valid time in RFC3339 format", value)
+		}
+		if endTime.Before(params.startTime) {
+			return nil, errors.Errorf("end time %q is before start time", value)
+		}
+		params.endTime = endTime
+	}
+
+	if value := queryMap.Get("message"); value != "" {
+		if _, err := regexp.Compile(value); err != nil {
+			return nil, errors.Errorf("message value %q is not a valid regular expression", value)
+		}
+		params.message = value
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
 	params.excludeModule = queryMap["excludeModule"]
+	params.includeLabel = queryMap["includeLabel"]
+	params.excludeLabel = queryMap["excludeLabel"]
 
 	return params, nil
 }
@@ -59,11 +59,15 @@ func makeLogTailerParams(reqParams *debugLogParams) *state.LogTailerParams {
 		MinLevel:      reqParams.filterLevel,
 		NoTail:        reqParams.noTail,
 		StartTime:     reqParams.startTime,
+		EndTime:       reqParams.endTime,
 		InitialLines:  int(reqParams.backlog),
 		IncludeEntity: reqParams.includeEntity,
 		ExcludeEntity: reqParams.excludeEntity,
 		IncludeModule: reqParams.includeModule,
 		ExcludeModule: reqParams.excludeModule,
+		IncludeLabel:  reqParams.includeLabel,
+		ExcludeLabel:  reqParams.excludeLabel,
+		Message:       reqParams.message,
 	}
 	if reqParams.fromTheStart {
 		params.InitialLines = 0
@@ -79,6 +83,7 @@ func formatLogRecord(r *state.LogRecord) *params.LogMessage {
 		Module:    r.Module,
 		Location:  r.Location,
 		Message:   r.Message,
+		Labels:    r.Labels,
 	}
 }
 
@@ -5,6 +5,7 @@ package apiserver
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/juju/loggo"
@@ -36,20 +37,23 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		noTail:        true,
 		backlog:       11,
 		startTime:     t1,
+		endTime:       t1.Add(time.Hour),
 		filterLevel:   loggo.INFO,
 		includeEntity: []string{"foo"},
 		includeModule: []string{"bar"},
 		excludeEntity: []string{"baz"},
 		excludeModule: []string{"qux"},
+		includeLabel:  []string{"http"},
+		excludeLabel:  []string{"pinger"},
+		message:       "connection (refused|reset)",
 	}
 
 	called := false
 	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params *state.LogTailerParams) (state.LogTailer, error) {
 		called = true
 
-		// Start time will be used once the client is extended to send
-		// time range arguments.
 		c.Assert(params.StartTime, gc.Equals, t1)
+		c.Assert(params.EndTime, gc.Equals, t1.Add(time.Hour))
 		c.Assert(params.NoTail, jc.IsTrue)
 		c.Assert(params.MinLevel, gc.Equals, loggo.INFO)
 		c.Assert(params.InitialLines, gc.Equals, 11)
@@ -57,6 +61,9 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		c.Assert(params.IncludeModule, jc.DeepEquals, []string{"bar"})
 		c.Assert(params.ExcludeEntity, jc.DeepEquals, []string{"baz"})
 		c.Assert(params.ExcludeModule, jc.DeepEquals, []string{"qux"})
+		c.Assert(params.IncludeLabel, jc.DeepEquals, []string{"http"})
+		c.Assert(params.ExcludeLabel, jc.DeepEquals, []string{"pinger"})
+		c.Assert(params.Message, gc.Equals, "connection (refused|reset)")
 
 		return newFakeLogTailer(), nil
 	})
@@ -91,6 +98,65 @@ func (s *debugLogDBIntSuite) TestParamConversionReplay(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *debugLogDBIntSuite) TestReadParamsTimeRangeAndMessage(c *gc.C) {
+	params, err := readDebugLogParams(url.Values{
+		"startTime": {"2016-11-30T10:51:00Z"},
+		"endTime":   {"2016-11-30T11:51:00Z"},
+		"message":   {"connection (refused|reset)"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	t1 := time.Date(2016, 11, 30, 10, 51, 0, 0, time.UTC)
+	c.Assert(params.startTime.Equal(t1), jc.IsTrue)
+	c.Assert(params.endTime.Equal(t1.Add(time.Hour)), jc.IsTrue)
+	c.Assert(params.message, gc.Equals, "connection (refused|reset)")
+}
+
+func (s *debugLogDBIntSuite) TestReadParamsLabels(c *gc.C) {
+	params, err := readDebugLogParams(url.Values{
+		"includeLabel": {"http", "charmstore"},
+		"excludeLabel": {"pinger"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(params.includeLabel, jc.DeepEquals, []string{"http", "charmstore"})
+	c.Assert(params.excludeLabel, jc.DeepEquals, []string{"pinger"})
+}
+
+func (s *debugLogDBIntSuite) TestFormatLogRecordLabels(c *gc.C) {
+	msg := formatLogRecord(&state.LogRecord{
+		Time:     time.Date(2015, 6, 19, 15, 34, 37, 0, time.UTC),
+		Entity:   names.NewMachineTag("99"),
+		Module:   "some.where",
+		Location: "code.go:42",
+		Level:    loggo.INFO,
+		Message:  "stuff happened",
+		Labels:   []string{"http"},
+	})
+	c.Assert(msg.Labels, jc.DeepEquals, []string{"http"})
+}
+
+func (s *debugLogDBIntSuite) TestReadParamsErrors(c *gc.C) {
+	for i, test := range []struct {
+		query url.Values
+		err   string
+	}{{
+		query: url.Values{"endTime": {"yesterday"}},
+		err:   `end time "yesterday" is not a valid time in RFC3339 format`,
+	}, {
+		query: url.Values{
+			"startTime": {"2016-11-30T10:51:00Z"},
+			"endTime":   {"2016-11-30T09:51:00Z"},
+		},
+		err: `end time "2016-11-30T09:51:00Z" is before start time`,
+	}, {
+		query: url.Values{"message": {"connection (refused"}},
+		err:   `message value "connection \(refused" is not a valid regular expression`,
+	}} {
+		c.Logf("test %d: %v", i, test.query)
+		_, err := readDebugLogParams(test.query)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *debugLogDBIntSuite) TestFullRequest(c *gc.C) {
 	// Set up a fake log tailer with a 2 log records ready to send.
 	tailer := newFakeLogTailer()
@@ -0,0 +1,140 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package egressfirewaller implements the API used by the machine
+// agents to enforce egress rules the provider cannot enforce.
+package egressfirewaller
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend defines the state methods this facade needs, so they can be
+// mocked for testing.
+type Backend interface {
+	common.EgressRulesBackend
+	ModelConfig() (*config.Config, error)
+	Machine(id string) (Machine, error)
+}
+
+// Machine defines the machine methods this facade needs.
+type Machine interface {
+	common.EgressRulesMachine
+}
+
+// EgressFirewallerAPI provides access to the egress rules
+// to be enforced by the machine agents.
+type EgressFirewallerAPI struct {
+	backend    Backend
+	newEnviron func() (environs.Environ, error)
+	resources  facade.Resources
+	authorizer facade.Authorizer
+}
+
+// NewAPIWithBacking creates a new server-side API facade with the given Backend.
+func NewAPIWithBacking(
+	backend Backend,
+	newEnviron func() (environs.Environ, error),
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*EgressFirewallerAPI, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &EgressFirewallerAPI{
+		backend:    backend,
+		newEnviron: newEnviron,
+		resources:  resources,
+		authorizer: authorizer,
+	}, nil
+}
+
+// machine returns the machine with the given tag, if the
+// authenticated machine agent is allowed to access it.
+func (api *EgressFirewallerAPI) machine(tag string) (Machine, error) {
+	machineTag, err := names.ParseMachineTag(tag)
+	if err != nil || !api.authorizer.AuthOwner(machineTag) {
+		return nil, common.ErrPerm
+	}
+	return api.backend.Machine(machineTag.Id())
+}
+
+// WatchEgressRules returns a NotifyWatcher for each given machine,
+// which notifies of changes to the egress rules to be enforced on
+// the machine.
+func (api *EgressFirewallerAPI) WatchEgressRules(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		m, err := api.machine(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		w := common.WatchMachineEgressRules(api.backend, m)
+		if _, ok := <-w.Changes(); ok {
+			results.Results[i].NotifyWatcherId = api.resources.Register(w)
+		} else {
+			results.Results[i].Error = common.ServerError(watcher.EnsureErr(w))
+		}
+	}
+	return results, nil
+}
+
+// EgressRules returns the egress rules to be enforced on each given
+// machine, and whether they are already enforced by the provider.
+func (api *EgressFirewallerAPI) EgressRules(args params.Entities) (params.MachineEgressRulesResults, error) {
+	results := params.MachineEgressRulesResults{
+		Results: make([]params.MachineEgressRulesResult, len(args.Entities)),
+	}
+	var providerManaged *bool
+	for i, entity := range args.Entities {
+		m, err := api.machine(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		rules, err := common.MachineEgressRules(api.backend, m)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if providerManaged == nil {
+			managed, err := api.providerManaged()
+			if err != nil {
+				return params.MachineEgressRulesResults{}, errors.Trace(err)
+			}
+			providerManaged = &managed
+		}
+		results.Results[i].Rules = params.FromNetworkEgressRules(rules)
+		results.Results[i].ProviderManaged = *providerManaged
+	}
+	return results, nil
+}
+
+// providerManaged reports whether the model's egress rules are
+// enforced by the provider, which the model's firewaller does with
+// the instance firewall mode if the environ supports egress rules.
+func (api *EgressFirewallerAPI) providerManaged() (bool, error) {
+	cfg, err := api.backend.ModelConfig()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if cfg.FirewallMode() != config.FwInstance {
+		return false, nil
+	}
+	env, err := api.newEnviron()
+	if err != nil {
+		return false, errors.Annotate(err, "opening environment")
+	}
+	return environs.SupportsEgressRules(env), nil
+}
@@ -0,0 +1,223 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package egressfirewaller_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/egressfirewaller"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type EgressFirewallerSuite struct {
+	coretesting.BaseSuite
+
+	backend    *stubBackend
+	environ    *stubEnviron
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+	facade     *egressfirewaller.EgressFirewallerAPI
+}
+
+var _ = gc.Suite(&EgressFirewallerSuite{})
+
+func (s *EgressFirewallerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.resources = common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("1"),
+	}
+	s.backend = &stubBackend{
+		Stub:         &testing.Stub{},
+		c:            c,
+		firewallMode: config.FwInstance,
+		machine: &stubMachine{
+			rules:   []network.EgressRule{network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8")},
+			watcher: workertest.NewFakeWatcher(1, 1),
+		},
+		hpWatcher: workertest.NewFakeWatcher(1, 1),
+	}
+	s.AddCleanup(func(_ *gc.C) {
+		s.backend.machine.watcher.Kill()
+		s.backend.hpWatcher.Kill()
+	})
+	s.environ = &stubEnviron{}
+
+	var err error
+	s.facade, err = egressfirewaller.NewAPIWithBacking(s.backend, s.newEnviron, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *EgressFirewallerSuite) newEnviron() (environs.Environ, error) {
+	s.backend.MethodCall(s.backend, "NewEnviron")
+	return s.environ, s.backend.NextErr()
+}
+
+func (s *EgressFirewallerSuite) entities(tags ...string) params.Entities {
+	var entities params.Entities
+	for _, tag := range tags {
+		entities.Entities = append(entities.Entities, params.Entity{Tag: tag})
+	}
+	return entities
+}
+
+func (s *EgressFirewallerSuite) TestNewAPIRequiresMachineAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("mysql/0")
+	_, err := egressfirewaller.NewAPIWithBacking(s.backend, s.newEnviron, s.resources, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *EgressFirewallerSuite) TestEgressRules(c *gc.C) {
+	results, err := s.facade.EgressRules(s.entities("machine-1", "machine-2", "application-mysql"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.MachineEgressRulesResults{
+		Results: []params.MachineEgressRulesResult{{
+			Rules: params.FromNetworkEgressRules([]network.EgressRule{
+				network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+				network.MustNewEgressRule("tcp", 17070, 17070, "10.0.0.1/32"),
+			}),
+		}, {
+			Error: apiservertesting.ErrUnauthorized,
+		}, {
+			Error: apiservertesting.ErrUnauthorized,
+		}},
+	})
+	s.backend.CheckCallNames(c, "Machine", "APIHostPorts", "ModelConfig", "NewEnviron")
+}
+
+func (s *EgressFirewallerSuite) TestEgressRulesProviderManaged(c *gc.C) {
+	s.environ.supported = true
+	results, err := s.facade.EgressRules(s.entities("machine-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].ProviderManaged, jc.IsTrue)
+}
+
+func (s *EgressFirewallerSuite) TestEgressRulesFirewallModeGlobal(c *gc.C) {
+	s.environ.supported = true
+	s.backend.firewallMode = config.FwGlobal
+	results, err := s.facade.EgressRules(s.entities("machine-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].ProviderManaged, jc.IsFalse)
+	s.backend.CheckCallNames(c, "Machine", "APIHostPorts", "ModelConfig")
+}
+
+func (s *EgressFirewallerSuite) TestEgressRulesUnrestricted(c *gc.C) {
+	s.backend.machine.rules = nil
+	results, err := s.facade.EgressRules(s.entities("machine-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Rules, gc.HasLen, 0)
+}
+
+func (s *EgressFirewallerSuite) TestEgressRulesMachineNotFound(c *gc.C) {
+	s.backend.SetErrors(errors.NotFoundf("machine 1"))
+	results, err := s.facade.EgressRules(s.entities("machine-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *EgressFirewallerSuite) TestWatchEgressRules(c *gc.C) {
+	results, err := s.facade.WatchEgressRules(s.entities("machine-1", "machine-2"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	_, ok := s.resources.Get("1").(state.NotifyWatcher)
+	c.Assert(ok, jc.IsTrue)
+	s.backend.CheckCallNames(c, "Machine", "WatchAPIHostPorts")
+}
+
+type stubBackend struct {
+	*testing.Stub
+
+	c            *gc.C
+	firewallMode string
+	machine      *stubMachine
+	hpWatcher    workertest.NotAWatcher
+}
+
+func (b *stubBackend) ModelConfig() (*config.Config, error) {
+	b.MethodCall(b, "ModelConfig")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return coretesting.CustomModelConfig(b.c, coretesting.Attrs{
+		"firewall-mode": b.firewallMode,
+	}), nil
+}
+
+func (b *stubBackend) Machine(id string) (egressfirewaller.Machine, error) {
+	b.MethodCall(b, "Machine", id)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.machine, nil
+}
+
+func (b *stubBackend) APIHostPorts() ([][]network.HostPort, error) {
+	b.MethodCall(b, "APIHostPorts")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return [][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.1", "controller.example.com"),
+	}, nil
+}
+
+func (b *stubBackend) WatchAPIHostPorts() state.NotifyWatcher {
+	b.MethodCall(b, "WatchAPIHostPorts")
+	return b.hpWatcher
+}
+
+type stubMachine struct {
+	rules   []network.EgressRule
+	watcher workertest.NotAWatcher
+}
+
+func (m *stubMachine) EgressRules() ([]network.EgressRule, error) {
+	return m.rules, nil
+}
+
+func (m *stubMachine) WatchEgressRules() state.NotifyWatcher {
+	return m.watcher
+}
+
+type stubEnviron struct {
+	environs.Environ
+	supported bool
+}
+
+func (e *stubEnviron) SupportsEgressRules() (bool, error) {
+	return e.supported, nil
+}
+
+func (e *stubEnviron) SetEgressRules(string, []network.EgressRule) error {
+	return errors.NotImplementedf("SetEgressRules")
+}
+
+func (e *stubEnviron) EgressRules(string) ([]network.EgressRule, error) {
+	return nil, errors.NotImplementedf("EgressRules")
+}
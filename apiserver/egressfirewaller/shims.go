@@ -0,0 +1,28 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package egressfirewaller
+
+import (
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+)
+
+// NewAPI creates a new API server-side facade with a state.State backing.
+func NewAPI(st *state.State, res facade.Resources, auth facade.Authorizer) (*EgressFirewallerAPI, error) {
+	newEnviron := func() (environs.Environ, error) {
+		return stateenvirons.GetNewEnvironFunc(environs.New)(st)
+	}
+	return NewAPIWithBacking(&stateShim{st}, newEnviron, res, auth)
+}
+
+// stateShim forwards and adapts state.State methods to Backend.
+type stateShim struct {
+	*state.State
+}
+
+func (s *stateShim) Machine(id string) (Machine, error) {
+	return s.State.Machine(id)
+}
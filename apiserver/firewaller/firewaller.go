@@ -192,6 +192,7 @@ func (f *FirewallerAPI) GetMachinePorts(args params.MachinePortsParams) (params.
 		}
 		if ports != nil {
 			portRangeMap := ports.AllPortRanges()
+			endpoints := ports.AllPortRangeEndpoints()
 			var portRanges []network.PortRange
 			for portRange := range portRangeMap {
 				portRanges = append(portRanges, portRange)
@@ -204,6 +205,7 @@ func (f *FirewallerAPI) GetMachinePorts(args params.MachinePortsParams) (params.
 					params.MachinePortRange{
 						UnitTag:   unitTag,
 						PortRange: params.FromNetworkPortRange(portRange),
+						Endpoint:  endpoints[portRange],
 					})
 			}
 		}
@@ -282,6 +284,105 @@ func (f *FirewallerAPI) GetExposed(args params.Entities) (params.BoolResults, er
 	return result, nil
 }
 
+// GetExposeInfo returns the exposed flag and the expose settings,
+// keyed by endpoint name, for each given application.
+func (f *FirewallerAPI) GetExposeInfo(args params.Entities) (params.ExposeInfoResults, error) {
+	result := params.ExposeInfoResults{
+		Results: make([]params.ExposeInfoResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.ExposeInfoResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Exposed = application.IsExposed()
+		exposedEndpoints := application.ExposedEndpoints()
+		if len(exposedEndpoints) == 0 {
+			continue
+		}
+		result.Results[i].ExposedEndpoints = make(map[string]params.ExposedEndpoint)
+		for endpoint, settings := range exposedEndpoints {
+			result.Results[i].ExposedEndpoints[endpoint] = params.ExposedEndpoint{
+				ExposeToCIDRs: settings.ExposeToCIDRs,
+			}
+		}
+	}
+	return result, nil
+}
+
+// WatchEgressRules returns a NotifyWatcher for the egress rules
+// to be enforced on each given machine.
+func (f *FirewallerAPI) WatchEgressRules(args params.Entities) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessMachine()
+	if err != nil {
+		return params.NotifyWatchResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		machine, err := f.getMachine(canAccess, tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		w := common.WatchMachineEgressRules(f.st, machine)
+		if _, ok := <-w.Changes(); ok {
+			result.Results[i].NotifyWatcherId = f.resources.Register(w)
+		} else {
+			result.Results[i].Error = common.ServerError(watcher.EnsureErr(w))
+		}
+	}
+	return result, nil
+}
+
+// GetMachineEgressRules returns the egress rules to be enforced on
+// each given machine. Machines whose outgoing traffic is unrestricted
+// have no rules.
+func (f *FirewallerAPI) GetMachineEgressRules(args params.Entities) (params.EgressRulesResults, error) {
+	result := params.EgressRulesResults{
+		Results: make([]params.EgressRulesResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessMachine()
+	if err != nil {
+		return params.EgressRulesResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		machine, err := f.getMachine(canAccess, tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		rules, err := common.MachineEgressRules(f.st, machine)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Rules = params.FromNetworkEgressRules(rules)
+	}
+	return result, nil
+}
+
 // GetAssignedMachine returns the assigned machine tag (if any) for
 // each given unit.
 func (f *FirewallerAPI) GetAssignedMachine(args params.Entities) (params.StringResults, error) {
@@ -97,7 +97,7 @@ func (s *agentLoggingStrategy) Start() {
 // LoggingStrategy.
 func (s *agentLoggingStrategy) Log(m params.LogRecord) bool {
 	level, _ := loggo.ParseLevel(m.Level)
-	dbErr := s.dbLogger.Log(m.Time, m.Module, m.Location, level, m.Message)
+	dbErr := s.dbLogger.Log(m.Time, m.Module, m.Location, level, m.Message, m.Labels...)
 	if dbErr != nil {
 		logger.Errorf("logging to DB failed: %v", dbErr)
 	}
@@ -121,6 +121,7 @@ func (s *logsinkSuite) TestLogging(c *gc.C) {
 		Location: "bar.go:99",
 		Level:    loggo.ERROR.String(),
 		Message:  "oh noes",
+		Labels:   []string{"http"},
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -150,6 +151,7 @@ func (s *logsinkSuite) TestLogging(c *gc.C) {
 	c.Assert(docs[0]["l"], gc.Equals, "foo.go:42")
 	c.Assert(docs[0]["v"], gc.Equals, int(loggo.INFO))
 	c.Assert(docs[0]["x"], gc.Equals, "all is well")
+	c.Assert(docs[0]["c"], gc.IsNil)
 
 	c.Assert(docs[1]["t"], gc.Equals, t1.UnixNano())
 	c.Assert(docs[1]["e"], gc.Equals, modelUUID)
@@ -158,6 +160,7 @@ func (s *logsinkSuite) TestLogging(c *gc.C) {
 	c.Assert(docs[1]["l"], gc.Equals, "bar.go:99")
 	c.Assert(docs[1]["v"], gc.Equals, int(loggo.ERROR))
 	c.Assert(docs[1]["x"], gc.Equals, "oh noes")
+	c.Assert(docs[1]["c"], jc.DeepEquals, []interface{}{"http"})
 
 	// Close connection.
 	err = conn.Close()
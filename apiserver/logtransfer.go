@@ -66,7 +66,7 @@ func (s *migrationLoggingStrategy) Start() {
 // logger. Part of LoggingStrategy.
 func (s *migrationLoggingStrategy) Log(m params.LogRecord) bool {
 	level, _ := loggo.ParseLevel(m.Level)
-	dbErr := s.dbLogger.Log(m.Time, m.Entity, m.Module, m.Location, level, m.Message)
+	dbErr := s.dbLogger.Log(m.Time, m.Entity, m.Module, m.Location, level, m.Message, m.Labels...)
 	if dbErr == nil {
 		dbErr = s.tracker.Track(m.Time)
 	}
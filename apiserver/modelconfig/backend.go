@@ -18,7 +18,9 @@ type Backend interface {
 	ControllerTag() names.ControllerTag
 	ModelTag() names.ModelTag
 	ModelConfigValues() (config.ConfigValues, error)
-	UpdateModelConfig(map[string]interface{}, []string, ...state.ValidateConfigFunc) error
+	UpdateModelConfigAs(string, map[string]interface{}, []string, ...state.ValidateConfigFunc) error
+	ModelConfigHistory() ([]state.SettingsRevision, error)
+	RevertModelConfig(revision int, author string) error
 	SetSLA(level, owner string, credentials []byte) error
 	SLALevel() (string, error)
 }
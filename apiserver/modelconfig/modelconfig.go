@@ -144,7 +144,7 @@ func (c *ModelConfigAPI) ModelSet(args params.ModelSet) error {
 
 	// Replace any deprecated attributes with their new values.
 	attrs := config.ProcessDeprecatedAttributes(args.Config)
-	return c.backend.UpdateModelConfig(attrs, nil, checkAgentVersion, checkLogTrace)
+	return c.backend.UpdateModelConfigAs(c.author(), attrs, nil, checkAgentVersion, checkLogTrace)
 }
 
 // ModelUnset implements the server-side part of the
@@ -156,7 +156,41 @@ func (c *ModelConfigAPI) ModelUnset(args params.ModelUnset) error {
 	if err := c.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
-	return c.backend.UpdateModelConfig(nil, args.Keys)
+	return c.backend.UpdateModelConfigAs(c.author(), nil, args.Keys)
+}
+
+// ModelGetHistory returns the changes made to the model config, oldest
+// first.
+func (c *ModelConfigAPI) ModelGetHistory() (params.ModelConfigHistoryResult, error) {
+	result := params.ModelConfigHistoryResult{}
+	if err := c.checkCanWrite(); err != nil {
+		return result, errors.Trace(err)
+	}
+	history, err := c.backend.ModelConfigHistory()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	// Authorized keys are omitted, as they are by ModelGet.
+	result.Revisions = common.ConfigRevisions(history, config.AuthorizedKeysKey)
+	return result, nil
+}
+
+// ModelRevert returns the model config to the values it had immediately
+// after the given revision was made.
+func (c *ModelConfigAPI) ModelRevert(args params.ModelConfigRevert) error {
+	if err := c.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	return c.backend.RevertModelConfig(args.Revision, c.author())
+}
+
+// author returns the tag of the authenticated user, which is recorded
+// in the model config history.
+func (c *ModelConfigAPI) author() string {
+	return c.auth.GetAuthTag().String()
 }
 
 // SetSLALevel sets the sla level on the model.
@@ -4,6 +4,8 @@
 package modelconfig_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -165,7 +167,7 @@ func (s *modelconfigSuite) TestUserCannotSetLogTrace(c *gc.C) {
 }
 
 func (s *modelconfigSuite) TestModelUnset(c *gc.C) {
-	err := s.backend.UpdateModelConfig(map[string]interface{}{"abc": 123}, nil)
+	err := s.backend.UpdateModelConfigAs("", map[string]interface{}{"abc": 123}, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	args := params.ModelUnset{[]string{"abc"}}
@@ -175,7 +177,7 @@ func (s *modelconfigSuite) TestModelUnset(c *gc.C) {
 }
 
 func (s *modelconfigSuite) TestBlockModelUnset(c *gc.C) {
-	err := s.backend.UpdateModelConfig(map[string]interface{}{"abc": 123}, nil)
+	err := s.backend.UpdateModelConfigAs("", map[string]interface{}{"abc": 123}, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	s.blockAllChanges(c, "TestBlockModelUnset")
 
@@ -196,18 +198,89 @@ func (s *modelconfigSuite) TestSetSupportCredentals(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *modelconfigSuite) TestModelSetRecordsAuthor(c *gc.C) {
+	err := s.api.ModelSet(params.ModelSet{Config: map[string]interface{}{"some-key": "value"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.backend.author, gc.Equals, "user-bruce")
+}
+
+func (s *modelconfigSuite) TestModelGetHistory(c *gc.C) {
+	now := time.Now().UTC()
+	s.backend.history = []state.SettingsRevision{{
+		Revision:  1,
+		Author:    "user-bruce",
+		Timestamp: now,
+		Changes: []state.ItemChange{
+			{Type: state.ItemAdded, Key: "some-key", NewValue: "value"},
+			{Type: state.ItemModified, Key: "authorized-keys", OldValue: "a", NewValue: "b"},
+		},
+	}, {
+		Revision:  2,
+		Timestamp: now,
+		Changes: []state.ItemChange{
+			{Type: state.ItemModified, Key: "some-key", OldValue: "value", NewValue: "other"},
+			{Type: state.ItemDeleted, Key: "ftp-proxy", OldValue: "http://proxy"},
+		},
+	}}
+	result, err := s.api.ModelGetHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelConfigHistoryResult{
+		Revisions: []params.ConfigRevision{{
+			Revision:  1,
+			Author:    "user-bruce",
+			Timestamp: now,
+			Changes: []params.ConfigChange{
+				{Type: "added", Key: "some-key", NewValue: "value"},
+			},
+		}, {
+			Revision:  2,
+			Timestamp: now,
+			Changes: []params.ConfigChange{
+				{Type: "modified", Key: "some-key", OldValue: "value", NewValue: "other"},
+				{Type: "deleted", Key: "ftp-proxy", OldValue: "http://proxy"},
+			},
+		}},
+	})
+}
+
+func (s *modelconfigSuite) TestModelGetHistoryPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	s.authorizer.AdminTag = names.NewUserTag("bruce@local")
+	_, err := s.api.ModelGetHistory()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelconfigSuite) TestModelRevert(c *gc.C) {
+	err := s.api.ModelRevert(params.ModelConfigRevert{Revision: 3})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.backend.reverted, gc.Equals, 3)
+	c.Assert(s.backend.author, gc.Equals, "user-bruce")
+}
+
+func (s *modelconfigSuite) TestBlockModelRevert(c *gc.C) {
+	s.blockAllChanges(c, "TestBlockModelRevert")
+	err := s.api.ModelRevert(params.ModelConfigRevert{Revision: 3})
+	s.assertBlocked(c, err, "TestBlockModelRevert")
+	c.Assert(s.backend.reverted, gc.Equals, 0)
+}
+
 type mockBackend struct {
 	cfg config.ConfigValues
 	old *config.Config
 	b   state.BlockType
 	msg string
+
+	author   string
+	history  []state.SettingsRevision
+	reverted int
 }
 
 func (m *mockBackend) ModelConfigValues() (config.ConfigValues, error) {
 	return m.cfg, nil
 }
 
-func (m *mockBackend) UpdateModelConfig(update map[string]interface{}, remove []string, validate ...state.ValidateConfigFunc) error {
+func (m *mockBackend) UpdateModelConfigAs(author string, update map[string]interface{}, remove []string, validate ...state.ValidateConfigFunc) error {
+	m.author = author
 	for _, validateFunc := range validate {
 		if err := validateFunc(update, remove, m.old); err != nil {
 			return err
@@ -222,6 +295,16 @@ func (m *mockBackend) UpdateModelConfig(update map[string]interface{}, remove []
 	return nil
 }
 
+func (m *mockBackend) ModelConfigHistory() ([]state.SettingsRevision, error) {
+	return m.history, nil
+}
+
+func (m *mockBackend) RevertModelConfig(revision int, author string) error {
+	m.author = author
+	m.reverted = revision
+	return nil
+}
+
 func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	if m.b == t {
 		return &mockBlock{t: t, m: m.msg}, true, nil
@@ -5,9 +5,11 @@ package observer
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 
@@ -25,15 +27,24 @@ type AuditContext struct {
 	// ModelUUID is the UUID of the model the audit observer is
 	// currently running on.
 	ModelUUID string
+
+	// Clock is used to timestamp audit entries and to measure the
+	// duration of requests. If it is nil, the wall clock is used.
+	Clock clock.Clock
 }
 
 type ErrorHandler func(error)
 
 // NewAudit creates a new Audit with the information provided via the Context.
 func NewAudit(ctx *AuditContext, handleAuditEntry audit.AuditEntrySinkFn, errorHandler ErrorHandler) *Audit {
+	clk := ctx.Clock
+	if clk == nil {
+		clk = clock.WallClock
+	}
 	return &Audit{
 		jujuServerVersion: ctx.JujuServerVersion,
 		modelUUID:         ctx.ModelUUID,
+		clock:             clk,
 		errorHandler:      errorHandler,
 		handleAuditEntry:  handleAuditEntry,
 	}
@@ -44,6 +55,7 @@ func NewAudit(ctx *AuditContext, handleAuditEntry audit.AuditEntrySinkFn, errorH
 type Audit struct {
 	jujuServerVersion version.Number
 	modelUUID         string
+	clock             clock.Clock
 	errorHandler      ErrorHandler
 	handleAuditEntry  audit.AuditEntrySinkFn
 
@@ -79,6 +91,7 @@ func (a *Audit) RPCObserver() rpc.Observer {
 	return &AuditRPCObserver{
 		jujuServerVersion: a.jujuServerVersion,
 		modelUUID:         a.modelUUID,
+		clock:             a.clock,
 		errorHandler:      a.errorHandler,
 		handleAuditEntry:  a.handleAuditEntry,
 		authenticatedTag:  a.state.authenticatedTag,
@@ -87,43 +100,86 @@ func (a *Audit) RPCObserver() rpc.Observer {
 }
 
 // AuditRPCObserver is an observer which will log RPC requests using
-// the function provided.
+// the function provided. A new AuditRPCObserver is created for each
+// request; a single audit entry is recorded for the request when its
+// reply is sent, holding the facade, method, redacted arguments,
+// result or error, and the time taken to handle the request.
 type AuditRPCObserver struct {
 	jujuServerVersion version.Number
 	modelUUID         string
+	clock             clock.Clock
 	errorHandler      ErrorHandler
 	handleAuditEntry  audit.AuditEntrySinkFn
 	authenticatedTag  string
 	remoteAddress     string
+
+	// started and args are recorded when the request is received.
+	started time.Time
+	args    interface{}
 }
 
 // ServerRequest implements Observer.
 func (a *AuditRPCObserver) ServerRequest(hdr *rpc.Header, body interface{}) {
-	auditEntry := a.boilerplateAuditEntry()
-	auditEntry.OriginName = a.authenticatedTag
+	a.started = a.clock.Now()
+	if !omitBody(hdr.Request) {
+		a.args = audit.Redact(body)
+	}
+}
 
+// ServerReply implements Observer.
+func (a *AuditRPCObserver) ServerReply(req rpc.Request, hdr *rpc.Header, body interface{}) {
+	now := a.clock.Now()
+	if a.started.IsZero() {
+		a.started = now
+	}
+	auditEntry := a.boilerplateAuditEntry()
 	auditEntry.OriginType = "API request"
-	auditEntry.Operation = rpcRequestToOperation(hdr.Request)
-	auditEntry.Data = map[string]interface{}{"request-body": body}
+	auditEntry.Operation = rpcRequestToOperation(req)
+	data := map[string]interface{}{
+		"facade":     req.Type,
+		"version":    req.Version,
+		"method":     req.Action,
+		"request-id": hdr.RequestId,
+		"args":       a.args,
+		"duration":   now.Sub(a.started).String(),
+	}
+	if req.Id != "" {
+		data["id"] = req.Id
+	}
+	if hdr.Error != "" {
+		data["error"] = hdr.Error
+		if hdr.ErrorCode != "" {
+			data["error-code"] = hdr.ErrorCode
+		}
+	} else if !omitBody(req) {
+		data["result"] = audit.Redact(body)
+	}
+	auditEntry.Data = data
 	err := a.handleAuditEntry(auditEntry)
 	if err != nil {
 		a.errorHandler(errors.Trace(err))
 	}
 }
 
-// ServerReply implements Observer.
-func (a *AuditRPCObserver) ServerReply(rpc.Request, *rpc.Header, interface{}) {}
-
 func (a *AuditRPCObserver) boilerplateAuditEntry() audit.AuditEntry {
 	return audit.AuditEntry{
 		JujuServerVersion: a.jujuServerVersion,
 		ModelUUID:         a.modelUUID,
-		Timestamp:         time.Now().UTC(),
+		Timestamp:         a.started.UTC(),
 		RemoteAddress:     a.remoteAddress,
 		OriginName:        a.authenticatedTag,
 	}
 }
 
+// omitBody reports whether the arguments and result of the request
+// should be left out of its audit entry. Watcher Next calls are made
+// continually by every client and agent, and their results can be
+// large, so redacting them would add significant work to every reply
+// for little benefit; the entry still records that the call was made.
+func omitBody(req rpc.Request) bool {
+	return req.Action == "Next" && strings.HasSuffix(req.Type, "Watcher")
+}
+
 func rpcRequestToOperation(req rpc.Request) string {
 	return fmt.Sprintf("%s:v%d - %s", req.Type, req.Version, req.Action)
 }
@@ -0,0 +1,145 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package observer_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/rpc"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditSuite struct {
+	testing.IsolationSuite
+
+	clock   *testing.Clock
+	entries []audit.AuditEntry
+	errors  []error
+}
+
+var _ = gc.Suite(&auditSuite{})
+
+func (s *auditSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2017, time.June, 1, 12, 0, 0, 0, time.UTC))
+	s.entries = nil
+	s.errors = nil
+}
+
+func (s *auditSuite) newRPCObserver() rpc.Observer {
+	auditor := observer.NewAudit(&observer.AuditContext{
+		JujuServerVersion: version.MustParse("2.2.0"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+		Clock:             s.clock,
+	}, func(entry audit.AuditEntry) error {
+		s.entries = append(s.entries, entry)
+		return nil
+	}, func(err error) {
+		s.errors = append(s.errors, err)
+	})
+	auditor.Join(&http.Request{RemoteAddr: "10.0.0.1:1234"}, 1)
+	auditor.Login(names.NewUserTag("bob"), coretesting.ModelTag, false, "")
+	return auditor.RPCObserver()
+}
+
+func (s *auditSuite) TestServerReply(c *gc.C) {
+	o := s.newRPCObserver()
+	req := rpc.Request{Type: "Application", Version: 4, Action: "SetConfig"}
+	o.ServerRequest(&rpc.Header{RequestId: 7, Request: req}, map[string]interface{}{
+		"application": "mysql",
+		"password":    "sekrit",
+	})
+	s.clock.Advance(2 * time.Second)
+	o.ServerReply(req, &rpc.Header{RequestId: 7}, struct{}{})
+
+	c.Assert(s.errors, gc.HasLen, 0)
+	c.Assert(s.entries, gc.HasLen, 1)
+	c.Assert(s.entries[0], jc.DeepEquals, audit.AuditEntry{
+		JujuServerVersion: version.MustParse("2.2.0"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+		Timestamp:         time.Date(2017, time.June, 1, 12, 0, 0, 0, time.UTC),
+		RemoteAddress:     "10.0.0.1:1234",
+		OriginType:        "API request",
+		OriginName:        "user-bob",
+		Operation:         "Application:v4 - SetConfig",
+		Data: map[string]interface{}{
+			"facade":     "Application",
+			"version":    4,
+			"method":     "SetConfig",
+			"request-id": uint64(7),
+			"args": map[string]interface{}{
+				"application": "mysql",
+				"password":    audit.Redacted,
+			},
+			"result":   map[string]interface{}{},
+			"duration": "2s",
+		},
+	})
+}
+
+func (s *auditSuite) TestServerReplyError(c *gc.C) {
+	o := s.newRPCObserver()
+	req := rpc.Request{Type: "Application", Version: 4, Action: "Destroy", Id: "mysql"}
+	o.ServerRequest(&rpc.Header{RequestId: 8, Request: req}, nil)
+	o.ServerReply(req, &rpc.Header{
+		RequestId: 8,
+		Error:     "permission denied",
+		ErrorCode: "unauthorized access",
+	}, struct{}{})
+
+	c.Assert(s.entries, gc.HasLen, 1)
+	data := s.entries[0].Data
+	c.Check(data["id"], gc.Equals, "mysql")
+	c.Check(data["error"], gc.Equals, "permission denied")
+	c.Check(data["error-code"], gc.Equals, "unauthorized access")
+	c.Check(data["args"], gc.IsNil)
+	_, ok := data["result"]
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *auditSuite) TestServerReplyWatcherNextOmitsBody(c *gc.C) {
+	o := s.newRPCObserver()
+	req := rpc.Request{Type: "AllWatcher", Version: 1, Action: "Next", Id: "1"}
+	o.ServerRequest(&rpc.Header{RequestId: 9, Request: req}, struct{}{})
+	o.ServerReply(req, &rpc.Header{RequestId: 9}, map[string]interface{}{
+		"deltas": []string{"a", "b"},
+	})
+
+	c.Assert(s.errors, gc.HasLen, 0)
+	c.Assert(s.entries, gc.HasLen, 1)
+	data := s.entries[0].Data
+	c.Check(data["method"], gc.Equals, "Next")
+	c.Check(data["args"], gc.IsNil)
+	_, ok := data["result"]
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *auditSuite) TestSinkError(c *gc.C) {
+	auditor := observer.NewAudit(&observer.AuditContext{
+		JujuServerVersion: version.MustParse("2.2.0"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+		Clock:             s.clock,
+	}, func(audit.AuditEntry) error {
+		return errors.New("disk full")
+	}, func(err error) {
+		s.errors = append(s.errors, err)
+	})
+	o := auditor.RPCObserver()
+	req := rpc.Request{Type: "Client", Version: 1, Action: "FullStatus"}
+	o.ServerRequest(&rpc.Header{RequestId: 1, Request: req}, nil)
+	o.ServerReply(req, &rpc.Header{RequestId: 1}, struct{}{})
+
+	c.Assert(s.errors, gc.HasLen, 1)
+	c.Assert(s.errors[0], gc.ErrorMatches, "disk full")
+}
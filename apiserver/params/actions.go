@@ -38,6 +38,7 @@ type Action struct {
 	Receiver   string                 `json:"receiver"`
 	Name       string                 `json:"name"`
 	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Timeout    time.Duration          `json:"timeout,omitempty"`
 }
 
 // ActionResults is a slice of ActionResult for bulk requests.
@@ -54,9 +55,46 @@ type ActionResult struct {
 	Status    string                 `json:"status,omitempty"`
 	Message   string                 `json:"message,omitempty"`
 	Output    map[string]interface{} `json:"output,omitempty"`
+	Log       []ActionMessage        `json:"log,omitempty"`
 	Error     *Error                 `json:"error,omitempty"`
 }
 
+// ActionMessage is a timestamped progress message logged by an action.
+type ActionMessage struct {
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
+// ActionMessageParams holds the progress messages to log for a bulk
+// action API call.
+type ActionMessageParams struct {
+	Messages []EntityString `json:"messages"`
+}
+
+// EntityString holds an entity tag and a string value.
+type EntityString struct {
+	Tag   string `json:"tag"`
+	Value string `json:"value"`
+}
+
+// OperationQueryArgs holds the ids of the operations to look up.
+type OperationQueryArgs struct {
+	Operations []string `json:"operations"`
+}
+
+// OperationResults is a slice of OperationResult for bulk requests.
+type OperationResults struct {
+	Results []OperationResult `json:"results,omitempty"`
+}
+
+// OperationResult holds the actions enqueued on each receiver as part
+// of an operation.
+type OperationResult struct {
+	OperationId string         `json:"operation-id,omitempty"`
+	Actions     []ActionResult `json:"actions,omitempty"`
+	Error       *Error         `json:"error,omitempty"`
+}
+
 // ActionsByReceivers wrap a slice of Actions for API calls.
 type ActionsByReceivers struct {
 	Actions []ActionsByReceiver `json:"actions,omitempty"`
@@ -138,3 +176,9 @@ type ActionSpec struct {
 	Description string                 `json:"description"`
 	Params      map[string]interface{} `json:"params"`
 }
+
+// ActionPruneArgs holds arguments for the action pruning process.
+type ActionPruneArgs struct {
+	MaxHistoryTime time.Duration `json:"max-history-time"`
+	MaxHistoryMB   int           `json:"max-history-mb"`
+}
@@ -250,6 +250,22 @@ type ControllerConfigResult struct {
 	Config ControllerConfig `json:"config"`
 }
 
+// ControllerConfigSet holds new values for controller configuration.
+// Only attributes that may be changed after bootstrap are accepted.
+type ControllerConfigSet struct {
+	Config map[string]interface{} `json:"config"`
+}
+
+// BackupStatus holds the outcome of the most recent scheduled
+// controller backups. The times are nil if there has been no such
+// backup.
+type BackupStatus struct {
+	LastSuccess      *time.Time `json:"last-success,omitempty"`
+	LastSuccessID    string     `json:"last-success-id,omitempty"`
+	LastFailure      *time.Time `json:"last-failure,omitempty"`
+	LastFailureError string     `json:"last-failure-error,omitempty"`
+}
+
 // RelationUnit holds a relation and a unit tag.
 type RelationUnit struct {
 	Relation string `json:"relation"`
@@ -578,6 +594,23 @@ type RunParams struct {
 	Machines     []string      `json:"machines,omitempty"`
 	Applications []string      `json:"applications,omitempty"`
 	Units        []string      `json:"units,omitempty"`
+
+	// LeaderOnly, Status and Series select which of the targets the
+	// commands are run on: only units that are the leaders of their
+	// applications; only units whose workload status, or machines
+	// whose status, is Status; and only targets on machines of the
+	// given series.
+	LeaderOnly bool   `json:"leader-only,omitempty"`
+	Status     string `json:"status,omitempty"`
+	Series     string `json:"series,omitempty"`
+}
+
+// CheckNetworkParams is used to provide the parameters to the
+// CheckNetwork method. If no applications are specified, the
+// units of all the applications in the model are checked.
+type CheckNetworkParams struct {
+	Applications []string      `json:"applications,omitempty"`
+	Timeout      time.Duration `json:"timeout"`
 }
 
 // RunResult contains the result from an individual run call on a machine.
@@ -721,6 +754,7 @@ type LogMessage struct {
 	Module    string    `json:"mod"`
 	Location  string    `json:"loc"`
 	Message   string    `json:"msg"`
+	Labels    []string  `json:"labels,omitempty"`
 }
 
 // ResourceUploadResult is used to return some details about an
@@ -72,6 +72,35 @@ type ModelUnset struct {
 	Keys []string `json:"keys"`
 }
 
+// ConfigChange describes a change made to a single config attribute.
+// The type is one of "added", "modified" or "deleted".
+type ConfigChange struct {
+	Type     string      `json:"type"`
+	Key      string      `json:"key"`
+	OldValue interface{} `json:"old-value,omitempty"`
+	NewValue interface{} `json:"new-value,omitempty"`
+}
+
+// ConfigRevision describes a change made to a config, and who made it.
+type ConfigRevision struct {
+	Revision  int            `json:"revision"`
+	Author    string         `json:"author,omitempty"`
+	Timestamp time.Time      `json:"timestamp"`
+	Changes   []ConfigChange `json:"changes"`
+}
+
+// ModelConfigHistoryResult contains the result of the ModelGetHistory
+// client API call, oldest revision first.
+type ModelConfigHistoryResult struct {
+	Revisions []ConfigRevision `json:"revisions"`
+}
+
+// ModelConfigRevert contains the arguments for the ModelRevert client
+// API call.
+type ModelConfigRevert struct {
+	Revision int `json:"revision"`
+}
+
 // ModelSLA contains the arguments for the SetSLALevel client API
 // call.
 type ModelSLA struct {
@@ -259,12 +259,14 @@ type EntitiesPorts struct {
 	Entities []EntityPort `json:"entities"`
 }
 
-// EntityPortRange holds an entity's tag, a protocol and a port range.
+// EntityPortRange holds an entity's tag, a protocol and a port range,
+// and optionally the endpoint the port range is opened for.
 type EntityPortRange struct {
 	Tag      string `json:"tag"`
 	Protocol string `json:"protocol"`
 	FromPort int    `json:"from-port"`
 	ToPort   int    `json:"to-port"`
+	Endpoint string `json:"endpoint,omitempty"`
 }
 
 // EntitiesPortRanges holds the parameters for making an OpenPorts or
@@ -432,11 +434,13 @@ type MachineAddressesResults struct {
 }
 
 // MachinePortRange holds a single port range open on a machine for
-// the given unit and relation tags.
+// the given unit and relation tags, and the endpoint, if any, the
+// port range is opened for.
 type MachinePortRange struct {
 	UnitTag     string    `json:"unit-tag"`
 	RelationTag string    `json:"relation-tag"`
 	PortRange   PortRange `json:"port-range"`
+	Endpoint    string    `json:"endpoint,omitempty"`
 }
 
 // MachinePorts holds a machine and subnet tags. It's used when referring to
@@ -529,6 +533,89 @@ type MachinePortsResults struct {
 	Results []MachinePortsResult `json:"results"`
 }
 
+// ExposeInfoResult holds a single result of the
+// FirewallerAPI.GetExposeInfo() API call.
+type ExposeInfoResult struct {
+	Error            *Error                     `json:"error,omitempty"`
+	Exposed          bool                       `json:"exposed"`
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+}
+
+// ExposeInfoResults holds all the results of the
+// FirewallerAPI.GetExposeInfo() API call.
+type ExposeInfoResults struct {
+	Results []ExposeInfoResult `json:"results"`
+}
+
+// EgressRule holds a range of ports and the destinations to
+// which outgoing packets are allowed.
+type EgressRule struct {
+	PortRange        PortRange `json:"port-range"`
+	DestinationCIDRs []string  `json:"destination-cidrs,omitempty"`
+}
+
+// FromNetworkEgressRules is a convenience helper to create a parameter
+// out of the network type, here for EgressRule.
+func FromNetworkEgressRules(rules []network.EgressRule) []EgressRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	result := make([]EgressRule, len(rules))
+	for i, rule := range rules {
+		result[i] = EgressRule{
+			PortRange:        FromNetworkPortRange(rule.PortRange),
+			DestinationCIDRs: rule.DestinationCIDRs,
+		}
+	}
+	return result
+}
+
+// NetworkEgressRules is a convenience helper to return the parameters
+// as network type, here for EgressRule.
+func NetworkEgressRules(rules []EgressRule) []network.EgressRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	result := make([]network.EgressRule, len(rules))
+	for i, rule := range rules {
+		result[i] = network.EgressRule{
+			PortRange:        rule.PortRange.NetworkPortRange(),
+			DestinationCIDRs: rule.DestinationCIDRs,
+		}
+	}
+	return result
+}
+
+// EgressRulesResult holds a single result of the
+// GetEgressRules() API calls.
+type EgressRulesResult struct {
+	Error *Error       `json:"error,omitempty"`
+	Rules []EgressRule `json:"rules,omitempty"`
+}
+
+// EgressRulesResults holds all the results of the
+// GetEgressRules() API calls.
+type EgressRulesResults struct {
+	Results []EgressRulesResult `json:"results"`
+}
+
+// MachineEgressRulesResult holds a single result of the
+// EgressFirewallerAPI.EgressRules() API call.
+type MachineEgressRulesResult struct {
+	Error *Error       `json:"error,omitempty"`
+	Rules []EgressRule `json:"rules,omitempty"`
+
+	// ProviderManaged is true if the rules are enforced by
+	// the provider, and must not be enforced on the machine.
+	ProviderManaged bool `json:"provider-managed"`
+}
+
+// MachineEgressRulesResults holds all the results of the
+// EgressFirewallerAPI.EgressRules() API call.
+type MachineEgressRulesResults struct {
+	Results []MachineEgressRulesResult `json:"results"`
+}
+
 // APIHostPortsResult holds the result of an APIHostPorts
 // call. Each element in the top level slice holds
 // the addresses for one API server.
@@ -694,6 +781,15 @@ type NetworkInfo struct {
 type NetworkInfoResult struct {
 	Error *Error        `json:"error,omitempty" yaml:"error,omitempty"`
 	Info  []NetworkInfo `json:"network-info" yaml:"info"`
+
+	// IngressAddresses holds the addresses advertised to related units
+	// in place of those of the unit, if the application overrides the
+	// ingress address of the binding's endpoint.
+	IngressAddresses []string `json:"ingress-addresses,omitempty" yaml:"ingress-addresses,omitempty"`
+
+	// IngressPort holds the port of the overriding ingress address,
+	// if one was specified.
+	IngressPort int `json:"ingress-port,omitempty" yaml:"ingress-port,omitempty"`
 }
 
 // NetworkInfoResults holds a mapping from binding name to NetworkInfoResult.
@@ -225,6 +225,7 @@ type ApplicationDeploy struct {
 	Storage          map[string]storage.Constraints `json:"storage,omitempty"`
 	EndpointBindings map[string]string              `json:"endpoint-bindings,omitempty"`
 	Resources        map[string]string              `json:"resources,omitempty"`
+	IngressAddresses map[string]string              `json:"ingress-addresses,omitempty"`
 }
 
 // ApplicationUpdate holds the parameters for making the application Update call.
@@ -281,6 +282,99 @@ type ApplicationSetCharm struct {
 // ApplicationExpose holds the parameters for making the application Expose call.
 type ApplicationExpose struct {
 	ApplicationName string `json:"application"`
+
+	// ExposedEndpoints holds the expose settings to merge into the
+	// application's existing settings, keyed by endpoint name. The
+	// settings with an empty endpoint name apply to all endpoints.
+	// If empty, the application is exposed to all sources. This
+	// field is only understood by Application facade version 5
+	// and greater.
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+}
+
+// ApplicationSetEgressRules holds the parameters for making the
+// application SetEgressRules call.
+type ApplicationSetEgressRules struct {
+	ApplicationName string `json:"application"`
+
+	// Rules holds the application's new egress policy. If empty,
+	// outgoing traffic from the application's units is unrestricted.
+	Rules []EgressRule `json:"rules"`
+}
+
+// ApplicationSetIngressAddresses holds the parameters for making the
+// application SetIngressAddresses call.
+type ApplicationSetIngressAddresses struct {
+	ApplicationName string `json:"application"`
+
+	// IngressAddresses holds the ingress address overrides to merge
+	// into those of the application, keyed by endpoint name. An empty
+	// address removes the override of the endpoint.
+	IngressAddresses map[string]string `json:"ingress-addresses"`
+}
+
+// IngressAddressesResult holds the ingress address overrides of an
+// application, keyed by endpoint name, or an error.
+type IngressAddressesResult struct {
+	IngressAddresses map[string]string `json:"ingress-addresses,omitempty"`
+	Error            *Error            `json:"error,omitempty"`
+}
+
+// IngressAddressesResults holds the results of the application
+// GetIngressAddresses call.
+type IngressAddressesResults struct {
+	Results []IngressAddressesResult `json:"results"`
+}
+
+// ApplicationSetActionConcurrency holds the parameters for making the
+// application SetActionConcurrency call.
+type ApplicationSetActionConcurrency struct {
+	ApplicationName string `json:"application"`
+
+	// Limit is the maximum number of actions that may run on the
+	// application's units at once; zero removes the limit.
+	Limit int `json:"limit"`
+}
+
+// ActionConcurrencyResult holds the action concurrency limit of an
+// application, or an error.
+type ActionConcurrencyResult struct {
+	Limit int    `json:"limit"`
+	Error *Error `json:"error,omitempty"`
+}
+
+// ActionConcurrencyResults holds the results of the application
+// GetActionConcurrency call.
+type ActionConcurrencyResults struct {
+	Results []ActionConcurrencyResult `json:"results"`
+}
+
+// ApplicationConfigHistoryResult holds the changes made to an
+// application's charm config, oldest revision first, or an error.
+type ApplicationConfigHistoryResult struct {
+	Revisions []ConfigRevision `json:"revisions"`
+	Error     *Error           `json:"error,omitempty"`
+}
+
+// ApplicationConfigHistoryResults holds the results of the application
+// GetConfigHistory call.
+type ApplicationConfigHistoryResults struct {
+	Results []ApplicationConfigHistoryResult `json:"results"`
+}
+
+// ApplicationConfigRevert holds the parameters for making the
+// application RevertConfig call.
+type ApplicationConfigRevert struct {
+	ApplicationName string `json:"application"`
+	Revision        int    `json:"revision"`
+}
+
+// ExposedEndpoint holds the sources that may access the ports
+// opened for an application endpoint when the application is exposed.
+type ExposedEndpoint struct {
+	// ExposeToCIDRs holds the CIDRs that may access the opened
+	// ports. If empty, the ports may be accessed from anywhere.
+	ExposeToCIDRs []string `json:"expose-to-cidrs,omitempty"`
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -326,6 +420,12 @@ type ApplicationCharmRelationsResults struct {
 // ApplicationUnexpose holds parameters for the application Unexpose call.
 type ApplicationUnexpose struct {
 	ApplicationName string `json:"application"`
+
+	// ExposedEndpoints holds the names of the endpoints whose expose
+	// settings are to be removed. If empty, the application is
+	// unexposed entirely. This field is only understood by
+	// Application facade version 5 and greater.
+	ExposedEndpoints []string `json:"exposed-endpoints,omitempty"`
 }
 
 // ApplicationMetricCredential holds parameters for the SetApplicationCredentials call.
@@ -547,6 +647,7 @@ type ContainerConfig struct {
 	Proxy                   proxy.Settings `json:"proxy"`
 	AptProxy                proxy.Settings `json:"apt-proxy"`
 	AptMirror               string         `json:"apt-mirror"`
+	PreferredAddressFamily  string         `json:"preferred-address-family,omitempty"`
 	*UpdateBehavior
 }
 
@@ -807,6 +908,7 @@ type LogRecord struct {
 	Level    string    `json:"v"`
 	Message  string    `json:"x"`
 	Entity   string    `json:"e,omitempty"`
+	Labels   []string  `json:"c,omitempty"`
 }
 
 // PubSubMessage is used to propagate pubsub messages from one api server to the
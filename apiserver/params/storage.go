@@ -3,7 +3,11 @@
 
 package params
 
-import "github.com/juju/juju/storage"
+import (
+	"time"
+
+	"github.com/juju/juju/storage"
+)
 
 // MachineBlockDevices holds a machine tag and the block devices present
 // on that machine.
@@ -94,6 +98,9 @@ type StorageAttachment struct {
 	Kind     StorageKind `json:"kind"`
 	Location string      `json:"location"`
 	Life     Life        `json:"life"`
+
+	// Size is the size of the attached storage in MiB, if known.
+	Size uint64 `json:"size,omitempty"`
 }
 
 // StorageAttachmentId identifies a storage attachment by the tags of the
@@ -216,6 +223,7 @@ type VolumeParams struct {
 	Attributes map[string]interface{}  `json:"attributes,omitempty"`
 	Tags       map[string]string       `json:"tags,omitempty"`
 	Attachment *VolumeAttachmentParams `json:"attachment,omitempty"`
+	SnapshotId string                  `json:"snapshot-id,omitempty"`
 }
 
 // VolumeAttachmentParams holds the parameters for creating a volume
@@ -276,6 +284,26 @@ type VolumeParamsResults struct {
 	Results []VolumeParamsResult `json:"results,omitempty"`
 }
 
+// VolumeResizeParams holds the parameters for growing a storage volume.
+type VolumeResizeParams struct {
+	VolumeTag  string                 `json:"volume-tag"`
+	VolumeId   string                 `json:"volume-id"`
+	Size       uint64                 `json:"size"`
+	Provider   string                 `json:"provider"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// VolumeResizeParamsResult holds resize parameters for a volume.
+type VolumeResizeParamsResult struct {
+	Result VolumeResizeParams `json:"result"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// VolumeResizeParamsResults holds resize parameters for multiple volumes.
+type VolumeResizeParamsResults struct {
+	Results []VolumeResizeParamsResult `json:"results,omitempty"`
+}
+
 // VolumeAttachmentParamsResults holds provisioning parameters for a volume
 // attachment.
 type VolumeAttachmentParamsResult struct {
@@ -725,9 +753,123 @@ type StorageAddParams struct {
 
 	// Constraints are specified storage constraints.
 	Constraints StorageConstraints `json:"storage"`
+
+	// FromSnapshot, if non-empty, is the ID of a volume snapshot
+	// from which the storage's volume is to be created. Constraints
+	// must not specify a pool or size if FromSnapshot is set.
+	FromSnapshot string `json:"from-snapshot,omitempty"`
 }
 
 // StoragesAddParams holds storage details to add to units dynamically.
 type StoragesAddParams struct {
 	Storages []StorageAddParams `json:"storages"`
 }
+
+// StorageResizeParams holds the details of a storage instance to grow.
+type StorageResizeParams struct {
+	// StorageTag is the tag of the storage instance to grow.
+	StorageTag string `json:"storage-tag"`
+
+	// Size is the requested size of the storage in MiB.
+	Size uint64 `json:"size"`
+}
+
+// StoragesResizeParams holds the details of storage instances to grow.
+type StoragesResizeParams struct {
+	Storages []StorageResizeParams `json:"storages"`
+}
+
+// ImportStorageParams contains the parameters for importing a storage
+// entity, created outside of Juju, into the model.
+type ImportStorageParams struct {
+	// Kind is the kind of the storage entity to import.
+	Kind StorageKind `json:"kind"`
+
+	// Pool is the name of the storage pool into which the storage
+	// entity will be imported.
+	Pool string `json:"pool"`
+
+	// ProviderId is the storage provider's unique ID for the
+	// storage entity, e.g. the EBS volume ID.
+	ProviderId string `json:"provider-id"`
+
+	// StorageName is the name of the storage as declared by the
+	// charm, e.g. "data" or "pgdata".
+	StorageName string `json:"storage-name"`
+
+	// Force controls whether storage tagged as belonging to another
+	// model is imported anyway.
+	Force bool `json:"force,omitempty"`
+}
+
+// BulkImportStorageParams contains the parameters for importing a
+// collection of storage entities.
+type BulkImportStorageParams struct {
+	Storage []ImportStorageParams `json:"storage"`
+}
+
+// ImportStorageDetails contains the details of an imported storage
+// entity.
+type ImportStorageDetails struct {
+	// StorageTag contains the string representation of the storage
+	// tag assigned to the imported storage entity.
+	StorageTag string `json:"storage-tag"`
+}
+
+// ImportStorageResult contains the result of importing a storage
+// entity.
+type ImportStorageResult struct {
+	Result *ImportStorageDetails `json:"result,omitempty"`
+	Error  *Error                `json:"error,omitempty"`
+}
+
+// ImportStorageResults contains the results of importing a
+// collection of storage entities.
+type ImportStorageResults struct {
+	Results []ImportStorageResult `json:"results"`
+}
+
+// VolumeSnapshotDetails describes a snapshot of a volume.
+type VolumeSnapshotDetails struct {
+	// Id is the Juju-assigned ID of the snapshot.
+	Id string `json:"id"`
+
+	// VolumeTag is the tag of the volume that was snapshotted.
+	VolumeTag string `json:"volume-tag"`
+
+	// Pool is the storage pool of the snapshotted volume.
+	Pool string `json:"pool"`
+
+	// SnapshotId is the provider-supplied ID of the snapshot.
+	SnapshotId string `json:"snapshot-id"`
+
+	// Size is the size of the snapshotted volume in MiB.
+	Size uint64 `json:"size"`
+
+	// Created is the time at which the snapshot was taken.
+	Created time.Time `json:"created"`
+}
+
+// VolumeSnapshotResult contains the result of creating a volume
+// snapshot.
+type VolumeSnapshotResult struct {
+	Result *VolumeSnapshotDetails `json:"result,omitempty"`
+	Error  *Error                 `json:"error,omitempty"`
+}
+
+// VolumeSnapshotResults contains the results of creating volume
+// snapshots.
+type VolumeSnapshotResults struct {
+	Results []VolumeSnapshotResult `json:"results"`
+}
+
+// VolumeSnapshotListResult contains the details of all volume
+// snapshots in a model.
+type VolumeSnapshotListResult struct {
+	Snapshots []VolumeSnapshotDetails `json:"snapshots"`
+}
+
+// VolumeSnapshotIds holds the IDs of volume snapshots.
+type VolumeSnapshotIds struct {
+	Ids []string `json:"ids"`
+}
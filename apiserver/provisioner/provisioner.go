@@ -276,6 +276,7 @@ func (p *ProvisionerAPI) ContainerConfig() (params.ContainerConfig, error) {
 	result.Proxy = config.ProxySettings()
 	result.AptProxy = config.AptProxySettings()
 	result.AptMirror = config.AptMirror()
+	result.PreferredAddressFamily = config.PreferredAddressFamily()
 
 	return result, nil
 }
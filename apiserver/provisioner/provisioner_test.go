@@ -1195,6 +1195,7 @@ func (s *withoutControllerSuite) TestContainerConfig(c *gc.C) {
 	c.Check(results.Proxy, gc.DeepEquals, expectedProxy)
 	c.Check(results.AptProxy, gc.DeepEquals, expectedAPTProxy)
 	c.Check(results.AptMirror, gc.DeepEquals, "http://example.mirror.com")
+	c.Check(results.PreferredAddressFamily, gc.Equals, "ipv4")
 }
 
 func (s *withoutControllerSuite) TestSetSupportedContainers(c *gc.C) {
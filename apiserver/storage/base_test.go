@@ -90,6 +90,7 @@ const (
 	volumeAttachmentCall                    = "volumeAttachment"
 	detachStorageCall                       = "detachStorage"
 	destroyStorageInstanceCall              = "destroyStorageInstance"
+	attachStorageCall                       = "attachStorage"
 )
 
 func (s *baseStorageSuite) constructState() *mockState {
@@ -251,6 +252,17 @@ func (s *baseStorageSuite) constructState() *mockState {
 				names.ReadableString(unit),
 			)
 		},
+		attachStorage: func(storage names.StorageTag, unit names.UnitTag) error {
+			s.stub.AddCall(attachStorageCall, storage, unit)
+			if storage == s.storageTag && unit == s.unitTag {
+				return nil
+			}
+			return errors.Errorf(
+				"cannot attach %s to %s",
+				names.ReadableString(storage),
+				names.ReadableString(unit),
+			)
+		},
 		destroyStorageInstance: func(tag names.StorageTag) error {
 			s.stub.AddCall(destroyStorageInstanceCall)
 			return errors.New("cannae do it")
@@ -0,0 +1,174 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/dummy"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type importSuite struct {
+	baseStorageSuite
+
+	volumeImporter     *mockVolumeImporter
+	filesystemImporter *mockFilesystemImporter
+}
+
+var _ = gc.Suite(&importSuite{})
+
+func (s *importSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+
+	s.volumeImporter = &mockVolumeImporter{}
+	s.filesystemImporter = &mockFilesystemImporter{}
+	s.pools["ebs"], _ = jujustorage.NewConfig("ebs", "ebs", nil)
+	s.pools["lxd"], _ = jujustorage.NewConfig("lxd", "lxd", nil)
+	s.pools["static"], _ = jujustorage.NewConfig("static", "static", nil)
+	s.registry.Providers["ebs"] = &dummy.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		VolumeSourceFunc: func(*jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return s.volumeImporter, nil
+		},
+	}
+	s.registry.Providers["lxd"] = &dummy.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		FilesystemSourceFunc: func(*jujustorage.Config) (jujustorage.FilesystemSource, error) {
+			return s.filesystemImporter, nil
+		},
+	}
+	s.registry.Providers["static"] = &dummy.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		VolumeSourceFunc: func(*jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return &dummy.VolumeSource{}, nil
+		},
+	}
+	s.registry.Providers["loop"] = &dummy.StorageProvider{
+		StorageScope: jujustorage.ScopeMachine,
+	}
+
+	s.state.modelConfig = coretesting.ModelConfig(c)
+	s.state.importVolume = func(info state.VolumeInfo, storageName string) (names.StorageTag, error) {
+		s.stub.AddCall("ImportVolume", info, storageName)
+		return names.NewStorageTag(storageName + "/0"), s.stub.NextErr()
+	}
+	s.state.importFilesystem = func(info state.FilesystemInfo, storageName string) (names.StorageTag, error) {
+		s.stub.AddCall("ImportFilesystem", info, storageName)
+		return names.NewStorageTag(storageName + "/1"), s.stub.NextErr()
+	}
+}
+
+func (s *importSuite) TestImportVolume(c *gc.C) {
+	results, err := s.api.Import(params.BulkImportStorageParams{
+		Storage: []params.ImportStorageParams{{
+			Kind:        params.StorageKindBlock,
+			Pool:        "ebs",
+			ProviderId:  "vol-1234",
+			StorageName: "pgdata",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ImportStorageResults{
+		Results: []params.ImportStorageResult{{
+			Result: &params.ImportStorageDetails{StorageTag: "storage-pgdata-0"},
+		}},
+	})
+
+	s.volumeImporter.CheckCallNames(c, "ImportVolume")
+	args := s.volumeImporter.Calls()[0].Args
+	c.Assert(args[0], gc.Equals, "vol-1234")
+	resourceTags := args[1].(map[string]string)
+	c.Assert(resourceTags["juju-model-uuid"], gc.Equals, coretesting.ModelTag.Id())
+	c.Assert(resourceTags["juju-controller-uuid"], gc.Equals, coretesting.ControllerTag.Id())
+	c.Assert(args[2], jc.IsFalse)
+
+	s.stub.CheckCall(c, len(s.stub.Calls())-1, "ImportVolume", state.VolumeInfo{
+		Pool:       "ebs",
+		VolumeId:   "vol-1234",
+		Size:       1024,
+		Persistent: true,
+	}, "pgdata")
+}
+
+func (s *importSuite) TestImportFilesystem(c *gc.C) {
+	results, err := s.api.Import(params.BulkImportStorageParams{
+		Storage: []params.ImportStorageParams{{
+			Kind:        params.StorageKindFilesystem,
+			Pool:        "lxd",
+			ProviderId:  "lxd:juju-data",
+			StorageName: "data",
+			Force:       true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ImportStorageResults{
+		Results: []params.ImportStorageResult{{
+			Result: &params.ImportStorageDetails{StorageTag: "storage-data-1"},
+		}},
+	})
+	s.filesystemImporter.CheckCallNames(c, "ImportFilesystem")
+	c.Assert(s.filesystemImporter.Calls()[0].Args[2], jc.IsTrue)
+	s.stub.CheckCall(c, len(s.stub.Calls())-1, "ImportFilesystem", state.FilesystemInfo{
+		Pool:         "lxd",
+		FilesystemId: "lxd:juju-data",
+		Size:         2048,
+	}, "data")
+}
+
+func (s *importSuite) TestImportNotSupported(c *gc.C) {
+	results, err := s.api.Import(params.BulkImportStorageParams{
+		Storage: []params.ImportStorageParams{{
+			Kind:        params.StorageKindBlock,
+			Pool:        "static",
+			ProviderId:  "vol-1234",
+			StorageName: "pgdata",
+		}, {
+			Kind:        params.StorageKindBlock,
+			Pool:        "loop",
+			ProviderId:  "loop0",
+			StorageName: "pgdata",
+		}, {
+			Kind:        params.StorageKindFilesystem,
+			Pool:        "ebs",
+			ProviderId:  "vol-1234",
+			StorageName: "pgdata",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `importing "static" volumes not supported`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `importing "loop" storage not supported`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `filesystems not supported`)
+}
+
+func (s *importSuite) TestImportProviderError(c *gc.C) {
+	s.volumeImporter.SetErrors(errors.New("volume in use"))
+	results, err := s.api.Import(params.BulkImportStorageParams{
+		Storage: []params.ImportStorageParams{{
+			Kind:        params.StorageKindBlock,
+			Pool:        "ebs",
+			ProviderId:  "vol-1234",
+			StorageName: "pgdata",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `importing volume "vol-1234": volume in use`)
+	for _, call := range s.stub.Calls() {
+		c.Assert(call.FuncName, gc.Not(gc.Equals), "ImportVolume")
+	}
+}
+
+func (s *importSuite) TestImportBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestImportBlocked")
+	_, err := s.api.Import(params.BulkImportStorageParams{})
+	s.assertBlocked(c, err, "TestImportBlocked")
+}
@@ -4,13 +4,19 @@
 package storage_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/testing"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/dummy"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type mockPoolManager struct {
@@ -64,6 +70,16 @@ type mockState struct {
 	blockDevices                        func(names.MachineTag) ([]state.BlockDeviceInfo, error)
 	destroyStorageInstance              func(names.StorageTag) error
 	detachStorage                       func(names.StorageTag, names.UnitTag) error
+	modelConfig                         *config.Config
+	addVolumeSnapshot                   func(names.VolumeTag, state.VolumeSnapshotInfo) (state.VolumeSnapshot, error)
+	volumeSnapshot                      func(string) (state.VolumeSnapshot, error)
+	volumeSnapshots                     func() ([]state.VolumeSnapshot, error)
+	removeVolumeSnapshot                func(string) error
+	addStorageFromSnapshot              func(names.UnitTag, string, string) error
+	resizeStorageInstance               func(names.StorageTag, uint64) error
+	attachStorage                       func(names.StorageTag, names.UnitTag) error
+	importVolume                        func(state.VolumeInfo, string) (names.StorageTag, error)
+	importFilesystem                    func(state.FilesystemInfo, string) (names.StorageTag, error)
 }
 
 func (st *mockState) StorageInstance(s names.StorageTag) (state.StorageInstance, error) {
@@ -177,6 +193,50 @@ func (st *mockState) DestroyStorageInstance(tag names.StorageTag) error {
 	return st.destroyStorageInstance(tag)
 }
 
+func (st *mockState) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (st *mockState) ModelConfig() (*config.Config, error) {
+	return st.modelConfig, nil
+}
+
+func (st *mockState) AddVolumeSnapshot(tag names.VolumeTag, info state.VolumeSnapshotInfo) (state.VolumeSnapshot, error) {
+	return st.addVolumeSnapshot(tag, info)
+}
+
+func (st *mockState) VolumeSnapshot(id string) (state.VolumeSnapshot, error) {
+	return st.volumeSnapshot(id)
+}
+
+func (st *mockState) VolumeSnapshots() ([]state.VolumeSnapshot, error) {
+	return st.volumeSnapshots()
+}
+
+func (st *mockState) RemoveVolumeSnapshot(id string) error {
+	return st.removeVolumeSnapshot(id)
+}
+
+func (st *mockState) AddStorageFromSnapshot(u names.UnitTag, name, snapshotId string) error {
+	return st.addStorageFromSnapshot(u, name, snapshotId)
+}
+
+func (st *mockState) ResizeStorageInstance(tag names.StorageTag, size uint64) error {
+	return st.resizeStorageInstance(tag, size)
+}
+
+func (st *mockState) AttachStorage(storage names.StorageTag, unit names.UnitTag) error {
+	return st.attachStorage(storage, unit)
+}
+
+func (st *mockState) ImportVolume(info state.VolumeInfo, storageName string) (names.StorageTag, error) {
+	return st.importVolume(info, storageName)
+}
+
+func (st *mockState) ImportFilesystem(info state.FilesystemInfo, storageName string) (names.StorageTag, error) {
+	return st.importFilesystem(info, storageName)
+}
+
 type mockNotifyWatcher struct {
 	state.NotifyWatcher
 	changes chan struct{}
@@ -389,3 +449,92 @@ func (b mockBlock) Type() state.BlockType {
 func (b mockBlock) Message() string {
 	return b.msg
 }
+
+type mockVolumeSnapshot struct {
+	id         string
+	volume     names.VolumeTag
+	pool       string
+	snapshotId string
+	size       uint64
+	created    time.Time
+}
+
+func (m *mockVolumeSnapshot) Id() string {
+	return m.id
+}
+
+func (m *mockVolumeSnapshot) Volume() names.VolumeTag {
+	return m.volume
+}
+
+func (m *mockVolumeSnapshot) Pool() string {
+	return m.pool
+}
+
+func (m *mockVolumeSnapshot) SnapshotId() string {
+	return m.snapshotId
+}
+
+func (m *mockVolumeSnapshot) Size() uint64 {
+	return m.size
+}
+
+func (m *mockVolumeSnapshot) Created() time.Time {
+	return m.created
+}
+
+type mockVolumeSnapshotter struct {
+	dummy.VolumeSource
+	createSnapshots func([]jujustorage.SnapshotParams) ([]jujustorage.CreateSnapshotsResult, error)
+	deleteSnapshots func([]string) ([]error, error)
+}
+
+func (m *mockVolumeSnapshotter) CreateSnapshots(params []jujustorage.SnapshotParams) ([]jujustorage.CreateSnapshotsResult, error) {
+	m.MethodCall(m, "CreateSnapshots", params)
+	return m.createSnapshots(params)
+}
+
+func (m *mockVolumeSnapshotter) ListSnapshots() ([]string, error) {
+	m.MethodCall(m, "ListSnapshots")
+	return nil, m.NextErr()
+}
+
+func (m *mockVolumeSnapshotter) DeleteSnapshots(ids []string) ([]error, error) {
+	m.MethodCall(m, "DeleteSnapshots", ids)
+	return m.deleteSnapshots(ids)
+}
+
+type mockVolumeResizer struct {
+	dummy.VolumeSource
+}
+
+func (m *mockVolumeResizer) ResizeVolumes(params []jujustorage.ResizeVolumeParams) ([]jujustorage.ResizeVolumesResult, error) {
+	m.MethodCall(m, "ResizeVolumes", params)
+	return make([]jujustorage.ResizeVolumesResult, len(params)), m.NextErr()
+}
+
+type mockVolumeImporter struct {
+	dummy.VolumeSource
+}
+
+func (m *mockVolumeImporter) ImportVolume(volumeId string, resourceTags map[string]string, force bool) (jujustorage.VolumeInfo, error) {
+	m.MethodCall(m, "ImportVolume", volumeId, resourceTags, force)
+	return jujustorage.VolumeInfo{
+		VolumeId:   volumeId,
+		Size:       1024,
+		Persistent: true,
+	}, m.NextErr()
+}
+
+type mockFilesystemImporter struct {
+	jujustorage.FilesystemSource
+	testing.Stub
+}
+
+func (m *mockFilesystemImporter) ImportFilesystem(filesystemId string, resourceTags map[string]string, force bool) (jujustorage.FilesystemInfo, error) {
+	m.MethodCall(m, "ImportFilesystem", filesystemId, resourceTags, force)
+	return jujustorage.FilesystemInfo{
+		FilesystemId: filesystemId,
+		Size:         2048,
+	}, m.NextErr()
+}
@@ -0,0 +1,103 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/dummy"
+)
+
+type resizeSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&resizeSuite{})
+
+func (s *resizeSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+
+	s.volume.info = &state.VolumeInfo{
+		Pool:     "elastic",
+		VolumeId: "vol-1234",
+		Size:     1024,
+	}
+	s.pools["elastic"], _ = jujustorage.NewConfig("elastic", "elastic", nil)
+	s.pools["static"], _ = jujustorage.NewConfig("static", "static", nil)
+	s.registry.Providers["elastic"] = &dummy.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		VolumeSourceFunc: func(*jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return &mockVolumeResizer{}, nil
+		},
+	}
+	s.registry.Providers["static"] = &dummy.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		VolumeSourceFunc: func(*jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return &dummy.VolumeSource{}, nil
+		},
+	}
+	s.registry.Providers["loop"] = &dummy.StorageProvider{
+		StorageScope: jujustorage.ScopeMachine,
+	}
+	s.state.resizeStorageInstance = func(tag names.StorageTag, size uint64) error {
+		s.stub.AddCall("ResizeStorageInstance", tag, size)
+		return s.stub.NextErr()
+	}
+}
+
+func (s *resizeSuite) TestResizeStorage(c *gc.C) {
+	results, err := s.api.ResizeStorage(params.StoragesResizeParams{
+		Storages: []params.StorageResizeParams{
+			{StorageTag: s.storageTag.String(), Size: 2048},
+			{StorageTag: "volume-0", Size: 2048},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+		},
+	})
+	s.stub.CheckCall(c, len(s.stub.Calls())-1, "ResizeStorageInstance", s.storageTag, uint64(2048))
+}
+
+func (s *resizeSuite) TestResizeStorageNotSupported(c *gc.C) {
+	s.volume.info.Pool = "static"
+	results, err := s.api.ResizeStorage(params.StoragesResizeParams{
+		Storages: []params.StorageResizeParams{{StorageTag: s.storageTag.String(), Size: 2048}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `resizing "static" volumes not supported`)
+	for _, call := range s.stub.Calls() {
+		c.Assert(call.FuncName, gc.Not(gc.Equals), "ResizeStorageInstance")
+	}
+}
+
+func (s *resizeSuite) TestResizeStorageMachineScoped(c *gc.C) {
+	// Volumes managed by machine-scoped providers can only be
+	// checked by the storage provisioner on the machine.
+	s.volume.info.Pool = "loop"
+	results, err := s.api.ResizeStorage(params.StoragesResizeParams{
+		Storages: []params.StorageResizeParams{{StorageTag: s.storageTag.String(), Size: 2048}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.stub.CheckCall(c, len(s.stub.Calls())-1, "ResizeStorageInstance", s.storageTag, uint64(2048))
+}
+
+func (s *resizeSuite) TestResizeStorageBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestResizeStorageBlocked")
+	_, err := s.api.ResizeStorage(params.StoragesResizeParams{
+		Storages: []params.StorageResizeParams{{StorageTag: s.storageTag.String(), Size: 2048}},
+	})
+	s.assertBlocked(c, err, "TestResizeStorageBlocked")
+}
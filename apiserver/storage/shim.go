@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
 	"github.com/juju/juju/storage/poolmanager"
@@ -116,6 +117,39 @@ type storageAccess interface {
 
 	// DestroyStorageInstance destroys the storage instance with the specified tag.
 	DestroyStorageInstance(names.StorageTag) error
+
+	// ControllerTag is required for tagging volume snapshots.
+	ControllerTag() names.ControllerTag
+
+	// ModelConfig is required for tagging volume snapshots.
+	ModelConfig() (*config.Config, error)
+
+	// AddVolumeSnapshot is required for volume snapshot functionality.
+	AddVolumeSnapshot(names.VolumeTag, state.VolumeSnapshotInfo) (state.VolumeSnapshot, error)
+
+	// VolumeSnapshot is required for volume snapshot functionality.
+	VolumeSnapshot(id string) (state.VolumeSnapshot, error)
+
+	// VolumeSnapshots is required for volume snapshot functionality.
+	VolumeSnapshots() ([]state.VolumeSnapshot, error)
+
+	// RemoveVolumeSnapshot is required for volume snapshot functionality.
+	RemoveVolumeSnapshot(id string) error
+
+	// AddStorageFromSnapshot is required for storage add functionality.
+	AddStorageFromSnapshot(tag names.UnitTag, name, snapshotId string) error
+
+	// ResizeStorageInstance is required for storage resize functionality.
+	ResizeStorageInstance(tag names.StorageTag, size uint64) error
+
+	// AttachStorage is required for storage attach functionality.
+	AttachStorage(names.StorageTag, names.UnitTag) error
+
+	// ImportVolume is required for storage import functionality.
+	ImportVolume(info state.VolumeInfo, storageName string) (names.StorageTag, error)
+
+	// ImportFilesystem is required for storage import functionality.
+	ImportFilesystem(info state.FilesystemInfo, storageName string) (names.StorageTag, error)
 }
 
 var getState = func(st *state.State) storageAccess {
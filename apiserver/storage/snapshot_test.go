@@ -0,0 +1,241 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/dummy"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type snapshotSuite struct {
+	baseStorageSuite
+
+	snapshotter *mockVolumeSnapshotter
+	snapshots   map[string]state.VolumeSnapshot
+	created     time.Time
+}
+
+var _ = gc.Suite(&snapshotSuite{})
+
+func (s *snapshotSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.created = time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.snapshots = make(map[string]state.VolumeSnapshot)
+
+	s.volume.info = &state.VolumeInfo{
+		Pool:     "ebs-ssd",
+		VolumeId: "vol-1234",
+		Size:     1024,
+	}
+	s.pools["ebs-ssd"], _ = jujustorage.NewConfig("ebs-ssd", "ebs", nil)
+	s.snapshotter = &mockVolumeSnapshotter{
+		createSnapshots: func(args []jujustorage.SnapshotParams) ([]jujustorage.CreateSnapshotsResult, error) {
+			return []jujustorage.CreateSnapshotsResult{{
+				Snapshot: &jujustorage.Snapshot{
+					SnapshotId: "snap-1234",
+					Volume:     args[0].Volume,
+					Size:       1024,
+				},
+			}}, nil
+		},
+		deleteSnapshots: func(ids []string) ([]error, error) {
+			return make([]error, len(ids)), nil
+		},
+	}
+	s.registry.Providers["ebs"] = &dummy.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		VolumeSourceFunc: func(*jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return s.snapshotter, nil
+		},
+	}
+	s.registry.Providers["loop"] = &dummy.StorageProvider{
+		StorageScope: jujustorage.ScopeMachine,
+	}
+
+	s.state.modelConfig = coretesting.ModelConfig(c)
+	s.state.addVolumeSnapshot = func(tag names.VolumeTag, info state.VolumeSnapshotInfo) (state.VolumeSnapshot, error) {
+		s.stub.AddCall("AddVolumeSnapshot", tag, info)
+		if err := s.stub.NextErr(); err != nil {
+			return nil, err
+		}
+		snapshot := &mockVolumeSnapshot{
+			id:         "0",
+			volume:     tag,
+			pool:       "ebs-ssd",
+			snapshotId: info.SnapshotId,
+			size:       info.Size,
+			created:    s.created,
+		}
+		s.snapshots[snapshot.id] = snapshot
+		return snapshot, nil
+	}
+	s.state.volumeSnapshot = func(id string) (state.VolumeSnapshot, error) {
+		s.stub.AddCall("VolumeSnapshot", id)
+		if snapshot, ok := s.snapshots[id]; ok {
+			return snapshot, nil
+		}
+		return nil, errors.NotFoundf("volume snapshot %q", id)
+	}
+	s.state.volumeSnapshots = func() ([]state.VolumeSnapshot, error) {
+		s.stub.AddCall("VolumeSnapshots")
+		var snapshots []state.VolumeSnapshot
+		for _, snapshot := range s.snapshots {
+			snapshots = append(snapshots, snapshot)
+		}
+		return snapshots, nil
+	}
+	s.state.removeVolumeSnapshot = func(id string) error {
+		s.stub.AddCall("RemoveVolumeSnapshot", id)
+		delete(s.snapshots, id)
+		return nil
+	}
+}
+
+func (s *snapshotSuite) TestCreateSnapshots(c *gc.C) {
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{
+		{s.volumeTag.String()},
+		{s.storageTag.String()},
+		{"volume-42"},
+		{"machine-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	expected := &params.VolumeSnapshotDetails{
+		Id:         "0",
+		VolumeTag:  s.volumeTag.String(),
+		Pool:       "ebs-ssd",
+		SnapshotId: "snap-1234",
+		Size:       1024,
+		Created:    s.created,
+	}
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotResults{
+		Results: []params.VolumeSnapshotResult{
+			{Result: expected},
+			{Result: expected},
+			{Error: &params.Error{Code: params.CodeNotFound, Message: "volume 42 not found"}},
+			{Error: &params.Error{Message: `tag kind "machine" not valid`}},
+		},
+	})
+
+	s.snapshotter.CheckCallNames(c, "CreateSnapshots", "CreateSnapshots")
+	args := s.snapshotter.Calls()[0].Args[0].([]jujustorage.SnapshotParams)
+	c.Assert(args, gc.HasLen, 1)
+	c.Assert(args[0].Volume, gc.Equals, s.volumeTag)
+	c.Assert(args[0].VolumeId, gc.Equals, "vol-1234")
+	c.Assert(args[0].ResourceTags["juju-model-uuid"], gc.Equals, coretesting.ModelTag.Id())
+	c.Assert(args[0].ResourceTags["juju-controller-uuid"], gc.Equals, coretesting.ControllerTag.Id())
+}
+
+func (s *snapshotSuite) TestCreateSnapshotsNotSupported(c *gc.C) {
+	s.volume.info.Pool = "loop"
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{{s.volumeTag.String()}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `snapshots of "loop" volumes not supported`)
+}
+
+func (s *snapshotSuite) TestCreateSnapshotsUnprovisioned(c *gc.C) {
+	s.volume.info = nil
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{{s.volumeTag.String()}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `volume-22 not provisioned`)
+	s.snapshotter.CheckNoCalls(c)
+}
+
+func (s *snapshotSuite) TestCreateSnapshotsRecordFails(c *gc.C) {
+	s.stub.SetErrors(errors.New("boom"))
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{{s.volumeTag.String()}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "boom")
+
+	// The snapshot could not be recorded, so it must be deleted.
+	s.snapshotter.CheckCallNames(c, "CreateSnapshots", "DeleteSnapshots")
+	s.snapshotter.CheckCall(c, 1, "DeleteSnapshots", []string{"snap-1234"})
+}
+
+func (s *snapshotSuite) TestCreateSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestCreateSnapshotsBlocked")
+	_, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{{s.volumeTag.String()}}})
+	s.assertBlocked(c, err, "TestCreateSnapshotsBlocked")
+}
+
+func (s *snapshotSuite) TestListSnapshots(c *gc.C) {
+	s.snapshots["0"] = &mockVolumeSnapshot{
+		id:         "0",
+		volume:     s.volumeTag,
+		pool:       "ebs-ssd",
+		snapshotId: "snap-1234",
+		size:       1024,
+		created:    s.created,
+	}
+	result, err := s.api.ListSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.VolumeSnapshotListResult{
+		Snapshots: []params.VolumeSnapshotDetails{{
+			Id:         "0",
+			VolumeTag:  "volume-22",
+			Pool:       "ebs-ssd",
+			SnapshotId: "snap-1234",
+			Size:       1024,
+			Created:    s.created,
+		}},
+	})
+}
+
+func (s *snapshotSuite) TestDestroySnapshots(c *gc.C) {
+	s.snapshots["0"] = &mockVolumeSnapshot{
+		id:         "0",
+		volume:     s.volumeTag,
+		pool:       "ebs-ssd",
+		snapshotId: "snap-1234",
+	}
+	results, err := s.api.DestroySnapshots(params.VolumeSnapshotIds{[]string{"0", "1"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Code: params.CodeNotFound, Message: `volume snapshot "1" not found`}},
+		},
+	})
+	s.snapshotter.CheckCallNames(c, "DeleteSnapshots")
+	s.snapshotter.CheckCall(c, 0, "DeleteSnapshots", []string{"snap-1234"})
+	c.Assert(s.snapshots, gc.HasLen, 0)
+}
+
+func (s *snapshotSuite) TestDestroySnapshotsProviderError(c *gc.C) {
+	s.snapshots["0"] = &mockVolumeSnapshot{
+		id:         "0",
+		volume:     s.volumeTag,
+		pool:       "ebs-ssd",
+		snapshotId: "snap-1234",
+	}
+	s.snapshotter.deleteSnapshots = func(ids []string) ([]error, error) {
+		return []error{errors.New("snapshot in use")}, nil
+	}
+	results, err := s.api.DestroySnapshots(params.VolumeSnapshotIds{[]string{"0"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `deleting volume snapshot "0": snapshot in use`)
+
+	// The snapshot must still be recorded, so that it
+	// can be destroyed later.
+	c.Assert(s.snapshots, gc.HasLen, 1)
+}
+
+func (s *snapshotSuite) TestDestroySnapshotsBlocked(c *gc.C) {
+	s.blockRemoveObject(c, "TestDestroySnapshotsBlocked")
+	_, err := s.api.DestroySnapshots(params.VolumeSnapshotIds{[]string{"0"}})
+	s.assertBlocked(c, err, "TestDestroySnapshotsBlocked")
+}
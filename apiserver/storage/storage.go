@@ -7,6 +7,7 @@ package storage
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
@@ -21,6 +23,8 @@ import (
 	"github.com/juju/juju/storage/poolmanager"
 )
 
+var logger = loggo.GetLogger("juju.apiserver.storage")
+
 // API implements the storage interface and is the concrete
 // implementation of the api end point.
 type API struct {
@@ -718,7 +722,11 @@ func (a *API) AddToUnit(args params.StoragesAddParams) (params.ErrorResults, err
 			continue
 		}
 
-		err = a.storage.AddStorageForUnit(u, one.StorageName, paramsToState(one.Constraints))
+		if one.FromSnapshot != "" {
+			err = a.addStorageFromSnapshot(u, one)
+		} else {
+			err = a.storage.AddStorageForUnit(u, one.StorageName, paramsToState(one.Constraints))
+		}
 		if err != nil {
 			result[i] = params.ErrorResult{Error: common.ServerError(err)}
 		}
@@ -726,6 +734,18 @@ func (a *API) AddToUnit(args params.StoragesAddParams) (params.ErrorResults, err
 	return params.ErrorResults{Results: result}, nil
 }
 
+func (a *API) addStorageFromSnapshot(u names.UnitTag, args params.StorageAddParams) error {
+	// The pool and size of the new volume are those of the
+	// snapshotted volume.
+	if args.Constraints.Pool != "" || args.Constraints.Size != nil {
+		return errors.NotValidf("storage pool or size with a snapshot")
+	}
+	if args.Constraints.Count != nil && *args.Constraints.Count != 1 {
+		return errors.NotValidf("storage count %d with a snapshot", *args.Constraints.Count)
+	}
+	return a.storage.AddStorageFromSnapshot(u, args.StorageName, args.FromSnapshot)
+}
+
 // Destroy sets the specified storage entities to Dying, unless they are
 // already Dying or Dead.
 func (a *API) Destroy(args params.Entities) (params.ErrorResults, error) {
@@ -851,10 +871,383 @@ func (a *API) Attach(args params.StorageAttachmentIds) (params.ErrorResults, err
 	for i, arg := range args.Ids {
 		result[i].Error = common.ServerError(attachOne(arg))
 	}
-	return params.ErrorResults{Results: result}, errors.NotImplementedf("attaching storage")
+	return params.ErrorResults{Results: result}, nil
 }
 
 func (a *API) attachStorage(storageTag names.StorageTag, unitTag names.UnitTag) error {
-	// TODO(axw)
+	return a.storage.AttachStorage(storageTag, unitTag)
+}
+
+// Import imports existing storage, created outside of Juju, into the
+// model. The imported storage has no owner until it is attached to a
+// unit with Attach. Only storage managed by a provider that supports
+// importing may be imported.
+// A "CHANGE" block can block this operation.
+func (a *API) Import(args params.BulkImportStorageParams) (params.ImportStorageResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+
+	modelConfig, err := a.storage.ModelConfig()
+	if err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+	resourceTags := tags.ResourceTags(
+		names.NewModelTag(modelConfig.UUID()),
+		a.storage.ControllerTag(),
+		modelConfig,
+	)
+
+	results := make([]params.ImportStorageResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		details, err := a.importStorage(arg, resourceTags)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = details
+	}
+	return params.ImportStorageResults{Results: results}, nil
+}
+
+func (a *API) importStorage(
+	arg params.ImportStorageParams,
+	resourceTags map[string]string,
+) (*params.ImportStorageDetails, error) {
+	providerType, cfg, err := storagecommon.StoragePoolConfig(arg.Pool, a.poolManager, a.registry)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	provider, err := a.registry.StorageProvider(providerType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if provider.Scope() != storage.ScopeEnviron {
+		return nil, errors.NotSupportedf("importing %q storage", providerType)
+	}
+	var storageTag names.StorageTag
+	switch arg.Kind {
+	case params.StorageKindBlock:
+		source, err := provider.VolumeSource(cfg)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		importer, ok := source.(storage.VolumeImporter)
+		if !ok {
+			return nil, errors.NotSupportedf("importing %q volumes", providerType)
+		}
+		info, err := importer.ImportVolume(arg.ProviderId, resourceTags, arg.Force)
+		if err != nil {
+			return nil, errors.Annotatef(err, "importing volume %q", arg.ProviderId)
+		}
+		storageTag, err = a.storage.ImportVolume(state.VolumeInfo{
+			HardwareId: info.HardwareId,
+			WWN:        info.WWN,
+			Size:       info.Size,
+			Pool:       arg.Pool,
+			VolumeId:   info.VolumeId,
+			Persistent: info.Persistent,
+		}, arg.StorageName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	case params.StorageKindFilesystem:
+		source, err := provider.FilesystemSource(cfg)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		importer, ok := source.(storage.FilesystemImporter)
+		if !ok {
+			return nil, errors.NotSupportedf("importing %q filesystems", providerType)
+		}
+		info, err := importer.ImportFilesystem(arg.ProviderId, resourceTags, arg.Force)
+		if err != nil {
+			return nil, errors.Annotatef(err, "importing filesystem %q", arg.ProviderId)
+		}
+		storageTag, err = a.storage.ImportFilesystem(state.FilesystemInfo{
+			Size:         info.Size,
+			Pool:         arg.Pool,
+			FilesystemId: info.FilesystemId,
+		}, arg.StorageName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	default:
+		return nil, errors.NotValidf("storage kind %q", arg.Kind.String())
+	}
+	return &params.ImportStorageDetails{StorageTag: storageTag.String()}, nil
+}
+
+// CreateSnapshots creates snapshots of the volumes identified by the
+// specified volume or storage tags. Only volumes managed by a storage
+// provider that supports snapshots may be snapshotted.
+// A "CHANGE" block can block this operation.
+func (a *API) CreateSnapshots(args params.Entities) (params.VolumeSnapshotResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.VolumeSnapshotResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.VolumeSnapshotResults{}, errors.Trace(err)
+	}
+
+	modelConfig, err := a.storage.ModelConfig()
+	if err != nil {
+		return params.VolumeSnapshotResults{}, errors.Trace(err)
+	}
+	resourceTags := tags.ResourceTags(
+		names.NewModelTag(modelConfig.UUID()),
+		a.storage.ControllerTag(),
+		modelConfig,
+	)
+
+	results := make([]params.VolumeSnapshotResult, len(args.Entities))
+	for i, one := range args.Entities {
+		volumeTag, err := a.snapshotVolumeTag(one.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		snapshot, err := a.createSnapshot(volumeTag, resourceTags)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		details := createVolumeSnapshotDetails(snapshot)
+		results[i].Result = &details
+	}
+	return params.VolumeSnapshotResults{Results: results}, nil
+}
+
+// snapshotVolumeTag returns the tag of the volume identified by the
+// given volume or storage tag.
+func (a *API) snapshotVolumeTag(tagString string) (names.VolumeTag, error) {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return names.VolumeTag{}, errors.Trace(err)
+	}
+	switch tag := tag.(type) {
+	case names.VolumeTag:
+		return tag, nil
+	case names.StorageTag:
+		volume, err := a.storage.StorageInstanceVolume(tag)
+		if err != nil {
+			return names.VolumeTag{}, errors.Trace(err)
+		}
+		return volume.VolumeTag(), nil
+	}
+	return names.VolumeTag{}, errors.NotValidf("tag kind %q", tag.Kind())
+}
+
+func (a *API) createSnapshot(tag names.VolumeTag, resourceTags map[string]string) (state.VolumeSnapshot, error) {
+	volume, err := a.storage.Volume(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info, err := volume.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshotter, err := a.volumeSnapshotter(info.Pool)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results, err := snapshotter.CreateSnapshots([]storage.SnapshotParams{{
+		Volume:       tag,
+		VolumeId:     info.VolumeId,
+		ResourceTags: resourceTags,
+	}})
+	if err != nil {
+		return nil, errors.Annotatef(err, "creating snapshot of volume %q", tag.Id())
+	}
+	if results[0].Error != nil {
+		return nil, errors.Annotatef(results[0].Error, "creating snapshot of volume %q", tag.Id())
+	}
+	snapshot, err := a.storage.AddVolumeSnapshot(tag, state.VolumeSnapshotInfo{
+		SnapshotId: results[0].Snapshot.SnapshotId,
+		Size:       results[0].Snapshot.Size,
+	})
+	if err != nil {
+		// The snapshot could not be recorded, so remove it
+		// from the provider rather than leaking it.
+		if _, err := snapshotter.DeleteSnapshots([]string{results[0].Snapshot.SnapshotId}); err != nil {
+			logger.Errorf("deleting unrecorded snapshot of volume %q: %v", tag.Id(), err)
+		}
+		return nil, errors.Trace(err)
+	}
+	return snapshot, nil
+}
+
+// volumeSnapshotter returns a storage.VolumeSnapshotter for volumes in
+// the named storage pool, or a NotSupported error if the pool's
+// provider cannot snapshot volumes from the controller.
+func (a *API) volumeSnapshotter(pool string) (storage.VolumeSnapshotter, error) {
+	providerType, cfg, err := storagecommon.StoragePoolConfig(pool, a.poolManager, a.registry)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	provider, err := a.registry.StorageProvider(providerType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if provider.Scope() != storage.ScopeEnviron {
+		return nil, errors.NotSupportedf("snapshots of %q volumes", providerType)
+	}
+	source, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshotter, ok := source.(storage.VolumeSnapshotter)
+	if !ok {
+		return nil, errors.NotSupportedf("snapshots of %q volumes", providerType)
+	}
+	return snapshotter, nil
+}
+
+// ListSnapshots returns the details of all volume snapshots in
+// the model.
+func (a *API) ListSnapshots() (params.VolumeSnapshotListResult, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.VolumeSnapshotListResult{}, errors.Trace(err)
+	}
+	snapshots, err := a.storage.VolumeSnapshots()
+	if err != nil {
+		return params.VolumeSnapshotListResult{}, errors.Trace(err)
+	}
+	result := params.VolumeSnapshotListResult{
+		Snapshots: make([]params.VolumeSnapshotDetails, len(snapshots)),
+	}
+	for i, snapshot := range snapshots {
+		result.Snapshots[i] = createVolumeSnapshotDetails(snapshot)
+	}
+	return result, nil
+}
+
+// DestroySnapshots deletes the volume snapshots with the specified
+// IDs from their storage providers, and removes them from the model.
+// A "REMOVE" block can block this operation.
+func (a *API) DestroySnapshots(args params.VolumeSnapshotIds) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Ids))
+	for i, id := range args.Ids {
+		result[i].Error = common.ServerError(a.destroySnapshot(id))
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
+func (a *API) destroySnapshot(id string) error {
+	snapshot, err := a.storage.VolumeSnapshot(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	snapshotter, err := a.volumeSnapshotter(snapshot.Pool())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	errs, err := snapshotter.DeleteSnapshots([]string{snapshot.SnapshotId()})
+	if err != nil {
+		return errors.Annotatef(err, "deleting volume snapshot %q", id)
+	}
+	if errs[0] != nil {
+		return errors.Annotatef(errs[0], "deleting volume snapshot %q", id)
+	}
+	return a.storage.RemoveVolumeSnapshot(id)
+}
+
+// ResizeStorage records requests to grow the specified storage
+// instances. The volumes backing the storage are resized by the
+// storage provisioner, after which the units that the storage is
+// attached to are informed with the "storage-resized" hook.
+// A "CHANGE" block can block this operation.
+func (a *API) ResizeStorage(args params.StoragesResizeParams) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Storages))
+	for i, arg := range args.Storages {
+		result[i].Error = common.ServerError(a.resizeStorage(arg))
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
+func (a *API) resizeStorage(arg params.StorageResizeParams) error {
+	tag, err := names.ParseStorageTag(arg.StorageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Both block and filesystem storage are resized by resizing
+	// the backing volume, which records the storage instance.
+	volume, err := a.storage.StorageInstanceVolume(tag)
+	if errors.IsNotFound(err) {
+		// Leave it to state to report why the storage
+		// cannot be resized.
+		return errors.Trace(a.storage.ResizeStorageInstance(tag, arg.Size))
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	info, err := volume.Info()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := a.checkVolumeResizable(info.Pool); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(a.storage.ResizeStorageInstance(tag, arg.Size))
+}
+
+// checkVolumeResizable returns a NotSupported error if volumes in
+// the named storage pool are known not to be resizable. Volumes
+// managed by machine-scoped providers can only be checked by the
+// machine's storage provisioner, so they are assumed resizable.
+func (a *API) checkVolumeResizable(pool string) error {
+	providerType, cfg, err := storagecommon.StoragePoolConfig(pool, a.poolManager, a.registry)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	provider, err := a.registry.StorageProvider(providerType)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if provider.Scope() != storage.ScopeEnviron {
+		return nil
+	}
+	source, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := source.(storage.VolumeResizer); !ok {
+		return errors.NotSupportedf("resizing %q volumes", providerType)
+	}
 	return nil
 }
+
+func createVolumeSnapshotDetails(snapshot state.VolumeSnapshot) params.VolumeSnapshotDetails {
+	return params.VolumeSnapshotDetails{
+		Id:         snapshot.Id(),
+		VolumeTag:  snapshot.Volume().String(),
+		Pool:       snapshot.Pool(),
+		SnapshotId: snapshot.SnapshotId(),
+		Size:       snapshot.Size(),
+		Created:    snapshot.Created(),
+	}
+}
@@ -459,15 +459,19 @@ func (s *storageSuite) TestAttach(c *gc.C) {
 		{StorageTag: "storage-data-0", UnitTag: "unit-mysql-0"},
 		{StorageTag: "storage-data-0", UnitTag: "machine-0"},
 		{StorageTag: "volume-0", UnitTag: "unit-mysql-0"},
+		{StorageTag: "storage-data-0", UnitTag: "unit-mysql-1"},
 	}})
-	c.Assert(err, gc.ErrorMatches, "attaching storage not implemented")
-	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
 	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
 		{Error: nil},
 		{Error: &params.Error{Message: `"machine-0" is not a valid unit tag`}},
 		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+		{Error: &params.Error{Message: "cannot attach storage data/0 to unit mysql/1"}},
 	})
 	s.stub.CheckCalls(c, []testing.StubCall{
 		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{attachStorageCall, []interface{}{s.storageTag, s.unitTag}},
+		{attachStorageCall, []interface{}{s.storageTag, names.NewUnitTag("mysql/1")}},
 	})
 }
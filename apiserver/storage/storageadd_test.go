@@ -156,3 +156,34 @@ func (s *storageAddSuite) TestStorageAddUnitNotFoundErr(c *gc.C) {
 	c.Assert(failures.Results[0].Error.Error(), gc.Matches, "sanity not found")
 	c.Assert(failures.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
 }
+
+func (s *storageAddSuite) TestStorageAddUnitFromSnapshot(c *gc.C) {
+	s.state.addStorageFromSnapshot = func(u names.UnitTag, name, snapshotId string) error {
+		s.stub.AddCall("addStorageFromSnapshot", u, name, snapshotId)
+		return nil
+	}
+
+	args := params.StorageAddParams{
+		UnitTag:      s.unitTag.String(),
+		StorageName:  "data",
+		FromSnapshot: "0",
+	}
+	s.assertStorageAddedNoErrors(c, args)
+	s.assertCalls(c, []string{getBlockForTypeCall, "addStorageFromSnapshot"})
+	s.stub.CheckCall(c, 1, "addStorageFromSnapshot", s.unitTag, "data", "0")
+}
+
+func (s *storageAddSuite) TestStorageAddUnitFromSnapshotWithConstraints(c *gc.C) {
+	size := uint64(1024)
+	args := params.StorageAddParams{
+		UnitTag:      s.unitTag.String(),
+		StorageName:  "data",
+		Constraints:  params.StorageConstraints{Size: &size},
+		FromSnapshot: "0",
+	}
+	failures, err := s.api.AddToUnit(params.StoragesAddParams{[]params.StorageAddParams{args}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures.Results, gc.HasLen, 1)
+	c.Assert(failures.Results[0].Error, gc.ErrorMatches, "storage pool or size with a snapshot not valid")
+	s.assertCalls(c, []string{getBlockForTypeCall})
+}
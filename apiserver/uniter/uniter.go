@@ -747,7 +747,9 @@ func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResul
 		if canAccess(tag) {
 			var unit *state.Unit
 			unit, err = u.getUnit(tag)
-			if err == nil {
+			if err == nil && entity.Endpoint != "" {
+				err = unit.OpenEndpointPorts(entity.Endpoint, entity.Protocol, entity.FromPort, entity.ToPort)
+			} else if err == nil {
 				err = unit.OpenPorts(entity.Protocol, entity.FromPort, entity.ToPort)
 			}
 		}
@@ -954,6 +956,18 @@ func (u *UniterAPI) BeginActions(args params.Entities) (params.ErrorResults, err
 	return common.BeginActions(args, actionFn), nil
 }
 
+// LogActionsMessages records the progress messages logged by running
+// Actions.
+func (u *UniterAPI) LogActionsMessages(args params.ActionMessageParams) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+
+	actionFn := common.AuthAndActionFromTagFn(canAccess, u.st.ActionByTag)
+	return common.LogActionsMessages(args, actionFn), nil
+}
+
 // FinishActions saves the result of a completed Action
 func (u *UniterAPI) FinishActions(args params.ActionExecutionResults) (params.ErrorResults, error) {
 	canAccess, err := u.accessUnit()
@@ -1062,16 +1076,15 @@ func (u *UniterAPI) EnterScope(args params.RelationUnits) (params.ErrorResults,
 			return err
 		}
 
-		settings := map[string]interface{}{}
-		settingsAddress, err := relUnit.SettingsAddress()
-		if err == nil {
-			// Construct the settings, passing the unit's address (we
-			// already know it). Normally this will be the private
-			// address, but if this relation is to a remote application it
-			// might be the public one.
-			settings["private-address"] = settingsAddress.Value
-		} else {
+		// Construct the settings, passing the unit's address (we
+		// already know it). Normally this will be the private
+		// address, but if this relation is to a remote application it
+		// might be the public one, and if the application overrides
+		// the endpoint's ingress address it will be that.
+		settings, err := relUnit.AddressSettings()
+		if err != nil {
 			logger.Warningf("cannot set private-address for unit %v in relation %v: %v", unitTag.Id(), relTag, err)
+			settings = map[string]interface{}{}
 		}
 		return relUnit.EnterScope(settings)
 	}
@@ -1764,10 +1777,20 @@ func (u *UniterAPI) NetworkInfo(args params.NetworkInfoParams) (params.NetworkIn
 		}
 	}
 
+	application, err := unit.Application()
+	if err != nil {
+		return params.NetworkInfoResults{}, err
+	}
+
 	networkInfos := machine.GetNetworkInfoForSpaces(spaces)
 
 	for binding, space := range bindingsToSpace {
-		result.Results[binding] = networkingcommon.MachineNetworkInfoResultToNetworkInfoResult(networkInfos[space])
+		info := networkingcommon.MachineNetworkInfoResultToNetworkInfoResult(networkInfos[space])
+		if address, port, ok := application.IngressAddress(binding); ok && info.Error == nil {
+			info.IngressAddresses = []string{address.Value}
+			info.IngressPort = port
+		}
+		result.Results[binding] = info
 	}
 
 	return result, nil
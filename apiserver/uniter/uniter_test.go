@@ -545,6 +545,33 @@ func (s *uniterSuite) TestNetworkInfoSpaceless(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestNetworkInfoSpacelessDualStack(c *gc.C) {
+	err := s.machine0.SetProviderAddresses(
+		network.NewScopedAddress("1.2.3.4", network.ScopeCloudLocal),
+		network.NewScopedAddress("fc00::4", network.ScopeCloudLocal),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.NetworkInfoParams{
+		Unit:     s.wordpressUnit.Tag().String(),
+		Bindings: []string{"db"},
+	}
+	result, err := s.uniter.NetworkInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.NetworkInfoResults{
+		Results: map[string]params.NetworkInfoResult{
+			"db": {
+				Info: []params.NetworkInfo{{
+					Addresses: []params.InterfaceAddress{
+						{Address: "1.2.3.4"},
+						{Address: "fc00::4"},
+					},
+				}},
+			},
+		},
+	})
+}
+
 func (s *uniterSuite) TestAvailabilityZone(c *gc.C) {
 	s.PatchValue(uniter.GetZone, func(st *state.State, tag names.Tag) (string, error) {
 		return "a_zone", nil
@@ -924,6 +951,28 @@ func (s *uniterSuite) TestOpenPorts(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestOpenPortsForEndpoint(c *gc.C) {
+	args := params.EntitiesPortRanges{Entities: []params.EntityPortRange{
+		{Tag: "unit-wordpress-0", Protocol: "tcp", FromPort: 80, ToPort: 80, Endpoint: "url"},
+		{Tag: "unit-wordpress-0", Protocol: "tcp", FromPort: 81, ToPort: 81, Endpoint: "bogus"},
+	}}
+	result, err := s.uniter.OpenPorts(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `.*application "wordpress" has no "bogus" relation`)
+
+	machineId, err := s.wordpressUnit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err := machine.OpenedPorts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.AllPortRangeEndpoints(), jc.DeepEquals, map[network.PortRange]string{
+		{Protocol: "tcp", FromPort: 80, ToPort: 80}: "url",
+	})
+}
+
 func (s *uniterSuite) TestClosePorts(c *gc.C) {
 	// Open port udp:4321 in advance on wordpressUnit.
 	err := s.wordpressUnit.OpenPorts("udp", 4321, 5000)
@@ -1419,6 +1468,35 @@ func (s *uniterSuite) TestBeginActions(c *gc.C) {
 	c.Assert(started.After(enqueued) || started.Equal(enqueued), jc.IsTrue, gc.Commentf("started should be after or equal to enqueued time"))
 }
 
+func (s *uniterSuite) TestLogActionsMessages(c *gc.C) {
+	running, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = running.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	pending, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	otherUnit, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.ActionMessageParams{Messages: []params.EntityString{
+		{Tag: running.ActionTag().String(), Value: "hello"},
+		{Tag: pending.ActionTag().String(), Value: "hello"},
+		{Tag: otherUnit.ActionTag().String(), Value: "hello"},
+	}}
+	res, err := s.uniter.LogActionsMessages(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 3)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Assert(res.Results[1].Error, gc.ErrorMatches, `cannot log message to action ".*": action is pending`)
+	c.Assert(res.Results[2].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+
+	action, err := s.State.Action(running.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message(), gc.Equals, "hello")
+}
+
 func (s *uniterSuite) TestRelation(c *gc.C) {
 	rel := s.addRelation(c, "wordpress", "mysql")
 	wpEp, err := rel.Endpoint("wordpress")
@@ -1553,6 +1631,35 @@ func (s *uniterSuite) TestEnterScope(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestEnterScopeWithIngressAddress(c *gc.C) {
+	err := s.machine0.SetProviderAddresses(
+		network.NewScopedAddress("1.2.3.4", network.ScopeCloudLocal),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpress.SetIngressAddresses(map[string]string{"db": "10.0.0.100:8080"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := s.uniter.EnterScope(params.RelationUnits{RelationUnits: []params.RelationUnit{
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{Error: nil}},
+	})
+
+	s.assertInScope(c, relUnit, true)
+	readSettings, err := relUnit.ReadSettings(s.wordpressUnit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readSettings, gc.DeepEquals, map[string]interface{}{
+		"private-address": "10.0.0.100",
+		"ingress-address": "10.0.0.100",
+		"ingress-port":    "8080",
+	})
+}
+
 func (s *uniterSuite) TestLeaveScope(c *gc.C) {
 	rel := s.addRelation(c, "wordpress", "mysql")
 	relUnit, err := rel.Unit(s.wordpressUnit)
@@ -3130,6 +3237,44 @@ func (s *uniterNetworkInfoSuite) TestNetworkInfoForExplicitlyBoundEndpointAndDef
 	})
 }
 
+func (s *uniterNetworkInfoSuite) TestNetworkInfoWithIngressAddress(c *gc.C) {
+	s.setupUniterAPIForUnit(c, s.base.mysqlUnit)
+	mysql, err := s.base.mysqlUnit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysql.SetIngressAddresses(map[string]string{"server": "[2001:db8::100]:3306"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.NetworkInfoParams{
+		Unit:     s.base.mysqlUnit.Tag().String(),
+		Bindings: []string{"server"},
+	}
+
+	privateAddress, err := s.base.machine1.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+
+	expectedInfo := params.NetworkInfoResult{
+		Info: []params.NetworkInfo{
+			{
+				MACAddress:    "00:11:22:33:20:50",
+				InterfaceName: "eth0.100",
+				Addresses: []params.InterfaceAddress{
+					{Address: privateAddress.Value, CIDR: "10.0.0.0/24"},
+				},
+			},
+		},
+		IngressAddresses: []string{"2001:db8::100"},
+		IngressPort:      3306,
+	}
+
+	result, err := s.base.uniter.NetworkInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.NetworkInfoResults{
+		Results: map[string]params.NetworkInfoResult{
+			"server": expectedInfo,
+		},
+	})
+}
+
 func (s *uniterNetworkInfoSuite) TestNetworkInfoL2Binding(c *gc.C) {
 	c.Skip("L2 not supported yet")
 	s.addRelationAndAssertInScope(c)
@@ -140,7 +140,9 @@ func newStringsWatcher(context facade.Context) (facade.Facade, error) {
 	auth := context.Auth()
 	resources := context.Resources()
 
-	if !isAgent(auth) {
+	// Clients may follow the watchers created by the API calls they
+	// are permitted to make, such as Action.WatchActionsProgress.
+	if !isAgent(auth) && !auth.AuthClient() {
 		return nil, common.ErrPerm
 	}
 	watcher, ok := resources.Get(id).(state.StringsWatcher)
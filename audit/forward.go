@@ -0,0 +1,228 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+// RecordSender sends log records to a remote log store, such as the
+// logfwd/syslog client.
+type RecordSender interface {
+	Send([]logfwd.Record) error
+	Close() error
+}
+
+// ForwardingConfig holds the configuration for a ForwardingSink.
+type ForwardingConfig struct {
+	// Open opens a connection to the remote log store. It is called
+	// when the connection is first needed, and again after any
+	// failure to send.
+	Open func() (RecordSender, error)
+
+	// Origin describes the agent recording the audit entries; the
+	// entity that caused the audit event is recorded in the entry
+	// itself.
+	Origin logfwd.Origin
+
+	// Clock is used to wait between attempts to connect.
+	Clock clock.Clock
+
+	// BufferSize is the number of entries held while waiting for
+	// the remote log store. Entries handled while the buffer is full
+	// are dropped.
+	BufferSize int
+
+	// RetryDelay is the time to wait after the first failure to
+	// open or send. The delay doubles after each consecutive
+	// failure, up to MaxRetryDelay.
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+}
+
+// Validate returns an error if the config cannot be used to start a
+// ForwardingSink.
+func (config ForwardingConfig) Validate() error {
+	if config.Open == nil {
+		return errors.NotValidf("nil Open")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.BufferSize <= 0 {
+		return errors.NotValidf("non-positive BufferSize")
+	}
+	if config.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	if config.MaxRetryDelay < config.RetryDelay {
+		return errors.NotValidf("MaxRetryDelay less than RetryDelay")
+	}
+	return nil
+}
+
+// NewForwardingSink returns a worker which forwards audit entries,
+// encoded as JSON, to a remote log store. Handle only queues the
+// entry; the connection is made and the records are sent by the
+// worker's own goroutine, so the caller never waits on the network.
+func NewForwardingSink(config ForwardingConfig) (*ForwardingSink, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	s := &ForwardingSink{
+		config:  config,
+		records: make(chan logfwd.Record, config.BufferSize),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &s.catacomb,
+		Work: s.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s, nil
+}
+
+// ForwardingSink forwards audit entries to a remote log store.
+type ForwardingSink struct {
+	catacomb catacomb.Catacomb
+	config   ForwardingConfig
+	records  chan logfwd.Record
+	dropped  int64
+}
+
+// Handle is an AuditEntrySinkFn which queues the entry to be
+// forwarded. If the buffer is full the entry is dropped and counted.
+func (s *ForwardingSink) Handle(entry AuditEntry) error {
+	data, err := MarshalJSONEntry(entry)
+	if err != nil {
+		return errors.Annotate(err, "cannot encode audit entry")
+	}
+	rec := logfwd.Record{
+		Origin:    s.config.Origin,
+		Timestamp: entry.Timestamp,
+		Level:     loggo.INFO,
+		Location:  logfwd.SourceLocation{Module: "juju.audit", Line: -1},
+		Message:   string(data),
+	}
+	if err := rec.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	select {
+	case s.records <- rec:
+	default:
+		atomic.AddInt64(&s.dropped, 1)
+	}
+	return nil
+}
+
+// Dropped returns the number of entries dropped because the buffer
+// was full.
+func (s *ForwardingSink) Dropped() int64 {
+	return atomic.LoadInt64(&s.dropped)
+}
+
+// Kill is part of the worker.Worker interface.
+func (s *ForwardingSink) Kill() {
+	s.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (s *ForwardingSink) Wait() error {
+	return s.catacomb.Wait()
+}
+
+func (s *ForwardingSink) loop() error {
+	var sender RecordSender
+	defer func() {
+		if sender != nil {
+			if err := sender.Close(); err != nil {
+				logger.Warningf("cannot close audit forwarding connection: %v", err)
+			}
+		}
+	}()
+	var lastID, reportedDropped int64
+	delay := s.config.RetryDelay
+	for {
+		var rec logfwd.Record
+		select {
+		case <-s.catacomb.Dying():
+			return s.catacomb.ErrDying()
+		case rec = <-s.records:
+		}
+		lastID++
+		rec.ID = lastID
+		for {
+			err := s.send(&sender, rec)
+			if err == nil {
+				break
+			}
+			logger.Warningf("%v (retrying in %s)", err, delay)
+			select {
+			case <-s.catacomb.Dying():
+				return s.catacomb.ErrDying()
+			case <-s.config.Clock.After(delay):
+			}
+			delay *= 2
+			if delay > s.config.MaxRetryDelay {
+				delay = s.config.MaxRetryDelay
+			}
+		}
+		delay = s.config.RetryDelay
+		if dropped := s.Dropped(); dropped != reportedDropped {
+			logger.Warningf("dropped %d audit entries while the forwarding buffer was full", dropped-reportedDropped)
+			reportedDropped = dropped
+		}
+	}
+}
+
+// send sends the record, opening the connection first if necessary.
+// The connection is closed if the send fails, so that it is opened
+// again on the next attempt.
+func (s *ForwardingSink) send(sender *RecordSender, rec logfwd.Record) error {
+	if *sender == nil {
+		opened, err := s.config.Open()
+		if err != nil {
+			return errors.Annotate(err, "cannot open audit forwarding connection")
+		}
+		*sender = opened
+	}
+	if err := (*sender).Send([]logfwd.Record{rec}); err != nil {
+		if err := (*sender).Close(); err != nil {
+			logger.Warningf("cannot close audit forwarding connection: %v", err)
+		}
+		*sender = nil
+		return errors.Annotate(err, "cannot forward audit entry")
+	}
+	return nil
+}
+
+// NewMultiSink returns an audit entry sink which sends each entry to
+// all of the given sinks. Every sink is tried, even if an earlier one
+// fails; the first error encountered is returned and any others are
+// logged.
+func NewMultiSink(sinks ...AuditEntrySinkFn) AuditEntrySinkFn {
+	return func(entry AuditEntry) error {
+		var firstErr error
+		for _, sink := range sinks {
+			err := sink(entry)
+			switch {
+			case err == nil:
+			case firstErr == nil:
+				firstErr = err
+			default:
+				logger.Errorf("cannot record audit entry: %v", err)
+			}
+		}
+		return firstErr
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/logfwd"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type forwardingSuite struct {
+	testing.IsolationSuite
+
+	stub   *testing.Stub
+	sender *fakeSender
+	clock  *testing.Clock
+	opened chan struct{}
+	config audit.ForwardingConfig
+}
+
+var _ = gc.Suite(&forwardingSuite{})
+
+func (s *forwardingSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = &testing.Stub{}
+	s.sender = &fakeSender{
+		stub: s.stub,
+		sent: make(chan logfwd.Record, 10),
+	}
+	s.clock = testing.NewClock(time.Time{})
+	s.opened = make(chan struct{}, 10)
+	s.config = audit.ForwardingConfig{
+		Open: s.open,
+		Origin: logfwd.OriginForMachineAgent(
+			names.NewMachineTag("0"),
+			coretesting.ControllerTag.Id(),
+			coretesting.ModelTag.Id(),
+			version.MustParse("2.2.0"),
+		),
+		Clock:         s.clock,
+		BufferSize:    10,
+		RetryDelay:    time.Second,
+		MaxRetryDelay: 4 * time.Second,
+	}
+}
+
+func (s *forwardingSuite) open() (audit.RecordSender, error) {
+	s.stub.AddCall("Open")
+	s.opened <- struct{}{}
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return s.sender, nil
+}
+
+func (s *forwardingSuite) newSink(c *gc.C) *audit.ForwardingSink {
+	sink, err := audit.NewForwardingSink(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, sink) })
+	return sink
+}
+
+func (s *forwardingSuite) waitSent(c *gc.C) logfwd.Record {
+	select {
+	case rec := <-s.sender.sent:
+		return rec
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for record to be sent")
+	}
+	panic("unreachable")
+}
+
+func (s *forwardingSuite) waitOpened(c *gc.C) {
+	select {
+	case <-s.opened:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for connection to be opened")
+	}
+}
+
+func (s *forwardingSuite) TestValidate(c *gc.C) {
+	s.testValidate(c, func(config *audit.ForwardingConfig) {
+		config.Open = nil
+	}, "nil Open not valid")
+	s.testValidate(c, func(config *audit.ForwardingConfig) {
+		config.Clock = nil
+	}, "nil Clock not valid")
+	s.testValidate(c, func(config *audit.ForwardingConfig) {
+		config.BufferSize = 0
+	}, "non-positive BufferSize not valid")
+	s.testValidate(c, func(config *audit.ForwardingConfig) {
+		config.MaxRetryDelay = time.Millisecond
+	}, "MaxRetryDelay less than RetryDelay not valid")
+}
+
+func (s *forwardingSuite) testValidate(c *gc.C, mutate func(*audit.ForwardingConfig), expect string) {
+	config := s.config
+	mutate(&config)
+	sink, err := audit.NewForwardingSink(config)
+	c.Check(sink, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, expect)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *forwardingSuite) TestForward(c *gc.C) {
+	sink := s.newSink(c)
+	entry := validEntry()
+	err := sink.Handle(entry)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink.Handle(entry)
+	c.Assert(err, jc.ErrorIsNil)
+
+	message, err := audit.MarshalJSONEntry(entry)
+	c.Assert(err, jc.ErrorIsNil)
+	rec := s.waitSent(c)
+	c.Check(rec.ID, gc.Equals, int64(1))
+	c.Check(rec.Origin, jc.DeepEquals, s.config.Origin)
+	c.Check(rec.Timestamp, gc.Equals, entry.Timestamp)
+	c.Check(rec.Message, gc.Equals, string(message))
+	c.Check(s.waitSent(c).ID, gc.Equals, int64(2))
+
+	workertest.CleanKill(c, sink)
+	s.stub.CheckCallNames(c, "Open", "Send", "Send", "Close")
+}
+
+func (s *forwardingSuite) TestInvalidEntry(c *gc.C) {
+	sink := s.newSink(c)
+	entry := validEntry()
+	entry.Timestamp = time.Time{}
+	err := sink.Handle(entry)
+	c.Assert(err, gc.ErrorMatches, "empty Timestamp not valid")
+	workertest.CleanKill(c, sink)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *forwardingSuite) TestOpenErrorRetries(c *gc.C) {
+	s.stub.SetErrors(errors.New("no route to host"))
+	sink := s.newSink(c)
+	err := sink.Handle(validEntry())
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitOpened(c)
+
+	// The connection is opened again after the retry delay.
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.waitSent(c).ID, gc.Equals, int64(1))
+	workertest.CleanKill(c, sink)
+	s.stub.CheckCallNames(c, "Open", "Open", "Send", "Close")
+}
+
+func (s *forwardingSuite) TestRetryBacksOff(c *gc.C) {
+	s.stub.SetErrors(
+		errors.New("no route to host"),
+		errors.New("no route to host"),
+		errors.New("no route to host"),
+		errors.New("no route to host"),
+	)
+	sink := s.newSink(c)
+	err := sink.Handle(validEntry())
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, delay := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second} {
+		s.waitOpened(c)
+		err = s.clock.WaitAdvance(delay, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Check(s.waitSent(c).ID, gc.Equals, int64(1))
+	workertest.CleanKill(c, sink)
+}
+
+func (s *forwardingSuite) TestSendErrorReopens(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("broken pipe"))
+	sink := s.newSink(c)
+	err := sink.Handle(validEntry())
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitOpened(c)
+
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.waitSent(c).ID, gc.Equals, int64(1))
+	workertest.CleanKill(c, sink)
+	s.stub.CheckCallNames(c, "Open", "Send", "Close", "Open", "Send", "Close")
+}
+
+func (s *forwardingSuite) TestDropsWhenBufferFull(c *gc.C) {
+	s.stub.SetErrors(errors.New("no route to host"))
+	s.config.BufferSize = 1
+	sink := s.newSink(c)
+
+	// The first entry is taken by the worker, which then waits to
+	// retry; the second fills the buffer and the rest are dropped.
+	err := sink.Handle(validEntry())
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitOpened(c)
+	for i := 0; i < 3; i++ {
+		err := sink.Handle(validEntry())
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Check(sink.Dropped(), gc.Equals, int64(2))
+
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.waitSent(c).ID, gc.Equals, int64(1))
+	c.Check(s.waitSent(c).ID, gc.Equals, int64(2))
+	workertest.CleanKill(c, sink)
+}
+
+func (s *forwardingSuite) TestMultiSink(c *gc.C) {
+	var called []string
+	newSink := func(name string, err error) audit.AuditEntrySinkFn {
+		return func(audit.AuditEntry) error {
+			called = append(called, name)
+			return err
+		}
+	}
+	sink := audit.NewMultiSink(
+		newSink("a", nil),
+		newSink("b", errors.New("b failed")),
+		newSink("c", errors.New("c failed")),
+	)
+	err := sink(validEntry())
+	c.Assert(err, gc.ErrorMatches, "b failed")
+	c.Assert(called, jc.DeepEquals, []string{"a", "b", "c"})
+}
+
+type fakeSender struct {
+	stub *testing.Stub
+	sent chan logfwd.Record
+}
+
+func (s *fakeSender) Send(records []logfwd.Record) error {
+	s.stub.AddCall("Send", records)
+	if err := s.stub.NextErr(); err != nil {
+		return err
+	}
+	for _, rec := range records {
+		s.sent <- rec
+	}
+	return nil
+}
+
+func (s *fakeSender) Close() error {
+	s.stub.AddCall("Close")
+	return s.stub.NextErr()
+}
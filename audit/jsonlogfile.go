@@ -0,0 +1,79 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// NewJSONLogFileSink returns an audit entry sink which writes each
+// entry as a single line of JSON to an audit.jsonl file in the
+// specified directory. The file is rotated when it grows too large.
+func NewJSONLogFileSink(logDir string) AuditEntrySinkFn {
+	logPath := filepath.Join(logDir, "audit.jsonl")
+	if err := primeLogFile(logPath); err != nil {
+		// This isn't a fatal error so log and continue if priming
+		// fails.
+		logger.Errorf("Unable to prime %s (proceeding anyway): %v", logPath, err)
+	}
+	handler := &jsonLogFileSink{
+		fileLogger: &lumberjack.Logger{
+			Filename:   logPath,
+			MaxSize:    300, // MB
+			MaxBackups: 10,
+		},
+	}
+	return handler.handle
+}
+
+// jsonAuditEntry is the JSON representation of an AuditEntry.
+type jsonAuditEntry struct {
+	JujuServerVersion string                 `json:"juju-server-version"`
+	ModelUUID         string                 `json:"model-uuid"`
+	Timestamp         string                 `json:"timestamp"`
+	RemoteAddress     string                 `json:"remote-address"`
+	OriginType        string                 `json:"origin-type"`
+	OriginName        string                 `json:"origin-name"`
+	Operation         string                 `json:"operation"`
+	Data              map[string]interface{} `json:"data,omitempty"`
+}
+
+// MarshalJSONEntry returns the JSON encoding of the audit entry, as
+// written by the JSON sinks.
+func MarshalJSONEntry(entry AuditEntry) ([]byte, error) {
+	data, err := json.Marshal(jsonAuditEntry{
+		JujuServerVersion: entry.JujuServerVersion.String(),
+		ModelUUID:         entry.ModelUUID,
+		Timestamp:         entry.Timestamp.In(time.UTC).Format(time.RFC3339Nano),
+		RemoteAddress:     entry.RemoteAddress,
+		OriginType:        entry.OriginType,
+		OriginName:        entry.OriginName,
+		Operation:         entry.Operation,
+		Data:              entry.Data,
+	})
+	return data, errors.Trace(err)
+}
+
+type jsonLogFileSink struct {
+	mu         sync.Mutex
+	fileLogger io.WriteCloser
+}
+
+func (a *jsonLogFileSink) handle(entry AuditEntry) error {
+	data, err := MarshalJSONEntry(entry)
+	if err != nil {
+		return errors.Annotate(err, "cannot encode audit entry")
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, err = a.fileLogger.Write(append(data, '\n'))
+	return errors.Trace(err)
+}
@@ -0,0 +1,80 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Redacted is the value recorded in place of any value that may
+// hold a secret.
+const Redacted = "<redacted>"
+
+// secretKeyWords holds the words that, when found in a field name,
+// indicate that the field's value may hold a secret.
+var secretKeyWords = []string{
+	"password",
+	"secret",
+	"macaroon",
+	"token",
+	"private-key",
+	"privatekey",
+	"client-key",
+	"credential",
+	"auth-key",
+}
+
+// IsSecretKey reports whether the value of the named field may hold a
+// secret and so must not be recorded.
+func IsSecretKey(name string) bool {
+	name = strings.ToLower(name)
+	for _, word := range secretKeyWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact returns a copy of the given value, as it would be encoded
+// to JSON, with the values of any fields that may hold secrets
+// replaced. The result is made up of maps, slices and simple values
+// only, so it is suitable for encoding by any of the audit sinks.
+// If the value cannot be encoded as JSON, Redacted is returned.
+func Redact(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return Redacted
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return Redacted
+	}
+	return redact(decoded)
+}
+
+func redact(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for name, v := range value {
+			if IsSecretKey(name) {
+				value[name] = Redacted
+			} else {
+				value[name] = redact(v)
+			}
+		}
+		return value
+	case []interface{}:
+		for i, v := range value {
+			value[i] = redact(v)
+		}
+		return value
+	default:
+		return value
+	}
+}
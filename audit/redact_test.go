@@ -0,0 +1,62 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+)
+
+type redactSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&redactSuite{})
+
+func (s *redactSuite) TestIsSecretKey(c *gc.C) {
+	for _, name := range []string{"password", "Password", "admin-secret", "macaroons", "token", "private-key", "syslog-client-key", "credentials"} {
+		c.Check(audit.IsSecretKey(name), jc.IsTrue, gc.Commentf("%s", name))
+	}
+	for _, name := range []string{"name", "authorized-keys", "charm-url", "tag"} {
+		c.Check(audit.IsSecretKey(name), jc.IsFalse, gc.Commentf("%s", name))
+	}
+}
+
+func (s *redactSuite) TestRedact(c *gc.C) {
+	type credential struct {
+		Tag      string `json:"tag"`
+		Password string `json:"password"`
+	}
+	type args struct {
+		Entities []credential           `json:"entities"`
+		Config   map[string]interface{} `json:"config"`
+	}
+	redacted := audit.Redact(args{
+		Entities: []credential{{Tag: "user-bob", Password: "sekrit"}},
+		Config: map[string]interface{}{
+			"admin-secret": "hush",
+			"name":         "foo",
+		},
+	})
+	c.Assert(redacted, jc.DeepEquals, map[string]interface{}{
+		"entities": []interface{}{
+			map[string]interface{}{"tag": "user-bob", "password": audit.Redacted},
+		},
+		"config": map[string]interface{}{
+			"admin-secret": audit.Redacted,
+			"name":         "foo",
+		},
+	})
+}
+
+func (s *redactSuite) TestRedactNil(c *gc.C) {
+	c.Assert(audit.Redact(nil), gc.IsNil)
+}
+
+func (s *redactSuite) TestRedactUnencodable(c *gc.C) {
+	c.Assert(audit.Redact(make(chan int)), gc.Equals, audit.Redacted)
+}
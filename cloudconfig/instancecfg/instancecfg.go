@@ -819,6 +819,7 @@ func FinishInstanceConfig(icfg *InstanceConfig, cfg *config.Config) (err error)
 	); err != nil {
 		return errors.Trace(err)
 	}
+	icfg.AgentEnvironment[agent.PreferredAddressFamily] = cfg.PreferredAddressFamily()
 	if icfg.Controller != nil {
 		// Add NUMACTL preference. Needed to work for both bootstrap and high availability
 		// Only makes sense for controller
@@ -64,8 +64,9 @@ func (s *CloudInitSuite) TestFinishInstanceConfig(c *gc.C) {
 	expectedMcfg := &instancecfg.InstanceConfig{
 		AuthorizedKeys: "we-are-the-keys",
 		AgentEnvironment: map[string]string{
-			agent.ProviderType:  "dummy",
-			agent.ContainerType: "",
+			agent.ProviderType:           "dummy",
+			agent.ContainerType:          "",
+			agent.PreferredAddressFamily: "ipv4",
 		},
 		APIInfo: &api.Info{Tag: userTag},
 		DisableSSLHostnameVerification: false,
@@ -105,6 +106,7 @@ func (s *CloudInitSuite) TestFinishInstanceConfigNonDefault(c *gc.C) {
 	attrs := dummySampleConfig().Merge(testing.Attrs{
 		"authorized-keys":           "we-are-the-keys",
 		"ssl-hostname-verification": false,
+		"preferred-address-family":  "ipv6",
 	})
 	cfg, err := config.New(config.NoDefaults, attrs)
 	c.Assert(err, jc.ErrorIsNil)
@@ -116,8 +118,9 @@ func (s *CloudInitSuite) TestFinishInstanceConfigNonDefault(c *gc.C) {
 	c.Assert(icfg, jc.DeepEquals, &instancecfg.InstanceConfig{
 		AuthorizedKeys: "we-are-the-keys",
 		AgentEnvironment: map[string]string{
-			agent.ProviderType:  "dummy",
-			agent.ContainerType: "",
+			agent.ProviderType:           "dummy",
+			agent.ContainerType:          "",
+			agent.PreferredAddressFamily: "ipv6",
 		},
 		APIInfo: &api.Info{Tag: userTag},
 		DisableSSLHostnameVerification: true,
@@ -7,10 +7,12 @@ import (
 	"io"
 
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/action"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/watcher"
 )
 
 // type APIClient represents the action API functionality.
@@ -23,6 +25,15 @@ type APIClient interface {
 	// Action.
 	Enqueue(params.Actions) (params.ActionResults, error)
 
+	// EnqueueOperation takes a list of Actions and queues them up as a
+	// single operation, returning the id of the operation and the
+	// params.Action queued on each unit.
+	EnqueueOperation(params.Actions) (params.OperationResult, error)
+
+	// Operations takes a list of operation ids and returns the Actions
+	// queued as part of each operation.
+	Operations(params.OperationQueryArgs) (params.OperationResults, error)
+
 	// ListAll takes a list of Tags representing ActionReceivers and returns
 	// all of the Actions that have been queued or run by each of those
 	// Entities.
@@ -56,6 +67,10 @@ type APIClient interface {
 	// FindActionsByNames takes a list of names and finds a corresponding list of
 	// Actions for every name.
 	FindActionsByNames(params.FindActionsByNames) (params.ActionsByNames, error)
+
+	// WatchActionProgress returns a watcher for the progress messages
+	// logged by the Action with the given tag.
+	WatchActionProgress(names.ActionTag) (watcher.StringsWatcher, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
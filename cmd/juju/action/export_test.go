@@ -4,6 +4,8 @@
 package action
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"gopkg.in/juju/names.v2"
 
@@ -21,6 +23,14 @@ type ShowOutputCommand struct {
 	*showOutputCommand
 }
 
+type ShowOperationCommand struct {
+	*showOperationCommand
+}
+
+func (c *ShowOperationCommand) OperationId() string {
+	return c.operationId
+}
+
 type StatusCommand struct {
 	*statusCommand
 }
@@ -37,6 +47,10 @@ func (c *RunCommand) UnitTag() names.UnitTag {
 	return c.unitTag
 }
 
+func (c *RunCommand) Operation() string {
+	return c.operation
+}
+
 func (c *RunCommand) ActionName() string {
 	return c.actionName
 }
@@ -45,6 +59,10 @@ func (c *RunCommand) ParseStrings() bool {
 	return c.parseStrings
 }
 
+func (c *RunCommand) Timeout() time.Duration {
+	return c.timeout
+}
+
 func (c *RunCommand) ParamsYAML() cmd.FileVar {
 	return c.paramsYAML
 }
@@ -71,6 +89,12 @@ func NewShowOutputCommandForTest(store jujuclient.ClientStore) (cmd.Command, *Sh
 	return modelcmd.Wrap(c), &ShowOutputCommand{c}
 }
 
+func NewShowOperationCommandForTest(store jujuclient.ClientStore) (cmd.Command, *ShowOperationCommand) {
+	c := &showOperationCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c), &ShowOperationCommand{c}
+}
+
 func NewStatusCommandForTest(store jujuclient.ClientStore) (cmd.Command, *StatusCommand) {
 	c := &statusCommand{}
 	c.SetClientStore(store)
@@ -14,17 +14,20 @@ import (
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/action"
 	"github.com/juju/juju/jujuclient"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
 )
 
 const (
 	validActionTagString   = "action-f47ac10b-58cc-4372-a567-0e02b2c3d479"
 	invalidActionTagString = "action-f47ac10b-58cc-4372-a567-0e02b2c3d47"
 	validActionId          = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	validActionId2         = "6b9e5e0a-1b1c-4d8e-9f2a-3c4d5e6f7a8b"
 	invalidActionId        = "f47ac10b-58cc-4372-a567-0e02b2c3d47"
 	validUnitId            = "mysql/0"
 	invalidUnitId          = "something-strange-"
@@ -127,6 +130,9 @@ type fakeAPIClient struct {
 	actionTagMatches   params.FindTagsResults
 	actionsByNames     params.ActionsByNames
 	charmActions       map[string]params.ActionSpec
+	actionMessages     [][]string
+	operationResult    params.OperationResult
+	operationResults   []params.OperationResult
 	apiErr             error
 }
 
@@ -147,6 +153,15 @@ func (c *fakeAPIClient) Enqueue(args params.Actions) (params.ActionResults, erro
 	return params.ActionResults{Results: c.actionResults}, c.apiErr
 }
 
+func (c *fakeAPIClient) EnqueueOperation(args params.Actions) (params.OperationResult, error) {
+	c.enqueuedActions = args
+	return c.operationResult, c.apiErr
+}
+
+func (c *fakeAPIClient) Operations(args params.OperationQueryArgs) (params.OperationResults, error) {
+	return params.OperationResults{Results: c.operationResults}, c.apiErr
+}
+
 func (c *fakeAPIClient) ListAll(args params.Entities) (params.ActionsByReceivers, error) {
 	return params.ActionsByReceivers{
 		Actions: c.actionsByReceivers,
@@ -209,3 +224,28 @@ func (c *fakeAPIClient) FindActionTagsByPrefix(arg params.FindTags) (params.Find
 func (c *fakeAPIClient) FindActionsByNames(args params.FindActionsByNames) (params.ActionsByNames, error) {
 	return c.actionsByNames, c.apiErr
 }
+
+func (c *fakeAPIClient) WatchActionProgress(names.ActionTag) (watcher.StringsWatcher, error) {
+	if c.apiErr != nil {
+		return nil, c.apiErr
+	}
+	changes := make(chan []string, len(c.actionMessages))
+	for _, messages := range c.actionMessages {
+		changes <- messages
+	}
+	return &fakeStringsWatcher{changes: changes}, nil
+}
+
+type fakeStringsWatcher struct {
+	changes chan []string
+}
+
+func (w *fakeStringsWatcher) Changes() watcher.StringsChannel {
+	return w.changes
+}
+
+func (w *fakeStringsWatcher) Kill() {}
+
+func (w *fakeStringsWatcher) Wait() error {
+	return nil
+}
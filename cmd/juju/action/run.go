@@ -31,9 +31,11 @@ func NewRunCommand() cmd.Command {
 type runCommand struct {
 	ActionCommandBase
 	unitTag      names.UnitTag
+	operation    string
 	actionName   string
 	paramsYAML   cmd.FileVar
 	parseStrings bool
+	timeout      time.Duration
 	wait         waitFlag
 	out          cmd.Output
 	args         [][]string
@@ -43,6 +45,13 @@ const runDoc = `
 Queue an Action for execution on a given unit, with a given set of params.
 The Action ID is returned for use with 'juju show-action-output <ID>' or
 'juju show-action-status <ID>'.
+
+An application may be given instead of a unit, to queue the Action on all of
+the application's units, or "<application>/leader", to queue it on the unit
+that is the application's leader when the Action is queued. The Actions are
+queued as a single operation, whose ID is returned along with the ID of the
+Action queued on each unit; the results of all of the Actions may be shown
+with 'juju show-operation <ID>'.
  
 Params are validated according to the charm for the unit's application.  The 
 valid params can be seen using "juju actions <application> --schema".
@@ -56,6 +65,11 @@ is a boolean true in YAML.
 If --params is passed, along with key.key...=value explicit arguments, the
 explicit arguments will override the parameter file.
 
+The --timeout flag limits how long the Action may run on each unit: if it has
+not finished by then, it is killed and marked as failed. The number of Actions
+running at once on the units of an application may be limited with
+'juju set-action-concurrency'.
+
 Examples:
 
 $ juju run-action mysql/3 backup --wait
@@ -79,6 +93,26 @@ result:
     units: GB
     name: foo.sql
 
+$ juju run-action memcached flush --wait
+operation-id: <ID>
+units:
+  memcached/0:
+    action-id: <ID>
+    status: completed
+    ...
+  memcached/1:
+    action-id: <ID>
+    status: completed
+    ...
+
+$ juju run-action mysql/leader backup
+operation-id: <ID>
+units:
+  mysql/3:
+    action-id: <ID>
+    status: pending
+    ...
+
 $ juju run-action mysql/3 backup --params parameters.yml
 ...
 Params sent will be the contents of parameters.yml.
@@ -113,6 +147,9 @@ file:
 $ juju run-action sleeper/0 pause time=1000
 ...
 
+$ juju run-action mysql/3 backup --timeout 30m
+...
+
 $ juju run-action sleeper/0 pause --string-args time=1000
 ...
 The value for the "time" param will be the string literal "1000".
@@ -128,18 +165,20 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
 	f.Var(&c.wait, "wait", "Wait for results, with optional timeout")
+	f.DurationVar(&c.timeout, "timeout", 0, "Kill and fail the action if it runs for longer than this")
 }
 
 func (c *runCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "run-action",
-		Args:    "<unit> <action name> [key.key.key...=value]",
+		Args:    "<unit> | <application>[/leader] <action name> [key.key.key...=value]",
 		Purpose: "Queue an action for execution.",
 		Doc:     runDoc,
 	}
 }
 
-// Init gets the unit tag, and checks for other correct args.
+// Init gets the unit tag, or the receiver of the operation for an
+// application, and checks for other correct args.
 func (c *runCommand) Init(args []string) error {
 	switch len(args) {
 	case 0:
@@ -147,16 +186,27 @@ func (c *runCommand) Init(args []string) error {
 	case 1:
 		return errors.New("no action specified")
 	default:
+		if c.timeout < 0 {
+			return errors.Errorf("invalid timeout %v, must not be negative", c.timeout)
+		}
 		// Grab and verify the unit and action names.
-		unitName := args[0]
-		if !names.IsValidUnit(unitName) {
-			return errors.Errorf("invalid unit name %q", unitName)
+		receiver := args[0]
+		switch {
+		case names.IsValidUnit(receiver):
+			c.unitTag = names.NewUnitTag(receiver)
+		case names.IsValidApplication(receiver):
+			c.operation = names.NewApplicationTag(receiver).String()
+		case strings.HasSuffix(receiver, leaderSuffix) &&
+			names.IsValidApplication(strings.TrimSuffix(receiver, leaderSuffix)):
+			// The leader is resolved by the controller.
+			c.operation = receiver
+		default:
+			return errors.Errorf("invalid unit or application name %q", receiver)
 		}
 		ActionName := args[1]
 		if valid := ActionNameRule.MatchString(ActionName); !valid {
 			return errors.Errorf("invalid action name %q", ActionName)
 		}
-		c.unitTag = names.NewUnitTag(unitName)
 		c.actionName = ActionName
 		if len(args) == 2 {
 			return nil
@@ -242,11 +292,16 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("params must be a map, got %T", typedConformantParams)
 	}
 
+	if c.operation != "" {
+		return c.runOperation(ctx, api, actionParams)
+	}
+
 	actionParam := params.Actions{
 		Actions: []params.Action{{
 			Receiver:   c.unitTag.String(),
 			Name:       c.actionName,
 			Parameters: actionParams,
+			Timeout:    c.timeout,
 		}},
 	}
 
@@ -273,7 +328,7 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return err
 	}
 
-	if !c.wait.forever && c.wait.d.Nanoseconds() <= 0 {
+	if !c.wait.enabled() {
 		// Immediate return. This is the default, although rarely
 		// what cli users want. We should consider changing this
 		// default with Juju 3.0.
@@ -281,16 +336,7 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return c.out.Write(ctx, output)
 	}
 
-	var wait *time.Timer
-	if c.wait.d.Nanoseconds() <= 0 {
-		// Indefinite wait. Discard the tick.
-		wait = time.NewTimer(0 * time.Second)
-		_ = <-wait.C
-	} else {
-		wait = time.NewTimer(c.wait.d)
-	}
-
-	result, err = GetActionResult(api, tag.Id(), wait)
+	result, err = GetActionResult(api, tag.Id(), c.wait.timer())
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -298,3 +344,56 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	output["action-id"] = tag.Id() // Action ID is required in case we timed out.
 	return c.out.Write(ctx, output)
 }
+
+// runOperation queues the Action on the units of the application, or
+// on its leader, as a single operation, and writes out the result of
+// the Action queued on each unit.
+func (c *runCommand) runOperation(ctx *cmd.Context, api APIClient, actionParams map[string]interface{}) error {
+	result, err := api.EnqueueOperation(params.Actions{
+		Actions: []params.Action{{
+			Receiver:   c.operation,
+			Name:       c.actionName,
+			Parameters: actionParams,
+			Timeout:    c.timeout,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Report the Actions that could not be queued, and carry on with
+	// the rest.
+	var failed []error
+	enqueued := result.Actions[:0]
+	for _, actionResult := range result.Actions {
+		if actionResult.Error != nil {
+			failed = append(failed, actionResult.Error)
+			continue
+		}
+		enqueued = append(enqueued, actionResult)
+	}
+	result.Actions = enqueued
+	if len(enqueued) == 0 {
+		if len(failed) == 1 {
+			return failed[0]
+		}
+		return errors.New("no actions were queued")
+	}
+	for _, err := range failed {
+		ctx.Infof("ERROR %v", err)
+	}
+
+	if c.wait.enabled() {
+		result, err = GetOperationResult(api, result.OperationId, c.wait.timer())
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := c.out.Write(ctx, FormatOperationResult(result)); err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		return cmd.ErrSilent
+	}
+	return nil
+}
@@ -7,8 +7,10 @@ import (
 	"bytes"
 	"errors"
 	"strings"
+	"time"
 	"unicode/utf8"
 
+	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -60,9 +62,11 @@ func (s *RunSuite) TestInit(c *gc.C) {
 		should               string
 		args                 []string
 		expectUnit           names.UnitTag
+		expectOperation      string
 		expectAction         string
 		expectParamsYamlPath string
 		expectParseStrings   bool
+		expectTimeout        time.Duration
 		expectKVArgs         [][]string
 		expectOutput         string
 		expectError          string
@@ -77,7 +81,21 @@ func (s *RunSuite) TestInit(c *gc.C) {
 	}, {
 		should:      "fail with invalid unit tag",
 		args:        []string{invalidUnitId, "valid-action-name"},
-		expectError: "invalid unit name \"something-strange-\"",
+		expectError: "invalid unit or application name \"something-strange-\"",
+	}, {
+		should:      "fail with invalid leader",
+		args:        []string{"something-strange-/leader", "valid-action-name"},
+		expectError: "invalid unit or application name \"something-strange-/leader\"",
+	}, {
+		should:          "init properly with an application",
+		args:            []string{"mysql", "valid-action-name"},
+		expectOperation: "application-mysql",
+		expectAction:    "valid-action-name",
+	}, {
+		should:          "init properly with an application leader",
+		args:            []string{"mysql/leader", "valid-action-name"},
+		expectOperation: "mysql/leader",
+		expectAction:    "valid-action-name",
 	}, {
 		should:      "fail with invalid action name",
 		args:        []string{validUnitId, "BadName"},
@@ -106,6 +124,16 @@ func (s *RunSuite) TestInit(c *gc.C) {
 		expectUnit:         names.NewUnitTag(validUnitId),
 		expectAction:       "valid-action-name",
 		expectParseStrings: true,
+	}, {
+		should:        "handle --timeout",
+		args:          []string{validUnitId, "valid-action-name", "--timeout", "5m"},
+		expectUnit:    names.NewUnitTag(validUnitId),
+		expectAction:  "valid-action-name",
+		expectTimeout: 5 * time.Minute,
+	}, {
+		should:      "fail with negative --timeout",
+		args:        []string{validUnitId, "valid-action-name", "--timeout=-5m"},
+		expectError: "invalid timeout -5m0s, must not be negative",
 	}, {
 		// cf. worker/uniter/runner/jujuc/action-set_test.go per @fwereade
 		should:       "work with multiple '=' signs",
@@ -169,10 +197,12 @@ func (s *RunSuite) TestInit(c *gc.C) {
 			err := cmdtesting.InitCommand(wrappedCommand, args)
 			if t.expectError == "" {
 				c.Check(command.UnitTag(), gc.Equals, t.expectUnit)
+				c.Check(command.Operation(), gc.Equals, t.expectOperation)
 				c.Check(command.ActionName(), gc.Equals, t.expectAction)
 				c.Check(command.ParamsYAML().Path, gc.Equals, t.expectParamsYamlPath)
 				c.Check(command.Args(), jc.DeepEquals, t.expectKVArgs)
 				c.Check(command.ParseStrings(), gc.Equals, t.expectParseStrings)
+				c.Check(command.Timeout(), gc.Equals, t.expectTimeout)
 			} else {
 				c.Check(err, gc.ErrorMatches, t.expectError)
 			}
@@ -252,6 +282,18 @@ func (s *RunSuite) TestRun(c *gc.C) {
 			Parameters: map[string]interface{}{},
 			Receiver:   names.NewUnitTag(validUnitId).String(),
 		},
+	}, {
+		should:   "enqueue an action with a timeout",
+		withArgs: []string{validUnitId, "some-action", "--timeout", "30s"},
+		withActionResults: []params.ActionResult{{
+			Action: &params.Action{Tag: validActionTagString},
+		}},
+		expectedActionEnqueued: params.Action{
+			Name:       "some-action",
+			Parameters: map[string]interface{}{},
+			Receiver:   names.NewUnitTag(validUnitId).String(),
+			Timeout:    30 * time.Second,
+		},
 	}, {
 		should: "enqueue an action with some explicit params",
 		withArgs: []string{validUnitId, "some-action",
@@ -399,3 +441,105 @@ func (s *RunSuite) TestRun(c *gc.C) {
 		}
 	}
 }
+
+func (s *RunSuite) TestRunOperation(c *gc.C) {
+	operationResult := params.OperationResult{
+		OperationId: "op-id",
+		Actions: []params.ActionResult{{
+			Action: &params.Action{Tag: validActionTagString, Receiver: "unit-mysql-0"},
+			Status: params.ActionPending,
+		}, {
+			Action: &params.Action{Tag: "action-" + validActionId2, Receiver: "unit-mysql-1"},
+			Status: params.ActionPending,
+		}},
+	}
+	for _, modelFlag := range s.modelFlags {
+		fakeClient := &fakeAPIClient{operationResult: operationResult}
+		restore := s.patchAPIClient(fakeClient)
+
+		wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+		args := []string{modelFlag, "admin", "mysql", "some-action", "out.name=bar"}
+		ctx, err := cmdtesting.RunCommand(c, wrappedCommand, args...)
+		restore()
+		c.Assert(err, jc.ErrorIsNil)
+
+		c.Check(fakeClient.EnqueuedActions().Actions, jc.DeepEquals, []params.Action{{
+			Receiver: "application-mysql",
+			Name:     "some-action",
+			Parameters: map[string]interface{}{
+				"out": map[string]interface{}{"name": "bar"},
+			},
+		}})
+		c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+operation-id: op-id
+units:
+  mysql/0:
+    action-id: `+validActionId+`
+    status: pending
+  mysql/1:
+    action-id: `+validActionId2+`
+    status: pending
+`[1:])
+	}
+}
+
+func (s *RunSuite) TestRunOperationWait(c *gc.C) {
+	completed := params.OperationResult{
+		OperationId: "op-id",
+		Actions: []params.ActionResult{{
+			Action: &params.Action{Tag: validActionTagString, Receiver: "unit-mysql-0"},
+			Status: params.ActionCompleted,
+			Output: map[string]interface{}{"flushed": "yes"},
+		}},
+	}
+	fakeClient := &fakeAPIClient{
+		operationResult: params.OperationResult{
+			OperationId: "op-id",
+			Actions: []params.ActionResult{{
+				Action: &params.Action{Tag: validActionTagString, Receiver: "unit-mysql-0"},
+				Status: params.ActionPending,
+			}, {
+				Action: &params.Action{Receiver: "unit-mysql-1"},
+				Error:  common.ServerError(errors.New("action \"flush\" not defined")),
+			}},
+		},
+		operationResults: []params.OperationResult{completed},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	args := []string{"-m", "admin", "mysql", "flush", "--wait"}
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand, args...)
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "ERROR action \"flush\" not defined\n")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+operation-id: op-id
+units:
+  mysql/0:
+    action-id: `+validActionId+`
+    results:
+      flushed: "yes"
+    status: completed
+`[1:])
+}
+
+func (s *RunSuite) TestRunOperationLeaderNotFound(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		operationResult: params.OperationResult{
+			OperationId: "op-id",
+			Actions: []params.ActionResult{{
+				Action: &params.Action{Receiver: "mysql/leader"},
+				Error:  common.ServerError(errors.New(`leader of application "mysql" not found`)),
+			}},
+		},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	args := []string{"-m", "admin", "mysql/leader", "flush"}
+	_, err := cmdtesting.RunCommand(c, wrappedCommand, args...)
+	c.Assert(err, gc.ErrorMatches, `leader of application "mysql" not found`)
+	c.Check(fakeClient.EnqueuedActions().Actions[0].Receiver, gc.Equals, "mysql/leader")
+}
@@ -0,0 +1,194 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// leaderSuffix marks the receiver of an operation as the leader of the
+// named application, as in "mysql/leader".
+const leaderSuffix = "/leader"
+
+func NewShowOperationCommand() cmd.Command {
+	return modelcmd.Wrap(&showOperationCommand{})
+}
+
+// showOperationCommand fetches the results of the actions queued as
+// part of an operation.
+type showOperationCommand struct {
+	ActionCommandBase
+	out         cmd.Output
+	operationId string
+	wait        waitFlag
+}
+
+const showOperationDoc = `
+Show the results of the actions queued on each unit as part of the operation
+with the given ID, as returned by 'juju run-action' for an application or its
+leader.  To block until all of the actions are completed or failed, use the
+--wait flag, optionally with a duration, as in --wait=5m.
+
+Examples:
+
+$ juju show-operation <ID>
+operation-id: <ID>
+units:
+  memcached/0:
+    action-id: <ID>
+    status: completed
+    ...
+  memcached/1:
+    action-id: <ID>
+    status: running
+    ...
+
+$ juju show-operation <ID> --wait
+`
+
+// SetFlags offers an option for YAML output.
+func (c *showOperationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+	f.Var(&c.wait, "wait", "Wait for results, with optional timeout")
+}
+
+func (c *showOperationCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-operation",
+		Args:    "<operation ID>",
+		Purpose: "Show results of the actions of an operation by ID.",
+		Doc:     showOperationDoc,
+	}
+}
+
+// Init validates the operation ID.
+func (c *showOperationCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no operation ID specified")
+	case 1:
+		c.operationId = args[0]
+		return nil
+	default:
+		return cmd.CheckEmpty(args[1:])
+	}
+}
+
+// Run issues the API call to get the Actions of the operation.
+func (c *showOperationCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	var result params.OperationResult
+	if c.wait.enabled() {
+		result, err = GetOperationResult(api, c.operationId, c.wait.timer())
+	} else {
+		result, err = fetchOperationResult(api, c.operationId)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, FormatOperationResult(result))
+}
+
+// GetOperationResult repeatedly fetches the actions of an operation
+// until they are all in a completed state, and then returns them. It
+// waits for a maximum of "wait" before returning with the latest
+// status of the actions.
+func GetOperationResult(api APIClient, operationId string, wait *time.Timer) (params.OperationResult, error) {
+	tick := time.NewTimer(2 * time.Second)
+	for {
+		result, err := fetchOperationResult(api, operationId)
+		if err != nil {
+			return result, err
+		}
+		if operationCompleted(result) {
+			return result, nil
+		}
+
+		// Block until a tick happens, or the timeout arrives.
+		select {
+		case _ = <-wait.C:
+			return result, nil
+
+		case _ = <-tick.C:
+			tick.Reset(2 * time.Second)
+		}
+	}
+}
+
+// fetchOperationResult returns the actions of the operation.
+func fetchOperationResult(api APIClient, operationId string) (params.OperationResult, error) {
+	none := params.OperationResult{}
+
+	results, err := api.Operations(params.OperationQueryArgs{
+		Operations: []string{operationId},
+	})
+	if err != nil {
+		return none, err
+	}
+	if len(results.Results) != 1 {
+		return none, errors.Errorf("expected 1 result for operation %s, got %d", operationId, len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return none, result.Error
+	}
+	return result, nil
+}
+
+// operationCompleted reports whether none of the actions of the
+// operation are pending or running.
+func operationCompleted(result params.OperationResult) bool {
+	for _, actionResult := range result.Actions {
+		switch actionResult.Status {
+		case params.ActionRunning, params.ActionPending:
+			return false
+		}
+	}
+	return true
+}
+
+// FormatOperationResult inserts the result of each of the actions of
+// the given OperationResult, keyed by the name of the unit, in a
+// map[string]interface{} for cmd.Output to write in an easy-to-read
+// format.
+func FormatOperationResult(result params.OperationResult) map[string]interface{} {
+	units := make(map[string]interface{})
+	for _, actionResult := range result.Actions {
+		if actionResult.Action == nil {
+			continue
+		}
+		receiver := actionResult.Action.Receiver
+		if tag, err := names.ParseUnitTag(receiver); err == nil {
+			receiver = tag.Id()
+		}
+		if actionResult.Error != nil {
+			units[receiver] = map[string]interface{}{"error": actionResult.Error.Error()}
+			continue
+		}
+		response := FormatActionResult(actionResult)
+		if tag, err := names.ParseActionTag(actionResult.Action.Tag); err == nil {
+			response["action-id"] = tag.Id()
+		}
+		units[receiver] = response
+	}
+	return map[string]interface{}{
+		"operation-id": result.OperationId,
+		"units":        units,
+	}
+}
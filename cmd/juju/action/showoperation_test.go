@@ -0,0 +1,108 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type ShowOperationSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&ShowOperationSuite{})
+
+func (s *ShowOperationSuite) TestInit(c *gc.C) {
+	tests := []struct {
+		should            string
+		args              []string
+		expectOperationId string
+		expectError       string
+	}{{
+		should:      "fail with missing arg",
+		args:        []string{},
+		expectError: "no operation ID specified",
+	}, {
+		should:      "fail with multiple args",
+		args:        []string{"12345", "54321"},
+		expectError: `unrecognized args: \["54321"\]`,
+	}, {
+		should:            "init properly with an operation ID",
+		args:              []string{"12345"},
+		expectOperationId: "12345",
+	}}
+
+	for i, t := range tests {
+		for _, modelFlag := range s.modelFlags {
+			c.Logf("test %d: it should %s: juju show-operation %s", i,
+				t.should, strings.Join(t.args, " "))
+			wrappedCommand, command := action.NewShowOperationCommandForTest(s.store)
+			args := append([]string{modelFlag, "admin"}, t.args...)
+			err := cmdtesting.InitCommand(wrappedCommand, args)
+			if t.expectError != "" {
+				c.Check(err, gc.ErrorMatches, t.expectError)
+			} else {
+				c.Check(err, jc.ErrorIsNil)
+				c.Check(command.OperationId(), gc.Equals, t.expectOperationId)
+			}
+		}
+	}
+}
+
+func (s *ShowOperationSuite) TestRun(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		operationResults: []params.OperationResult{{
+			OperationId: "op-id",
+			Actions: []params.ActionResult{{
+				Action:  &params.Action{Tag: validActionTagString, Receiver: "unit-mysql-0"},
+				Status:  params.ActionFailed,
+				Message: "cache is locked",
+			}, {
+				Action: &params.Action{Tag: "action-" + validActionId2, Receiver: "unit-mysql-1"},
+				Status: params.ActionRunning,
+			}},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewShowOperationCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand, "-m", "admin", "op-id")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+operation-id: op-id
+units:
+  mysql/0:
+    action-id: `+validActionId+`
+    message: cache is locked
+    status: failed
+  mysql/1:
+    action-id: `+validActionId2+`
+    status: running
+`[1:])
+}
+
+func (s *ShowOperationSuite) TestRunNotFound(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		operationResults: []params.OperationResult{{
+			OperationId: "op-id",
+			Error:       common.ServerError(errors.New(`operation "op-id" not found`)),
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewShowOperationCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, wrappedCommand, "-m", "admin", "op-id")
+	c.Assert(err, gc.ErrorMatches, `operation "op-id" not found`)
+}
@@ -4,6 +4,8 @@
 package action
 
 import (
+	"encoding/json"
+	"fmt"
 	"regexp"
 	"time"
 
@@ -27,6 +29,7 @@ type showOutputCommand struct {
 	requestedId string
 	fullSchema  bool
 	wait        string
+	watch       bool
 }
 
 const showOutputDoc = `
@@ -38,6 +41,12 @@ to wait indefinitely.  If units are left off, seconds are assumed.
 The default behavior without --wait is to immediately check and return; if
 the results are "pending" then only the available information will be
 displayed.  This is also the behavior when any negative time is given.
+
+Actions may log progress messages with the action-log hook tool while they
+run.  To follow these messages as they are logged, use the --watch flag; the
+messages are written to stderr, each with the time it was logged, until the
+action completes or fails, and then the results are shown.  Unless --wait is
+also given, --watch waits indefinitely.
 `
 
 // Set up the output.
@@ -45,6 +54,7 @@ func (c *showOutputCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ActionCommandBase.SetFlags(f)
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.StringVar(&c.wait, "wait", "-1s", "Wait for results")
+	f.BoolVar(&c.watch, "watch", false, "Show progress messages as they are logged, until the action completes")
 }
 
 func (c *showOutputCommand) Info() *cmd.Info {
@@ -83,6 +93,10 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.watch && waitDur < 0 {
+		// Watching implies waiting for the action to finish.
+		waitDur = 0
+	}
 
 	api, err := c.NewActionAPIClient()
 	if err != nil {
@@ -104,7 +118,12 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 		wait = time.NewTimer(waitDur)
 	}
 
-	result, err := GetActionResult(api, c.requestedId, wait)
+	var result params.ActionResult
+	if c.watch {
+		result, err = watchActionProgress(ctx, api, c.requestedId, wait)
+	} else {
+		result, err = GetActionResult(api, c.requestedId, wait)
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -160,6 +179,69 @@ func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer) (param
 	}
 }
 
+// watchActionProgress writes the progress messages logged by the given
+// action to stderr as they arrive, until the action is no longer pending
+// or running, or "wait" times out. It returns the latest action result.
+func watchActionProgress(ctx *cmd.Context, api APIClient, requestedId string, wait *time.Timer) (params.ActionResult, error) {
+	none := params.ActionResult{}
+
+	actionTag, err := getActionTagByPrefix(api, requestedId)
+	if err != nil {
+		return none, err
+	}
+	w, err := api.WatchActionProgress(actionTag)
+	if err != nil {
+		return none, errors.Trace(err)
+	}
+	defer w.Kill()
+
+	// Check the status of the action straight away, and then every
+	// two seconds.
+	tick := time.NewTimer(0)
+	logged := 0
+	for {
+		select {
+		case changes, ok := <-w.Changes():
+			if !ok {
+				return none, errors.Annotate(w.Wait(), "watching action progress")
+			}
+			for _, change := range changes {
+				var message params.ActionMessage
+				if err := json.Unmarshal([]byte(change), &message); err != nil {
+					return none, errors.Annotate(err, "cannot decode action progress message")
+				}
+				writeActionMessage(ctx, message)
+				logged++
+			}
+
+		case <-tick.C:
+			result, err := fetchResult(api, requestedId)
+			if err != nil {
+				return result, err
+			}
+			switch result.Status {
+			case params.ActionRunning, params.ActionPending:
+				tick.Reset(2 * time.Second)
+				continue
+			}
+			// The action has finished; write any messages the
+			// watcher has not delivered yet.
+			for i := logged; i < len(result.Log); i++ {
+				writeActionMessage(ctx, result.Log[i])
+			}
+			return result, nil
+
+		case <-wait.C:
+			return fetchResult(api, requestedId)
+		}
+	}
+}
+
+// writeActionMessage writes an action progress message to stderr.
+func writeActionMessage(ctx *cmd.Context, message params.ActionMessage) {
+	fmt.Fprintf(ctx.Stderr, "%s %s\n", message.Timestamp, message.Message)
+}
+
 // fetchResult queries the given API for the given Action ID prefix, and
 // makes sure the results are acceptable, returning an error if they are not.
 func fetchResult(api APIClient, requestedId string) (params.ActionResult, error) {
@@ -204,6 +286,13 @@ func FormatActionResult(result params.ActionResult) map[string]interface{} {
 	if len(result.Output) != 0 {
 		response["results"] = result.Output
 	}
+	if len(result.Log) != 0 {
+		log := make([]string, len(result.Log))
+		for i, message := range result.Log {
+			log[i] = fmt.Sprintf("%s %s", message.Timestamp, message.Message)
+		}
+		response["log"] = log
+	}
 
 	if result.Enqueued.IsZero() && result.Started.IsZero() && result.Completed.IsZero() {
 		return response
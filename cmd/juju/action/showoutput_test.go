@@ -5,11 +5,13 @@ package action_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"strings"
 	"time"
 
 	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
@@ -290,6 +292,78 @@ timing:
 	}
 }
 
+func (s *ShowOutputSuite) TestRunWithLog(c *gc.C) {
+	client := makeFakeClient(
+		0, 10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Status: "completed",
+			Log: []params.ActionMessage{{
+				Timestamp: time.Date(2015, time.February, 14, 8, 15, 10, 0, time.UTC),
+				Message:   "backing up",
+			}},
+			Enqueued:  time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+			Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+		}},
+		params.ActionsByNames{}, "",
+	)
+	testRunHelper(c, s, client, "", `
+log:
+- 2015-02-14 08:15:10 +0000 UTC backing up
+status: completed
+timing:
+  completed: 2015-02-14 08:15:30 +0000 UTC
+  enqueued: 2015-02-14 08:13:00 +0000 UTC
+`[1:], "", validActionId, "-m")
+}
+
+func (s *ShowOutputSuite) TestRunWatch(c *gc.C) {
+	log := []params.ActionMessage{{
+		Timestamp: time.Date(2015, time.February, 14, 8, 15, 10, 0, time.UTC),
+		Message:   "backing up",
+	}, {
+		Timestamp: time.Date(2015, time.February, 14, 8, 15, 20, 0, time.UTC),
+		Message:   "compressing",
+	}, {
+		Timestamp: time.Date(2015, time.February, 14, 8, 15, 25, 0, time.UTC),
+		Message:   "done",
+	}}
+	client := makeFakeClient(
+		0, 10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Status:    "completed",
+			Log:       log,
+			Enqueued:  time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+			Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+		}},
+		params.ActionsByNames{}, "",
+	)
+	// The watcher delivers the first two messages; the last one is
+	// taken from the results of the finished action.
+	for _, message := range log[:2] {
+		data, err := json.Marshal(message)
+		c.Assert(err, jc.ErrorIsNil)
+		client.actionMessages = append(client.actionMessages, []string{string(data)})
+	}
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", validActionId, "--watch", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+2015-02-14 08:15:10 +0000 UTC backing up
+2015-02-14 08:15:20 +0000 UTC compressing
+2015-02-14 08:15:25 +0000 UTC done
+`[1:])
+	var result map[string]interface{}
+	err = json.Unmarshal([]byte(cmdtesting.Stdout(ctx)), &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result["status"], gc.Equals, "completed")
+	c.Check(result["log"], gc.HasLen, 3)
+}
+
 func testRunHelper(c *gc.C, s *ShowOutputSuite, client *fakeAPIClient, expectedErr, expectedOutput, wait, query, modelFlag string) {
 	unpatch := s.BaseActionSuite.patchAPIClient(client)
 	defer unpatch()
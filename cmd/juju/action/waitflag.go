@@ -45,3 +45,20 @@ func (f *waitFlag) String() string {
 func (f *waitFlag) IsBoolFlag() bool {
 	return true
 }
+
+// enabled reports whether the command should wait for results.
+func (f *waitFlag) enabled() bool {
+	return f.forever || f.d.Nanoseconds() > 0
+}
+
+// timer returns a timer that fires when the wait is over, or never if
+// the wait is indefinite.
+func (f *waitFlag) timer() *time.Timer {
+	if f.d.Nanoseconds() <= 0 {
+		// Indefinite wait. Discard the tick.
+		wait := time.NewTimer(0 * time.Second)
+		_ = <-wait.C
+		return wait
+	}
+	return time.NewTimer(f.d)
+}
@@ -0,0 +1,124 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageSetActionConcurrencySummary = `
+Shows or sets the maximum number of actions running at once on an application.`[1:]
+
+var usageSetActionConcurrencyDetails = `
+By default, every action queued on a unit of an application is run as soon as
+the unit is free to run it, so an action queued on all of the units of an
+application runs on all of them at once. An application may instead be limited
+to a number of actions running at once across all of its units: further
+actions stay pending, and are started in the order they were queued as earlier
+actions finish. This allows, for example, a rolling restart of the units of
+an application with "juju run-action <application> restart".
+
+A limit of 0 removes the limit. With no limit given, the application's current
+limit is shown.
+
+Examples:
+    juju set-action-concurrency mysql
+    juju set-action-concurrency mysql 1
+    juju set-action-concurrency mysql 0
+
+See also:
+    run-action
+    show-operation`[1:]
+
+// NewSetActionConcurrencyCommand returns a command to show or set the
+// maximum number of actions running at once on an application.
+func NewSetActionConcurrencyCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&setActionConcurrencyCommand{})
+}
+
+// setActionConcurrencyCommand shows or sets the maximum number of
+// actions running at once on an application.
+type setActionConcurrencyCommand struct {
+	modelcmd.ModelCommandBase
+	api setActionConcurrencyAPI
+
+	ApplicationName string
+	Limit           *int
+}
+
+func (c *setActionConcurrencyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-action-concurrency",
+		Args:    "<application name> [<limit>]",
+		Purpose: usageSetActionConcurrencySummary,
+		Doc:     usageSetActionConcurrencyDetails,
+	}
+}
+
+func (c *setActionConcurrencyCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no application name specified")
+	case 1:
+	case 2:
+		limit, err := strconv.Atoi(args[1])
+		if err != nil || limit < 0 {
+			return errors.Errorf("invalid limit %q, expected a non-negative integer", args[1])
+		}
+		c.Limit = &limit
+	default:
+		return cmd.CheckEmpty(args[2:])
+	}
+	c.ApplicationName = args[0]
+	return nil
+}
+
+type setActionConcurrencyAPI interface {
+	Close() error
+	ActionConcurrency(application string) (int, error)
+	SetActionConcurrency(application string, limit int) error
+}
+
+func (c *setActionConcurrencyCommand) getAPI() (setActionConcurrencyAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run shows or sets the action concurrency limit of the application.
+func (c *setActionConcurrencyCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if c.Limit == nil {
+		limit, err := client.ActionConcurrency(c.ApplicationName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if limit == 0 {
+			ctx.Infof("application %q has no action concurrency limit", c.ApplicationName)
+			return nil
+		}
+		fmt.Fprintln(ctx.Stdout, limit)
+		return nil
+	}
+	err = client.SetActionConcurrency(c.ApplicationName, *c.Limit)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,107 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"strings"
+
+	"github.com/juju/cmd/cmdtesting"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/testing"
+)
+
+type SetActionConcurrencySuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeSetActionConcurrencyAPI
+}
+
+var _ = gc.Suite(&SetActionConcurrencySuite{})
+
+type fakeSetActionConcurrencyAPI struct {
+	jujutesting.Stub
+	limit int
+}
+
+func (f *fakeSetActionConcurrencyAPI) Close() error {
+	return nil
+}
+
+func (f *fakeSetActionConcurrencyAPI) ActionConcurrency(application string) (int, error) {
+	f.MethodCall(f, "ActionConcurrency", application)
+	return f.limit, f.NextErr()
+}
+
+func (f *fakeSetActionConcurrencyAPI) SetActionConcurrency(application string, limit int) error {
+	f.MethodCall(f, "SetActionConcurrency", application, limit)
+	return f.NextErr()
+}
+
+func (s *SetActionConcurrencySuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeSetActionConcurrencyAPI{}
+}
+
+func (s *SetActionConcurrencySuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  `no application name specified`,
+	}, {
+		args: []string{"mysql", "many"},
+		err:  `invalid limit "many", expected a non-negative integer`,
+	}, {
+		args: []string{"mysql", "-1"},
+		err:  `invalid limit "-1", expected a non-negative integer`,
+	}, {
+		args: []string{"mysql", "1", "2"},
+		err:  `unrecognized args: \["2"\]`,
+	}} {
+		c.Logf("test %d", i)
+		err := cmdtesting.InitCommand(application.NewSetActionConcurrencyCommandForTest(s.fake), t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SetActionConcurrencySuite) TestShowActionConcurrency(c *gc.C) {
+	s.fake.limit = 2
+	ctx, err := cmdtesting.RunCommand(c, application.NewSetActionConcurrencyCommandForTest(s.fake), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "2\n")
+	s.fake.CheckCall(c, 0, "ActionConcurrency", "mysql")
+}
+
+func (s *SetActionConcurrencySuite) TestShowNoActionConcurrency(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, application.NewSetActionConcurrencyCommandForTest(s.fake), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "application \"mysql\" has no action concurrency limit\n")
+}
+
+func (s *SetActionConcurrencySuite) TestSetActionConcurrency(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, application.NewSetActionConcurrencyCommandForTest(s.fake), "mysql", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCall(c, 0, "SetActionConcurrency", "mysql", 1)
+}
+
+func (s *SetActionConcurrencySuite) TestRemoveActionConcurrency(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, application.NewSetActionConcurrencyCommandForTest(s.fake), "mysql", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCall(c, 0, "SetActionConcurrency", "mysql", 0)
+}
+
+func (s *SetActionConcurrencySuite) TestBlockSetActionConcurrency(c *gc.C) {
+	s.fake.SetErrors(common.OperationBlockedError("TestBlockSetActionConcurrency"))
+	cmdtesting.RunCommand(c, application.NewSetActionConcurrencyCommandForTest(s.fake), "mysql", "1")
+
+	// msg is logged
+	stripped := strings.Replace(c.GetTestLog(), "\n", "", -1)
+	c.Check(stripped, gc.Matches, ".*TestBlockSetActionConcurrency.*")
+}
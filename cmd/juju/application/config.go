@@ -8,12 +8,14 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/utils/keyvalues"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/apiserver/params"
@@ -33,6 +35,13 @@ Output includes the name of the charm used to deploy the application and a
 listing of the application-specific configuration settings.
 See ` + "`juju status`" + ` for application names.
 
+Every change made to the application configuration is recorded as a
+numbered revision. The --history option lists these revisions, showing
+when each change was made, who made it and what changed. The --revert
+option returns the application configuration to the values it had
+immediately after the given revision was made; the revert is itself
+recorded as a new revision.
+
 Examples:
     juju config apache2
     juju config --format=json apache2
@@ -41,6 +50,8 @@ Examples:
     juju config apache2 --file path/to/config.yaml
     juju config mysql dataset-size=80% backup_dir=/vol1/mysql/backups
     juju config apache2 --model mymodel --file /home/ubuntu/mysql.yaml
+    juju config mysql --history
+    juju config mysql --revert 3
 
 See also:
     deploy
@@ -77,6 +88,8 @@ type configCommand struct {
 	resetKeys       []string // Holds the keys to be reset once parsed.
 	useFile         bool
 	values          attributes
+	history         bool
+	revert          int
 }
 
 // configCommandAPI is an interface to allow passing in a fake implementation under test.
@@ -86,13 +99,15 @@ type configCommandAPI interface {
 	Get(application string) (*params.ApplicationGetResults, error)
 	Set(application string, options map[string]string) error
 	Unset(application string, options []string) error
+	ConfigHistory(application string) ([]params.ConfigRevision, error)
+	RevertConfig(application string, revision int) error
 }
 
 // Info is part of the cmd.Command interface.
 func (c *configCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "config",
-		Args:    "<application name> [--reset <key[,key]>] [<attribute-key>][=<value>] ...]",
+		Args:    "<application name> [--reset <key[,key]>] [--history] [--revert <revision>] [<attribute-key>][=<value>] ...]",
 		Purpose: configSummary,
 		Doc:     configDetails,
 	}
@@ -104,6 +119,8 @@ func (c *configCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.Var(&c.configFile, "file", "path to yaml-formatted application config")
 	f.Var(cmd.NewAppendStringsValue(&c.reset), "reset", "Reset the provided comma delimited keys")
+	f.BoolVar(&c.history, "history", false, "Show the history of changes to the application configuration")
+	f.IntVar(&c.revert, "revert", 0, "Revert the application configuration to the given revision")
 }
 
 // getAPI either uses the fake API set at test time or that is nil, gets a real
@@ -137,6 +154,10 @@ func (c *configCommand) Init(args []string) error {
 	c.applicationName = args[0]
 	args = args[1:]
 
+	if c.history || c.revert != 0 {
+		return c.handleHistoryArgs(args)
+	}
+
 	switch len(args) {
 	case 0:
 		return c.handleZeroArgs()
@@ -147,6 +168,26 @@ func (c *configCommand) Init(args []string) error {
 	}
 }
 
+// handleHistoryArgs handles the --history and --revert options, which
+// cannot be combined with each other or with any other operation.
+func (c *configCommand) handleHistoryArgs(args []string) error {
+	if c.history && c.revert != 0 {
+		return errors.New("cannot show history and revert simultaneously")
+	}
+	if len(args) > 0 || len(c.resetKeys) > 0 || c.configFile.Path != "" {
+		return errors.New("cannot get, set or reset application values with --history or --revert")
+	}
+	if c.history {
+		c.action = c.getHistory
+		return nil
+	}
+	if c.revert < 0 {
+		return errors.Errorf("revision %d not valid", c.revert)
+	}
+	c.action = c.revertConfig
+	return nil
+}
+
 // handleZeroArgs handles the case where there are no positional args.
 func (c *configCommand) handleZeroArgs() error {
 	// If there's a path we're setting args from a file
@@ -323,6 +364,59 @@ func (c *configCommand) setConfigFromFile(client configCommandAPI, ctx *cmd.Cont
 				SettingsYAML:    string(b)}), block.BlockChange)
 }
 
+// revertConfig is the run action to return the application config to
+// the requested revision.
+func (c *configCommand) revertConfig(client configCommandAPI, ctx *cmd.Context) error {
+	return block.ProcessBlockedError(client.RevertConfig(c.applicationName, c.revert), block.BlockChange)
+}
+
+// configRevision holds a revision of the application config for display.
+type configRevision struct {
+	Revision  int            `yaml:"revision" json:"revision"`
+	Author    string         `yaml:"author,omitempty" json:"author,omitempty"`
+	Timestamp string         `yaml:"timestamp" json:"timestamp"`
+	Changes   []configChange `yaml:"changes" json:"changes"`
+}
+
+// configChange holds a change to a single application config setting
+// for display.
+type configChange struct {
+	Type     string      `yaml:"type" json:"type"`
+	Key      string      `yaml:"key" json:"key"`
+	OldValue interface{} `yaml:"old-value,omitempty" json:"old-value,omitempty"`
+	NewValue interface{} `yaml:"new-value,omitempty" json:"new-value,omitempty"`
+}
+
+// getHistory is the run action to show the changes made to the
+// application config.
+func (c *configCommand) getHistory(client configCommandAPI, ctx *cmd.Context) error {
+	revisions, err := client.ConfigHistory(c.applicationName)
+	if err != nil {
+		return err
+	}
+	history := make([]configRevision, len(revisions))
+	for i, rev := range revisions {
+		history[i] = configRevision{
+			Revision:  rev.Revision,
+			Author:    rev.Author,
+			Timestamp: rev.Timestamp.UTC().Format(time.RFC3339),
+		}
+		// Users are shown by name rather than by tag.
+		if tag, err := names.ParseUserTag(rev.Author); err == nil {
+			history[i].Author = tag.Id()
+		}
+		for _, change := range rev.Changes {
+			history[i].Changes = append(history[i].Changes, configChange{
+				Type:     change.Type,
+				Key:      change.Key,
+				OldValue: change.OldValue,
+				NewValue: change.NewValue,
+			})
+		}
+	}
+	return c.out.Write(ctx, history)
+}
+
 // getConfig is the run action to return one or all configuration values.
 func (c *configCommand) getConfig(client configCommandAPI, ctx *cmd.Context) error {
 	results, err := client.Get(c.applicationName)
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/juju/cmd"
@@ -18,6 +19,7 @@ import (
 	goyaml "gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/application"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -183,6 +185,22 @@ var setCommandInitErrorTests = []struct {
 	about:       "init too many args fails",
 	args:        []string{"application", "key", "another"},
 	expectError: "can only retrieve a single value, or all values",
+}, {
+	about:       "history and revert fails",
+	args:        []string{"application", "--history", "--revert", "3"},
+	expectError: "cannot show history and revert simultaneously",
+}, {
+	about:       "history with a key fails",
+	args:        []string{"application", "--history", "key"},
+	expectError: "cannot get, set or reset application values with --history or --revert",
+}, {
+	about:       "revert with reset fails",
+	args:        []string{"application", "--revert", "3", "--reset", "key"},
+	expectError: "cannot get, set or reset application values with --history or --revert",
+}, {
+	about:       "revert to a negative revision fails",
+	args:        []string{"application", "--revert", "-1"},
+	expectError: "revision -1 not valid",
 }}
 
 func (s *configCommandSuite) TestSetCommandInitError(c *gc.C) {
@@ -309,6 +327,51 @@ func (s *configCommandSuite) TestBlockSetConfig(c *gc.C) {
 	c.Check(c.GetTestLog(), gc.Matches, "(.|\n)*TestBlockSetConfig(.|\n)*")
 }
 
+func (s *configCommandSuite) TestHistory(c *gc.C) {
+	s.fake.history = []params.ConfigRevision{{
+		Revision:  1,
+		Author:    "user-bob@external",
+		Timestamp: time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC),
+		Changes: []params.ConfigChange{
+			{Type: "modified", Key: "username", OldValue: "admin001", NewValue: "bob"},
+			{Type: "deleted", Key: "title", OldValue: "Nearly There"},
+		},
+	}}
+	cmd := application.NewConfigCommandForTest(s.fake)
+	cmd.SetClientStore(application.NewMockStore())
+	ctx, err := cmdtesting.RunCommand(c, cmd, "dummy-application", "--history")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"- revision: 1\n"+
+		"  author: bob@external\n"+
+		"  timestamp: 2017-06-01T12:00:00Z\n"+
+		"  changes:\n"+
+		"  - type: modified\n"+
+		"    key: username\n"+
+		"    old-value: admin001\n"+
+		"    new-value: bob\n"+
+		"  - type: deleted\n"+
+		"    key: title\n"+
+		"    old-value: Nearly There\n")
+}
+
+func (s *configCommandSuite) TestRevert(c *gc.C) {
+	cmd := application.NewConfigCommandForTest(s.fake)
+	cmd.SetClientStore(application.NewMockStore())
+	_, err := cmdtesting.RunCommand(c, cmd, "dummy-application", "--revert", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.reverted, gc.Equals, 3)
+}
+
+func (s *configCommandSuite) TestBlockRevert(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockRevert")
+	cmd := application.NewConfigCommandForTest(s.fake)
+	cmd.SetClientStore(application.NewMockStore())
+	_, err := cmdtesting.RunCommand(c, cmd, "dummy-application", "--revert", "3")
+	c.Assert(err, gc.ErrorMatches, `(.|\n)*All operations that change model have been disabled(.|\n)*`)
+	c.Check(c.GetTestLog(), gc.Matches, "(.|\n)*TestBlockRevert(.|\n)*")
+}
+
 // assertSetSuccess sets configuration options and checks the expected settings.
 // TODO(rog) the expect parameter is ignored here - presumably
 // it's meant to be checked somehow.
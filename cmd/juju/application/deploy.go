@@ -33,6 +33,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/resource/resourceadapters"
 	"github.com/juju/juju/storage"
 )
@@ -294,6 +295,10 @@ type DeployCommand struct {
 	Bindings map[string]string
 	Steps    []DeployStep
 
+	// IngressAddresses is a map of endpoint name to the address, and
+	// optionally port, advertised to related units on that endpoint.
+	IngressAddresses map[string]string
+
 	// NewAPIRoot stores a function which returns a new API root.
 	NewAPIRoot func() (DeployAPI, error)
 
@@ -354,6 +359,15 @@ than one resource.
 
 Where 'bar' and 'baz' are resources named in the metadata for the 'foo' charm.
 
+The address advertised to related units on an endpoint, in place of the
+addresses of the application's units, may be overridden by specifying the
+'--ingress' option followed by an endpoint=address[:port] pair; e.g. to point
+them at a load balancer in front of the units. An empty endpoint name sets the
+address for all endpoints. This option may be repeated. See also
+` + "`juju set-ingress`" + `.
+
+  juju deploy mysql --ingress server=10.0.0.100:3306
+
 When using a placement directive to deploy to an existing machine or container
 ('--to' option), the ` + "`juju status`" + ` command should be used for guidance. A few
 placement directives are provider-dependent (e.g.: 'zone').
@@ -444,7 +458,7 @@ func (c *DeployCommand) Info() *cmd.Info {
 var (
 	// charmOnlyFlags and bundleOnlyFlags are used to validate flags based on
 	// whether we are deploying a charm or a bundle.
-	charmOnlyFlags  = []string{"bind", "config", "constraints", "force", "n", "num-units", "series", "to", "resource"}
+	charmOnlyFlags  = []string{"bind", "config", "constraints", "force", "n", "num-units", "series", "to", "resource", "ingress"}
 	bundleOnlyFlags = []string{}
 )
 
@@ -462,6 +476,7 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "Charm storage constraints")
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.StringVar(&c.BindToSpaces, "bind", "", "Configure application endpoint bindings to spaces")
+	f.Var(stringMap{&c.IngressAddresses}, "ingress", "Override the ingress address of an application endpoint")
 
 	for _, step := range c.Steps {
 		step.SetFlags(f)
@@ -491,6 +506,11 @@ func (c *DeployCommand) Init(args []string) error {
 	if err := c.parseBind(); err != nil {
 		return err
 	}
+	for endpoint, address := range c.IngressAddresses {
+		if _, _, err := network.ParseAddressPort(address); err != nil {
+			return errors.Annotatef(err, "invalid --ingress for endpoint %q", endpoint)
+		}
+	}
 	return c.UnitCommandBase.Init(args)
 }
 
@@ -627,6 +647,7 @@ func (c *DeployCommand) deployCharm(
 		Storage:          c.Storage,
 		Resources:        ids,
 		EndpointBindings: c.Bindings,
+		IngressAddresses: c.IngressAddresses,
 	}))
 }
 
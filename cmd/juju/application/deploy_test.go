@@ -94,6 +94,12 @@ var initErrorTests = []struct {
 	}, {
 		args: []string{"charm", "application", "--force"},
 		err:  `--force is only used with --series`,
+	}, {
+		args: []string{"charm", "--ingress", "server"},
+		err:  `.*badly formatted name value pair: server`,
+	}, {
+		args: []string{"charm", "--ingress", "server=10.0.0.100:mysql"},
+		err:  `invalid --ingress for endpoint "server": port in "10.0.0.100:mysql" not valid`,
 	},
 }
 
@@ -300,6 +306,18 @@ func (s *DeploySuite) TestConstraints(c *gc.C) {
 	c.Assert(cons, jc.DeepEquals, constraints.MustParse("mem=2G cores=2"))
 }
 
+func (s *DeploySuite) TestIngressAddresses(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "multi-series")
+	_, err := runDeploy(c, ch, "--series", "trusty", "--ingress", "juju-info=10.0.0.100:80", "--ingress", "=lb.example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	curl := charm.MustParseURL("local:trusty/multi-series-1")
+	application, _ := s.AssertService(c, "multi-series", curl, 1, 0)
+	c.Assert(application.IngressAddresses(), jc.DeepEquals, map[string]string{
+		"juju-info": "10.0.0.100:80",
+		"":          "lb.example.com",
+	})
+}
+
 func (s *DeploySuite) TestResources(c *gc.C) {
 	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
 	dir := c.MkDir()
@@ -0,0 +1,358 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/charmrepo.v2-unstable"
+
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/constraints"
+)
+
+var usageDiffBundleSummary = `
+Compares a bundle with the current model and reports the differences.`[1:]
+
+var usageDiffBundleDetails = `
+The bundle can be a local bundle file, or the path to a local bundle
+directory or archive.
+
+The model is exported as a bundle and compared with the given bundle.
+Differences are reported for the applications, their charms, series,
+options, constraints, exposure and unit counts, and for the relations
+between applications. For each difference, the value in the bundle and
+the value in the model are shown. Applications and relations that only
+exist on one side are listed as missing from the other.
+
+Machine placement directives are not compared.
+
+Examples:
+    juju diff-bundle ./mybundle.yaml
+    juju diff-bundle ./mybundle --format json
+
+See also:
+    deploy
+    export-bundle`[1:]
+
+// NewDiffBundleCommand returns a command to compare a bundle against
+// the current model.
+func NewDiffBundleCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&diffBundleCommand{})
+}
+
+// diffBundleCommand reports the differences between a bundle and the
+// current model.
+type diffBundleCommand struct {
+	modelcmd.ModelCommandBase
+	out        cmd.Output
+	bundle     string
+	newAPIFunc func() (DiffBundleAPI, error)
+}
+
+// DiffBundleAPI specifies the API calls used by the diff-bundle command.
+type DiffBundleAPI interface {
+	Close() error
+	ExportBundle() (string, error)
+}
+
+// Info implements cmd.Command.
+func (c *diffBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "diff-bundle",
+		Args:    "<bundle file or directory>",
+		Purpose: usageDiffBundleSummary,
+		Doc:     usageDiffBundleDetails,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *diffBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Init implements cmd.Command.
+func (c *diffBundleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no bundle specified")
+	}
+	c.bundle = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *diffBundleCommand) getAPI() (DiffBundleAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return bundle.NewClient(root), nil
+}
+
+// Run implements cmd.Command.
+func (c *diffBundleCommand) Run(ctx *cmd.Context) error {
+	bundleData, err := readLocalBundle(ctx.AbsPath(c.bundle))
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	modelData := &charm.BundleData{}
+	modelYAML, err := client.ExportBundle()
+	switch {
+	case params.IsCodeNotFound(err):
+		// The model has no applications to export, so
+		// everything in the bundle is missing from it.
+	case err != nil:
+		return errors.Annotate(err, "exporting model")
+	default:
+		modelData, err = charm.ReadBundleData(strings.NewReader(modelYAML))
+		if err != nil {
+			return errors.Annotate(err, "reading exported model")
+		}
+	}
+	return c.out.Write(ctx, diffBundle(bundleData, modelData))
+}
+
+// readLocalBundle reads the bundle data from a bundle file, or from a
+// bundle directory or archive.
+func readLocalBundle(path string) (*charm.BundleData, error) {
+	data, err := charmrepo.ReadBundleFile(path)
+	if err == nil {
+		return data, nil
+	}
+	b, _, err := charmrepo.NewBundleAtPath(path)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read bundle %q", path)
+	}
+	return b.Data(), nil
+}
+
+// bundleDiff describes the differences between a bundle and a model.
+type bundleDiff struct {
+	Applications map[string]*applicationDiff `yaml:"applications,omitempty" json:"applications,omitempty"`
+	Relations    *relationsDiff              `yaml:"relations,omitempty" json:"relations,omitempty"`
+}
+
+// applicationDiff describes the differences between an application in
+// a bundle and the same application in the model. Missing is set to
+// "bundle" or "model" when the application only exists on one side.
+type applicationDiff struct {
+	Missing     string                `yaml:"missing,omitempty" json:"missing,omitempty"`
+	Charm       *stringDiff           `yaml:"charm,omitempty" json:"charm,omitempty"`
+	Series      *stringDiff           `yaml:"series,omitempty" json:"series,omitempty"`
+	Constraints *stringDiff           `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+	Options     map[string]optionDiff `yaml:"options,omitempty" json:"options,omitempty"`
+	Expose      *boolDiff             `yaml:"expose,omitempty" json:"expose,omitempty"`
+	NumUnits    *intDiff              `yaml:"num_units,omitempty" json:"num_units,omitempty"`
+}
+
+func (d *applicationDiff) empty() bool {
+	return d.Missing == "" &&
+		d.Charm == nil &&
+		d.Series == nil &&
+		d.Constraints == nil &&
+		len(d.Options) == 0 &&
+		d.Expose == nil &&
+		d.NumUnits == nil
+}
+
+type stringDiff struct {
+	Bundle string `yaml:"bundle" json:"bundle"`
+	Model  string `yaml:"model" json:"model"`
+}
+
+type boolDiff struct {
+	Bundle bool `yaml:"bundle" json:"bundle"`
+	Model  bool `yaml:"model" json:"model"`
+}
+
+type intDiff struct {
+	Bundle int `yaml:"bundle" json:"bundle"`
+	Model  int `yaml:"model" json:"model"`
+}
+
+type optionDiff struct {
+	Bundle interface{} `yaml:"bundle" json:"bundle"`
+	Model  interface{} `yaml:"model" json:"model"`
+}
+
+// relationsDiff holds the relations that are only present in the
+// bundle and the relations that are only present in the model.
+type relationsDiff struct {
+	BundleAdditions [][]string `yaml:"bundle-additions,omitempty" json:"bundle-additions,omitempty"`
+	ModelAdditions  [][]string `yaml:"model-additions,omitempty" json:"model-additions,omitempty"`
+}
+
+// diffBundle compares the bundle data with the data exported from the
+// model.
+func diffBundle(bundleData, modelData *charm.BundleData) *bundleDiff {
+	result := &bundleDiff{
+		Applications: make(map[string]*applicationDiff),
+	}
+	for name, bundleApp := range bundleData.Applications {
+		modelApp, ok := modelData.Applications[name]
+		if !ok {
+			result.Applications[name] = &applicationDiff{Missing: "model"}
+			continue
+		}
+		diff := diffApplication(bundleData.Series, bundleApp, modelApp)
+		if !diff.empty() {
+			result.Applications[name] = diff
+		}
+	}
+	for name := range modelData.Applications {
+		if _, ok := bundleData.Applications[name]; !ok {
+			result.Applications[name] = &applicationDiff{Missing: "bundle"}
+		}
+	}
+	result.Relations = diffRelations(bundleData.Relations, modelData.Relations)
+	return result
+}
+
+func diffApplication(defaultSeries string, bundleApp, modelApp *charm.ApplicationSpec) *applicationDiff {
+	result := &applicationDiff{}
+	if !charmMatches(bundleApp.Charm, modelApp.Charm) {
+		result.Charm = &stringDiff{Bundle: bundleApp.Charm, Model: modelApp.Charm}
+	}
+	series := bundleApp.Series
+	if series == "" {
+		series = defaultSeries
+	}
+	if series != "" && series != modelApp.Series {
+		result.Series = &stringDiff{Bundle: series, Model: modelApp.Series}
+	}
+	if bundleCons, modelCons := normaliseConstraints(bundleApp.Constraints), normaliseConstraints(modelApp.Constraints); bundleCons != modelCons {
+		result.Constraints = &stringDiff{Bundle: bundleApp.Constraints, Model: modelApp.Constraints}
+	}
+	if bundleApp.Expose != modelApp.Expose {
+		result.Expose = &boolDiff{Bundle: bundleApp.Expose, Model: modelApp.Expose}
+	}
+	if bundleApp.NumUnits != modelApp.NumUnits {
+		result.NumUnits = &intDiff{Bundle: bundleApp.NumUnits, Model: modelApp.NumUnits}
+	}
+	for name, bundleValue := range bundleApp.Options {
+		modelValue, ok := modelApp.Options[name]
+		if !ok || !reflect.DeepEqual(bundleValue, modelValue) {
+			if result.Options == nil {
+				result.Options = make(map[string]optionDiff)
+			}
+			result.Options[name] = optionDiff{Bundle: bundleValue, Model: modelValue}
+		}
+	}
+	for name, modelValue := range modelApp.Options {
+		if _, ok := bundleApp.Options[name]; !ok {
+			if result.Options == nil {
+				result.Options = make(map[string]optionDiff)
+			}
+			result.Options[name] = optionDiff{Model: modelValue}
+		}
+	}
+	return result
+}
+
+// charmMatches reports whether the charm URL in the bundle refers to
+// the charm in the model. The series and revision of the model's charm
+// are only compared if they are specified in the bundle, so that
+// "cs:mysql" in a bundle matches "cs:xenial/mysql-57" in the model.
+func charmMatches(bundleCharm, modelCharm string) bool {
+	bundleURL, err := charm.ParseURL(bundleCharm)
+	if err != nil {
+		return bundleCharm == modelCharm
+	}
+	modelURL, err := charm.ParseURL(modelCharm)
+	if err != nil {
+		return false
+	}
+	if bundleURL.Schema != modelURL.Schema ||
+		bundleURL.User != modelURL.User ||
+		bundleURL.Name != modelURL.Name {
+		return false
+	}
+	if bundleURL.Series != "" && bundleURL.Series != modelURL.Series {
+		return false
+	}
+	if bundleURL.Revision >= 0 && bundleURL.Revision != modelURL.Revision {
+		return false
+	}
+	return true
+}
+
+func normaliseConstraints(s string) string {
+	cons, err := constraints.Parse(s)
+	if err != nil {
+		return s
+	}
+	return cons.String()
+}
+
+// diffRelations compares the relations in the bundle with those in the
+// model. Bundle relation endpoints may omit the relation name, in which
+// case they match any relation of the named application.
+func diffRelations(bundleRelations, modelRelations [][]string) *relationsDiff {
+	remaining := make([][]string, len(modelRelations))
+	copy(remaining, modelRelations)
+	var result relationsDiff
+	for _, bundleRel := range bundleRelations {
+		found := false
+		for i, modelRel := range remaining {
+			if relationMatches(bundleRel, modelRel) {
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			result.BundleAdditions = append(result.BundleAdditions, bundleRel)
+		}
+	}
+	result.ModelAdditions = remaining
+	if len(result.BundleAdditions) == 0 && len(result.ModelAdditions) == 0 {
+		return nil
+	}
+	sort.Sort(relationsByEndpoints(result.BundleAdditions))
+	sort.Sort(relationsByEndpoints(result.ModelAdditions))
+	return &result
+}
+
+func relationMatches(bundleRel, modelRel []string) bool {
+	if len(bundleRel) != 2 || len(modelRel) != 2 {
+		return false
+	}
+	return endpointMatches(bundleRel[0], modelRel[0]) && endpointMatches(bundleRel[1], modelRel[1]) ||
+		endpointMatches(bundleRel[0], modelRel[1]) && endpointMatches(bundleRel[1], modelRel[0])
+}
+
+func endpointMatches(bundleEndpoint, modelEndpoint string) bool {
+	if strings.Contains(bundleEndpoint, ":") {
+		return bundleEndpoint == modelEndpoint
+	}
+	return bundleEndpoint == strings.SplitN(modelEndpoint, ":", 2)[0]
+}
+
+// relationsByEndpoints sorts relations by their endpoints.
+type relationsByEndpoints [][]string
+
+func (r relationsByEndpoints) Len() int      { return len(r) }
+func (r relationsByEndpoints) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r relationsByEndpoints) Less(i, j int) bool {
+	return strings.Join(r[i], " ") < strings.Join(r[j], " ")
+}
@@ -0,0 +1,202 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient"
+)
+
+type DiffBundleSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockDiffBundleAPI
+	store   *jujuclient.MemStore
+	dir     string
+}
+
+var _ = gc.Suite(&DiffBundleSuite{})
+
+func (s *DiffBundleSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockDiffBundleAPI{Stub: &testing.Stub{}}
+	s.dir = c.MkDir()
+
+	controllerName := "test-master"
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = controllerName
+	s.store.Controllers[controllerName] = jujuclient.ControllerDetails{}
+	s.store.Models[controllerName] = &jujuclient.ControllerModels{
+		CurrentModel: "bob/test",
+		Models: map[string]jujuclient.ModelDetails{
+			"bob/test": {"test-uuid"},
+		},
+	}
+	s.store.Accounts[controllerName] = jujuclient.AccountDetails{
+		User: "bob",
+	}
+}
+
+func (s *DiffBundleSuite) writeBundle(c *gc.C, content string) string {
+	path := filepath.Join(s.dir, "bundle.yaml")
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *DiffBundleSuite) runDiffBundle(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, application.NewDiffBundleCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *DiffBundleSuite) TestNoArguments(c *gc.C) {
+	_, err := s.runDiffBundle(c)
+	c.Assert(err, gc.ErrorMatches, "no bundle specified")
+}
+
+func (s *DiffBundleSuite) TestTooManyArguments(c *gc.C) {
+	_, err := s.runDiffBundle(c, "bundle.yaml", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *DiffBundleSuite) TestMissingBundle(c *gc.C) {
+	_, err := s.runDiffBundle(c, filepath.Join(s.dir, "missing.yaml"))
+	c.Assert(err, gc.ErrorMatches, `cannot read bundle ".*missing.yaml": .*`)
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *DiffBundleSuite) TestErrorFromAPI(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("infirmary"))
+	_, err := s.runDiffBundle(c, s.writeBundle(c, diffBundleModel))
+	c.Assert(err, gc.ErrorMatches, "exporting model: infirmary")
+	s.mockAPI.CheckCallNames(c, "ExportBundle", "Close")
+}
+
+func (s *DiffBundleSuite) TestEmptyModel(c *gc.C) {
+	s.mockAPI.SetErrors(&params.Error{
+		Code:    params.CodeNotFound,
+		Message: "applications in the model not found",
+	})
+	ctx, err := s.runDiffBundle(c, s.writeBundle(c, `
+applications:
+  mysql:
+    charm: cs:mysql
+    num_units: 1
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+applications:
+  mysql:
+    missing: model
+`[1:])
+}
+
+func (s *DiffBundleSuite) TestNoDifferences(c *gc.C) {
+	s.mockAPI.model = diffBundleModel
+	ctx, err := s.runDiffBundle(c, s.writeBundle(c, diffBundleModel))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "{}\n")
+}
+
+func (s *DiffBundleSuite) TestDifferences(c *gc.C) {
+	s.mockAPI.model = diffBundleModel
+	ctx, err := s.runDiffBundle(c, s.writeBundle(c, `
+series: xenial
+applications:
+  mysql:
+    charm: cs:mysql
+    num_units: 1
+    options:
+      dataset-size: 80%
+    constraints: mem=4G
+  wordpress:
+    charm: cs:xenial/wordpress-5
+    num_units: 2
+    expose: true
+  haproxy:
+    charm: cs:haproxy
+    num_units: 1
+relations:
+- [wordpress, mysql]
+- [wordpress:website, haproxy:reverseproxy]
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+applications:
+  haproxy:
+    missing: model
+  memcached:
+    missing: bundle
+  mysql:
+    options:
+      dataset-size:
+        bundle: 80%
+        model: 60%
+      max-connections:
+        bundle: null
+        model: 500
+  wordpress:
+    charm:
+      bundle: cs:xenial/wordpress-5
+      model: cs:xenial/wordpress-4
+    num_units:
+      bundle: 2
+      model: 3
+relations:
+  bundle-additions:
+  - - wordpress:website
+    - haproxy:reverseproxy
+  model-additions:
+  - - wordpress:cache
+    - memcached:cache
+`[1:])
+}
+
+const diffBundleModel = `
+applications:
+  mysql:
+    charm: cs:xenial/mysql-57
+    series: xenial
+    num_units: 1
+    options:
+      dataset-size: 60%
+      max-connections: 500
+    constraints: mem=4096M
+  wordpress:
+    charm: cs:xenial/wordpress-4
+    series: xenial
+    num_units: 3
+    expose: true
+  memcached:
+    charm: cs:xenial/memcached-10
+    series: xenial
+    num_units: 1
+relations:
+- [wordpress:db, mysql:db]
+- [wordpress:cache, memcached:cache]
+`
+
+type mockDiffBundleAPI struct {
+	*testing.Stub
+	model string
+}
+
+func (m *mockDiffBundleAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockDiffBundleAPI) ExportBundle() (string, error) {
+	m.MethodCall(m, "ExportBundle")
+	return m.model, m.NextErr()
+}
@@ -0,0 +1,150 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/network"
+)
+
+var usageEgressSummary = `
+Shows or sets the egress policy of an application.`[1:]
+
+var usageEgressDetails = `
+An application's egress policy restricts the destinations to which its
+units may send outgoing traffic. Once a policy is set, outgoing traffic
+is only allowed to the ports and CIDRs of the policy's rules, to the
+controllers, and in reply to incoming connections. The policy is
+enforced by the cloud's security groups where supported, which is
+currently only OpenStack with the instance firewall mode, and by the
+machine's firewall otherwise. Machines hosting units of an application
+without an egress policy are not restricted.
+
+Each rule is a port or port range with an optional protocol (tcp by
+default), or "icmp", optionally followed by "@" and a comma-separated
+list of destination CIDRs. A rule without destination CIDRs allows
+outgoing traffic to the ports of any destination.
+
+With no rules, the application's current policy is shown. Specifying
+rules replaces the policy; the --reset option removes it, lifting the
+restriction.
+
+Examples:
+    juju egress mysql
+    juju egress mysql 443/tcp@10.0.0.0/8 53/udp@10.0.0.2/32 icmp@10.0.0.0/8
+    juju egress wordpress 80 443 3306@192.168.1.0/24
+    juju egress mysql --reset
+
+See also:
+    expose`[1:]
+
+// NewEgressCommand returns a command to show or set the egress
+// policy of an application.
+func NewEgressCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&egressCommand{})
+}
+
+// egressCommand shows or sets the egress policy of an application.
+type egressCommand struct {
+	modelcmd.ModelCommandBase
+	api egressAPI
+
+	ApplicationName string
+	Rules           []network.EgressRule
+	Reset           bool
+}
+
+func (c *egressCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "egress",
+		Args:    "<application name> [<rule> ...]",
+		Purpose: usageEgressSummary,
+		Doc:     usageEgressDetails,
+	}
+}
+
+func (c *egressCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.Reset, "reset", false, "Remove the egress policy of the application")
+}
+
+func (c *egressCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	c.ApplicationName = args[0]
+	if c.Reset && len(args) > 1 {
+		return errors.New("cannot specify rules with --reset")
+	}
+	for _, arg := range args[1:] {
+		rule, err := network.ParseEgressRule(arg)
+		if err != nil {
+			return errors.Annotatef(err, "invalid rule %q", arg)
+		}
+		c.Rules = append(c.Rules, rule)
+	}
+	return nil
+}
+
+type egressAPI interface {
+	Close() error
+	EgressRules(application string) ([]network.EgressRule, error)
+	SetEgressRules(application string, rules []network.EgressRule) error
+}
+
+func (c *egressCommand) getAPI() (egressAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run shows or sets the egress policy of the application.
+func (c *egressCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if !c.Reset && len(c.Rules) == 0 {
+		rules, err := client.EgressRules(c.ApplicationName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(rules) == 0 {
+			ctx.Infof("application %q has no egress policy", c.ApplicationName)
+			return nil
+		}
+		for _, rule := range rules {
+			fmt.Fprintln(ctx.Stdout, formatEgressRule(rule))
+		}
+		return nil
+	}
+	err = client.SetEgressRules(c.ApplicationName, c.Rules)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
+// formatEgressRule formats the rule in the syntax
+// accepted by network.ParseEgressRule.
+func formatEgressRule(rule network.EgressRule) string {
+	formatted := rule.PortRange.String()
+	if len(rule.DestinationCIDRs) > 0 {
+		formatted += "@" + strings.Join(rule.DestinationCIDRs, ",")
+	}
+	return formatted
+}
@@ -0,0 +1,116 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"strings"
+
+	"github.com/juju/cmd/cmdtesting"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type EgressSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeEgressAPI
+}
+
+var _ = gc.Suite(&EgressSuite{})
+
+type fakeEgressAPI struct {
+	jujutesting.Stub
+	rules []network.EgressRule
+}
+
+func (f *fakeEgressAPI) Close() error {
+	return nil
+}
+
+func (f *fakeEgressAPI) EgressRules(application string) ([]network.EgressRule, error) {
+	f.MethodCall(f, "EgressRules", application)
+	return f.rules, f.NextErr()
+}
+
+func (f *fakeEgressAPI) SetEgressRules(application string, rules []network.EgressRule) error {
+	f.MethodCall(f, "SetEgressRules", application, rules)
+	return f.NextErr()
+}
+
+func (s *EgressSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeEgressAPI{}
+}
+
+func (s *EgressSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  `no application name specified`,
+	}, {
+		args: []string{"mysql", "--reset", "443"},
+		err:  `cannot specify rules with --reset`,
+	}, {
+		args: []string{"mysql", "443/tcp@10.0.0/8"},
+		err:  `invalid rule "443/tcp@10.0.0/8": invalid CIDR address: 10.0.0/8`,
+	}, {
+		args: []string{"mysql", "443/icmp"},
+		err:  `invalid rule "443/icmp": invalid protocol "icmp", expected "tcp" or "udp"`,
+	}} {
+		c.Logf("test %d", i)
+		err := cmdtesting.InitCommand(application.NewEgressCommandForTest(s.fake), t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *EgressSuite) TestShowRules(c *gc.C) {
+	s.fake.rules = []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8", "192.168.1.0/24"),
+		network.MustNewEgressRule("udp", 53, 53),
+	}
+	ctx, err := cmdtesting.RunCommand(c, application.NewEgressCommandForTest(s.fake), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "443/tcp@10.0.0.0/8,192.168.1.0/24\n53/udp\n")
+	s.fake.CheckCall(c, 0, "EgressRules", "mysql")
+}
+
+func (s *EgressSuite) TestShowNoRules(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, application.NewEgressCommandForTest(s.fake), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "application \"mysql\" has no egress policy\n")
+}
+
+func (s *EgressSuite) TestSetRules(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, application.NewEgressCommandForTest(s.fake),
+		"mysql", "443@10.0.0.0/8", "53/udp@10.0.0.2/32",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCall(c, 0, "SetEgressRules", "mysql", []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+	})
+}
+
+func (s *EgressSuite) TestReset(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, application.NewEgressCommandForTest(s.fake), "mysql", "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCall(c, 0, "SetEgressRules", "mysql", []network.EgressRule(nil))
+}
+
+func (s *EgressSuite) TestBlockSetRules(c *gc.C) {
+	s.fake.SetErrors(common.OperationBlockedError("TestBlockSetRules"))
+	cmdtesting.RunCommand(c, application.NewEgressCommandForTest(s.fake), "mysql", "443")
+
+	// msg is logged
+	stripped := strings.Replace(c.GetTestLog(), "\n", "", -1)
+	c.Check(stripped, gc.Matches, ".*TestBlockSetRules.*")
+}
@@ -46,6 +46,27 @@ func NewAddUnitCommandForTest(api serviceAddUnitAPI) cmd.Command {
 	})
 }
 
+// NewEgressCommandForTest returns an EgressCommand with the api provided as specified.
+func NewEgressCommandForTest(api egressAPI) cmd.Command {
+	return modelcmd.Wrap(&egressCommand{
+		api: api,
+	})
+}
+
+// NewSetIngressCommandForTest returns a SetIngressCommand with the api provided as specified.
+func NewSetIngressCommandForTest(api setIngressAPI) cmd.Command {
+	return modelcmd.Wrap(&setIngressCommand{
+		api: api,
+	})
+}
+
+// NewSetActionConcurrencyCommandForTest returns a SetActionConcurrencyCommand with the api provided as specified.
+func NewSetActionConcurrencyCommandForTest(api setActionConcurrencyAPI) cmd.Command {
+	return modelcmd.Wrap(&setActionConcurrencyCommand{
+		api: api,
+	})
+}
+
 // NewAddRelationCommandForTest returns an AddRelationCommand with the api provided as specified.
 func NewAddRelationCommandForTest(api ApplicationAddRelationAPI) modelcmd.ModelCommand {
 	cmd := &addRelationCommand{newAPIFunc: func() (ApplicationAddRelationAPI, error) {
@@ -81,3 +102,12 @@ func PatchNewCharmStoreClient(s Patcher, url string) {
 		})
 	})
 }
+
+// NewDiffBundleCommandForTest returns a DiffBundleCommand with the api provided as specified.
+func NewDiffBundleCommandForTest(api DiffBundleAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &diffBundleCommand{newAPIFunc: func() (DiffBundleAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -4,10 +4,15 @@
 package application
 
 import (
+	"net"
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -19,8 +24,19 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+By default the application is exposed to all sources. The --to-cidrs
+option restricts access to the specified comma-separated CIDRs, and
+the --endpoints option applies the restriction to the specified
+comma-separated application endpoints only. Ports opened by the
+application's units for an endpoint may be accessed from the CIDRs
+the endpoint is exposed to; other ports use the settings applied
+without --endpoints, if any. Running expose again for other endpoints
+adds to the existing settings.
+
 Examples:
     juju expose wordpress
+    juju expose wordpress --to-cidrs 10.0.0.0/24,192.168.1.0/24
+    juju expose mysql --endpoints server-admin --to-cidrs 203.0.113.0/24
 
 See also: 
     unexpose`[1:]
@@ -34,6 +50,10 @@ func NewExposeCommand() modelcmd.ModelCommand {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	endpointsList   string
+	cidrsList       string
+	endpoints       []string
+	cidrs           []string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,18 +65,45 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.endpointsList, "endpoints", "", "Comma-separated list of endpoints to expose")
+	f.StringVar(&c.cidrsList, "to-cidrs", "", "Comma-separated list of CIDRs to expose the application to")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
 	}
 	c.ApplicationName = args[0]
+	c.endpoints = splitCommaList(c.endpointsList)
+	c.cidrs = splitCommaList(c.cidrsList)
+	for _, cidr := range c.cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.Errorf("invalid CIDR %q", cidr)
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
+// splitCommaList splits a comma-separated list,
+// ignoring surrounding whitespace and empty items.
+func splitCommaList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 type serviceExposeAPI interface {
 	Close() error
 	Expose(serviceName string) error
+	ExposeEndpoints(serviceName string, exposed map[string]params.ExposedEndpoint) error
 	Unexpose(serviceName string) error
+	UnexposeEndpoints(serviceName string, endpoints []string) error
 }
 
 func (c *exposeCommand) getAPI() (serviceExposeAPI, error) {
@@ -75,5 +122,17 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	if len(c.endpoints) == 0 && len(c.cidrs) == 0 {
+		return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	}
+	endpoints := c.endpoints
+	if len(endpoints) == 0 {
+		// The settings apply to all endpoints.
+		endpoints = []string{""}
+	}
+	exposed := make(map[string]params.ExposedEndpoint)
+	for _, endpoint := range endpoints {
+		exposed[endpoint] = params.ExposedEndpoint{ExposeToCIDRs: c.cidrs}
+	}
+	return block.ProcessBlockedError(client.ExposeEndpoints(c.ApplicationName, exposed), block.BlockChange)
 }
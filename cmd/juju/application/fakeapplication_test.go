@@ -18,6 +18,8 @@ type fakeApplicationAPI struct {
 	charmName string
 	values    map[string]interface{}
 	config    string
+	history   []params.ConfigRevision
+	reverted  int
 	err       error
 }
 
@@ -100,3 +102,23 @@ func (f *fakeApplicationAPI) Unset(application string, options []string) error {
 
 	return nil
 }
+
+func (f *fakeApplicationAPI) ConfigHistory(application string) ([]params.ConfigRevision, error) {
+	if application != f.name {
+		return nil, errors.NotFoundf("application %q", application)
+	}
+	return f.history, nil
+}
+
+func (f *fakeApplicationAPI) RevertConfig(application string, revision int) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	if application != f.name {
+		return errors.NotFoundf("application %q", application)
+	}
+
+	f.reverted = revision
+	return nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/network"
+)
+
+var usageSetIngressSummary = `
+Shows or sets the ingress addresses of an application's endpoints.`[1:]
+
+var usageSetIngressDetails = `
+Related units reach the units of an application at the ingress addresses
+advertised on its endpoints: by default, the addresses of the units on the
+spaces the endpoints are bound to. When the units are behind NAT or a load
+balancer, the ingress address of an endpoint may be overridden, so that
+related units are pointed at the external address or the virtual IP of the
+load balancer instead.
+
+Each override is an endpoint name and an address, optionally followed by a
+port, separated by "="; IPv6 addresses with a port must be enclosed in square
+brackets. An override without an endpoint name applies to all endpoints
+without one of their own, and an override without an address removes that of
+the endpoint. Overrides are merged into those of the application.
+
+The overriding address is advertised in the "ingress-address" and
+"private-address" relation settings of the application's units, and any port
+in the "ingress-port" setting; the settings are updated when the overrides
+change, running the relation-changed hooks of related units. Units can see
+the ingress address of an endpoint with "network-get --ingress-address".
+
+With no overrides, the application's current overrides are shown.
+
+Examples:
+    juju set-ingress mysql
+    juju set-ingress mysql db=10.0.0.100:3306
+    juju set-ingress haproxy =lb.example.com website=[2001:db8::100]:80
+    juju set-ingress mysql db=
+
+See also:
+    deploy
+    expose`[1:]
+
+// NewSetIngressCommand returns a command to show or set the ingress
+// addresses of an application's endpoints.
+func NewSetIngressCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&setIngressCommand{})
+}
+
+// setIngressCommand shows or sets the ingress addresses of an
+// application's endpoints.
+type setIngressCommand struct {
+	modelcmd.ModelCommandBase
+	api setIngressAPI
+
+	ApplicationName  string
+	IngressAddresses map[string]string
+}
+
+func (c *setIngressCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-ingress",
+		Args:    "<application name> [[<endpoint>]=[<address>[:<port>]] ...]",
+		Purpose: usageSetIngressSummary,
+		Doc:     usageSetIngressDetails,
+	}
+}
+
+func (c *setIngressCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	c.ApplicationName = args[0]
+	for _, arg := range args[1:] {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 {
+			return errors.Errorf("expected <endpoint>=<address>[:<port>], got %q", arg)
+		}
+		endpoint, address := parts[0], parts[1]
+		if _, ok := c.IngressAddresses[endpoint]; ok {
+			return errors.Errorf("duplicate endpoint %q", endpoint)
+		}
+		if address != "" {
+			if _, _, err := network.ParseAddressPort(address); err != nil {
+				return errors.Annotatef(err, "invalid ingress address for endpoint %q", endpoint)
+			}
+		}
+		if c.IngressAddresses == nil {
+			c.IngressAddresses = make(map[string]string)
+		}
+		c.IngressAddresses[endpoint] = address
+	}
+	return nil
+}
+
+type setIngressAPI interface {
+	Close() error
+	IngressAddresses(application string) (map[string]string, error)
+	SetIngressAddresses(application string, addresses map[string]string) error
+}
+
+func (c *setIngressCommand) getAPI() (setIngressAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run shows or sets the ingress addresses of the application.
+func (c *setIngressCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if len(c.IngressAddresses) == 0 {
+		addresses, err := client.IngressAddresses(c.ApplicationName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(addresses) == 0 {
+			ctx.Infof("application %q has no ingress addresses", c.ApplicationName)
+			return nil
+		}
+		endpoints := make([]string, 0, len(addresses))
+		for endpoint := range addresses {
+			endpoints = append(endpoints, endpoint)
+		}
+		sort.Strings(endpoints)
+		for _, endpoint := range endpoints {
+			fmt.Fprintf(ctx.Stdout, "%s=%s\n", endpoint, addresses[endpoint])
+		}
+		return nil
+	}
+	err = client.SetIngressAddresses(c.ApplicationName, c.IngressAddresses)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
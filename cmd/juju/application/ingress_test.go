@@ -0,0 +1,110 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"strings"
+
+	"github.com/juju/cmd/cmdtesting"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/testing"
+)
+
+type SetIngressSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeSetIngressAPI
+}
+
+var _ = gc.Suite(&SetIngressSuite{})
+
+type fakeSetIngressAPI struct {
+	jujutesting.Stub
+	addresses map[string]string
+}
+
+func (f *fakeSetIngressAPI) Close() error {
+	return nil
+}
+
+func (f *fakeSetIngressAPI) IngressAddresses(application string) (map[string]string, error) {
+	f.MethodCall(f, "IngressAddresses", application)
+	return f.addresses, f.NextErr()
+}
+
+func (f *fakeSetIngressAPI) SetIngressAddresses(application string, addresses map[string]string) error {
+	f.MethodCall(f, "SetIngressAddresses", application, addresses)
+	return f.NextErr()
+}
+
+func (s *SetIngressSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeSetIngressAPI{}
+}
+
+func (s *SetIngressSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  `no application name specified`,
+	}, {
+		args: []string{"mysql", "10.0.0.100"},
+		err:  `expected <endpoint>=<address>\[:<port>\], got "10.0.0.100"`,
+	}, {
+		args: []string{"mysql", "db=10.0.0.100", "db="},
+		err:  `duplicate endpoint "db"`,
+	}, {
+		args: []string{"mysql", "db=10.0.0.100:mysql"},
+		err:  `invalid ingress address for endpoint "db": port in "10.0.0.100:mysql" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := cmdtesting.InitCommand(application.NewSetIngressCommandForTest(s.fake), t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SetIngressSuite) TestShowIngressAddresses(c *gc.C) {
+	s.fake.addresses = map[string]string{
+		"db": "10.0.0.100:3306",
+		"":   "lb.example.com",
+	}
+	ctx, err := cmdtesting.RunCommand(c, application.NewSetIngressCommandForTest(s.fake), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "=lb.example.com\ndb=10.0.0.100:3306\n")
+	s.fake.CheckCall(c, 0, "IngressAddresses", "mysql")
+}
+
+func (s *SetIngressSuite) TestShowNoIngressAddresses(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, application.NewSetIngressCommandForTest(s.fake), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "application \"mysql\" has no ingress addresses\n")
+}
+
+func (s *SetIngressSuite) TestSetIngressAddresses(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, application.NewSetIngressCommandForTest(s.fake),
+		"mysql", "db=[2001:db8::100]:3306", "=lb.example.com", "admin=",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCall(c, 0, "SetIngressAddresses", "mysql", map[string]string{
+		"db":    "[2001:db8::100]:3306",
+		"":      "lb.example.com",
+		"admin": "",
+	})
+}
+
+func (s *SetIngressSuite) TestBlockSetIngressAddresses(c *gc.C) {
+	s.fake.SetErrors(common.OperationBlockedError("TestBlockSetIngressAddresses"))
+	cmdtesting.RunCommand(c, application.NewSetIngressCommandForTest(s.fake), "mysql", "db=10.0.0.100")
+
+	// msg is logged
+	stripped := strings.Replace(c.GetTestLog(), "\n", "", -1)
+	c.Check(stripped, gc.Matches, ".*TestBlockSetIngressAddresses.*")
+}
@@ -6,6 +6,7 @@ package application
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/cmd/juju/block"
@@ -20,8 +21,13 @@ Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to deny public access to the application.
 An application is unexposed by default when it gets created.
 
+The --endpoints option removes the expose settings of the specified
+comma-separated application endpoints only; the application remains
+exposed while any settings remain.
+
 Examples:
     juju unexpose wordpress
+    juju unexpose mysql --endpoints server-admin
 
 See also: 
     expose`[1:]
@@ -35,6 +41,8 @@ func NewUnexposeCommand() modelcmd.ModelCommand {
 type unexposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	endpointsList   string
+	endpoints       []string
 }
 
 func (c *unexposeCommand) Info() *cmd.Info {
@@ -46,11 +54,17 @@ func (c *unexposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *unexposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.endpointsList, "endpoints", "", "Comma-separated list of endpoints to unexpose")
+}
+
 func (c *unexposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
 	}
 	c.ApplicationName = args[0]
+	c.endpoints = splitCommaList(c.endpointsList)
 	return cmd.CheckEmpty(args[1:])
 }
 
@@ -70,5 +84,8 @@ func (c *unexposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
+	if len(c.endpoints) > 0 {
+		return block.ProcessBlockedError(client.UnexposeEndpoints(c.ApplicationName, c.endpoints), block.BlockChange)
+	}
 	return block.ProcessBlockedError(client.Unexpose(c.ApplicationName), block.BlockChange)
 }
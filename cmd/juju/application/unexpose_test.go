@@ -65,6 +65,25 @@ func (s *UnexposeSuite) TestUnexpose(c *gc.C) {
 	})
 }
 
+func (s *UnexposeSuite) TestUnexposeEndpoints(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "multi-series")
+	_, err := runDeploy(c, ch, "some-application-name", "--series", "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runExpose(c, "some-application-name", "--to-cidrs", "10.0.0.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	err = runExpose(c, "some-application-name", "--endpoints", "juju-info", "--to-cidrs", "203.0.113.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runUnexpose(c, "some-application-name", "--endpoints", "juju-info")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExposed(c, "some-application-name", true)
+
+	err = runUnexpose(c, "some-application-name", "--endpoints", "juju-info")
+	c.Assert(err, gc.ErrorMatches, `cannot unexpose application "some-application-name": expose settings for endpoint "juju-info" not found`)
+	s.assertExposed(c, "some-application-name", true)
+}
+
 func (s *UnexposeSuite) TestBlockUnexpose(c *gc.C) {
 	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "multi-series")
 	_, err := runDeploy(c, ch, "some-application-name", "--series", "trusty")
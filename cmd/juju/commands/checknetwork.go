@@ -0,0 +1,319 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	actionapi "github.com/juju/juju/api/action"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+func newDefaultCheckNetworkCommand() cmd.Command {
+	return newCheckNetworkCommand(time.After)
+}
+
+func newCheckNetworkCommand(timeAfter func(time.Duration) <-chan time.Time) cmd.Command {
+	return modelcmd.Wrap(&checkNetworkCommand{
+		timeAfter: timeAfter,
+	})
+}
+
+// checkNetworkCommand checks that the units of related applications
+// can reach each other.
+type checkNetworkCommand struct {
+	modelcmd.ModelCommandBase
+	out          cmd.Output
+	timeout      time.Duration
+	probeTimeout time.Duration
+	applications []string
+	timeAfter    func(time.Duration) <-chan time.Time
+}
+
+const checkNetworkDoc = `
+Check that the units of the specified applications can reach the units
+they are related to. Only admin users of a model are able to use this
+command.
+
+Each unit probes the ingress address of each of its related units, on
+the space the relation's endpoint is bound to; these are the addresses
+reported to the units by network-get. If no applications are specified,
+the units of all the applications in the model are checked.
+
+The report lists each pair of units with the address that was probed,
+and whether it could be reached. The command fails if any pair is
+unreachable, or if any unit does not report back before the timeout.
+
+Since check-network creates actions, you can query for the status of
+checks by calling "juju show-action-status --name juju-check-network".
+
+Examples:
+    juju check-network
+    juju check-network wordpress mysql
+    juju check-network --probe-timeout 10s mysql
+
+See also:
+    network-get
+`
+
+func (c *checkNetworkCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "check-network",
+		Args:    "[<application name> ...]",
+		Purpose: "Check connectivity between the units of related applications.",
+		Doc:     checkNetworkDoc,
+	}
+}
+
+func (c *checkNetworkCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatCheckNetworkTabular,
+	})
+	f.DurationVar(&c.timeout, "timeout", 5*time.Minute, "How long to wait for the units to report")
+	f.DurationVar(&c.probeTimeout, "probe-timeout", 5*time.Second, "How long to wait for a reply from each address")
+}
+
+func (c *checkNetworkCommand) Init(args []string) error {
+	var nameErrors []string
+	for _, application := range args {
+		if !names.IsValidApplication(application) {
+			nameErrors = append(nameErrors, fmt.Sprintf("  %q is not a valid application name", application))
+		}
+	}
+	if len(nameErrors) > 0 {
+		return errors.Errorf("The following applications are not valid:\n%s",
+			strings.Join(nameErrors, "\n"))
+	}
+	if c.probeTimeout <= 0 {
+		return errors.New("probe timeout must be positive")
+	}
+	c.applications = args
+	return nil
+}
+
+// networkCheck records whether a unit could reach a related unit.
+type networkCheck struct {
+	Unit     string `yaml:"unit" json:"unit"`
+	Target   string `yaml:"target" json:"target"`
+	Relation string `yaml:"relation" json:"relation"`
+	Address  string `yaml:"address" json:"address"`
+	Status   string `yaml:"status" json:"status"`
+	Message  string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+const (
+	networkCheckReachable   = "reachable"
+	networkCheckUnreachable = "unreachable"
+	networkCheckFailed      = "failed"
+	networkCheckTimedOut    = "timed out"
+)
+
+func (c *checkNetworkCommand) Run(ctx *cmd.Context) error {
+	client, err := getCheckNetworkAPIClient(c)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	results, err := client.CheckNetwork(c.applications, c.probeTimeout)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+
+	actionsToQuery := []actionQuery{}
+	for _, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.GetStderr(), "couldn't queue one action: %v\n", result.Error)
+			continue
+		}
+		actionTag, err := names.ParseActionTag(result.Action.Tag)
+		if err != nil {
+			fmt.Fprintf(ctx.GetStderr(), "got invalid action tag %v for receiver %v\n", result.Action.Tag, result.Action.Receiver)
+			continue
+		}
+		unitTag, err := names.ParseUnitTag(result.Action.Receiver)
+		if err != nil {
+			fmt.Fprintf(ctx.GetStderr(), "got invalid unit tag %v for action %v\n", result.Action.Receiver, result.Action.Tag)
+			continue
+		}
+		actionsToQuery = append(actionsToQuery, actionQuery{
+			actionTag: actionTag,
+			receiver: actionReceiver{
+				receiverType: "UnitId",
+				tag:          unitTag,
+			}})
+	}
+	if len(actionsToQuery) == 0 {
+		if len(results) > 0 {
+			return errors.New("no actions were successfully enqueued, aborting")
+		}
+		ctx.Infof("no related units to check")
+		return nil
+	}
+
+	timeout := c.timeAfter(c.timeout)
+	var checks []networkCheck
+	for len(actionsToQuery) > 0 {
+		actionResults, err := client.Actions(entities(actionsToQuery))
+		if err != nil {
+			return errors.Trace(err)
+		}
+
+		newActionsToQuery := []actionQuery{}
+		for i, result := range actionResults.Results {
+			if result.Error == nil {
+				switch result.Status {
+				case params.ActionRunning, params.ActionPending:
+					newActionsToQuery = append(newActionsToQuery, actionsToQuery[i])
+					continue
+				}
+			}
+			checks = append(checks, convertNetworkCheckResults(result, actionsToQuery[i])...)
+		}
+		actionsToQuery = newActionsToQuery
+
+		if len(actionsToQuery) > 0 {
+			var timedOut bool
+			select {
+			case <-timeout:
+				timedOut = true
+			case <-c.timeAfter(1 * time.Second):
+			}
+			if timedOut {
+				break
+			}
+		}
+	}
+	for _, query := range actionsToQuery {
+		checks = append(checks, networkCheck{
+			Unit:   query.receiver.tag.Id(),
+			Status: networkCheckTimedOut,
+		})
+	}
+
+	sort.Sort(networkChecks(checks))
+	if err := c.out.Write(ctx, checks); err != nil {
+		return err
+	}
+
+	var failed int
+	for _, check := range checks {
+		if check.Status != networkCheckReachable {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return errors.Errorf("%d of %d network checks failed", failed, len(checks))
+	}
+	return nil
+}
+
+// convertNetworkCheckResults returns the checks reported in the
+// results of a juju-check-network action. If the action failed,
+// a single failed check is returned for the unit.
+func convertNetworkCheckResults(result params.ActionResult, query actionQuery) []networkCheck {
+	unit := query.receiver.tag.Id()
+	failed := func(message string) []networkCheck {
+		return []networkCheck{{
+			Unit:    unit,
+			Status:  networkCheckFailed,
+			Message: message,
+		}}
+	}
+	switch {
+	case result.Error != nil:
+		return failed(result.Error.Error())
+	case result.Action == nil || result.Action.Tag != query.actionTag.String():
+		return failed(fmt.Sprintf("expected action tag %q", query.actionTag.String()))
+	case result.Status != params.ActionCompleted:
+		message := result.Message
+		if message == "" {
+			message = fmt.Sprintf("action %s", result.Status)
+		}
+		return failed(message)
+	}
+
+	var checks []networkCheck
+	for i := 0; ; i++ {
+		target, ok := result.Output[fmt.Sprintf("target-%d", i)].(map[string]interface{})
+		if !ok {
+			break
+		}
+		value := func(key string) string {
+			s, _ := target[key].(string)
+			return s
+		}
+		checks = append(checks, networkCheck{
+			Unit:     unit,
+			Target:   value("unit"),
+			Relation: value("relation"),
+			Address:  value("address"),
+			Status:   value("status"),
+			Message:  value("message"),
+		})
+	}
+	return checks
+}
+
+// networkChecks sorts checks by unit, then by target.
+type networkChecks []networkCheck
+
+func (c networkChecks) Len() int      { return len(c) }
+func (c networkChecks) Swap(i, j int) { c[i], c[j] = c[j], c[i] }
+func (c networkChecks) Less(i, j int) bool {
+	if c[i].Unit != c[j].Unit {
+		return c[i].Unit < c[j].Unit
+	}
+	return c[i].Target < c[j].Target
+}
+
+// formatCheckNetworkTabular writes a tabular summary of network checks.
+func formatCheckNetworkTabular(writer io.Writer, value interface{}) error {
+	checks, ok := value.([]networkCheck)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", checks, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "Unit\tTarget\tRelation\tAddress\tStatus\tMessage")
+	for _, check := range checks {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			check.Unit, check.Target, check.Relation,
+			check.Address, check.Status, check.Message,
+		)
+	}
+	return tw.Flush()
+}
+
+// CheckNetworkClient exposes the capabilities required by the
+// check-network command.
+type CheckNetworkClient interface {
+	action.APIClient
+	CheckNetwork(applications []string, timeout time.Duration) ([]params.ActionResult, error)
+}
+
+// In order to be able to easily mock out the API side for testing,
+// the API client is retrieved using a function.
+var getCheckNetworkAPIClient = func(c *checkNetworkCommand) (CheckNetworkClient, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return actionapi.NewClient(root), nil
+}
@@ -0,0 +1,187 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/testing"
+)
+
+type CheckNetworkSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *mockCheckNetworkAPI
+}
+
+var _ = gc.Suite(&CheckNetworkSuite{})
+
+func (s *CheckNetworkSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &mockCheckNetworkAPI{
+		actions: make(map[string]params.ActionResult),
+	}
+	s.PatchValue(&getCheckNetworkAPIClient, func(_ *checkNetworkCommand) (CheckNetworkClient, error) {
+		return s.api, nil
+	})
+}
+
+func (s *CheckNetworkSuite) addAction(unit string, result params.ActionResult) {
+	tag := names.NewActionTag(validUUID[:len(validUUID)-1] + fmt.Sprint(len(s.api.actions)))
+	result.Action = &params.Action{
+		Tag:      tag.String(),
+		Receiver: names.NewUnitTag(unit).String(),
+		Name:     "juju-check-network",
+	}
+	s.api.queued = append(s.api.queued, params.ActionResult{Action: result.Action})
+	s.api.actions[tag.Id()] = result
+}
+
+func (s *CheckNetworkSuite) TestInit(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, newCheckNetworkCommand(time.After), "mysql", "no/good")
+	c.Assert(err, gc.ErrorMatches, "The following applications are not valid:\n  \"no/good\" is not a valid application name")
+	_, err = cmdtesting.RunCommand(c, newCheckNetworkCommand(time.After), "--probe-timeout", "0s")
+	c.Assert(err, gc.ErrorMatches, "probe timeout must be positive")
+}
+
+func (s *CheckNetworkSuite) TestCheckNetwork(c *gc.C) {
+	s.addAction("wordpress/0", params.ActionResult{
+		Status: params.ActionCompleted,
+		Output: map[string]interface{}{
+			"target-0": map[string]interface{}{
+				"unit":     "mysql/0",
+				"relation": "wordpress:db mysql:server",
+				"address":  "10.0.0.2",
+				"status":   "reachable",
+			},
+		},
+	})
+	s.addAction("mysql/0", params.ActionResult{
+		Status: params.ActionCompleted,
+		Output: map[string]interface{}{
+			"target-0": map[string]interface{}{
+				"unit":     "wordpress/0",
+				"relation": "wordpress:db mysql:server",
+				"address":  "10.0.0.1",
+				"status":   "reachable",
+			},
+		},
+	})
+
+	ctx, err := cmdtesting.RunCommand(c, newCheckNetworkCommand(time.After), "wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.applications, jc.DeepEquals, []string{"wordpress", "mysql"})
+	c.Assert(s.api.timeout, gc.Equals, 5*time.Second)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Unit         Target       Relation                   Address   Status     Message
+mysql/0      wordpress/0  wordpress:db mysql:server  10.0.0.1  reachable  
+wordpress/0  mysql/0      wordpress:db mysql:server  10.0.0.2  reachable  
+`[1:])
+}
+
+func (s *CheckNetworkSuite) TestCheckNetworkUnreachable(c *gc.C) {
+	s.addAction("wordpress/0", params.ActionResult{
+		Status: params.ActionCompleted,
+		Output: map[string]interface{}{
+			"target-0": map[string]interface{}{
+				"unit":     "mysql/0",
+				"relation": "wordpress:db mysql:server",
+				"address":  "10.0.0.2",
+				"status":   "unreachable",
+				"message":  "exit status 1",
+			},
+		},
+	})
+	s.addAction("mysql/0", params.ActionResult{
+		Status:  params.ActionFailed,
+		Message: "no targets parameter to juju-check-network action",
+	})
+
+	ctx, err := cmdtesting.RunCommand(c, newCheckNetworkCommand(time.After), "--format", "yaml", "--probe-timeout", "2s")
+	c.Assert(err, gc.ErrorMatches, "2 of 2 network checks failed")
+	c.Assert(s.api.applications, gc.HasLen, 0)
+	c.Assert(s.api.timeout, gc.Equals, 2*time.Second)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- unit: mysql/0
+  target: ""
+  relation: ""
+  address: ""
+  status: failed
+  message: no targets parameter to juju-check-network action
+- unit: wordpress/0
+  target: mysql/0
+  relation: wordpress:db mysql:server
+  address: 10.0.0.2
+  status: unreachable
+  message: exit status 1
+`[1:])
+}
+
+func (s *CheckNetworkSuite) TestCheckNetworkTimedOut(c *gc.C) {
+	s.addAction("wordpress/0", params.ActionResult{
+		Status: params.ActionPending,
+	})
+	clock := &mockClock{}
+	ctx, err := cmdtesting.RunCommand(c, newCheckNetworkCommand(clock.After), "--format", "json")
+	c.Assert(err, gc.ErrorMatches, "1 of 1 network checks failed")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals,
+		`[{"unit":"wordpress/0","target":"","relation":"","address":"","status":"timed out"}]`+"\n")
+}
+
+func (s *CheckNetworkSuite) TestCheckNetworkNoRelations(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, newCheckNetworkCommand(time.After))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "no related units to check\n")
+}
+
+func (s *CheckNetworkSuite) TestCheckNetworkBlocked(c *gc.C) {
+	s.api.block = true
+	_, err := cmdtesting.RunCommand(c, newCheckNetworkCommand(time.After))
+	testing.AssertOperationWasBlocked(c, err, ".*To enable changes.*")
+}
+
+type mockCheckNetworkAPI struct {
+	action.APIClient
+	applications []string
+	timeout      time.Duration
+	queued       []params.ActionResult
+	actions      map[string]params.ActionResult
+	block        bool
+}
+
+var _ CheckNetworkClient = (*mockCheckNetworkAPI)(nil)
+
+func (*mockCheckNetworkAPI) Close() error {
+	return nil
+}
+
+func (m *mockCheckNetworkAPI) CheckNetwork(applications []string, timeout time.Duration) ([]params.ActionResult, error) {
+	if m.block {
+		return nil, common.OperationBlockedError("the operation has been blocked")
+	}
+	m.applications = applications
+	m.timeout = timeout
+	return m.queued, nil
+}
+
+func (m *mockCheckNetworkAPI) Actions(actionTags params.Entities) (params.ActionResults, error) {
+	results := params.ActionResults{Results: make([]params.ActionResult, len(actionTags.Entities))}
+	for i, entity := range actionTags.Entities {
+		tag, err := names.ParseActionTag(entity.Tag)
+		if err != nil {
+			return params.ActionResults{}, err
+		}
+		results.Results[i] = m.actions[tag.Id()]
+	}
+	return results, nil
+}
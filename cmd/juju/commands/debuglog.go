@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"regexp"
 	"time"
 
 	"github.com/juju/ansiterm"
@@ -15,6 +16,7 @@ import (
 	"github.com/juju/gnuflag"
 	"github.com/juju/loggo"
 	"github.com/juju/loggo/loggocolor"
+	"github.com/juju/utils/clock"
 	"github.com/mattn/go-isatty"
 
 	"github.com/juju/juju/api/common"
@@ -49,13 +51,33 @@ The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
+The '--include-label' and '--exclude-label' options filter by the labels
+attached to log messages. A message matches '--include-label' if it carries
+any of the given labels; messages without labels are never excluded by
+'--exclude-label'. Output from charm hooks and juju-log is labelled "charm".
+
+The '--grep' option only shows messages that match the given regular
+expression.
+
+The '--since' and '--until' options limit the messages shown to those logged
+in a time window. Each takes either an RFC3339 timestamp, such as
+2017-03-01T14:30:00Z, or a duration, such as 90m, which is taken to mean
+that long ago. When '--until' is given, debug-log stops after returning the
+existing log messages unless '--tail' is also given.
+
+All filtering is done by the controller, so only matching messages are
+sent to the client.
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
 * All --include-module options are logically ORed together.
 * All --exclude-module options are logically ORed together.
-* The combined --include, --exclude, --include-module and --exclude-module
-  selections are logically ANDed to form the complete filter.
+* All --include-label options are logically ORed together.
+* All --exclude-label options are logically ORed together.
+* The combined --include, --exclude, --include-module, --exclude-module,
+  --include-label, --exclude-label, --grep, --since and --until selections
+  are logically ANDed to form the complete filter.
 
 Examples:
 
@@ -94,6 +116,24 @@ new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
 
+Show all ERROR messages mentioning a refused connection that were logged in
+the last hour:
+
+    juju debug-log --replay --level ERROR --since 1h --grep 'connection refused'
+
+Show the messages logged by unit mysql/0 during a ten minute window:
+
+    juju debug-log --replay --include unit-mysql-0 \
+        --since 2017-03-01T14:30:00Z --until 2017-03-01T14:40:00Z
+
+Show only the output of charms:
+
+    juju debug-log --replay --include-label charm
+
+Show everything except the output of charms:
+
+    juju debug-log --exclude-label charm
+
 See also: 
     status
     ssh`
@@ -111,13 +151,15 @@ func newDebugLogCommand() cmd.Command {
 }
 
 func newDebugLogCommandTZ(tz *time.Location) cmd.Command {
-	return modelcmd.Wrap(&debugLogCommand{tz: tz})
+	return modelcmd.Wrap(&debugLogCommand{tz: tz, clock: clock.WallClock})
 }
 
 type debugLogCommand struct {
 	modelcmd.ModelCommandBase
 
 	level  string
+	since  string
+	until  string
 	params common.DebugLogParams
 
 	utc      bool
@@ -131,6 +173,7 @@ type debugLogCommand struct {
 
 	format string
 	tz     *time.Location
+	clock  clock.Clock
 }
 
 func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
@@ -141,6 +184,8 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeEntity), "exclude", "Do not show log messages for these entities")
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeModule), "include-module", "Only show log messages for these logging modules")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeModule), "exclude-module", "Do not show log messages for these logging modules")
+	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeLabel), "include-label", "Only show log messages with these labels")
+	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeLabel), "exclude-label", "Do not show log messages with these labels")
 
 	f.StringVar(&c.level, "l", "", "Log level to show, one of [TRACE, DEBUG, INFO, WARNING, ERROR]")
 	f.StringVar(&c.level, "level", "", "")
@@ -149,6 +194,9 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.UintVar(&c.params.Backlog, "lines", defaultLineCount, "")
 	f.UintVar(&c.params.Limit, "limit", 0, "Exit once this many of the most recent (possibly filtered) lines are shown")
 	f.BoolVar(&c.params.Replay, "replay", false, "Show the entire (possibly filtered) log and continue to append")
+	f.StringVar(&c.params.Message, "grep", "", "Only show log messages matching this regular expression")
+	f.StringVar(&c.since, "since", "", "Only show log messages logged at or after this time (RFC3339 time or duration ago)")
+	f.StringVar(&c.until, "until", "", "Only show log messages logged at or before this time (RFC3339 time or duration ago)")
 
 	f.BoolVar(&c.notail, "no-tail", false, "Stop after returning existing log messages")
 	f.BoolVar(&c.tail, "tail", false, "Wait for new logs")
@@ -172,6 +220,28 @@ func (c *debugLogCommand) Init(args []string) error {
 	if c.tail && c.notail {
 		return errors.NotValidf("setting --tail and --no-tail")
 	}
+	if c.params.Message != "" {
+		if _, err := regexp.Compile(c.params.Message); err != nil {
+			return errors.Errorf("--grep value %q is not a valid regular expression", c.params.Message)
+		}
+	}
+	if c.since != "" {
+		since, err := parseLogTime(c.since, c.clock.Now())
+		if err != nil {
+			return errors.Annotate(err, "--since")
+		}
+		c.params.StartTime = since
+	}
+	if c.until != "" {
+		until, err := parseLogTime(c.until, c.clock.Now())
+		if err != nil {
+			return errors.Annotate(err, "--until")
+		}
+		if until.Before(c.params.StartTime) {
+			return errors.New("--until must not be before --since")
+		}
+		c.params.EndTime = until
+	}
 	if c.utc {
 		c.tz = time.UTC
 	}
@@ -186,6 +256,22 @@ func (c *debugLogCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
 
+// parseLogTime parses value as either an RFC3339 time or a duration
+// before now.
+func parseLogTime(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, errors.Errorf("duration %q must not be negative", value)
+		}
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, errors.Errorf("%q is not a valid RFC3339 time or duration", value)
+	}
+	return t, nil
+}
+
 type DebugLogAPI interface {
 	WatchDebugLog(params common.DebugLogParams) (<-chan common.LogMessage, error)
 	Close() error
@@ -207,7 +293,9 @@ func isTerminal(out io.Writer) bool {
 func (c *debugLogCommand) Run(ctx *cmd.Context) (err error) {
 	if c.tail {
 		c.params.NoTail = false
-	} else if c.notail {
+	} else if c.notail || !c.params.EndTime.IsZero() {
+		// Logs written after the end time are filtered out, so
+		// there is nothing worth waiting for.
 		c.params.NoTail = true
 	} else {
 		// Set the default tail option to true if the caller is
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/loggo"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -23,6 +24,7 @@ type DebugLogSuite struct {
 var _ = gc.Suite(&DebugLogSuite{})
 
 func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
+	now := time.Date(2017, 3, 1, 15, 0, 0, 0, time.UTC)
 	for i, test := range []struct {
 		args     []string
 		expected common.DebugLogParams
@@ -72,6 +74,18 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				ExcludeModule: []string{"juju.foo", "unit"},
 				Backlog:       10,
 			},
+		}, {
+			args: []string{"--include-label", "http", "--include-label", "charmstore"},
+			expected: common.DebugLogParams{
+				IncludeLabel: []string{"http", "charmstore"},
+				Backlog:      10,
+			},
+		}, {
+			args: []string{"--exclude-label", "http", "--exclude-label", "charmstore"},
+			expected: common.DebugLogParams{
+				ExcludeLabel: []string{"http", "charmstore"},
+				Backlog:      10,
+			},
 		}, {
 			args: []string{"--replay"},
 			expected: common.DebugLogParams{
@@ -87,10 +101,35 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				Backlog: 10,
 				Limit:   100,
 			},
+		}, {
+			args: []string{"--grep", "connection (refused|reset)"},
+			expected: common.DebugLogParams{
+				Backlog: 10,
+				Message: "connection (refused|reset)",
+			},
+		}, {
+			args:     []string{"--grep", "connection (refused"},
+			errMatch: `--grep value "connection \(refused" is not a valid regular expression`,
+		}, {
+			args: []string{"--since", "1h", "--until", "2017-03-01T14:30:00Z"},
+			expected: common.DebugLogParams{
+				Backlog:   10,
+				StartTime: now.Add(-time.Hour),
+				EndTime:   time.Date(2017, 3, 1, 14, 30, 0, 0, time.UTC),
+			},
+		}, {
+			args:     []string{"--since", "yesterday"},
+			errMatch: `--since: "yesterday" is not a valid RFC3339 time or duration`,
+		}, {
+			args:     []string{"--until", "-5m"},
+			errMatch: `--until: duration "-5m" must not be negative`,
+		}, {
+			args:     []string{"--since", "10m", "--until", "1h"},
+			errMatch: `--until must not be before --since`,
 		},
 	} {
 		c.Logf("test %v", i)
-		command := &debugLogCommand{}
+		command := &debugLogCommand{clock: jujutesting.NewClock(now)}
 		err := cmdtesting.InitCommand(modelcmd.Wrap(command), test.args)
 		if test.errMatch == "" {
 			c.Check(err, jc.ErrorIsNil)
@@ -124,6 +163,26 @@ func (s *DebugLogSuite) TestParamsPassed(c *gc.C) {
 	})
 }
 
+func (s *DebugLogSuite) TestUntilImpliesNoTail(c *gc.C) {
+	fake := &fakeDebugLogAPI{}
+	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
+		return fake, nil
+	})
+	_, err := cmdtesting.RunCommand(c, newDebugLogCommand(),
+		"--grep=refused",
+		"--since=2017-03-01T14:00:00Z",
+		"--until=2017-03-01T15:00:00Z",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fake.params, gc.DeepEquals, common.DebugLogParams{
+		Backlog:   10,
+		Message:   "refused",
+		StartTime: time.Date(2017, 3, 1, 14, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2017, 3, 1, 15, 0, 0, 0, time.UTC),
+		NoTail:    true,
+	})
+}
+
 func (s *DebugLogSuite) TestLogOutput(c *gc.C) {
 	// test timezone is 6 hours east of UTC
 	tz := time.FixedZone("test", 6*60*60)
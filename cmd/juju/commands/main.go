@@ -290,6 +290,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Error resolution and debugging commands.
 	r.Register(newDefaultRunCommand())
+	r.Register(newDefaultCheckNetworkCommand())
 	r.Register(newSCPCommand(nil))
 	r.Register(newSSHCommand(nil))
 	r.Register(newResolvedCommand())
@@ -352,6 +353,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewGrantCommand())
 	r.Register(model.NewRevokeCommand())
 	r.Register(model.NewShowCommand())
+	r.Register(model.NewExportBundleCommand())
+	r.Register(model.NewWaitCommand())
 
 	r.Register(newMigrateCommand())
 	if featureflag.Enabled(feature.DeveloperMode) {
@@ -363,6 +366,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(action.NewStatusCommand())
 	r.Register(action.NewRunCommand())
 	r.Register(action.NewShowOutputCommand())
+	r.Register(action.NewShowOperationCommand())
 	r.Register(action.NewListCommand())
 	r.Register(action.NewCancelCommand())
 
@@ -373,7 +377,11 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewAddUnitCommand())
 	r.Register(application.NewConfigCommand())
 	r.Register(application.NewDeployCommand())
+	r.Register(application.NewDiffBundleCommand())
+	r.Register(application.NewEgressCommand())
 	r.Register(application.NewExposeCommand())
+	r.Register(application.NewSetIngressCommand())
+	r.Register(application.NewSetActionConcurrencyCommand())
 	r.Register(application.NewUnexposeCommand())
 	r.Register(application.NewServiceGetConstraintsCommand())
 	r.Register(application.NewServiceSetConstraintsCommand())
@@ -390,9 +398,13 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(storage.NewPoolListCommand())
 	r.Register(storage.NewShowCommand())
 	r.Register(storage.NewRemoveStorageCommandWithAPI())
+	r.Register(storage.NewSnapshotStorageCommandWithAPI())
+	r.Register(storage.NewGrowStorageCommandWithAPI())
+	r.Register(storage.NewImportVolumeCommandWithAPI())
+	r.Register(storage.NewImportFilesystemCommandWithAPI())
+	r.Register(storage.NewAttachStorageCommandWithAPI())
 	if featureflag.Enabled(feature.PersistentStorage) {
 		r.Register(storage.NewDetachStorageCommandWithAPI())
-		r.Register(storage.NewAttachStorageCommandWithAPI())
 	}
 
 	// Manage spaces
@@ -402,6 +402,7 @@ var commandNames = []string{
 	"agree",
 	"agreements",
 	"attach",
+	"attach-storage",
 	"autoload-credentials",
 	"backups",
 	"bootstrap",
@@ -410,6 +411,7 @@ var commandNames = []string{
 	"cancel-action",
 	"change-user-password",
 	"charm",
+	"check-network",
 	"clouds",
 	"collect-metrics",
 	"config",
@@ -424,22 +426,28 @@ var commandNames = []string{
 	"deploy",
 	"destroy-controller",
 	"destroy-model",
+	"diff-bundle",
 	"disable-command",
 	"disable-user",
 	"disabled-commands",
 	"download-backup",
+	"egress",
 	"enable-command",
 	"enable-destroy-controller",
 	"enable-ha",
 	"enable-user",
+	"export-bundle",
 	"expose",
 	"get-constraints",
 	"get-model-constraints",
 	"grant",
+	"grow-storage",
 	"gui",
 	"help",
 	"help-tool",
+	"import-filesystem",
 	"import-ssh-key",
+	"import-volume",
 	"kill-controller",
 	"list-actions",
 	"list-agreements",
@@ -494,9 +502,11 @@ var commandNames = []string{
 	"run",
 	"run-action",
 	"scp",
+	"set-action-concurrency",
 	"set-constraints",
 	"set-default-credential",
 	"set-default-region",
+	"set-ingress",
 	"set-meter-status",
 	"set-model-constraints",
 	"set-plan",
@@ -508,12 +518,14 @@ var commandNames = []string{
 	"show-controller",
 	"show-machine",
 	"show-model",
+	"show-operation",
 	"show-status",
 	"show-status-log",
 	"show-storage",
 	"show-user",
 	"show-wallet",
 	"sla",
+	"snapshot-storage",
 	"spaces",
 	"ssh",
 	"ssh-keys",
@@ -533,6 +545,7 @@ var commandNames = []string{
 	"upload-backup",
 	"users",
 	"version",
+	"wait",
 	"wallets",
 	"whoami",
 }
@@ -545,7 +558,6 @@ var devFeatures = []string{
 
 // These are the commands that are behind the `devFeatures`.
 var commandNamesBehindFlags = set.NewStrings(
-	"attach-storage",
 	"consume",
 	"detach-storage",
 	"find-endpoints",
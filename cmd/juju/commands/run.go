@@ -5,7 +5,10 @@ package commands
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -33,17 +36,30 @@ func newRunCommand(timeAfter func(time.Duration) <-chan time.Time) cmd.Command {
 	})
 }
 
+// The exit codes of a run with --summary, when the commands failed on
+// all of the targets, or on only some of them.
+const (
+	runFailedCode          = 1
+	runPartiallyFailedCode = 2
+)
+
 // runCommand is responsible for running arbitrary commands on remote machines.
 type runCommand struct {
 	modelcmd.ModelCommandBase
-	out       cmd.Output
-	all       bool
-	timeout   time.Duration
-	machines  []string
-	services  []string
-	units     []string
-	commands  string
-	timeAfter func(time.Duration) <-chan time.Time
+	out        cmd.Output
+	all        bool
+	timeout    time.Duration
+	machines   []string
+	services   []string
+	units      []string
+	leaderOnly bool
+	where      []string
+	status     string
+	series     string
+	summary    bool
+	stream     bool
+	commands   string
+	timeAfter  func(time.Duration) <-chan time.Time
 }
 
 const runDoc = `
@@ -73,6 +89,26 @@ the unit.
 in the model.  If you specify --all you cannot provide additional
 targets.
 
+The targets may be narrowed down with selectors. --leader-only runs the
+command only on the units that are the leaders of their applications, and
+so cannot be used with --all or --machine. --where takes comma separated
+key=value pairs, all of which a target must match:
+  status=<status>  units with the given workload status, or machines
+                   with the given status
+  series=<series>  targets on machines of the given series
+For example, to run a command on the blocked units of mysql:
+  juju run --application mysql --where status=blocked -- hostname
+
+--summary writes the exit code of the command on each target to stderr
+once all of the targets have completed, and exits with code 0 if the
+command succeeded on all of them, 1 if it failed on all of them, and 2 if
+it failed on only some of them. A target that the command could not be
+run on, or that timed out, counts as failed.
+
+--stream writes the result of each target to stdout as a JSON object on
+its own line as soon as the target completes, rather than writing all of
+the results once they have all completed.
+
 Since juju run creates actions, you can query for the status of commands
 started with juju run by calling "juju show-action-status --name juju-run".
 
@@ -105,6 +141,10 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewStringsValue(nil, &c.machines), "machine", "One or more machine ids")
 	f.Var(cmd.NewStringsValue(nil, &c.services), "application", "One or more application names")
 	f.Var(cmd.NewStringsValue(nil, &c.units), "unit", "One or more unit ids")
+	f.BoolVar(&c.leaderOnly, "leader-only", false, "Run the commands only on the leaders of the applications")
+	f.Var(cmd.NewStringsValue(nil, &c.where), "where", "One or more key=value target selectors, by status or series")
+	f.BoolVar(&c.summary, "summary", false, "Summarise the exit code of each target, and exit non-zero if any failed")
+	f.BoolVar(&c.stream, "stream", false, "Write each target's result as a line of JSON as soon as it completes")
 }
 
 func (c *runCommand) Init(args []string) error {
@@ -159,9 +199,36 @@ func (c *runCommand) Init(args []string) error {
 			strings.Join(nameErrors, "\n"))
 	}
 
+	if c.leaderOnly && (c.all || len(c.machines) != 0) {
+		return errors.Errorf("--leader-only can only be used with --application or --unit")
+	}
+	for _, selector := range c.where {
+		parts := strings.SplitN(selector, "=", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return errors.Errorf("invalid --where selector %q, expected <key>=<value>", selector)
+		}
+		switch parts[0] {
+		case "status":
+			c.status = parts[1]
+		case "series":
+			c.series = parts[1]
+		default:
+			return errors.Errorf("unknown --where key %q, expected \"status\" or \"series\"", parts[0])
+		}
+	}
+	if c.stream && c.out.Name() == "yaml" {
+		return errors.Errorf("--stream writes JSON, it cannot be used with --format=yaml")
+	}
+
 	return nil
 }
 
+// selective reports whether the targets of the command are narrowed
+// down by selectors.
+func (c *runCommand) selective() bool {
+	return c.leaderOnly || c.status != "" || c.series != ""
+}
+
 // ConvertActionResults takes the results from the api and creates a map
 // suitable for format converstion to YAML or JSON.
 func ConvertActionResults(result params.ActionResult, query actionQuery) map[string]interface{} {
@@ -218,7 +285,7 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	defer client.Close()
 
 	var runResults []params.ActionResult
-	if c.all {
+	if c.all && !c.selective() {
 		runResults, err = client.RunOnAllMachines(c.commands, c.timeout)
 	} else {
 		params := params.RunParams{
@@ -227,18 +294,34 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 			Machines:     c.machines,
 			Applications: c.services,
 			Units:        c.units,
+			LeaderOnly:   c.leaderOnly,
+			Status:       c.status,
+			Series:       c.series,
+		}
+		if c.all {
+			runResults, err = client.RunOnSelectedMachines(params)
+		} else {
+			runResults, err = client.Run(params)
 		}
-		runResults, err = client.Run(params)
 	}
 
 	if err != nil {
 		return block.ProcessBlockedError(err, block.BlockChange)
 	}
+	if len(runResults) == 0 && c.selective() {
+		return errors.New("no targets match the selectors")
+	}
 
+	var summary runSummary
 	actionsToQuery := []actionQuery{}
 	for _, result := range runResults {
 		if result.Error != nil {
 			fmt.Fprintf(ctx.GetStderr(), "couldn't queue one action: %v", result.Error)
+			if result.Action != nil {
+				if receiverTag, err := names.ActionReceiverFromTag(result.Action.Receiver); err == nil {
+					summary.addFailed(receiverTag)
+				}
+			}
 			continue
 		}
 		actionTag, err := names.ParseActionTag(result.Action.Tag)
@@ -291,7 +374,14 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 				}
 			}
 
-			values = append(values, ConvertActionResults(result, actionsToQuery[i]))
+			value := ConvertActionResults(result, actionsToQuery[i])
+			if c.stream {
+				if err := json.NewEncoder(ctx.Stdout).Encode(value); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			values = append(values, value)
+			summary.add(actionsToQuery[i].receiver.tag, result, value)
 		}
 		actionsToQuery = newActionsToQuery
 
@@ -313,8 +403,8 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	}
 
 	// If we are just dealing with one result, AND we are using the default
-	// format, then pretend we were running it locally.
-	if len(actionsToQuery) == 0 && len(values) == 1 && c.out.Name() == "default" {
+	// format without a summary, then pretend we were running it locally.
+	if !c.stream && !c.summary && len(actionsToQuery) == 0 && len(values) == 1 && c.out.Name() == "default" {
 		result, ok := values[0].(map[string]interface{})
 		if !ok {
 			return errors.New("couldn't read action output")
@@ -335,12 +425,20 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return nil
 	}
 
-	if len(values) > 0 {
+	if len(values) > 0 && !c.stream {
 		if err := c.out.Write(ctx, values); err != nil {
 			return err
 		}
 	}
 
+	if c.summary {
+		for _, actionToQuery := range actionsToQuery {
+			summary.addTimedOut(actionToQuery.receiver.tag)
+		}
+		summary.write(ctx.Stderr)
+		return summary.exitError()
+	}
+
 	if n := len(actionsToQuery); n > 0 {
 		// There are action results remaining, so return an error.
 		suffix := ""
@@ -359,6 +457,76 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// runSummary records the exit code of the commands on each target, or
+// whether they could not be run or timed out.
+type runSummary struct {
+	codes    map[int][]string
+	failed   []string
+	timedOut []string
+}
+
+// add records the outcome of the commands on the receiver, given the
+// action result and its conversion by ConvertActionResults.
+func (s *runSummary) add(receiver names.Tag, result params.ActionResult, value map[string]interface{}) {
+	if _, ok := value["Error"]; ok || result.Status == params.ActionFailed {
+		s.addFailed(receiver)
+		return
+	}
+	code, _ := value["ReturnCode"].(int)
+	if s.codes == nil {
+		s.codes = make(map[int][]string)
+	}
+	s.codes[code] = append(s.codes[code], names.ReadableString(receiver))
+}
+
+// addFailed records that the commands could not be run on the receiver.
+func (s *runSummary) addFailed(receiver names.Tag) {
+	s.failed = append(s.failed, names.ReadableString(receiver))
+}
+
+// addTimedOut records that the receiver did not complete in time.
+func (s *runSummary) addTimedOut(receiver names.Tag) {
+	s.timedOut = append(s.timedOut, names.ReadableString(receiver))
+}
+
+// write writes the targets grouped by exit code, followed by those
+// that failed or timed out.
+func (s *runSummary) write(w io.Writer) {
+	codes := make([]int, 0, len(s.codes))
+	for code := range s.codes {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	for _, code := range codes {
+		fmt.Fprintf(w, "exit code %d: %s\n", code, strings.Join(s.codes[code], ", "))
+	}
+	if len(s.failed) > 0 {
+		fmt.Fprintf(w, "failed: %s\n", strings.Join(s.failed, ", "))
+	}
+	if len(s.timedOut) > 0 {
+		fmt.Fprintf(w, "timed out: %s\n", strings.Join(s.timedOut, ", "))
+	}
+}
+
+// exitError returns nil if the commands succeeded on all of the
+// targets, or an error carrying the exit code of a failed or partially
+// failed run.
+func (s *runSummary) exitError() error {
+	succeeded := len(s.codes[0])
+	total := len(s.failed) + len(s.timedOut)
+	for _, targets := range s.codes {
+		total += len(targets)
+	}
+	switch {
+	case succeeded == total:
+		return nil
+	case succeeded == 0:
+		return cmd.NewRcPassthroughError(runFailedCode)
+	default:
+		return cmd.NewRcPassthroughError(runPartiallyFailedCode)
+	}
+}
+
 type actionReceiver struct {
 	receiverType string
 	tag          names.Tag
@@ -373,6 +541,7 @@ type actionQuery struct {
 type RunClient interface {
 	action.APIClient
 	RunOnAllMachines(commands string, timeout time.Duration) ([]params.ActionResult, error)
+	RunOnSelectedMachines(params.RunParams) ([]params.ActionResult, error)
 	Run(params.RunParams) ([]params.ActionResult, error)
 }
 
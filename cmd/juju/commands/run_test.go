@@ -133,6 +133,56 @@ func (*RunSuite) TestTargetArgParsing(c *gc.C) {
 	}
 }
 
+func (*RunSuite) TestSelectorArgParsing(c *gc.C) {
+	for i, test := range []struct {
+		message    string
+		args       []string
+		leaderOnly bool
+		status     string
+		series     string
+		errMatch   string
+	}{{
+		message:    "leader only",
+		args:       []string{"--application=mysql", "--leader-only", "hostname"},
+		leaderOnly: true,
+	}, {
+		message:  "leader only with all",
+		args:     []string{"--all", "--leader-only", "hostname"},
+		errMatch: "--leader-only can only be used with --application or --unit",
+	}, {
+		message:  "leader only with machines",
+		args:     []string{"--machine=0", "--unit=mysql/0", "--leader-only", "hostname"},
+		errMatch: "--leader-only can only be used with --application or --unit",
+	}, {
+		message: "status and series",
+		args:    []string{"--all", "--where", "status=blocked,series=xenial", "hostname"},
+		status:  "blocked",
+		series:  "xenial",
+	}, {
+		message:  "missing value",
+		args:     []string{"--all", "--where", "status=", "hostname"},
+		errMatch: `invalid --where selector "status=", expected <key>=<value>`,
+	}, {
+		message:  "unknown key",
+		args:     []string{"--all", "--where", "life=dying", "hostname"},
+		errMatch: `unknown --where key "life", expected "status" or "series"`,
+	}, {
+		message:  "stream with yaml",
+		args:     []string{"--all", "--stream", "--format=yaml", "hostname"},
+		errMatch: "--stream writes JSON, it cannot be used with --format=yaml",
+	}} {
+		c.Log(fmt.Sprintf("%v: %s", i, test.message))
+		cmd := &runCommand{}
+		runCmd := modelcmd.Wrap(cmd)
+		cmdtesting.TestInit(c, runCmd, test.args, test.errMatch)
+		if test.errMatch == "" {
+			c.Check(cmd.leaderOnly, gc.Equals, test.leaderOnly)
+			c.Check(cmd.status, gc.Equals, test.status)
+			c.Check(cmd.series, gc.Equals, test.series)
+		}
+	}
+}
+
 func (*RunSuite) TestTimeoutArgParsing(c *gc.C) {
 	for i, test := range []struct {
 		message  string
@@ -385,6 +435,116 @@ func (s *RunSuite) TestTimeout(c *gc.C) {
 	})
 }
 
+func (s *RunSuite) TestRunSelectors(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.setMachinesAlive("0", "1")
+	mock.setResponse("1", mockResponse{stdout: "megatron\n", machineTag: "machine-1"})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["1"]: mock.runResponses["1"],
+	}
+
+	_, err := cmdtesting.RunCommand(c, newTestRunCommand(&mockClock{}),
+		"--format=json", "--all", "--where", "series=xenial", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mock.runParams, jc.DeepEquals, params.RunParams{
+		Commands: "hostname",
+		Timeout:  5 * time.Minute,
+		Series:   "xenial",
+	})
+
+	mock.runResponses = nil
+	_, err = cmdtesting.RunCommand(c, newTestRunCommand(&mockClock{}),
+		"--application=mysql", "--leader-only", "--where", "status=blocked", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, "no targets match the selectors")
+	c.Check(mock.runParams, jc.DeepEquals, params.RunParams{
+		Commands:     "hostname",
+		Timeout:      5 * time.Minute,
+		Applications: []string{"mysql"},
+		LeaderOnly:   true,
+		Status:       "blocked",
+	})
+}
+
+func (s *RunSuite) TestSummary(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.setMachinesAlive("0", "1", "2")
+	mock.setResponse("0", mockResponse{stdout: "megatron\n", machineTag: "machine-0"})
+	mock.setResponse("1", mockResponse{code: "3", machineTag: "machine-1"})
+	mock.setResponse("2", mockResponse{machineTag: "machine-2"})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]: mock.runResponses["0"],
+		mock.receiverIdMap["1"]: mock.runResponses["1"],
+	}
+
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(&mockClock{}),
+		"--format=json", "--all", "--summary", "hostname",
+	)
+	c.Assert(err, gc.FitsTypeOf, &cmd.RcPassthroughError{})
+	c.Check(err.(*cmd.RcPassthroughError).Code, gc.Equals, 2)
+	c.Check(cmdtesting.Stderr(context), gc.Equals, ""+
+		"exit code 0: machine 0\n"+
+		"exit code 3: machine 1\n"+
+		"failed: machine 2\n",
+	)
+
+	delete(mock.machines, "1")
+	delete(mock.machines, "2")
+	context, err = cmdtesting.RunCommand(c, newTestRunCommand(&mockClock{}),
+		"--format=json", "--all", "--summary", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(context), gc.Equals, "exit code 0: machine 0\n")
+}
+
+func (s *RunSuite) TestSummarySingleResponse(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.setMachinesAlive("0")
+	mock.setResponse("0", mockResponse{stdout: "stdout\n", code: "42", machineTag: "machine-0"})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]: mock.runResponses["0"],
+	}
+
+	query := makeActionQuery(mock.receiverIdMap["0"], "MachineId", names.NewMachineTag("0"))
+	var buf bytes.Buffer
+	err := cmd.FormatYaml(&buf, []interface{}{
+		ConvertActionResults(mock.runResponses["0"], query),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(&mockClock{}), "--all", "--summary", "hostname")
+	c.Assert(err, gc.FitsTypeOf, &cmd.RcPassthroughError{})
+	c.Check(err.(*cmd.RcPassthroughError).Code, gc.Equals, 1)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, buf.String())
+	c.Check(cmdtesting.Stderr(context), gc.Equals, "exit code 42: machine 0\n")
+}
+
+func (s *RunSuite) TestStream(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.setMachinesAlive("0", "1")
+	mock.setResponse("0", mockResponse{stdout: "megatron\n", machineTag: "machine-0"})
+	mock.setResponse("1", mockResponse{stdout: "bumblebee\n", machineTag: "machine-1", status: params.ActionRunning})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]: mock.runResponses["0"],
+		mock.receiverIdMap["1"]: mock.runResponses["1"],
+	}
+
+	var clock mockClock
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(&clock),
+		"--all", "--stream", "--summary", "hostname",
+	)
+	c.Assert(err, gc.FitsTypeOf, &cmd.RcPassthroughError{})
+	c.Check(err.(*cmd.RcPassthroughError).Code, gc.Equals, 2)
+	c.Check(cmdtesting.Stdout(context), gc.Equals,
+		`{"MachineId":"0","Stdout":"megatron\n"}`+"\n",
+	)
+	c.Check(cmdtesting.Stderr(context), gc.Equals, ""+
+		"exit code 0: machine 0\n"+
+		"timed out: machine 1\n",
+	)
+}
+
 type mockClock struct {
 	gitjujutesting.Stub
 	clock.Clock
@@ -509,6 +669,7 @@ type mockRunAPI struct {
 	runResponses    map[string]params.ActionResult
 	actionResponses map[string]params.ActionResult
 	receiverIdMap   map[string]string
+	runParams       params.RunParams
 	block           bool
 }
 
@@ -615,7 +776,13 @@ func (m *mockRunAPI) RunOnAllMachines(commands string, timeout time.Duration) ([
 	return result, nil
 }
 
+func (m *mockRunAPI) RunOnSelectedMachines(runParams params.RunParams) ([]params.ActionResult, error) {
+	m.runParams = runParams
+	return m.RunOnAllMachines(runParams.Commands, runParams.Timeout)
+}
+
 func (m *mockRunAPI) Run(runParams params.RunParams) ([]params.ActionResult, error) {
+	m.runParams = runParams
 	var result []params.ActionResult
 
 	if m.block {
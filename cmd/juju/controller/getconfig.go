@@ -11,6 +11,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/utils/keyvalues"
 	"github.com/juju/utils/set"
 
 	apicontroller "github.com/juju/juju/api/controller"
@@ -24,23 +25,38 @@ func NewGetConfigCommand() cmd.Command {
 }
 
 // getConfigCommand is able to output either the entire environment or
-// the requested value in a format of the user's choosing.
+// the requested value in a format of the user's choosing. It can also
+// change the values of the attributes that may be updated at runtime.
 type getConfigCommand struct {
 	modelcmd.ControllerCommandBase
-	api controllerAPI
-	key string
-	out cmd.Output
+	api    controllerAPI
+	key    string
+	values map[string]interface{}
+	out    cmd.Output
 }
 
 const getControllerHelpDoc = `
 By default, all configuration (keys and values) for the controller are
 displayed if a key is not specified.
 
+Supplying one or more key=value pairs changes those attributes. Only
+the following attributes may be changed after bootstrap:
+
+    auditing-enabled
+    backup-retention-age
+    backup-retention-count
+    backup-schedule
+    max-logs-age
+    max-logs-size
+    mongo-memory-profile
+
 Examples:
 
     juju controller-config
     juju controller-config api-port
     juju controller-config -c mycontroller
+    juju controller-config max-logs-age=24h max-logs-size=2G
+    juju controller-config backup-schedule="0 3 * * *" backup-retention-count=7
 
 See also:
     controllers
@@ -49,8 +65,8 @@ See also:
 func (c *getConfigCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "controller-config",
-		Args:    "[<attribute key>]",
-		Purpose: "Displays configuration settings for a controller.",
+		Args:    "[<attribute key>[=<value>] ...]",
+		Purpose: "Displays or sets configuration settings for a controller.",
 		Doc:     strings.TrimSpace(getControllerHelpDoc),
 	}
 }
@@ -65,13 +81,32 @@ func (c *getConfigCommand) SetFlags(f *gnuflag.FlagSet) {
 }
 
 func (c *getConfigCommand) Init(args []string) (err error) {
+	if len(args) > 0 && strings.Contains(args[0], "=") {
+		return c.parseSetKeys(args)
+	}
 	c.key, err = cmd.ZeroOrOneArgs(args)
 	return
 }
 
+func (c *getConfigCommand) parseSetKeys(args []string) error {
+	options, err := keyvalues.Parse(args, true)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.values = make(map[string]interface{}, len(options))
+	for key, value := range options {
+		if !controller.AllowedUpdateConfigAttributes.Contains(key) {
+			return errors.Errorf("invalid or read-only controller config attribute %q", key)
+		}
+		c.values[key] = value
+	}
+	return nil
+}
+
 type controllerAPI interface {
 	Close() error
 	ControllerConfig() (controller.Config, error)
+	ConfigSet(map[string]interface{}) error
 }
 
 func (c *getConfigCommand) getAPI() (controllerAPI, error) {
@@ -96,6 +131,10 @@ func (c *getConfigCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if len(c.values) > 0 {
+		return errors.Trace(client.ConfigSet(c.values))
+	}
+
 	attrs, err := client.ControllerConfig()
 	if err != nil {
 		return err
@@ -32,6 +32,15 @@ func (s *GetConfigSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
 	return cmdtesting.RunCommand(c, command, args...)
 }
 
+func (s *GetConfigSuite) TestInitSetValues(c *gc.C) {
+	err := cmdtesting.InitCommand(controller.NewGetConfigCommandForTest(&fakeControllerAPI{}, s.store), []string{"max-logs-age=12h", "max-logs-size=1G"})
+	c.Check(err, jc.ErrorIsNil)
+	err = cmdtesting.InitCommand(controller.NewGetConfigCommandForTest(&fakeControllerAPI{}, s.store), []string{"max-logs-age=12h", "two"})
+	c.Check(err, gc.ErrorMatches, `expected "key=value", got "two"`)
+	err = cmdtesting.InitCommand(controller.NewGetConfigCommandForTest(&fakeControllerAPI{}, s.store), []string{"api-port=1234"})
+	c.Check(err, gc.ErrorMatches, `invalid or read-only controller config attribute "api-port"`)
+}
+
 func (s *GetConfigSuite) TestInit(c *gc.C) {
 	// zero or one args is fine.
 	err := cmdtesting.InitCommand(controller.NewGetConfigCommandForTest(&fakeControllerAPI{}, s.store), nil)
@@ -83,6 +92,24 @@ func (s *GetConfigSuite) TestAllValuesJSON(c *gc.C) {
 	c.Assert(output, gc.Equals, expected)
 }
 
+func (s *GetConfigSuite) TestSetValues(c *gc.C) {
+	api := &fakeControllerAPI{}
+	command := controller.NewGetConfigCommandForTest(api, s.store)
+	context, err := cmdtesting.RunCommand(c, command, "max-logs-age=12h", "auditing-enabled=true")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, "")
+	c.Assert(api.values, jc.DeepEquals, map[string]interface{}{
+		"max-logs-age":     "12h",
+		"auditing-enabled": "true",
+	})
+}
+
+func (s *GetConfigSuite) TestSetValuesError(c *gc.C) {
+	command := controller.NewGetConfigCommandForTest(&fakeControllerAPI{err: errors.New("error")}, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "max-logs-age=12h")
+	c.Assert(err, gc.ErrorMatches, "error")
+}
+
 func (s *GetConfigSuite) TestError(c *gc.C) {
 	command := controller.NewGetConfigCommandForTest(&fakeControllerAPI{err: errors.New("error")}, s.store)
 	_, err := cmdtesting.RunCommand(c, command)
@@ -90,7 +117,8 @@ func (s *GetConfigSuite) TestError(c *gc.C) {
 }
 
 type fakeControllerAPI struct {
-	err error
+	err    error
+	values map[string]interface{}
 }
 
 func (f *fakeControllerAPI) Close() error {
@@ -107,3 +135,11 @@ func (f *fakeControllerAPI) ControllerConfig() (jujucontroller.Config, error) {
 		"ca-cert":         "multi\nline",
 	}, nil
 }
+
+func (f *fakeControllerAPI) ConfigSet(values map[string]interface{}) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.values = values
+	return nil
+}
@@ -5,6 +5,7 @@ package controller
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -86,6 +87,7 @@ type ControllerAccessAPI interface {
 	ModelConfig() (map[string]interface{}, error)
 	ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error)
 	AllModels() ([]base.UserModel, error)
+	BackupStatus() (params.BackupStatus, error)
 	Close() error
 }
 
@@ -119,6 +121,7 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 			return err
 		}
 		var access string
+		var backups *BackupDetails
 		client, err := c.getAPI(controllerName)
 		if err != nil {
 			return err
@@ -131,6 +134,7 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 		} else {
 			access = c.userAccess(client, ctx, accountDetails.User)
 			one.AgentVersion = c.agentVersion(client, ctx)
+			backups = c.backupStatus(client, ctx)
 		}
 
 		var details ShowControllerDetails
@@ -150,6 +154,7 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 			continue
 		}
 		c.convertControllerForShow(&details, controllerName, one, access, allModels, modelStatus)
+		details.Backups = backups
 		controllers[controllerName] = details
 	}
 	return c.out.Write(ctx, controllers)
@@ -188,6 +193,33 @@ func (c *showControllerCommand) agentVersion(client ControllerAccessAPI, ctx *cm
 	return mc["agent-version"].(string)
 }
 
+// backupStatus returns the outcome of the controller's most recent
+// scheduled backups, or nil if there is nothing to show. Users who are
+// not controller administrators, and controllers that do not support
+// scheduled backups, have nothing to show.
+func (c *showControllerCommand) backupStatus(client ControllerAccessAPI, ctx *cmd.Context) *BackupDetails {
+	status, err := client.BackupStatus()
+	if errors.IsNotSupported(err) || params.IsCodeUnauthorized(err) {
+		return nil
+	} else if err != nil {
+		fmt.Fprintln(ctx.Stderr, err)
+		return nil
+	}
+	var details BackupDetails
+	if status.LastSuccess != nil {
+		details.LastSuccess = status.LastSuccess.UTC().Format(time.RFC3339)
+		details.LastSuccessID = status.LastSuccessID
+	}
+	if status.LastFailure != nil {
+		details.LastFailure = status.LastFailure.UTC().Format(time.RFC3339)
+		details.LastFailureError = status.LastFailureError
+	}
+	if details == (BackupDetails{}) {
+		return nil
+	}
+	return &details
+}
+
 type ShowControllerDetails struct {
 	// Details contains the same details that client store caches for this controller.
 	Details ControllerDetails `yaml:"details,omitempty" json:"details,omitempty"`
@@ -204,6 +236,10 @@ type ShowControllerDetails struct {
 	// Account is the account details for the user logged into this controller.
 	Account *AccountDetails `yaml:"account,omitempty" json:"account,omitempty"`
 
+	// Backups holds the outcome of the most recent scheduled backups
+	// of the controller.
+	Backups *BackupDetails `yaml:"backups,omitempty" json:"backups,omitempty"`
+
 	// Errors is a collection of errors related to accessing this controller details.
 	Errors []string `yaml:"errors,omitempty" json:"errors,omitempty"`
 }
@@ -268,6 +304,22 @@ type AccountDetails struct {
 	Password string `yaml:"password,omitempty" json:"password,omitempty"`
 }
 
+// BackupDetails holds details of the scheduled backups of a controller
+// to show.
+type BackupDetails struct {
+	// LastSuccess is when a scheduled backup last succeeded.
+	LastSuccess string `yaml:"last-success,omitempty" json:"last-success,omitempty"`
+
+	// LastSuccessID is the ID of the most recent successful scheduled backup.
+	LastSuccessID string `yaml:"last-success-id,omitempty" json:"last-success-id,omitempty"`
+
+	// LastFailure is when a scheduled backup last failed.
+	LastFailure string `yaml:"last-failure,omitempty" json:"last-failure,omitempty"`
+
+	// LastFailureError is the error that caused the most recent failure.
+	LastFailureError string `yaml:"last-failure-error,omitempty" json:"last-failure-error,omitempty"`
+}
+
 func (c *showControllerCommand) convertControllerForShow(
 	controller *ShowControllerDetails,
 	controllerName string,
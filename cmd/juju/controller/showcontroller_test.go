@@ -5,6 +5,7 @@ package controller_test
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -14,6 +15,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
@@ -300,6 +302,33 @@ func (s *ShowControllerSuite) TestShowControllerNoArgs(c *gc.C) {
 	s.assertShowController(c, "--format", "json")
 }
 
+func (s *ShowControllerSuite) TestShowControllerBackupStatus(c *gc.C) {
+	s.fakeController.store = s.createTestClientStore(c)
+	lastSuccess := time.Date(2017, 3, 14, 3, 0, 0, 0, time.UTC)
+	lastFailure := time.Date(2017, 3, 15, 3, 0, 0, 0, time.UTC)
+	s.fakeController.backupStatus = params.BackupStatus{
+		LastSuccess:      &lastSuccess,
+		LastSuccessID:    "backup-1",
+		LastFailure:      &lastFailure,
+		LastFailureError: "disk full",
+	}
+
+	s.expectedOutput = `
+{"mark-test-prodstack":{"details":{"uuid":"this-is-a-uuid","api-endpoints":["this-is-one-of-many-api-endpoints"],"ca-cert":"this-is-a-ca-cert","cloud":"prodstack","agent-version":"999.99.99"},"account":{"user":"admin","access":"superuser"},"backups":{"last-success":"2017-03-14T03:00:00Z","last-success-id":"backup-1","last-failure":"2017-03-15T03:00:00Z","last-failure-error":"disk full"}}}
+`[1:]
+	s.assertShowController(c, "--format", "json", "mark-test-prodstack")
+}
+
+func (s *ShowControllerSuite) TestShowControllerBackupStatusUnauthorized(c *gc.C) {
+	s.fakeController.store = s.createTestClientStore(c)
+	s.fakeController.backupErr = &params.Error{Code: params.CodeUnauthorized, Message: "permission denied"}
+
+	s.expectedOutput = `
+{"mark-test-prodstack":{"details":{"uuid":"this-is-a-uuid","api-endpoints":["this-is-one-of-many-api-endpoints"],"ca-cert":"this-is-a-ca-cert","cloud":"prodstack","agent-version":"999.99.99"},"account":{"user":"admin","access":"superuser"}}}
+`[1:]
+	s.assertShowController(c, "--format", "json", "mark-test-prodstack")
+}
+
 func (s *ShowControllerSuite) TestShowControllerNoArgsNoCurrent(c *gc.C) {
 	store := s.createTestClientStore(c)
 	store.CurrentControllerName = ""
@@ -344,6 +373,8 @@ type fakeController struct {
 	store          jujuclient.ClientStore
 	modelNames     map[string]string
 	machines       map[string][]base.Machine
+	backupStatus   params.BackupStatus
+	backupErr      error
 }
 
 func (*fakeController) GetControllerAccess(user string) (permission.Access, error) {
@@ -383,6 +414,10 @@ func (c *fakeController) AllModels() (result []base.UserModel, _ error) {
 	return result, nil
 }
 
+func (c *fakeController) BackupStatus() (params.BackupStatus, error) {
+	return c.backupStatus, c.backupErr
+}
+
 func (*fakeController) Close() error {
 	return nil
 }
@@ -4,16 +4,20 @@ package model
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/utils/keyvalues"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/cmd/output"
@@ -30,12 +34,21 @@ Supplying one key name returns only the value for the key. Supplying key=value
 will set the supplied key to the supplied value, this can be repeated for
 multiple keys.
 
+Every change made to the model configuration is recorded as a numbered
+revision. The --history option lists these revisions, showing when each
+change was made, who made it and what changed. The --revert option
+returns the model configuration to the values it had immediately after
+the given revision was made; the revert is itself recorded as a new
+revision.
+
 Examples
     juju model-config default-series
     juju model-config -m mycontroller:mymodel
     juju model-config ftp-proxy=10.0.0.1:8000
     juju model-config -m othercontroller:mymodel default-series=yakkety test-mode=false
     juju model-config --reset default-series test-mode
+    juju model-config --history
+    juju model-config --revert 3
 
 See also:
     models
@@ -62,6 +75,8 @@ type configCommand struct {
 	reset     []string // Holds the keys to be reset until parsed.
 	resetKeys []string // Holds the keys to be reset once parsed.
 	values    attributes
+	history   bool
+	revert    int
 }
 
 // configCommandAPI defines an API interface to be used during testing.
@@ -71,6 +86,8 @@ type configCommandAPI interface {
 	ModelGetWithMetadata() (config.ConfigValues, error)
 	ModelSet(config map[string]interface{}) error
 	ModelUnset(keys ...string) error
+	ModelGetHistory() ([]params.ConfigRevision, error)
+	ModelRevert(revision int) error
 }
 
 // Info implements part of the cmd.Command interface.
@@ -93,6 +110,8 @@ func (c *configCommand) SetFlags(f *gnuflag.FlagSet) {
 		"yaml":    cmd.FormatYaml,
 	})
 	f.Var(cmd.NewAppendStringsValue(&c.reset), "reset", "Reset the provided comma delimited keys")
+	f.BoolVar(&c.history, "history", false, "Show the history of changes to the model configuration")
+	f.IntVar(&c.revert, "revert", 0, "Revert the model configuration to the given revision")
 }
 
 // Init implements part of the cmd.Command interface.
@@ -105,6 +124,10 @@ func (c *configCommand) Init(args []string) error {
 		return errors.Trace(err)
 	}
 
+	if c.history || c.revert != 0 {
+		return c.handleHistoryArgs(args)
+	}
+
 	switch len(args) {
 	case 0:
 		return c.handleZeroArgs()
@@ -115,6 +138,26 @@ func (c *configCommand) Init(args []string) error {
 	}
 }
 
+// handleHistoryArgs handles the --history and --revert options, which
+// cannot be combined with each other or with any other operation.
+func (c *configCommand) handleHistoryArgs(args []string) error {
+	if c.history && c.revert != 0 {
+		return errors.New("cannot show history and revert simultaneously")
+	}
+	if len(args) > 0 || len(c.resetKeys) > 0 {
+		return errors.New("cannot get, set or reset model values with --history or --revert")
+	}
+	if c.history {
+		c.action = c.getHistory
+		return nil
+	}
+	if c.revert < 0 {
+		return errors.Errorf("revision %d not valid", c.revert)
+	}
+	c.action = c.revertConfig
+	return nil
+}
+
 // handleZeroArgs handles the case where there are no positional args.
 func (c *configCommand) handleZeroArgs() error {
 	// If reset is empty we're getting configuration
@@ -270,6 +313,67 @@ func (c *configCommand) setConfig(client configCommandAPI, ctx *cmd.Context) err
 	return block.ProcessBlockedError(client.ModelSet(c.values), block.BlockChange)
 }
 
+// revertConfig returns the model config to the requested revision.
+func (c *configCommand) revertConfig(client configCommandAPI, ctx *cmd.Context) error {
+	return block.ProcessBlockedError(client.ModelRevert(c.revert), block.BlockChange)
+}
+
+// configRevision holds a revision of the model config for display.
+type configRevision struct {
+	Revision  int            `yaml:"revision" json:"revision"`
+	Author    string         `yaml:"author,omitempty" json:"author,omitempty"`
+	Timestamp string         `yaml:"timestamp" json:"timestamp"`
+	Changes   []configChange `yaml:"changes" json:"changes"`
+}
+
+// configChange holds a change to a single model config attribute for
+// display.
+type configChange struct {
+	Type     string      `yaml:"type" json:"type"`
+	Key      string      `yaml:"key" json:"key"`
+	OldValue interface{} `yaml:"old-value,omitempty" json:"old-value,omitempty"`
+	NewValue interface{} `yaml:"new-value,omitempty" json:"new-value,omitempty"`
+}
+
+// getHistory writes the history of changes to the model config to the
+// cmd.Context.
+func (c *configCommand) getHistory(client configCommandAPI, ctx *cmd.Context) error {
+	revisions, err := client.ModelGetHistory()
+	if err != nil {
+		return err
+	}
+	history := make([]configRevision, len(revisions))
+	for i, rev := range revisions {
+		history[i] = configRevision{
+			Revision:  rev.Revision,
+			Author:    authorName(rev.Author),
+			Timestamp: rev.Timestamp.UTC().Format(time.RFC3339),
+		}
+		for _, change := range rev.Changes {
+			history[i].Changes = append(history[i].Changes, configChange{
+				Type:     change.Type,
+				Key:      change.Key,
+				OldValue: change.OldValue,
+				NewValue: change.NewValue,
+			})
+		}
+	}
+	if c.out.Name() == "tabular" {
+		return c.out.WriteFormatter(ctx, formatHistoryTabular, history)
+	}
+	return c.out.Write(ctx, history)
+}
+
+// authorName returns the name of the user identified by the given tag,
+// or the tag itself if it does not identify a user.
+func authorName(author string) string {
+	tag, err := names.ParseUserTag(author)
+	if err != nil {
+		return author
+	}
+	return tag.Id()
+}
+
 // get writes the value of a single key or the full output for the model to the cmd.Context.
 func (c *configCommand) getConfig(client configCommandAPI, ctx *cmd.Context) error {
 	attrs, err := client.ModelGetWithMetadata()
@@ -378,3 +482,40 @@ func formatConfigTabular(writer io.Writer, value interface{}) error {
 	tw.Flush()
 	return nil
 }
+
+// formatHistoryTabular writes a tabular summary of the model config
+// history, one line per changed attribute.
+func formatHistoryTabular(writer io.Writer, value interface{}) error {
+	history, ok := value.([]configRevision)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", history, value)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Revision", "Time", "Author", "Change")
+	for _, rev := range history {
+		revision := fmt.Sprint(rev.Revision)
+		timestamp := rev.Timestamp
+		author := rev.Author
+		for _, change := range rev.Changes {
+			w.Println(revision, timestamp, author, describeChange(change))
+			// Only the first change in each revision shows
+			// the revision details.
+			revision, timestamp, author = "", "", ""
+		}
+	}
+	tw.Flush()
+	return nil
+}
+
+func describeChange(change configChange) string {
+	switch change.Type {
+	case "added":
+		return fmt.Sprintf("set %s=%v", change.Key, change.NewValue)
+	case "deleted":
+		return fmt.Sprintf("removed %s (was %v)", change.Key, change.OldValue)
+	default:
+		return fmt.Sprintf("changed %s from %v to %v", change.Key, change.OldValue, change.NewValue)
+	}
+}
@@ -5,6 +5,7 @@ package model_test
 import (
 	"io/ioutil"
 	"path/filepath"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -12,6 +13,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/model"
 	"github.com/juju/juju/testing"
 )
@@ -81,6 +83,31 @@ func (s *ConfigCommandSuite) TestInit(c *gc.C) {
 			desc:   "test reset interspersed",
 			args:   []string{"--reset", "one", "special=foo", "--reset", "two"},
 			nilErr: true,
+		}, {
+			// Test history and revert
+			desc:   "history succeeds",
+			args:   []string{"--history"},
+			nilErr: true,
+		}, {
+			desc:   "revert succeeds",
+			args:   []string{"--revert", "3"},
+			nilErr: true,
+		}, {
+			desc:       "cannot show history and revert",
+			args:       []string{"--history", "--revert", "3"},
+			errorMatch: "cannot show history and revert simultaneously",
+		}, {
+			desc:       "history takes no args",
+			args:       []string{"--history", "special"},
+			errorMatch: "cannot get, set or reset model values with --history or --revert",
+		}, {
+			desc:       "revert cannot be combined with reset",
+			args:       []string{"--revert", "3", "--reset", "special"},
+			errorMatch: "cannot get, set or reset model values with --history or --revert",
+		}, {
+			desc:       "revert requires a valid revision",
+			args:       []string{"--revert", "-1"},
+			errorMatch: "revision -1 not valid",
 		},
 	} {
 		c.Logf("test %d: %s", i, test.desc)
@@ -227,3 +254,66 @@ func (s *ConfigCommandSuite) TestResetBlockedError(c *gc.C) {
 	_, err := s.run(c, "--reset", "special")
 	testing.AssertOperationWasBlocked(c, err, ".*TestBlockedError.*")
 }
+
+func (s *ConfigCommandSuite) setHistory() {
+	ts := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.fake.history = []params.ConfigRevision{{
+		Revision:  1,
+		Author:    "user-admin",
+		Timestamp: ts,
+		Changes: []params.ConfigChange{
+			{Type: "added", Key: "special", NewValue: "special value"},
+			{Type: "modified", Key: "running", OldValue: false, NewValue: true},
+		},
+	}, {
+		Revision:  2,
+		Author:    "user-bob@external",
+		Timestamp: ts,
+		Changes: []params.ConfigChange{
+			{Type: "deleted", Key: "ftp-proxy", OldValue: "http://proxy"},
+		},
+	}}
+}
+
+func (s *ConfigCommandSuite) TestHistoryTabular(c *gc.C) {
+	s.setHistory()
+	context, err := s.run(c, "--history")
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := cmdtesting.Stdout(context)
+	c.Assert(output, gc.Matches, ""+
+		"Revision +Time +Author +Change\n"+
+		"1 +2017-06-01T12:00:00Z +admin +set special=special value\n"+
+		" +changed running from false to true\n"+
+		"2 +2017-06-01T12:00:00Z +bob@external +removed ftp-proxy \\(was http://proxy\\)\n"+
+		"\n")
+}
+
+func (s *ConfigCommandSuite) TestHistoryYAML(c *gc.C) {
+	s.setHistory()
+	s.fake.history = s.fake.history[1:]
+	context, err := s.run(c, "--history", "--format=yaml")
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := cmdtesting.Stdout(context)
+	c.Assert(output, gc.Equals, ""+
+		"- revision: 2\n"+
+		"  author: bob@external\n"+
+		"  timestamp: 2017-06-01T12:00:00Z\n"+
+		"  changes:\n"+
+		"  - type: deleted\n"+
+		"    key: ftp-proxy\n"+
+		"    old-value: http://proxy\n")
+}
+
+func (s *ConfigCommandSuite) TestRevert(c *gc.C) {
+	_, err := s.run(c, "--revert", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.reverted, gc.Equals, 3)
+}
+
+func (s *ConfigCommandSuite) TestRevertBlockedError(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockedError")
+	_, err := s.run(c, "--revert", "3")
+	testing.AssertOperationWasBlocked(c, err, ".*TestBlockedError.*")
+}
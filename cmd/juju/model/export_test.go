@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
@@ -113,3 +114,25 @@ func NewRevokeCommandForTest(modelsApi RevokeModelAPI, offersAPI RevokeOfferAPI,
 }
 
 var GetBudgetAPIClient = &getBudgetAPIClient
+
+// NewExportBundleCommandForTest returns an ExportBundleCommand with the api provided as specified.
+func NewExportBundleCommandForTest(api ExportBundleAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &exportBundleCommand{newAPIFunc: func() (ExportBundleAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewWaitCommandForTest returns a wait command with the api and clock
+// provided as specified.
+func NewWaitCommandForTest(api WaitAPI, clock clock.Clock, store jujuclient.ClientStore) cmd.Command {
+	cmd := &waitCommand{
+		newAPIFunc: func() (WaitAPI, error) {
+			return api, nil
+		},
+		clock: clock,
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -10,6 +10,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/testing"
@@ -45,6 +46,8 @@ type fakeEnvAPI struct {
 	err           error
 	keys          []string
 	resetKeys     []string
+	history       []params.ConfigRevision
+	reverted      int
 }
 
 func (f *fakeEnvAPI) Close() error {
@@ -73,6 +76,15 @@ func (f *fakeEnvAPI) ModelUnset(keys ...string) error {
 	return f.err
 }
 
+func (f *fakeEnvAPI) ModelGetHistory() ([]params.ConfigRevision, error) {
+	return f.history, f.err
+}
+
+func (f *fakeEnvAPI) ModelRevert(revision int) error {
+	f.reverted = revision
+	return f.err
+}
+
 // ModelDefaults related fake environment for testing.
 
 type fakeModelDefaultEnvSuite struct {
@@ -0,0 +1,331 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+)
+
+// NewWaitCommand returns a command that waits for the model to reach
+// the requested state.
+func NewWaitCommand() cmd.Command {
+	return modelcmd.Wrap(&waitCommand{
+		clock: clock.WallClock,
+	})
+}
+
+const waitHelpDoc = `
+Waits until the current model reaches the requested state.
+
+By default, the command waits until every unit in the model has an
+"active" workload status and an "idle" agent status. The units
+considered can be limited to those of a single application with
+--application, and --units additionally requires that the application
+has exactly that number of units. The workload status to wait for can
+be changed with --workload-status.
+
+With --machine, the command waits until each of the given machines has
+started. Machine and unit conditions can be combined.
+
+Changes to the model are followed as they happen, and progress is
+reported whenever the set of outstanding conditions changes. The
+command fails if a unit or machine being waited on enters an error
+state, if a unit becomes blocked (unless blocked is the requested
+workload status), or if --timeout expires first.
+
+Examples:
+
+    juju wait
+    juju wait --application mysql --units 3
+    juju wait --machine 3 --timeout 10m
+    juju wait --application mysql --workload-status blocked
+
+See also:
+    status
+`
+
+// WaitAPI specifies the API calls used by the wait command.
+type WaitAPI interface {
+	Close() error
+	WatchAll() (AllWatcher, error)
+}
+
+// AllWatcher describes the all-watcher used to follow changes to the
+// model.
+type AllWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// waitCommand blocks until the model reaches the requested state.
+type waitCommand struct {
+	modelcmd.ModelCommandBase
+	newAPIFunc func() (WaitAPI, error)
+	clock      clock.Clock
+
+	application    string
+	units          int
+	machines       string
+	workloadStatus string
+	timeout        time.Duration
+
+	machineIds []string
+}
+
+// Info implements Command.
+func (c *waitCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "wait",
+		Purpose: "Waits until the model reaches the requested state.",
+		Doc:     waitHelpDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *waitCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.application, "application", "", "Only wait for the units of this application")
+	f.IntVar(&c.units, "units", -1, "The number of units the application must have")
+	f.StringVar(&c.machines, "machine", "", "Comma-separated list of machines that must be started")
+	f.StringVar(&c.workloadStatus, "workload-status", string(status.Active), "The workload status the units must have")
+	f.DurationVar(&c.timeout, "timeout", 0, "How long to wait before failing (0 waits forever)")
+}
+
+// Init implements Command.
+func (c *waitCommand) Init(args []string) error {
+	if c.application != "" && !names.IsValidApplication(c.application) {
+		return errors.NotValidf("application name %q", c.application)
+	}
+	if c.units >= 0 && c.application == "" {
+		return errors.New("--units requires --application")
+	}
+	if c.machines != "" {
+		for _, id := range strings.Split(c.machines, ",") {
+			id = strings.TrimSpace(id)
+			if !names.IsValidMachine(id) {
+				return errors.NotValidf("machine %q", id)
+			}
+			c.machineIds = append(c.machineIds, id)
+		}
+	}
+	if c.timeout < 0 {
+		return errors.New("--timeout must not be negative")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+type waitAPIAdaptor struct {
+	*api.Client
+}
+
+// WatchAll is part of the WaitAPI interface.
+func (a waitAPIAdaptor) WatchAll() (AllWatcher, error) {
+	watcher, err := a.Client.WatchAll()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return watcher, nil
+}
+
+func (c *waitCommand) getAPI() (WaitAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	apiRoot, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return waitAPIAdaptor{apiRoot.Client()}, nil
+}
+
+// Run implements Command.
+func (c *waitCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return errors.Annotate(err, "cannot watch model")
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- c.wait(ctx, watcher)
+	}()
+
+	var timeout <-chan time.Time
+	if c.timeout > 0 {
+		timeout = c.clock.After(c.timeout)
+	}
+	select {
+	case err := <-done:
+		watcher.Stop()
+		return err
+	case <-timeout:
+		// Stopping the watcher causes any pending Next call to
+		// return, so the waiting goroutine will finish.
+		watcher.Stop()
+		return errors.Errorf("timed out after %v", c.timeout)
+	}
+}
+
+// wait applies the changes reported by the watcher to its view of the
+// model until the conditions are met or cannot be met.
+func (c *waitCommand) wait(ctx *cmd.Context, watcher AllWatcher) error {
+	model := newModelView()
+	var lastProgress string
+	for {
+		deltas, err := watcher.Next()
+		if err != nil {
+			return errors.Annotate(err, "watching model")
+		}
+		model.apply(deltas)
+		pending, err := c.check(model)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(pending) == 0 {
+			ctx.Infof("model is ready")
+			return nil
+		}
+		progress := "waiting for " + strings.Join(pending, ", ")
+		if progress != lastProgress {
+			ctx.Infof("%s", progress)
+			lastProgress = progress
+		}
+	}
+}
+
+// check returns a description of each condition that has not yet been
+// met, or an error if the conditions cannot be met.
+func (c *waitCommand) check(model *modelView) ([]string, error) {
+	var pending []string
+	for _, id := range c.machineIds {
+		machine, ok := model.machines[id]
+		if !ok {
+			pending = append(pending, fmt.Sprintf("machine %s to be added", id))
+			continue
+		}
+		if isErrorStatus(machine.AgentStatus.Current) || isErrorStatus(machine.InstanceStatus.Current) {
+			return nil, errors.Errorf("machine %s is in error: %s", id, machineMessage(machine))
+		}
+		if machine.AgentStatus.Current != status.Started {
+			pending = append(pending, fmt.Sprintf("machine %s to start (%s)", id, machine.AgentStatus.Current))
+		}
+	}
+	if len(c.machineIds) > 0 && c.application == "" {
+		return pending, nil
+	}
+
+	if c.application != "" {
+		if _, ok := model.applications[c.application]; !ok {
+			return append(pending, fmt.Sprintf("application %s to be deployed", c.application)), nil
+		}
+	}
+	var units []*multiwatcher.UnitInfo
+	for _, unit := range model.units {
+		if c.application == "" || unit.Application == c.application {
+			units = append(units, unit)
+		}
+	}
+	sort.Sort(unitsByName(units))
+	if c.units >= 0 && len(units) != c.units {
+		pending = append(pending, fmt.Sprintf("application %s to have %d units (has %d)", c.application, c.units, len(units)))
+	}
+	for _, unit := range units {
+		workload, agent := unit.WorkloadStatus, unit.AgentStatus
+		if isErrorStatus(workload.Current) || isErrorStatus(agent.Current) {
+			return nil, errors.Errorf("unit %s is in error: %s", unit.Name, unitMessage(unit))
+		}
+		if workload.Current == status.Blocked && c.workloadStatus != string(status.Blocked) {
+			return nil, errors.Errorf("unit %s is blocked: %s", unit.Name, workload.Message)
+		}
+		if string(workload.Current) != c.workloadStatus || agent.Current != status.Idle {
+			pending = append(pending, fmt.Sprintf("unit %s (%s/%s)", unit.Name, workload.Current, agent.Current))
+		}
+	}
+	return pending, nil
+}
+
+func isErrorStatus(s status.Status) bool {
+	return s == status.Error || s == status.ProvisioningError
+}
+
+func machineMessage(machine *multiwatcher.MachineInfo) string {
+	if isErrorStatus(machine.InstanceStatus.Current) {
+		return machine.InstanceStatus.Message
+	}
+	return machine.AgentStatus.Message
+}
+
+func unitMessage(unit *multiwatcher.UnitInfo) string {
+	if isErrorStatus(unit.AgentStatus.Current) {
+		return unit.AgentStatus.Message
+	}
+	return unit.WorkloadStatus.Message
+}
+
+// modelView holds the parts of the model reported by the all-watcher
+// that the wait command is interested in.
+type modelView struct {
+	applications map[string]*multiwatcher.ApplicationInfo
+	machines     map[string]*multiwatcher.MachineInfo
+	units        map[string]*multiwatcher.UnitInfo
+}
+
+func newModelView() *modelView {
+	return &modelView{
+		applications: make(map[string]*multiwatcher.ApplicationInfo),
+		machines:     make(map[string]*multiwatcher.MachineInfo),
+		units:        make(map[string]*multiwatcher.UnitInfo),
+	}
+}
+
+func (m *modelView) apply(deltas []multiwatcher.Delta) {
+	for _, delta := range deltas {
+		switch entity := delta.Entity.(type) {
+		case *multiwatcher.ApplicationInfo:
+			if delta.Removed {
+				delete(m.applications, entity.Name)
+			} else {
+				m.applications[entity.Name] = entity
+			}
+		case *multiwatcher.MachineInfo:
+			if delta.Removed {
+				delete(m.machines, entity.Id)
+			} else {
+				m.machines[entity.Id] = entity
+			}
+		case *multiwatcher.UnitInfo:
+			if delta.Removed {
+				delete(m.units, entity.Name)
+			} else {
+				m.units[entity.Name] = entity
+			}
+		}
+	}
+}
+
+type unitsByName []*multiwatcher.UnitInfo
+
+func (u unitsByName) Len() int           { return len(u) }
+func (u unitsByName) Swap(i, j int)      { u[i], u[j] = u[j], u[i] }
+func (u unitsByName) Less(i, j int) bool { return u[i].Name < u[j].Name }
@@ -0,0 +1,265 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing"
+)
+
+type WaitCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store   *jujuclient.MemStore
+	clock   *gitjujutesting.Clock
+	stub    *gitjujutesting.Stub
+	watcher *fakeAllWatcher
+}
+
+var _ = gc.Suite(&WaitCommandSuite{})
+
+func (s *WaitCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+
+	s.clock = gitjujutesting.NewClock(time.Now())
+	s.stub = &gitjujutesting.Stub{}
+	s.watcher = &fakeAllWatcher{
+		stub:    s.stub,
+		stopped: make(chan struct{}),
+	}
+}
+
+func (s *WaitCommandSuite) run(c *gc.C, args ...string) (string, error) {
+	api := &fakeWaitAPI{stub: s.stub, watcher: s.watcher}
+	ctx, err := cmdtesting.RunCommand(c, model.NewWaitCommandForTest(api, s.clock, s.store), args...)
+	if ctx == nil {
+		return "", err
+	}
+	return cmdtesting.Stderr(ctx), err
+}
+
+func unit(name, app string, workload, agent status.Status) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{
+		Name:           name,
+		Application:    app,
+		WorkloadStatus: multiwatcher.StatusInfo{Current: workload, Message: "workload message"},
+		AgentStatus:    multiwatcher.StatusInfo{Current: agent, Message: "agent message"},
+	}}
+}
+
+func application(name string) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.ApplicationInfo{Name: name}}
+}
+
+func machine(id string, agent status.Status) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.MachineInfo{
+		Id:          id,
+		AgentStatus: multiwatcher.StatusInfo{Current: agent, Message: "agent message"},
+	}}
+}
+
+func (s *WaitCommandSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"--units", "3"},
+		err:  "--units requires --application",
+	}, {
+		args: []string{"--application", "$bad"},
+		err:  `application name "\$bad" not valid`,
+	}, {
+		args: []string{"--machine", "0,foo"},
+		err:  `machine "foo" not valid`,
+	}, {
+		args: []string{"--timeout", "-1s"},
+		err:  "--timeout must not be negative",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WaitCommandSuite) TestWaitAllUnits(c *gc.C) {
+	s.watcher.deltas = [][]multiwatcher.Delta{{
+		application("mysql"),
+		unit("mysql/0", "mysql", status.Maintenance, status.Executing),
+		unit("mysql/1", "mysql", status.Active, status.Idle),
+	}, {
+		unit("mysql/2", "mysql", status.Waiting, status.Allocating),
+	}, {
+		unit("mysql/0", "mysql", status.Active, status.Idle),
+		unit("mysql/2", "mysql", status.Active, status.Idle),
+	}}
+	stderr, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stderr, gc.Equals, ""+
+		"waiting for unit mysql/0 (maintenance/executing)\n"+
+		"waiting for unit mysql/0 (maintenance/executing), unit mysql/2 (waiting/allocating)\n"+
+		"model is ready\n")
+	s.stub.CheckCallNames(c, "WatchAll", "Next", "Next", "Next", "Stop", "Close")
+}
+
+func (s *WaitCommandSuite) TestWaitApplicationUnits(c *gc.C) {
+	s.watcher.deltas = [][]multiwatcher.Delta{{
+		unit("wordpress/0", "wordpress", status.Maintenance, status.Executing),
+	}, {
+		application("mysql"),
+		unit("mysql/0", "mysql", status.Active, status.Idle),
+	}, {
+		unit("mysql/1", "mysql", status.Active, status.Idle),
+	}}
+	stderr, err := s.run(c, "--application", "mysql", "--units", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stderr, gc.Equals, ""+
+		"waiting for application mysql to be deployed\n"+
+		"waiting for application mysql to have 2 units (has 1)\n"+
+		"model is ready\n")
+}
+
+func (s *WaitCommandSuite) TestWaitMachines(c *gc.C) {
+	s.watcher.deltas = [][]multiwatcher.Delta{{
+		machine("0", status.Started),
+		machine("3", status.Pending),
+		unit("mysql/0", "mysql", status.Maintenance, status.Executing),
+	}, {
+		machine("3", status.Started),
+	}}
+	stderr, err := s.run(c, "--machine", "0,3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stderr, gc.Equals, ""+
+		"waiting for machine 3 to start (pending)\n"+
+		"model is ready\n")
+}
+
+func (s *WaitCommandSuite) TestUnitError(c *gc.C) {
+	s.watcher.deltas = [][]multiwatcher.Delta{{
+		application("mysql"),
+		unit("mysql/0", "mysql", status.Error, status.Idle),
+	}}
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "unit mysql/0 is in error: workload message")
+}
+
+func (s *WaitCommandSuite) TestUnitBlocked(c *gc.C) {
+	s.watcher.deltas = [][]multiwatcher.Delta{{
+		application("mysql"),
+		unit("mysql/0", "mysql", status.Blocked, status.Idle),
+	}}
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "unit mysql/0 is blocked: workload message")
+}
+
+func (s *WaitCommandSuite) TestWaitForBlocked(c *gc.C) {
+	s.watcher.deltas = [][]multiwatcher.Delta{{
+		application("mysql"),
+		unit("mysql/0", "mysql", status.Blocked, status.Idle),
+	}}
+	_, err := s.run(c, "--application", "mysql", "--workload-status", "blocked")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WaitCommandSuite) TestMachineError(c *gc.C) {
+	s.watcher.deltas = [][]multiwatcher.Delta{{
+		machine("0", status.Error),
+	}}
+	_, err := s.run(c, "--machine", "0")
+	c.Assert(err, gc.ErrorMatches, "machine 0 is in error: agent message")
+}
+
+func (s *WaitCommandSuite) TestWatchError(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("connection lost"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "watching model: connection lost")
+}
+
+func (s *WaitCommandSuite) TestTimeout(c *gc.C) {
+	s.watcher.deltas = [][]multiwatcher.Delta{{
+		application("mysql"),
+		unit("mysql/0", "mysql", status.Maintenance, status.Executing),
+	}}
+	go func() {
+		// Wait for the command to start waiting before advancing
+		// the clock past the timeout.
+		c.Check(s.clock.WaitAdvance(time.Minute, testing.LongWait, 1), jc.ErrorIsNil)
+	}()
+	_, err := s.run(c, "--timeout", "1m")
+	c.Assert(err, gc.ErrorMatches, "timed out after 1m0s")
+	select {
+	case <-s.watcher.stopped:
+	default:
+		c.Fatalf("watcher not stopped")
+	}
+}
+
+type fakeWaitAPI struct {
+	stub    *gitjujutesting.Stub
+	watcher *fakeAllWatcher
+}
+
+func (f *fakeWaitAPI) WatchAll() (model.AllWatcher, error) {
+	f.stub.AddCall("WatchAll")
+	if err := f.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.watcher, nil
+}
+
+func (f *fakeWaitAPI) Close() error {
+	f.stub.AddCall("Close")
+	return nil
+}
+
+// fakeAllWatcher returns each of its batches of deltas in turn, and
+// then blocks until it is stopped.
+type fakeAllWatcher struct {
+	stub    *gitjujutesting.Stub
+	deltas  [][]multiwatcher.Delta
+	stopped chan struct{}
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	w.stub.AddCall("Next")
+	if err := w.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	if len(w.deltas) == 0 {
+		<-w.stopped
+		return nil, errors.New("watcher stopped")
+	}
+	deltas := w.deltas[0]
+	w.deltas = w.deltas[1:]
+	return deltas, nil
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	w.stub.AddCall("Stop")
+	close(w.stopped)
+	return nil
+}
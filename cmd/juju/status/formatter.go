@@ -187,6 +187,18 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 		StatusInfo:    sf.getApplicationStatusInfo(application),
 		Version:       application.WorkloadVersion,
 	}
+	for endpoint, settings := range application.ExposedEndpoints {
+		if out.ExposedEndpoints == nil {
+			out.ExposedEndpoints = make(map[string]exposedEndpoint)
+		}
+		if endpoint == "" {
+			// The settings apply to all endpoints.
+			endpoint = "*"
+		}
+		out.ExposedEndpoints[endpoint] = exposedEndpoint{
+			ExposeToCIDRs: settings.ExposeToCIDRs,
+		}
+	}
 	for k, m := range application.Units {
 		out.Units[k] = sf.formatUnit(unitFormatInfo{
 			unit:            m,
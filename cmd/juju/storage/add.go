@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
@@ -57,6 +58,12 @@ Storage constraints can be optionally ommitted.
 Model default values will be used for all ommitted constraint values.
 There is no need to comma-separate ommitted constraints. 
 
+With --from-snapshot, a single storage instance is added, backed by a
+new volume created from the specified volume snapshot, as created by
+juju snapshot-storage. The volume is created in the storage pool of the
+snapshotted volume, with the same size, so no storage constraints may
+be specified. Only block storage may be created from a snapshot.
+
 Examples:
     # Add 3 ebs storage instances for "data" storage to unit u/0:
 
@@ -73,6 +80,11 @@ Examples:
       juju add-storage u/0 data=1 
     or
       juju add-storage u/0 data 
+
+    # Add storage for "data" to unit u/0, using a volume
+    # created from snapshot 3:
+
+      juju add-storage u/0 data --from-snapshot 3
 `
 	addCommandAgs = `
 <unit name> <storage directive> ...
@@ -92,6 +104,16 @@ type addCommand struct {
 	// defined in charm storage metadata.
 	storageCons map[string]storage.Constraints
 	newAPIFunc  func() (StorageAddAPI, error)
+
+	// fromSnapshot is the ID of the volume snapshot from which
+	// to create the storage, if any.
+	fromSnapshot string
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.StringVar(&c.fromSnapshot, "from-snapshot", "", "Create the storage from the specified volume snapshot")
 }
 
 // Init implements Command.Init.
@@ -106,6 +128,13 @@ func (c *addCommand) Init(args []string) (err error) {
 	}
 	c.unitTag = names.NewUnitTag(u).String()
 
+	if c.fromSnapshot != "" {
+		if len(args) > 2 || strings.Contains(args[1], "=") {
+			return errors.New("--from-snapshot requires a single storage name without constraints")
+		}
+		c.storageCons = map[string]storage.Constraints{args[1]: {}}
+		return nil
+	}
 	c.storageCons, err = storage.ParseConstraintsMap(args[1:], false)
 	return
 }
@@ -187,6 +216,14 @@ type StorageAddAPI interface {
 func (c *addCommand) createStorageAddParams() []params.StorageAddParams {
 	all := make([]params.StorageAddParams, 0, len(c.storageCons))
 	for one, cons := range c.storageCons {
+		if c.fromSnapshot != "" {
+			all = append(all, params.StorageAddParams{
+				UnitTag:      c.unitTag,
+				StorageName:  one,
+				FromSnapshot: c.fromSnapshot,
+			})
+			continue
+		}
 		all = append(all,
 			params.StorageAddParams{
 				UnitTag:     c.unitTag,
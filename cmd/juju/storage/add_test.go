@@ -237,6 +237,28 @@ func (s *addSuite) TestUnauthorizedMentionsJujuGrant(c *gc.C) {
 	c.Assert(errString, gc.Matches, `.*juju grant.*`)
 }
 
+func (s *addSuite) TestAddFromSnapshot(c *gc.C) {
+	s.args = []string{"tst/123", "data", "--from-snapshot", "3"}
+	var added []params.StorageAddParams
+	s.mockAPI.addToUnitFunc = func(storages []params.StorageAddParams) ([]params.ErrorResult, error) {
+		added = storages
+		return make([]params.ErrorResult, len(storages)), nil
+	}
+	s.assertAddOutput(c, "added \"data\"\n", "")
+	c.Assert(added, jc.DeepEquals, []params.StorageAddParams{{
+		UnitTag:      "unit-tst-123",
+		StorageName:  "data",
+		FromSnapshot: "3",
+	}})
+}
+
+func (s *addSuite) TestAddFromSnapshotWithConstraints(c *gc.C) {
+	_, err := s.runAdd(c, "tst/123", "data=ebs,10G", "--from-snapshot", "3")
+	c.Assert(err, gc.ErrorMatches, "--from-snapshot requires a single storage name without constraints")
+	_, err = s.runAdd(c, "tst/123", "data", "logs", "--from-snapshot", "3")
+	c.Assert(err, gc.ErrorMatches, "--from-snapshot requires a single storage name without constraints")
+}
+
 func (s *addSuite) assertAddOutput(c *gc.C, expectedOut, expectedErr string) {
 	context, err := s.runAdd(c, s.args...)
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,136 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewGrowStorageCommandWithAPI returns a command
+// used to grow storage in the model.
+func NewGrowStorageCommandWithAPI() cmd.Command {
+	cmd := &growStorageCommand{}
+	cmd.newResizerCloser = func() (ResizerCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewGrowStorageCommand returns a command
+// used to grow storage in the model.
+func NewGrowStorageCommand(new NewResizerCloserFunc) cmd.Command {
+	cmd := &growStorageCommand{}
+	cmd.newResizerCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	growStorageCommandDoc = `
+Grows the specified storage to the given size, as output by "juju storage".
+
+The size is a number, optionally followed by one of the suffixes
+M, G, T, P or E; without a suffix, the size is in megabytes.
+Storage can only be grown, never shrunk.
+
+The volume backing the storage is resized by the storage provider,
+which must support resizing volumes, such as gce and loop. Resizing
+ebs and cinder volumes is not yet supported. Once the
+volume has been resized, the "storage-resized" hook is run for the unit
+that the storage is attached to, so that the charm can make use of the
+additional space.
+
+Juju does not grow the filesystem on the volume, even for filesystem
+storage whose filesystem Juju created and mounted. The charm's
+"storage-resized" hook must grow it; for example, with resize2fs for
+ext4 filesystems or xfs_growfs for xfs filesystems.
+
+Examples:
+    juju grow-storage pgdata/0 200G
+`
+	growStorageCommandArgs = `<storage> <size>`
+)
+
+type growStorageCommand struct {
+	StorageCommandBase
+	newResizerCloser NewResizerCloserFunc
+	storageId        string
+	size             uint64
+}
+
+// Info implements Command.Info.
+func (c *growStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "grow-storage",
+		Purpose: "Grows storage to a larger size.",
+		Doc:     growStorageCommandDoc,
+		Args:    growStorageCommandArgs,
+	}
+}
+
+// Init implements Command.Init.
+func (c *growStorageCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("grow-storage requires a storage ID and size")
+	case 1:
+		return errors.New("grow-storage requires a size")
+	}
+	storageId, sizeString, rest := args[0], args[1], args[2:]
+	if !names.IsValidStorage(storageId) {
+		return errors.NotValidf("storage ID %q", storageId)
+	}
+	size, err := utils.ParseSize(sizeString)
+	if err != nil {
+		return errors.Annotate(err, "invalid size")
+	}
+	if size == 0 {
+		return errors.NotValidf("size 0")
+	}
+	c.storageId = storageId
+	c.size = size
+	return cmd.CheckEmpty(rest)
+}
+
+// Run implements Command.Run.
+func (c *growStorageCommand) Run(ctx *cmd.Context) error {
+	resizer, err := c.newResizerCloser()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resizer.Close()
+
+	if err := resizer.ResizeStorage(c.storageId, c.size); err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "grow storage")
+		}
+		return err
+	}
+	fmt.Fprintf(ctx.Stdout, "growing %s to %dMiB\n", c.storageId, c.size)
+	return nil
+}
+
+// NewResizerCloserFunc is the type of a function that returns a
+// ResizerCloser.
+type NewResizerCloserFunc func() (ResizerCloser, error)
+
+// ResizerCloser extends Resizer with a Closer method.
+type ResizerCloser interface {
+	Resizer
+	Close() error
+}
+
+// Resizer defines an interface for growing the storage instance
+// with the specified ID to the given size in MiB.
+type Resizer interface {
+	ResizeStorage(storageId string, size uint64) error
+}
@@ -0,0 +1,88 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type GrowStorageSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&GrowStorageSuite{})
+
+func (s *GrowStorageSuite) TestGrowStorage(c *gc.C) {
+	var fake fakeResizer
+	cmd := storage.NewGrowStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "pgdata/0", "200G")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewResizerCloser", "ResizeStorage", "Close")
+	fake.CheckCall(c, 1, "ResizeStorage", "pgdata/0", uint64(200*1024))
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "growing pgdata/0 to 204800MiB\n")
+}
+
+func (s *GrowStorageSuite) TestGrowStorageError(c *gc.C) {
+	var fake fakeResizer
+	fake.SetErrors(nil, errors.New(`cannot resize volume "0": requested size 1024MiB is not larger than current size 2048MiB`))
+	cmd := storage.NewGrowStorageCommand(fake.new)
+	_, err := cmdtesting.RunCommand(c, cmd, "pgdata/0", "1024")
+	c.Assert(err, gc.ErrorMatches, `cannot resize volume "0": requested size 1024MiB is not larger than current size 2048MiB`)
+	fake.CheckCallNames(c, "NewResizerCloser", "ResizeStorage", "Close")
+}
+
+func (s *GrowStorageSuite) TestGrowStorageUnauthorizedError(c *gc.C) {
+	var fake fakeResizer
+	fake.SetErrors(nil, &params.Error{Code: params.CodeUnauthorized, Message: "nope"})
+	cmd := storage.NewGrowStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "pgdata/0", "200G")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+You do not have permission to grow storage.
+You may ask an administrator to grant you access with "juju grant".
+
+`)
+}
+
+func (s *GrowStorageSuite) TestGrowStorageInitErrors(c *gc.C) {
+	s.testGrowStorageInitError(c, []string{}, "grow-storage requires a storage ID and size")
+	s.testGrowStorageInitError(c, []string{"pgdata/0"}, "grow-storage requires a size")
+	s.testGrowStorageInitError(c, []string{"pgdata", "200G"}, `storage ID "pgdata" not valid`)
+	s.testGrowStorageInitError(c, []string{"pgdata/0", "lots"}, `invalid size: .*`)
+	s.testGrowStorageInitError(c, []string{"pgdata/0", "0"}, "size 0 not valid")
+	s.testGrowStorageInitError(c, []string{"pgdata/0", "200G", "extra"}, `unrecognized args: \["extra"\]`)
+}
+
+func (s *GrowStorageSuite) testGrowStorageInitError(c *gc.C, args []string, expect string) {
+	var fake fakeResizer
+	cmd := storage.NewGrowStorageCommand(fake.new)
+	_, err := cmdtesting.RunCommand(c, cmd, args...)
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+type fakeResizer struct {
+	testing.Stub
+}
+
+func (f *fakeResizer) new() (storage.ResizerCloser, error) {
+	f.MethodCall(f, "NewResizerCloser")
+	return f, f.NextErr()
+}
+
+func (f *fakeResizer) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeResizer) ResizeStorage(id string, size uint64) error {
+	f.MethodCall(f, "ResizeStorage", id, size)
+	return f.NextErr()
+}
@@ -0,0 +1,188 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewImportVolumeCommandWithAPI returns a command
+// used to import existing volumes into the model.
+func NewImportVolumeCommandWithAPI() cmd.Command {
+	return newImportStorageCommandWithAPI(params.StorageKindBlock)
+}
+
+// NewImportVolumeCommand returns a command
+// used to import existing volumes into the model.
+func NewImportVolumeCommand(new NewStorageImporterCloserFunc) cmd.Command {
+	return newImportStorageCommand(params.StorageKindBlock, new)
+}
+
+// NewImportFilesystemCommandWithAPI returns a command
+// used to import existing filesystems into the model.
+func NewImportFilesystemCommandWithAPI() cmd.Command {
+	return newImportStorageCommandWithAPI(params.StorageKindFilesystem)
+}
+
+// NewImportFilesystemCommand returns a command
+// used to import existing filesystems into the model.
+func NewImportFilesystemCommand(new NewStorageImporterCloserFunc) cmd.Command {
+	return newImportStorageCommand(params.StorageKindFilesystem, new)
+}
+
+func newImportStorageCommandWithAPI(kind params.StorageKind) cmd.Command {
+	cmd := &importStorageCommand{kind: kind}
+	cmd.newStorageImporterCloser = func() (StorageImporterCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+func newImportStorageCommand(kind params.StorageKind, new NewStorageImporterCloserFunc) cmd.Command {
+	cmd := &importStorageCommand{kind: kind}
+	cmd.newStorageImporterCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	importVolumeCommandDoc = `
+Imports an existing volume into the model, creating a new storage
+instance that is not attached to any unit. Specify the storage pool
+the volume belongs to, the volume's provider ID, and the name of the
+charm storage the volume will be used for.
+
+The ID of the new storage is printed. The storage may then be attached
+to a unit with "juju attach-storage".
+
+Volumes can only be imported from storage pools whose provider supports
+importing, such as ebs, cinder or gce. Where the provider supports
+tagging, the volume is re-tagged as belonging to the model.
+
+A volume tagged as belonging to another model is refused, unless
+--force is specified. Forcing the import of a volume that is still in
+use by another model may lead to data loss.
+
+Examples:
+    juju import-volume gce us-east1-b--0a8f3f0c-b9e8-4e8e-9b9e-29bdc6f5bd2e pgdata
+    juju attach-storage postgresql/0 pgdata/0
+`
+
+	importFilesystemCommandDoc = `
+Imports an existing filesystem into the model, creating a new storage
+instance that is not attached to any unit. Specify the storage pool
+the filesystem belongs to, the filesystem's provider ID, and the name
+of the charm storage the filesystem will be used for.
+
+The ID of the new storage is printed. The storage may then be attached
+to a unit with "juju attach-storage".
+
+Filesystems can only be imported from storage pools whose provider
+supports importing, such as lxd.
+
+A filesystem tagged as belonging to another model is refused, unless
+--force is specified. Forcing the import of a filesystem that is still
+in use by another model may lead to data loss.
+
+Examples:
+    juju import-filesystem lxd default:juju-data data
+    juju attach-storage mysql/0 data/0
+`
+
+	importStorageCommandArgs = `<pool> <provider-id> <storage-name>`
+)
+
+type importStorageCommand struct {
+	StorageCommandBase
+	newStorageImporterCloser NewStorageImporterCloserFunc
+	kind                     params.StorageKind
+	pool                     string
+	providerId               string
+	storageName              string
+	force                    bool
+}
+
+func (c *importStorageCommand) commandName() string {
+	if c.kind == params.StorageKindFilesystem {
+		return "import-filesystem"
+	}
+	return "import-volume"
+}
+
+// Info implements Command.Info.
+func (c *importStorageCommand) Info() *cmd.Info {
+	info := &cmd.Info{
+		Name:    "import-volume",
+		Purpose: "Imports an existing volume into the model.",
+		Doc:     importVolumeCommandDoc,
+		Args:    importStorageCommandArgs,
+	}
+	if c.kind == params.StorageKindFilesystem {
+		info.Name = "import-filesystem"
+		info.Purpose = "Imports an existing filesystem into the model."
+		info.Doc = importFilesystemCommandDoc
+	}
+	return info
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *importStorageCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.BoolVar(&c.force, "force", false, "Import storage tagged as belonging to another model")
+}
+
+// Init implements Command.Init.
+func (c *importStorageCommand) Init(args []string) error {
+	if len(args) != 3 {
+		return errors.Errorf("%s requires a pool, provider ID, and storage name", c.commandName())
+	}
+	c.pool, c.providerId, c.storageName = args[0], args[1], args[2]
+	if !names.IsValidStorage(c.storageName + "/0") {
+		return errors.NotValidf("storage name %q", c.storageName)
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *importStorageCommand) Run(ctx *cmd.Context) error {
+	importer, err := c.newStorageImporterCloser()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer importer.Close()
+
+	storageTag, err := importer.Import(c.kind, c.pool, c.providerId, c.storageName, c.force)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "import storage")
+		}
+		return err
+	}
+	fmt.Fprintf(ctx.Stdout, "imported storage %s\n", storageTag.Id())
+	return nil
+}
+
+// NewStorageImporterCloserFunc is the type of a function that returns a
+// StorageImporterCloser.
+type NewStorageImporterCloserFunc func() (StorageImporterCloser, error)
+
+// StorageImporterCloser extends StorageImporter with a Closer method.
+type StorageImporterCloser interface {
+	StorageImporter
+	Close() error
+}
+
+// StorageImporter defines an interface for importing existing
+// volumes and filesystems into the model.
+type StorageImporter interface {
+	Import(kind params.StorageKind, pool, providerId, storageName string, force bool) (names.StorageTag, error)
+}
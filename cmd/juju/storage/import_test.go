@@ -0,0 +1,101 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type ImportStorageSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ImportStorageSuite{})
+
+func (s *ImportStorageSuite) TestImportVolume(c *gc.C) {
+	fake := fakeStorageImporter{tag: names.NewStorageTag("pgdata/0")}
+	cmd := storage.NewImportVolumeCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "gce", "us-east1-b--0a8f", "pgdata")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewStorageImporterCloser", "Import", "Close")
+	fake.CheckCall(c, 1, "Import", params.StorageKindBlock, "gce", "us-east1-b--0a8f", "pgdata", false)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "imported storage pgdata/0\n")
+}
+
+func (s *ImportStorageSuite) TestImportFilesystem(c *gc.C) {
+	fake := fakeStorageImporter{tag: names.NewStorageTag("data/1")}
+	cmd := storage.NewImportFilesystemCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "lxd", "default:juju-data", "data")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCall(c, 1, "Import", params.StorageKindFilesystem, "lxd", "default:juju-data", "data", false)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "imported storage data/1\n")
+}
+
+func (s *ImportStorageSuite) TestImportForce(c *gc.C) {
+	fake := fakeStorageImporter{tag: names.NewStorageTag("pgdata/0")}
+	cmd := storage.NewImportVolumeCommand(fake.new)
+	_, err := cmdtesting.RunCommand(c, cmd, "gce", "us-east1-b--0a8f", "pgdata", "--force")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCall(c, 1, "Import", params.StorageKindBlock, "gce", "us-east1-b--0a8f", "pgdata", true)
+}
+
+func (s *ImportStorageSuite) TestImportError(c *gc.C) {
+	var fake fakeStorageImporter
+	fake.SetErrors(nil, &params.Error{Message: "storage already exists"})
+	cmd := storage.NewImportVolumeCommand(fake.new)
+	_, err := cmdtesting.RunCommand(c, cmd, "gce", "us-east1-b--0a8f", "pgdata")
+	c.Assert(err, gc.ErrorMatches, "storage already exists")
+	fake.CheckCallNames(c, "NewStorageImporterCloser", "Import", "Close")
+}
+
+func (s *ImportStorageSuite) TestImportUnauthorizedError(c *gc.C) {
+	var fake fakeStorageImporter
+	fake.SetErrors(nil, &params.Error{Code: params.CodeUnauthorized, Message: "nope"})
+	cmd := storage.NewImportFilesystemCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "lxd", "default:juju-data", "data")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+You do not have permission to import storage.
+You may ask an administrator to grant you access with "juju grant".
+
+`)
+}
+
+func (s *ImportStorageSuite) TestImportInitErrors(c *gc.C) {
+	var fake fakeStorageImporter
+	_, err := cmdtesting.RunCommand(c, storage.NewImportVolumeCommand(fake.new), "gce", "vol-1234")
+	c.Assert(err, gc.ErrorMatches, "import-volume requires a pool, provider ID, and storage name")
+	_, err = cmdtesting.RunCommand(c, storage.NewImportFilesystemCommand(fake.new))
+	c.Assert(err, gc.ErrorMatches, "import-filesystem requires a pool, provider ID, and storage name")
+	_, err = cmdtesting.RunCommand(c, storage.NewImportVolumeCommand(fake.new), "gce", "vol-1234", "0pgdata")
+	c.Assert(err, gc.ErrorMatches, `storage name "0pgdata" not valid`)
+	fake.CheckNoCalls(c)
+}
+
+type fakeStorageImporter struct {
+	testing.Stub
+	tag names.StorageTag
+}
+
+func (f *fakeStorageImporter) new() (storage.StorageImporterCloser, error) {
+	f.MethodCall(f, "NewStorageImporterCloser")
+	return f, f.NextErr()
+}
+
+func (f *fakeStorageImporter) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeStorageImporter) Import(kind params.StorageKind, pool, providerId, storageName string, force bool) (names.StorageTag, error) {
+	f.MethodCall(f, "Import", kind, pool, providerId, storageName, force)
+	return f.tag, f.NextErr()
+}
@@ -36,6 +36,16 @@ into named resources that can be used during deployment.
 Pools defined at the model level are easily reused across applications.
 Pool creation requires a pool name, the provider type and attributes for
 configuration as space-separated pairs, e.g. tags, size, path, etc.
+
+Storage from an "nfs" pool mounts the export given by the pool's "server"
+and "path" attributes. Every storage instance drawn from the pool mounts
+the same export, and so shares its files; create a pool for each export
+to keep storage instances apart. Machines that the storage is attached to
+must have the NFS client (the nfs-common package) installed.
+
+Examples:
+
+    juju create-storage-pool media nfs server=10.0.0.1 path=/srv/media
 `
 
 // NewPoolCreateCommand returns a command that creates or defines a storage pool
@@ -0,0 +1,123 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewSnapshotStorageCommandWithAPI returns a command
+// used to snapshot storage in the model.
+func NewSnapshotStorageCommandWithAPI() cmd.Command {
+	cmd := &snapshotStorageCommand{}
+	cmd.newSnapshotterCloser = func() (SnapshotterCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewSnapshotStorageCommand returns a command
+// used to snapshot storage in the model.
+func NewSnapshotStorageCommand(new NewSnapshotterCloserFunc) cmd.Command {
+	cmd := &snapshotStorageCommand{}
+	cmd.newSnapshotterCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	snapshotStorageCommandDoc = `
+Creates snapshots of the volumes backing the specified block storage.
+Specify one or more storage IDs, as output by "juju storage".
+
+The ID of each snapshot is printed. A snapshot may be used to create
+new storage for a unit with "juju add-storage --from-snapshot".
+
+Snapshots can only be taken of volumes in storage pools whose provider
+supports snapshots, such as ebs, gce, and cinder. Volumes managed by the
+machine itself, such as loop volumes, cannot be snapshotted.
+Snapshots are deleted when the model is destroyed.
+
+Examples:
+    juju snapshot-storage pgdata/0
+`
+	snapshotStorageCommandArgs = `<storage> [<storage> ...]`
+)
+
+type snapshotStorageCommand struct {
+	StorageCommandBase
+	newSnapshotterCloser NewSnapshotterCloserFunc
+	storageIds           []string
+}
+
+// Info implements Command.Info.
+func (c *snapshotStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "snapshot-storage",
+		Purpose: "Creates snapshots of storage volumes.",
+		Doc:     snapshotStorageCommandDoc,
+		Args:    snapshotStorageCommandArgs,
+	}
+}
+
+// Init implements Command.Init.
+func (c *snapshotStorageCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("snapshot-storage requires at least one storage ID")
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *snapshotStorageCommand) Run(ctx *cmd.Context) error {
+	snapshotter, err := c.newSnapshotterCloser()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer snapshotter.Close()
+
+	results, err := snapshotter.CreateSnapshots(c.storageIds)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "snapshot storage")
+		}
+		return err
+	}
+	anyFailed := false
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to snapshot %s: %s", c.storageIds[i], result.Error)
+			anyFailed = true
+			continue
+		}
+		fmt.Fprintf(ctx.Stdout, "created snapshot %s of %s\n", result.Result.Id, c.storageIds[i])
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// NewSnapshotterCloserFunc is the type of a function that returns a
+// SnapshotterCloser.
+type NewSnapshotterCloserFunc func() (SnapshotterCloser, error)
+
+// SnapshotterCloser extends Snapshotter with a Closer method.
+type SnapshotterCloser interface {
+	Snapshotter
+	Close() error
+}
+
+// Snapshotter defines an interface for creating snapshots of the
+// volumes backing storage instances with the specified IDs.
+type Snapshotter interface {
+	CreateSnapshots([]string) ([]params.VolumeSnapshotResult, error)
+}
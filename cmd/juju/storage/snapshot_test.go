@@ -0,0 +1,89 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type SnapshotStorageSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SnapshotStorageSuite{})
+
+func (s *SnapshotStorageSuite) TestSnapshotStorage(c *gc.C) {
+	fake := fakeSnapshotter{results: []params.VolumeSnapshotResult{
+		{Result: &params.VolumeSnapshotDetails{Id: "0"}},
+		{Result: &params.VolumeSnapshotDetails{Id: "1"}},
+	}}
+	cmd := storage.NewSnapshotStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "pgdata/0", "pgdata/1")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewSnapshotterCloser", "CreateSnapshots", "Close")
+	fake.CheckCall(c, 1, "CreateSnapshots", []string{"pgdata/0", "pgdata/1"})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+created snapshot 0 of pgdata/0
+created snapshot 1 of pgdata/1
+`[1:])
+}
+
+func (s *SnapshotStorageSuite) TestSnapshotStorageError(c *gc.C) {
+	fake := fakeSnapshotter{results: []params.VolumeSnapshotResult{
+		{Result: &params.VolumeSnapshotDetails{Id: "0"}},
+		{Error: &params.Error{Message: "foo"}},
+	}}
+	snapshotCmd := storage.NewSnapshotStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, snapshotCmd, "pgdata/0", "pgdata/1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "created snapshot 0 of pgdata/0\n")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "failed to snapshot pgdata/1: foo\n")
+}
+
+func (s *SnapshotStorageSuite) TestSnapshotStorageUnauthorizedError(c *gc.C) {
+	var fake fakeSnapshotter
+	fake.SetErrors(nil, &params.Error{Code: params.CodeUnauthorized, Message: "nope"})
+	cmd := storage.NewSnapshotStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "pgdata/0")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+You do not have permission to snapshot storage.
+You may ask an administrator to grant you access with "juju grant".
+
+`)
+}
+
+func (s *SnapshotStorageSuite) TestSnapshotStorageInitErrors(c *gc.C) {
+	var fake fakeSnapshotter
+	cmd := storage.NewSnapshotStorageCommand(fake.new)
+	_, err := cmdtesting.RunCommand(c, cmd)
+	c.Assert(err, gc.ErrorMatches, "snapshot-storage requires at least one storage ID")
+}
+
+type fakeSnapshotter struct {
+	testing.Stub
+	results []params.VolumeSnapshotResult
+}
+
+func (f *fakeSnapshotter) new() (storage.SnapshotterCloser, error) {
+	f.MethodCall(f, "NewSnapshotterCloser")
+	return f, f.NextErr()
+}
+
+func (f *fakeSnapshotter) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeSnapshotter) CreateSnapshots(ids []string) ([]params.VolumeSnapshotResult, error) {
+	f.MethodCall(f, "CreateSnapshots", ids)
+	return f.results, f.NextErr()
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/cmd/jujud/util"
+	"github.com/juju/juju/network"
 )
 
 // AgentConf is a terribly confused interface.
@@ -78,7 +79,8 @@ func (c *agentConf) DataDir() string {
 	return c.dataDir
 }
 
-// ReadConfig reads the agent's config from its config file.
+// ReadConfig reads the agent's config from its config file, and
+// applies the agent's preferred address family.
 func (c *agentConf) ReadConfig(tag string) error {
 	t, err := names.ParseTag(tag)
 	if err != nil {
@@ -91,6 +93,7 @@ func (c *agentConf) ReadConfig(tag string) error {
 		return err
 	}
 	c._config = conf
+	network.SetPreferIPv6(conf.Value(agent.PreferredAddressFamily) == string(network.IPv6Address))
 	return nil
 }
 
@@ -51,6 +51,7 @@ var (
 		"space-importer",
 		"state-cleaner",
 		"status-history-pruner",
+		"action-pruner",
 		"storage-provisioner",
 		"unit-assigner",
 		"remote-relations",
@@ -111,6 +112,7 @@ var (
 	notMigratingMachineWorkers = []string{
 		"api-address-updater",
 		"disk-manager",
+		// "egress-firewaller", not stable, requires root to run iptables
 		// "host-key-reporter", not stable, exits when done
 		"log-sender",
 		"logging-config-updater",
@@ -65,12 +65,15 @@ import (
 	"github.com/juju/juju/instance"
 	jujunames "github.com/juju/juju/juju/names"
 	"github.com/juju/juju/juju/paths"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/mongo/txnmetrics"
 	"github.com/juju/juju/pubsub/centralhub"
 	"github.com/juju/juju/service"
 	"github.com/juju/juju/service/common"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/state/stateenvirons"
 	"github.com/juju/juju/state/statemetrics"
@@ -80,7 +83,9 @@ import (
 	"github.com/juju/juju/watcher"
 	jworker "github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/apicaller"
+	"github.com/juju/juju/worker/backupscheduler"
 	"github.com/juju/juju/worker/certupdater"
+	"github.com/juju/juju/worker/controllerconfigupdater"
 	"github.com/juju/juju/worker/conv2state"
 	"github.com/juju/juju/worker/dblogpruner"
 	"github.com/juju/juju/worker/dependency"
@@ -300,6 +305,7 @@ func NewMachineAgent(
 		machineId:                   machineId,
 		AgentConfigWriter:           agentConfWriter,
 		configChangedVal:            voyeur.NewValue(true),
+		auditingEnabled:             voyeur.NewValue(false),
 		bufferedLogger:              bufferedLogger,
 		workersStarted:              make(chan struct{}),
 		runner:                      runner,
@@ -367,6 +373,11 @@ type MachineAgent struct {
 	// worker can have a single thing to hold that can report on the state pool.
 	// The content of the state pool holder is updated as the pool changes.
 	statePool *statePoolHolder
+
+	// auditingEnabled holds whether the controller config currently
+	// enables auditing of API requests. It is updated by the
+	// controllerconfigupdater worker.
+	auditingEnabled *voyeur.Value
 }
 
 type statePoolHolder struct {
@@ -1076,6 +1087,13 @@ func (a *MachineAgent) startStateWorkers(
 				return newCertificateUpdater(m, agentConfig, st, st, stateServingSetter), nil
 			})
 
+			a.startWorkerAfterUpgrade(runner, "controllerconfigupdater", func() (worker.Worker, error) {
+				return controllerconfigupdater.New(controllerconfigupdater.Config{
+					Source:  st,
+					Changed: a.controllerConfigChanged,
+				})
+			})
+
 			a.startWorkerAfterUpgrade(singularRunner, "dblogpruner", func() (worker.Worker, error) {
 				return dblogpruner.New(st, dblogpruner.NewLogPruneParams()), nil
 			})
@@ -1083,6 +1101,19 @@ func (a *MachineAgent) startStateWorkers(
 			a.startWorkerAfterUpgrade(singularRunner, "txnpruner", func() (worker.Worker, error) {
 				return txnpruner.New(st, time.Hour, clock.WallClock), nil
 			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "backupscheduler", func() (worker.Worker, error) {
+				paths := backups.Paths{
+					DataDir: agentConfig.DataDir(),
+					LogsDir: agentConfig.LogDir(),
+				}
+				return backupscheduler.New(backupscheduler.Config{
+					Clock:   clock.WallClock,
+					Source:  st,
+					Backups: backupscheduler.NewStateBackups(st, paths, a.machineId),
+					Status:  st,
+				})
+			})
 		default:
 			return nil, errors.Errorf("unknown job type %q", job)
 		}
@@ -1117,6 +1148,7 @@ func (a *MachineAgent) startModelWorkers(controllerUUID, modelUUID string) (work
 		CharmRevisionUpdateInterval: 24 * time.Hour,
 		InstPollerAggregationDelay:  3 * time.Second,
 		StatusHistoryPrunerInterval: 5 * time.Minute,
+		ActionPrunerInterval:        5 * time.Minute,
 		SpacesImportedGate:          a.discoverSpacesComplete,
 		NewEnvironFunc:              newEnvirons,
 		NewMigrationMaster:          migrationmaster.NewWorker,
@@ -1192,17 +1224,29 @@ func (a *MachineAgent) newAPIserverWorker(
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot fetch the controller config")
 	}
+	a.auditingEnabled.Set(controllerConfig.AuditingEnabled())
 
+	auditOrigin := logfwd.OriginForMachineAgent(
+		names.NewMachineTag(a.machineId),
+		controllerConfig.ControllerUUID(),
+		agentConfig.Model().Id(),
+		jujuversion.Current,
+	)
+	auditEntrySink, closeAuditEntrySink, err := newAuditEntrySink(st, logDir, controllerConfig, auditOrigin)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create audit entry sink")
+	}
 	newObserver, err := newObserverFn(
-		controllerConfig,
+		a.isAuditingEnabled,
 		clock.WallClock,
 		jujuversion.Current,
 		agentConfig.Model().Id(),
-		newAuditEntrySink(st, logDir),
+		auditEntrySink,
 		auditErrorHandler,
 		a.prometheusRegistry,
 	)
 	if err != nil {
+		closeAuditEntrySink()
 		return nil, errors.Annotate(err, "cannot create RPC observer factory")
 	}
 	statePool := state.NewStatePool(st)
@@ -1235,38 +1279,155 @@ func (a *MachineAgent) newAPIserverWorker(
 		RegisterIntrospectionHandlers: registerIntrospectionHandlers,
 	})
 	if err != nil {
+		closeAuditEntrySink()
 		return nil, errors.Annotate(err, "cannot start api server worker")
 	}
+	go func() {
+		server.Wait()
+		closeAuditEntrySink()
+	}()
 
 	return server, nil
 }
 
-func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
+// newAuditEntrySink returns the sink that records the audit entries
+// generated by the API server, and a func that releases the resources
+// used by the sink. Entries are written to the audit log files and,
+// if enabled in the controller config, queued to be forwarded to the
+// controller model's syslog host by a background worker. Only entries
+// for user actions are recorded in the database.
+func newAuditEntrySink(
+	st *state.State,
+	logDir string,
+	controllerConfig controller.Config,
+	origin logfwd.Origin,
+) (audit.AuditEntrySinkFn, func(), error) {
 	persistFn := st.PutAuditEntryFn()
-	fileSinkFn := audit.NewLogFileSink(logDir)
-	return func(entry audit.AuditEntry) error {
-		// We don't care about auditing anything but user actions.
+	persist := func(entry audit.AuditEntry) error {
 		if _, err := names.ParseUserTag(entry.OriginName); err != nil {
 			return nil
 		}
+		return errors.Annotate(persistFn(entry), "cannot save audit record to database")
+	}
+	sinks := []audit.AuditEntrySinkFn{
+		persist,
+		annotateAuditSink(audit.NewLogFileSink(logDir), "cannot save audit record to file"),
+		annotateAuditSink(audit.NewJSONLogFileSink(logDir), "cannot save audit record to JSON file"),
+	}
+	closeSinks := func() {}
+	if controllerConfig.AuditLogForwardingEnabled() {
+		forwarder, err := audit.NewForwardingSink(audit.ForwardingConfig{
+			Open: func() (audit.RecordSender, error) {
+				return openAuditSyslogClient(st)
+			},
+			Origin:        origin,
+			Clock:         clock.WallClock,
+			BufferSize:    auditForwardingBufferSize,
+			RetryDelay:    auditForwardingRetryDelay,
+			MaxRetryDelay: auditForwardingMaxRetryDelay,
+		})
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		sinks = append(sinks, forwarder.Handle)
+		closeSinks = func() {
+			if err := worker.Stop(forwarder); err != nil {
+				logger.Errorf("audit forwarding worker failed: %v", err)
+			}
+			if dropped := forwarder.Dropped(); dropped > 0 {
+				logger.Warningf("dropped %d audit entries that could not be forwarded", dropped)
+			}
+		}
+	}
+	sink := audit.NewMultiSink(sinks...)
+	return func(entry audit.AuditEntry) error {
 		// TODO(wallyworld) - Pinger requests should not originate as a user action.
 		if strings.HasPrefix(entry.Operation, "Pinger:") {
 			return nil
 		}
-		persistErr := persistFn(entry)
-		sinkErr := fileSinkFn(entry)
-		if persistErr == nil {
-			return errors.Annotate(sinkErr, "cannot save audit record to file")
-		}
-		if sinkErr == nil {
-			return errors.Annotate(persistErr, "cannot save audit record to database")
-		}
-		return errors.Annotate(persistErr, "cannot save audit record to file or database")
+		return sink(entry)
+	}, closeSinks, nil
+}
+
+const (
+	// auditForwardingBufferSize is the number of audit entries held
+	// while waiting for the syslog host; entries beyond this are
+	// dropped rather than delaying API requests.
+	auditForwardingBufferSize = 1000
+
+	// auditForwardingRetryDelay and auditForwardingMaxRetryDelay
+	// bound the backoff between attempts to reach the syslog host.
+	auditForwardingRetryDelay    = time.Second
+	auditForwardingMaxRetryDelay = time.Minute
+)
+
+func annotateAuditSink(sink audit.AuditEntrySinkFn, message string) audit.AuditEntrySinkFn {
+	return func(entry audit.AuditEntry) error {
+		return errors.Annotate(sink(entry), message)
 	}
 }
 
+// openAuditSyslogClient connects to the syslog host configured for
+// the controller model.
+func openAuditSyslogClient(st *state.State) (audit.RecordSender, error) {
+	modelConfig, err := st.ModelConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot fetch the controller model config")
+	}
+	syslogConfig, ok := modelConfig.LogFwdSyslog()
+	if !ok || syslogConfig.Host == "" {
+		return nil, errors.New("no syslog host configured for the controller model")
+	}
+	client, err := syslog.Open(*syslogConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return client, nil
+}
+
+// isAuditingEnabled reports whether the controller config currently
+// enables auditing of API requests.
+func (a *MachineAgent) isAuditingEnabled() bool {
+	enabled, _ := a.auditingEnabled.Get().(bool)
+	return enabled
+}
+
+// controllerConfigChanged is called by the controllerconfigupdater
+// worker with the controller config whenever it changes. It updates
+// the auditing state used by the API server, and restarts mongo if
+// the mongo memory profile has changed.
+func (a *MachineAgent) controllerConfigChanged(controllerConfig controller.Config) error {
+	a.auditingEnabled.Set(controllerConfig.AuditingEnabled())
+
+	profile, err := mongo.NewMemoryProfile(controllerConfig.MongoMemoryProfile())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if profile == a.CurrentConfig().MongoMemoryProfile() {
+		return nil
+	}
+	logger.Infof("mongo memory profile changed to %q", profile)
+	err = a.ChangeConfig(func(config agent.ConfigSetter) error {
+		config.SetMongoMemoryProfile(profile)
+		return nil
+	})
+	if err != nil {
+		return errors.Annotate(err, "cannot set mongo memory profile")
+	}
+	return errors.Annotate(a.restartMongoServer(), "cannot apply mongo memory profile")
+}
+
+// restartMongoServer reinstalls the mongo service using the current
+// agent config, restarting mongo if its configuration has changed.
+func (a *MachineAgent) restartMongoServer() error {
+	a.mongoInitMutex.Lock()
+	a.mongoInitialized = false
+	a.mongoInitMutex.Unlock()
+	return a.ensureMongoServer(a.CurrentConfig())
+}
+
 func newObserverFn(
-	controllerConfig controller.Config,
+	auditingEnabled func() bool,
 	clock clock.Clock,
 	jujuServerVersion version.Number,
 	modelUUID string,
@@ -1287,17 +1448,21 @@ func newObserverFn(
 		return observer.NewRequestObserver(ctx)
 	})
 
-	// Auditing observer
+	// Auditing observer. Auditing can be enabled and disabled while
+	// the controller is running, so the controller config is checked
+	// for each new connection.
 	// TODO(katco): Auditing needs feature tests (lp:1604551)
-	if controllerConfig.AuditingEnabled() {
-		observerFactories = append(observerFactories, func() observer.Observer {
-			ctx := &observer.AuditContext{
-				JujuServerVersion: jujuServerVersion,
-				ModelUUID:         modelUUID,
-			}
-			return observer.NewAudit(ctx, persistAuditEntry, auditErrorHandler)
-		})
-	}
+	observerFactories = append(observerFactories, func() observer.Observer {
+		if !auditingEnabled() {
+			return nil
+		}
+		ctx := &observer.AuditContext{
+			JujuServerVersion: jujuServerVersion,
+			ModelUUID:         modelUUID,
+			Clock:             clock,
+		}
+		return observer.NewAudit(ctx, persistAuditEntry, auditErrorHandler)
+	})
 
 	// Metrics observer.
 	metricObserver, err := metricobserver.NewObserverFactory(metricobserver.Config{
@@ -1687,7 +1852,7 @@ var newDeployContext = func(st *apideployer.State, agentConfig agent.Config) dep
 
 func newStateMetricsWorker(st *state.State, registry *prometheus.Registry) worker.Worker {
 	return jworker.NewSimpleWorker(func(stop <-chan struct{}) error {
-		collector := statemetrics.New(statemetrics.NewState(st))
+		collector := statemetrics.New(statemetrics.NewState(st), clock.WallClock)
 		if err := registry.Register(collector); err != nil {
 			return errors.Annotate(err, "registering statemetrics collector")
 		}
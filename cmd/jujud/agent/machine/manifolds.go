@@ -35,6 +35,7 @@ import (
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/deployer"
 	"github.com/juju/juju/worker/diskmanager"
+	"github.com/juju/juju/worker/egressfirewaller"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/hostkeyreporter"
@@ -390,6 +391,17 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			InProcessUpdate: proxyconfig.DefaultConfig.Set,
 		})),
 
+		// The egress firewaller is a leaf worker that restricts outgoing
+		// traffic from the machine to its egress rules, unless the rules
+		// are enforced by the provider.
+		egressFirewallerName: ifNotMigrating(egressfirewaller.Manifold(egressfirewaller.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			NewFacade:     egressfirewaller.NewFacade,
+			NewWorker:     egressfirewaller.NewWorker,
+			RunScript:     egressfirewaller.RunScript,
+		})),
+
 		// The api address updater is a leaf worker that rewrites agent config
 		// as the state server addresses change. We should only need one of
 		// these in a consolidated agent.
@@ -481,7 +493,12 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 			Sinks: []logforwarder.LogSinkSpec{{
 				Name:   "juju-log-forward",
+				Config: sinks.SyslogConfig,
 				OpenFn: sinks.OpenSyslog,
+			}, {
+				Name:   "juju-log-forward-http",
+				Config: sinks.HTTPConfig,
+				OpenFn: sinks.OpenHTTP,
 			}},
 		})),
 	}
@@ -528,6 +545,7 @@ const (
 	loggingConfigUpdaterName = "logging-config-updater"
 	diskManagerName          = "disk-manager"
 	proxyConfigUpdater       = "proxy-config-updater"
+	egressFirewallerName     = "egress-firewaller"
 	apiAddressUpdaterName    = "api-address-updater"
 	machinerName             = "machiner"
 	logSenderName            = "log-sender"
@@ -48,6 +48,7 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"api-config-watcher",
 		"central-hub",
 		"disk-manager",
+		"egress-firewaller",
 		"host-key-reporter",
 		"log-forwarder",
 		"log-sender",
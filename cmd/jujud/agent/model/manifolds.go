@@ -4,6 +4,7 @@
 package model
 
 import (
+	"path/filepath"
 	"time"
 
 	"github.com/juju/utils/clock"
@@ -19,6 +20,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/feature"
 	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/actionpruner"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
@@ -86,6 +88,10 @@ type ManifoldsConfig struct {
 	// behaviour.
 	StatusHistoryPrunerInterval time.Duration
 
+	// ActionPrunerInterval determines how often finished actions are
+	// pruned, and archived if the model is configured to do so.
+	ActionPrunerInterval time.Duration
+
 	// SpacesImportedGate will be unlocked when spaces are known to
 	// have been imported.
 	SpacesImportedGate gate.Lock
@@ -295,6 +301,15 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			// TODO(fwereade): 2016-03-17 lp:1558657
 			NewTimer: jworker.NewTimer,
 		})),
+		actionPrunerName: ifNotMigrating(actionpruner.Manifold(actionpruner.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ArchiveDir:    filepath.Join(config.Agent.CurrentConfig().DataDir(), "action-archive", modelTag.Id()),
+			Clock:         config.Clock,
+			NewWorker:     actionpruner.New,
+			NewFacade:     actionpruner.NewFacade,
+			PruneInterval: config.ActionPrunerInterval,
+			NewTimer:      jworker.NewTimer,
+		})),
 		machineUndertakerName: ifNotMigrating(machineundertaker.Manifold(machineundertaker.ManifoldConfig{
 			APICallerName: apiCallerName,
 			EnvironName:   environTrackerName,
@@ -402,6 +417,7 @@ const (
 	metricWorkerName         = "metric-worker"
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
+	actionPrunerName         = "action-pruner"
 	machineUndertakerName    = "machine-undertaker"
 	remoteRelationsName      = "remote-relations"
 )
@@ -12,9 +12,11 @@ import (
 	"github.com/juju/schema"
 	"github.com/juju/utils"
 	utilscert "github.com/juju/utils/cert"
+	"github.com/juju/utils/set"
 	"gopkg.in/macaroon-bakery.v1/bakery"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/core/cron"
 )
 
 const (
@@ -32,6 +34,11 @@ const (
 	// auditing information.
 	AuditingEnabled = "auditing-enabled"
 
+	// AuditLogForwardingEnabled determines whether the controller
+	// will forward audit entries to the syslog host configured for
+	// the controller model, in addition to recording them locally.
+	AuditLogForwardingEnabled = "audit-log-forwarding-enabled"
+
 	// StatePort is the port used for mongo connections.
 	StatePort = "state-port"
 
@@ -79,12 +86,29 @@ const (
 	// before it is pruned, eg "4M"
 	MaxLogsSize = "max-logs-size"
 
+	// BackupSchedule is a cron-style schedule, eg "0 3 * * *", on
+	// which the controller creates backups of itself. Scheduled
+	// backups are disabled when it is not set.
+	BackupSchedule = "backup-schedule"
+
+	// BackupRetentionCount is the number of scheduled backups to keep;
+	// older ones are removed. Zero means there is no limit.
+	BackupRetentionCount = "backup-retention-count"
+
+	// BackupRetentionAge is the maximum age of scheduled backups before
+	// they are removed, eg "720h". Zero means there is no limit.
+	BackupRetentionAge = "backup-retention-age"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
 	// AuditingEnabled config value.
 	DefaultAuditingEnabled = false
 
+	// DefaultAuditLogForwardingEnabled contains the default value for
+	// the AuditLogForwardingEnabled config value.
+	DefaultAuditLogForwardingEnabled = false
+
 	// DefaultNUMAControlPolicy should not be used by default.
 	// Only use numactl if user specifically requests it
 	DefaultNUMAControlPolicy = false
@@ -104,6 +128,10 @@ const (
 	// DefaultMaxLogCollectionMB is the maximum size the log collection can
 	// grow to before being pruned.
 	DefaultMaxLogCollectionMB = 4 * 1024 // 4 GB
+
+	// DefaultBackupRetentionCount is the number of scheduled backups
+	// kept when no retention count has been configured.
+	DefaultBackupRetentionCount = 7
 )
 
 // ControllerOnlyConfigAttributes are attributes which are only relevant
@@ -111,6 +139,7 @@ const (
 var ControllerOnlyConfigAttributes = []string{
 	AllowModelAccessKey,
 	APIPort,
+	AuditLogForwardingEnabled,
 	AutocertDNSNameKey,
 	AutocertURLKey,
 	CACertKey,
@@ -122,8 +151,24 @@ var ControllerOnlyConfigAttributes = []string{
 	MongoMemoryProfile,
 	MaxLogsSize,
 	MaxLogsAge,
+	BackupSchedule,
+	BackupRetentionCount,
+	BackupRetentionAge,
 }
 
+// AllowedUpdateConfigAttributes contains the controller config
+// attributes that may be changed after the controller has been
+// bootstrapped.
+var AllowedUpdateConfigAttributes = set.NewStrings(
+	AuditingEnabled,
+	BackupRetentionAge,
+	BackupRetentionCount,
+	BackupSchedule,
+	MaxLogsAge,
+	MaxLogsSize,
+	MongoMemoryProfile,
+)
+
 // ControllerOnlyAttribute returns true if the specified attribute name
 // is only relevant for a controller.
 func ControllerOnlyAttribute(attr string) bool {
@@ -160,6 +205,22 @@ func NewConfig(controllerUUID, caCert string, attrs map[string]interface{}) (Con
 	return config, config.Validate()
 }
 
+// ValidateUpdateAttributes checks that the specified attributes may be
+// changed on a running controller, returning an error naming the first
+// attribute that may not.
+func ValidateUpdateAttributes(updateAttrs map[string]interface{}, removeAttrs []string) error {
+	names := set.NewStrings(removeAttrs...)
+	for name := range updateAttrs {
+		names.Add(name)
+	}
+	for _, name := range names.SortedValues() {
+		if !AllowedUpdateConfigAttributes.Contains(name) {
+			return errors.Errorf("can't change %q after bootstrap", name)
+		}
+	}
+	return nil
+}
+
 // mustInt returns the named attribute as an integer, panicking if
 // it is not found or is zero. Zero values should have been
 // diagnosed at Validate time.
@@ -212,6 +273,15 @@ func (c Config) AuditingEnabled() bool {
 	return false
 }
 
+// AuditLogForwardingEnabled returns whether audit entries are to be
+// forwarded to the controller model's syslog host. The default is false.
+func (c Config) AuditLogForwardingEnabled() bool {
+	if v, ok := c[AuditLogForwardingEnabled]; ok {
+		return v.(bool)
+	}
+	return DefaultAuditLogForwardingEnabled
+}
+
 // ControllerUUID returns the uuid for the model's controller.
 func (c Config) ControllerUUID() string {
 	return c.mustString(ControllerUUIDKey)
@@ -299,6 +369,42 @@ func (c Config) MaxLogSizeMB() int {
 	return int(val)
 }
 
+// BackupSchedule returns the schedule on which the controller backs
+// itself up, and whether scheduled backups are enabled.
+func (c Config) BackupSchedule() (*cron.Schedule, bool) {
+	spec := c.asString(BackupSchedule)
+	if spec == "" {
+		return nil, false
+	}
+	// Value has already been validated.
+	schedule, err := cron.Parse(spec)
+	if err != nil {
+		return nil, false
+	}
+	return schedule, true
+}
+
+// BackupRetentionCount returns the number of scheduled backups to
+// keep. Zero means there is no limit.
+func (c Config) BackupRetentionCount() int {
+	// Values obtained over the api are encoded as float64.
+	switch value := c[BackupRetentionCount].(type) {
+	case float64:
+		return int(value)
+	case int:
+		return value
+	}
+	return DefaultBackupRetentionCount
+}
+
+// BackupRetentionAge returns the maximum age of scheduled backups
+// before they are removed. Zero means there is no limit.
+func (c Config) BackupRetentionAge() time.Duration {
+	// Value has already been validated.
+	val, _ := time.ParseDuration(c.asString(BackupRetentionAge))
+	return val
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -352,6 +458,26 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[BackupSchedule].(string); ok && v != "" {
+		if _, err := cron.Parse(v); err != nil {
+			return errors.Annotate(err, "invalid backup schedule in configuration")
+		}
+	}
+
+	if c.BackupRetentionCount() < 0 {
+		return errors.Errorf("%s: expected a non-negative number, got %d", BackupRetentionCount, c.BackupRetentionCount())
+	}
+
+	if v, ok := c[BackupRetentionAge].(string); ok && v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid backup retention age in configuration")
+		}
+		if d < 0 {
+			return errors.Errorf("%s: expected a non-negative duration, got %q", BackupRetentionAge, v)
+		}
+	}
+
 	return nil
 }
 
@@ -362,29 +488,37 @@ func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string)
 }
 
 var configChecker = schema.FieldMap(schema.Fields{
-	AuditingEnabled:         schema.Bool(),
-	APIPort:                 schema.ForceInt(),
-	StatePort:               schema.ForceInt(),
-	IdentityURL:             schema.String(),
-	IdentityPublicKey:       schema.String(),
-	SetNUMAControlPolicyKey: schema.Bool(),
-	AutocertURLKey:          schema.String(),
-	AutocertDNSNameKey:      schema.String(),
-	AllowModelAccessKey:     schema.Bool(),
-	MongoMemoryProfile:      schema.String(),
-	MaxLogsAge:              schema.String(),
-	MaxLogsSize:             schema.String(),
+	AuditingEnabled:           schema.Bool(),
+	AuditLogForwardingEnabled: schema.Bool(),
+	APIPort:                   schema.ForceInt(),
+	StatePort:                 schema.ForceInt(),
+	IdentityURL:               schema.String(),
+	IdentityPublicKey:         schema.String(),
+	SetNUMAControlPolicyKey:   schema.Bool(),
+	AutocertURLKey:            schema.String(),
+	AutocertDNSNameKey:        schema.String(),
+	AllowModelAccessKey:       schema.Bool(),
+	MongoMemoryProfile:        schema.String(),
+	MaxLogsAge:                schema.String(),
+	MaxLogsSize:               schema.String(),
+	BackupSchedule:            schema.String(),
+	BackupRetentionCount:      schema.ForceInt(),
+	BackupRetentionAge:        schema.String(),
 }, schema.Defaults{
-	APIPort:                 DefaultAPIPort,
-	AuditingEnabled:         DefaultAuditingEnabled,
-	StatePort:               DefaultStatePort,
-	IdentityURL:             schema.Omit,
-	IdentityPublicKey:       schema.Omit,
-	SetNUMAControlPolicyKey: DefaultNUMAControlPolicy,
-	AutocertURLKey:          schema.Omit,
-	AutocertDNSNameKey:      schema.Omit,
-	AllowModelAccessKey:     schema.Omit,
-	MongoMemoryProfile:      schema.Omit,
-	MaxLogsAge:              fmt.Sprintf("%vh", DefaultMaxLogsAgeDays*24),
-	MaxLogsSize:             fmt.Sprintf("%vM", DefaultMaxLogCollectionMB),
+	APIPort:                   DefaultAPIPort,
+	AuditingEnabled:           DefaultAuditingEnabled,
+	AuditLogForwardingEnabled: DefaultAuditLogForwardingEnabled,
+	StatePort:                 DefaultStatePort,
+	IdentityURL:               schema.Omit,
+	IdentityPublicKey:         schema.Omit,
+	SetNUMAControlPolicyKey:   DefaultNUMAControlPolicy,
+	AutocertURLKey:            schema.Omit,
+	AutocertDNSNameKey:        schema.Omit,
+	AllowModelAccessKey:       schema.Omit,
+	MongoMemoryProfile:        schema.Omit,
+	MaxLogsAge:                fmt.Sprintf("%vh", DefaultMaxLogsAgeDays*24),
+	MaxLogsSize:               fmt.Sprintf("%vM", DefaultMaxLogCollectionMB),
+	BackupSchedule:            schema.Omit,
+	BackupRetentionCount:      schema.Omit,
+	BackupRetentionAge:        schema.Omit,
 })
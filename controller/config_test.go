@@ -121,6 +121,34 @@ var validateTests = []struct {
 		controller.CACertKey:         testing.CACert,
 	},
 	expectError: `invalid identity public key: wrong length for base64 key, got 3 want 32`,
+}, {
+	about: "invalid backup schedule",
+	config: controller.Config{
+		controller.CACertKey:      testing.CACert,
+		controller.BackupSchedule: "0 25 * * *",
+	},
+	expectError: `invalid backup schedule in configuration: schedule "0 25 \* \* \*": hour "25" not valid`,
+}, {
+	about: "negative backup retention count",
+	config: controller.Config{
+		controller.CACertKey:            testing.CACert,
+		controller.BackupRetentionCount: -1,
+	},
+	expectError: `backup-retention-count: expected a non-negative number, got -1`,
+}, {
+	about: "invalid backup retention age",
+	config: controller.Config{
+		controller.CACertKey:          testing.CACert,
+		controller.BackupRetentionAge: "a week",
+	},
+	expectError: `invalid backup retention age in configuration: time: invalid duration .*`,
+}, {
+	about: "negative backup retention age",
+	config: controller.Config{
+		controller.CACertKey:          testing.CACert,
+		controller.BackupRetentionAge: "-1h",
+	},
+	expectError: `backup-retention-age: expected a non-negative duration, got "-1h"`,
 }}
 
 func (s *ConfigSuite) TestValidate(c *gc.C) {
@@ -155,3 +183,69 @@ func (s *ConfigSuite) TestLogConfigValues(c *gc.C) {
 	c.Assert(cfg.MaxLogsAge(), gc.Equals, 96*time.Hour)
 	c.Assert(cfg.MaxLogSizeMB(), gc.Equals, 8192)
 }
+
+func (s *ConfigSuite) TestBackupConfigDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := cfg.BackupSchedule()
+	c.Assert(ok, jc.IsFalse)
+	c.Assert(cfg.BackupRetentionCount(), gc.Equals, controller.DefaultBackupRetentionCount)
+	c.Assert(cfg.BackupRetentionAge(), gc.Equals, time.Duration(0))
+}
+
+func (s *ConfigSuite) TestBackupConfigValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-schedule":        "0 3 * * *",
+			"backup-retention-count": 0,
+			"backup-retention-age":   "720h",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	schedule, ok := cfg.BackupSchedule()
+	c.Assert(ok, jc.IsTrue)
+	from := time.Date(2017, 3, 15, 10, 0, 0, 0, time.UTC)
+	c.Assert(schedule.Next(from), gc.Equals, time.Date(2017, 3, 16, 3, 0, 0, 0, time.UTC))
+	c.Assert(cfg.BackupRetentionCount(), gc.Equals, 0)
+	c.Assert(cfg.BackupRetentionAge(), gc.Equals, 720*time.Hour)
+}
+
+func (s *ConfigSuite) TestAuditLogForwardingEnabled(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogForwardingEnabled(), jc.IsFalse)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"audit-log-forwarding-enabled": true,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogForwardingEnabled(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestValidateUpdateAttributes(c *gc.C) {
+	err := controller.ValidateUpdateAttributes(map[string]interface{}{
+		controller.AuditingEnabled:    true,
+		controller.MaxLogsAge:         "12h",
+		controller.MaxLogsSize:        "1G",
+		controller.MongoMemoryProfile: "default",
+		controller.BackupSchedule:     "@daily",
+	}, []string{controller.MaxLogsAge, controller.BackupRetentionAge})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestValidateUpdateAttributesNotAllowed(c *gc.C) {
+	err := controller.ValidateUpdateAttributes(map[string]interface{}{
+		controller.MaxLogsAge: "12h",
+		controller.APIPort:    1234,
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `can't change "api-port" after bootstrap`)
+
+	err = controller.ValidateUpdateAttributes(nil, []string{controller.CACertKey})
+	c.Assert(err, gc.ErrorMatches, `can't change "ca-cert" after bootstrap`)
+}
@@ -11,6 +11,10 @@ import (
 // JujuRunActionName defines the action name used by juju-run.
 const JujuRunActionName = "juju-run"
 
+// JujuCheckNetworkActionName defines the action name used by
+// check-network to probe the addresses of related units.
+const JujuCheckNetworkActionName = "juju-check-network"
+
 // PredefinedActionsSpec defines a spec for each predefined action.
 var PredefinedActionsSpec = map[string]charm.ActionSpec{
 	JujuRunActionName: charm.ActionSpec{
@@ -32,4 +36,41 @@ var PredefinedActionsSpec = map[string]charm.ActionSpec{
 			},
 		},
 	},
+	JujuCheckNetworkActionName: charm.ActionSpec{
+		Description: "predefined juju-check-network action",
+		Params: map[string]interface{}{
+			"type":        "object",
+			"title":       JujuCheckNetworkActionName,
+			"description": "predefined juju-check-network action params",
+			"required":    []interface{}{"targets", "timeout"},
+			"properties": map[string]interface{}{
+				"targets": map[string]interface{}{
+					"type":        "array",
+					"description": "related units to probe",
+					"items": map[string]interface{}{
+						"type":     "object",
+						"required": []interface{}{"unit", "relation", "address"},
+						"properties": map[string]interface{}{
+							"unit": map[string]interface{}{
+								"type":        "string",
+								"description": "name of the related unit",
+							},
+							"relation": map[string]interface{}{
+								"type":        "string",
+								"description": "key of the relation to the unit",
+							},
+							"address": map[string]interface{}{
+								"type":        "string",
+								"description": "ingress address of the unit on the relation's space",
+							},
+						},
+					},
+				},
+				"timeout": map[string]interface{}{
+					"type":        "number",
+					"description": "timeout for each probe",
+				},
+			},
+		},
+	},
 }
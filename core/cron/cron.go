@@ -0,0 +1,196 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cron parses cron-style schedule expressions and computes
+// the times at which they fire.
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Schedule is a parsed cron schedule. Schedules are evaluated in the
+// location of the time passed to Next.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// domAny and dowAny record whether the day-of-month and
+	// day-of-week fields were "*". When both fields are restricted,
+	// a day matches if either field matches, as in cron(8).
+	domAny, dowAny bool
+}
+
+type fieldRange struct {
+	name     string
+	min, max int
+}
+
+var (
+	minuteRange = fieldRange{"minute", 0, 59}
+	hourRange   = fieldRange{"hour", 0, 23}
+	domRange    = fieldRange{"day of month", 1, 31}
+	monthRange  = fieldRange{"month", 1, 12}
+	dowRange    = fieldRange{"day of week", 0, 7}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses a schedule in the standard five-field cron format:
+//
+//	minute hour day-of-month month day-of-week
+//
+// Each field may be "*", a number, a range "a-b", or a comma-separated
+// list of these, and any "*" or range may be followed by "/step". Day
+// of week runs from 0 (Sunday) to 7 (also Sunday). The descriptors
+// @yearly, @annually, @monthly, @weekly, @daily, @midnight and @hourly
+// are also accepted.
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if expanded, ok := descriptors[spec]; ok {
+		spec = expanded
+	} else if strings.HasPrefix(spec, "@") {
+		return nil, errors.NotValidf("schedule descriptor %q", spec)
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, errors.Errorf("schedule %q: expected 5 fields, got %d", spec, len(fields))
+	}
+	var s Schedule
+	var err error
+	if s.minute, err = parseField(fields[0], minuteRange); err != nil {
+		return nil, errors.Annotatef(err, "schedule %q", spec)
+	}
+	if s.hour, err = parseField(fields[1], hourRange); err != nil {
+		return nil, errors.Annotatef(err, "schedule %q", spec)
+	}
+	if s.dom, err = parseField(fields[2], domRange); err != nil {
+		return nil, errors.Annotatef(err, "schedule %q", spec)
+	}
+	if s.month, err = parseField(fields[3], monthRange); err != nil {
+		return nil, errors.Annotatef(err, "schedule %q", spec)
+	}
+	if s.dow, err = parseField(fields[4], dowRange); err != nil {
+		return nil, errors.Annotatef(err, "schedule %q", spec)
+	}
+	// Sunday may be written as 0 or 7.
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domAny = strings.HasPrefix(fields[2], "*")
+	s.dowAny = strings.HasPrefix(fields[4], "*")
+	return &s, nil
+}
+
+// parseField returns the set of values matched by a single field, as a
+// bitmask.
+func parseField(field string, r fieldRange) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, errors.NotValidf("%s step %q", r.name, part[i+1:])
+			}
+			part = part[:i]
+		}
+		var lo, hi int
+		switch {
+		case part == "*":
+			lo, hi = r.min, r.max
+		case strings.Contains(part, "-"):
+			i := strings.Index(part, "-")
+			var err error
+			if lo, err = parseValue(part[:i], r); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if hi, err = parseValue(part[i+1:], r); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if hi < lo {
+				return 0, errors.NotValidf("%s range %q", r.name, part)
+			}
+		default:
+			var err error
+			if lo, err = parseValue(part, r); err != nil {
+				return 0, errors.Trace(err)
+			}
+			hi = lo
+			if step != 1 {
+				// "n/step" means from n to the end of the range.
+				hi = r.max
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseValue(s string, r fieldRange) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < r.min || v > r.max {
+		return 0, errors.NotValidf("%s %q", r.name, s)
+	}
+	return v, nil
+}
+
+// maxSearch bounds the search for the next matching time, so that
+// schedules that can never fire (such as "0 0 31 2 *") do not loop
+// forever.
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+// Next returns the first time after t at which the schedule fires. If
+// the schedule never fires, the zero time is returned.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxSearch)
+	for t.Before(limit) {
+		if !s.has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.has(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if !s.has(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.has(s.dom, t.Day())
+	dowMatch := s.has(s.dow, int(t.Weekday()))
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func (s *Schedule) has(bits uint64, v int) bool {
+	return bits&(1<<uint(v)) != 0
+}
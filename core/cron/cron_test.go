@@ -0,0 +1,139 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cron_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cron"
+)
+
+type CronSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CronSuite{})
+
+func (s *CronSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{{
+		spec: "",
+		err:  `schedule "": expected 5 fields, got 0`,
+	}, {
+		spec: "* * * *",
+		err:  `schedule "\* \* \* \*": expected 5 fields, got 4`,
+	}, {
+		spec: "@fortnightly",
+		err:  `schedule descriptor "@fortnightly" not valid`,
+	}, {
+		spec: "60 * * * *",
+		err:  `schedule "60 \* \* \* \*": minute "60" not valid`,
+	}, {
+		spec: "* 24 * * *",
+		err:  `schedule "\* 24 \* \* \*": hour "24" not valid`,
+	}, {
+		spec: "* * 0 * *",
+		err:  `schedule "\* \* 0 \* \*": day of month "0" not valid`,
+	}, {
+		spec: "* * * 13 *",
+		err:  `schedule "\* \* \* 13 \*": month "13" not valid`,
+	}, {
+		spec: "* * * * 8",
+		err:  `schedule "\* \* \* \* 8": day of week "8" not valid`,
+	}, {
+		spec: "5-1 * * * *",
+		err:  `schedule "5-1 \* \* \* \*": minute range "5-1" not valid`,
+	}, {
+		spec: "*/0 * * * *",
+		err:  `schedule "\*/0 \* \* \* \*": minute step "0" not valid`,
+	}, {
+		spec: "x * * * *",
+		err:  `schedule "x \* \* \* \*": minute "x" not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := cron.Parse(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *CronSuite) TestNext(c *gc.C) {
+	// 2017-03-15 is a Wednesday.
+	from := time.Date(2017, 3, 15, 10, 30, 45, 0, time.UTC)
+	for i, test := range []struct {
+		spec string
+		next time.Time
+	}{{
+		spec: "* * * * *",
+		next: time.Date(2017, 3, 15, 10, 31, 0, 0, time.UTC),
+	}, {
+		spec: "@hourly",
+		next: time.Date(2017, 3, 15, 11, 0, 0, 0, time.UTC),
+	}, {
+		spec: "@daily",
+		next: time.Date(2017, 3, 16, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec: "@weekly",
+		next: time.Date(2017, 3, 19, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec: "@monthly",
+		next: time.Date(2017, 4, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec: "@yearly",
+		next: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec: "*/15 * * * *",
+		next: time.Date(2017, 3, 15, 10, 45, 0, 0, time.UTC),
+	}, {
+		spec: "30 2 * * *",
+		next: time.Date(2017, 3, 16, 2, 30, 0, 0, time.UTC),
+	}, {
+		spec: "0 9-17/4 * * *",
+		next: time.Date(2017, 3, 15, 13, 0, 0, 0, time.UTC),
+	}, {
+		spec: "0 0 * * 1,5",
+		next: time.Date(2017, 3, 17, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec: "0 0 * * 7",
+		next: time.Date(2017, 3, 19, 0, 0, 0, 0, time.UTC),
+	}, {
+		// When both day fields are restricted, either may match.
+		spec: "0 0 20 * 5",
+		next: time.Date(2017, 3, 17, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec: "0 0 31 * *",
+		next: time.Date(2017, 3, 31, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec: "0 0 29 2 *",
+		next: time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec: "0 0 31 2 *",
+		next: time.Time{},
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		schedule, err := cron.Parse(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(schedule.Next(from), gc.Equals, test.next)
+	}
+}
+
+func (s *CronSuite) TestNextIsAfter(c *gc.C) {
+	schedule, err := cron.Parse("0 * * * *")
+	c.Assert(err, jc.ErrorIsNil)
+	from := time.Date(2017, 3, 15, 10, 0, 0, 0, time.UTC)
+	c.Assert(schedule.Next(from), gc.Equals, from.Add(time.Hour))
+}
+
+func (s *CronSuite) TestNextUsesLocation(c *gc.C) {
+	loc := time.FixedZone("IST", 5*60*60+30*60)
+	schedule, err := cron.Parse("0 3 * * *")
+	c.Assert(err, jc.ErrorIsNil)
+	from := time.Date(2017, 3, 15, 2, 50, 0, 0, loc)
+	c.Assert(schedule.Next(from), gc.Equals, time.Date(2017, 3, 15, 3, 0, 0, 0, loc))
+}
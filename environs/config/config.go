@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
+	logfwdhttp "github.com/juju/juju/logfwd/http"
 	"github.com/juju/juju/logfwd/syslog"
 )
 
@@ -104,6 +105,10 @@ const (
 	// the network for containers.
 	NetBondReconfigureDelayKey = "net-bond-reconfigure-delay"
 
+	// PreferredAddressFamilyKey is the key for the address family,
+	// "ipv4" or "ipv6", preferred by the model's agents.
+	PreferredAddressFamilyKey = "preferred-address-family"
+
 	// The default block storage source.
 	StorageDefaultBlockSourceKey = "storage-default-block-source"
 
@@ -129,6 +134,22 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogFwdHTTPURL sets the URL to which log records are POSTed
+	// when forwarding logs over HTTP.
+	LogFwdHTTPURL = "logforward-http-url"
+
+	// LogFwdHTTPCACert sets the certificate of the CA that signed the
+	// HTTP log forwarding server certificate.
+	LogFwdHTTPCACert = "logforward-http-ca-cert"
+
+	// LogFwdHTTPClientCert sets the client certificate for HTTP log
+	// forwarding.
+	LogFwdHTTPClientCert = "logforward-http-client-cert"
+
+	// LogFwdHTTPClientKey sets the client key for HTTP log
+	// forwarding.
+	LogFwdHTTPClientKey = "logforward-http-client-key"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -149,6 +170,21 @@ const (
 	// collection can grow to before it is pruned, eg "5M"
 	MaxStatusHistorySize = "max-status-history-size"
 
+	// MaxActionResultsAge is the maximum age of completed, failed and
+	// cancelled actions to keep when pruning, eg "72h"
+	MaxActionResultsAge = "max-action-results-age"
+
+	// MaxActionResultsSize is the maximum size the actions collection
+	// can grow to before finished actions are pruned, eg "5M"
+	MaxActionResultsSize = "max-action-results-size"
+
+	// ArchiveActionResults is the key for whether pruned actions are
+	// archived to JSON files on the controller before being removed.
+	// The files are written to action-archive/<model-uuid> in the data
+	// directory of the controller machine doing the pruning, and are
+	// pruned to the same age and size as action results.
+	ArchiveActionResults = "archive-action-results"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -303,6 +339,12 @@ const (
 
 	// DefaultStatusHistorySize is the default value for MaxStatusHistorySize.
 	DefaultStatusHistorySize = "5G"
+
+	// DefaultActionResultsAge is the default value for MaxActionResultsAge.
+	DefaultActionResultsAge = "336h" // 2 weeks
+
+	// DefaultActionResultsSize is the default value for MaxActionResultsSize.
+	DefaultActionResultsSize = "5G"
 )
 
 var defaultConfigValues = map[string]interface{}{
@@ -312,6 +354,7 @@ var defaultConfigValues = map[string]interface{}{
 	IgnoreMachineAddresses:       false,
 	"ssl-hostname-verification":  true,
 	"proxy-ssh":                  false,
+	PreferredAddressFamilyKey:    "ipv4",
 
 	// Why is net-bond-reconfigure-delay set to 17 seconds?
 	//
@@ -366,6 +409,11 @@ var defaultConfigValues = map[string]interface{}{
 	// Status history settings
 	MaxStatusHistoryAge:  DefaultStatusHistoryAge,
 	MaxStatusHistorySize: DefaultStatusHistorySize,
+
+	// Action pruning settings
+	MaxActionResultsAge:  DefaultActionResultsAge,
+	MaxActionResultsSize: DefaultActionResultsSize,
+	ArchiveActionResults: false,
 }
 
 // ConfigDefaults returns the config default values
@@ -488,11 +536,19 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
-	if lfCfg, ok := cfg.LogFwdSyslog(); ok {
+	// Log forwarding may be enabled for HTTP alone, in which case
+	// the syslog host need not be set.
+	httpCfg, hasHTTPCfg := cfg.LogFwdHTTP()
+	if lfCfg, ok := cfg.LogFwdSyslog(); ok && (lfCfg.Host != "" || !hasHTTPCfg) {
 		if err := lfCfg.Validate(); err != nil {
 			return errors.Annotate(err, "invalid syslog forwarding config")
 		}
 	}
+	if hasHTTPCfg {
+		if err := httpCfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid HTTP log forwarding config")
+		}
+	}
 
 	if uuid := cfg.UUID(); !utils.IsValidUUIDString(uuid) {
 		return errors.Errorf("uuid: expected UUID, got string(%q)", uuid)
@@ -515,6 +571,18 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[MaxActionResultsAge].(string); ok {
+		if _, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid max action results age in model configuration")
+		}
+	}
+
+	if v, ok := cfg.defined[MaxActionResultsSize].(string); ok {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotate(err, "invalid max action results size in model configuration")
+		}
+	}
+
 	// Check the immutable config values.  These can't change
 	if old != nil {
 		for _, attr := range immutableAttributes {
@@ -748,6 +816,41 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogFwdHTTP returns the HTTP log forwarding config. It reports
+// false if none of the HTTP-specific attributes are set.
+func (c *Config) LogFwdHTTP() (*logfwdhttp.RawConfig, bool) {
+	partial := false
+	var lfCfg logfwdhttp.RawConfig
+
+	if s, ok := c.defined[LogFwdHTTPURL]; ok && s != "" {
+		partial = true
+		lfCfg.URL = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdHTTPCACert]; ok && s != "" {
+		partial = true
+		lfCfg.CACert = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdHTTPClientCert]; ok && s != "" {
+		partial = true
+		lfCfg.ClientCert = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdHTTPClientKey]; ok && s != "" {
+		partial = true
+		lfCfg.ClientKey = s.(string)
+	}
+
+	if !partial {
+		return nil, false
+	}
+	if s, ok := c.defined[LogForwardEnabled]; ok {
+		lfCfg.Enabled = s.(bool)
+	}
+	return &lfCfg, true
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -755,6 +858,15 @@ func (c *Config) FirewallMode() string {
 	return c.mustString("firewall-mode")
 }
 
+// PreferredAddressFamily returns the address family, "ipv4" or "ipv6",
+// preferred when selecting addresses for agents to connect to.
+func (c *Config) PreferredAddressFamily() string {
+	if family, ok := c.defined[PreferredAddressFamilyKey].(string); ok && family != "" {
+		return family
+	}
+	return "ipv4"
+}
+
 // AgentVersion returns the proposed version number for the agent tools,
 // and whether it has been set. Once an environment is bootstrapped, this
 // must always be valid.
@@ -952,6 +1064,39 @@ func (c *Config) MaxStatusHistorySizeMB() uint {
 	return uint(val)
 }
 
+// MaxActionResultsAge is the maximum age of completed, failed and
+// cancelled actions before being pruned.
+func (c *Config) MaxActionResultsAge() time.Duration {
+	// Models created before action pruning may not have a value.
+	v, _ := c.defined[MaxActionResultsAge].(string)
+	if v == "" {
+		v = DefaultActionResultsAge
+	}
+	// Value has already been validated.
+	val, _ := time.ParseDuration(v)
+	return val
+}
+
+// MaxActionResultsSizeMB is the maximum size in MiB which the actions
+// collection can grow to before finished actions are pruned.
+func (c *Config) MaxActionResultsSizeMB() uint {
+	// Models created before action pruning may not have a value.
+	v, _ := c.defined[MaxActionResultsSize].(string)
+	if v == "" {
+		v = DefaultActionResultsSize
+	}
+	// Value has already been validated.
+	val, _ := utils.ParseSize(v)
+	return uint(val)
+}
+
+// ArchiveActionResults reports whether pruned actions are archived
+// before being removed.
+func (c *Config) ArchiveActionResults() bool {
+	value, _ := c.defined[ArchiveActionResults].(bool)
+	return value
+}
+
 // UnknownAttrs returns a copy of the raw configuration attributes
 // that are supposedly specific to the environment type. They could
 // also be wrong attributes, though. Only the specific environment
@@ -1022,12 +1167,17 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:     schema.Omit,
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
+	LogFwdHTTPURL:          schema.Omit,
+	LogFwdHTTPCACert:       schema.Omit,
+	LogFwdHTTPClientCert:   schema.Omit,
+	LogFwdHTTPClientKey:    schema.Omit,
 
 	// Storage related config.
 	// Environ providers will specify their own defaults.
 	StorageDefaultBlockSourceKey: schema.Omit,
 
 	"firewall-mode":              schema.Omit,
+	PreferredAddressFamilyKey:    schema.Omit,
 	"logging-config":             schema.Omit,
 	ProvisionerHarvestModeKey:    schema.Omit,
 	HTTPProxyKey:                 schema.Omit,
@@ -1059,6 +1209,9 @@ var alwaysOptional = schema.Defaults{
 	NetBondReconfigureDelayKey:   schema.Omit,
 	MaxStatusHistoryAge:          schema.Omit,
 	MaxStatusHistorySize:         schema.Omit,
+	MaxActionResultsAge:          schema.Omit,
+	MaxActionResultsSize:         schema.Omit,
+	ArchiveActionResults:         schema.Omit,
 }
 
 func allowEmpty(attr string) bool {
@@ -1092,6 +1245,7 @@ var immutableAttributes = []string{
 	TypeKey,
 	UUIDKey,
 	"firewall-mode",
+	PreferredAddressFamilyKey,
 }
 
 var (
@@ -1300,6 +1454,20 @@ global or per instance security groups.`,
 		Immutable: true,
 		Group:     environschema.EnvironGroup,
 	},
+	PreferredAddressFamilyKey: {
+		Description: `The address family preferred by the model's agents.
+
+'ipv4' prefers IPv4 addresses when agents select addresses to connect
+to, falling back to IPv6 addresses if there are none.
+
+'ipv6' prefers IPv6 addresses instead, which is required for IPv6-only
+networks. Exposed applications are then opened to both IPv4 and IPv6
+sources.`,
+		Type:      environschema.Tstring,
+		Values:    []interface{}{"ipv4", "ipv6"},
+		Immutable: true,
+		Group:     environschema.EnvironGroup,
+	},
 	FTPProxyKey: {
 		Description: "The FTP proxy value to configure on instances, in the FTP_PROXY environment variable",
 		Type:        environschema.Tstring,
@@ -1361,7 +1529,7 @@ global or per instance security groups.`,
 		Group:       environschema.EnvironGroup,
 	},
 	LogForwardEnabled: {
-		Description: `Whether syslog forwarding is enabled.`,
+		Description: `Whether log forwarding is enabled.`,
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
@@ -1385,6 +1553,26 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogFwdHTTPURL: {
+		Description: `The http or https URL to which batches of log records are POSTed as JSON.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPCACert: {
+		Description: `The certificate of the CA that signed the HTTP log forwarding server certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPClientCert: {
+		Description: `The HTTP log forwarding client certificate in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPClientKey: {
+		Description: `The HTTP log forwarding client key in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -1440,4 +1628,19 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	MaxActionResultsAge: {
+		Description: "The maximum age for completed, failed and cancelled actions before they are pruned, in human-readable time format",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxActionResultsSize: {
+		Description: "The maximum size for the actions collection before finished actions are pruned, in human-readable memory format",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	ArchiveActionResults: {
+		Description: "Whether pruned actions are archived to JSON files before they are removed. The files are written to /var/lib/juju/action-archive/<model-uuid> on the controller machine pruning the actions, which may vary over time with HA controllers, and are themselves pruned to max-action-results-age and max-action-results-size",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 }
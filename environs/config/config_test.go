@@ -286,6 +286,19 @@ var configTests = []configTest{
 			"firewall-mode": "illegal",
 		}),
 		err: `firewall-mode: expected one of \[instance global none\], got "illegal"`,
+	}, {
+		about:       "IPv6 preferred address family",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"preferred-address-family": "ipv6",
+		}),
+	}, {
+		about:       "Illegal preferred address family",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"preferred-address-family": "ipv5",
+		}),
+		err: `preferred-address-family: expected one of \[ipv4 ipv6\], got "ipv5"`,
 	}, {
 		about:       "ssl-hostname-verification off",
 		useDefaults: config.UseDefaults,
@@ -550,6 +563,34 @@ var configTests = []configTest{
 			"syslog-client-cert": testing.ServerCert,
 			"syslog-client-key":  testing.ServerKey,
 		}),
+	}, {
+		about:       "Valid HTTP log forwarding config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":          true,
+			"logforward-http-url":         "https://logs.example.com/juju",
+			"logforward-http-ca-cert":     testing.CACert,
+			"logforward-http-client-cert": testing.ServerCert,
+			"logforward-http-client-key":  testing.ServerKey,
+		}),
+	}, {
+		about:       "Invalid HTTP log forwarding URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":  true,
+			"logforward-http-url": "tcp://logs.example.com",
+		}),
+		err: `invalid HTTP log forwarding config: URL scheme "tcp" not valid`,
+	}, {
+		about:       "Mismatched HTTP log forwarding cert and key",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":          true,
+			"logforward-http-url":         "https://logs.example.com/juju",
+			"logforward-http-client-cert": testing.ServerCert,
+			"logforward-http-client-key":  serverKey2,
+		}),
+		err: `invalid HTTP log forwarding config: validating TLS config: parsing client key pair: (crypto/)?tls: private key does not match public key`,
 	},
 }
 
@@ -652,6 +693,18 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 		c.Check(lfCfg.ClientKey, gc.Equals, "")
 	}
 
+	httpCfg, hasHTTPCfg := cfg.LogFwdHTTP()
+	if v, ok := test.attrs["logforward-http-url"].(string); ok {
+		c.Assert(hasHTTPCfg, jc.IsTrue)
+		c.Assert(httpCfg.URL, gc.Equals, v)
+		enabled, _ := test.attrs["logforward-enabled"].(bool)
+		c.Assert(httpCfg.Enabled, gc.Equals, enabled)
+	}
+	if v, ok := test.attrs["logforward-http-client-cert"].(string); ok {
+		c.Assert(hasHTTPCfg, jc.IsTrue)
+		c.Assert(httpCfg.ClientCert, gc.Equals, v)
+	}
+
 	if v, ok := test.attrs["ssl-hostname-verification"]; ok {
 		c.Assert(cfg.SSLHostnameVerification(), gc.Equals, v)
 	}
@@ -1106,6 +1159,19 @@ func (s *ConfigSuite) TestAptProxyConfigMap(c *gc.C) {
 	c.Assert(cfg.AptProxySettings(), gc.DeepEquals, proxySettings)
 }
 
+func (s *ConfigSuite) TestPreferredAddressFamily(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.PreferredAddressFamily(), gc.Equals, "ipv4")
+
+	cfg = newTestConfig(c, testing.Attrs{"preferred-address-family": "ipv6"})
+	c.Assert(cfg.PreferredAddressFamily(), gc.Equals, "ipv6")
+
+	newCfg, err := cfg.Apply(testing.Attrs{"preferred-address-family": "ipv4"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = config.Validate(newCfg, cfg)
+	c.Assert(err, gc.ErrorMatches, `cannot change preferred-address-family from "ipv6" to "ipv4"`)
+}
+
 func (s *ConfigSuite) TestStatusHistoryConfigDefaults(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{})
 	c.Assert(cfg.MaxStatusHistoryAge(), gc.Equals, 336*time.Hour)
@@ -1121,6 +1187,31 @@ func (s *ConfigSuite) TestStatusHistoryConfigValues(c *gc.C) {
 	c.Assert(cfg.MaxStatusHistorySizeMB(), gc.Equals, uint(8192))
 }
 
+func (s *ConfigSuite) TestActionResultsConfigDefaults(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.MaxActionResultsAge(), gc.Equals, 336*time.Hour)
+	c.Assert(cfg.MaxActionResultsSizeMB(), gc.Equals, uint(5120))
+	c.Assert(cfg.ArchiveActionResults(), jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestActionResultsConfigValues(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"max-action-results-size": "1G",
+		"max-action-results-age":  "72h",
+		"archive-action-results":  true,
+	})
+	c.Assert(cfg.MaxActionResultsAge(), gc.Equals, 72*time.Hour)
+	c.Assert(cfg.MaxActionResultsSizeMB(), gc.Equals, uint(1024))
+	c.Assert(cfg.ArchiveActionResults(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestActionResultsConfigInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, sampleConfig.Merge(testing.Attrs{
+		"max-action-results-age": "a while",
+	}))
+	c.Assert(err, gc.ErrorMatches, `invalid max action results age in model configuration: time: invalid duration .*`)
+}
+
 func (s *ConfigSuite) TestSchemaNoExtra(c *gc.C) {
 	schema, err := config.Schema(nil)
 	c.Assert(err, gc.IsNil)
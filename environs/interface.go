@@ -384,6 +384,28 @@ type Firewaller interface {
 	IngressRules() ([]network.IngressRule, error)
 }
 
+// EgressFirewaller is an interface that may be implemented by an
+// Environ whose instance firewalls can restrict outgoing traffic.
+// Egress rules are only applied with the FwInstance firewall mode;
+// Juju enforces them on the machines themselves otherwise.
+type EgressFirewaller interface {
+	// SupportsEgressRules returns whether the environment
+	// supports restricting outgoing traffic from machines.
+	SupportsEgressRules() (bool, error)
+
+	// SetEgressRules replaces the egress rules applied to the
+	// machine with the given ID. Once rules are set, outgoing
+	// traffic from the machine is only allowed to the rules'
+	// destinations; if no rules are specified, outgoing traffic
+	// is unrestricted.
+	SetEgressRules(machineId string, rules []network.EgressRule) error
+
+	// EgressRules returns the egress rules applied to the machine
+	// with the given ID. If outgoing traffic from the machine is
+	// unrestricted, nil is returned.
+	EgressRules(machineId string) ([]network.EgressRule, error)
+}
+
 // InstanceTagger is an interface that can be used for tagging instances.
 type InstanceTagger interface {
 	// TagInstance tags the given instance with the specified tags.
@@ -125,6 +125,23 @@ func SupportsSpaces(env Environ) bool {
 	return ok
 }
 
+// SupportsEgressRules checks if the environment implements
+// EgressFirewaller and also if it supports egress rules.
+func SupportsEgressRules(env Environ) bool {
+	fw, ok := env.(EgressFirewaller)
+	if !ok {
+		return false
+	}
+	ok, err := fw.SupportsEgressRules()
+	if err != nil {
+		if !errors.IsNotSupported(err) {
+			logger.Errorf("checking egress rules support failed with: %v", err)
+		}
+		return false
+	}
+	return ok
+}
+
 // SupportsContainerAddresses checks if the environment will let us allocate
 // addresses for containers from the host ranges.
 func SupportsContainerAddresses(env Environ) bool {
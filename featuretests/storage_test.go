@@ -242,6 +242,8 @@ modelscoped:
   provider: modelscoped
 modelscoped-block:
   provider: modelscoped-block
+nfs:
+  provider: nfs
 rootfs:
   provider: rootfs
 static:
@@ -262,6 +264,7 @@ loop               loop
 machinescoped      machinescoped      
 modelscoped        modelscoped        
 modelscoped-block  modelscoped-block  
+nfs                nfs                
 rootfs             rootfs             
 static             static             
 tmpfs              tmpfs              
@@ -36,6 +36,9 @@ type DeployApplicationParams struct {
 	EndpointBindings map[string]string
 	// Resources is a map of resource name to IDs of pending resources.
 	Resources map[string]string
+	// IngressAddresses is a map of endpoint name to the address, and
+	// optionally port, advertised to related units on that endpoint.
+	IngressAddresses map[string]string
 }
 
 type ApplicationDeployer interface {
@@ -84,6 +87,7 @@ func DeployApplication(st ApplicationDeployer, args DeployApplicationParams) (*s
 		Placement:        args.Placement,
 		Resources:        args.Resources,
 		EndpointBindings: effectiveBindings,
+		IngressAddresses: args.IngressAddresses,
 	}
 
 	if !args.Charm.Meta().Subordinate {
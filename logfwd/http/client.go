@@ -0,0 +1,254 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/retry"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/logfwd"
+)
+
+var logger = loggo.GetLogger("juju.logfwd.http")
+
+const (
+	// maxBatchSize is the largest number of records sent in a
+	// single request.
+	maxBatchSize = 100
+
+	// requestTimeout bounds the time taken by each request.
+	requestTimeout = 30 * time.Second
+)
+
+// Doer exposes the underlying functionality needed by Client.
+type Doer interface {
+	// Do sends the HTTP request and returns the response.
+	Do(*http.Request) (*http.Response, error)
+}
+
+// RetryConfig controls how failed requests are retried.
+type RetryConfig struct {
+	// Attempts is the maximum number of times a batch is sent.
+	Attempts int
+
+	// Delay is the delay before the first retry. It is doubled for
+	// each subsequent retry, up to MaxDelay.
+	Delay time.Duration
+
+	// MaxDelay is the longest delay between retries.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryConfig is the RetryConfig used by Open.
+var DefaultRetryConfig = RetryConfig{
+	Attempts: 8,
+	Delay:    time.Second,
+	MaxDelay: time.Minute,
+}
+
+// Client forwards log records to an HTTP endpoint, POSTing them in
+// batches encoded as JSON.
+type Client struct {
+	url   string
+	doer  Doer
+	clock clock.Clock
+	retry RetryConfig
+}
+
+// Open returns a new client that sends log records to the URL in the
+// config, using the configured TLS certificates.
+func Open(cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	doer := &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsCfg,
+		},
+		Timeout: requestTimeout,
+	}
+	client, err := OpenForDoer(cfg, doer, clock.WallClock, DefaultRetryConfig)
+	return client, errors.Trace(err)
+}
+
+// OpenForDoer returns a new client that sends log records to the URL
+// in the config using the given Doer.
+func OpenForDoer(cfg RawConfig, doer Doer, clock clock.Clock, retryCfg RetryConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := &Client{
+		url:   cfg.URL,
+		doer:  doer,
+		clock: clock,
+		retry: retryCfg,
+	}
+	return client, nil
+}
+
+// Close releases the client's resources.
+func (client *Client) Close() error {
+	if c, ok := client.doer.(*http.Client); ok {
+		if t, ok := c.Transport.(*http.Transport); ok {
+			t.CloseIdleConnections()
+		}
+	}
+	return nil
+}
+
+// Send sends the records to the remote endpoint, in batches of at
+// most 100 records. A batch that cannot be delivered is retried with
+// an increasing delay; if it still cannot be delivered, an error is
+// returned and no later batches are sent.
+func (client *Client) Send(records []logfwd.Record) error {
+	for len(records) > 0 {
+		n := len(records)
+		if n > maxBatchSize {
+			n = maxBatchSize
+		}
+		if err := client.sendBatch(records[:n]); err != nil {
+			return errors.Trace(err)
+		}
+		records = records[n:]
+	}
+	return nil
+}
+
+func (client *Client) sendBatch(records []logfwd.Record) error {
+	batch := Batch{Records: make([]Record, len(records))}
+	for i, rec := range records {
+		batch.Records[i] = recordFromLogfwd(rec)
+	}
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return errors.Annotate(err, "marshalling log records")
+	}
+
+	var lastErr error
+	err = retry.Call(retry.CallArgs{
+		Func: func() error {
+			return client.post(body)
+		},
+		IsFatalError: func(err error) bool {
+			_, ok := errors.Cause(err).(*permanentError)
+			return ok
+		},
+		NotifyFunc: func(err error, i int) {
+			logger.Debugf("(attempt %d) retrying log forwarding to %s due to error: %v", i, client.url, err)
+			lastErr = err
+		},
+		Attempts:    client.retry.Attempts,
+		Delay:       client.retry.Delay,
+		MaxDelay:    client.retry.MaxDelay,
+		BackoffFunc: retry.DoubleDelay,
+		Clock:       client.clock,
+	})
+	if retry.IsAttemptsExceeded(err) {
+		return errors.Annotate(lastErr, "failed after retrying")
+	}
+	return errors.Trace(err)
+}
+
+// permanentError indicates that a request failed in a way that
+// retrying will not fix.
+type permanentError struct {
+	error
+}
+
+func (client *Client) post(body []byte) error {
+	req, err := http.NewRequest("POST", client.url, bytes.NewReader(body))
+	if err != nil {
+		return &permanentError{errors.Trace(err)}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.doer.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	// Drain the body so that the connection can be reused.
+	io.Copy(ioutil.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return errors.Errorf("log sink returned %s", resp.Status)
+	default:
+		return &permanentError{errors.Errorf("log sink returned %s", resp.Status)}
+	}
+}
+
+// Batch is the body of each request sent by Client.
+type Batch struct {
+	Records []Record `json:"records"`
+}
+
+// Record is the JSON representation of a single log record.
+type Record struct {
+	ID             int64     `json:"id"`
+	ModelUUID      string    `json:"model-uuid"`
+	ControllerUUID string    `json:"controller-uuid"`
+	Origin         Origin    `json:"origin"`
+	Timestamp      time.Time `json:"timestamp"`
+	Level          string    `json:"level"`
+	Location       Location  `json:"location"`
+	Message        string    `json:"message"`
+}
+
+// Origin describes the entity and software that created a record.
+type Origin struct {
+	Type            string `json:"type"`
+	Name            string `json:"name,omitempty"`
+	Hostname        string `json:"hostname,omitempty"`
+	Software        string `json:"software"`
+	SoftwareVersion string `json:"software-version"`
+}
+
+// Location describes where in the source a record was created.
+type Location struct {
+	Module   string `json:"module,omitempty"`
+	Filename string `json:"filename,omitempty"`
+	Line     int    `json:"line,omitempty"`
+}
+
+func recordFromLogfwd(rec logfwd.Record) Record {
+	result := Record{
+		ID:             rec.ID,
+		ModelUUID:      rec.Origin.ModelUUID,
+		ControllerUUID: rec.Origin.ControllerUUID,
+		Origin: Origin{
+			Type:            rec.Origin.Type.String(),
+			Name:            rec.Origin.Name,
+			Hostname:        rec.Origin.Hostname,
+			Software:        rec.Origin.Software.Name,
+			SoftwareVersion: rec.Origin.Software.Version.String(),
+		},
+		Timestamp: rec.Timestamp.UTC(),
+		Level:     rec.Level.String(),
+		Location: Location{
+			Module:   rec.Location.Module,
+			Filename: rec.Location.Filename,
+		},
+		Message: rec.Message,
+	}
+	if rec.Location.Line > 0 {
+		result.Location.Line = rec.Location.Line
+	}
+	return result
+}
@@ -0,0 +1,244 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package http_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	logfwdhttp "github.com/juju/juju/logfwd/http"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	stub  *testing.Stub
+	doer  *stubDoer
+	clock *testing.Clock
+	rec   logfwd.Record
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub = &testing.Stub{}
+	s.doer = &stubDoer{stub: s.stub}
+	s.clock = testing.NewClock(time.Now())
+	s.rec = logfwd.Record{
+		ID: 10,
+		Origin: logfwd.Origin{
+			ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
+			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Hostname:       "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Type:           logfwd.OriginTypeMachine,
+			Name:           "99",
+			Software: logfwd.Software{
+				PrivateEnterpriseNumber: 28978,
+				Name:                    "jujud-machine-agent",
+				Version:                 version.MustParse("2.0.1"),
+			},
+		},
+		Timestamp: time.Date(2017, 3, 1, 12, 30, 0, 0, time.UTC),
+		Level:     loggo.ERROR,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.x.y",
+			Filename: "x/y/spam.go",
+			Line:     42,
+		},
+		Message: "(╯°□°)╯︵ ┻━┻",
+	}
+}
+
+func (s *ClientSuite) open(c *gc.C) *logfwdhttp.Client {
+	cfg := logfwdhttp.RawConfig{
+		Enabled:    true,
+		URL:        "https://logs.example.com/juju",
+		CACert:     coretesting.CACert,
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.ServerKey,
+	}
+	client, err := logfwdhttp.OpenForDoer(cfg, s.doer, s.clock, logfwdhttp.RetryConfig{
+		Attempts: 3,
+		Delay:    time.Second,
+		MaxDelay: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return client
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	cfg := logfwdhttp.RawConfig{Enabled: true}
+	_, err := logfwdhttp.OpenForDoer(cfg, s.doer, s.clock, logfwdhttp.DefaultRetryConfig)
+	c.Assert(err, gc.ErrorMatches, `URL "" not valid`)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	client := s.open(c)
+
+	err := client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Do")
+	c.Assert(s.doer.requests, gc.HasLen, 1)
+	req := s.doer.requests[0]
+	c.Check(req.method, gc.Equals, "POST")
+	c.Check(req.url, gc.Equals, "https://logs.example.com/juju")
+	c.Check(req.contentType, gc.Equals, "application/json")
+
+	var body map[string]interface{}
+	err = json.Unmarshal(req.body, &body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(body, jc.DeepEquals, map[string]interface{}{
+		"records": []interface{}{map[string]interface{}{
+			"id":              float64(10),
+			"model-uuid":      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			"controller-uuid": "feebdaed-2f18-4fd2-967d-db9663db7bea",
+			"origin": map[string]interface{}{
+				"type":             "machine",
+				"name":             "99",
+				"hostname":         "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+				"software":         "jujud-machine-agent",
+				"software-version": "2.0.1",
+			},
+			"timestamp": "2017-03-01T12:30:00Z",
+			"level":     "ERROR",
+			"location": map[string]interface{}{
+				"module":   "juju.x.y",
+				"filename": "x/y/spam.go",
+				"line":     float64(42),
+			},
+			"message": "(╯°□°)╯︵ ┻━┻",
+		}},
+	})
+}
+
+func (s *ClientSuite) TestSendBatches(c *gc.C) {
+	client := s.open(c)
+
+	records := make([]logfwd.Record, 250)
+	for i := range records {
+		records[i] = s.rec
+		records[i].ID = int64(i)
+	}
+	err := client.Send(records)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Do", "Do", "Do")
+	var sizes []int
+	for _, req := range s.doer.requests {
+		var batch logfwdhttp.Batch
+		err := json.Unmarshal(req.body, &batch)
+		c.Assert(err, jc.ErrorIsNil)
+		sizes = append(sizes, len(batch.Records))
+	}
+	c.Check(sizes, jc.DeepEquals, []int{100, 100, 50})
+}
+
+func (s *ClientSuite) TestSendRetries(c *gc.C) {
+	client := s.open(c)
+	s.doer.statuses = []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK}
+	s.stub.SetErrors(errors.New("connection refused"))
+
+	done := make(chan error)
+	go func() {
+		done <- client.Send([]logfwd.Record{s.rec})
+	}()
+	for i := 0; i < 3; i++ {
+		err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for send")
+	}
+	s.stub.CheckCallNames(c, "Do", "Do", "Do", "Do")
+}
+
+func (s *ClientSuite) TestSendRetriesExhausted(c *gc.C) {
+	client := s.open(c)
+	s.doer.statuses = []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}
+
+	done := make(chan error)
+	go func() {
+		done <- client.Send([]logfwd.Record{s.rec})
+	}()
+	for i := 0; i < 2; i++ {
+		err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	select {
+	case err := <-done:
+		c.Assert(err, gc.ErrorMatches, "failed after retrying: log sink returned 502 Bad Gateway")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for send")
+	}
+	s.stub.CheckCallNames(c, "Do", "Do", "Do")
+}
+
+func (s *ClientSuite) TestSendPermanentFailure(c *gc.C) {
+	client := s.open(c)
+	s.doer.statuses = []int{http.StatusBadRequest}
+
+	err := client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, gc.ErrorMatches, "log sink returned 400 Bad Request")
+	s.stub.CheckCallNames(c, "Do")
+}
+
+type request struct {
+	method      string
+	url         string
+	contentType string
+	body        []byte
+}
+
+type stubDoer struct {
+	stub     *testing.Stub
+	statuses []int
+	requests []request
+}
+
+func (d *stubDoer) Do(req *http.Request) (*http.Response, error) {
+	d.stub.AddCall("Do")
+	if err := d.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	d.requests = append(d.requests, request{
+		method:      req.Method,
+		url:         req.URL.String(),
+		contentType: req.Header.Get("Content-Type"),
+		body:        body,
+	})
+	status := http.StatusOK
+	if len(d.statuses) > 0 {
+		status = d.statuses[0]
+		d.statuses = d.statuses[1:]
+	}
+	return &http.Response{
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package http_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/http"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateFull(c *gc.C) {
+	cfg := http.RawConfig{
+		Enabled:    true,
+		URL:        "https://logs.example.com/juju",
+		CACert:     coretesting.CACert,
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.ServerKey,
+	}
+	err := cfg.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateWithoutCerts(c *gc.C) {
+	cfg := http.RawConfig{
+		Enabled: true,
+		URL:     "http://10.0.0.1:8080/logs",
+	}
+	err := cfg.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateZeroValue(c *gc.C) {
+	var cfg http.RawConfig
+	err := cfg.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingURL(c *gc.C) {
+	cfg := http.RawConfig{
+		Enabled: true,
+	}
+	err := cfg.Validate()
+	c.Check(err, gc.ErrorMatches, `URL "" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadScheme(c *gc.C) {
+	cfg := http.RawConfig{
+		Enabled: true,
+		URL:     "ftp://logs.example.com",
+	}
+	err := cfg.Validate()
+	c.Check(err, gc.ErrorMatches, `URL scheme "ftp" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingHost(c *gc.C) {
+	cfg := http.RawConfig{
+		Enabled: true,
+		URL:     "https:///logs",
+	}
+	err := cfg.Validate()
+	c.Check(err, gc.ErrorMatches, `URL "https:///logs" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateClientCertWithoutKey(c *gc.C) {
+	cfg := http.RawConfig{
+		Enabled:    true,
+		URL:        "https://logs.example.com",
+		ClientCert: coretesting.ServerCert,
+	}
+	err := cfg.Validate()
+	c.Check(err, gc.ErrorMatches, `validating TLS config: parsing client key pair: .*`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadCACert(c *gc.C) {
+	cfg := http.RawConfig{
+		Enabled: true,
+		URL:     "https://logs.example.com",
+		CACert:  "abc",
+	}
+	err := cfg.Validate()
+	c.Check(err, gc.ErrorMatches, `validating TLS config: parsing CA certificate: .*`)
+}
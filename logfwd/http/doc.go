@@ -0,0 +1,7 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The http package holds the tools needed to perform log forwarding
+// from Juju to a remote HTTP endpoint, which receives batches of log
+// records encoded as JSON.
+package http
@@ -10,6 +10,7 @@ import (
 	"net"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/set"
@@ -51,6 +52,41 @@ const (
 	IPv6Address AddressType = "ipv6"
 )
 
+var (
+	preferIPv6Mutex sync.Mutex
+	preferIPv6      bool
+)
+
+// PreferIPv6 returns true if IPv6 addresses are preferred over IPv4
+// addresses when selecting and sorting addresses.
+func PreferIPv6() bool {
+	preferIPv6Mutex.Lock()
+	defer preferIPv6Mutex.Unlock()
+	return preferIPv6
+}
+
+// SetPreferIPv6 sets whether IPv6 addresses are preferred over IPv4
+// addresses when selecting and sorting addresses. By default, IPv4
+// addresses are preferred. The setting is process-wide, so it is only
+// for agents choosing the addresses they dial; code serving several
+// models should pass the model's preference to the functions that
+// take an explicit preferred AddressType instead.
+func SetPreferIPv6(prefer bool) {
+	preferIPv6Mutex.Lock()
+	defer preferIPv6Mutex.Unlock()
+	preferIPv6 = prefer
+	logger.Infof("setting prefer-ipv6 to %v", prefer)
+}
+
+// preferredAddressType returns the type of the addresses which are
+// preferred when selecting and sorting addresses.
+func preferredAddressType() AddressType {
+	if PreferIPv6() {
+		return IPv6Address
+	}
+	return IPv4Address
+}
+
 // Scope denotes the context a location may apply to. If a name or
 // address can be reached from the wider internet, it is considered
 // public. A private network address is either specific to the cloud
@@ -365,9 +401,16 @@ func SelectMongoHostPortsByScope(hostPorts []HostPort, machineLocal bool) []stri
 // are no suitable addresses, then ok is false (and an empty address is
 // returned). If a suitable address is then ok is true.
 func SelectPublicAddress(addresses []Address) (Address, bool) {
+	return SelectPublicAddressPreferring(addresses, preferredAddressType())
+}
+
+// SelectPublicAddressPreferring is like SelectPublicAddress, but
+// prefers addresses of the given type rather than the process-wide
+// preference.
+func SelectPublicAddressPreferring(addresses []Address, preferred AddressType) (Address, bool) {
 	index := bestAddressIndex(len(addresses), func(i int) Address {
 		return addresses[i]
-	}, publicMatch)
+	}, publicMatcher(preferred))
 	if index < 0 {
 		return Address{}, false
 	}
@@ -380,7 +423,7 @@ func SelectPublicAddress(addresses []Address) (Address, bool) {
 func SelectPublicHostPort(hps []HostPort) string {
 	index := bestAddressIndex(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, publicMatch)
+	}, publicMatcher(preferredAddressType()))
 	if index < 0 {
 		return ""
 	}
@@ -392,9 +435,16 @@ func SelectPublicHostPort(hps []HostPort) string {
 // are no suitable addresses, then ok is false (and an empty address is
 // returned). If a suitable address was found then ok is true.
 func SelectInternalAddress(addresses []Address, machineLocal bool) (Address, bool) {
+	return SelectInternalAddressPreferring(addresses, machineLocal, preferredAddressType())
+}
+
+// SelectInternalAddressPreferring is like SelectInternalAddress, but
+// prefers addresses of the given type rather than the process-wide
+// preference.
+func SelectInternalAddressPreferring(addresses []Address, machineLocal bool, preferred AddressType) (Address, bool) {
 	index := bestAddressIndex(len(addresses), func(i int) Address {
 		return addresses[i]
-	}, internalAddressMatcher(machineLocal))
+	}, internalAddressMatcher(machineLocal, preferred))
 	if index < 0 {
 		return Address{}, false
 	}
@@ -408,7 +458,7 @@ func SelectInternalAddress(addresses []Address, machineLocal bool) (Address, boo
 func SelectInternalHostPort(hps []HostPort, machineLocal bool) string {
 	index := bestAddressIndex(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, internalAddressMatcher(machineLocal))
+	}, internalAddressMatcher(machineLocal, preferredAddressType()))
 	if index < 0 {
 		return ""
 	}
@@ -422,7 +472,7 @@ func SelectInternalHostPort(hps []HostPort, machineLocal bool) string {
 func SelectInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 	indexes := bestAddressIndexes(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, internalAddressMatcher(machineLocal))
+	}, internalAddressMatcher(machineLocal, preferredAddressType()))
 
 	out := make([]string, 0, len(indexes))
 	for _, index := range indexes {
@@ -436,9 +486,16 @@ func SelectInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 // returns them in NetAddr form. If there are no suitable addresses
 // then an empty slice is returned.
 func PrioritizeInternalHostPorts(hps []HostPort, machineLocal bool) []string {
+	return PrioritizeInternalHostPortsPreferring(hps, machineLocal, preferredAddressType())
+}
+
+// PrioritizeInternalHostPortsPreferring is like
+// PrioritizeInternalHostPorts, but prefers addresses of the given type
+// rather than the process-wide preference.
+func PrioritizeInternalHostPortsPreferring(hps []HostPort, machineLocal bool, preferred AddressType) []string {
 	indexes := prioritizedAddressIndexes(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, internalAddressMatcher(machineLocal))
+	}, internalAddressMatcher(machineLocal, preferred))
 
 	out := make([]string, 0, len(indexes))
 	for _, index := range indexes {
@@ -447,38 +504,44 @@ func PrioritizeInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 	return out
 }
 
-func publicMatch(addr Address) scopeMatch {
-	switch addr.Scope {
-	case ScopePublic:
-		if addr.Type == IPv4Address {
-			return exactScopeIPv4
+func publicMatcher(preferred AddressType) scopeMatchFunc {
+	return func(addr Address) scopeMatch {
+		switch addr.Scope {
+		case ScopePublic:
+			if addr.Type == preferred {
+				return exactScopeIPv4
+			}
+			return exactScope
+		case ScopeCloudLocal, ScopeUnknown:
+			if addr.Type == preferred {
+				return fallbackScopeIPv4
+			}
+			return fallbackScope
 		}
-		return exactScope
-	case ScopeCloudLocal, ScopeUnknown:
-		if addr.Type == IPv4Address {
-			return fallbackScopeIPv4
-		}
-		return fallbackScope
+		return invalidScope
 	}
-	return invalidScope
 }
 
-func internalAddressMatcher(machineLocal bool) scopeMatchFunc {
+func internalAddressMatcher(machineLocal bool, preferred AddressType) scopeMatchFunc {
 	if machineLocal {
-		return cloudOrMachineLocalMatch
+		return func(addr Address) scopeMatch {
+			return cloudOrMachineLocalMatch(addr, preferred)
+		}
+	}
+	return func(addr Address) scopeMatch {
+		return cloudLocalMatch(addr, preferred)
 	}
-	return cloudLocalMatch
 }
 
-func cloudLocalMatch(addr Address) scopeMatch {
+func cloudLocalMatch(addr Address, preferred AddressType) scopeMatch {
 	switch addr.Scope {
 	case ScopeCloudLocal:
-		if addr.Type == IPv4Address {
+		if addr.Type == preferred {
 			return exactScopeIPv4
 		}
 		return exactScope
 	case ScopePublic, ScopeUnknown:
-		if addr.Type == IPv4Address {
+		if addr.Type == preferred {
 			return fallbackScopeIPv4
 		}
 		return fallbackScope
@@ -486,18 +549,20 @@ func cloudLocalMatch(addr Address) scopeMatch {
 	return invalidScope
 }
 
-func cloudOrMachineLocalMatch(addr Address) scopeMatch {
+func cloudOrMachineLocalMatch(addr Address, preferred AddressType) scopeMatch {
 	if addr.Scope == ScopeMachineLocal {
-		if addr.Type == IPv4Address {
+		if addr.Type == preferred {
 			return exactScopeIPv4
 		}
 		return exactScope
 	}
-	return cloudLocalMatch(addr)
+	return cloudLocalMatch(addr, preferred)
 }
 
 type scopeMatch int
 
+// The "IPv4" matches are for addresses of the preferred type, which is
+// IPv4 unless IPv6 addresses are preferred.
 const (
 	invalidScope scopeMatch = iota
 	exactScopeIPv4
@@ -575,7 +640,8 @@ func filterAndCollateAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc,
 // - machine-local next;
 // - link-local next;
 // - non-hostnames with unknown scope last.
-func (a Address) sortOrder() int {
+// Within each scope, addresses of the preferred type come first.
+func (a Address) sortOrder(preferred AddressType) int {
 	order := 0xFF
 	switch a.Scope {
 	case ScopePublic:
@@ -593,23 +659,28 @@ func (a Address) sortOrder() int {
 		if a.Value == "localhost" {
 			order++
 		}
-	case IPv6Address:
-		// Prefer IPv4 over IPv6 addresses.
-		order++
-	case IPv4Address:
+	case IPv4Address, IPv6Address:
+		// Prefer IPv4 over IPv6 addresses, unless IPv6
+		// addresses are preferred.
+		if a.Type != preferred {
+			order++
+		}
 	}
 	return order
 }
 
-type addressesPreferringIPv4Slice []Address
+type addressesPreferringSlice struct {
+	addrs     []Address
+	preferred AddressType
+}
 
-func (a addressesPreferringIPv4Slice) Len() int      { return len(a) }
-func (a addressesPreferringIPv4Slice) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
-func (a addressesPreferringIPv4Slice) Less(i, j int) bool {
-	addr1 := a[i]
-	addr2 := a[j]
-	order1 := addr1.sortOrder()
-	order2 := addr2.sortOrder()
+func (a addressesPreferringSlice) Len() int      { return len(a.addrs) }
+func (a addressesPreferringSlice) Swap(i, j int) { a.addrs[i], a.addrs[j] = a.addrs[j], a.addrs[i] }
+func (a addressesPreferringSlice) Less(i, j int) bool {
+	addr1 := a.addrs[i]
+	addr2 := a.addrs[j]
+	order1 := addr1.sortOrder(a.preferred)
+	order2 := addr2.sortOrder(a.preferred)
 	if order1 == order2 {
 		return addr1.Value < addr2.Value
 	}
@@ -619,7 +690,14 @@ func (a addressesPreferringIPv4Slice) Less(i, j int) bool {
 // SortAddresses sorts the given Address slice according to the sortOrder of
 // each address. See Address.sortOrder() for more info.
 func SortAddresses(addrs []Address) {
-	sort.Sort(addressesPreferringIPv4Slice(addrs))
+	SortAddressesPreferring(addrs, preferredAddressType())
+}
+
+// SortAddressesPreferring is like SortAddresses, but puts addresses of
+// the given type first within each scope rather than following the
+// process-wide preference.
+func SortAddressesPreferring(addrs []Address, preferred AddressType) {
+	sort.Sort(addressesPreferringSlice{addrs, preferred})
 }
 
 // DecimalToIPv4 converts a decimal to the dotted quad IP address format.
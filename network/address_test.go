@@ -734,6 +734,76 @@ func (*AddressSuite) TestSortAddresses(c *gc.C) {
 	))
 }
 
+func (s *AddressSuite) TestSortAddressesPreferIPv6(c *gc.C) {
+	network.SetPreferIPv6(true)
+	s.AddCleanup(func(*gc.C) { network.SetPreferIPv6(false) })
+
+	addrs := network.NewAddresses(
+		"7.8.8.8",
+		"2001:db8::1",
+		"172.16.0.1",
+		"fc00::1",
+		"127.0.0.1",
+		"::1",
+	)
+	network.SortAddresses(addrs)
+	c.Assert(addrs, jc.DeepEquals, network.NewAddresses(
+		"2001:db8::1",
+		"7.8.8.8",
+		"fc00::1",
+		"172.16.0.1",
+		"::1",
+		"127.0.0.1",
+	))
+}
+
+func (s *AddressSuite) TestSelectInternalHostPortsPreferIPv6(c *gc.C) {
+	hps := []network.HostPort{
+		{network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal), 17070},
+		{network.NewScopedAddress("fc00::1", network.ScopeCloudLocal), 17070},
+		{network.NewScopedAddress("8.8.8.8", network.ScopePublic), 17070},
+	}
+	c.Assert(network.SelectInternalHostPorts(hps, false), jc.DeepEquals, []string{"10.0.0.1:17070"})
+
+	network.SetPreferIPv6(true)
+	s.AddCleanup(func(*gc.C) { network.SetPreferIPv6(false) })
+	c.Assert(network.PreferIPv6(), jc.IsTrue)
+	c.Assert(network.SelectInternalHostPorts(hps, false), jc.DeepEquals, []string{"[fc00::1]:17070"})
+	c.Assert(network.PrioritizeInternalHostPorts(hps, false), jc.DeepEquals, []string{
+		"[fc00::1]:17070", "10.0.0.1:17070", "8.8.8.8:17070",
+	})
+	addr, ok := network.SelectPublicAddress(network.NewAddresses("8.8.8.8", "2001:db8::1"))
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(addr.Value, gc.Equals, "2001:db8::1")
+}
+
+func (*AddressSuite) TestExplicitPreferenceIgnoresProcessPreference(c *gc.C) {
+	// The process-wide preference is left at IPv4; the explicit
+	// preference given to the "Preferring" functions wins.
+	c.Assert(network.PreferIPv6(), jc.IsFalse)
+	addrs := network.NewAddresses("8.8.8.8", "2001:db8::1", "172.16.0.1", "fc00::1")
+
+	addr, ok := network.SelectPublicAddressPreferring(addrs, network.IPv6Address)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(addr.Value, gc.Equals, "2001:db8::1")
+	addr, ok = network.SelectInternalAddressPreferring(addrs, false, network.IPv6Address)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(addr.Value, gc.Equals, "fc00::1")
+	addr, ok = network.SelectInternalAddressPreferring(addrs, false, network.IPv4Address)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(addr.Value, gc.Equals, "172.16.0.1")
+
+	network.SortAddressesPreferring(addrs, network.IPv6Address)
+	c.Check(addrs, jc.DeepEquals, network.NewAddresses(
+		"2001:db8::1", "8.8.8.8", "fc00::1", "172.16.0.1",
+	))
+
+	hps := network.NewHostPorts(17070, "10.0.0.1", "fc00::1")
+	c.Check(network.PrioritizeInternalHostPortsPreferring(hps, false, network.IPv6Address), jc.DeepEquals, []string{
+		"[fc00::1]:17070", "10.0.0.1:17070",
+	})
+}
+
 func (*AddressSuite) TestIPv4ToDecimal(c *gc.C) {
 	zeroIP, err := network.IPv4ToDecimal(net.ParseIP("0.0.0.0"))
 	c.Assert(err, jc.ErrorIsNil)
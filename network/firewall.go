@@ -108,3 +108,125 @@ func (p IngressRuleSlice) Less(i, j int) bool {
 func SortIngressRules(IngressRules []IngressRule) {
 	sort.Sort(IngressRuleSlice(IngressRules))
 }
+
+// EgressRule represents a range of ports and destinations
+// to which outgoing packets are allowed.
+type EgressRule struct {
+	// PortRange is the range of ports for which outgoing
+	// packets are allowed.
+	PortRange
+
+	// DestinationCIDRs is a list of IP address blocks expressed in CIDR
+	// format to which this rule applies.
+	DestinationCIDRs []string
+}
+
+// NewEgressRule returns an EgressRule for the specified port
+// range. If no explicit destination ranges are specified, outgoing
+// traffic is allowed to any destination. ICMP has no ports, so
+// an ICMP rule's port range is always -1 to -1.
+func NewEgressRule(protocol string, from, to int, destinationCIDRs ...string) (EgressRule, error) {
+	rule := EgressRule{
+		PortRange: PortRange{
+			Protocol: protocol,
+			FromPort: from,
+			ToPort:   to,
+		},
+	}
+	if strings.ToLower(protocol) == "icmp" {
+		rule.PortRange = PortRange{Protocol: "icmp", FromPort: -1, ToPort: -1}
+	} else if err := rule.PortRange.Validate(); err != nil {
+		return EgressRule{}, errors.Trace(err)
+	}
+	for _, cidr := range destinationCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return EgressRule{}, errors.Trace(err)
+		}
+	}
+	if len(destinationCIDRs) > 0 {
+		rule.DestinationCIDRs = destinationCIDRs
+	}
+	return rule, nil
+}
+
+// MustNewEgressRule returns an EgressRule for the specified port
+// range. If no explicit destination ranges are specified, outgoing
+// traffic is allowed to any destination.
+// The method will panic if there is an error.
+func MustNewEgressRule(protocol string, from, to int, destinationCIDRs ...string) EgressRule {
+	rule, err := NewEgressRule(protocol, from, to, destinationCIDRs...)
+	if err != nil {
+		panic(err)
+	}
+	return rule
+}
+
+// String is the string representation of EgressRule.
+func (r EgressRule) String() string {
+	destination := ""
+	to := strings.Join(r.DestinationCIDRs, ",")
+	if to != "" && to != "0.0.0.0/0" {
+		destination = " to " + to
+	}
+	if r.Protocol == "icmp" {
+		return "icmp" + destination
+	}
+	if r.FromPort == r.ToPort {
+		return fmt.Sprintf("%d/%s%s", r.FromPort, strings.ToLower(r.Protocol), destination)
+	}
+	return fmt.Sprintf("%d-%d/%s%s", r.FromPort, r.ToPort, strings.ToLower(r.Protocol), destination)
+}
+
+// GoString is used to print values passed as an operand to a %#v format.
+func (r EgressRule) GoString() string {
+	return r.String()
+}
+
+type EgressRuleSlice []EgressRule
+
+func (p EgressRuleSlice) Len() int      { return len(p) }
+func (p EgressRuleSlice) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p EgressRuleSlice) Less(i, j int) bool {
+	p1 := p[i]
+	p2 := p[j]
+	if p1.Protocol != p2.Protocol {
+		return p1.Protocol < p2.Protocol
+	}
+	if p1.FromPort != p2.FromPort {
+		return p1.FromPort < p2.FromPort
+	}
+	if p1.ToPort != p2.ToPort {
+		return p1.ToPort < p2.ToPort
+	}
+	d1 := strings.Join(p1.DestinationCIDRs, ",")
+	d2 := strings.Join(p2.DestinationCIDRs, ",")
+	return d1 < d2
+}
+
+// SortEgressRules sorts the given rules, first by protocol, then by ports.
+func SortEgressRules(egressRules []EgressRule) {
+	sort.Sort(EgressRuleSlice(egressRules))
+}
+
+// ParseEgressRule builds an EgressRule from the provided string, which
+// is a port range as accepted by ParsePortRange or "icmp", optionally
+// followed by "@" and a comma-separated list of destination CIDRs.
+// Example strings: "443/tcp", "53/udp@10.0.0.2/32", "8000-8080@10.0.0.0/8,192.168.0.0/16", "icmp".
+func ParseEgressRule(inRule string) (EgressRule, error) {
+	var destinationCIDRs []string
+	parts := strings.SplitN(inRule, "@", 2)
+	if len(parts) == 2 {
+		if parts[1] == "" {
+			return EgressRule{}, errors.Errorf("invalid egress rule %q, expected destination CIDRs after %q", inRule, "@")
+		}
+		destinationCIDRs = strings.Split(parts[1], ",")
+	}
+	if parts[0] == "icmp" {
+		return NewEgressRule("icmp", -1, -1, destinationCIDRs...)
+	}
+	portRange, err := ParsePortRange(parts[0])
+	if err != nil {
+		return EgressRule{}, errors.Trace(err)
+	}
+	return NewEgressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, destinationCIDRs...)
+}
@@ -72,3 +72,97 @@ func (*FirewallSuite) TestNewIngressRuleBadCIDR(c *gc.C) {
 	_, err := network.NewIngressRule("tcp", 80, 100, "0.0.0.0/0", "192.168.0/24")
 	c.Assert(err, gc.ErrorMatches, "invalid CIDR address: 192.168.0/24")
 }
+
+func (*FirewallSuite) TestEgressRuleStrings(c *gc.C) {
+	rule := network.MustNewEgressRule("tcp", 443, 443)
+	c.Assert(rule.String(), gc.Equals, "443/tcp")
+	c.Assert(rule.GoString(), gc.Equals, "443/tcp")
+
+	rule = network.MustNewEgressRule("tcp", 443, 443, "0.0.0.0/0")
+	c.Assert(rule.String(), gc.Equals, "443/tcp")
+
+	rule = network.MustNewEgressRule("udp", 5000, 5010, "10.0.0.0/8", "192.168.1.0/24")
+	c.Assert(rule.String(), gc.Equals, "5000-5010/udp to 10.0.0.0/8,192.168.1.0/24")
+	c.Assert(rule.GoString(), gc.Equals, "5000-5010/udp to 10.0.0.0/8,192.168.1.0/24")
+}
+
+func (*FirewallSuite) TestSortEgressRules(c *gc.C) {
+	rule1 := network.MustNewEgressRule("udp", 10, 100, "10.0.0.0/8")
+	rule2 := network.MustNewEgressRule("tcp", 80, 90, "10.0.0.0/8")
+	rule3 := network.MustNewEgressRule("tcp", 80, 80, "192.168.1.0/24")
+	rule4 := network.MustNewEgressRule("tcp", 80, 80, "10.0.0.0/8")
+
+	rules := []network.EgressRule{rule1, rule2, rule3, rule4}
+	expected := []network.EgressRule{rule4, rule3, rule2, rule1}
+	network.SortEgressRules(rules)
+	c.Assert(rules, gc.DeepEquals, expected)
+}
+
+func (*FirewallSuite) TestNewEgressRule(c *gc.C) {
+	rule, err := network.NewEgressRule("tcp", 80, 100, "10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.Protocol, gc.Equals, "tcp")
+	c.Assert(rule.FromPort, gc.Equals, 80)
+	c.Assert(rule.ToPort, gc.Equals, 100)
+	c.Assert(rule.DestinationCIDRs, jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	rule, err = network.NewEgressRule("udp", 53, 53)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.DestinationCIDRs, gc.IsNil)
+
+	// ICMP has no ports.
+	rule, err = network.NewEgressRule("icmp", 8, 8, "10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.PortRange, jc.DeepEquals, network.PortRange{Protocol: "icmp", FromPort: -1, ToPort: -1})
+	c.Assert(rule.String(), gc.Equals, "icmp to 10.0.0.0/8")
+}
+
+func (*FirewallSuite) TestNewEgressRuleInvalid(c *gc.C) {
+	_, err := network.NewEgressRule("tcp", 80, 100, "192.168.0/24")
+	c.Assert(err, gc.ErrorMatches, "invalid CIDR address: 192.168.0/24")
+	_, err = network.NewEgressRule("tcp", 100, 80)
+	c.Assert(err, gc.ErrorMatches, "invalid port range 100-80/tcp")
+	_, err = network.NewEgressRule("sctp", 1, 1)
+	c.Assert(err, gc.ErrorMatches, `invalid protocol "sctp", expected "tcp" or "udp"`)
+}
+
+func (*FirewallSuite) TestParseEgressRule(c *gc.C) {
+	for i, test := range []struct {
+		in     string
+		expect network.EgressRule
+		err    string
+	}{{
+		in:     "443",
+		expect: network.MustNewEgressRule("tcp", 443, 443),
+	}, {
+		in:     "53/udp@10.0.0.2/32",
+		expect: network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+	}, {
+		in:     "8000-8080/tcp@10.0.0.0/8,192.168.0.0/16",
+		expect: network.MustNewEgressRule("tcp", 8000, 8080, "10.0.0.0/8", "192.168.0.0/16"),
+	}, {
+		in:     "icmp",
+		expect: network.MustNewEgressRule("icmp", -1, -1),
+	}, {
+		in:     "icmp@10.0.0.0/8",
+		expect: network.MustNewEgressRule("icmp", -1, -1, "10.0.0.0/8"),
+	}, {
+		in:  "443/tcp@",
+		err: `invalid egress rule "443/tcp@", expected destination CIDRs after "@"`,
+	}, {
+		in:  "443/tcp@10.0.0/8",
+		err: "invalid CIDR address: 10.0.0/8",
+	}, {
+		in:  "https",
+		err: `invalid port "https": .*`,
+	}} {
+		c.Logf("test %d: %s", i, test.in)
+		rule, err := network.ParseEgressRule(test.in)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(rule, jc.DeepEquals, test.expect)
+	}
+}
@@ -38,8 +38,9 @@ func (hp HostPort) GoString() string {
 // Less reports whether hp1 is ordered before hp2
 // according to the criteria used by SortHostPorts.
 func (hp1 HostPort) Less(hp2 HostPort) bool {
-	order1 := hp1.sortOrder()
-	order2 := hp2.sortOrder()
+	preferred := preferredAddressType()
+	order1 := hp1.sortOrder(preferred)
+	order2 := hp2.sortOrder(preferred)
 	if order1 == order2 {
 		if hp1.Address.Value == hp2.Address.Value {
 			return hp1.Port < hp2.Port
@@ -107,6 +108,30 @@ func ParseHostPort(hp string) (*HostPort, error) {
 	}, nil
 }
 
+// ParseAddressPort converts a string containing an address, optionally
+// followed by a port, to an Address and a port; e.g. "10.0.0.1",
+// "10.0.0.1:80", "[2001:db8::1]:80" or "lb.example.com". If no port is
+// specified, the returned port is 0.
+func ParseAddressPort(s string) (Address, int, error) {
+	host, port := s, 0
+	if h, p, err := net.SplitHostPort(s); err == nil {
+		numPort, err := strconv.Atoi(p)
+		if err != nil || numPort < 1 || numPort > 65535 {
+			return Address{}, 0, errors.NotValidf("port in %q", s)
+		}
+		host, port = h, numPort
+	} else if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+		host = s[1 : len(s)-1]
+	}
+	if host == "" || strings.ContainsAny(host, " \t[]/") {
+		return Address{}, 0, errors.NotValidf("address %q", s)
+	}
+	if strings.Contains(host, ":") && net.ParseIP(host) == nil {
+		return Address{}, 0, errors.NotValidf("address %q", s)
+	}
+	return NewAddress(host), port, nil
+}
+
 // HostsWithoutPort strips the port from each HostPort, returning just
 // the addresses.
 func HostsWithoutPort(hps []HostPort) []Address {
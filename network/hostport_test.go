@@ -276,6 +276,62 @@ func (*HostPortSuite) TestParseHostPortsSuccess(c *gc.C) {
 	}
 }
 
+func (*HostPortSuite) TestParseAddressPort(c *gc.C) {
+	for i, test := range []struct {
+		input   string
+		address network.Address
+		port    int
+		err     string
+	}{{
+		input:   "10.0.0.1",
+		address: network.NewAddress("10.0.0.1"),
+	}, {
+		input:   "10.0.0.1:3306",
+		address: network.NewAddress("10.0.0.1"),
+		port:    3306,
+	}, {
+		input:   "2001:db8::1",
+		address: network.NewAddress("2001:db8::1"),
+	}, {
+		input:   "[2001:db8::1]",
+		address: network.NewAddress("2001:db8::1"),
+	}, {
+		input:   "[2001:db8::1]:443",
+		address: network.NewAddress("2001:db8::1"),
+		port:    443,
+	}, {
+		input:   "lb.example.com:80",
+		address: network.NewAddress("lb.example.com"),
+		port:    80,
+	}, {
+		input: "",
+		err:   `address "" not valid`,
+	}, {
+		input: "lb.example.com:http",
+		err:   `port in "lb.example.com:http" not valid`,
+	}, {
+		input: "10.0.0.1:70000",
+		err:   `port in "10.0.0.1:70000" not valid`,
+	}, {
+		input: "10.0.0.0/24",
+		err:   `address "10.0.0.0/24" not valid`,
+	}, {
+		input: "foo:bar:baz",
+		err:   `address "foo:bar:baz" not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.input)
+		address, port, err := network.ParseAddressPort(test.input)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			c.Check(err, jc.Satisfies, errors.IsNotValid)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(address, jc.DeepEquals, test.address)
+		c.Check(port, gc.Equals, test.port)
+	}
+}
+
 func (*HostPortSuite) TestAddressesWithPortAndHostsWithoutPort(c *gc.C) {
 	addrs := network.NewAddresses("0.1.2.3", "0.2.4.6")
 	hps := network.AddressesWithPort(addrs, 999)
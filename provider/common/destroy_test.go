@@ -166,6 +166,90 @@ func (s *DestroySuite) TestDestroyEnvScopedVolumes(c *gc.C) {
 	})
 }
 
+func (s *DestroySuite) TestDestroyEnvScopedSnapshots(c *gc.C) {
+	volumeSource := &snapshottingVolumeSource{
+		VolumeSource: dummy.VolumeSource{
+			ListVolumesFunc: func() ([]string, error) {
+				return []string{"vol-0"}, nil
+			},
+			DestroyVolumesFunc: func(ids []string) ([]error, error) {
+				return make([]error, len(ids)), nil
+			},
+		},
+		snapshotIds: []string{"snap-0", "snap-1"},
+	}
+	env := s.snapshottingEnviron(c, volumeSource)
+	err := common.Destroy(env)
+	c.Assert(err, jc.ErrorIsNil)
+
+	volumeSource.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ListVolumes", nil},
+		{"DestroyVolumes", []interface{}{[]string{"vol-0"}}},
+		{"ListSnapshots", nil},
+		{"DeleteSnapshots", []interface{}{[]string{"snap-0", "snap-1"}}},
+	})
+}
+
+func (s *DestroySuite) TestDestroySnapshotErrors(c *gc.C) {
+	volumeSource := &snapshottingVolumeSource{
+		VolumeSource: dummy.VolumeSource{
+			DestroyVolumesFunc: func(ids []string) ([]error, error) {
+				return make([]error, len(ids)), nil
+			},
+		},
+		snapshotIds:    []string{"snap-0", "snap-1"},
+		deleteFailures: []error{nil, errors.New("cannot delete snap-1")},
+	}
+	env := s.snapshottingEnviron(c, volumeSource)
+	err := common.Destroy(env)
+	c.Assert(err, gc.ErrorMatches, "destroying storage: deleting snapshots: cannot delete snap-1")
+}
+
+func (s *DestroySuite) snapshottingEnviron(c *gc.C, volumeSource storage.VolumeSource) environs.Environ {
+	storageProvider := &dummy.StorageProvider{
+		IsDynamic:    true,
+		StorageScope: storage.ScopeEnviron,
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return volumeSource, nil
+		},
+	}
+	return &mockEnviron{
+		config: configGetter(c),
+		allInstances: func() ([]instance.Instance, error) {
+			return nil, environs.ErrNoInstances
+		},
+		storageProviders: storage.StaticProviderRegistry{
+			map[storage.ProviderType]storage.Provider{
+				"environ": storageProvider,
+			},
+		},
+	}
+}
+
+type snapshottingVolumeSource struct {
+	dummy.VolumeSource
+	snapshotIds    []string
+	deleteFailures []error
+}
+
+func (s *snapshottingVolumeSource) CreateSnapshots(params []storage.SnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+	s.MethodCall(s, "CreateSnapshots", params)
+	return nil, s.NextErr()
+}
+
+func (s *snapshottingVolumeSource) ListSnapshots() ([]string, error) {
+	s.MethodCall(s, "ListSnapshots")
+	return s.snapshotIds, s.NextErr()
+}
+
+func (s *snapshottingVolumeSource) DeleteSnapshots(snapshotIds []string) ([]error, error) {
+	s.MethodCall(s, "DeleteSnapshots", snapshotIds)
+	if s.deleteFailures != nil {
+		return s.deleteFailures, s.NextErr()
+	}
+	return make([]error, len(snapshotIds)), s.NextErr()
+}
+
 func (s *DestroySuite) TestDestroyVolumeErrors(c *gc.C) {
 	volumeSource := &dummy.VolumeSource{
 		ListVolumesFunc: func() ([]string, error) {
@@ -0,0 +1,75 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/network"
+)
+
+// SubnetsFromInterfaces returns the distinct subnets of the addresses
+// of the network interfaces, in the order they are first seen. It is
+// intended for providers with no subnet model of their own, which
+// discover subnets from the interfaces of their hosts; such subnets
+// are identified by their CIDRs.
+func SubnetsFromInterfaces(interfaces []network.InterfaceInfo) []network.SubnetInfo {
+	var subnets []network.SubnetInfo
+	seen := set.NewStrings()
+	for _, iface := range interfaces {
+		if iface.CIDR == "" || seen.Contains(iface.CIDR) {
+			continue
+		}
+		seen.Add(iface.CIDR)
+		subnets = append(subnets, network.SubnetInfo{
+			ProviderId:        iface.ProviderSubnetId,
+			ProviderNetworkId: iface.ProviderNetworkId,
+			CIDR:              iface.CIDR,
+		})
+	}
+	return subnets
+}
+
+// FilterSubnets returns the subnets with the specified provider IDs,
+// or all of the subnets if no IDs are specified. An error satisfying
+// errors.IsNotFound is returned if any of the subnets are not found.
+func FilterSubnets(subnets []network.SubnetInfo, subnetIds []network.Id) ([]network.SubnetInfo, error) {
+	if len(subnetIds) == 0 {
+		return subnets, nil
+	}
+	missing := set.NewStrings()
+	for _, id := range subnetIds {
+		missing.Add(string(id))
+	}
+	var results []network.SubnetInfo
+	for _, subnet := range subnets {
+		if missing.Contains(string(subnet.ProviderId)) {
+			missing.Remove(string(subnet.ProviderId))
+			results = append(results, subnet)
+		}
+	}
+	if !missing.IsEmpty() {
+		ids := missing.SortedValues()
+		for i, id := range ids {
+			ids[i] = fmt.Sprintf("%q", id)
+		}
+		return nil, errors.NotFoundf("subnets [%s]", strings.Join(ids, ", "))
+	}
+	return results, nil
+}
+
+// SubnetCIDR returns the CIDR of the subnet containing the address with
+// the specified prefix length, e.g. "10.0.0.0/24" for "10.0.0.5" and 24.
+func SubnetCIDR(address string, prefixLength int) (string, error) {
+	_, ipNet, err := net.ParseCIDR(fmt.Sprintf("%s/%d", address, prefixLength))
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return ipNet.String(), nil
+}
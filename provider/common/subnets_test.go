@@ -0,0 +1,78 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/common"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SubnetsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&SubnetsSuite{})
+
+func (s *SubnetsSuite) TestSubnetsFromInterfaces(c *gc.C) {
+	subnets := common.SubnetsFromInterfaces([]network.InterfaceInfo{{
+		InterfaceName:     "eth0",
+		CIDR:              "10.0.0.0/24",
+		ProviderSubnetId:  "10.0.0.0/24",
+		ProviderNetworkId: "br0",
+	}, {
+		InterfaceName: "eth1",
+	}, {
+		InterfaceName:    "eth1",
+		CIDR:             "2001:db8::/64",
+		ProviderSubnetId: "2001:db8::/64",
+	}, {
+		InterfaceName:    "eth2",
+		CIDR:             "10.0.0.0/24",
+		ProviderSubnetId: "10.0.0.0/24",
+	}})
+	c.Assert(subnets, jc.DeepEquals, []network.SubnetInfo{{
+		ProviderId:        "10.0.0.0/24",
+		ProviderNetworkId: "br0",
+		CIDR:              "10.0.0.0/24",
+	}, {
+		ProviderId: "2001:db8::/64",
+		CIDR:       "2001:db8::/64",
+	}})
+}
+
+func (s *SubnetsSuite) TestFilterSubnets(c *gc.C) {
+	subnets := []network.SubnetInfo{
+		{ProviderId: "10.0.0.0/24", CIDR: "10.0.0.0/24"},
+		{ProviderId: "10.0.1.0/24", CIDR: "10.0.1.0/24"},
+	}
+	results, err := common.FilterSubnets(subnets, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, subnets)
+
+	results, err = common.FilterSubnets(subnets, []network.Id{"10.0.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, subnets[1:])
+
+	_, err = common.FilterSubnets(subnets, []network.Id{"10.0.1.0/24", "10.0.3.0/24", "10.0.2.0/24"})
+	c.Assert(err, gc.ErrorMatches, `subnets \["10.0.2.0/24", "10.0.3.0/24"\] not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SubnetsSuite) TestSubnetCIDR(c *gc.C) {
+	cidr, err := common.SubnetCIDR("10.0.0.5", 24)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidr, gc.Equals, "10.0.0.0/24")
+
+	cidr, err = common.SubnetCIDR("2001:db8::5", 64)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidr, gc.Equals, "2001:db8::/64")
+
+	_, err = common.SubnetCIDR("foo", 24)
+	c.Assert(err, gc.ErrorMatches, "invalid CIDR address: foo/24")
+}
@@ -60,6 +60,7 @@ const (
 	deviceInUse        = "InvalidDevice.InUse"
 	attachmentNotFound = "InvalidAttachment.NotFound"
 	volumeNotFound     = "InvalidVolume.NotFound"
+	snapshotNotFound   = "InvalidSnapshot.NotFound"
 )
 
 const (
@@ -248,6 +249,13 @@ type ebsVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*ebsVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*ebsVolumeSource)(nil)
+var _ storage.VolumeImporter = (*ebsVolumeSource)(nil)
+
+// TODO(storage) implement storage.VolumeResizer with the EC2
+// ModifyVolume API. The pinned gopkg.in/amz.v3 predates ModifyVolume,
+// so this needs the client library updated first; until then, growing
+// ebs volumes is reported as not supported.
 
 // parseVolumeOptions uses storage volume parameters to make a struct used to create volumes.
 func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.CreateVolume, _ error) {
@@ -345,6 +353,7 @@ func (v *ebsVolumeSource) createVolume(p storage.VolumeParams, instances instanc
 	}
 	vol, _ := parseVolumeOptions(p.Size, p.Attributes)
 	vol.AvailZone = inst.AvailZone
+	vol.SnapshotId = p.SnapshotId
 	resp, err := v.env.ec2.CreateVolume(vol)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
@@ -573,6 +582,107 @@ func destroyVolume(client *ec2.EC2, volumeId string) (err error) {
 	return nil
 }
 
+// CreateSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) CreateSnapshots(params []storage.SnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+	results := make([]storage.CreateSnapshotsResult, len(params))
+	for i, p := range params {
+		snapshot, err := v.createSnapshot(p)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (v *ebsVolumeSource) createSnapshot(p storage.SnapshotParams) (*storage.Snapshot, error) {
+	volume, err := describeVolume(v.env.ec2, p.VolumeId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	description := resourceName(p.Volume, v.envName)
+	resp, err := v.env.ec2.CreateSnapshot(p.VolumeId, description)
+	if err != nil {
+		return nil, errors.Annotatef(err, "creating snapshot of %q", p.VolumeId)
+	}
+	snapshotId := resp.Snapshot.Id
+
+	resourceTags := make(map[string]string)
+	for k, v := range p.ResourceTags {
+		resourceTags[k] = v
+	}
+	resourceTags[tagName] = description
+	if err := tagResources(v.env.ec2, resourceTags, snapshotId); err != nil {
+		if _, err := v.env.ec2.DeleteSnapshots([]string{snapshotId}); err != nil {
+			logger.Errorf("error cleaning up snapshot %v: %v", snapshotId, err)
+		}
+		return nil, errors.Annotate(err, "tagging snapshot")
+	}
+	return &storage.Snapshot{
+		SnapshotId: snapshotId,
+		Volume:     p.Volume,
+		Size:       gibToMib(uint64(volume.Size)),
+	}, nil
+}
+
+// ListSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) ListSnapshots() ([]string, error) {
+	filter := ec2.NewFilter()
+	filter.Add("tag:"+tags.JujuModel, v.modelUUID)
+	resp, err := v.env.ec2.Snapshots(nil, filter)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshotIds := make([]string, len(resp.Snapshots))
+	for i, snapshot := range resp.Snapshots {
+		snapshotIds[i] = snapshot.Id
+	}
+	return snapshotIds, nil
+}
+
+// DeleteSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) DeleteSnapshots(snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		_, err := v.env.ec2.DeleteSnapshots([]string{snapshotId})
+		if err != nil && ec2ErrCode(err) != snapshotNotFound {
+			results[i] = errors.Annotatef(err, "deleting snapshot %q", snapshotId)
+		}
+	}
+	return results, nil
+}
+
+// ImportVolume is specified on the storage.VolumeImporter interface.
+func (v *ebsVolumeSource) ImportVolume(volumeId string, resourceTags map[string]string, force bool) (storage.VolumeInfo, error) {
+	volume, err := describeVolume(v.env.ec2, volumeId)
+	if err != nil {
+		return storage.VolumeInfo{}, errors.Annotatef(err, "cannot get volume %q", volumeId)
+	}
+	if volume.Status != volumeStatusAvailable {
+		return storage.VolumeInfo{}, errors.Errorf(
+			"cannot import volume %q with status %q", volumeId, volume.Status,
+		)
+	}
+	if !force {
+		for _, tag := range volume.Tags {
+			if tag.Key == tags.JujuModel && tag.Value != resourceTags[tags.JujuModel] {
+				return storage.VolumeInfo{}, errors.Errorf("volume %q belongs to another model", volumeId)
+			}
+		}
+	}
+	// Re-tag the volume, replacing any tags identifying the
+	// model and controller that previously managed it.
+	if err := tagResources(v.env.ec2, resourceTags, volumeId); err != nil {
+		return storage.VolumeInfo{}, errors.Annotate(err, "tagging volume")
+	}
+	return storage.VolumeInfo{
+		VolumeId:   volumeId,
+		Size:       gibToMib(uint64(volume.Size)),
+		Persistent: true,
+	}, nil
+}
+
 // ValidateVolumeParams is specified on the storage.VolumeSource interface.
 func (v *ebsVolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
 	vol, err := parseVolumeOptions(params.Size, params.Attributes)
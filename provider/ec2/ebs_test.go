@@ -445,6 +445,165 @@ func (s *ebsSuite) TestListVolumesIgnoresRootDisks(c *gc.C) {
 	c.Assert(volIds, gc.HasLen, 0)
 }
 
+func (s *ebsSuite) createVolumeWithModelTag(c *gc.C, modelUUID string) string {
+	resp, err := s.client.CreateVolume(awsec2.CreateVolume{
+		AvailZone:  "test-available",
+		VolumeSize: 10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	if modelUUID != "" {
+		_, err = s.client.CreateTags([]string{resp.Id}, []awsec2.Tag{
+			{tags.JujuModel, modelUUID},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return resp.Id
+}
+
+func (s *ebsSuite) TestImportVolume(c *gc.C) {
+	volumeId := s.createVolumeWithModelTag(c, "")
+	vs := s.volumeSource(c, nil)
+	importer, ok := vs.(storage.VolumeImporter)
+	c.Assert(ok, jc.IsTrue)
+
+	info, err := importer.ImportVolume(volumeId, map[string]string{
+		tags.JujuModel: s.TestConfig["uuid"].(string),
+	}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, storage.VolumeInfo{
+		VolumeId:   volumeId,
+		Size:       10240,
+		Persistent: true,
+	})
+
+	volIds, err := vs.ListVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volIds, jc.SameContents, []string{volumeId})
+}
+
+func (s *ebsSuite) TestImportVolumeOtherModel(c *gc.C) {
+	volumeId := s.createVolumeWithModelTag(c, "a-different-model-uuid")
+	vs := s.volumeSource(c, nil)
+	importer := vs.(storage.VolumeImporter)
+	_, err := importer.ImportVolume(volumeId, map[string]string{
+		tags.JujuModel: s.TestConfig["uuid"].(string),
+	}, false)
+	c.Assert(err, gc.ErrorMatches, `volume "vol-0" belongs to another model`)
+}
+
+func (s *ebsSuite) TestImportVolumeOtherModelForce(c *gc.C) {
+	volumeId := s.createVolumeWithModelTag(c, "a-different-model-uuid")
+	vs := s.volumeSource(c, nil)
+	importer := vs.(storage.VolumeImporter)
+	_, err := importer.ImportVolume(volumeId, map[string]string{
+		tags.JujuModel: s.TestConfig["uuid"].(string),
+	}, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The volume is re-tagged with the importing model's UUID.
+	ec2Vols, err := s.client.Volumes([]string{volumeId}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ec2Vols.Volumes, gc.HasLen, 1)
+	c.Assert(ec2Vols.Volumes[0].Tags, jc.SameContents, []awsec2.Tag{
+		{tags.JujuModel, s.TestConfig["uuid"].(string)},
+	})
+}
+
+func (s *ebsSuite) TestImportVolumeInUse(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	params := s.setupAttachVolumesTest(c, vs, ec2test.Running)
+	_, err := vs.AttachVolumes(params)
+	c.Assert(err, jc.ErrorIsNil)
+	importer := vs.(storage.VolumeImporter)
+	_, err = importer.ImportVolume("vol-0", map[string]string{
+		tags.JujuModel: s.TestConfig["uuid"].(string),
+	}, false)
+	c.Assert(err, gc.ErrorMatches, `cannot import volume "vol-0" with status "in-use"`)
+}
+
+func (s *ebsSuite) TestImportVolumeNotFound(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	importer := vs.(storage.VolumeImporter)
+	_, err := importer.ImportVolume("vol-42", nil, false)
+	c.Assert(err, gc.ErrorMatches, `cannot get volume "vol-42": .*`)
+}
+
+func (s *ebsSuite) createSnapshot(c *gc.C, vs storage.VolumeSource, volumeId, modelUUID string) storage.Snapshot {
+	snapshotter := vs.(storage.VolumeSnapshotter)
+	results, err := snapshotter.CreateSnapshots([]storage.SnapshotParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: volumeId,
+		ResourceTags: map[string]string{
+			tags.JujuModel: modelUUID,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Snapshot, gc.NotNil)
+	return *results[0].Snapshot
+}
+
+func (s *ebsSuite) TestCreateSnapshots(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+
+	snapshot := s.createSnapshot(c, vs, "vol-0", s.TestConfig["uuid"].(string))
+	c.Assert(snapshot.SnapshotId, gc.Not(gc.Equals), "")
+	c.Assert(snapshot.Volume, gc.Equals, names.NewVolumeTag("0"))
+	c.Assert(snapshot.Size, gc.Equals, uint64(10240))
+
+	resp, err := s.client.Snapshots([]string{snapshot.SnapshotId}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Snapshots, gc.HasLen, 1)
+	c.Assert(resp.Snapshots[0].VolumeId, gc.Equals, "vol-0")
+}
+
+func (s *ebsSuite) TestCreateSnapshotsVolumeNotFound(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	results, err := vs.(storage.VolumeSnapshotter).CreateSnapshots([]storage.SnapshotParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "vol-42",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.NotNil)
+	c.Assert(results[0].Snapshot, gc.IsNil)
+
+	resp, err := s.client.Snapshots(nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Snapshots, gc.HasLen, 0)
+}
+
+func (s *ebsSuite) TestListSnapshots(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+	ours := s.createSnapshot(c, vs, "vol-0", s.TestConfig["uuid"].(string))
+	s.createSnapshot(c, vs, "vol-1", "something-else")
+
+	// Only the snapshot tagged with the model's
+	// UUID is listed.
+	snapshotIds, err := vs.(storage.VolumeSnapshotter).ListSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotIds, jc.DeepEquals, []string{ours.SnapshotId})
+}
+
+func (s *ebsSuite) TestDeleteSnapshots(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+	snapshot0 := s.createSnapshot(c, vs, "vol-0", s.TestConfig["uuid"].(string))
+	snapshot1 := s.createSnapshot(c, vs, "vol-1", s.TestConfig["uuid"].(string))
+
+	snapshotter := vs.(storage.VolumeSnapshotter)
+	errs, err := snapshotter.DeleteSnapshots([]string{snapshot0.SnapshotId})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+
+	snapshotIds, err := snapshotter.ListSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotIds, jc.DeepEquals, []string{snapshot1.SnapshotId})
+}
+
 func (s *ebsSuite) TestCreateVolumesErrors(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	volume0 := names.NewVolumeTag("0")
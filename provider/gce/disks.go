@@ -205,6 +205,7 @@ func (v *volumeSource) createOneVolume(p storage.VolumeParams, instances instanc
 		Name:               volumeName,
 		PersistentDiskType: persistentType,
 		Description:        v.modelUUID,
+		SourceSnapshot:     p.SnapshotId,
 	}
 
 	gceDisks, err := v.gce.CreateDisks(zone, []google.DiskSpec{disk})
@@ -344,6 +345,141 @@ func (v *volumeSource) describeOneVolume(volName string) (storage.DescribeVolume
 	return desc, nil
 }
 
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+func (v *volumeSource) ResizeVolumes(params []storage.ResizeVolumeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		size, err := v.resizeOneVolume(p)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (v *volumeSource) resizeOneVolume(p storage.ResizeVolumeParams) (uint64, error) {
+	zone, _, err := parseVolumeId(p.VolumeId)
+	if err != nil {
+		return 0, errors.Annotatef(err, "invalid volume id %q", p.VolumeId)
+	}
+	disk, err := v.gce.Disk(zone, p.VolumeId)
+	if err != nil {
+		return 0, errors.Annotatef(err, "cannot get volume %q", p.VolumeId)
+	}
+	if disk.Size >= p.Size {
+		// The disk has already been resized.
+		return disk.Size, nil
+	}
+	disk, err = v.gce.ResizeDisk(zone, p.VolumeId, mibToGib(p.Size))
+	if err != nil {
+		return 0, errors.Annotatef(err, "cannot resize volume %q", p.VolumeId)
+	}
+	return disk.Size, nil
+}
+
+// ImportVolume is specified on the storage.VolumeImporter interface.
+//
+// GCE does not allow the description of an existing disk to be changed,
+// so the model UUID cannot be recorded on the disk. Disks created for
+// another model are refused unless force is true; disks with no
+// description may be imported into any model.
+func (v *volumeSource) ImportVolume(volumeId string, resourceTags map[string]string, force bool) (storage.VolumeInfo, error) {
+	zone, _, err := parseVolumeId(volumeId)
+	if err != nil {
+		return storage.VolumeInfo{}, errors.NotValidf("volume ID %q", volumeId)
+	}
+	disk, err := v.gce.Disk(zone, volumeId)
+	if err != nil {
+		return storage.VolumeInfo{}, errors.Annotatef(err, "cannot get volume %q", volumeId)
+	}
+	if disk.Description != v.modelUUID && disk.Description != "" && !force {
+		return storage.VolumeInfo{}, errors.Errorf("volume %q belongs to another model", volumeId)
+	}
+	if disk.Status != google.StatusReady {
+		return storage.VolumeInfo{}, errors.Errorf(
+			"volume %q is not ready (status %q)", volumeId, disk.Status,
+		)
+	}
+	return storage.VolumeInfo{
+		VolumeId:   disk.Name,
+		Size:       disk.Size,
+		Persistent: true,
+	}, nil
+}
+
+// CreateSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *volumeSource) CreateSnapshots(params []storage.SnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+	results := make([]storage.CreateSnapshotsResult, len(params))
+	for i, p := range params {
+		snapshot, err := v.createOneSnapshot(p)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (v *volumeSource) createOneSnapshot(p storage.SnapshotParams) (*storage.Snapshot, error) {
+	zone, _, err := parseVolumeId(p.VolumeId)
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid volume id %q", p.VolumeId)
+	}
+	snapshotUUID, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot generate uuid to name the snapshot")
+	}
+	spec := google.SnapshotSpec{
+		Name:        snapshotPrefix + snapshotUUID.String(),
+		Description: v.modelUUID,
+	}
+	snapshot, err := v.gce.CreateSnapshot(zone, p.VolumeId, spec)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot snapshot volume %q", p.VolumeId)
+	}
+	return &storage.Snapshot{
+		SnapshotId: snapshot.Name,
+		Volume:     p.Volume,
+		Size:       snapshot.Size,
+	}, nil
+}
+
+// snapshotPrefix is prepended to the names of snapshots created by
+// Juju, so that we never lay hands on snapshots we did not create.
+const snapshotPrefix = "juju-snapshot-"
+
+// ListSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *volumeSource) ListSnapshots() ([]string, error) {
+	snapshots, err := v.gce.Snapshots()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot list snapshots")
+	}
+	var names []string
+	for _, snapshot := range snapshots {
+		if snapshot.Description != v.modelUUID {
+			continue
+		}
+		if strings.HasPrefix(snapshot.Name, snapshotPrefix) {
+			names = append(names, snapshot.Name)
+		}
+	}
+	return names, nil
+}
+
+// DeleteSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *volumeSource) DeleteSnapshots(snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, name := range snapshotIds {
+		if err := v.gce.RemoveSnapshot(name); err != nil {
+			results[i] = errors.Annotatef(err, "cannot delete snapshot %q", name)
+		}
+	}
+	return results, nil
+}
+
 // TODO(perrito666) These rules are yet to be defined.
 func (v *volumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
 	return nil
@@ -302,3 +302,138 @@ func (s *volumeSourceSuite) TestDetachVolumes(c *gc.C) {
 	c.Assert(call[0].InstanceId, gc.Equals, string(s.instId))
 	c.Assert(call[0].VolumeName, gc.Equals, volName)
 }
+
+func (s *volumeSourceSuite) TestCreateSnapshots(c *gc.C) {
+	s.FakeConn.GoogleSnapshot = &google.Snapshot{
+		Name:       "juju-snapshot-1234",
+		SourceDisk: s.BaseDisk.Name,
+		Size:       10240,
+	}
+	snapshotter, ok := s.source.(storage.VolumeSnapshotter)
+	c.Assert(ok, jc.IsTrue)
+	res, err := snapshotter.CreateSnapshots([]storage.SnapshotParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: s.BaseDisk.Name,
+	}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, gc.HasLen, 1)
+	c.Assert(res[0].Error, jc.ErrorIsNil)
+	c.Assert(res[0].Snapshot, jc.DeepEquals, &storage.Snapshot{
+		SnapshotId: "juju-snapshot-1234",
+		Volume:     names.NewVolumeTag("0"),
+		Size:       10240,
+	})
+
+	createCalled, call := s.FakeConn.WasCalled("CreateSnapshot")
+	c.Assert(createCalled, jc.IsTrue)
+	c.Assert(call, gc.HasLen, 1)
+	c.Assert(call[0].ZoneName, gc.Equals, "home-zone")
+	c.Assert(call[0].VolumeName, gc.Equals, s.BaseDisk.Name)
+	c.Assert(call[0].SnapshotSpec.Name, jc.HasPrefix, "juju-snapshot-")
+	c.Assert(call[0].SnapshotSpec.Description, gc.Equals, s.Env.Config().UUID())
+}
+
+func (s *volumeSourceSuite) TestResizeVolumes(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	resized := *s.BaseDisk
+	resized.Size = 3072
+	s.FakeConn.ResizedDisk = &resized
+	resizer, ok := s.source.(storage.VolumeResizer)
+	c.Assert(ok, jc.IsTrue)
+	res, err := resizer.ResizeVolumes([]storage.ResizeVolumeParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: s.BaseDisk.Name,
+		Size:     2500,
+	}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 3072}})
+
+	resizeCalled, call := s.FakeConn.WasCalled("ResizeDisk")
+	c.Assert(resizeCalled, jc.IsTrue)
+	c.Assert(call, gc.HasLen, 1)
+	c.Assert(call[0].ZoneName, gc.Equals, "home-zone")
+	c.Assert(call[0].VolumeName, gc.Equals, s.BaseDisk.Name)
+	// The requested size is rounded up to the nearest GiB.
+	c.Assert(call[0].SizeGb, gc.Equals, uint64(3))
+}
+
+func (s *volumeSourceSuite) TestResizeVolumesAlreadyResized(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	resizer := s.source.(storage.VolumeResizer)
+	res, err := resizer.ResizeVolumes([]storage.ResizeVolumeParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: s.BaseDisk.Name,
+		Size:     1024,
+	}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 1024}})
+	resizeCalled, _ := s.FakeConn.WasCalled("ResizeDisk")
+	c.Assert(resizeCalled, jc.IsFalse)
+}
+
+func (s *volumeSourceSuite) TestImportVolume(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	importer, ok := s.source.(storage.VolumeImporter)
+	c.Assert(ok, jc.IsTrue)
+	info, err := importer.ImportVolume(s.BaseDisk.Name, nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, storage.VolumeInfo{
+		VolumeId:   s.BaseDisk.Name,
+		Size:       1024,
+		Persistent: true,
+	})
+}
+
+func (s *volumeSourceSuite) TestImportVolumeOtherModel(c *gc.C) {
+	disk := *s.BaseDisk
+	disk.Description = "a-different-model-uuid"
+	s.FakeConn.GoogleDisk = &disk
+	importer := s.source.(storage.VolumeImporter)
+	_, err := importer.ImportVolume(s.BaseDisk.Name, nil, false)
+	c.Assert(err, gc.ErrorMatches, `volume "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4" belongs to another model`)
+}
+
+func (s *volumeSourceSuite) TestImportVolumeOtherModelForce(c *gc.C) {
+	disk := *s.BaseDisk
+	disk.Description = "a-different-model-uuid"
+	s.FakeConn.GoogleDisk = &disk
+	importer := s.source.(storage.VolumeImporter)
+	info, err := importer.ImportVolume(s.BaseDisk.Name, nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, storage.VolumeInfo{
+		VolumeId:   s.BaseDisk.Name,
+		Size:       1024,
+		Persistent: true,
+	})
+}
+
+func (s *volumeSourceSuite) TestImportVolumeInvalidId(c *gc.C) {
+	importer := s.source.(storage.VolumeImporter)
+	_, err := importer.ImportVolume("my-disk", nil, false)
+	c.Assert(err, gc.ErrorMatches, `volume ID "my-disk" not valid`)
+}
+
+func (s *volumeSourceSuite) TestListSnapshotsOnlyListsCurrentModelUUID(c *gc.C) {
+	uuid := s.Env.Config().UUID()
+	s.FakeConn.GoogleSnapshots = []*google.Snapshot{
+		{Name: "juju-snapshot-1", Description: uuid},
+		{Name: "juju-snapshot-2", Description: "a-different-model-uuid"},
+		{Name: "not-ours", Description: uuid},
+	}
+	snapshotter := s.source.(storage.VolumeSnapshotter)
+	ids, err := snapshotter.ListSnapshots()
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []string{"juju-snapshot-1"})
+}
+
+func (s *volumeSourceSuite) TestDeleteSnapshots(c *gc.C) {
+	snapshotter := s.source.(storage.VolumeSnapshotter)
+	errs, err := snapshotter.DeleteSnapshots([]string{"juju-snapshot-1"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+
+	removeCalled, call := s.FakeConn.WasCalled("RemoveSnapshot")
+	c.Assert(removeCalled, jc.IsTrue)
+	c.Assert(call, gc.HasLen, 1)
+	c.Assert(call[0].ID, gc.Equals, "juju-snapshot-1")
+}
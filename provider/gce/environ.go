@@ -55,6 +55,16 @@ type gceConnection interface {
 	Disk(zone, id string) (*google.Disk, error)
 	// RemoveDisk will destroy the disk identified by <name> in <zone>.
 	RemoveDisk(zone, id string) error
+	// ResizeDisk will grow the disk identified by <name> in <zone> to
+	// <sizeGb> GiB, returning the resized disk.
+	ResizeDisk(zone, name string, sizeGb uint64) (*google.Disk, error)
+	// CreateSnapshot will create a snapshot of the disk identified by
+	// <diskName> in <zone>, as described by <spec>.
+	CreateSnapshot(zone, diskName string, spec google.SnapshotSpec) (*google.Snapshot, error)
+	// Snapshots will return a list of the snapshots in the project.
+	Snapshots() ([]*google.Snapshot, error)
+	// RemoveSnapshot will destroy the snapshot identified by <name>.
+	RemoveSnapshot(name string) error
 	// AttachDisk will attach the volume identified by <volumeName> into the instance
 	// <instanceId> and return an AttachedDisk representing it or error.
 	AttachDisk(zone, volumeName, instanceId string, mode google.DiskMode) (*google.AttachedDisk, error)
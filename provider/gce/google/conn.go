@@ -65,6 +65,18 @@ type rawConnectionWrapper interface {
 	RemoveDisk(project, zone, id string) error
 	// GetDisk will return the disk correspondent to the passed id.
 	GetDisk(project, zone, id string) (*compute.Disk, error)
+	// ResizeDisk will grow the disk named diskName to sizeGb GiB.
+	ResizeDisk(project, zone, diskName string, sizeGb int64) error
+	// CreateSnapshot will create a snapshot of the disk named diskName
+	// that matches the specified spec.
+	CreateSnapshot(project, zone, diskName string, spec *compute.Snapshot) error
+	// ListSnapshots returns a list of snapshots available for a given
+	// project.
+	ListSnapshots(project string) ([]*compute.Snapshot, error)
+	// GetSnapshot will return the snapshot with the passed name.
+	GetSnapshot(project, name string) (*compute.Snapshot, error)
+	// RemoveSnapshot will delete the snapshot with the passed name.
+	RemoveSnapshot(project, name string) error
 	// AttachDisk will attach the disk described in attachedDisks (if it exists) into
 	// the instance with id instanceId.
 	AttachDisk(project, zone, instanceId string, attachedDisk *compute.AttachedDisk) error
@@ -59,6 +59,56 @@ func (gce *Connection) Disk(zone, name string) (*Disk, error) {
 	return NewDisk(d), nil
 }
 
+// ResizeDisk implements storage section of gceConnection.
+func (gce *Connection) ResizeDisk(zone, name string, sizeGb uint64) (*Disk, error) {
+	if err := gce.raw.ResizeDisk(gce.projectID, zone, name, int64(sizeGb)); err != nil {
+		return nil, errors.Annotatef(err, "cannot resize disk %q in zone %q", name, zone)
+	}
+	d, err := gce.raw.GetDisk(gce.projectID, zone, name)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get disk %q in zone %q", name, zone)
+	}
+	return NewDisk(d), nil
+}
+
+// CreateSnapshot implements storage section of gceConnection.
+func (gce *Connection) CreateSnapshot(zone, diskName string, spec SnapshotSpec) (*Snapshot, error) {
+	snapshot := &compute.Snapshot{
+		Name:        spec.Name,
+		Description: spec.Description,
+	}
+	if err := gce.raw.CreateSnapshot(gce.projectID, zone, diskName, snapshot); err != nil {
+		return nil, errors.Annotatef(err, "cannot create snapshot %q", spec.Name)
+	}
+	created, err := gce.raw.GetSnapshot(gce.projectID, spec.Name)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get snapshot %q", spec.Name)
+	}
+	return NewSnapshot(created), nil
+}
+
+// Snapshots implements storage section of gceConnection.
+func (gce *Connection) Snapshots() ([]*Snapshot, error) {
+	computeSnapshots, err := gce.raw.ListSnapshots(gce.projectID)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot list snapshots")
+	}
+	snapshots := make([]*Snapshot, len(computeSnapshots))
+	for i, snapshot := range computeSnapshots {
+		snapshots[i] = NewSnapshot(snapshot)
+	}
+	return snapshots, nil
+}
+
+// RemoveSnapshot implements storage section of gceConnection.
+func (gce *Connection) RemoveSnapshot(name string) error {
+	err := gce.raw.RemoveSnapshot(gce.projectID, name)
+	if errors.IsNotFound(convertRawAPIError(errors.Cause(err))) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
 // deviceName will generate a device name from the passed
 // <zone> and <diskId>, the device name must not be confused
 // with the volume name, as it is used mainly to name the
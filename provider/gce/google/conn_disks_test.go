@@ -72,6 +72,23 @@ func (s *connSuite) TestConnectionDisk(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
 }
 
+func (s *connSuite) TestConnectionResizeDisk(c *gc.C) {
+	_, fakeDisk, err := fakeDiskAndSpec()
+	c.Check(err, jc.ErrorIsNil)
+	s.FakeConn.Disk = fakeDisk
+	disk, err := s.Conn.ResizeDisk("home-zone", fakeVolName, 20)
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(disk, gc.DeepEquals, google.NewDisk(fakeDisk))
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ResizeDisk")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, fakeVolName)
+	c.Check(s.FakeConn.Calls[0].SizeGb, gc.Equals, int64(20))
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "GetDisk")
+}
+
 func (s *connSuite) TestConnectionAttachDisk(c *gc.C) {
 	_, fakeDisk, err := fakeDiskAndSpec()
 	c.Check(err, jc.ErrorIsNil)
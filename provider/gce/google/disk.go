@@ -114,6 +114,9 @@ type DiskSpec struct {
 	// Description was picked because it is not mutable (actually no field is) for disks.
 	// There is a metadata API but it is not supported for disks for the moment.
 	Description string
+	// SourceSnapshot is the name of the snapshot from which the disk
+	// should be initialized, if any. (detached only)
+	SourceSnapshot string
 }
 
 // TooSmall checks the spec's size hint and indicates whether or not
@@ -175,13 +178,17 @@ func (ds *DiskSpec) newDetached() (*compute.Disk, error) {
 	if ds.PersistentDiskType == DiskLocalSSD {
 		return nil, errors.New("cannot create local ssd disks detached")
 	}
-	return &compute.Disk{
+	disk := &compute.Disk{
 		Name:        ds.Name,
 		SizeGb:      int64(ds.SizeGB()),
 		SourceImage: ds.ImageURL,
 		Type:        string(ds.PersistentDiskType),
 		Description: ds.Description,
-	}, nil
+	}
+	if ds.SourceSnapshot != "" {
+		disk.SourceSnapshot = snapshotSource(ds.SourceSnapshot)
+	}
+	return disk, nil
 }
 
 // AttachedDisk represents a disk that is attached to an instance.
@@ -228,3 +235,47 @@ func NewDisk(cd *compute.Disk) *Disk {
 	}
 	return d
 }
+
+// SnapshotSpec holds all the data needed to request a new snapshot
+// of a disk on GCE.
+type SnapshotSpec struct {
+	// Name is the name of the snapshot; it has the same restrictions
+	// as disk names.
+	Name string
+	// Description holds a description of the snapshot; as for disks,
+	// it currently holds the modelUUID.
+	Description string
+}
+
+// Snapshot represents a gce disk snapshot.
+type Snapshot struct {
+	// Name is a unique identifier string for each snapshot.
+	Name string
+	// Description holds the description field for a snapshot, we
+	// store env UUID here.
+	Description string
+	// SourceDisk is the name of the disk the snapshot was taken of.
+	SourceDisk string
+	// Size is the size of the source disk in MiB.
+	Size uint64
+	// Status holds the status of the snapshot.
+	Status string
+}
+
+// NewSnapshot returns a Snapshot describing the provided compute
+// snapshot.
+func NewSnapshot(cs *compute.Snapshot) *Snapshot {
+	return &Snapshot{
+		Name:        cs.Name,
+		Description: cs.Description,
+		SourceDisk:  sourceToVolumeName(cs.SourceDisk),
+		Size:        gibToMib(cs.DiskSizeGb),
+		Status:      cs.Status,
+	}
+}
+
+// snapshotSource returns the partial URL by which a disk refers to
+// the named snapshot.
+func snapshotSource(name string) string {
+	return "global/snapshots/" + name
+}
@@ -245,6 +245,57 @@ func (rc *rawConn) RemoveDisk(project, zone, id string) error {
 	return errors.Trace(rc.waitOperation(project, op, attemptsLong))
 }
 
+func (rc *rawConn) ResizeDisk(project, zone, diskName string, sizeGb int64) error {
+	request := &compute.DisksResizeRequest{SizeGb: sizeGb}
+	op, err := rc.Disks.Resize(project, zone, diskName, request).Do()
+	if err != nil {
+		return errors.Annotatef(err, "could not resize disk %q", diskName)
+	}
+	return errors.Trace(rc.waitOperation(project, op, attemptsLong))
+}
+
+func (rc *rawConn) CreateSnapshot(project, zone, diskName string, spec *compute.Snapshot) error {
+	call := rc.Disks.CreateSnapshot(project, zone, diskName, spec)
+	op, err := call.Do()
+	if err != nil {
+		return errors.Annotatef(err, "could not create a snapshot of disk %q", diskName)
+	}
+	return errors.Trace(rc.waitOperation(project, op, attemptsLong))
+}
+
+func (rc *rawConn) ListSnapshots(project string) ([]*compute.Snapshot, error) {
+	call := rc.Snapshots.List(project)
+	var results []*compute.Snapshot
+	for {
+		snapshotList, err := call.Do()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		results = append(results, snapshotList.Items...)
+		if snapshotList.NextPageToken == "" {
+			break
+		}
+		call = call.PageToken(snapshotList.NextPageToken)
+	}
+	return results, nil
+}
+
+func (rc *rawConn) GetSnapshot(project, name string) (*compute.Snapshot, error) {
+	snapshot, err := rc.Snapshots.Get(project, name).Do()
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get snapshot %q in project %q", name, project)
+	}
+	return snapshot, nil
+}
+
+func (rc *rawConn) RemoveSnapshot(project, name string) error {
+	op, err := rc.Snapshots.Delete(project, name).Do()
+	if err != nil {
+		return errors.Annotatef(err, "could not delete snapshot %q", name)
+	}
+	return errors.Trace(rc.waitOperation(project, op, attemptsLong))
+}
+
 func (rc *rawConn) GetDisk(project, zone, id string) (*compute.Disk, error) {
 	ds := rc.Disks
 	call := ds.Get(project, zone, id)
@@ -474,6 +474,8 @@ type fakeConnCall struct {
 	Rules        []network.IngressRule
 	Region       string
 	Disks        []google.DiskSpec
+	SnapshotSpec google.SnapshotSpec
+	SizeGb       uint64
 	VolumeName   string
 	InstanceId   string
 	Mode         string
@@ -493,9 +495,13 @@ type fakeConn struct {
 
 	GoogleDisks   []*google.Disk
 	GoogleDisk    *google.Disk
+	ResizedDisk   *google.Disk
 	AttachedDisk  *google.AttachedDisk
 	AttachedDisks []*google.AttachedDisk
 
+	GoogleSnapshot  *google.Snapshot
+	GoogleSnapshots []*google.Snapshot
+
 	Err        error
 	FailOnCall int
 }
@@ -635,6 +641,41 @@ func (fc *fakeConn) RemoveDisk(zone, id string) error {
 	return fc.err()
 }
 
+func (fc *fakeConn) ResizeDisk(zone, name string, sizeGb uint64) (*google.Disk, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:   "ResizeDisk",
+		ZoneName:   zone,
+		VolumeName: name,
+		SizeGb:     sizeGb,
+	})
+	return fc.ResizedDisk, fc.err()
+}
+
+func (fc *fakeConn) CreateSnapshot(zone, diskName string, spec google.SnapshotSpec) (*google.Snapshot, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "CreateSnapshot",
+		ZoneName:     zone,
+		VolumeName:   diskName,
+		SnapshotSpec: spec,
+	})
+	return fc.GoogleSnapshot, fc.err()
+}
+
+func (fc *fakeConn) Snapshots() ([]*google.Snapshot, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "Snapshots",
+	})
+	return fc.GoogleSnapshots, fc.err()
+}
+
+func (fc *fakeConn) RemoveSnapshot(name string) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "RemoveSnapshot",
+		ID:       name,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) Disk(zone, id string) (*google.Disk, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName: "Disk",
@@ -186,6 +186,13 @@ func (env *environ) newRawInstance(
 		return nil, errors.Trace(err)
 	}
 
+	// If the instance must be started in a space, attach it to
+	// the managed network of one of the space's subnets.
+	devices, err := env.networkDevices(args.SubnetsToZones)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	// TODO(ericsnow) Use the env ID for the network name (instead of default)?
 	// TODO(ericsnow) Make the network name configurable?
 	// TODO(ericsnow) Support multiple networks?
@@ -197,6 +204,7 @@ func (env *environ) newRawInstance(
 		//Disks:             getDisks(spec, args.Constraints),
 		//NetworkInterfaces: []string{"ExternalNAT"},
 		Metadata: metadata,
+		Devices:  devices,
 		Profiles: []string{
 			//TODO(wwitzel3) allow the user to specify lxc profiles to apply. This allows the
 			// user to setup any custom devices order config settings for their environment.
@@ -6,10 +6,20 @@
 package lxd
 
 import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/tools/lxdclient"
 )
 
 // globalFirewallName returns the name to use for the global firewall.
@@ -52,3 +62,244 @@ func (env *environ) IngressRules() ([]network.IngressRule, error) {
 	}
 	return ports, errors.Trace(err)
 }
+
+// Subnets implements environs.NetworkingEnviron.
+//
+// LXD has no subnet model of its own, so subnets are discovered from
+// the LXD host's managed networks and from the addresses of the
+// model's containers, and are identified by their CIDRs. If an
+// instance is specified, only the subnets of its interfaces are
+// returned.
+func (env *environ) Subnets(instId instance.Id, subnetIds []network.Id) ([]network.SubnetInfo, error) {
+	networkSubnets, err := env.networkSubnets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	networkIds := make(map[string]network.Id)
+	for _, subnet := range networkSubnets {
+		networkIds[subnet.CIDR] = subnet.ProviderNetworkId
+	}
+
+	var subnets []network.SubnetInfo
+	var instIds []instance.Id
+	if instId != instance.UnknownId {
+		instIds = []instance.Id{instId}
+	} else {
+		subnets = networkSubnets
+		instances, err := env.allInstances()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, inst := range instances {
+			instIds = append(instIds, inst.Id())
+		}
+	}
+	seen := set.NewStrings()
+	for _, subnet := range subnets {
+		seen.Add(subnet.CIDR)
+	}
+	for _, id := range instIds {
+		interfaces, err := env.instanceInterfaces(id, networkIds)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, subnet := range common.SubnetsFromInterfaces(interfaces) {
+			if !seen.Contains(subnet.CIDR) {
+				seen.Add(subnet.CIDR)
+				subnets = append(subnets, subnet)
+			}
+		}
+	}
+	return common.FilterSubnets(subnets, subnetIds)
+}
+
+// networkSubnets returns the subnets of the LXD host's managed
+// networks. Each managed network is configured with the address
+// of the host on the network, e.g. "10.0.8.1/24".
+func (env *environ) networkSubnets() ([]network.SubnetInfo, error) {
+	networks, err := env.raw.NetworkList()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("not discovering LXD networks: %v", err)
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var subnets []network.SubnetInfo
+	for _, lxdNetwork := range networks {
+		if !lxdNetwork.Managed {
+			continue
+		}
+		for _, key := range []string{"ipv4.address", "ipv6.address"} {
+			_, ipNet, err := net.ParseCIDR(lxdNetwork.Config[key])
+			if err != nil {
+				// The address is "none", or not yet allocated.
+				continue
+			}
+			subnets = append(subnets, network.SubnetInfo{
+				ProviderId:        network.Id(ipNet.String()),
+				ProviderNetworkId: network.Id(lxdNetwork.Name),
+				CIDR:              ipNet.String(),
+			})
+		}
+	}
+	return subnets, nil
+}
+
+// NetworkInterfaces implements environs.NetworkingEnviron.
+func (env *environ) NetworkInterfaces(instId instance.Id) ([]network.InterfaceInfo, error) {
+	networkSubnets, err := env.networkSubnets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	networkIds := make(map[string]network.Id)
+	for _, subnet := range networkSubnets {
+		networkIds[subnet.CIDR] = subnet.ProviderNetworkId
+	}
+	return env.instanceInterfaces(instId, networkIds)
+}
+
+// instanceInterfaces returns the network interfaces of the container,
+// with an entry for each of an interface's global addresses. The
+// network IDs of subnets on the host's managed networks are taken
+// from networkIds, keyed by CIDR.
+func (env *environ) instanceInterfaces(instId instance.Id, networkIds map[string]network.Id) ([]network.InterfaceInfo, error) {
+	state, err := env.raw.InstanceState(string(instId))
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting state of instance %q", instId)
+	}
+	var names []string
+	for name, netState := range state.Network {
+		if netState.Type == "loopback" {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var results []network.InterfaceInfo
+	for i, name := range names {
+		netState := state.Network[name]
+		info := network.InterfaceInfo{
+			DeviceIndex:   i,
+			MACAddress:    netState.Hwaddr,
+			ProviderId:    network.Id(fmt.Sprintf("%s/%s", instId, name)),
+			InterfaceName: name,
+			InterfaceType: network.EthernetInterface,
+			ConfigType:    network.ConfigDHCP,
+			MTU:           netState.Mtu,
+		}
+		var haveAddress bool
+		for _, addr := range netState.Addresses {
+			if addr.Scope != "global" {
+				continue
+			}
+			prefixLength, err := strconv.Atoi(addr.Netmask)
+			if err != nil {
+				logger.Warningf("ignoring address %q of %s on %q: invalid netmask %q", addr.Address, name, instId, addr.Netmask)
+				continue
+			}
+			cidr, err := common.SubnetCIDR(addr.Address, prefixLength)
+			if err != nil {
+				logger.Warningf("ignoring address %q of %s on %q: %v", addr.Address, name, instId, err)
+				continue
+			}
+			addrInfo := info
+			addrInfo.CIDR = cidr
+			addrInfo.ProviderSubnetId = network.Id(cidr)
+			addrInfo.ProviderNetworkId = networkIds[cidr]
+			addrInfo.Address = network.NewAddress(addr.Address)
+			results = append(results, addrInfo)
+			haveAddress = true
+		}
+		if !haveAddress {
+			results = append(results, info)
+		}
+	}
+	return results, nil
+}
+
+// networkDevices returns the NIC device which attaches a container
+// to the managed network of one of the specified subnets, or nil if
+// no subnets are specified. The device overrides the default
+// profile's "eth0" device.
+func (env *environ) networkDevices(subnetsToZones map[network.Id][]string) (lxdclient.Devices, error) {
+	if len(subnetsToZones) == 0 {
+		return nil, nil
+	}
+	networkSubnets, err := env.networkSubnets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	networkIds := make(map[network.Id]network.Id)
+	for _, subnet := range networkSubnets {
+		networkIds[subnet.ProviderId] = subnet.ProviderNetworkId
+	}
+	var subnetIds []string
+	for id := range subnetsToZones {
+		subnetIds = append(subnetIds, string(id))
+	}
+	sort.Strings(subnetIds)
+	for _, id := range subnetIds {
+		networkId, ok := networkIds[network.Id(id)]
+		if !ok {
+			continue
+		}
+		return lxdclient.Devices{
+			"eth0": lxdclient.Device{
+				"type":    "nic",
+				"nictype": "bridged",
+				"parent":  string(networkId),
+				"name":    "eth0",
+			},
+		}, nil
+	}
+	return nil, errors.Errorf("no LXD network found for subnets %v", subnetIds)
+}
+
+// SupportsSpaces implements environs.NetworkingEnviron.
+//
+// Spaces are not discovered, but may be created from the
+// discovered subnets with "juju add-space".
+func (env *environ) SupportsSpaces() (bool, error) {
+	return true, nil
+}
+
+// SupportsSpaceDiscovery implements environs.NetworkingEnviron.
+func (env *environ) SupportsSpaceDiscovery() (bool, error) {
+	return false, nil
+}
+
+// Spaces implements environs.NetworkingEnviron.
+func (env *environ) Spaces() ([]network.SpaceInfo, error) {
+	return nil, errors.NotSupportedf("spaces")
+}
+
+// ProviderSpaceInfo implements environs.NetworkingEnviron.
+func (*environ) ProviderSpaceInfo(space *network.SpaceInfo) (*environs.ProviderSpaceInfo, error) {
+	return nil, errors.NotSupportedf("provider space info")
+}
+
+// AreSpacesRoutable implements environs.NetworkingEnviron.
+func (*environ) AreSpacesRoutable(space1, space2 *environs.ProviderSpaceInfo) (bool, error) {
+	return false, nil
+}
+
+// SupportsContainerAddresses implements environs.NetworkingEnviron.
+func (env *environ) SupportsContainerAddresses() (bool, error) {
+	return false, errors.NotSupportedf("container address allocation")
+}
+
+// AllocateContainerAddresses implements environs.NetworkingEnviron.
+func (env *environ) AllocateContainerAddresses(instance.Id, names.MachineTag, []network.InterfaceInfo) ([]network.InterfaceInfo, error) {
+	return nil, errors.NotSupportedf("container address allocation")
+}
+
+// ReleaseContainerAddresses implements environs.NetworkingEnviron.
+func (env *environ) ReleaseContainerAddresses([]network.ProviderInterfaceInfo) error {
+	return errors.NotSupportedf("container address allocation")
+}
+
+// SSHAddresses implements environs.SSHAddresses.
+func (*environ) SSHAddresses(addresses []network.Address) ([]network.Address, error) {
+	return addresses, nil
+}
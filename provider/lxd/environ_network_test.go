@@ -6,11 +6,17 @@
 package lxd_test
 
 import (
+	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/lxc/lxd/shared/api"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/lxd"
+	"github.com/juju/juju/tools/lxdclient"
 )
 
 type environNetSuite struct {
@@ -87,3 +93,162 @@ func (s *environNetSuite) TestPortsAPI(c *gc.C) {
 		},
 	}})
 }
+
+func (s *environNetSuite) setUpNetworks(c *gc.C) {
+	s.Client.Networks = []api.Network{{
+		Name:    "lxdbr0",
+		Type:    "bridge",
+		Managed: true,
+		NetworkPut: api.NetworkPut{
+			Config: map[string]string{
+				"ipv4.address": "10.0.8.1/24",
+				"ipv6.address": "none",
+			},
+		},
+	}, {
+		Name: "eth0",
+		Type: "physical",
+	}}
+	s.Client.Insts = []lxdclient.Instance{*s.NewRawInstance(c, "juju-spam")}
+	s.Client.InstanceStates = map[string]*api.ContainerState{
+		"juju-spam": {
+			Network: map[string]api.ContainerStateNetwork{
+				"lo": {
+					Type: "loopback",
+					Addresses: []api.ContainerStateNetworkAddress{{
+						Family: "inet", Address: "127.0.0.1", Netmask: "8", Scope: "local",
+					}},
+				},
+				"eth1": {
+					Type:   "broadcast",
+					Hwaddr: "00:16:3e:00:00:02",
+					Mtu:    9000,
+					Addresses: []api.ContainerStateNetworkAddress{{
+						Family: "inet", Address: "192.168.1.10", Netmask: "24", Scope: "global",
+					}},
+				},
+				"eth0": {
+					Type:   "broadcast",
+					Hwaddr: "00:16:3e:00:00:01",
+					Mtu:    1500,
+					Addresses: []api.ContainerStateNetworkAddress{{
+						Family: "inet", Address: "10.0.8.10", Netmask: "24", Scope: "global",
+					}, {
+						Family: "inet6", Address: "fe80::216:3eff:fe00:1", Netmask: "64", Scope: "link",
+					}},
+				},
+			},
+		},
+	}
+}
+
+func (s *environNetSuite) TestNetworking(c *gc.C) {
+	c.Assert(environs.SupportsSpaces(s.Env), jc.IsTrue)
+
+	supported, err := s.Env.SupportsSpaces()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(supported, jc.IsTrue)
+
+	supported, err = s.Env.SupportsSpaceDiscovery()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(supported, jc.IsFalse)
+
+	_, err = s.Env.Spaces()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *environNetSuite) TestNetworkInterfaces(c *gc.C) {
+	s.setUpNetworks(c)
+
+	interfaces, err := s.Env.NetworkInterfaces("juju-spam")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(interfaces, jc.DeepEquals, []network.InterfaceInfo{{
+		DeviceIndex:       0,
+		MACAddress:        "00:16:3e:00:00:01",
+		CIDR:              "10.0.8.0/24",
+		ProviderId:        "juju-spam/eth0",
+		ProviderSubnetId:  "10.0.8.0/24",
+		ProviderNetworkId: "lxdbr0",
+		InterfaceName:     "eth0",
+		InterfaceType:     network.EthernetInterface,
+		ConfigType:        network.ConfigDHCP,
+		Address:           network.NewAddress("10.0.8.10"),
+		MTU:               1500,
+	}, {
+		DeviceIndex:      1,
+		MACAddress:       "00:16:3e:00:00:02",
+		CIDR:             "192.168.1.0/24",
+		ProviderId:       "juju-spam/eth1",
+		ProviderSubnetId: "192.168.1.0/24",
+		InterfaceName:    "eth1",
+		InterfaceType:    network.EthernetInterface,
+		ConfigType:       network.ConfigDHCP,
+		Address:          network.NewAddress("192.168.1.10"),
+		MTU:              9000,
+	}})
+}
+
+func (s *environNetSuite) TestSubnets(c *gc.C) {
+	s.setUpNetworks(c)
+
+	subnets, err := s.Env.Subnets(instance.UnknownId, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, jc.DeepEquals, []network.SubnetInfo{{
+		ProviderId:        "10.0.8.0/24",
+		ProviderNetworkId: "lxdbr0",
+		CIDR:              "10.0.8.0/24",
+	}, {
+		ProviderId: "192.168.1.0/24",
+		CIDR:       "192.168.1.0/24",
+	}})
+
+	subnets, err = s.Env.Subnets("juju-spam", []network.Id{"192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, jc.DeepEquals, []network.SubnetInfo{{
+		ProviderId: "192.168.1.0/24",
+		CIDR:       "192.168.1.0/24",
+	}})
+
+	_, err = s.Env.Subnets(instance.UnknownId, []network.Id{"10.0.9.0/24"})
+	c.Assert(err, gc.ErrorMatches, `subnets \["10.0.9.0/24"\] not found`)
+}
+
+func (s *environNetSuite) TestSubnetsNetworkAPINotSupported(c *gc.C) {
+	s.setUpNetworks(c)
+	s.Stub.SetErrors(errors.NotSupportedf("network API"))
+
+	subnets, err := s.Env.Subnets(instance.UnknownId, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, jc.DeepEquals, []network.SubnetInfo{{
+		ProviderId: "10.0.8.0/24",
+		CIDR:       "10.0.8.0/24",
+	}, {
+		ProviderId: "192.168.1.0/24",
+		CIDR:       "192.168.1.0/24",
+	}})
+}
+
+func (s *environNetSuite) TestNetworkDevices(c *gc.C) {
+	s.setUpNetworks(c)
+
+	devices, err := lxd.NetworkDevices(s.Env, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(devices, gc.IsNil)
+
+	devices, err = lxd.NetworkDevices(s.Env, map[network.Id][]string{
+		"192.168.1.0/24": nil,
+		"10.0.8.0/24":    nil,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(devices, jc.DeepEquals, lxdclient.Devices{
+		"eth0": lxdclient.Device{
+			"type":    "nic",
+			"nictype": "bridged",
+			"parent":  "lxdbr0",
+			"name":    "eth0",
+		},
+	})
+
+	_, err = lxd.NetworkDevices(s.Env, map[network.Id][]string{"192.168.1.0/24": nil})
+	c.Assert(err, gc.ErrorMatches, `no LXD network found for subnets \[192.168.1.0/24\]`)
+}
@@ -22,6 +22,7 @@ type rawProvider struct {
 	lxdProfiles
 	lxdImages
 	lxdStorage
+	lxdNetworks
 	common.Firewaller
 
 	remote lxdclient.Remote
@@ -45,6 +46,7 @@ type lxdInstances interface {
 	AddInstance(lxdclient.InstanceSpec) (*lxdclient.Instance, error)
 	RemoveInstances(string, ...string) error
 	Addresses(string) ([]network.Address, error)
+	InstanceState(string) (*lxdapi.ContainerState, error)
 	AttachDisk(string, string, lxdclient.DiskDevice) error
 	RemoveDevice(string, string) error
 }
@@ -66,6 +68,10 @@ type lxdStorage interface {
 	VolumeList(pool string) ([]lxdapi.StorageVolume, error)
 }
 
+type lxdNetworks interface {
+	NetworkList() ([]lxdapi.Network, error)
+}
+
 func newRawProvider(spec environs.CloudSpec, local bool) (*rawProvider, error) {
 	if local {
 		return newLocalRawProvider()
@@ -98,6 +104,7 @@ func newRawProviderFromConfig(config lxdclient.Config) (*rawProvider, error) {
 		lxdProfiles:  client,
 		lxdImages:    client,
 		lxdStorage:   client,
+		lxdNetworks:  client,
 		Firewaller:   common.NewFirewaller(),
 		remote:       config.Remote,
 	}, nil
@@ -10,6 +10,7 @@ import "github.com/juju/juju/tools/lxdclient"
 var (
 	GlobalFirewallName = (*environ).globalFirewallName
 	NewInstance        = newInstance
+	NetworkDevices     = (*environ).networkDevices
 )
 
 func ExposeInstRaw(inst *environInstance) *lxdclient.Instance {
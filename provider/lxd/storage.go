@@ -13,10 +13,12 @@ import (
 	"github.com/juju/utils/featureflag"
 	"github.com/juju/utils/set"
 	"github.com/lxc/lxd"
+	"github.com/lxc/lxd/shared"
 	"github.com/lxc/lxd/shared/api"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/storage"
@@ -264,6 +266,61 @@ func (s *lxdFilesystemSource) DescribeFilesystems(filesystemIds []string) ([]sto
 	return results, nil
 }
 
+// ImportFilesystem is specified on the storage.FilesystemImporter interface.
+//
+// TODO the LXD storage API does not yet allow us to update
+// the config of an existing volume, so the resource tags are not
+// recorded on the volume. Volumes tagged as belonging to another
+// model are refused unless force is true.
+func (s *lxdFilesystemSource) ImportFilesystem(
+	filesystemId string, resourceTags map[string]string, force bool,
+) (storage.FilesystemInfo, error) {
+	volumeName, err := s.parseFilesystemId(filesystemId)
+	if err != nil {
+		return storage.FilesystemInfo{}, errors.Trace(err)
+	}
+	volumes, err := s.env.raw.VolumeList(s.cfg.pool)
+	if err != nil {
+		return storage.FilesystemInfo{}, errors.Trace(err)
+	}
+	for _, v := range volumes {
+		if v.Name != volumeName {
+			continue
+		}
+		modelUUID := v.Config["user."+tags.JujuModel]
+		if modelUUID != "" && modelUUID != resourceTags[tags.JujuModel] && !force {
+			return storage.FilesystemInfo{}, errors.Errorf(
+				"filesystem %q belongs to another model", filesystemId,
+			)
+		}
+		size, err := volumeSize(v)
+		if err != nil {
+			return storage.FilesystemInfo{}, errors.Annotatef(err, "filesystem %q", filesystemId)
+		}
+		return storage.FilesystemInfo{
+			FilesystemId: filesystemId,
+			Size:         size,
+		}, nil
+	}
+	return storage.FilesystemInfo{}, errors.NotFoundf("filesystem %q", filesystemId)
+}
+
+// volumeSize returns the size of the given volume in MiB, as recorded
+// in its "size" config. Volumes created with the "dir" driver have no
+// size, in which case zero is returned.
+func volumeSize(v api.StorageVolume) (uint64, error) {
+	sizeString := v.Config["size"]
+	if sizeString == "" {
+		return 0, nil
+	}
+	size, err := shared.ParseByteSizeString(sizeString)
+	if err != nil {
+		return 0, errors.Annotatef(err, "parsing size %q", sizeString)
+	}
+	const mib = 1024 * 1024
+	return uint64((size + mib - 1) / mib), nil
+}
+
 // DestroyFilesystems is specified on the storage.FilesystemSource interface.
 func (s *lxdFilesystemSource) DestroyFilesystems(filesystemIds []string) ([]error, error) {
 	results := make([]error, len(filesystemIds))
@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/lxc/lxd/shared/api"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
@@ -130,6 +131,70 @@ func (s *storageSuite) TestDestroyFilesystems(c *gc.C) {
 	})
 }
 
+func (s *storageSuite) TestImportFilesystem(c *gc.C) {
+	s.Client.Volumes = map[string][]api.StorageVolume{
+		"pool": {{
+			Name: "ours",
+			StorageVolumePut: api.StorageVolumePut{
+				Config: map[string]string{"user.juju-model-uuid": "model-uuid"},
+			},
+		}, {
+			Name: "theirs",
+			StorageVolumePut: api.StorageVolumePut{
+				Config: map[string]string{"user.juju-model-uuid": "other-uuid"},
+			},
+		}, {
+			Name: "unmanaged",
+		}, {
+			Name: "sized",
+			StorageVolumePut: api.StorageVolumePut{
+				Config: map[string]string{"size": "10GiB"},
+			},
+		}, {
+			Name: "badsize",
+			StorageVolumePut: api.StorageVolumePut{
+				Config: map[string]string{"size": "lots"},
+			},
+		}},
+	}
+	source := s.filesystemSource(c, "pool")
+	importer, ok := source.(storage.FilesystemImporter)
+	c.Assert(ok, jc.IsTrue)
+	resourceTags := map[string]string{"juju-model-uuid": "model-uuid"}
+
+	info, err := importer.ImportFilesystem("pool:ours", resourceTags, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, storage.FilesystemInfo{FilesystemId: "pool:ours"})
+
+	info, err = importer.ImportFilesystem("pool:unmanaged", resourceTags, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, storage.FilesystemInfo{FilesystemId: "pool:unmanaged"})
+
+	_, err = importer.ImportFilesystem("pool:theirs", resourceTags, false)
+	c.Assert(err, gc.ErrorMatches, `filesystem "pool:theirs" belongs to another model`)
+
+	info, err = importer.ImportFilesystem("pool:theirs", resourceTags, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, storage.FilesystemInfo{FilesystemId: "pool:theirs"})
+
+	info, err = importer.ImportFilesystem("pool:sized", resourceTags, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, storage.FilesystemInfo{
+		FilesystemId: "pool:sized",
+		Size:         10240,
+	})
+
+	_, err = importer.ImportFilesystem("pool:badsize", resourceTags, false)
+	c.Assert(err, gc.ErrorMatches, `filesystem "pool:badsize": parsing size "lots": .*`)
+
+	_, err = importer.ImportFilesystem("pool:missing", resourceTags, false)
+	c.Assert(err, gc.ErrorMatches, `filesystem "pool:missing" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	_, err = importer.ImportFilesystem("notmypool:ours", resourceTags, false)
+	c.Assert(err, gc.ErrorMatches, `filesystem ID "notmypool:ours" not valid`)
+}
+
 func (s *storageSuite) TestAttachFilesystems(c *gc.C) {
 	raw := s.NewRawInstance(c, "inst-0")
 	raw.Devices = map[string]map[string]string{
@@ -349,6 +349,7 @@ func (s *BaseSuite) SetUpTest(c *gc.C) {
 		lxdProfiles:  s.Client,
 		lxdImages:    s.Client,
 		lxdStorage:   s.Client,
+		lxdNetworks:  s.Client,
 		Firewaller:   s.Firewaller,
 		remote: lxdclient.Remote{
 			Cert: &lxdclient.Cert{
@@ -489,6 +490,8 @@ type StubClient struct {
 	Server             *api.Server
 	StorageIsSupported bool
 	Volumes            map[string][]api.StorageVolume
+	Networks           []api.Network
+	InstanceStates     map[string]*api.ContainerState
 }
 
 func (conn *StubClient) Instances(prefix string, statuses ...string) ([]lxdclient.Instance, error) {
@@ -633,6 +636,26 @@ func (conn *StubClient) VolumeList(pool string) ([]api.StorageVolume, error) {
 	return conn.Volumes[pool], nil
 }
 
+func (conn *StubClient) InstanceState(name string) (*api.ContainerState, error) {
+	conn.AddCall("InstanceState", name)
+	if err := conn.NextErr(); err != nil {
+		return nil, err
+	}
+	state, ok := conn.InstanceStates[name]
+	if !ok {
+		return nil, errors.NotFoundf("container %q", name)
+	}
+	return state, nil
+}
+
+func (conn *StubClient) NetworkList() ([]api.Network, error) {
+	conn.AddCall("NetworkList")
+	if err := conn.NextErr(); err != nil {
+		return nil, err
+	}
+	return conn.Networks, nil
+}
+
 // TODO(ericsnow) Move stubFirewaller to environs/testing or provider/common/testing.
 
 type stubFirewaller struct {
@@ -0,0 +1,224 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package manual
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/common"
+)
+
+// interfacesScript lists the links and addresses of a host's
+// network interfaces, one per line.
+const interfacesScript = "ip -o link show && ip -o addr show"
+
+// runLocalScript runs the script on the local host, and returns
+// its output.
+var runLocalScript = func(script string) (string, error) {
+	cmd := exec.Command("/bin/bash")
+	cmd.Stdin = strings.NewReader(script)
+	var stdoutBuf, stderrBuf bytes.Buffer
+	cmd.Stdout = &stdoutBuf
+	cmd.Stderr = &stderrBuf
+	if err := cmd.Run(); err != nil {
+		if stderr := strings.TrimSpace(stderrBuf.String()); len(stderr) > 0 {
+			err = errors.Annotate(err, stderr)
+		}
+		return "", err
+	}
+	return stdoutBuf.String(), nil
+}
+
+// Subnets implements environs.NetworkingEnviron.
+//
+// The manual provider has no subnet model of its own, so subnets are
+// discovered from the network interfaces of the bootstrap host, and
+// are identified by their CIDRs.
+func (e *manualEnviron) Subnets(instId instance.Id, subnetIds []network.Id) ([]network.SubnetInfo, error) {
+	if instId == instance.UnknownId {
+		instId = BootstrapInstanceId
+	}
+	interfaces, err := e.NetworkInterfaces(instId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.FilterSubnets(common.SubnetsFromInterfaces(interfaces), subnetIds)
+}
+
+// NetworkInterfaces implements environs.NetworkingEnviron.
+//
+// Only the interfaces of the bootstrap host can be listed, by running
+// "ip" on it from the controller itself. The machine agents of other
+// manually provisioned machines report the network interfaces they
+// observe, which are recorded as the machines' link-layer devices, so
+// the provider never connects to those machines.
+func (e *manualEnviron) NetworkInterfaces(instId instance.Id) ([]network.InterfaceInfo, error) {
+	if instId != BootstrapInstanceId {
+		if !strings.HasPrefix(string(instId), manual.ManualInstancePrefix) {
+			return nil, errors.NotFoundf("instance %q", instId)
+		}
+		return nil, errors.NotSupportedf("listing network interfaces of manually provisioned machine %q", instId)
+	}
+	if !isRunningController() {
+		return nil, errors.NotSupportedf("listing network interfaces outside the controller")
+	}
+	output, err := runLocalScript(interfacesScript)
+	if err != nil {
+		return nil, errors.Annotatef(err, "listing network interfaces of %q", e.host)
+	}
+	return parseInterfaces(instId, output), nil
+}
+
+// parseInterfaces parses the output of interfacesScript run on the
+// host of the instance. Loopback interfaces, and addresses which are
+// not globally scoped, are omitted; an interface is listed once for
+// each of its remaining addresses, or once if it has none.
+func parseInterfaces(instId instance.Id, output string) []network.InterfaceInfo {
+	type hostInterface struct {
+		info      network.InterfaceInfo
+		addresses []network.InterfaceInfo
+	}
+	interfaces := make(map[string]*hostInterface)
+	var interfaceNames []string
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		switch {
+		case strings.HasSuffix(fields[1], ":"):
+			// e.g. 2: eth0: <BROADCAST,UP> mtu 1500 ... link/ether 52:54:00:12:34:56 brd ...
+			name := strings.TrimSuffix(fields[1], ":")
+			if i := strings.Index(name, "@"); i >= 0 {
+				name = name[:i]
+			}
+			info := network.InterfaceInfo{
+				ProviderId:    network.Id(fmt.Sprintf("%s/%s", instId, name)),
+				InterfaceName: name,
+				InterfaceType: network.EthernetInterface,
+			}
+			var loopback bool
+			for i := 2; i < len(fields)-1; i++ {
+				switch fields[i] {
+				case "mtu":
+					if mtu, err := strconv.Atoi(fields[i+1]); err == nil {
+						info.MTU = mtu
+					}
+				case "link/ether":
+					info.MACAddress = fields[i+1]
+				case "link/loopback":
+					loopback = true
+				}
+			}
+			if loopback {
+				continue
+			}
+			if _, ok := interfaces[name]; !ok {
+				interfaceNames = append(interfaceNames, name)
+			}
+			interfaces[name] = &hostInterface{info: info}
+		case fields[2] == "inet" || fields[2] == "inet6":
+			// e.g. 2: eth0    inet 10.0.0.5/24 brd 10.0.0.255 scope global eth0 ...
+			iface, ok := interfaces[fields[1]]
+			if !ok {
+				continue
+			}
+			var scope string
+			for i := 4; i < len(fields)-1; i++ {
+				if fields[i] == "scope" {
+					scope = fields[i+1]
+					break
+				}
+			}
+			if scope != "global" {
+				continue
+			}
+			ip, ipNet, err := net.ParseCIDR(fields[3])
+			if err != nil {
+				logger.Warningf("ignoring address %q of %s on %q: %v", fields[3], fields[1], instId, err)
+				continue
+			}
+			info := iface.info
+			info.CIDR = ipNet.String()
+			info.ProviderSubnetId = network.Id(info.CIDR)
+			info.Address = network.NewAddress(ip.String())
+			iface.addresses = append(iface.addresses, info)
+		}
+	}
+
+	sort.Strings(interfaceNames)
+	var results []network.InterfaceInfo
+	for i, name := range interfaceNames {
+		iface := interfaces[name]
+		if len(iface.addresses) == 0 {
+			iface.addresses = []network.InterfaceInfo{iface.info}
+		}
+		for _, info := range iface.addresses {
+			info.DeviceIndex = i
+			results = append(results, info)
+		}
+	}
+	return results
+}
+
+// SupportsSpaces implements environs.NetworkingEnviron.
+//
+// Spaces are not discovered, but may be created from the
+// discovered subnets with "juju add-space".
+func (e *manualEnviron) SupportsSpaces() (bool, error) {
+	return true, nil
+}
+
+// SupportsSpaceDiscovery implements environs.NetworkingEnviron.
+func (e *manualEnviron) SupportsSpaceDiscovery() (bool, error) {
+	return false, nil
+}
+
+// Spaces implements environs.NetworkingEnviron.
+func (e *manualEnviron) Spaces() ([]network.SpaceInfo, error) {
+	return nil, errors.NotSupportedf("spaces")
+}
+
+// ProviderSpaceInfo implements environs.NetworkingEnviron.
+func (*manualEnviron) ProviderSpaceInfo(space *network.SpaceInfo) (*environs.ProviderSpaceInfo, error) {
+	return nil, errors.NotSupportedf("provider space info")
+}
+
+// AreSpacesRoutable implements environs.NetworkingEnviron.
+func (*manualEnviron) AreSpacesRoutable(space1, space2 *environs.ProviderSpaceInfo) (bool, error) {
+	return false, nil
+}
+
+// SupportsContainerAddresses implements environs.NetworkingEnviron.
+func (e *manualEnviron) SupportsContainerAddresses() (bool, error) {
+	return false, errors.NotSupportedf("container address allocation")
+}
+
+// AllocateContainerAddresses implements environs.NetworkingEnviron.
+func (e *manualEnviron) AllocateContainerAddresses(instance.Id, names.MachineTag, []network.InterfaceInfo) ([]network.InterfaceInfo, error) {
+	return nil, errors.NotSupportedf("container address allocation")
+}
+
+// ReleaseContainerAddresses implements environs.NetworkingEnviron.
+func (e *manualEnviron) ReleaseContainerAddresses([]network.ProviderInterfaceInfo) error {
+	return errors.NotSupportedf("container address allocation")
+}
+
+// SSHAddresses implements environs.SSHAddresses.
+func (*manualEnviron) SSHAddresses(addresses []network.Address) ([]network.Address, error) {
+	return addresses, nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package manual
+
+import (
+	"os"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+type environNetworkSuite struct {
+	baseEnvironSuite
+}
+
+var _ = gc.Suite(&environNetworkSuite{})
+
+const ipOutput = `
+1: lo: <LOOPBACK,UP,LOWER_UP> mtu 65536 qdisc noqueue state UNKNOWN mode DEFAULT group default qlen 1\    link/loopback 00:00:00:00:00:00 brd 00:00:00:00:00:00
+2: eth0: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1500 qdisc pfifo_fast state UP mode DEFAULT group default qlen 1000\    link/ether 52:54:00:12:34:56 brd ff:ff:ff:ff:ff:ff
+3: eth1@if7: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 9000 qdisc noqueue state UP mode DEFAULT group default qlen 1000\    link/ether 52:54:00:12:34:57 brd ff:ff:ff:ff:ff:ff link-netnsid 0
+4: eth2: <BROADCAST,MULTICAST> mtu 1500 qdisc noop state DOWN mode DEFAULT group default qlen 1000\    link/ether 52:54:00:12:34:58 brd ff:ff:ff:ff:ff:ff
+1: lo    inet 127.0.0.1/8 scope host lo\       valid_lft forever preferred_lft forever
+2: eth0    inet 10.0.0.5/24 brd 10.0.0.255 scope global eth0\       valid_lft forever preferred_lft forever
+2: eth0    inet6 2001:db8::5/64 scope global \       valid_lft forever preferred_lft forever
+2: eth0    inet6 fe80::5054:ff:fe12:3456/64 scope link \       valid_lft forever preferred_lft forever
+3: eth1    inet 192.168.1.5/24 brd 192.168.1.255 scope global eth1\       valid_lft forever preferred_lft forever
+`
+
+func (s *environNetworkSuite) TestSupportsSpaces(c *gc.C) {
+	c.Assert(environs.SupportsSpaces(s.env), jc.IsTrue)
+	supported, err := s.env.SupportsSpaceDiscovery()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(supported, jc.IsFalse)
+	_, err = s.env.Spaces()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *environNetworkSuite) patchRunningController(c *gc.C, output string, err error) {
+	// Patch os.Args so it appears that we're running in "jujud".
+	s.PatchValue(&os.Args, []string{"/some/where/containing/jujud", "whatever"})
+	s.PatchValue(&runSSHCommand, func(host string, command []string, stdin string) (string, string, error) {
+		c.Fatalf("unexpected SSH command to %q", host)
+		return "", "", nil
+	})
+	s.PatchValue(&runLocalScript, func(script string) (string, error) {
+		c.Assert(script, gc.Equals, interfacesScript)
+		return output, err
+	})
+}
+
+func (s *environNetworkSuite) TestNetworkInterfaces(c *gc.C) {
+	s.patchRunningController(c, ipOutput, nil)
+
+	interfaces, err := s.env.NetworkInterfaces(BootstrapInstanceId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(interfaces, jc.DeepEquals, []network.InterfaceInfo{{
+		DeviceIndex:      0,
+		MACAddress:       "52:54:00:12:34:56",
+		CIDR:             "10.0.0.0/24",
+		ProviderId:       "manual:/eth0",
+		ProviderSubnetId: "10.0.0.0/24",
+		InterfaceName:    "eth0",
+		InterfaceType:    network.EthernetInterface,
+		Address:          network.NewAddress("10.0.0.5"),
+		MTU:              1500,
+	}, {
+		DeviceIndex:      0,
+		MACAddress:       "52:54:00:12:34:56",
+		CIDR:             "2001:db8::/64",
+		ProviderId:       "manual:/eth0",
+		ProviderSubnetId: "2001:db8::/64",
+		InterfaceName:    "eth0",
+		InterfaceType:    network.EthernetInterface,
+		Address:          network.NewAddress("2001:db8::5"),
+		MTU:              1500,
+	}, {
+		DeviceIndex:      1,
+		MACAddress:       "52:54:00:12:34:57",
+		CIDR:             "192.168.1.0/24",
+		ProviderId:       "manual:/eth1",
+		ProviderSubnetId: "192.168.1.0/24",
+		InterfaceName:    "eth1",
+		InterfaceType:    network.EthernetInterface,
+		Address:          network.NewAddress("192.168.1.5"),
+		MTU:              9000,
+	}, {
+		DeviceIndex:   2,
+		MACAddress:    "52:54:00:12:34:58",
+		ProviderId:    "manual:/eth2",
+		InterfaceName: "eth2",
+		InterfaceType: network.EthernetInterface,
+		MTU:           1500,
+	}})
+}
+
+func (s *environNetworkSuite) TestNetworkInterfacesOtherInstances(c *gc.C) {
+	s.patchRunningController(c, ipOutput, nil)
+
+	_, err := s.env.NetworkInterfaces("manual:10.0.0.5")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = s.env.NetworkInterfaces("i-foo")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *environNetworkSuite) TestNetworkInterfacesNotRunningController(c *gc.C) {
+	s.PatchValue(&runSSHCommand, func(host string, command []string, stdin string) (string, string, error) {
+		c.Fatalf("unexpected SSH command to %q", host)
+		return "", "", nil
+	})
+	_, err := s.env.NetworkInterfaces(BootstrapInstanceId)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *environNetworkSuite) TestNetworkInterfacesError(c *gc.C) {
+	s.patchRunningController(c, "", errors.New("oh noes"))
+	_, err := s.env.NetworkInterfaces(BootstrapInstanceId)
+	c.Assert(err, gc.ErrorMatches, `listing network interfaces of "hostname": oh noes`)
+}
+
+func (s *environNetworkSuite) TestSubnets(c *gc.C) {
+	s.patchRunningController(c, ipOutput, nil)
+
+	subnets, err := s.env.Subnets(instance.UnknownId, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, jc.DeepEquals, []network.SubnetInfo{
+		{ProviderId: "10.0.0.0/24", CIDR: "10.0.0.0/24"},
+		{ProviderId: "2001:db8::/64", CIDR: "2001:db8::/64"},
+		{ProviderId: "192.168.1.0/24", CIDR: "192.168.1.0/24"},
+	})
+
+	subnets, err = s.env.Subnets(instance.UnknownId, []network.Id{"192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, jc.DeepEquals, []network.SubnetInfo{
+		{ProviderId: "192.168.1.0/24", CIDR: "192.168.1.0/24"},
+	})
+}
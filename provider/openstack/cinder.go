@@ -12,6 +12,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/utils"
 	"gopkg.in/goose.v2/cinder"
+	gooseerrors "gopkg.in/goose.v2/errors"
 	"gopkg.in/goose.v2/identity"
 	"gopkg.in/goose.v2/nova"
 
@@ -159,6 +160,14 @@ type cinderVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*cinderVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*cinderVolumeSource)(nil)
+var _ storage.VolumeImporter = (*cinderVolumeSource)(nil)
+
+// TODO(storage) implement storage.VolumeResizer with the Cinder
+// os-extend volume action. Extending attached volumes requires the
+// volume v3 API (microversion 3.42), which the pinned goose does not
+// support; until it does, growing cinder volumes is reported as not
+// supported.
 
 // CreateVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -187,6 +196,7 @@ func (s *cinderVolumeSource) createVolume(arg storage.VolumeParams) (*storage.Vo
 		// TODO(axw) use the AZ of the initially attached machine.
 		AvailabilityZone: "",
 		Metadata:         metadata,
+		SnapshotId:       arg.SnapshotId,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -415,6 +425,111 @@ func waitVolume(
 	return nil, errors.New("timed out")
 }
 
+// CreateSnapshots implements storage.VolumeSnapshotter.
+func (s *cinderVolumeSource) CreateSnapshots(args []storage.SnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+	results := make([]storage.CreateSnapshotsResult, len(args))
+	for i, arg := range args {
+		snapshot, err := s.createSnapshot(arg)
+		if err != nil {
+			results[i].Error = errors.Trace(err)
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (s *cinderVolumeSource) createSnapshot(arg storage.SnapshotParams) (*storage.Snapshot, error) {
+	cinderSnapshot, err := s.storageAdapter.CreateSnapshot(cinder.CreateSnapshotSnapshotParams{
+		VolumeId: arg.VolumeId,
+		Name:     resourceName(s.namespace, s.envName, arg.Volume.String()),
+		// Cinder snapshots cannot be tagged, so we record the
+		// model UUID in the description.
+		Description: s.modelUUID,
+		// Snapshots of in-use volumes must be forced.
+		Force: true,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Wait for the snapshot to become available, so that volumes
+	// can be created from it immediately.
+	snapshotId := cinderSnapshot.ID
+	for a := cinderAttempt.Start(); a.Next(); {
+		cinderSnapshot, err = s.storageAdapter.GetSnapshot(snapshotId)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting snapshot")
+		}
+		if cinderSnapshot.Status != "creating" {
+			break
+		}
+	}
+	if cinderSnapshot.Status != "available" {
+		if err := s.storageAdapter.DeleteSnapshot(snapshotId); err != nil {
+			logger.Warningf("deleting snapshot %s: %s", snapshotId, err)
+		}
+		return nil, errors.Errorf("snapshot %s is %q, expected \"available\"", snapshotId, cinderSnapshot.Status)
+	}
+	return &storage.Snapshot{
+		SnapshotId: snapshotId,
+		Volume:     arg.Volume,
+		Size:       uint64(cinderSnapshot.Size * 1024),
+	}, nil
+}
+
+// ListSnapshots implements storage.VolumeSnapshotter.
+func (s *cinderVolumeSource) ListSnapshots() ([]string, error) {
+	cinderSnapshots, err := s.storageAdapter.GetSnapshotsDetail()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var snapshotIds []string
+	for _, snapshot := range cinderSnapshots {
+		if snapshot.Description == s.modelUUID {
+			snapshotIds = append(snapshotIds, snapshot.ID)
+		}
+	}
+	return snapshotIds, nil
+}
+
+// DeleteSnapshots implements storage.VolumeSnapshotter.
+func (s *cinderVolumeSource) DeleteSnapshots(snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		err := s.storageAdapter.DeleteSnapshot(snapshotId)
+		if err != nil && !gooseerrors.IsNotFound(err) {
+			results[i] = errors.Annotatef(err, "deleting snapshot %q", snapshotId)
+		}
+	}
+	return results, nil
+}
+
+// ImportVolume implements storage.VolumeImporter.
+func (s *cinderVolumeSource) ImportVolume(volumeId string, resourceTags map[string]string, force bool) (storage.VolumeInfo, error) {
+	volume, err := s.storageAdapter.GetVolume(volumeId)
+	if err != nil {
+		return storage.VolumeInfo{}, errors.Annotatef(err, "getting volume %q", volumeId)
+	}
+	if volume.Status != "available" {
+		return storage.VolumeInfo{}, errors.Errorf(
+			"cannot import volume %q with status %q", volumeId, volume.Status,
+		)
+	}
+	modelUUID := volume.Metadata[tags.JujuModel]
+	if modelUUID != "" && modelUUID != resourceTags[tags.JujuModel] && !force {
+		return storage.VolumeInfo{}, errors.Errorf("volume %q belongs to another model", volumeId)
+	}
+	// Re-tag the volume, replacing any metadata identifying the
+	// model and controller that previously managed it.
+	if len(resourceTags) > 0 {
+		if _, err := s.storageAdapter.SetVolumeMetadata(volumeId, resourceTags); err != nil {
+			return storage.VolumeInfo{}, errors.Annotatef(err, "tagging volume %q", volumeId)
+		}
+	}
+	return cinderToJujuVolumeInfo(volume), nil
+}
+
 // DetachVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) DetachVolumes(args []storage.VolumeAttachmentParams) ([]error, error) {
 	return detachVolumes(s.storageAdapter, args)
@@ -482,6 +597,10 @@ type OpenstackStorage interface {
 	DetachVolume(serverId, attachmentId string) error
 	ListVolumeAttachments(serverId string) ([]nova.VolumeAttachment, error)
 	SetVolumeMetadata(volumeId string, metadata map[string]string) (map[string]string, error)
+	CreateSnapshot(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	GetSnapshot(snapshotId string) (*cinder.Snapshot, error)
+	GetSnapshotsDetail() ([]cinder.Snapshot, error)
+	DeleteSnapshot(snapshotId string) error
 }
 
 type endpointResolver interface {
@@ -553,3 +672,30 @@ func (ga *openstackStorageAdapter) GetVolume(volumeId string) (*cinder.Volume, e
 func (ga *openstackStorageAdapter) SetVolumeMetadata(volumeId string, metadata map[string]string) (map[string]string, error) {
 	return ga.cinderClient.SetVolumeMetadata(volumeId, metadata)
 }
+
+// CreateSnapshot is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.CreateSnapshot(args)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Snapshot, nil
+}
+
+// GetSnapshot is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) GetSnapshot(snapshotId string) (*cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.GetSnapshot(snapshotId)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Snapshot, nil
+}
+
+// GetSnapshotsDetail is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) GetSnapshotsDetail() ([]cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.GetSnapshotsDetail()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Snapshots, nil
+}
@@ -287,6 +287,140 @@ func (s *cinderVolumeSourceSuite) TestDescribeVolumes(c *gc.C) {
 	}})
 }
 
+func (s *cinderVolumeSourceSuite) TestCreateSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		createSnapshot: func(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+			c.Assert(args, jc.DeepEquals, cinder.CreateSnapshotSnapshotParams{
+				VolumeId:    mockVolId,
+				Name:        "juju-testenv-volume-123",
+				Description: testing.ModelTag.Id(),
+				Force:       true,
+			})
+			return &cinder.Snapshot{ID: "snap-0", Status: "creating"}, nil
+		},
+		getSnapshot: func(snapshotId string) (*cinder.Snapshot, error) {
+			return &cinder.Snapshot{
+				ID:     snapshotId,
+				Size:   mockVolSize / 1024,
+				Status: "available",
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.(storage.VolumeSnapshotter).CreateSnapshots([]storage.SnapshotParams{{
+		Volume:   mockVolumeTag,
+		VolumeId: mockVolId,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Check(results[0].Snapshot, jc.DeepEquals, &storage.Snapshot{
+		SnapshotId: "snap-0",
+		Volume:     mockVolumeTag,
+		Size:       mockVolSize,
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestListSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getSnapshotsDetail: func() ([]cinder.Snapshot, error) {
+			return []cinder.Snapshot{{
+				ID: "snap-1",
+			}, {
+				ID:          "snap-2",
+				Description: "something-else",
+			}, {
+				ID:          "snap-3",
+				Description: testing.ModelTag.Id(),
+			}}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	snapshotIds, err := volSource.(storage.VolumeSnapshotter).ListSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(snapshotIds, jc.DeepEquals, []string{"snap-3"})
+}
+
+func (s *cinderVolumeSourceSuite) TestDeleteSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	errs, err := volSource.(storage.VolumeSnapshotter).DeleteSnapshots([]string{"snap-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"DeleteSnapshot", []interface{}{"snap-0"}},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestImportVolume(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID:     volumeId,
+				Size:   mockVolSize / 1024,
+				Status: "available",
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	importer, ok := volSource.(storage.VolumeImporter)
+	c.Assert(ok, jc.IsTrue)
+	resourceTags := map[string]string{tags.JujuModel: testing.ModelTag.Id()}
+	info, err := importer.ImportVolume(mockVolId, resourceTags, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info, jc.DeepEquals, storage.VolumeInfo{
+		VolumeId:   mockVolId,
+		Size:       mockVolSize,
+		Persistent: true,
+	})
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"GetVolume", []interface{}{mockVolId}},
+		{"SetVolumeMetadata", []interface{}{mockVolId, resourceTags}},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestImportVolumeOtherModel(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID:       volumeId,
+				Status:   "available",
+				Metadata: map[string]string{tags.JujuModel: "something-else"},
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	importer := volSource.(storage.VolumeImporter)
+	resourceTags := map[string]string{tags.JujuModel: testing.ModelTag.Id()}
+	_, err := importer.ImportVolume(mockVolId, resourceTags, false)
+	c.Assert(err, gc.ErrorMatches, `volume "0" belongs to another model`)
+	mockAdapter.CheckCallNames(c, "GetVolume")
+
+	// With force, the volume is imported and re-tagged.
+	mockAdapter.ResetCalls()
+	_, err = importer.ImportVolume(mockVolId, resourceTags, true)
+	c.Assert(err, jc.ErrorIsNil)
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"GetVolume", []interface{}{mockVolId}},
+		{"SetVolumeMetadata", []interface{}{mockVolId, resourceTags}},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestImportVolumeInUse(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID:     volumeId,
+				Status: "in-use",
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	importer := volSource.(storage.VolumeImporter)
+	_, err := importer.ImportVolume(mockVolId, nil, false)
+	c.Assert(err, gc.ErrorMatches, `cannot import volume "0" with status "in-use"`)
+}
+
 func (s *cinderVolumeSourceSuite) TestDestroyVolumes(c *gc.C) {
 	mockAdapter := &mockAdapter{}
 	volSource := openstack.NewCinderVolumeSource(mockAdapter)
@@ -474,6 +608,10 @@ type mockAdapter struct {
 	detachVolume          func(string, string) error
 	listVolumeAttachments func(string) ([]nova.VolumeAttachment, error)
 	setVolumeMetadata     func(string, map[string]string) (map[string]string, error)
+	createSnapshot        func(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	getSnapshot           func(string) (*cinder.Snapshot, error)
+	getSnapshotsDetail    func() ([]cinder.Snapshot, error)
+	deleteSnapshot        func(string) error
 }
 
 func (ma *mockAdapter) GetVolume(volumeId string) (*cinder.Volume, error) {
@@ -543,6 +681,41 @@ func (ma *mockAdapter) SetVolumeMetadata(volumeId string, metadata map[string]st
 	return nil, nil
 }
 
+func (ma *mockAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	ma.MethodCall(ma, "CreateSnapshot", args)
+	if ma.createSnapshot != nil {
+		return ma.createSnapshot(args)
+	}
+	return nil, errors.NotImplementedf("CreateSnapshot")
+}
+
+func (ma *mockAdapter) GetSnapshot(snapshotId string) (*cinder.Snapshot, error) {
+	ma.MethodCall(ma, "GetSnapshot", snapshotId)
+	if ma.getSnapshot != nil {
+		return ma.getSnapshot(snapshotId)
+	}
+	return &cinder.Snapshot{
+		ID:     snapshotId,
+		Status: "available",
+	}, nil
+}
+
+func (ma *mockAdapter) GetSnapshotsDetail() ([]cinder.Snapshot, error) {
+	ma.MethodCall(ma, "GetSnapshotsDetail")
+	if ma.getSnapshotsDetail != nil {
+		return ma.getSnapshotsDetail()
+	}
+	return nil, nil
+}
+
+func (ma *mockAdapter) DeleteSnapshot(snapshotId string) error {
+	ma.MethodCall(ma, "DeleteSnapshot", snapshotId)
+	if ma.deleteSnapshot != nil {
+		return ma.deleteSnapshot(snapshotId)
+	}
+	return nil
+}
+
 type testEndpointResolver struct {
 	authenticated   bool
 	regionEndpoints map[string]identity.ServiceURLs
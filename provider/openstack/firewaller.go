@@ -205,6 +205,47 @@ func (f *switchingFirewaller) InstanceIngressRules(inst instance.Instance, machi
 	return f.fw.InstanceIngressRules(inst, machineId)
 }
 
+// egressFirewaller is implemented by Firewallers that can restrict
+// outgoing traffic from machines. See environs.EgressFirewaller.
+type egressFirewaller interface {
+	SupportsEgressRules() (bool, error)
+	SetEgressRules(machineId string, rules []network.EgressRule) error
+	EgressRules(machineId string) ([]network.EgressRule, error)
+}
+
+func (f *switchingFirewaller) SupportsEgressRules() (bool, error) {
+	if err := f.initFirewaller(); err != nil {
+		return false, errors.Trace(err)
+	}
+	fw, ok := f.fw.(egressFirewaller)
+	if !ok {
+		return false, nil
+	}
+	return fw.SupportsEgressRules()
+}
+
+func (f *switchingFirewaller) SetEgressRules(machineId string, rules []network.EgressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	fw, ok := f.fw.(egressFirewaller)
+	if !ok {
+		return errors.NotSupportedf("egress rules")
+	}
+	return fw.SetEgressRules(machineId, rules)
+}
+
+func (f *switchingFirewaller) EgressRules(machineId string) ([]network.EgressRule, error) {
+	if err := f.initFirewaller(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	fw, ok := f.fw.(egressFirewaller)
+	if !ok {
+		return nil, errors.NotSupportedf("egress rules")
+	}
+	return fw.EgressRules(machineId)
+}
+
 type firewallerBase struct {
 	environ *Environ
 }
@@ -946,6 +987,179 @@ func (c *neutronFirewaller) ingressRulesInGroup(nameRegexp string) (rules []netw
 	return rules, nil
 }
 
+// defaultEgressRules holds the rules created by Neutron with any new
+// Security Group, which allow all outgoing traffic.
+var defaultEgressRules = []neutron.RuleInfoV2{{
+	Direction:    "egress",
+	EthernetType: "IPv4",
+}, {
+	Direction:    "egress",
+	EthernetType: "IPv6",
+}}
+
+// SupportsEgressRules implements egressFirewaller.
+//
+// Egress rules are applied to the machine security groups, so they are
+// only supported with the instance firewall mode, and not when the
+// default security group, which allows all outgoing traffic, is used.
+func (c *neutronFirewaller) SupportsEgressRules() (bool, error) {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return false, nil
+	}
+	return !c.environ.ecfg().useDefaultSecurityGroup(), nil
+}
+
+// SetEgressRules implements egressFirewaller.
+func (c *neutronFirewaller) SetEgressRules(machineId string, rules []network.EgressRule) error {
+	if ok, err := c.SupportsEgressRules(); err != nil {
+		return errors.Trace(err)
+	} else if !ok {
+		return errors.NotSupportedf("egress rules with firewall mode %q", c.environ.Config().FirewallMode())
+	}
+	want := defaultEgressRules
+	if len(rules) > 0 {
+		// Security groups are additive, so outgoing traffic can only
+		// be restricted if the model's group does not allow it. The
+		// machine groups hold the default egress rules of their own.
+		jujuGroup, err := c.matchingGroup("^" + c.jujuGroupRegexp() + "$")
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := c.replaceEgressRules(jujuGroup, nil); err != nil {
+			return errors.Trace(err)
+		}
+		want = egressRulesToRuleInfo(rules)
+	}
+	group, err := c.matchingGroup(c.machineGroupRegexp(machineId))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(c.replaceEgressRules(group, want))
+}
+
+// replaceEgressRules replaces the egress rules of the group with the
+// specified rules, leaving the group's ingress rules untouched.
+func (c *neutronFirewaller) replaceEgressRules(group neutron.SecurityGroupV2, rules []neutron.RuleInfoV2) error {
+	neutronClient := c.environ.neutron()
+	have := newRuleInfoSetFromRules(group.Rules)
+	want := newRuleInfoSetFromRuleInfo(rules)
+	for k, ruleId := range have {
+		if _, ok := want[k]; !ok && k.Direction == "egress" {
+			if err := neutronClient.DeleteSecurityGroupRuleV2(ruleId); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	for k := range want {
+		if _, ok := have[k]; ok {
+			continue
+		}
+		k.ParentGroupId = group.Id
+		if _, err := neutronClient.CreateSecurityGroupRuleV2(k); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// EgressRules implements egressFirewaller.
+func (c *neutronFirewaller) EgressRules(machineId string) ([]network.EgressRule, error) {
+	if ok, err := c.SupportsEgressRules(); err != nil {
+		return nil, errors.Trace(err)
+	} else if !ok {
+		return nil, errors.NotSupportedf("egress rules with firewall mode %q", c.environ.Config().FirewallMode())
+	}
+	group, err := c.matchingGroup(c.machineGroupRegexp(machineId))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Keep track of all the RemoteIPPrefixes for each port range.
+	var portRanges []network.PortRange
+	portDestinationCIDRs := make(map[network.PortRange][]string)
+	for _, p := range group.Rules {
+		if p.Direction != "egress" {
+			continue
+		}
+		var portRange network.PortRange
+		switch {
+		case p.IPProtocol != nil && *p.IPProtocol == "icmp":
+			portRange = network.PortRange{Protocol: "icmp", FromPort: -1, ToPort: -1}
+		case p.IPProtocol == nil || p.PortRangeMin == nil || p.PortRangeMax == nil:
+			// A rule for all ports and protocols; outgoing
+			// traffic is unrestricted.
+			return nil, nil
+		default:
+			portRange = network.PortRange{
+				Protocol: *p.IPProtocol,
+				FromPort: *p.PortRangeMin,
+				ToPort:   *p.PortRangeMax,
+			}
+		}
+		if _, ok := portDestinationCIDRs[portRange]; !ok {
+			portRanges = append(portRanges, portRange)
+		}
+		portDestinationCIDRs[portRange] = append(portDestinationCIDRs[portRange], p.RemoteIPPrefix)
+	}
+	var rules []network.EgressRule
+	for _, portRange := range portRanges {
+		destinationCIDRs := portDestinationCIDRs[portRange]
+		if isAnyDestination(destinationCIDRs) {
+			destinationCIDRs = nil
+		}
+		rule, err := network.NewEgressRule(
+			portRange.Protocol,
+			portRange.FromPort,
+			portRange.ToPort,
+			destinationCIDRs...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	network.SortEgressRules(rules)
+	return rules, nil
+}
+
+// egressRulesToRuleInfo returns the neutron security group rules
+// that allow outgoing traffic as specified by the egress rules.
+func egressRulesToRuleInfo(rules []network.EgressRule) []neutron.RuleInfoV2 {
+	var result []neutron.RuleInfoV2
+	for _, r := range rules {
+		ruleInfo := neutron.RuleInfoV2{
+			Direction:  "egress",
+			IPProtocol: r.Protocol,
+		}
+		if r.Protocol != "icmp" {
+			// ICMP rules have no ports.
+			ruleInfo.PortRangeMin = r.FromPort
+			ruleInfo.PortRangeMax = r.ToPort
+		}
+		destinationCIDRs := r.DestinationCIDRs
+		if len(destinationCIDRs) == 0 {
+			destinationCIDRs = []string{"0.0.0.0/0", "::/0"}
+		}
+		for _, cidr := range destinationCIDRs {
+			ruleInfo.RemoteIPPrefix = cidr
+			ruleInfo.EthernetType = "IPv4"
+			if strings.Contains(cidr, ":") {
+				ruleInfo.EthernetType = "IPv6"
+			}
+			result = append(result, ruleInfo)
+		}
+	}
+	return result
+}
+
+// isAnyDestination reports whether the CIDRs
+// are those used for rules without destinations.
+func isAnyDestination(cidrs []string) bool {
+	if len(cidrs) != 2 {
+		return false
+	}
+	return (cidrs[0] == "0.0.0.0/0" && cidrs[1] == "::/0") ||
+		(cidrs[0] == "::/0" && cidrs[1] == "0.0.0.0/0")
+}
+
 func replaceControllerUUID(oldName, controllerUUID string) (string, error) {
 	if !extractControllerRe.MatchString(oldName) {
 		return "", errors.Errorf("unexpected security group name format for %q", oldName)
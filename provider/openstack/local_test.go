@@ -1349,6 +1349,55 @@ func ruleToRuleInfo(rules []neutron.SecurityGroupRuleV2) []neutron.RuleInfoV2 {
 	return ruleInfo
 }
 
+func (s *localServerSuite) TestEgressRules(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"firewall-mode": config.FwInstance})
+	testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	c.Assert(environs.SupportsEgressRules(env), jc.IsTrue)
+	fw := env.(environs.EgressFirewaller)
+
+	rules, err := fw.EgressRules("100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.IsNil)
+
+	expected := []network.EgressRule{
+		network.MustNewEgressRule("icmp", -1, -1, "10.0.0.0/8"),
+		network.MustNewEgressRule("tcp", 443, 443),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+	}
+	err = fw.SetEgressRules("100", expected)
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = fw.EgressRules("100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, expected)
+
+	// The model's security group no longer allows outgoing traffic.
+	neutronClient := openstack.GetNeutronClient(env)
+	groups, err := neutronClient.SecurityGroupByNameV2(
+		fmt.Sprintf("juju-%v-%v", s.ControllerUUID, env.Config().UUID()),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, gc.HasLen, 1)
+	for _, rule := range groups[0].Rules {
+		c.Check(rule.Direction, gc.Not(gc.Equals), "egress")
+	}
+
+	// Removing the rules allows all outgoing traffic again.
+	err = fw.SetEgressRules("100", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = fw.EgressRules("100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.IsNil)
+}
+
+func (s *localServerSuite) TestEgressRulesFirewallModeGlobal(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"firewall-mode": config.FwGlobal})
+	c.Assert(environs.SupportsEgressRules(env), jc.IsFalse)
+	err := env.(environs.EgressFirewaller).SetEgressRules("100", []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443),
+	})
+	c.Assert(err, gc.ErrorMatches, `egress rules with firewall mode "global" not supported`)
+}
+
 // TestEnsureGroup checks that when creating a duplicate security group, the existing group is
 // returned and the existing rules have been left as is.
 func (s *localServerSuite) TestEnsureGroup(c *gc.C) {
@@ -295,6 +295,7 @@ var _ simplestreams.HasRegion = (*Environ)(nil)
 var _ state.Prechecker = (*Environ)(nil)
 var _ instance.Distributor = (*Environ)(nil)
 var _ environs.InstanceTagger = (*Environ)(nil)
+var _ environs.EgressFirewaller = (*Environ)(nil)
 
 type openstackInstance struct {
 	e        *Environ
@@ -1592,6 +1593,33 @@ func (e *Environ) IngressRules() ([]network.IngressRule, error) {
 	return e.firewaller.IngressRules()
 }
 
+// SupportsEgressRules is specified on the environs.EgressFirewaller interface.
+func (e *Environ) SupportsEgressRules() (bool, error) {
+	fw, ok := e.firewaller.(egressFirewaller)
+	if !ok {
+		return false, nil
+	}
+	return fw.SupportsEgressRules()
+}
+
+// SetEgressRules is specified on the environs.EgressFirewaller interface.
+func (e *Environ) SetEgressRules(machineId string, rules []network.EgressRule) error {
+	fw, ok := e.firewaller.(egressFirewaller)
+	if !ok {
+		return errors.NotSupportedf("egress rules")
+	}
+	return fw.SetEgressRules(machineId, rules)
+}
+
+// EgressRules is specified on the environs.EgressFirewaller interface.
+func (e *Environ) EgressRules(machineId string) ([]network.EgressRule, error) {
+	fw, ok := e.firewaller.(egressFirewaller)
+	if !ok {
+		return nil, errors.NotSupportedf("egress rules")
+	}
+	return fw.EgressRules(machineId)
+}
+
 func (e *Environ) Provider() environs.EnvironProvider {
 	return providerInstance
 }
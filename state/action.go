@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
@@ -81,6 +82,30 @@ type actionDoc struct {
 	// match an action defined by the unit's charm.
 	Name string `bson:"name"`
 
+	// Operation is the id of the operation the action was enqueued
+	// as part of, if any; the actions of an operation are enqueued
+	// together on several receivers.
+	Operation string `bson:"operation,omitempty"`
+
+	// Timeout is how long the action may run for before it is
+	// stopped and marked as failed; zero means no timeout.
+	Timeout time.Duration `bson:"timeout,omitempty"`
+
+	// Throttled is true if the action counts against the action
+	// concurrency limit of its receiver's application while it is
+	// dispatched.
+	Throttled bool `bson:"throttled,omitempty"`
+
+	// Held is true if the action is throttled and has not yet been
+	// dispatched to its receiver, because the application's action
+	// concurrency limit was reached.
+	Held bool `bson:"held,omitempty"`
+
+	// HeldSequence orders the held actions of the model, so that
+	// they are dispatched in the order they were enqueued, even if
+	// they were enqueued within the same second.
+	HeldSequence int `bson:"held-sequence,omitempty"`
+
 	// Parameters holds the action's parameters, if any; it should validate
 	// against the schema defined by the named action in the unit's charm.
 	Parameters map[string]interface{} `bson:"parameters"`
@@ -104,6 +129,26 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// Logs holds the progress messages logged by the action while it
+	// was running.
+	Logs []ActionMessage `bson:"messages,omitempty"`
+}
+
+// ActionMessage represents a progress message logged by an action.
+type ActionMessage struct {
+	MessageValue   string    `bson:"message"`
+	TimestampValue time.Time `bson:"timestamp"`
+}
+
+// Timestamp returns the message timestamp.
+func (m ActionMessage) Timestamp() time.Time {
+	return m.TimestampValue
+}
+
+// Message returns the message string.
+func (m ActionMessage) Message() string {
+	return m.MessageValue
 }
 
 // action represents an instruction to do some "action" and is expected
@@ -124,6 +169,18 @@ func (a *action) Receiver() string {
 	return a.doc.Receiver
 }
 
+// Operation returns the id of the operation the action was enqueued
+// as part of, or "" if it was enqueued on its own.
+func (a *action) Operation() string {
+	return a.doc.Operation
+}
+
+// Timeout returns how long the action may run for before it is
+// stopped and marked as failed, or zero if there is no timeout.
+func (a *action) Timeout() time.Duration {
+	return a.doc.Timeout
+}
+
 // Name returns the name of the action, as defined in the charm.
 func (a *action) Name() string {
 	return a.doc.Name
@@ -162,6 +219,11 @@ func (a *action) Results() (map[string]interface{}, string) {
 	return a.doc.Results, a.doc.Message
 }
 
+// Messages returns the progress messages logged by the action.
+func (a *action) Messages() []ActionMessage {
+	return a.doc.Logs
+}
+
 // Tag implements the Entity interface and returns a names.Tag that
 // is a names.ActionTag.
 func (a *action) Tag() names.Tag {
@@ -201,6 +263,45 @@ func (a *action) Begin() (Action, error) {
 	return a.st.Action(a.Id())
 }
 
+// maxActionMessages is the maximum number of progress messages that
+// are recorded for an action; any further messages are dropped, so that
+// a misbehaving action cannot grow its document without bound.
+const maxActionMessages = 1000
+
+// Log adds a timestamped progress message to the action. It asserts
+// that the action is currently running.
+func (a *action) Log(message string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			current, err := a.st.Action(a.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			a = current.(*action)
+		}
+		if status := a.doc.Status; status != ActionRunning {
+			return nil, errors.Errorf("action is %s", status)
+		}
+		if len(a.doc.Logs) >= maxActionMessages {
+			actionLogger.Warningf("action %q logged more than %d messages, dropping %q", a.Id(), maxActionMessages, message)
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      actionsC,
+			Id:     a.doc.DocId,
+			Assert: bson.D{{"status", ActionRunning}},
+			Update: bson.D{{"$push", bson.D{{"messages", ActionMessage{
+				MessageValue:   message,
+				TimestampValue: a.st.NowToTheSecond(),
+			}}}}},
+		}}, nil
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot log message to action %q", a.Id())
+	}
+	return nil
+}
+
 // Finish removes action from the pending queue and captures the output
 // and end state of the action.
 func (a *action) Finish(results ActionResults) (Action, error) {
@@ -211,8 +312,25 @@ func (a *action) Finish(results ActionResults) (Action, error) {
 // an actionresult to capture the outcome of the action. It asserts that
 // the action is not already completed.
 func (a *action) removeAndLog(finalStatus ActionStatus, results map[string]interface{}, message string) (Action, error) {
-	err := a.st.runTransaction([]txn.Op{
-		{
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			current, err := a.st.Action(a.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			a = current.(*action)
+			switch a.doc.Status {
+			case ActionCompleted, ActionCancelled, ActionFailed:
+				return nil, txn.ErrAborted
+			}
+		}
+		// Whether the action is held determines whether finishing it
+		// releases its dispatch, so it must not have changed.
+		held := bson.DocElem{"held", bson.D{{"$ne", true}}}
+		if a.doc.Held {
+			held = bson.DocElem{"held", true}
+		}
+		ops := []txn.Op{{
 			C:  actionsC,
 			Id: a.doc.DocId,
 			Assert: bson.D{{"status", bson.D{
@@ -220,7 +338,7 @@ func (a *action) removeAndLog(finalStatus ActionStatus, results map[string]inter
 					ActionCompleted,
 					ActionCancelled,
 					ActionFailed,
-				}}}}},
+				}}}}, held},
 			Update: bson.D{{"$set", bson.D{
 				{"status", finalStatus},
 				{"message", message},
@@ -231,8 +349,14 @@ func (a *action) removeAndLog(finalStatus ActionStatus, results map[string]inter
 			C:      actionNotificationsC,
 			Id:     a.st.docID(ensureActionMarker(a.Receiver()) + a.Id()),
 			Remove: true,
-		}})
-	if err != nil {
+		}}
+		releaseOps, err := a.st.finishActionDispatchOps(a.doc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, releaseOps...), nil
+	}
+	if err := a.st.run(buildTxn); err != nil {
 		return nil, err
 	}
 	return a.st.Action(a.Id())
@@ -247,7 +371,7 @@ func newAction(st *State, adoc actionDoc) Action {
 }
 
 // newActionDoc builds the actionDoc with the given name and parameters.
-func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameters map[string]interface{}) (actionDoc, actionNotificationDoc, error) {
+func newActionDoc(st *State, receiverTag names.Tag, operationId, actionName string, parameters map[string]interface{}) (actionDoc, actionNotificationDoc, error) {
 	prefix := ensureActionMarker(receiverTag.Id())
 	actionId, err := NewUUID()
 	if err != nil {
@@ -260,6 +384,7 @@ func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameter
 			ModelUUID:  modelUUID,
 			Receiver:   receiverTag.Id(),
 			Name:       actionName,
+			Operation:  operationId,
 			Parameters: parameters,
 			Enqueued:   st.NowToTheSecond(),
 			Status:     ActionPending,
@@ -354,47 +479,85 @@ func (st *State) FindActionsByName(name string) ([]Action, error) {
 	return results, errors.Trace(iter.Close())
 }
 
+// FindActionsByOperation finds the Actions enqueued as part of the
+// operation with the given id.
+func (st *State) FindActionsByOperation(operationId string) ([]Action, error) {
+	var results []Action
+	var doc actionDoc
+
+	actions, closer := st.db().GetCollection(actionsC)
+	defer closer()
+
+	iter := actions.Find(bson.D{{"operation", operationId}}).Iter()
+	for iter.Next(&doc) {
+		results = append(results, newAction(st, doc))
+	}
+	return results, errors.Trace(iter.Close())
+}
+
 // EnqueueAction
 func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}) (Action, error) {
+	return st.EnqueueActionWithOptions(receiver, actionName, payload, ActionOptions{})
+}
+
+// ActionOptions holds the optional settings of an Action.
+type ActionOptions struct {
+	// Operation is the id of the operation the action is enqueued as
+	// part of, so that the results of the actions enqueued on several
+	// receivers may be collected together.
+	Operation string
+
+	// Timeout is how long the action may run for before it is stopped
+	// and marked as failed; zero means no timeout.
+	Timeout time.Duration
+}
+
+// EnqueueActionWithOptions enqueues an Action on the receiver with the
+// given options. If the receiver is a unit of an application with an
+// action concurrency limit, the action is held until it may be
+// dispatched without exceeding the limit.
+func (st *State) EnqueueActionWithOptions(receiver names.Tag, actionName string, payload map[string]interface{}, options ActionOptions) (Action, error) {
 	if len(actionName) == 0 {
 		return nil, errors.New("action name required")
 	}
+	if options.Timeout < 0 {
+		return nil, errors.New("action timeout cannot be negative")
+	}
 
 	receiverCollectionName, receiverId, err := st.tagToCollectionAndId(receiver)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
-	doc, ndoc, err := newActionDoc(st, receiver, actionName, payload)
+	doc, ndoc, err := newActionDoc(st, receiver, options.Operation, actionName, payload)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-
-	ops := []txn.Op{{
-		C:      receiverCollectionName,
-		Id:     receiverId,
-		Assert: notDeadDoc,
-	}, {
-		C:      actionsC,
-		Id:     doc.DocId,
-		Assert: txn.DocMissing,
-		Insert: doc,
-	}, {
-		C:      actionNotificationsC,
-		Id:     ndoc.DocId,
-		Assert: txn.DocMissing,
-		Insert: ndoc,
-	}}
+	doc.Timeout = options.Timeout
 
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		if notDead, err := isNotDead(st, receiverCollectionName, receiverId); err != nil {
 			return nil, err
 		} else if !notDead {
 			return nil, ErrDead
-		} else if attempt != 0 {
-			return nil, errors.Errorf("unexpected attempt number '%d'", attempt)
 		}
-		return ops, nil
+		// The dispatch ops mark the action as held or throttled, so
+		// they must be built before the action is inserted.
+		dispatchOps, err := st.enqueueActionDispatchOps(receiver, &doc, ndoc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      receiverCollectionName,
+			Id:     receiverId,
+			Assert: notDeadDoc,
+		}, {
+			C:      actionsC,
+			Id:     doc.DocId,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		}}
+		return append(ops, dispatchOps...), nil
 	}
 	if err = st.run(buildTxn); err == nil {
 		return newAction(st, doc), nil
@@ -5,8 +5,10 @@ package state_test
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -15,6 +17,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing"
@@ -463,6 +466,49 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 	c.Assert(len(actions), gc.Equals, 0)
 }
 
+func (s *ActionSuite) TestLog(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Messages(), gc.HasLen, 0)
+
+	action, err := a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = action.Log("one")
+	c.Assert(err, jc.ErrorIsNil)
+	err = action.Log("two")
+	c.Assert(err, jc.ErrorIsNil)
+
+	action, err = s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Assert(messages[0].Message(), gc.Equals, "one")
+	c.Assert(messages[1].Message(), gc.Equals, "two")
+	c.Assert(messages[0].Timestamp().Before(action.Started()), jc.IsFalse)
+
+	// The messages are kept when the action finishes.
+	action, err = action.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Messages(), jc.DeepEquals, messages)
+}
+
+func (s *ActionSuite) TestLogNotRunning(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = a.Log("too early")
+	c.Assert(err, gc.ErrorMatches, `cannot log message to action ".*": action is pending`)
+
+	action, err := a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Finish(state.ActionResults{Status: state.ActionFailed})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The action is refreshed when the transaction is retried.
+	err = action.Log("too late")
+	c.Assert(err, gc.ErrorMatches, `cannot log message to action ".*": action is failed`)
+}
+
 func (s *ActionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	prefix := "feedbeef"
 	uuidMock := uuidMockHelper{}
@@ -519,6 +565,48 @@ func (s *ActionSuite) TestFindActionsByName(c *gc.C) {
 	}
 }
 
+func (s *ActionSuite) TestFindActionsByOperation(c *gc.C) {
+	a1, err := s.State.EnqueueActionWithOptions(s.unit.Tag(), "snapshot", nil, state.ActionOptions{Operation: "op-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	a2, err := s.State.EnqueueActionWithOptions(s.unit2.Tag(), "snapshot", nil, state.ActionOptions{Operation: "op-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.EnqueueActionWithOptions(s.unit.Tag(), "snapshot", nil, state.ActionOptions{Operation: "op-2"})
+	c.Assert(err, jc.ErrorIsNil)
+	a4, err := s.State.EnqueueAction(s.unit.Tag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(a1.Operation(), gc.Equals, "op-1")
+	c.Check(a4.Operation(), gc.Equals, "")
+
+	results, err := s.State.FindActionsByOperation("op-1")
+	c.Assert(err, jc.ErrorIsNil)
+	ids := make([]string, len(results))
+	for i, result := range results {
+		c.Check(result.Operation(), gc.Equals, "op-1")
+		ids[i] = result.Id()
+	}
+	c.Check(ids, jc.SameContents, []string{a1.Id(), a2.Id()})
+}
+
+func (s *ActionSuite) TestUnitAddActionWithOptions(c *gc.C) {
+	a, err := s.unit.AddActionWithOptions("snapshot", map[string]interface{}{"outfile": "out"}, state.ActionOptions{
+		Operation: "op-1",
+		Timeout:   time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(a.Operation(), gc.Equals, "op-1")
+
+	a, err = s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(a.Operation(), gc.Equals, "op-1")
+	c.Check(a.Timeout(), gc.Equals, time.Minute)
+	c.Check(a.Receiver(), gc.Equals, s.unit.Name())
+}
+
+func (s *ActionSuite) TestEnqueueActionNegativeTimeout(c *gc.C) {
+	_, err := s.unit.AddActionWithOptions("snapshot", nil, state.ActionOptions{Timeout: -time.Second})
+	c.Assert(err, gc.ErrorMatches, "action timeout cannot be negative")
+}
+
 func (s *ActionSuite) TestActionsWatcherEmitsInitialChanges(c *gc.C) {
 	// LP-1391914 :: idPrefixWatcher fails watcher contract to send
 	// initial Change event
@@ -773,6 +861,52 @@ func (s *ActionSuite) TestWatchActionNotifications(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *ActionSuite) TestWatchActionMessages(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err := a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = action.Log("one")
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchActionMessages(a.Id())
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	// The initial event holds the messages logged so far.
+	wc.AssertChange(encodeActionMessages(c, s.State, a.Id(), 0)...)
+	wc.AssertNoChange()
+
+	err = action.Log("two")
+	c.Assert(err, jc.ErrorIsNil)
+	err = action.Log("three")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(encodeActionMessages(c, s.State, a.Id(), 1)...)
+	wc.AssertNoChange()
+
+	// Finishing the action does not log any messages.
+	_, err = action.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+// encodeActionMessages returns the messages of the action with the
+// given id, skipping the first skip messages, as they are reported by
+// the action messages watcher.
+func encodeActionMessages(c *gc.C, st *state.State, id string, skip int) []string {
+	action, err := st.Action(id)
+	c.Assert(err, jc.ErrorIsNil)
+	var encoded []string
+	for _, message := range action.Messages()[skip:] {
+		data, err := json.Marshal(params.ActionMessage{
+			Message:   message.Message(),
+			Timestamp: message.Timestamp(),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		encoded = append(encoded, string(data))
+	}
+	return encoded
+}
+
 func (s *ActionSuite) TestActionStatusWatcher(c *gc.C) {
 	testCase := []struct {
 		receiver state.ActionReceiver
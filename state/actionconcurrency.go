@@ -0,0 +1,264 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// Actions enqueued on the units of an application with an action
+// concurrency limit are throttled: an action is dispatched to its unit,
+// by inserting its notification, only while fewer than the limit of the
+// application's throttled actions are dispatched and unfinished. Other
+// actions are held, and dispatched in the order they were enqueued as
+// earlier actions finish. The application document counts the
+// dispatched actions, so that the limit may be asserted on, and the
+// held actions, so that an action cannot be held while the last
+// dispatched action finishes without dispatching it.
+
+// ActionConcurrency returns the maximum number of actions that may be
+// dispatched to the application's units at once, or zero if there is
+// no limit.
+func (a *Application) ActionConcurrency() int {
+	return a.doc.ActionConcurrency
+}
+
+// SetActionConcurrency sets the maximum number of actions that may be
+// dispatched to the application's units at once; zero removes the
+// limit. Actions held back by the previous limit are dispatched as far
+// as the new limit allows.
+func (a *Application) SetActionConcurrency(limit int) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set action concurrency for application %q", a)
+	if limit < 0 {
+		return errors.New("action concurrency cannot be negative")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		if limit == a.doc.ActionConcurrency {
+			return nil, jujutxn.ErrNoOperations
+		}
+		var held []actionDoc
+		var err error
+		switch {
+		case limit == 0:
+			held, err = a.st.heldActions(a.doc.Name, 0)
+		case limit > a.doc.DispatchedActions:
+			held, err = a.st.heldActions(a.doc.Name, limit-a.doc.DispatchedActions)
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		update := bson.D{{"$set", bson.D{{"action-concurrency", limit}}}}
+		if len(held) > 0 {
+			update = append(update, bson.DocElem{"$inc", bson.D{
+				{"dispatched-actions", len(held)},
+				{"held-actions", -len(held)},
+			}})
+		}
+		if limit == 0 {
+			// Without a limit, actions are no longer counted.
+			update = bson.D{{"$unset", bson.D{
+				{"action-concurrency", nil},
+				{"dispatched-actions", nil},
+				{"held-actions", nil},
+			}}}
+		}
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: bson.D{{"life", Alive}, {"txn-revno", a.doc.TxnRevno}},
+			Update: update,
+		}}
+		for _, doc := range held {
+			ops = append(ops, dispatchHeldActionOps(a.st, doc)...)
+		}
+		return ops, nil
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	a.doc.ActionConcurrency = limit
+	if limit == 0 {
+		a.doc.DispatchedActions = 0
+		a.doc.HeldActions = 0
+	}
+	return nil
+}
+
+// enqueueActionDispatchOps returns the operations that dispatch a newly
+// enqueued action to its receiver. If the receiver is a unit of an
+// application with an action concurrency limit, the action is marked as
+// throttled, and is held instead if the limit has been reached.
+func (st *State) enqueueActionDispatchOps(receiver names.Tag, doc *actionDoc, ndoc actionNotificationDoc) ([]txn.Op, error) {
+	doc.Throttled, doc.Held, doc.HeldSequence = false, false, 0
+	notifyOp := txn.Op{
+		C:      actionNotificationsC,
+		Id:     ndoc.DocId,
+		Assert: txn.DocMissing,
+		Insert: ndoc,
+	}
+	if receiver.Kind() != names.UnitTagKind {
+		return []txn.Op{notifyOp}, nil
+	}
+	applicationName, err := names.UnitApplication(receiver.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := st.Application(applicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	limit := app.doc.ActionConcurrency
+	if limit == 0 {
+		// Without a limit the application is not asserted on, so that
+		// enqueueing actions does not contend for its document.
+		return []txn.Op{notifyOp}, nil
+	}
+
+	doc.Throttled = true
+	if app.doc.DispatchedActions >= limit {
+		seq, err := st.sequenceWithMin("heldaction", 1)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		doc.Held, doc.HeldSequence = true, seq
+		return []txn.Op{{
+			C:  applicationsC,
+			Id: app.doc.DocID,
+			Assert: bson.D{
+				{"action-concurrency", limit},
+				{"dispatched-actions", bson.D{{"$gte", limit}}},
+			},
+			Update: bson.D{{"$inc", bson.D{{"held-actions", 1}}}},
+		}}, nil
+	}
+	return []txn.Op{{
+		C:  applicationsC,
+		Id: app.doc.DocID,
+		Assert: bson.D{
+			{"action-concurrency", limit},
+			// The count is not stored until an action is dispatched.
+			{"dispatched-actions", bson.D{{"$not", bson.D{{"$gte", limit}}}}},
+		},
+		Update: bson.D{{"$inc", bson.D{{"dispatched-actions", 1}}}},
+	}, notifyOp}, nil
+}
+
+// finishActionDispatchOps returns the operations that release the
+// dispatch of a finishing throttled action, dispatching the next held
+// action of the application in its place if there is one. A held
+// action that finishes, by being cancelled, is no longer counted.
+func (st *State) finishActionDispatchOps(doc actionDoc) ([]txn.Op, error) {
+	if !doc.Throttled {
+		return nil, nil
+	}
+	applicationName, err := names.UnitApplication(doc.Receiver)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := st.Application(applicationName)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if doc.Held {
+		if app.doc.HeldActions <= 0 {
+			return nil, nil
+		}
+		return []txn.Op{releaseHeldActionOp(app)}, nil
+	}
+
+	next, err := st.heldActions(applicationName, 1)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(next) > 0 {
+		ops := []txn.Op{releaseHeldActionOp(app)}
+		return append(ops, dispatchHeldActionOps(st, next[0])...), nil
+	}
+	if app.doc.DispatchedActions <= 0 {
+		return nil, nil
+	}
+	return []txn.Op{{
+		C:  applicationsC,
+		Id: app.doc.DocID,
+		Assert: bson.D{
+			{"dispatched-actions", bson.D{{"$gt", 0}}},
+			// If an action is held concurrently, it must be
+			// dispatched in place of this one instead.
+			{"held-actions", bson.D{{"$not", bson.D{{"$gt", 0}}}}},
+		},
+		Update: bson.D{{"$inc", bson.D{{"dispatched-actions", -1}}}},
+	}}, nil
+}
+
+// releaseHeldActionOp returns the operation that stops counting one of
+// the application's held actions, as it is dispatched or finished.
+func releaseHeldActionOp(app *Application) txn.Op {
+	return txn.Op{
+		C:      applicationsC,
+		Id:     app.doc.DocID,
+		Assert: bson.D{{"held-actions", bson.D{{"$gt", 0}}}},
+		Update: bson.D{{"$inc", bson.D{{"held-actions", -1}}}},
+	}
+}
+
+// heldActions returns up to max of the held actions of the application's
+// units, in the order they were held; if max is zero, all of them are
+// returned.
+func (st *State) heldActions(applicationName string, max int) ([]actionDoc, error) {
+	actions, closer := st.db().GetCollection(actionsC)
+	defer closer()
+
+	query := actions.Find(bson.D{
+		{"receiver", bson.D{{"$regex", "^" + regexp.QuoteMeta(applicationName) + "/"}}},
+		{"held", true},
+		{"status", ActionPending},
+	}).Sort("held-sequence", "enqueued", "_id")
+	if max > 0 {
+		query = query.Limit(max)
+	}
+	var docs []actionDoc
+	if err := query.All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get held actions of application %q", applicationName)
+	}
+	return docs, nil
+}
+
+// dispatchHeldActionOps returns the operations that dispatch the held
+// action to its receiver.
+func dispatchHeldActionOps(st *State, doc actionDoc) []txn.Op {
+	actionId := st.localID(doc.DocId)
+	return []txn.Op{{
+		C:      actionsC,
+		Id:     doc.DocId,
+		Assert: bson.D{{"held", true}, {"status", ActionPending}},
+		Update: bson.D{{"$unset", bson.D{{"held", nil}, {"held-sequence", nil}}}},
+	}, {
+		C:      actionNotificationsC,
+		Id:     st.docID(ensureActionMarker(doc.Receiver) + actionId),
+		Assert: txn.DocMissing,
+		Insert: actionNotificationDoc{
+			DocId:     st.docID(ensureActionMarker(doc.Receiver) + actionId),
+			ModelUUID: doc.ModelUUID,
+			Receiver:  doc.Receiver,
+			ActionID:  actionId,
+		},
+	}}
+}
@@ -0,0 +1,175 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+func (s *ActionSuite) TestSetActionConcurrency(c *gc.C) {
+	c.Assert(s.service.ActionConcurrency(), gc.Equals, 0)
+	err := s.service.SetActionConcurrency(2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.service.ActionConcurrency(), gc.Equals, 2)
+
+	err = s.service.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.service.ActionConcurrency(), gc.Equals, 2)
+
+	err = s.service.SetActionConcurrency(-1)
+	c.Assert(err, gc.ErrorMatches, `cannot set action concurrency for application "dummy": action concurrency cannot be negative`)
+}
+
+func (s *ActionSuite) TestActionConcurrencyHoldsActions(c *gc.C) {
+	err := s.service.SetActionConcurrency(1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	a1, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a2, err := s.unit2.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a3, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(state.ActionIsHeld(c, s.State, a1.Id()), jc.IsFalse)
+	c.Check(state.ActionIsHeld(c, s.State, a2.Id()), jc.IsTrue)
+	c.Check(state.ActionIsHeld(c, s.State, a3.Id()), jc.IsTrue)
+
+	// Actions of other applications are not held.
+	a4, err := s.actionlessUnit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(state.ActionIsHeld(c, s.State, a4.Id()), jc.IsFalse)
+
+	// Finishing an action dispatches the oldest held action.
+	_, err = a1.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(state.ActionIsHeld(c, s.State, a2.Id()), jc.IsFalse)
+	c.Check(state.ActionIsHeld(c, s.State, a3.Id()), jc.IsTrue)
+
+	// Cancelling a held action does not release a dispatch.
+	_, err = s.unit.CancelAction(a3)
+	c.Assert(err, jc.ErrorIsNil)
+	a5, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(state.ActionIsHeld(c, s.State, a5.Id()), jc.IsTrue)
+
+	a2, err = s.State.Action(a2.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a2.Finish(state.ActionResults{Status: state.ActionFailed})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(state.ActionIsHeld(c, s.State, a5.Id()), jc.IsFalse)
+
+	// With no held actions left, the limit applies to new actions
+	// once more.
+	a5, err = s.State.Action(a5.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a5.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	a6, err := s.unit2.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(state.ActionIsHeld(c, s.State, a6.Id()), jc.IsFalse)
+	a7, err := s.unit2.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(state.ActionIsHeld(c, s.State, a7.Id()), jc.IsTrue)
+}
+
+func (s *ActionSuite) TestSetActionConcurrencyDispatchesHeldActions(c *gc.C) {
+	err := s.service.SetActionConcurrency(1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var ids []string
+	for i := 0; i < 4; i++ {
+		a, err := s.unit.AddAction("snapshot", nil)
+		c.Assert(err, jc.ErrorIsNil)
+		ids = append(ids, a.Id())
+	}
+
+	err = s.service.SetActionConcurrency(2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(state.ActionIsHeld(c, s.State, ids[1]), jc.IsFalse)
+	c.Check(state.ActionIsHeld(c, s.State, ids[2]), jc.IsTrue)
+	c.Check(state.ActionIsHeld(c, s.State, ids[3]), jc.IsTrue)
+
+	err = s.service.SetActionConcurrency(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(state.ActionIsHeld(c, s.State, ids[2]), jc.IsFalse)
+	c.Check(state.ActionIsHeld(c, s.State, ids[3]), jc.IsFalse)
+}
+
+func (s *ActionSuite) TestActionConcurrencyDispatchesHeldActionsInOrder(c *gc.C) {
+	err := s.service.SetActionConcurrency(1)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The held actions are enqueued within the same second, so they
+	// can only be dispatched in order by their held sequence.
+	var held []string
+	for i := 0; i < 5; i++ {
+		a, err := s.unit2.AddAction("snapshot", nil)
+		c.Assert(err, jc.ErrorIsNil)
+		held = append(held, a.Id())
+	}
+	for i, id := range held {
+		_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(state.ActionIsHeld(c, s.State, id), jc.IsFalse)
+		for _, laterId := range held[i+1:] {
+			c.Assert(state.ActionIsHeld(c, s.State, laterId), jc.IsTrue)
+		}
+		a, err = s.State.Action(id)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *ActionSuite) TestActionConcurrencyHoldsActionWhileLastFinishes(c *gc.C) {
+	err := s.service.SetActionConcurrency(1)
+	c.Assert(err, jc.ErrorIsNil)
+	a1, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// An action held while the only dispatched action finishes must
+	// be dispatched in its place.
+	var a2 state.Action
+	defer state.SetBeforeHooks(c, s.State, func() {
+		a2, err = s.unit2.AddAction("snapshot", nil)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(state.ActionIsHeld(c, s.State, a2.Id()), jc.IsTrue)
+	}).Check()
+
+	_, err = a1.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(state.ActionIsHeld(c, s.State, a2.Id()), jc.IsFalse)
+}
+
+func (s *ActionSuite) TestRemovingUnitReleasesItsActions(c *gc.C) {
+	err := s.service.SetActionConcurrency(1)
+	c.Assert(err, jc.ErrorIsNil)
+	a1, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a2, err := s.unit2.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state.ActionIsHeld(c, s.State, a2.Id()), jc.IsTrue)
+
+	// The removed unit's unfinished actions are cancelled, releasing
+	// their dispatches to the held actions of other units.
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	a1, err = s.State.Action(a1.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(a1.Status(), gc.Equals, state.ActionCancelled)
+	c.Check(state.ActionIsHeld(c, s.State, a2.Id()), jc.IsFalse)
+
+	// With the dispatch released, the limit is reached once more.
+	a3, err := s.unit2.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(state.ActionIsHeld(c, s.State, a3.Id()), jc.IsTrue)
+}
@@ -0,0 +1,167 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// maxPrunedActions is the maximum number of actions returned by
+// ActionsToPrune at once, so that a large backlog is pruned over
+// several passes rather than in a single enormous request.
+const maxPrunedActions = 1000
+
+// maxRemovedActionsPerTxn is the maximum number of actions removed by
+// RemoveActions in a single transaction.
+const maxRemovedActionsPerTxn = 100
+
+// finishedActionStatuses holds the statuses of the actions that may be
+// pruned.
+var finishedActionStatuses = []interface{}{
+	ActionCompleted,
+	ActionCancelled,
+	ActionFailed,
+}
+
+// ActionsToPrune returns the completed, failed and cancelled actions
+// of the model that were finished longer than maxHistoryTime ago, or
+// that must be removed to bring the actions collection within
+// maxHistoryMB, oldest first. A zero maxHistoryTime or maxHistoryMB
+// disables that constraint.
+func ActionsToPrune(st *State, maxHistoryTime time.Duration, maxHistoryMB int) ([]Action, error) {
+	if maxHistoryMB < 0 {
+		return nil, errors.NotValidf("non-positive maxHistoryMB")
+	}
+	if maxHistoryTime < 0 {
+		return nil, errors.NotValidf("non-positive maxHistoryTime")
+	}
+	if maxHistoryMB == 0 && maxHistoryTime == 0 {
+		return nil, errors.NotValidf("backlog size and time constraints are both 0")
+	}
+
+	actions, closer := st.db().GetCollection(actionsC)
+	defer closer()
+	finished := bson.DocElem{"status", bson.D{{"$in", finishedActionStatuses}}}
+
+	var docs []actionDoc
+	if maxHistoryTime > 0 {
+		t := st.clock.Now().Add(-maxHistoryTime)
+		err := actions.Find(bson.D{
+			finished,
+			{"completed", bson.D{{"$lt", t}}},
+		}).Sort("completed", "_id").Limit(maxPrunedActions).All(&docs)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot get expired actions")
+		}
+	}
+	if maxHistoryMB > 0 {
+		excess, err := excessActions(st, maxHistoryMB)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if excess > len(docs) {
+			// The oldest actions include all of those that have
+			// expired, as both are sorted by completion time.
+			if excess > maxPrunedActions {
+				excess = maxPrunedActions
+			}
+			err := actions.Find(bson.D{finished}).Sort("completed", "_id").Limit(excess).All(&docs)
+			if err != nil {
+				return nil, errors.Annotate(err, "cannot get oldest actions")
+			}
+		}
+	}
+
+	results := make([]Action, len(docs))
+	for i, doc := range docs {
+		results[i] = newAction(st, doc)
+	}
+	return results, nil
+}
+
+// excessActions returns roughly the number of actions that must be
+// removed to bring the actions collection within maxHistoryMB.
+func excessActions(st *State, maxHistoryMB int) (int, error) {
+	// A raw collection is needed to obtain the size of the collection;
+	// it is only read, never written.
+	actions, closer := st.getRawCollection(actionsC)
+	defer closer()
+
+	collMB, err := getCollectionMB(actions)
+	if err != nil {
+		return 0, errors.Annotate(err, "retrieving actions collection size")
+	}
+	if collMB <= maxHistoryMB {
+		return 0, nil
+	}
+	count, err := actions.Count()
+	if err == mgo.ErrNotFound || count <= 0 {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Annotate(err, "counting actions")
+	}
+	// As with status history, we assume that the size of actions can be
+	// averaged to get a reasonable approximation.
+	sizePerAction := float64(collMB) / float64(count)
+	return int(float64(collMB-maxHistoryMB) / sizePerAction), nil
+}
+
+// RemoveActions removes the actions with the given ids from the model,
+// in batches of at most maxRemovedActionsPerTxn. Actions that have not
+// finished are never removed.
+func RemoveActions(st *State, ids []string) error {
+	for len(ids) > 0 {
+		batch := ids
+		if len(batch) > maxRemovedActionsPerTxn {
+			batch = batch[:maxRemovedActionsPerTxn]
+		}
+		ids = ids[len(batch):]
+		if err := removeFinishedActions(st, batch); err != nil {
+			return errors.Annotate(err, "cannot remove actions")
+		}
+	}
+	return nil
+}
+
+// removeFinishedActions removes those of the actions with the given ids
+// that exist and have finished, in a single transaction.
+func removeFinishedActions(st *State, ids []string) error {
+	actions, closer := st.db().GetCollection(actionsC)
+	defer closer()
+
+	finished := bson.D{{"status", bson.D{{"$in", finishedActionStatuses}}}}
+	buildTxn := func(int) ([]txn.Op, error) {
+		var docs []struct {
+			DocId string `bson:"_id"`
+		}
+		err := actions.Find(bson.D{
+			{"_id", bson.D{{"$in", ids}}},
+			finished[0],
+		}).Select(bson.D{{"_id", 1}}).All(&docs)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(docs) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		ops := make([]txn.Op, len(docs))
+		for i, doc := range docs {
+			ops[i] = txn.Op{
+				C:      actionsC,
+				Id:     doc.DocId,
+				Assert: finished,
+				Remove: true,
+			}
+		}
+		return ops, nil
+	}
+	return st.run(buildTxn)
+}
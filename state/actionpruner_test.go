@@ -0,0 +1,68 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+func (s *ActionSuite) TestActionsToPruneByAge(c *gc.C) {
+	clock := testing.NewClock(coretesting.NonZeroTime())
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	old, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = old.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	cancelled, err := s.unit2.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.unit2.CancelAction(cancelled)
+	c.Assert(err, jc.ErrorIsNil)
+	pending, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	clock.Advance(48 * time.Hour)
+	recent, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = recent.Finish(state.ActionResults{Status: state.ActionFailed})
+	c.Assert(err, jc.ErrorIsNil)
+
+	actions, err := state.ActionsToPrune(s.State, 24*time.Hour, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	ids := make([]string, len(actions))
+	for i, action := range actions {
+		ids[i] = action.Id()
+	}
+	c.Assert(ids, jc.SameContents, []string{old.Id(), cancelled.Id()})
+
+	// Actions that have not finished are never removed.
+	err = state.RemoveActions(s.State, append(ids, pending.Id()))
+	c.Assert(err, jc.ErrorIsNil)
+	for _, id := range ids {
+		_, err = s.State.Action(id)
+		c.Check(err, jc.Satisfies, errors.IsNotFound)
+	}
+	_, err = s.State.Action(pending.Id())
+	c.Check(err, jc.ErrorIsNil)
+	_, err = s.State.Action(recent.Id())
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ActionSuite) TestActionsToPruneInvalidArgs(c *gc.C) {
+	_, err := state.ActionsToPrune(s.State, 0, 0)
+	c.Assert(err, gc.ErrorMatches, "backlog size and time constraints are both 0 not valid")
+	_, err = state.ActionsToPrune(s.State, -time.Hour, 0)
+	c.Assert(err, gc.ErrorMatches, "non-positive maxHistoryTime not valid")
+	_, err = state.ActionsToPrune(s.State, 0, -1)
+	c.Assert(err, gc.ErrorMatches, "non-positive maxHistoryMB not valid")
+}
@@ -271,7 +271,10 @@ func (st *State) addMachineOps(template MachineTemplate) (*machineDoc, []txn.Op,
 	if err != nil {
 		return nil, nil, err
 	}
-	mdoc := st.machineDocForTemplate(template, strconv.Itoa(seq))
+	mdoc, err := st.machineDocForTemplate(template, strconv.Itoa(seq))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	prereqOps, machineOp, err := st.insertNewMachineOps(mdoc, template)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
@@ -349,7 +352,10 @@ func (st *State) addMachineInsideMachineOps(template MachineTemplate, parentId s
 	if err != nil {
 		return nil, nil, err
 	}
-	mdoc := st.machineDocForTemplate(template, newId)
+	mdoc, err := st.machineDocForTemplate(template, newId)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	mdoc.ContainerType = string(containerType)
 	prereqOps, machineOp, err := st.insertNewMachineOps(mdoc, template)
 	if err != nil {
@@ -399,7 +405,10 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 		}
 	}
 
-	parentDoc := st.machineDocForTemplate(parentTemplate, strconv.Itoa(seq))
+	parentDoc, err := st.machineDocForTemplate(parentTemplate, strconv.Itoa(seq))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	newId, err := st.newContainerId(parentDoc.Id, containerType)
 	if err != nil {
 		return nil, nil, err
@@ -408,7 +417,10 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 	if err != nil {
 		return nil, nil, err
 	}
-	mdoc := st.machineDocForTemplate(template, newId)
+	mdoc, err := st.machineDocForTemplate(template, newId)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	mdoc.ContainerType = string(containerType)
 	parentPrereqOps, parentOp, err := st.insertNewMachineOps(parentDoc, parentTemplate)
 	if err != nil {
@@ -428,13 +440,17 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 	return mdoc, append(prereqOps, parentOp, machineOp), nil
 }
 
-func (st *State) machineDocForTemplate(template MachineTemplate, id string) *machineDoc {
+func (st *State) machineDocForTemplate(template MachineTemplate, id string) (*machineDoc, error) {
+	preferred, err := st.preferredAddressType()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	// We ignore the error from Select*Address as an error indicates
 	// no address is available, in which case the empty address is returned
 	// and setting the preferred address to an empty one is the correct
 	// thing to do when none is available.
-	privateAddr, _ := network.SelectInternalAddress(template.Addresses, false)
-	publicAddr, _ := network.SelectPublicAddress(template.Addresses)
+	privateAddr, _ := network.SelectInternalAddressPreferring(template.Addresses, false, preferred)
+	publicAddr, _ := network.SelectPublicAddressPreferring(template.Addresses, preferred)
 	logger.Infof(
 		"new machine %q has preferred addresses: private %q, public %q",
 		id, privateAddr, publicAddr,
@@ -454,7 +470,7 @@ func (st *State) machineDocForTemplate(template MachineTemplate, id string) *mac
 		PreferredPublicAddress:  fromNetworkAddress(publicAddr, OriginMachine),
 		NoVote:                  template.NoVote,
 		Placement:               template.Placement,
-	}
+	}, nil
 }
 
 // insertNewMachineOps returns operations to insert the given machine document
@@ -601,8 +617,28 @@ func (st *State) machineStorageOps(
 		})
 	}
 
-	// TODO(axw) handle args.filesystemAttachments, args.volumeAttachments
-	// when we handle attaching to existing (e.g. shared) storage.
+	// Create attachments for existing filesystems and volumes, e.g.
+	// storage that was imported into the model. Each existing entity
+	// must be Alive for a new attachment to be recorded against it.
+	for tag, params := range args.filesystemAttachments {
+		if _, err := st.FilesystemAttachment(names.NewMachineTag(mdoc.Id), tag); err == nil {
+			// The filesystem is shared, and is already
+			// attached to the machine for another unit.
+			continue
+		} else if !errors.IsNotFound(err) {
+			return nil, nil, nil, errors.Trace(err)
+		}
+		filesystemOps = append(filesystemOps, increfMachineStorageOp(filesystemsC, tag.Id()))
+		fsAttachments = append(fsAttachments, filesystemAttachmentTemplate{
+			tag: tag, params: params,
+		})
+	}
+	for tag, params := range args.volumeAttachments {
+		volumeOps = append(volumeOps, increfMachineStorageOp(volumesC, tag.Id()))
+		volumeAttachments = append(volumeAttachments, volumeAttachmentTemplate{
+			tag, params,
+		})
+	}
 
 	ops := make([]txn.Op, 0, len(filesystemOps)+len(volumeOps)+len(fsAttachments)+len(volumeAttachments))
 	if len(fsAttachments) > 0 {
@@ -202,6 +202,22 @@ func fromNetworkAddresses(netAddrs []network.Address, origin Origin) []address {
 	return addrs
 }
 
+// preferredAddressType returns the type of address, IPv4 or IPv6,
+// that the model's preferred-address-family config prefers when
+// selecting and sorting machine addresses. The process-wide
+// preference in the network package is not used, as it belongs to
+// the agent rather than to any one model.
+func (st *State) preferredAddressType() (network.AddressType, error) {
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if cfg.PreferredAddressFamily() == string(network.IPv6Address) {
+		return network.IPv6Address, nil
+	}
+	return network.IPv4Address, nil
+}
+
 // networkAddresses is a convenience helper to return the state type
 // as network type, here for a slice of Address.
 func networkAddresses(addrs []address) []network.Address {
@@ -329,6 +329,7 @@ func allCollections() collectionSchema {
 			}},
 		},
 		volumeAttachmentsC: {},
+		volumeSnapshotsC:   {},
 
 		// -----
 
@@ -347,6 +348,10 @@ func allCollections() collectionSchema {
 		actionsC: {
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "name"},
+			}, {
+				Key: []string{"model-uuid", "operation"},
+			}, {
+				Key: []string{"model-uuid", "status", "completed"},
 			}},
 		},
 		actionNotificationsC: {},
@@ -384,6 +389,14 @@ func allCollections() collectionSchema {
 		// unit relation settings, model config, etc etc etc.
 		settingsC: {},
 
+		// This collection holds the history of changes made to the
+		// model config and application config settings.
+		settingsHistoryC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "key", "revision"},
+			}},
+		},
+
 		constraintsC:        {},
 		storageConstraintsC: {},
 		statusesC:           {},
@@ -498,6 +511,7 @@ const (
 	applicationsC            = "applications"
 	endpointBindingsC        = "endpointbindings"
 	settingsC                = "settings"
+	settingsHistoryC         = "settingshistory"
 	refcountsC               = "refcounts"
 	sshHostKeysC             = "sshhostkeys"
 	spacesC                  = "spaces"
@@ -520,6 +534,7 @@ const (
 	usersC                   = "users"
 	volumeAttachmentsC       = "volumeattachments"
 	volumesC                 = "volumes"
+	volumeSnapshotsC         = "volumesnapshots"
 	// "resources" (see resource/persistence/mongo.go)
 
 	// Cross model relations
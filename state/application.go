@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils/featureflag"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	"gopkg.in/juju/names.v2"
@@ -24,6 +26,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/feature"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 )
 
@@ -36,22 +39,28 @@ type Application struct {
 // applicationDoc represents the internal state of an application in MongoDB.
 // Note the correspondence with ApplicationInfo in apiserver.
 type applicationDoc struct {
-	DocID                string     `bson:"_id"`
-	Name                 string     `bson:"name"`
-	ModelUUID            string     `bson:"model-uuid"`
-	Series               string     `bson:"series"`
-	Subordinate          bool       `bson:"subordinate"`
-	CharmURL             *charm.URL `bson:"charmurl"`
-	Channel              string     `bson:"cs-channel"`
-	CharmModifiedVersion int        `bson:"charmmodifiedversion"`
-	ForceCharm           bool       `bson:"forcecharm"`
-	Life                 Life       `bson:"life"`
-	UnitCount            int        `bson:"unitcount"`
-	RelationCount        int        `bson:"relationcount"`
-	Exposed              bool       `bson:"exposed"`
-	MinUnits             int        `bson:"minunits"`
-	TxnRevno             int64      `bson:"txn-revno"`
-	MetricCredentials    []byte     `bson:"metric-credentials"`
+	DocID                string                     `bson:"_id"`
+	Name                 string                     `bson:"name"`
+	ModelUUID            string                     `bson:"model-uuid"`
+	Series               string                     `bson:"series"`
+	Subordinate          bool                       `bson:"subordinate"`
+	CharmURL             *charm.URL                 `bson:"charmurl"`
+	Channel              string                     `bson:"cs-channel"`
+	CharmModifiedVersion int                        `bson:"charmmodifiedversion"`
+	ForceCharm           bool                       `bson:"forcecharm"`
+	Life                 Life                       `bson:"life"`
+	UnitCount            int                        `bson:"unitcount"`
+	RelationCount        int                        `bson:"relationcount"`
+	Exposed              bool                       `bson:"exposed"`
+	ExposedEndpoints     map[string]ExposedEndpoint `bson:"exposed-endpoints,omitempty"`
+	EgressRules          []egressRuleDoc            `bson:"egress-rules,omitempty"`
+	IngressAddresses     map[string]string          `bson:"ingress-addresses,omitempty"`
+	MinUnits             int                        `bson:"minunits"`
+	ActionConcurrency    int                        `bson:"action-concurrency,omitempty"`
+	DispatchedActions    int                        `bson:"dispatched-actions,omitempty"`
+	HeldActions          int                        `bson:"held-actions,omitempty"`
+	TxnRevno             int64                      `bson:"txn-revno"`
+	MetricCredentials    []byte                     `bson:"metric-credentials"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -296,9 +305,30 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 		removeStatusOp(a.st, globalKey),
 		removeModelApplicationRefOp(a.st, name),
 	)
+	historyOps, err := removeSettingsHistoryOps(a.st, globalKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, historyOps...)
+	// Remove the application's shared storage; all of the units, and
+	// so all of the storage attachments, have been removed by now.
+	storageInstanceOps, err := removeStorageInstancesOps(a.st, a.Tag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, storageInstanceOps...)
 	return ops, nil
 }
 
+// ExposedEndpoint holds the expose settings for an application endpoint:
+// the sources that may access the ports opened by the application's units
+// when the application is exposed.
+type ExposedEndpoint struct {
+	// ExposeToCIDRs holds the CIDRs that may access the opened ports.
+	// If empty, the ports may be accessed from anywhere.
+	ExposeToCIDRs []string `bson:"to-cidrs,omitempty"`
+}
+
 // IsExposed returns whether this application is exposed. The explicitly open
 // ports (with open-port) for exposed applications may be accessed from machines
 // outside of the local deployment network. See SetExposed and ClearExposed.
@@ -306,13 +336,30 @@ func (a *Application) IsExposed() bool {
 	return a.doc.Exposed
 }
 
-// SetExposed marks the application as exposed.
+// ExposedEndpoints returns the expose settings of the application, keyed
+// by endpoint name. The settings with an empty endpoint name apply to all
+// of the application's endpoints. An exposed application with no expose
+// settings may be accessed from anywhere.
+func (a *Application) ExposedEndpoints() map[string]ExposedEndpoint {
+	if len(a.doc.ExposedEndpoints) == 0 {
+		return nil
+	}
+	result := make(map[string]ExposedEndpoint, len(a.doc.ExposedEndpoints))
+	for endpoint, exposed := range a.doc.ExposedEndpoints {
+		result[endpoint] = exposed
+	}
+	return result
+}
+
+// SetExposed marks the application as exposed to all sources,
+// replacing any existing expose settings.
 // See ClearExposed and IsExposed.
 func (a *Application) SetExposed() error {
 	return a.setExposed(true)
 }
 
-// ClearExposed removes the exposed flag from the application.
+// ClearExposed removes the exposed flag and any expose settings
+// from the application.
 // See SetExposed and IsExposed.
 func (a *Application) ClearExposed() error {
 	return a.setExposed(false)
@@ -323,12 +370,388 @@ func (a *Application) setExposed(exposed bool) (err error) {
 		C:      applicationsC,
 		Id:     a.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: bson.D{
+			{"$set", bson.D{{"exposed", exposed}}},
+			{"$unset", bson.D{{"exposed-endpoints", nil}}},
+		},
 	}}
 	if err := a.st.runTransaction(ops); err != nil {
 		return errors.Errorf("cannot set exposed flag for application %q to %v: %v", a, exposed, onAbort(err, errNotAlive))
 	}
 	a.doc.Exposed = exposed
+	a.doc.ExposedEndpoints = nil
+	return nil
+}
+
+// MergeExposeSettings marks the application as exposed, and merges the
+// specified expose settings, keyed by endpoint name, into the existing
+// settings. The settings with an empty endpoint name apply to all of the
+// application's endpoints.
+func (a *Application) MergeExposeSettings(exposed map[string]ExposedEndpoint) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot expose application %q", a)
+	if err := a.validateExposeSettings(exposed); err != nil {
+		return errors.Trace(err)
+	}
+	var merged map[string]ExposedEndpoint
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		// If the application is exposed without expose settings, as
+		// it is when exposed before expose settings were introduced,
+		// it is implicitly exposed to all sources; the specified
+		// settings replace that.
+		merged = make(map[string]ExposedEndpoint)
+		for endpoint, settings := range a.doc.ExposedEndpoints {
+			merged[endpoint] = settings
+		}
+		for endpoint, settings := range exposed {
+			merged[endpoint] = settings
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: bson.D{{"life", Alive}, {"txn-revno", a.doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{
+				{"exposed", true},
+				{"exposed-endpoints", merged},
+			}}},
+		}}, nil
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	a.doc.Exposed = true
+	a.doc.ExposedEndpoints = merged
+	return nil
+}
+
+// UnsetExposeSettings removes the expose settings for the specified
+// endpoints. If no expose settings remain, the application is no
+// longer exposed.
+func (a *Application) UnsetExposeSettings(endpoints []string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot unexpose application %q", a)
+	var remaining map[string]ExposedEndpoint
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		remaining = make(map[string]ExposedEndpoint)
+		for endpoint, settings := range a.doc.ExposedEndpoints {
+			remaining[endpoint] = settings
+		}
+		for _, endpoint := range endpoints {
+			if _, ok := remaining[endpoint]; !ok {
+				return nil, errors.NotFoundf("expose settings for endpoint %q", endpoint)
+			}
+			delete(remaining, endpoint)
+		}
+		update := bson.D{{"$set", bson.D{
+			{"exposed-endpoints", remaining},
+		}}}
+		if len(remaining) == 0 {
+			update = bson.D{
+				{"$set", bson.D{{"exposed", false}}},
+				{"$unset", bson.D{{"exposed-endpoints", nil}}},
+			}
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: bson.D{{"life", Alive}, {"txn-revno", a.doc.TxnRevno}},
+			Update: update,
+		}}, nil
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	if len(remaining) == 0 {
+		a.doc.Exposed = false
+		remaining = nil
+	}
+	a.doc.ExposedEndpoints = remaining
+	return nil
+}
+
+// validateExposeSettings checks that the specified expose settings
+// refer to endpoints of the application's charm, and hold valid CIDRs.
+func (a *Application) validateExposeSettings(exposed map[string]ExposedEndpoint) error {
+	eps, err := a.Endpoints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	known := set.NewStrings("")
+	for _, ep := range eps {
+		known.Add(ep.Name)
+	}
+	for endpoint, settings := range exposed {
+		if !known.Contains(endpoint) {
+			return errors.NotFoundf("endpoint %q", endpoint)
+		}
+		for _, cidr := range settings.ExposeToCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.NotValidf("CIDR %q for endpoint %q", cidr, endpoint)
+			}
+		}
+	}
+	return nil
+}
+
+// egressRuleDoc holds a single rule of an application's egress policy.
+type egressRuleDoc struct {
+	Protocol         string   `bson:"protocol"`
+	FromPort         int      `bson:"from-port"`
+	ToPort           int      `bson:"to-port"`
+	DestinationCIDRs []string `bson:"destination-cidrs,omitempty"`
+}
+
+func newEgressRuleDocs(rules []network.EgressRule) []egressRuleDoc {
+	if len(rules) == 0 {
+		return nil
+	}
+	docs := make([]egressRuleDoc, len(rules))
+	for i, rule := range rules {
+		docs[i] = egressRuleDoc{
+			Protocol:         strings.ToLower(rule.Protocol),
+			FromPort:         rule.FromPort,
+			ToPort:           rule.ToPort,
+			DestinationCIDRs: rule.DestinationCIDRs,
+		}
+	}
+	return docs
+}
+
+func egressRulesFromDocs(docs []egressRuleDoc) []network.EgressRule {
+	if len(docs) == 0 {
+		return nil
+	}
+	rules := make([]network.EgressRule, len(docs))
+	for i, doc := range docs {
+		rules[i] = network.EgressRule{
+			PortRange: network.PortRange{
+				Protocol: doc.Protocol,
+				FromPort: doc.FromPort,
+				ToPort:   doc.ToPort,
+			},
+			DestinationCIDRs: doc.DestinationCIDRs,
+		}
+	}
+	return rules
+}
+
+// EgressRules returns the egress policy of the application: the
+// destinations to which the application's units may send outgoing
+// traffic. If the application has no egress policy, nil is returned
+// and outgoing traffic is unrestricted.
+func (a *Application) EgressRules() []network.EgressRule {
+	return egressRulesFromDocs(a.doc.EgressRules)
+}
+
+// SetEgressRules replaces the egress policy of the application with
+// the specified rules. Once a policy is set, the application's units
+// may only send outgoing traffic to the destinations allowed by the
+// rules; setting an empty policy lifts the restriction.
+func (a *Application) SetEgressRules(rules []network.EgressRule) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set egress rules for application %q", a)
+	for _, rule := range rules {
+		if _, err := network.NewEgressRule(
+			rule.Protocol, rule.FromPort, rule.ToPort, rule.DestinationCIDRs...,
+		); err != nil {
+			return errors.NewNotValid(err, fmt.Sprintf("egress rule %q", rule))
+		}
+	}
+	sorted := make([]network.EgressRule, len(rules))
+	copy(sorted, rules)
+	network.SortEgressRules(sorted)
+	docs := newEgressRuleDocs(sorted)
+
+	update := bson.D{{"$set", bson.D{{"egress-rules", docs}}}}
+	if len(docs) == 0 {
+		update = bson.D{{"$unset", bson.D{{"egress-rules", nil}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if err := a.st.runTransaction(ops); err != nil {
+		return onAbort(err, errNotAlive)
+	}
+	a.doc.EgressRules = docs
+	return nil
+}
+
+// IngressAddresses returns the ingress address overrides of the
+// application, keyed by endpoint name. The override keyed by "", if
+// any, applies to the endpoints without an override of their own.
+func (a *Application) IngressAddresses() map[string]string {
+	if len(a.doc.IngressAddresses) == 0 {
+		return nil
+	}
+	addresses := make(map[string]string)
+	for endpoint, address := range a.doc.IngressAddresses {
+		addresses[endpoint] = address
+	}
+	return addresses
+}
+
+// IngressAddress returns the address, and the port if one was
+// specified, advertised to related units on the named endpoint in
+// place of the addresses of the application's units. If the ingress
+// address of the endpoint is not overridden, ok is false.
+func (a *Application) IngressAddress(endpoint string) (address network.Address, port int, ok bool) {
+	value, ok := a.doc.IngressAddresses[endpoint]
+	if !ok {
+		value, ok = a.doc.IngressAddresses[""]
+	}
+	if !ok {
+		return network.Address{}, 0, false
+	}
+	address, port, err := network.ParseAddressPort(value)
+	if err != nil {
+		// Should not happen, since overrides are validated when set.
+		logger.Warningf("ignoring ingress address of application %q endpoint %q: %v", a, endpoint, err)
+		return network.Address{}, 0, false
+	}
+	return address, port, true
+}
+
+// SetIngressAddresses merges the specified ingress address overrides,
+// keyed by endpoint name, into those of the application; the override
+// keyed by "" applies to all endpoints without one of their own. Each
+// override is an address, optionally followed by a port; an empty
+// override removes that of the endpoint. The address settings of the
+// application's units in the affected relations are updated, so that
+// related units observe the change.
+func (a *Application) SetIngressAddresses(addresses map[string]string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set ingress addresses for application %q", a)
+	ch, _, err := a.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := validateIngressAddresses(ch.Meta(), addresses); err != nil {
+		return errors.Trace(err)
+	}
+	var merged map[string]string
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		merged = make(map[string]string)
+		for endpoint, address := range a.doc.IngressAddresses {
+			merged[endpoint] = address
+		}
+		for endpoint, address := range addresses {
+			if address == "" {
+				delete(merged, endpoint)
+			} else {
+				merged[endpoint] = address
+			}
+		}
+		update := bson.D{{"$set", bson.D{{"ingress-addresses", merged}}}}
+		if len(merged) == 0 {
+			update = bson.D{{"$unset", bson.D{{"ingress-addresses", nil}}}}
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: bson.D{{"life", Alive}, {"txn-revno", a.doc.TxnRevno}},
+			Update: update,
+		}}, nil
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	if len(merged) == 0 {
+		merged = nil
+	}
+	a.doc.IngressAddresses = merged
+
+	endpoints := set.NewStrings()
+	for endpoint := range addresses {
+		endpoints.Add(endpoint)
+	}
+	return errors.Trace(a.updateAddressSettings(endpoints))
+}
+
+// validateIngressAddresses checks that the specified ingress address
+// overrides refer to relation endpoints of the charm, and hold valid
+// addresses.
+func validateIngressAddresses(meta *charm.Meta, addresses map[string]string) error {
+	known := set.NewStrings("", "juju-info")
+	for _, relations := range []map[string]charm.Relation{meta.Provides, meta.Requires, meta.Peers} {
+		for name := range relations {
+			known.Add(name)
+		}
+	}
+	for endpoint, address := range addresses {
+		if !known.Contains(endpoint) {
+			return errors.NotFoundf("endpoint %q", endpoint)
+		}
+		if address == "" {
+			continue
+		}
+		if _, _, err := network.ParseAddressPort(address); err != nil {
+			return errors.NewNotValid(err, fmt.Sprintf("ingress address for endpoint %q", endpoint))
+		}
+	}
+	return nil
+}
+
+// updateAddressSettings updates the address settings of the units of
+// the application in the scope of relations on the specified endpoints,
+// or on any endpoint if "" is specified. See RelationUnit.AddressSettings.
+func (a *Application) updateAddressSettings(endpoints set.Strings) error {
+	relations, err := a.Relations()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(relations) == 0 {
+		return nil
+	}
+	units, err := a.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, relation := range relations {
+		endpoint, err := relation.Endpoint(a.doc.Name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !endpoints.Contains(endpoint.Name) && !endpoints.Contains("") {
+			continue
+		}
+		for _, unit := range units {
+			ru, err := relation.Unit(unit)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if inScope, err := ru.InScope(); err != nil {
+				return errors.Trace(err)
+			} else if !inScope {
+				continue
+			}
+			if err := ru.updateAddressSettings(); err != nil {
+				return errors.Annotatef(err, "updating settings of unit %q in relation %q", unit, relation)
+			}
+		}
+	}
 	return nil
 }
 
@@ -1062,6 +1485,11 @@ type applicationAddUnitOpsArgs struct {
 	principalName string
 	cons          constraints.Value
 	storageCons   map[string]StorageConstraints
+
+	// sharedStorage, if non-nil, holds the shared storage instances
+	// being created along with the application, to which the unit
+	// will be attached.
+	sharedStorage []*storageInstance
 }
 
 // addApplicationUnitOps is just like addUnitOps but explicitly takes a
@@ -1116,6 +1544,21 @@ func (a *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 	if err != nil {
 		return "", nil, errors.Trace(err)
 	}
+	sharedStorageOps, numSharedStorageAttachments, err := sharedStorageAttachmentOps(
+		a.st,
+		a.ApplicationTag(),
+		unitTag,
+		charm.Meta(),
+		args.storageCons,
+		a.doc.Series,
+		args.sharedStorage,
+		machineAssignable,
+	)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	storageOps = append(storageOps, sharedStorageOps...)
+	numStorageAttachments += numSharedStorageAttachments
 
 	docID := a.st.docID(name)
 	globalKey := unitGlobalKey(name)
@@ -1363,6 +1806,13 @@ func (a *Application) ConfigSettings() (charm.Settings, error) {
 // UpdateConfigSettings changes a application's charm config settings. Values set
 // to nil will be deleted; unknown and invalid values will return an error.
 func (a *Application) UpdateConfigSettings(changes charm.Settings) error {
+	return a.UpdateConfigSettingsAs("", changes)
+}
+
+// UpdateConfigSettingsAs is like UpdateConfigSettings, but records the
+// tag of the entity making the change in the application's config
+// history.
+func (a *Application) UpdateConfigSettingsAs(author string, changes charm.Settings) error {
 	charm, _, err := a.Charm()
 	if err != nil {
 		return err
@@ -1386,10 +1836,36 @@ func (a *Application) UpdateConfigSettings(changes charm.Settings) error {
 			node.Set(name, value)
 		}
 	}
-	_, err = node.Write()
+	_, err = writeSettingsWithHistory(a.st, node, a.globalKey(), author)
 	return err
 }
 
+// ConfigSettingsHistory returns the recorded changes to the
+// application's charm config settings, oldest first.
+func (a *Application) ConfigSettingsHistory() ([]SettingsRevision, error) {
+	return readSettingsHistory(a.st, a.globalKey())
+}
+
+// RevertConfigSettings returns the application's charm config settings
+// to the values they had immediately after the given revision was made.
+// The revert is itself recorded as a new revision, made by the given
+// author.
+func (a *Application) RevertConfigSettings(revision int, author string) error {
+	history, err := a.ConfigSettingsHistory()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	updates, removes, err := settingsRevertChanges(history, revision)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	changes := charm.Settings(updates)
+	for _, key := range removes {
+		changes[key] = nil
+	}
+	return errors.Trace(a.UpdateConfigSettingsAs(author, changes))
+}
+
 // LeaderSettings returns a application's leader settings. If nothing has been set
 // yet, it will return an empty map; this is not an error.
 func (a *Application) LeaderSettings() (map[string]string, error) {
@@ -19,6 +19,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
@@ -921,6 +922,72 @@ func (s *ApplicationSuite) TestUpdateConfigSettings(c *gc.C) {
 	}
 }
 
+func (s *ApplicationSuite) TestConfigSettingsHistory(c *gc.C) {
+	app := s.AddTestingService(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	err := app.UpdateConfigSettingsAs("user-bob", charm.Settings{"outlook": "positive"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.UpdateConfigSettings(charm.Settings{"outlook": nil, "title": "sir"})
+	c.Assert(err, jc.ErrorIsNil)
+	// Setting an option to its current value is not recorded.
+	err = app.UpdateConfigSettings(charm.Settings{"title": "sir"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := app.ConfigSettingsHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Check(history[0].Revision, gc.Equals, 1)
+	c.Check(history[0].Author, gc.Equals, "user-bob")
+	c.Check(history[0].Changes, jc.DeepEquals, []state.ItemChange{
+		{state.ItemAdded, "outlook", nil, "positive"},
+	})
+	c.Check(history[1].Revision, gc.Equals, 2)
+	c.Check(history[1].Author, gc.Equals, "")
+	c.Check(history[1].Changes, jc.DeepEquals, []state.ItemChange{
+		{state.ItemDeleted, "outlook", "positive", nil},
+		{state.ItemAdded, "title", nil, "sir"},
+	})
+
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	history, err = app.ConfigSettingsHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestRevertConfigSettings(c *gc.C) {
+	app := s.AddTestingService(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	err := app.UpdateConfigSettings(charm.Settings{"outlook": "positive"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.UpdateConfigSettings(charm.Settings{"outlook": "negative", "title": "sir"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.RevertConfigSettings(1, "user-bob")
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := app.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"outlook": "positive"})
+
+	history, err := app.ConfigSettingsHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 3)
+	c.Check(history[2].Revision, gc.Equals, 3)
+	c.Check(history[2].Author, gc.Equals, "user-bob")
+	c.Check(history[2].Changes, jc.DeepEquals, []state.ItemChange{
+		{state.ItemModified, "outlook", "negative", "positive"},
+		{state.ItemDeleted, "title", "sir", nil},
+	})
+}
+
+func (s *ApplicationSuite) TestRevertConfigSettingsUnknownRevision(c *gc.C) {
+	app := s.AddTestingService(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	err := app.UpdateConfigSettings(charm.Settings{"outlook": "positive"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.RevertConfigSettings(42, "user-bob")
+	c.Assert(err, gc.ErrorMatches, "revision 42 not found")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func assertNoSettingsRef(c *gc.C, st *state.State, svcName string, sch *state.Charm) {
 	_, err := state.ServiceSettingsRefCount(st, svcName, sch.URL())
 	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
@@ -1393,6 +1460,253 @@ func (s *ApplicationSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestMergeExposeSettings(c *gc.C) {
+	err := s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server-admin": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server":       {},
+		"server-admin": {ExposeToCIDRs: []string{"10.0.0.0/24", "192.168.1.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"server":       {},
+		"server-admin": {ExposeToCIDRs: []string{"10.0.0.0/24", "192.168.1.0/24"}},
+	})
+
+	// Exposing the application to all sources replaces the settings.
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestMergeExposeSettingsReplacesExposedToAll(c *gc.C) {
+	// An application exposed without expose settings, as it is when
+	// exposed before expose settings were introduced, is exposed to
+	// all sources until explicit settings are merged.
+	err := s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.HasLen, 0)
+
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server-admin": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"server-admin": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+}
+
+func (s *ApplicationSuite) TestMergeExposeSettingsInvalid(c *gc.C) {
+	err := s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"foo": {},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "mysql": endpoint "foo" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"10.0.0.0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "mysql": CIDR "10.0.0.0" for endpoint "server" not valid`)
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
+func (s *ApplicationSuite) TestUnsetExposeSettings(c *gc.C) {
+	err := s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server":       {},
+		"server-admin": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.UnsetExposeSettings([]string{"juju-info"})
+	c.Assert(err, gc.ErrorMatches, `cannot unexpose application "mysql": expose settings for endpoint "juju-info" not found`)
+
+	err = s.mysql.UnsetExposeSettings([]string{"server"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"server-admin": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+
+	err = s.mysql.UnsetExposeSettings([]string{"server-admin"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestSetEgressRules(c *gc.C) {
+	c.Assert(s.mysql.EgressRules(), gc.HasLen, 0)
+
+	err := s.mysql.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8", "192.168.1.0/24"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expected := []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8", "192.168.1.0/24"),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+	}
+	c.Assert(s.mysql.EgressRules(), jc.DeepEquals, expected)
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.EgressRules(), jc.DeepEquals, expected)
+
+	// Setting an empty policy lifts the restriction.
+	err = s.mysql.SetEgressRules(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.EgressRules(), gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestSetEgressRulesInvalid(c *gc.C) {
+	err := s.mysql.SetEgressRules([]network.EgressRule{{
+		PortRange:        network.PortRange{Protocol: "tcp", FromPort: 443, ToPort: 443},
+		DestinationCIDRs: []string{"10.0.0.0"},
+	}})
+	c.Assert(err, gc.ErrorMatches, `cannot set egress rules for application "mysql": egress rule "443/tcp to 10.0.0.0": invalid CIDR address: 10.0.0.0`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ApplicationSuite) TestSetEgressRulesNotAlive(c *gc.C) {
+	_, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set egress rules for application "mysql": not found or not alive`)
+}
+
+func (s *ApplicationSuite) TestSetIngressAddresses(c *gc.C) {
+	c.Assert(s.mysql.IngressAddresses(), gc.HasLen, 0)
+	_, _, ok := s.mysql.IngressAddress("server")
+	c.Assert(ok, jc.IsFalse)
+
+	err := s.mysql.SetIngressAddresses(map[string]string{
+		"":       "lb.example.com",
+		"server": "10.0.0.100:3306",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IngressAddresses(), jc.DeepEquals, map[string]string{
+		"":       "lb.example.com",
+		"server": "10.0.0.100:3306",
+	})
+	address, port, ok := s.mysql.IngressAddress("server")
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(address, jc.DeepEquals, network.NewAddress("10.0.0.100"))
+	c.Assert(port, gc.Equals, 3306)
+	address, port, ok = s.mysql.IngressAddress("juju-info")
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(address, jc.DeepEquals, network.NewAddress("lb.example.com"))
+	c.Assert(port, gc.Equals, 0)
+
+	// An empty address removes the override; others are merged.
+	err = s.mysql.SetIngressAddresses(map[string]string{
+		"":       "",
+		"server": "[2001:db8::100]:3306",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IngressAddresses(), jc.DeepEquals, map[string]string{
+		"server": "[2001:db8::100]:3306",
+	})
+	_, _, ok = s.mysql.IngressAddress("juju-info")
+	c.Assert(ok, jc.IsFalse)
+
+	err = s.mysql.SetIngressAddresses(map[string]string{"server": ""})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IngressAddresses(), gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestSetIngressAddressesInvalid(c *gc.C) {
+	err := s.mysql.SetIngressAddresses(map[string]string{"foo": "10.0.0.100"})
+	c.Assert(err, gc.ErrorMatches, `cannot set ingress addresses for application "mysql": endpoint "foo" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.mysql.SetIngressAddresses(map[string]string{"server": "10.0.0.100:mysql"})
+	c.Assert(err, gc.ErrorMatches, `cannot set ingress addresses for application "mysql": ingress address for endpoint "server": port in "10.0.0.100:mysql" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ApplicationSuite) TestSetIngressAddressesUpdatesSettings(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	unit, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProviderAddresses(network.NewScopedAddress("10.0.0.5", network.ScopeCloudLocal))
+	c.Assert(err, jc.ErrorIsNil)
+
+	ru, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(map[string]interface{}{
+		"private-address": "10.0.0.5",
+		"user":            "root",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.SetIngressAddresses(map[string]string{"server": "10.0.0.100:3306"})
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := ru.ReadSettings(unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]interface{}{
+		"private-address": "10.0.0.100",
+		"ingress-address": "10.0.0.100",
+		"ingress-port":    "3306",
+		"user":            "root",
+	})
+
+	// Removing the override restores the unit's own address.
+	err = s.mysql.SetIngressAddresses(map[string]string{"server": ""})
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err = ru.ReadSettings(unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]interface{}{
+		"private-address": "10.0.0.5",
+		"user":            "root",
+	})
+}
+
+func (s *ApplicationSuite) TestSetIngressAddressesNotAlive(c *gc.C) {
+	_, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetIngressAddresses(map[string]string{"server": "10.0.0.100"})
+	c.Assert(err, gc.ErrorMatches, `cannot set ingress addresses for application "mysql": not found or not alive`)
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit()
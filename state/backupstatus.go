@@ -0,0 +1,116 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+const backupStatusKey = "backupStatus"
+
+// BackupStatus records the outcome of the most recent scheduled
+// controller backups.
+type BackupStatus struct {
+	// LastSuccess records when a scheduled backup last succeeded.
+	// It is the zero time if none has.
+	LastSuccess time.Time
+
+	// LastSuccessID holds the ID of the most recent successful
+	// scheduled backup.
+	LastSuccessID string
+
+	// LastFailure records when a scheduled backup last failed. It is
+	// the zero time if none has.
+	LastFailure time.Time
+
+	// LastFailureError holds the error that caused the most recent
+	// scheduled backup failure.
+	LastFailureError string
+}
+
+// backupStatusDoc is stored in the controllers collection.
+type backupStatusDoc struct {
+	DocID            string `bson:"_id"`
+	LastSuccess      int64  `bson:"last-success,omitempty"`
+	LastSuccessID    string `bson:"last-success-id,omitempty"`
+	LastFailure      int64  `bson:"last-failure,omitempty"`
+	LastFailureError string `bson:"last-failure-error,omitempty"`
+}
+
+// BackupStatus returns the outcome of the most recent scheduled
+// controller backups.
+func (st *State) BackupStatus() (BackupStatus, error) {
+	controllers, closer := st.db().GetCollection(controllersC)
+	defer closer()
+
+	var doc backupStatusDoc
+	err := controllers.FindId(backupStatusKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return BackupStatus{}, nil
+	} else if err != nil {
+		return BackupStatus{}, errors.Annotate(err, "cannot read backup status")
+	}
+	var status BackupStatus
+	if doc.LastSuccess != 0 {
+		status.LastSuccess = time.Unix(0, doc.LastSuccess).UTC()
+		status.LastSuccessID = doc.LastSuccessID
+	}
+	if doc.LastFailure != 0 {
+		status.LastFailure = time.Unix(0, doc.LastFailure).UTC()
+		status.LastFailureError = doc.LastFailureError
+	}
+	return status, nil
+}
+
+// RecordBackupSuccess records that the scheduled backup with the given
+// ID completed at the given time.
+func (st *State) RecordBackupSuccess(id string, when time.Time) error {
+	err := st.setBackupStatus(bson.D{
+		{"last-success", when.UnixNano()},
+		{"last-success-id", id},
+	})
+	return errors.Annotate(err, "cannot record backup success")
+}
+
+// RecordBackupFailure records that a scheduled backup failed at the
+// given time with the given error message.
+func (st *State) RecordBackupFailure(message string, when time.Time) error {
+	err := st.setBackupStatus(bson.D{
+		{"last-failure", when.UnixNano()},
+		{"last-failure-error", message},
+	})
+	return errors.Annotate(err, "cannot record backup failure")
+}
+
+func (st *State) setBackupStatus(fields bson.D) error {
+	controllers, closer := st.db().GetCollection(controllersC)
+	defer closer()
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		n, err := controllers.FindId(backupStatusKey).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n == 0 {
+			return []txn.Op{{
+				C:      controllersC,
+				Id:     backupStatusKey,
+				Assert: txn.DocMissing,
+				Insert: append(bson.D{{"_id", backupStatusKey}}, fields...),
+			}}, nil
+		}
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     backupStatusKey,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", fields}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type BackupStatusSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&BackupStatusSuite{})
+
+func (s *BackupStatusSuite) TestBackupStatusInitiallyEmpty(c *gc.C) {
+	status, err := s.State.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, state.BackupStatus{})
+}
+
+func (s *BackupStatusSuite) TestRecordBackupSuccess(c *gc.C) {
+	when := time.Date(2017, 3, 15, 3, 0, 0, 0, time.UTC)
+	err := s.State.RecordBackupSuccess("backup-1", when)
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := s.State.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, state.BackupStatus{
+		LastSuccess:   when,
+		LastSuccessID: "backup-1",
+	})
+}
+
+func (s *BackupStatusSuite) TestRecordBackupFailureKeepsLastSuccess(c *gc.C) {
+	success := time.Date(2017, 3, 15, 3, 0, 0, 0, time.UTC)
+	err := s.State.RecordBackupSuccess("backup-1", success)
+	c.Assert(err, jc.ErrorIsNil)
+
+	failure := success.Add(24 * time.Hour)
+	err = s.State.RecordBackupFailure("disk full", failure)
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := s.State.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, state.BackupStatus{
+		LastSuccess:      success,
+		LastSuccessID:    "backup-1",
+		LastFailure:      failure,
+		LastFailureError: "disk full",
+	})
+
+	later := failure.Add(24 * time.Hour)
+	err = s.State.RecordBackupSuccess("backup-2", later)
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err = s.State.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, state.BackupStatus{
+		LastSuccess:      later,
+		LastSuccessID:    "backup-2",
+		LastFailure:      failure,
+		LastFailureError: "disk full",
+	})
+}
@@ -426,6 +426,9 @@ func (st *State) cleanupUnitStorageAttachments(unitTag names.UnitTag, remove boo
 // cleanupRemovedUnit takes care of all the final cleanup required when
 // a unit is removed.
 func (st *State) cleanupRemovedUnit(unitId string) error {
+	// Cancelling the unit's unfinished actions also releases the
+	// dispatches of its throttled actions, so that the application's
+	// action concurrency limit is not left exhausted.
 	actions, err := st.matchingActionsByReceiverId(unitId)
 	if err != nil {
 		return errors.Trace(err)
@@ -33,3 +33,54 @@ func (st *State) ControllerConfig() (jujucontroller.Config, error) {
 	}
 	return settings.Map(), nil
 }
+
+// UpdateControllerConfig allows changing some of the configuration
+// for the controller. Changes passed in updateAttrs will be applied
+// to the current config, and keys in removeAttrs will be reset to
+// their default values. Only the attributes named in
+// controller.AllowedUpdateConfigAttributes may be changed.
+func (st *State) UpdateControllerConfig(updateAttrs map[string]interface{}, removeAttrs []string) error {
+	if len(updateAttrs)+len(removeAttrs) == 0 {
+		return nil
+	}
+	if err := jujucontroller.ValidateUpdateAttributes(updateAttrs, removeAttrs); err != nil {
+		return errors.Trace(err)
+	}
+	settings, err := readSettings(st, controllersC, controllerSettingsGlobalKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Build and validate the complete new config, so that any
+	// defaults are applied to removed attributes and updated
+	// values are coerced to their proper types.
+	current := jujucontroller.Config(settings.Map())
+	caCert, _ := current.CACert()
+	attrs := make(map[string]interface{})
+	for name, value := range current {
+		attrs[name] = value
+	}
+	for _, name := range removeAttrs {
+		delete(attrs, name)
+	}
+	for name, value := range updateAttrs {
+		attrs[name] = value
+	}
+	newConfig, err := jujucontroller.NewConfig(current.ControllerUUID(), caCert, attrs)
+	if err != nil {
+		return errors.Annotate(err, "invalid controller config")
+	}
+
+	for _, name := range removeAttrs {
+		if value, ok := newConfig[name]; ok {
+			settings.Set(name, value)
+		} else {
+			settings.Delete(name)
+		}
+	}
+	for name := range updateAttrs {
+		settings.Set(name, newConfig[name])
+	}
+	_, err = settings.Write()
+	return errors.Trace(err)
+}
@@ -4,11 +4,14 @@
 package state_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 )
 
 type ControllerConfigSuite struct {
@@ -24,12 +27,15 @@ func (s *ControllerConfigSuite) TestControllerAndModelConfigInitialisation(c *gc
 	c.Assert(err, jc.ErrorIsNil)
 
 	optional := map[string]bool{
-		controller.IdentityURL:         true,
-		controller.IdentityPublicKey:   true,
-		controller.AutocertURLKey:      true,
-		controller.AutocertDNSNameKey:  true,
-		controller.AllowModelAccessKey: true,
-		controller.MongoMemoryProfile:  true,
+		controller.IdentityURL:          true,
+		controller.IdentityPublicKey:    true,
+		controller.AutocertURLKey:       true,
+		controller.AutocertDNSNameKey:   true,
+		controller.AllowModelAccessKey:  true,
+		controller.MongoMemoryProfile:   true,
+		controller.BackupSchedule:       true,
+		controller.BackupRetentionAge:   true,
+		controller.BackupRetentionCount: true,
 	}
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
 		v, ok := controllerSettings.Get(controllerAttr)
@@ -47,3 +53,63 @@ func (s *ControllerConfigSuite) TestControllerConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg["controller-uuid"], gc.Equals, m.ControllerUUID())
 }
+
+func (s *ControllerConfigSuite) TestUpdateControllerConfig(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.AuditingEnabled:    "true",
+		controller.MaxLogsAge:         "12h",
+		controller.MongoMemoryProfile: "default",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditingEnabled(), jc.IsTrue)
+	c.Assert(cfg.MaxLogsAge(), gc.Equals, 12*time.Hour)
+	c.Assert(cfg.MongoMemoryProfile(), gc.Equals, controller.MongoProfDefault)
+}
+
+func (s *ControllerConfigSuite) TestUpdateControllerConfigRemoveResetsDefault(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.MaxLogsSize: "1G",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.UpdateControllerConfig(nil, []string{controller.MaxLogsSize})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MaxLogSizeMB(), gc.Equals, controller.DefaultMaxLogCollectionMB)
+}
+
+func (s *ControllerConfigSuite) TestUpdateControllerConfigNotAllowed(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.APIPort: 1234,
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `can't change "api-port" after bootstrap`)
+}
+
+func (s *ControllerConfigSuite) TestUpdateControllerConfigInvalid(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.MongoMemoryProfile: "huge",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid controller config: mongo-memory-profile: expected one of low or default got string\("huge"\)`)
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MongoMemoryProfile(), gc.Equals, controller.MongoProfLow)
+}
+
+func (s *ControllerConfigSuite) TestUpdateControllerConfigWatcher(c *gc.C) {
+	w := s.State.WatchControllerConfig()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.MaxLogsAge: "24h",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -321,6 +321,14 @@ func WatcherMakeIdFilter(st *State, marker string, receivers ...ActionReceiver)
 	return makeIdFilter(st, marker, receivers...)
 }
 
+// ActionIsHeld reports whether the action is held back by the action
+// concurrency limit of its receiver's application.
+func ActionIsHeld(c *gc.C, st *State, id string) bool {
+	a, err := st.Action(id)
+	c.Assert(err, jc.ErrorIsNil)
+	return a.(*action).doc.Held
+}
+
 func NewActionStatusWatcher(st *State, receivers []ActionReceiver, statuses ...ActionStatus) StringsWatcher {
 	return newActionStatusWatcher(st, receivers, statuses...)
 }
@@ -471,6 +479,7 @@ func MakeLogDoc(
 	location string,
 	level loggo.Level,
 	msg string,
+	labels ...string,
 ) *logDoc {
 	return &logDoc{
 		Id:        bson.NewObjectId(),
@@ -482,6 +491,7 @@ func MakeLogDoc(
 		Location:  location,
 		Level:     int(level),
 		Message:   msg,
+		Labels:    labels,
 	}
 }
 
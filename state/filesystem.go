@@ -113,6 +113,12 @@ type filesystemDoc struct {
 	// the volume as being non-detachable, and to determine
 	// which volumes must be removed along with said machine.
 	MachineId string `bson:"machineid,omitempty"`
+
+	// Shared records whether the filesystem was created by a shared
+	// filesystem provider. Shared filesystems are model-scoped, but
+	// are attached by the storage provisioner of each machine to
+	// which they are attached.
+	Shared bool `bson:"shared,omitempty"`
 }
 
 // filesystemAttachmentDoc records information about a filesystem attachment.
@@ -470,6 +476,12 @@ func (f *filesystem) detachable() bool {
 	return f.doc.MachineId == ""
 }
 
+// shared reports whether or not the filesystem may be attached to many
+// machines concurrently, with each attachment managed by the machine.
+func (f *filesystem) shared() bool {
+	return f.doc.Shared
+}
+
 // isDetachableFilesystemPool reports whether or not the given
 // storage pool will create a filesystem that is not inherently
 // bound to a machine, and therefore can be detached.
@@ -498,6 +510,18 @@ func isDetachableFilesystemPool(st *State, pool string) (bool, error) {
 	return true, nil
 }
 
+// isSharedFilesystemPool reports whether or not the given storage
+// pool will create filesystems that may be attached to many machines
+// concurrently.
+func isSharedFilesystemPool(st *State, pool string) (bool, error) {
+	_, provider, err := poolStorageProvider(st, pool)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	shared, ok := provider.(storage.SharedFilesystemProvider)
+	return ok && shared.SharedFilesystems(), nil
+}
+
 // DetachFilesystem marks the filesystem attachment identified by the specified machine
 // and filesystem tags as Dying, if it is Alive. DetachFilesystem will fail for
 // inherently machine-bound filesystems.
@@ -818,9 +842,9 @@ func (st *State) addFilesystemOps(params FilesystemParams, machineId string) ([]
 	if !provider.Supports(storage.StorageKindFilesystem) {
 		var volumeOps []txn.Op
 		volumeParams := VolumeParams{
-			params.storage,
-			params.Pool,
-			params.Size,
+			storage: params.storage,
+			Pool:    params.Pool,
+			Size:    params.Size,
 		}
 		volumeOps, volumeTag, err = st.addVolumeOps(volumeParams, machineId)
 		if err != nil {
@@ -830,6 +854,11 @@ func (st *State) addFilesystemOps(params FilesystemParams, machineId string) ([]
 		ops = append(ops, volumeOps...)
 	}
 
+	shared, err := isSharedFilesystemPool(st, params.Pool)
+	if err != nil {
+		return nil, names.FilesystemTag{}, names.VolumeTag{}, errors.Trace(err)
+	}
+
 	status := statusDoc{
 		Status:  status.Pending,
 		Updated: st.clock.Now().UnixNano(),
@@ -841,6 +870,7 @@ func (st *State) addFilesystemOps(params FilesystemParams, machineId string) ([]
 		Params:       &params,
 		// Every filesystem is created with one attachment.
 		AttachmentCount: 1,
+		Shared:          shared,
 	}
 	if !detachable {
 		doc.MachineId = origMachineId
@@ -849,6 +879,43 @@ func (st *State) addFilesystemOps(params FilesystemParams, machineId string) ([]
 	return ops, filesystemTag, volumeTag, nil
 }
 
+// addSharedFilesystemOps returns txn.Ops to create a new model-scoped
+// filesystem, with no attachments, for a shared storage instance. The
+// filesystem will be attached to the machines of the units that the
+// storage instance is attached to, as they are assigned.
+func (st *State) addSharedFilesystemOps(params FilesystemParams) ([]txn.Op, names.FilesystemTag, error) {
+	params, err := st.filesystemParamsWithDefaults(params, "")
+	if err != nil {
+		return nil, names.FilesystemTag{}, errors.Trace(err)
+	}
+	if _, err := st.validateFilesystemParams(params, ""); err != nil {
+		return nil, names.FilesystemTag{}, errors.Annotate(err, "validating filesystem params")
+	}
+	shared, err := isSharedFilesystemPool(st, params.Pool)
+	if err != nil {
+		return nil, names.FilesystemTag{}, errors.Trace(err)
+	} else if !shared {
+		return nil, names.FilesystemTag{}, errors.Errorf(
+			"pool %q does not support shared filesystems", params.Pool,
+		)
+	}
+	filesystemId, err := newFilesystemId(st, "")
+	if err != nil {
+		return nil, names.FilesystemTag{}, errors.Annotate(err, "cannot generate filesystem name")
+	}
+	status := statusDoc{
+		Status:  status.Pending,
+		Updated: st.clock.Now().UnixNano(),
+	}
+	doc := filesystemDoc{
+		FilesystemId: filesystemId,
+		StorageId:    params.storage.Id(),
+		Params:       &params,
+		Shared:       true,
+	}
+	return st.newFilesystemOps(doc, status), names.NewFilesystemTag(filesystemId), nil
+}
+
 func (st *State) newFilesystemOps(doc filesystemDoc, status statusDoc) []txn.Op {
 	return []txn.Op{
 		createStatusOp(st, filesystemGlobalKey(doc.FilesystemId), status),
@@ -10,8 +10,11 @@ import (
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
+	"github.com/juju/juju/storage/poolmanager"
+	"github.com/juju/juju/storage/provider"
 )
 
 type FilesystemStateSuite struct {
@@ -432,6 +435,144 @@ func (s *FilesystemStateSuite) TestWatchMachineFilesystemAttachments(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *FilesystemStateSuite) setupSharedFilesystemApplication(c *gc.C, pool string, numUnits int) *state.Application {
+	pm := poolmanager.New(state.NewStateSettings(s.State), dummy.StorageProviders())
+	_, err := pm.Create("nfs-pool", provider.NFSProviderType, map[string]interface{}{
+		"server": "10.0.0.1",
+		"path":   "/srv/data",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := s.createStorageCharm(c, "storage-filesystem-shared", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageFilesystem,
+		Shared:   true,
+		CountMin: 1,
+		CountMax: 1,
+	})
+	app, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:     "storage-filesystem-shared",
+		Charm:    ch,
+		NumUnits: numUnits,
+		Storage: map[string]state.StorageConstraints{
+			"data": makeStorageCons(pool, 1024, 1),
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return app
+}
+
+func (s *FilesystemStateSuite) TestAddApplicationSharedFilesystem(c *gc.C) {
+	app := s.setupSharedFilesystemApplication(c, "nfs-pool", 2)
+	u, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The storage instance is owned by the application,
+	// and attached to each of its units.
+	storageTag := names.NewStorageTag("data/0")
+	storageInstance, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, ok := storageInstance.Owner()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(owner, gc.Equals, app.Tag())
+	attachments, err := s.State.StorageAttachments(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	var units []string
+	for _, a := range attachments {
+		units = append(units, a.Unit().Id())
+	}
+	c.Assert(units, jc.SameContents, []string{
+		"storage-filesystem-shared/0",
+		"storage-filesystem-shared/1",
+		"storage-filesystem-shared/2",
+	})
+	unitAttachments, err := s.State.UnitStorageAttachments(u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitAttachments, gc.HasLen, 1)
+
+	// There is a single model-scoped filesystem for the storage.
+	filesystem, err := s.State.StorageInstanceFilesystem(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filesystem.FilesystemTag(), gc.Equals, names.NewFilesystemTag("0"))
+	params, ok := filesystem.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(params.Pool, gc.Equals, "nfs-pool")
+}
+
+func (s *FilesystemStateSuite) TestAddApplicationSharedFilesystemPoolNotShared(c *gc.C) {
+	ch := s.createStorageCharm(c, "storage-filesystem-shared", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageFilesystem,
+		Shared:   true,
+		CountMin: 1,
+		CountMax: 1,
+	})
+	_, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:  "storage-filesystem-shared",
+		Charm: ch,
+		Storage: map[string]state.StorageConstraints{
+			"data": makeStorageCons("modelscoped", 1024, 1),
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `.*charm "storage-filesystem-shared" store "data": pool "modelscoped" does not support shared filesystems`)
+}
+
+func (s *FilesystemStateSuite) TestSharedFilesystemAttachedOncePerMachine(c *gc.C) {
+	app := s.setupSharedFilesystemApplication(c, "nfs-pool", 0)
+	u0, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	u1, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u0.AssignToMachine(m)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u1.AssignToMachine(m)
+	c.Assert(err, jc.ErrorIsNil)
+
+	attachments, err := s.State.MachineFilesystemAttachments(m.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	c.Assert(attachments[0].Filesystem(), gc.Equals, names.NewFilesystemTag("0"))
+}
+
+func (s *FilesystemStateSuite) TestWatchSharedFilesystemAttachments(c *gc.C) {
+	app := s.setupSharedFilesystemApplication(c, "nfs-pool", 0)
+	addUnit := func() {
+		u, err := app.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+		err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	addUnit()
+
+	// Attachments of shared filesystems are handled
+	// by the machine storage provisioners.
+	w := s.State.WatchEnvironFilesystemAttachments()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange() // initial
+	wc.AssertNoChange()
+
+	mw := s.State.WatchMachineFilesystemAttachments(names.NewMachineTag("0"))
+	defer testing.AssertStop(c, mw)
+	mwc := testing.NewStringsWatcherC(c, s.State, mw)
+	mwc.AssertChangeInSingleEvent("0:0") // initial
+	mwc.AssertNoChange()
+
+	addUnit()
+	wc.AssertNoChange()
+	// no change, since we're only interested in the one machine.
+	mwc.AssertNoChange()
+
+	err := s.State.DetachFilesystem(names.NewMachineTag("0"), names.NewFilesystemTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+	mwc.AssertChangeInSingleEvent("0:0") // dying
+	mwc.AssertNoChange()
+}
+
 func (s *FilesystemStateSuite) TestParseFilesystemAttachmentId(c *gc.C) {
 	assertValid := func(id string, m names.MachineTag, v names.FilesystemTag) {
 		machineTag, filesystemTag, err := state.ParseFilesystemAttachmentId(id)
@@ -209,6 +209,17 @@ type Action interface {
 	// Results returns the structured output of the action and any error.
 	Results() (map[string]interface{}, string)
 
+	// Messages returns the progress messages logged by the action.
+	Messages() []ActionMessage
+
+	// Operation returns the id of the operation the action was
+	// enqueued as part of, if any.
+	Operation() string
+
+	// Timeout returns how long the action may run before it is
+	// stopped and failed, or zero if it may run indefinitely.
+	Timeout() time.Duration
+
 	// ActionTag returns an ActionTag constructed from this action's
 	// Prefix and Sequence.
 	ActionTag() names.ActionTag
@@ -217,6 +228,10 @@ type Action interface {
 	// It asserts that the action is currently pending.
 	Begin() (Action, error)
 
+	// Log adds a timestamped progress message to the action. It asserts
+	// that the action is currently running.
+	Log(message string) error
+
 	// Finish removes action from the pending queue and captures the output
 	// and end state of the action.
 	Finish(results ActionResults) (Action, error)
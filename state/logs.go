@@ -212,6 +212,7 @@ type logDoc struct {
 	Location  string        `bson:"l"` // "filename:lineno"
 	Level     int           `bson:"v"`
 	Message   string        `bson:"x"`
+	Labels    []string      `bson:"c,omitempty"`
 }
 
 type DbLogger struct {
@@ -227,8 +228,8 @@ func NewDbLogger(st ModelSessioner) *DbLogger {
 	}
 }
 
-// Log writes a log message to the database.
-func (logger *DbLogger) Log(t time.Time, entity string, module string, location string, level loggo.Level, msg string) error {
+// Log writes a log message, with any labels, to the database.
+func (logger *DbLogger) Log(t time.Time, entity string, module string, location string, level loggo.Level, msg string, labels ...string) error {
 	// TODO(ericsnow) Use a controller-global int sequence for Id.
 
 	unixEpochNanoUTC := t.UnixNano()
@@ -241,6 +242,7 @@ func (logger *DbLogger) Log(t time.Time, entity string, module string, location
 		Location:  location,
 		Level:     int(level),
 		Message:   msg,
+		Labels:    labels,
 	})
 }
 
@@ -269,8 +271,8 @@ func NewEntityDbLogger(st ModelSessioner, entity names.Tag, ver version.Number)
 	}
 }
 
-// Log writes a log message to the database.
-func (logger *EntityDbLogger) Log(t time.Time, module string, location string, level loggo.Level, msg string) error {
+// Log writes a log message, with any labels, to the database.
+func (logger *EntityDbLogger) Log(t time.Time, module string, location string, level loggo.Level, msg string, labels ...string) error {
 	// TODO(ericsnow) Use a controller-global int sequence for Id.
 
 	unixEpochNanoUTC := t.UnixNano()
@@ -284,6 +286,7 @@ func (logger *EntityDbLogger) Log(t time.Time, module string, location string, l
 		Location:  location,
 		Level:     int(level),
 		Message:   msg,
+		Labels:    labels,
 	})
 }
 
@@ -326,6 +329,7 @@ type LogRecord struct {
 	Module   string
 	Location string
 	Message  string
+	Labels   []string
 }
 
 // LogTailerParams specifies the filtering a LogTailer should apply to
@@ -333,6 +337,7 @@ type LogRecord struct {
 type LogTailerParams struct {
 	StartID       int64
 	StartTime     time.Time
+	EndTime       time.Time // Only records logged on or before EndTime, if set.
 	MinLevel      loggo.Level
 	InitialLines  int
 	NoTail        bool
@@ -340,6 +345,9 @@ type LogTailerParams struct {
 	ExcludeEntity []string
 	IncludeModule []string
 	ExcludeModule []string
+	IncludeLabel  []string
+	ExcludeLabel  []string
+	Message       string          // A regular expression the message must match, if set.
 	Oplog         *mgo.Collection // For testing only
 	AllModels     bool
 }
@@ -601,8 +609,15 @@ func (t *logTailer) tailOplog() error {
 
 func (t *logTailer) paramsToSelector(params *LogTailerParams, prefix string) bson.D {
 	sel := bson.D{}
+	timeRange := bson.M{}
 	if !params.StartTime.IsZero() {
-		sel = append(sel, bson.DocElem{"t", bson.M{"$gte": params.StartTime.UnixNano()}})
+		timeRange["$gte"] = params.StartTime.UnixNano()
+	}
+	if !params.EndTime.IsZero() {
+		timeRange["$lte"] = params.EndTime.UnixNano()
+	}
+	if len(timeRange) > 0 {
+		sel = append(sel, bson.DocElem{"t", timeRange})
 	}
 	if !params.AllModels {
 		sel = append(sel, bson.DocElem{"e", t.modelUUID})
@@ -626,6 +641,17 @@ func (t *logTailer) paramsToSelector(params *LogTailerParams, prefix string) bso
 		sel = append(sel,
 			bson.DocElem{"m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern(params.ExcludeModule)}}})
 	}
+	if len(params.IncludeLabel) > 0 {
+		sel = append(sel,
+			bson.DocElem{"c", bson.M{"$in": params.IncludeLabel}})
+	}
+	if len(params.ExcludeLabel) > 0 {
+		sel = append(sel,
+			bson.DocElem{"c", bson.M{"$nin": params.ExcludeLabel}})
+	}
+	if params.Message != "" {
+		sel = append(sel, bson.DocElem{"x", bson.RegEx{Pattern: params.Message}})
+	}
 	if prefix != "" {
 		for i, elem := range sel {
 			sel[i].Name = prefix + elem.Name
@@ -732,6 +758,7 @@ func logDocToRecord(doc *logDoc) (*LogRecord, error) {
 		Module:   doc.Module,
 		Location: doc.Location,
 		Message:  doc.Message,
+		Labels:   doc.Labels,
 	}
 	return rec, nil
 }
@@ -170,7 +170,7 @@ func (s *LogsSuite) TestEntityDbLogger(c *gc.C) {
 	t0 := truncateDBTime(coretesting.ZeroTime())
 	logger.Log(t0, "some.where", "foo.go:99", loggo.INFO, "all is well")
 	t1 := t0.Add(time.Second)
-	logger.Log(t1, "else.where", "bar.go:42", loggo.ERROR, "oh noes")
+	logger.Log(t1, "else.where", "bar.go:42", loggo.ERROR, "oh noes", "http", "charmstore")
 
 	var docs []bson.M
 	err := s.logsColl.Find(nil).Sort("t").All(&docs)
@@ -184,6 +184,7 @@ func (s *LogsSuite) TestEntityDbLogger(c *gc.C) {
 	c.Assert(docs[0]["l"], gc.Equals, "foo.go:99")
 	c.Assert(docs[0]["v"], gc.Equals, int(loggo.INFO))
 	c.Assert(docs[0]["x"], gc.Equals, "all is well")
+	c.Assert(docs[0]["c"], gc.IsNil)
 
 	c.Assert(docs[1]["t"], gc.Equals, t1.UnixNano())
 	c.Assert(docs[1]["e"], gc.Equals, s.State.ModelUUID())
@@ -192,6 +193,7 @@ func (s *LogsSuite) TestEntityDbLogger(c *gc.C) {
 	c.Assert(docs[1]["l"], gc.Equals, "bar.go:42")
 	c.Assert(docs[1]["v"], gc.Equals, int(loggo.ERROR))
 	c.Assert(docs[1]["x"], gc.Equals, "oh noes")
+	c.Assert(docs[1]["c"], jc.DeepEquals, []interface{}{"http", "charmstore"})
 }
 
 func (s *LogsSuite) TestDbLogger(c *gc.C) {
@@ -374,6 +376,50 @@ func (s *LogTailerSuite) TestTimeFiltering(c *gc.C) {
 
 }
 
+func (s *LogTailerSuite) TestTimeRangeFiltering(c *gc.C) {
+	threshT := coretesting.NonZeroTime()
+	s.writeLogsT(c,
+		threshT.Add(-5*time.Second), threshT.Add(-time.Millisecond), 5,
+		logTemplate{Message: "too early"},
+	)
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, threshT, threshT.Add(4*time.Second), 5, want)
+	s.writeLogsT(c,
+		threshT.Add(5*time.Second), threshT.Add(10*time.Second), 5,
+		logTemplate{Message: "too late"},
+	)
+
+	tailer, err := state.NewLogTailer(s.otherState, &state.LogTailerParams{
+		StartTime: threshT,
+		EndTime:   threshT.Add(4 * time.Second),
+		NoTail:    true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	s.assertTailer(c, tailer, 5, want)
+	s.assertTailerFinished(c, tailer)
+}
+
+func (s *LogTailerSuite) TestMessageFiltering(c *gc.C) {
+	refused := logTemplate{Message: "dial tcp 10.0.0.1:17070: connection refused"}
+	reset := logTemplate{Message: "read tcp: connection reset by peer"}
+	other := logTemplate{Message: "connection established"}
+	writeLogs := func() {
+		s.writeLogs(c, 1, refused)
+		s.writeLogs(c, 1, other)
+		s.writeLogs(c, 1, reset)
+		s.writeLogs(c, 1, other)
+	}
+	params := &state.LogTailerParams{
+		Message: "connection (refused|reset)",
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, refused)
+		s.assertTailer(c, tailer, 1, reset)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
 func (s *LogTailerSuite) TestOplogTransition(c *gc.C) {
 	// Ensure that logs aren't repeated as the log tailer moves from
 	// reading from the logs collection to tailing the oplog.
@@ -780,6 +826,49 @@ func (s *LogTailerSuite) TestIncludeExcludeModule(c *gc.C) {
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
 
+func (s *LogTailerSuite) TestIncludeLabel(c *gc.C) {
+	none := logTemplate{}
+	http := logTemplate{Labels: []string{"http"}}
+	httpCharm := logTemplate{Labels: []string{"http", "charmstore"}}
+	other := logTemplate{Labels: []string{"other"}}
+	writeLogs := func() {
+		s.writeLogs(c, 1, none)
+		s.writeLogs(c, 1, http)
+		s.writeLogs(c, 1, other)
+		s.writeLogs(c, 1, httpCharm)
+	}
+	params := &state.LogTailerParams{
+		IncludeLabel: []string{"http", "charmstore"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, http)
+		s.assertTailer(c, tailer, 1, httpCharm)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestExcludeLabel(c *gc.C) {
+	none := logTemplate{}
+	http := logTemplate{Labels: []string{"http"}}
+	httpCharm := logTemplate{Labels: []string{"http", "charmstore"}}
+	other := logTemplate{Labels: []string{"other"}}
+	writeLogs := func() {
+		s.writeLogs(c, 1, none)
+		s.writeLogs(c, 1, http)
+		s.writeLogs(c, 1, other)
+		s.writeLogs(c, 1, httpCharm)
+	}
+	params := &state.LogTailerParams{
+		ExcludeLabel: []string{"charmstore", "other"},
+	}
+	assert := func(tailer state.LogTailer) {
+		// Records without labels are never excluded.
+		s.assertTailer(c, tailer, 1, none)
+		s.assertTailer(c, tailer, 1, http)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
 func (s *LogTailerSuite) checkLogTailerFiltering(
 	c *gc.C,
 	st *state.State,
@@ -810,6 +899,7 @@ type logTemplate struct {
 	Location  string
 	Level     loggo.Level
 	Message   string
+	Labels    []string
 }
 
 // writeLogs creates count log messages at the current time using
@@ -882,9 +972,23 @@ func (s *LogTailerSuite) logTemplateToDoc(lt logTemplate, t time.Time) interface
 		lt.Location,
 		lt.Level,
 		lt.Message,
+		lt.Labels...,
 	)
 }
 
+// assertTailerFinished checks that the tailer reports no more logs
+// and stops itself.
+func (s *LogTailerSuite) assertTailerFinished(c *gc.C, tailer state.LogTailer) {
+	select {
+	case log, ok := <-tailer.Logs():
+		if ok {
+			c.Fatalf("unexpected log: %#v", log)
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+}
+
 func (s *LogTailerSuite) assertTailer(c *gc.C, tailer state.LogTailer, expectedCount int, lt logTemplate) {
 	s.normaliseLogTemplate(&lt)
 
@@ -902,6 +1006,7 @@ func (s *LogTailerSuite) assertTailer(c *gc.C, tailer state.LogTailer, expectedC
 			c.Assert(log.Location, gc.Equals, lt.Location)
 			c.Assert(log.Level, gc.Equals, lt.Level)
 			c.Assert(log.Message, gc.Equals, lt.Message)
+			c.Assert(log.Labels, jc.DeepEquals, lt.Labels)
 			c.Assert(log.ModelUUID, gc.Equals, lt.ModelUUID)
 			count++
 			if count == expectedCount {
@@ -1064,6 +1064,47 @@ func (m *Machine) Units() (units []*Unit, err error) {
 	return units, nil
 }
 
+// EgressRules returns the egress rules to be enforced on the machine:
+// the union of the egress policies of the applications with units
+// assigned to the machine. If any of those applications has no egress
+// policy, outgoing traffic from the machine must not be restricted,
+// and nil is returned.
+func (m *Machine) EgressRules() (_ []network.EgressRule, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot get egress rules for machine %v", m)
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	seenApps := set.NewStrings()
+	seenRules := set.NewStrings()
+	var rules []network.EgressRule
+	for _, u := range units {
+		appName := u.ApplicationName()
+		if seenApps.Contains(appName) {
+			continue
+		}
+		seenApps.Add(appName)
+		app, err := u.Application()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		appRules := app.EgressRules()
+		if len(appRules) == 0 {
+			return nil, nil
+		}
+		for _, rule := range appRules {
+			if key := rule.String(); !seenRules.Contains(key) {
+				seenRules.Add(key)
+				rules = append(rules, rule)
+			}
+		}
+	}
+	network.SortEgressRules(rules)
+	return rules, nil
+}
+
 // XXX(jam): 2016-12-09 These are just copied from
 // provider/maas/constraints.go, but they should be tied to machine
 // constraints, *not* tied to provider/maas constraints.
@@ -1361,7 +1402,7 @@ func (m *Machine) setPreferredAddressOps(addr address, isPublic bool) []txn.Op {
 	return ops
 }
 
-func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, address, bool) {
+func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddresses []address, preferred network.AddressType) ([]txn.Op, address, bool) {
 	publicAddress := m.doc.PreferredPublicAddress
 	logger.Tracef("machine %v: current public address: %#v \nprovider addresses: %#v \nmachine addresses: %#v", m.Id(), publicAddress, providerAddresses, machineAddresses)
 	// Always prefer an exact match if available.
@@ -1370,7 +1411,7 @@ func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddres
 	}
 	// Without an exact match, prefer a fallback match.
 	getAddr := func(addresses []address) network.Address {
-		addr, _ := network.SelectPublicAddress(networkAddresses(addresses))
+		addr, _ := network.SelectPublicAddressPreferring(networkAddresses(addresses), preferred)
 		return addr
 	}
 
@@ -1384,7 +1425,7 @@ func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddres
 	return ops, newAddr, true
 }
 
-func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, address, bool) {
+func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddresses []address, preferred network.AddressType) ([]txn.Op, address, bool) {
 	privateAddress := m.doc.PreferredPrivateAddress
 	// Always prefer an exact match if available.
 	checkScope := func(addr address) bool {
@@ -1392,7 +1433,7 @@ func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddre
 	}
 	// Without an exact match, prefer a fallback match.
 	getAddr := func(addresses []address) network.Address {
-		addr, _ := network.SelectInternalAddress(networkAddresses(addresses), false)
+		addr, _ := network.SelectInternalAddressPreferring(networkAddresses(addresses), false, preferred)
 		return addr
 	}
 
@@ -1456,11 +1497,15 @@ func (m *Machine) SetMachineAddresses(addresses ...network.Address) (err error)
 // only predicated on the machine not being Dead; concurrent address
 // changes are ignored.
 func (m *Machine) setAddresses(addresses []network.Address, field *[]address, fieldName string) error {
+	preferred, err := m.st.preferredAddressType()
+	if err != nil {
+		return errors.Trace(err)
+	}
 	addressesToSet := make([]network.Address, len(addresses))
 	copy(addressesToSet, addresses)
 
 	// Update addresses now.
-	network.SortAddresses(addressesToSet)
+	network.SortAddressesPreferring(addressesToSet, preferred)
 	origin := OriginProvider
 	if fieldName == "machineaddresses" {
 		origin = OriginMachine
@@ -1470,7 +1515,6 @@ func (m *Machine) setAddresses(addresses []network.Address, field *[]address, fi
 	var (
 		newPrivate, newPublic         address
 		changedPrivate, changedPublic bool
-		err                           error
 	)
 	machine := m
 	buildTxn := func(attempt int) ([]txn.Op, error) {
@@ -1500,8 +1544,8 @@ func (m *Machine) setAddresses(addresses []network.Address, field *[]address, fi
 		}
 
 		var setPrivateAddressOps, setPublicAddressOps []txn.Op
-		setPrivateAddressOps, newPrivate, changedPrivate = machine.setPrivateAddressOps(providerAddresses, machineAddresses)
-		setPublicAddressOps, newPublic, changedPublic = machine.setPublicAddressOps(providerAddresses, machineAddresses)
+		setPrivateAddressOps, newPrivate, changedPrivate = machine.setPrivateAddressOps(providerAddresses, machineAddresses, preferred)
+		setPublicAddressOps, newPublic, changedPublic = machine.setPublicAddressOps(providerAddresses, machineAddresses, preferred)
 		ops = append(ops, setPrivateAddressOps...)
 		ops = append(ops, setPublicAddressOps...)
 		return ops, nil
@@ -1156,21 +1156,51 @@ func addAddressToResult(networkInfos []network.NetworkInfo, address *Address) ([
 	return append(networkInfos, networkInfo), nil
 }
 
+// privateAddressesOfAllFamilies returns the machine's preferred private
+// address, followed by its best private address of the other IP address
+// family if it has one, so that both are reported on dual-stack machines.
+// The preferred address type is that of the model's config.
+func (m *Machine) privateAddressesOfAllFamilies(privateAddress network.Address, preferred network.AddressType) []network.Address {
+	var otherFamily []network.Address
+	for _, addr := range m.Addresses() {
+		switch addr.Type {
+		case network.IPv4Address, network.IPv6Address:
+			if addr.Type != privateAddress.Type {
+				otherFamily = append(otherFamily, addr)
+			}
+		}
+	}
+	result := []network.Address{privateAddress}
+	if addr, ok := network.SelectInternalAddressPreferring(otherFamily, false, preferred); ok {
+		result = append(result, addr)
+	}
+	return result
+}
+
 // GetNetworkInfoForSpaces returns MachineNetworkInfoResult with a list of devices for each space in spaces
 // TODO(wpk): 2017-05-04 This does not work for L2-only devices as it iterates over addresses, needs to be fixed.
 // When changing the method we have to keep the ordering.
 func (m *Machine) GetNetworkInfoForSpaces(spaces set.Strings) map[string](MachineNetworkInfoResult) {
 	results := make(map[string](MachineNetworkInfoResult))
 
-	var privateAddress network.Address
+	var privateAddresses []network.Address
+	privateValues := set.NewStrings()
 
 	if spaces.Contains("") {
-		var err error
-		privateAddress, err = m.PrivateAddress()
+		privateAddress, err := m.PrivateAddress()
+		var preferred network.AddressType
+		if err == nil {
+			preferred, err = m.st.preferredAddressType()
+		}
 		if err != nil {
 			error := errors.Annotatef(err, "getting machine %q preferred private address", m.MachineTag())
 			results[""] = MachineNetworkInfoResult{Error: &error}
 			spaces.Remove("")
+		} else {
+			privateAddresses = m.privateAddressesOfAllFamilies(privateAddress, preferred)
+			for _, addr := range privateAddresses {
+				privateValues.Add(addr.Value)
+			}
 		}
 	}
 
@@ -1206,7 +1236,7 @@ func (m *Machine) GetNetworkInfoForSpaces(spaces set.Strings) map[string](Machin
 					results[space] = r
 				}
 			}
-			if spaces.Contains("") && privateAddress.Value == addr.Value() {
+			if spaces.Contains("") && privateValues.Contains(addr.Value()) {
 				r := results[""]
 				r.NetworkInfos, err = addAddressToResult(r.NetworkInfos, addr)
 				if err != nil {
@@ -1221,10 +1251,14 @@ func (m *Machine) GetNetworkInfoForSpaces(spaces set.Strings) map[string](Machin
 	// For a spaceless environment we won't find a subnet that's linked to privateAddress,
 	// we have to work around that and at least return minimal information for --primary-address.
 	if r, filledPrivateAddress := results[""]; !filledPrivateAddress && spaces.Contains("") {
+		var ifaceAddresses []network.InterfaceAddress
+		for _, addr := range privateAddresses {
+			ifaceAddresses = append(ifaceAddresses, network.InterfaceAddress{
+				Address: addr.Value,
+			})
+		}
 		r.NetworkInfos = []network.NetworkInfo{{
-			Addresses: []network.InterfaceAddress{{
-				Address: privateAddress.Value,
-			}},
+			Addresses: ifaceAddresses,
 		}}
 		results[""] = r
 	}
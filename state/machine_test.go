@@ -29,6 +29,7 @@ import (
 	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/storage/provider"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type MachineSuite struct {
@@ -1457,6 +1458,87 @@ func (s *MachineSuite) TestWatchUnits(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *MachineSuite) TestEgressRules(c *gc.C) {
+	rules, err := s.machine.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.IsNil)
+
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	for _, app := range []*state.Application{mysql, wordpress} {
+		u, err := app.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+		err = u.AssignToMachine(s.machine)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// Only one of the applications has an egress policy,
+	// so the machine must not be restricted.
+	err = mysql.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = s.machine.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.IsNil)
+
+	err = wordpress.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("tcp", 3306, 3306, "192.168.1.0/24"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = s.machine.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("tcp", 3306, 3306, "192.168.1.0/24"),
+	})
+}
+
+func (s *MachineSuite) TestWatchEgressRules(c *gc.C) {
+	w := s.machine.WatchEgressRules()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Setting a policy for an application with no units
+	// on the machine does not change the machine's rules.
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	err := mysql.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Assigning a unit of the application does.
+	u, err := mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Changing the policy does.
+	err = mysql.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Exposing the application does not.
+	err = mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Lifting the policy does.
+	err = mysql.SetEgressRules(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	testing.AssertStop(c, w)
+	wc.AssertClosed()
+}
+
 func (s *MachineSuite) TestWatchUnitsDiesOnStateClose(c *gc.C) {
 	testWatcherDiesWhenStateCloses(c, s.modelTag, s.State.ControllerTag(), func(c *gc.C, st *state.State) waiter {
 		m, err := st.Machine(s.machine.Id())
@@ -1583,6 +1665,33 @@ func (s *MachineSuite) TestSetProviderAddresses(c *gc.C) {
 	c.Assert(machine.Addresses(), jc.DeepEquals, expectedAddresses)
 }
 
+func (s *MachineSuite) TestSetProviderAddressesModelPrefersIPv6(c *gc.C) {
+	st := s.Factory.MakeModel(c, &factory.ModelParams{
+		ConfigAttrs: coretesting.Attrs{"preferred-address-family": "ipv6"},
+	})
+	defer st.Close()
+	machine, err := st.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The model's preference is used, not the process-wide one.
+	c.Assert(network.PreferIPv6(), jc.IsFalse)
+	addresses := network.NewAddresses("10.0.0.1", "fc00::1", "8.8.8.8", "2001:db8::1")
+	err = machine.SetProviderAddresses(addresses...)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(machine.Addresses(), jc.DeepEquals, network.NewAddresses(
+		"2001:db8::1", "8.8.8.8", "fc00::1", "10.0.0.1",
+	))
+	privateAddress, err := machine.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(privateAddress.Value, gc.Equals, "fc00::1")
+	publicAddress, err := machine.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(publicAddress.Value, gc.Equals, "2001:db8::1")
+}
+
 func (s *MachineSuite) TestSetProviderAddressesWithContainers(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
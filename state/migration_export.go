@@ -425,7 +425,11 @@ func (e *exporter) newMachine(exParent description.Machine, machine *Machine, in
 		Size:    tools.Size,
 	})
 
-	for _, args := range e.openedPortsArgsForMachine(machine.Id(), portsData) {
+	openedPortsArgs, err := e.openedPortsArgsForMachine(machine.Id(), portsData)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, args := range openedPortsArgs {
 		exMachine.AddOpenedPorts(args)
 	}
 
@@ -440,13 +444,19 @@ func (e *exporter) newMachine(exParent description.Machine, machine *Machine, in
 	return exMachine, nil
 }
 
-func (e *exporter) openedPortsArgsForMachine(machineId string, portsData []portsDoc) []description.OpenedPortsArgs {
+func (e *exporter) openedPortsArgsForMachine(machineId string, portsData []portsDoc) ([]description.OpenedPortsArgs, error) {
 	var result []description.OpenedPortsArgs
 	for _, doc := range portsData {
 		// Don't bother including a subnet if there are no ports open on it.
 		if doc.MachineID == machineId && len(doc.Ports) > 0 {
 			args := description.OpenedPortsArgs{SubnetID: doc.SubnetID}
 			for _, p := range doc.Ports {
+				if p.Endpoint != "" {
+					// The model description cannot yet hold the endpoint
+					// a port range is opened for, and dropping it would
+					// change the sources the range may be accessed from.
+					return nil, errors.NotSupportedf("migrating port range %v opened for an endpoint", p)
+				}
 				args.OpenedPorts = append(args.OpenedPorts, description.PortRangeArgs{
 					UnitName: p.UnitName,
 					FromPort: p.FromPort,
@@ -457,7 +467,7 @@ func (e *exporter) openedPortsArgsForMachine(machineId string, portsData []ports
 			result = append(result, args)
 		}
 	}
-	return result
+	return result, nil
 }
 
 func (e *exporter) newAddressArgsSlice(a []address) []description.AddressArgs {
@@ -617,6 +627,25 @@ type addApplicationContext struct {
 	endpoingBindings map[string]bindingsMap
 }
 
+// exposedToAllSources reports whether the specified expose settings
+// allow access to all of an application's endpoints from all sources,
+// as the exposed flag alone does.
+func exposedToAllSources(exposedEndpoints map[string]ExposedEndpoint) bool {
+	if len(exposedEndpoints) == 0 {
+		return true
+	}
+	settings, ok := exposedEndpoints[""]
+	if !ok || len(exposedEndpoints) > 1 {
+		return false
+	}
+	for _, cidr := range settings.ExposeToCIDRs {
+		if cidr != "0.0.0.0/0" && cidr != "::/0" {
+			return false
+		}
+	}
+	return true
+}
+
 func (e *exporter) addApplication(ctx addApplicationContext) error {
 	application := ctx.application
 	appName := application.Name()
@@ -625,6 +654,22 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 	leadershipKey := leadershipSettingsKey(appName)
 	storageConstraintsKey := application.storageConstraintsKey()
 
+	if !exposedToAllSources(application.doc.ExposedEndpoints) {
+		// The model description cannot yet hold expose settings, and
+		// dropping them would expose the application to all sources.
+		return errors.NotSupportedf("migrating application %q with expose settings", appName)
+	}
+	if len(application.doc.EgressRules) > 0 {
+		// The model description cannot yet hold egress rules, and
+		// dropping them would lift the application's egress policy.
+		return errors.NotSupportedf("migrating application %q with egress rules", appName)
+	}
+	if len(application.doc.IngressAddresses) > 0 {
+		// The model description cannot yet hold ingress addresses, and
+		// dropping them would change the addresses related units use.
+		return errors.NotSupportedf("migrating application %q with ingress addresses", appName)
+	}
+
 	applicationSettingsDoc, found := e.modelSettings[settingsKey]
 	if !found {
 		return errors.Errorf("missing settings for application %q", appName)
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/juju/description"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
@@ -418,6 +419,32 @@ func (s *MigrationExportSuite) assertMigrateApplications(c *gc.C, cons constrain
 	s.checkStatusHistory(c, history[:addedHistoryCount], status.Active)
 }
 
+func (s *MigrationExportSuite) TestApplicationExposedToAllSources(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	applications := model.Applications()
+	c.Assert(applications, gc.HasLen, 1)
+	c.Assert(applications[0].Exposed(), jc.IsTrue)
+}
+
+func (s *MigrationExportSuite) TestApplicationWithExposeSettingsNotSupported(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *MigrationExportSuite) TestMultipleApplications(c *gc.C) {
 	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "first"})
 	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "second"})
@@ -1653,6 +1653,11 @@ func (i *importer) addFilesystem(filesystem description.Filesystem) error {
 	} else if !detachable && len(attachments) == 1 {
 		doc.MachineId = attachments[0].Machine().Id()
 	}
+	shared, err := isSharedFilesystemPool(i.st, filesystem.Pool())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	doc.Shared = shared
 	status := i.makeStatusDoc(filesystem.Status())
 	ops := i.st.newFilesystemOps(doc, status)
 
@@ -179,6 +179,14 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Metrics manager maintains controller specific state relating to
 		// the store and forward of charm metrics. Nothing to migrate here.
 		metricsManagerC,
+
+		// The settings history is not migrated; the history of config
+		// changes starts afresh in the target model.
+		settingsHistoryC,
+
+		// Volume snapshots are held by the source model's storage
+		// providers, and are not migrated.
+		volumeSnapshotsC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -350,6 +358,21 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// ExposedEndpoints are not supported by the model description,
+		// so only applications exposed to all sources can be migrated.
+		"ExposedEndpoints",
+		// EgressRules are not supported by the model description,
+		// so applications with egress rules cannot be migrated.
+		"EgressRules",
+		// IngressAddresses are not supported by the model description,
+		// so applications with ingress addresses cannot be migrated.
+		"IngressAddresses",
+		// The action concurrency limit is not supported by the model
+		// description, and the actions it counts are migrated without
+		// their dispatch state.
+		"ActionConcurrency",
+		"DispatchedActions",
+		"HeldActions",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -658,6 +681,14 @@ func (s *MigrationSuite) TestSSHHostKeyDocFields(c *gc.C) {
 func (s *MigrationSuite) TestActionDocFields(c *gc.C) {
 	ignored := set.NewStrings(
 		"ModelUUID",
+		// Progress messages are not migrated.
+		"Logs",
+		// Operation ids are not migrated.
+		"Operation",
+		// Nor are timeouts, or the dispatch state of throttled actions.
+		"Timeout",
+		"Throttled",
+		"Held",
 	)
 	migrated := set.NewStrings(
 		"DocId",
@@ -722,6 +753,7 @@ func (s *MigrationSuite) TestFilesystemDocFields(c *gc.C) {
 		"DocID",
 		"Life",
 		"MachineId", // recreated from pool properties
+		"Shared",    // recreated from pool properties
 	)
 	migrated := set.NewStrings(
 		"FilesystemId",
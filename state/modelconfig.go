@@ -277,6 +277,12 @@ type ValidateConfigFunc func(updateAttrs map[string]interface{}, removeAttrs []s
 // configuration of the model with the provided updateAttrs and
 // removeAttrs.
 func (st *State) UpdateModelConfig(updateAttrs map[string]interface{}, removeAttrs []string, additionalValidation ...ValidateConfigFunc) error {
+	return st.UpdateModelConfigAs("", updateAttrs, removeAttrs, additionalValidation...)
+}
+
+// UpdateModelConfigAs is like UpdateModelConfig, but records the tag of
+// the entity making the change in the model config history.
+func (st *State) UpdateModelConfigAs(author string, updateAttrs map[string]interface{}, removeAttrs []string, additionalValidation ...ValidateConfigFunc) error {
 	if len(updateAttrs)+len(removeAttrs) == 0 {
 		return nil
 	}
@@ -344,8 +350,29 @@ func (st *State) UpdateModelConfig(updateAttrs map[string]interface{}, removeAtt
 	validAttrs = config.CoerceForStorage(validAttrs)
 
 	modelSettings.Update(validAttrs)
-	_, ops := modelSettings.settingsUpdateOps()
-	return modelSettings.write(ops)
+	_, err = writeSettingsWithHistory(st, modelSettings, modelGlobalKey, author)
+	return errors.Trace(err)
+}
+
+// ModelConfigHistory returns the recorded changes to the model config,
+// oldest first.
+func (st *State) ModelConfigHistory() ([]SettingsRevision, error) {
+	return readSettingsHistory(st, modelGlobalKey)
+}
+
+// RevertModelConfig returns the model config to the values it had
+// immediately after the given revision was made. The revert is itself
+// recorded as a new revision, made by the given author.
+func (st *State) RevertModelConfig(revision int, author string) error {
+	history, err := st.ModelConfigHistory()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	updateAttrs, removeAttrs, err := settingsRevertChanges(history, revision)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(st.UpdateModelConfigAs(author, updateAttrs, removeAttrs))
 }
 
 type modelConfigSourceFunc func() (attrValues, error)
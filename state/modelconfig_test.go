@@ -260,6 +260,88 @@ func (s *ModelConfigSuite) TestUpdateModelConfigPreferredOverRemove(c *gc.C) {
 	c.Assert(ok, jc.IsFalse)
 }
 
+func findChange(changes []state.ItemChange, key string) (state.ItemChange, bool) {
+	for _, change := range changes {
+		if change.Key == key {
+			return change, true
+		}
+	}
+	return state.ItemChange{}, false
+}
+
+func (s *ModelConfigSuite) TestModelConfigHistory(c *gc.C) {
+	err := s.State.UpdateModelConfigAs("user-bob", map[string]interface{}{"arbitrary-key": "a"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfig(map[string]interface{}{"arbitrary-key": "b"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfigAs("user-mary", nil, []string{"arbitrary-key"})
+	c.Assert(err, jc.ErrorIsNil)
+	// A change which leaves the config as it was is not recorded.
+	err = s.State.UpdateModelConfig(map[string]interface{}{"apt-mirror": "http://cloud-mirror"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.State.ModelConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 3)
+	for i, expect := range []struct {
+		author string
+		change state.ItemChange
+	}{
+		{"user-bob", state.ItemChange{state.ItemAdded, "arbitrary-key", nil, "a"}},
+		{"", state.ItemChange{state.ItemModified, "arbitrary-key", "a", "b"}},
+		{"user-mary", state.ItemChange{state.ItemDeleted, "arbitrary-key", "b", nil}},
+	} {
+		rev := history[i]
+		c.Check(rev.Revision, gc.Equals, i+1)
+		c.Check(rev.Author, gc.Equals, expect.author)
+		c.Check(rev.Timestamp.IsZero(), jc.IsFalse)
+		change, ok := findChange(rev.Changes, "arbitrary-key")
+		c.Check(ok, jc.IsTrue)
+		c.Check(change, jc.DeepEquals, expect.change)
+	}
+}
+
+func (s *ModelConfigSuite) TestRevertModelConfig(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{"arbitrary-key": "a"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfig(map[string]interface{}{
+		"arbitrary-key": "b",
+		"other-key":     "c",
+		"apt-mirror":    "http://different-mirror",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfig(map[string]interface{}{"arbitrary-key": "d"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RevertModelConfig(1, "user-bob")
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	allAttrs := cfg.AllAttrs()
+	c.Assert(allAttrs["arbitrary-key"], gc.Equals, "a")
+	c.Assert(allAttrs["apt-mirror"], gc.Equals, "http://cloud-mirror")
+	_, ok := allAttrs["other-key"]
+	c.Assert(ok, jc.IsFalse)
+
+	history, err := s.State.ModelConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 4)
+	c.Assert(history[3].Revision, gc.Equals, 4)
+	c.Assert(history[3].Author, gc.Equals, "user-bob")
+	change, ok := findChange(history[3].Changes, "arbitrary-key")
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(change, jc.DeepEquals, state.ItemChange{state.ItemModified, "arbitrary-key", "d", "a"})
+}
+
+func (s *ModelConfigSuite) TestRevertModelConfigUnknownRevision(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{"arbitrary-key": "a"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RevertModelConfig(42, "user-bob")
+	c.Assert(err, gc.ErrorMatches, "revision 42 not found")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 type ModelConfigSourceSuite struct {
 	ConnSuite
 }
@@ -31,12 +31,15 @@ const (
 )
 
 // PortRange represents a single range of ports opened
-// by one unit.
+// by one unit, optionally for one of its endpoints.
 type PortRange struct {
 	UnitName string
 	FromPort int
 	ToPort   int
 	Protocol string
+	// Endpoint holds the name of the endpoint the range is opened
+	// for. If empty, the range is not associated with an endpoint.
+	Endpoint string `bson:"endpoint,omitempty"`
 }
 
 // NewPortRange create a new port range and validate it.
@@ -133,6 +136,9 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 
 // Strings returns the port range as a string.
 func (p PortRange) String() string {
+	if p.Endpoint != "" {
+		return fmt.Sprintf("%d-%d/%s (%q, endpoint %q)", p.FromPort, p.ToPort, strings.ToLower(p.Protocol), p.UnitName, p.Endpoint)
+	}
 	return fmt.Sprintf("%d-%d/%s (%q)", p.FromPort, p.ToPort, strings.ToLower(p.Protocol), p.UnitName)
 }
 
@@ -293,7 +299,11 @@ func (p *Ports) ClosePorts(portRange PortRange) (err error) {
 
 		found := false
 		for _, existingPortsDef := range ports.doc.Ports {
-			if existingPortsDef == portRange {
+			// A range is closed regardless of the endpoint
+			// it was opened for.
+			closing := portRange
+			closing.Endpoint = existingPortsDef.Endpoint
+			if existingPortsDef == closing {
 				found = true
 				continue
 			}
@@ -362,6 +372,25 @@ func (p *Ports) AllPortRanges() map[network.PortRange]string {
 	return result
 }
 
+// AllPortRangeEndpoints returns a map with network.PortRange as keys and
+// the names of the endpoints the ranges are opened for as values. Port
+// ranges not opened for an endpoint are omitted.
+func (p *Ports) AllPortRangeEndpoints() map[network.PortRange]string {
+	result := make(map[network.PortRange]string)
+	for _, portRange := range p.doc.Ports {
+		if portRange.Endpoint == "" {
+			continue
+		}
+		rawRange := network.PortRange{
+			FromPort: portRange.FromPort,
+			ToPort:   portRange.ToPort,
+			Protocol: portRange.Protocol,
+		}
+		result[rawRange] = portRange.Endpoint
+	}
+	return result
+}
+
 // Remove removes the ports document from state.
 func (p *Ports) Remove() error {
 	ports := &Ports{st: p.st, doc: p.doc}
@@ -505,17 +534,10 @@ func removePortsForUnitOps(st *State, unit *Unit) ([]txn.Op, error) {
 	}
 	var ops []txn.Op
 	for _, ports := range allPorts {
-		allRanges := ports.AllPortRanges()
 		var keepPorts []PortRange
-		for portRange, unitName := range allRanges {
-			if unitName != unit.Name() {
-				unitRange := PortRange{
-					UnitName: unitName,
-					FromPort: portRange.FromPort,
-					ToPort:   portRange.ToPort,
-					Protocol: portRange.Protocol,
-				}
-				keepPorts = append(keepPorts, unitRange)
+		for _, portRange := range ports.doc.Ports {
+			if portRange.UnitName != unit.Name() {
+				keepPorts = append(keepPorts, portRange)
 			}
 		}
 		if len(keepPorts) > 0 {
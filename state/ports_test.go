@@ -524,7 +524,7 @@ func (p *PortRangeSuite) TestPortRangeConflicts(c *gc.C) {
 		"port ranges .* conflict",
 	}, {
 		"invalid port range",
-		state.PortRange{"wordpress/0", 100, 80, "TCP"},
+		state.PortRange{"wordpress/0", 100, 80, "TCP", ""},
 		MustPortRange("wordpress/0", 80, 80, "TCP"),
 		"invalid port range 100-80",
 	}, {
@@ -580,11 +580,11 @@ func swapProtocol(protocol string) string {
 }
 
 func (p *PortRangeSuite) TestPortRangeString(c *gc.C) {
-	c.Assert(state.PortRange{"wordpress/42", 80, 80, "TCP"}.String(),
+	c.Assert(state.PortRange{"wordpress/42", 80, 80, "TCP", ""}.String(),
 		gc.Equals,
 		`80-80/tcp ("wordpress/42")`,
 	)
-	c.Assert(state.PortRange{"wordpress/0", 80, 100, "TCP"}.String(),
+	c.Assert(state.PortRange{"wordpress/0", 80, 100, "TCP", ""}.String(),
 		gc.Equals,
 		`80-100/tcp ("wordpress/0")`,
 	)
@@ -598,67 +598,67 @@ func (p *PortRangeSuite) TestPortRangeValidityAndLength(c *gc.C) {
 		expectedErr  string
 	}{{
 		"single valid port",
-		state.PortRange{"wordpress/0", 80, 80, "tcp"},
+		state.PortRange{"wordpress/0", 80, 80, "tcp", ""},
 		1,
 		"",
 	}, {
 		"valid tcp port range",
-		state.PortRange{"wordpress/0", 80, 90, "tcp"},
+		state.PortRange{"wordpress/0", 80, 90, "tcp", ""},
 		11,
 		"",
 	}, {
 		"valid udp port range",
-		state.PortRange{"wordpress/0", 80, 90, "UDP"},
+		state.PortRange{"wordpress/0", 80, 90, "UDP", ""},
 		11,
 		"",
 	}, {
 		"invalid port range boundaries",
-		state.PortRange{"wordpress/0", 90, 80, "tcp"},
+		state.PortRange{"wordpress/0", 90, 80, "tcp", ""},
 		0,
 		"invalid port range.*",
 	}, {
 		"invalid protocol",
-		state.PortRange{"wordpress/0", 80, 80, "some protocol"},
+		state.PortRange{"wordpress/0", 80, 80, "some protocol", ""},
 		0,
 		"invalid protocol.*",
 	}, {
 		"invalid unit",
-		state.PortRange{"invalid unit", 80, 80, "tcp"},
+		state.PortRange{"invalid unit", 80, 80, "tcp", ""},
 		0,
 		"invalid unit.*",
 	}, {
 		"negative lower bound",
-		state.PortRange{"wordpress/0", -10, 10, "tcp"},
+		state.PortRange{"wordpress/0", -10, 10, "tcp", ""},
 		0,
 		"port range bounds must be between 1 and 65535.*",
 	}, {
 		"zero lower bound",
-		state.PortRange{"wordpress/0", 0, 10, "tcp"},
+		state.PortRange{"wordpress/0", 0, 10, "tcp", ""},
 		0,
 		"port range bounds must be between 1 and 65535.*",
 	}, {
 		"negative upper bound",
-		state.PortRange{"wordpress/0", 10, -10, "tcp"},
+		state.PortRange{"wordpress/0", 10, -10, "tcp", ""},
 		0,
 		"invalid port range.*",
 	}, {
 		"zero upper bound",
-		state.PortRange{"wordpress/0", 10, 0, "tcp"},
+		state.PortRange{"wordpress/0", 10, 0, "tcp", ""},
 		0,
 		"invalid port range.*",
 	}, {
 		"too large lower bound",
-		state.PortRange{"wordpress/0", 65540, 99999, "tcp"},
+		state.PortRange{"wordpress/0", 65540, 99999, "tcp", ""},
 		0,
 		"port range bounds must be between 1 and 65535.*",
 	}, {
 		"too large upper bound",
-		state.PortRange{"wordpress/0", 10, 99999, "tcp"},
+		state.PortRange{"wordpress/0", 10, 99999, "tcp", ""},
 		0,
 		"port range bounds must be between 1 and 65535.*",
 	}, {
 		"longest valid range",
-		state.PortRange{"wordpress/0", 1, 65535, "tcp"},
+		state.PortRange{"wordpress/0", 1, 65535, "tcp", ""},
 		65535,
 		"",
 	}}
@@ -681,56 +681,56 @@ func (p *PortRangeSuite) TestSanitizeBounds(c *gc.C) {
 		output state.PortRange
 	}{{
 		"valid range",
-		state.PortRange{"", 100, 200, ""},
-		state.PortRange{"", 100, 200, ""},
+		state.PortRange{"", 100, 200, "", ""},
+		state.PortRange{"", 100, 200, "", ""},
 	}, {
 		"negative lower bound",
-		state.PortRange{"", -10, 10, ""},
-		state.PortRange{"", 1, 10, ""},
+		state.PortRange{"", -10, 10, "", ""},
+		state.PortRange{"", 1, 10, "", ""},
 	}, {
 		"zero lower bound",
-		state.PortRange{"", 0, 10, ""},
-		state.PortRange{"", 1, 10, ""},
+		state.PortRange{"", 0, 10, "", ""},
+		state.PortRange{"", 1, 10, "", ""},
 	}, {
 		"negative upper bound",
-		state.PortRange{"", 42, -20, ""},
-		state.PortRange{"", 1, 42, ""},
+		state.PortRange{"", 42, -20, "", ""},
+		state.PortRange{"", 1, 42, "", ""},
 	}, {
 		"zero upper bound",
-		state.PortRange{"", 42, 0, ""},
-		state.PortRange{"", 1, 42, ""},
+		state.PortRange{"", 42, 0, "", ""},
+		state.PortRange{"", 1, 42, "", ""},
 	}, {
 		"both bounds negative",
-		state.PortRange{"", -10, -20, ""},
-		state.PortRange{"", 1, 1, ""},
+		state.PortRange{"", -10, -20, "", ""},
+		state.PortRange{"", 1, 1, "", ""},
 	}, {
 		"both bounds zero",
-		state.PortRange{"", 0, 0, ""},
-		state.PortRange{"", 1, 1, ""},
+		state.PortRange{"", 0, 0, "", ""},
+		state.PortRange{"", 1, 1, "", ""},
 	}, {
 		"swapped bounds",
-		state.PortRange{"", 20, 10, ""},
-		state.PortRange{"", 10, 20, ""},
+		state.PortRange{"", 20, 10, "", ""},
+		state.PortRange{"", 10, 20, "", ""},
 	}, {
 		"too large upper bound",
-		state.PortRange{"", 20, 99999, ""},
-		state.PortRange{"", 20, 65535, ""},
+		state.PortRange{"", 20, 99999, "", ""},
+		state.PortRange{"", 20, 65535, "", ""},
 	}, {
 		"too large lower bound",
-		state.PortRange{"", 99999, 10, ""},
-		state.PortRange{"", 10, 65535, ""},
+		state.PortRange{"", 99999, 10, "", ""},
+		state.PortRange{"", 10, 65535, "", ""},
 	}, {
 		"both bounds too large",
-		state.PortRange{"", 88888, 99999, ""},
-		state.PortRange{"", 65535, 65535, ""},
+		state.PortRange{"", 88888, 99999, "", ""},
+		state.PortRange{"", 65535, 65535, "", ""},
 	}, {
 		"lower negative, upper too large",
-		state.PortRange{"", -10, 99999, ""},
-		state.PortRange{"", 1, 65535, ""},
+		state.PortRange{"", -10, 99999, "", ""},
+		state.PortRange{"", 1, 65535, "", ""},
 	}, {
 		"lower zero, upper too large",
-		state.PortRange{"", 0, 99999, ""},
-		state.PortRange{"", 1, 65535, ""},
+		state.PortRange{"", 0, 99999, "", ""},
+		state.PortRange{"", 1, 65535, "", ""},
 	}}
 	for i, t := range tests {
 		c.Logf("test %d: %s", i, t.about)
@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/juju/errors"
@@ -446,6 +447,63 @@ func (ru *RelationUnit) SettingsAddress() (network.Address, error) {
 	return address, nil
 }
 
+// ingressSettingsKeys holds the keys of the address settings that are
+// only present if the application overrides the ingress address of the
+// relation's endpoint.
+var ingressSettingsKeys = []string{"ingress-address", "ingress-port"}
+
+// AddressSettings returns the settings that advertise the address of
+// this unit to its counterparts in the relation. These hold the
+// `private-address` returned by SettingsAddress, unless the application
+// overrides the ingress address of the relation's endpoint; in which
+// case they hold the overriding address as both `ingress-address` and
+// `private-address`, and its port, if any, as `ingress-port`.
+func (ru *RelationUnit) AddressSettings() (map[string]interface{}, error) {
+	application, err := ru.st.Application(ru.endpoint.ApplicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if address, port, ok := application.IngressAddress(ru.endpoint.Name); ok {
+		settings := map[string]interface{}{
+			"private-address": address.Value,
+			"ingress-address": address.Value,
+		}
+		if port != 0 {
+			settings["ingress-port"] = strconv.Itoa(port)
+		}
+		return settings, nil
+	}
+	address, err := ru.SettingsAddress()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return map[string]interface{}{"private-address": address.Value}, nil
+}
+
+// updateAddressSettings replaces the address settings of the unit in
+// the relation with those returned by AddressSettings, leaving its
+// other settings untouched. If the unit has no address, its existing
+// private-address setting is left in place.
+func (ru *RelationUnit) updateAddressSettings() error {
+	addressSettings, err := ru.AddressSettings()
+	if err != nil {
+		logger.Warningf("cannot set private-address for unit %q in relation %q: %v", ru.unitName, ru.relation, err)
+		addressSettings = nil
+	}
+	settings, err := ru.Settings()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, key := range ingressSettingsKeys {
+		if _, ok := addressSettings[key]; !ok {
+			settings.Delete(key)
+		}
+	}
+	settings.Update(addressSettings)
+	_, err = settings.Write()
+	return errors.Trace(err)
+}
+
 // unitKey returns a string, based on the relation and the supplied unit name,
 // which is used as a key for that unit within this relation in the settings,
 // presence, and relationScopes collections.
@@ -0,0 +1,191 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// SettingsRevision describes a single change made to a settings node,
+// such as the model config or an application's charm config.
+type SettingsRevision struct {
+	// Revision identifies the change. Revisions of a settings node
+	// start at 1 and increase with each change.
+	Revision int
+
+	// Author holds the tag of the entity that made the change, if
+	// it is known.
+	Author string
+
+	// Timestamp records when the change was made.
+	Timestamp time.Time
+
+	// Changes holds the individual changes made to the settings.
+	Changes []ItemChange
+}
+
+// settingsHistoryDoc records a single change to a settings node.
+type settingsHistoryDoc struct {
+	DocID     string                  `bson:"_id"`
+	ModelUUID string                  `bson:"model-uuid"`
+	Key       string                  `bson:"key"`
+	Revision  int                     `bson:"revision"`
+	Author    string                  `bson:"author,omitempty"`
+	Updated   int64                   `bson:"updated"`
+	Changes   []settingsHistoryChange `bson:"changes"`
+}
+
+// settingsHistoryChange records the change made to a single setting.
+type settingsHistoryChange struct {
+	Type     int         `bson:"type"`
+	Key      string      `bson:"key"`
+	OldValue interface{} `bson:"old-value,omitempty"`
+	NewValue interface{} `bson:"new-value,omitempty"`
+}
+
+func settingsHistorySequence(key string) string {
+	return "settingshistory#" + key
+}
+
+func settingsHistoryDocID(key string, revision int) string {
+	return fmt.Sprintf("%s#%d", key, revision)
+}
+
+// writeSettingsWithHistory writes any changes made to the settings,
+// and records them in the history of the settings node identified by
+// historyKey in the same transaction.
+func writeSettingsWithHistory(st *State, settings *Settings, historyKey, author string) ([]ItemChange, error) {
+	changes, ops := settings.settingsUpdateOps()
+	if len(changes) == 0 {
+		return changes, nil
+	}
+	revision, err := st.sequenceWithMin(settingsHistorySequence(historyKey), 1)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc := settingsHistoryDoc{
+		DocID:     st.docID(settingsHistoryDocID(historyKey, revision)),
+		ModelUUID: st.ModelUUID(),
+		Key:       historyKey,
+		Revision:  revision,
+		Author:    author,
+		Updated:   st.clock.Now().UnixNano(),
+	}
+	for _, change := range changes {
+		doc.Changes = append(doc.Changes, settingsHistoryChange{
+			Type:     change.Type,
+			Key:      change.Key,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+	ops = append(ops, txn.Op{
+		C:      settingsHistoryC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	})
+	if err := settings.write(ops); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return changes, nil
+}
+
+// readSettingsHistory returns the recorded changes to the settings
+// node identified by historyKey, oldest first.
+func readSettingsHistory(st *State, historyKey string) ([]SettingsRevision, error) {
+	coll, closer := st.db().GetCollection(settingsHistoryC)
+	defer closer()
+
+	var docs []settingsHistoryDoc
+	err := coll.Find(bson.D{{"key", historyKey}}).Sort("revision").All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read settings history")
+	}
+	result := make([]SettingsRevision, len(docs))
+	for i, doc := range docs {
+		rev := SettingsRevision{
+			Revision:  doc.Revision,
+			Author:    doc.Author,
+			Timestamp: time.Unix(0, doc.Updated).UTC(),
+		}
+		for _, change := range doc.Changes {
+			rev.Changes = append(rev.Changes, ItemChange{
+				Type:     change.Type,
+				Key:      change.Key,
+				OldValue: change.OldValue,
+				NewValue: change.NewValue,
+			})
+		}
+		result[i] = rev
+	}
+	return result, nil
+}
+
+// removeSettingsHistoryOps returns the operations required to remove
+// the recorded history of the settings node identified by historyKey.
+func removeSettingsHistoryOps(st *State, historyKey string) ([]txn.Op, error) {
+	coll, closer := st.db().GetCollection(settingsHistoryC)
+	defer closer()
+
+	var docs []struct {
+		DocID string `bson:"_id"`
+	}
+	err := coll.Find(bson.D{{"key", historyKey}}).Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read settings history")
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      settingsHistoryC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
+// settingsRevertChanges returns the updates and removals needed to
+// return settings with the given history to the values they had
+// immediately after the specified revision was made.
+func settingsRevertChanges(history []SettingsRevision, revision int) (map[string]interface{}, []string, error) {
+	found := false
+	undone := make(map[string]ItemChange)
+	for _, rev := range history {
+		if rev.Revision == revision {
+			found = true
+		}
+		if rev.Revision <= revision {
+			continue
+		}
+		// Only the earliest change to each key after the
+		// revision matters: it holds the value to restore.
+		for _, change := range rev.Changes {
+			if _, ok := undone[change.Key]; !ok {
+				undone[change.Key] = change
+			}
+		}
+	}
+	if !found {
+		return nil, nil, errors.NotFoundf("revision %d", revision)
+	}
+	update := make(map[string]interface{})
+	var remove []string
+	for key, change := range undone {
+		if change.Type == ItemAdded {
+			remove = append(remove, key)
+		} else {
+			update[key] = change.OldValue
+		}
+	}
+	sort.Strings(remove)
+	return update, remove, nil
+}
@@ -979,6 +979,7 @@ type AddApplicationArgs struct {
 	Placement        []*instance.Placement
 	Constraints      constraints.Value
 	Resources        map[string]string
+	IngressAddresses map[string]string
 }
 
 // AddApplication creates a new application, running the supplied charm, with the
@@ -1063,6 +1064,20 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 		}
 	}
 
+	if err := validateIngressAddresses(args.Charm.Meta(), args.IngressAddresses); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ingressAddresses map[string]string
+	for endpoint, address := range args.IngressAddresses {
+		if address == "" {
+			continue
+		}
+		if ingressAddresses == nil {
+			ingressAddresses = make(map[string]string)
+		}
+		ingressAddresses[endpoint] = address
+	}
+
 	// Ignore constraints that result from this call as
 	// these would be accumulation of model and application constraints
 	// but we only want application constraints to be persisted here.
@@ -1107,15 +1122,16 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 	// The doc defaults to CharmModifiedVersion = 0, which is correct, since it
 	// has, by definition, at its initial state.
 	appDoc := &applicationDoc{
-		DocID:         applicationID,
-		Name:          args.Name,
-		ModelUUID:     st.ModelUUID(),
-		Series:        args.Series,
-		Subordinate:   args.Charm.Meta().Subordinate,
-		CharmURL:      args.Charm.URL(),
-		Channel:       string(args.Channel),
-		RelationCount: len(peers),
-		Life:          Alive,
+		DocID:            applicationID,
+		Name:             args.Name,
+		ModelUUID:        st.ModelUUID(),
+		Series:           args.Series,
+		Subordinate:      args.Charm.Meta().Subordinate,
+		CharmURL:         args.Charm.URL(),
+		Channel:          string(args.Channel),
+		RelationCount:    len(peers),
+		Life:             Alive,
+		IngressAddresses: ingressAddresses,
 	}
 
 	app := newApplication(st, appDoc)
@@ -1207,9 +1223,23 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 			ops = append(ops, resOps...)
 		}
 
+		// Collect shared storage operations. The shared storage is
+		// owned by the application, and attached to each of its units.
+		sharedStorageOps, sharedStorage, err := createSharedStorageOps(
+			st, app.ApplicationTag(), args.Charm.Meta(), args.Storage, args.NumUnits,
+		)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, sharedStorageOps...)
+
 		// Collect unit-adding operations.
 		for x := 0; x < args.NumUnits; x++ {
-			unitName, unitOps, err := app.addApplicationUnitOps(applicationAddUnitOpsArgs{cons: args.Constraints, storageCons: args.Storage})
+			unitName, unitOps, err := app.addApplicationUnitOps(applicationAddUnitOpsArgs{
+				cons:          args.Constraints,
+				storageCons:   args.Storage,
+				sharedStorage: sharedStorage,
+			})
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
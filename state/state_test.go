@@ -1455,6 +1455,29 @@ func (s *StateSuite) TestAddApplicationWithNilConfigValues(c *gc.C) {
 	c.Assert(dbFound, jc.IsFalse)
 }
 
+func (s *StateSuite) TestAddApplicationWithIngressAddresses(c *gc.C) {
+	ch := s.AddTestingCharm(c, "mysql")
+	mysql, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:  "mysql",
+		Charm: ch,
+		IngressAddresses: map[string]string{
+			"server": "10.0.0.100:3306",
+			"":       "",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mysql.IngressAddresses(), jc.DeepEquals, map[string]string{
+		"server": "10.0.0.100:3306",
+	})
+
+	_, err = s.State.AddApplication(state.AddApplicationArgs{
+		Name:             "mysql2",
+		Charm:            ch,
+		IngressAddresses: map[string]string{"db": "10.0.0.100"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "mysql2": endpoint "db" not found`)
+}
+
 func (s *StateSuite) TestAddServiceEnvironmentDying(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	// Check that services cannot be added if the model is initially Dying.
@@ -82,6 +82,18 @@ type mockModelState struct {
 	*mockModel
 }
 
+func (m mockModelState) AllApplications() ([]statemetrics.Application, error) {
+	m.MethodCall(m, "AllApplications")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	out := make([]statemetrics.Application, len(m.applications))
+	for i, a := range m.applications {
+		out[i] = a
+	}
+	return out, nil
+}
+
 func (m mockModelState) AllMachines() ([]statemetrics.Machine, error) {
 	m.MethodCall(m, "AllMachines")
 	if err := m.NextErr(); err != nil {
@@ -101,10 +113,11 @@ func (m mockModelState) Close() error {
 
 type mockModel struct {
 	testing.Stub
-	tag      names.ModelTag
-	life     state.Life
-	status   status.StatusInfo
-	machines []*mockMachine
+	tag          names.ModelTag
+	life         state.Life
+	status       status.StatusInfo
+	machines     []*mockMachine
+	applications []*mockApplication
 }
 
 func (m *mockModel) Life() state.Life {
@@ -150,6 +163,7 @@ func (u *mockUser) IsDisabled() bool {
 
 type mockMachine struct {
 	testing.Stub
+	id             string
 	instanceStatus status.StatusInfo
 	agentStatus    status.StatusInfo
 	life           state.Life
@@ -160,6 +174,11 @@ func (m *mockMachine) Life() state.Life {
 	return m.life
 }
 
+func (m *mockMachine) Id() string {
+	m.MethodCall(m, "Id")
+	return m.id
+}
+
 func (m *mockMachine) InstanceStatus() (status.StatusInfo, error) {
 	m.MethodCall(m, "InstanceStatus")
 	if err := m.NextErr(); err != nil {
@@ -175,3 +194,63 @@ func (m *mockMachine) Status() (status.StatusInfo, error) {
 	}
 	return m.agentStatus, nil
 }
+
+type mockApplication struct {
+	testing.Stub
+	life   state.Life
+	status status.StatusInfo
+	units  []*mockUnit
+}
+
+func (a *mockApplication) AllUnits() ([]statemetrics.Unit, error) {
+	a.MethodCall(a, "AllUnits")
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	out := make([]statemetrics.Unit, len(a.units))
+	for i, u := range a.units {
+		out[i] = u
+	}
+	return out, nil
+}
+
+func (a *mockApplication) Life() state.Life {
+	a.MethodCall(a, "Life")
+	return a.life
+}
+
+func (a *mockApplication) Status() (status.StatusInfo, error) {
+	a.MethodCall(a, "Status")
+	if err := a.NextErr(); err != nil {
+		return status.StatusInfo{}, err
+	}
+	return a.status, nil
+}
+
+type mockUnit struct {
+	testing.Stub
+	life           state.Life
+	agentStatus    status.StatusInfo
+	workloadStatus status.StatusInfo
+}
+
+func (u *mockUnit) AgentStatus() (status.StatusInfo, error) {
+	u.MethodCall(u, "AgentStatus")
+	if err := u.NextErr(); err != nil {
+		return status.StatusInfo{}, err
+	}
+	return u.agentStatus, nil
+}
+
+func (u *mockUnit) Life() state.Life {
+	u.MethodCall(u, "Life")
+	return u.life
+}
+
+func (u *mockUnit) Status() (status.StatusInfo, error) {
+	u.MethodCall(u, "Status")
+	if err := u.NextErr(); err != nil {
+		return status.StatusInfo{}, err
+	}
+	return u.workloadStatus, nil
+}
@@ -13,6 +13,7 @@ import (
 
 // State represents the global state managed by the Juju controller.
 type State interface {
+	AllApplications() ([]Application, error)
 	AllMachines() ([]Machine, error)
 	AllModels() ([]Model, error)
 	AllUsers() ([]User, error)
@@ -27,8 +28,23 @@ type StateCloser interface {
 	Close() error
 }
 
+// Application represents an application in a Juju model.
+type Application interface {
+	AllUnits() ([]Unit, error)
+	Life() state.Life
+	Status() (status.StatusInfo, error)
+}
+
+// Unit represents a unit of an application in a Juju model.
+type Unit interface {
+	AgentStatus() (status.StatusInfo, error)
+	Life() state.Life
+	Status() (status.StatusInfo, error)
+}
+
 // Machine represents a machine in a Juju model.
 type Machine interface {
+	Id() string
 	InstanceStatus() (status.StatusInfo, error)
 	Life() state.Life
 	Status() (status.StatusInfo, error)
@@ -57,6 +73,20 @@ type stateShim struct {
 	*state.State
 }
 
+func (s stateShim) AllApplications() ([]Application, error) {
+	applications, err := s.State.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]Application, len(applications))
+	for i, a := range applications {
+		if a != nil {
+			out[i] = applicationShim{a}
+		}
+	}
+	return out, nil
+}
+
 func (s stateShim) AllMachines() ([]Machine, error) {
 	machines, err := s.State.AllMachines()
 	if err != nil {
@@ -106,3 +136,21 @@ func (s stateShim) ForModel(tag names.ModelTag) (StateCloser, error) {
 	}
 	return stateShim{st}, nil
 }
+
+type applicationShim struct {
+	*state.Application
+}
+
+func (a applicationShim) AllUnits() ([]Unit, error) {
+	units, err := a.Application.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]Unit, len(units))
+	for i, u := range units {
+		if u != nil {
+			out[i] = u
+		}
+	}
+	return out, nil
+}
@@ -6,7 +6,10 @@ package statemetrics
 import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/status"
 )
 
 const (
@@ -20,13 +23,28 @@ const (
 	domainLabel           = "domain"
 	agentStatusLabel      = "agent_status"
 	machineStatusLabel    = "machine_status"
+	workloadStatusLabel   = "workload_status"
+	modelUUIDLabel        = "model_uuid"
+	machineLabel          = "machine"
 )
 
 var (
+	applicationLabelNames = []string{
+		lifeLabel,
+		modelUUIDLabel,
+		statusLabel,
+	}
+
 	machineLabelNames = []string{
 		agentStatusLabel,
 		lifeLabel,
 		machineStatusLabel,
+		modelUUIDLabel,
+	}
+
+	pendingMachineLabelNames = []string{
+		machineLabel,
+		modelUUIDLabel,
 	}
 
 	modelLabelNames = []string{
@@ -34,6 +52,13 @@ var (
 		statusLabel,
 	}
 
+	unitLabelNames = []string{
+		agentStatusLabel,
+		lifeLabel,
+		modelUUIDLabel,
+		workloadStatusLabel,
+	}
+
 	userLabelNames = []string{
 		controllerAccessLabel,
 		deletedLabel,
@@ -47,20 +72,26 @@ var (
 // Collector is a prometheus.Collector that collects metrics about
 // the Juju global state.
 type Collector struct {
-	st State
+	st    State
+	clock clock.Clock
 
 	scrapeDuration prometheus.Gauge
 	scrapeErrors   prometheus.Gauge
 
-	models   *prometheus.GaugeVec
-	machines *prometheus.GaugeVec
-	users    *prometheus.GaugeVec
+	models          *prometheus.GaugeVec
+	applications    *prometheus.GaugeVec
+	machines        *prometheus.GaugeVec
+	pendingMachines *prometheus.GaugeVec
+	units           *prometheus.GaugeVec
+	users           *prometheus.GaugeVec
 }
 
-// New returns a new Collector.
-func New(st State) *Collector {
+// New returns a new Collector. The clock is used to determine how
+// long machines have been pending.
+func New(st State, clock clock.Clock) *Collector {
 	return &Collector{
-		st: st,
+		st:    st,
+		clock: clock,
 		scrapeDuration: prometheus.NewGauge(
 			prometheus.GaugeOpts{
 				Namespace: metricsNamespace,
@@ -84,6 +115,14 @@ func New(st State) *Collector {
 			},
 			modelLabelNames,
 		),
+		applications: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "applications",
+				Help:      "Number of applications managed by the controller.",
+			},
+			applicationLabelNames,
+		),
 		machines: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace: metricsNamespace,
@@ -92,6 +131,22 @@ func New(st State) *Collector {
 			},
 			machineLabelNames,
 		),
+		pendingMachines: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "machine_pending_seconds",
+				Help:      "Time in seconds for which each pending machine has been waiting to start.",
+			},
+			pendingMachineLabelNames,
+		),
+		units: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "units",
+				Help:      "Number of units managed by the controller.",
+			},
+			unitLabelNames,
+		),
 		users: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace: metricsNamespace,
@@ -105,8 +160,11 @@ func New(st State) *Collector {
 
 // Describe is part of the prometheus.Collector interface.
 func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.applications.Describe(ch)
 	c.machines.Describe(ch)
 	c.models.Describe(ch)
+	c.pendingMachines.Describe(ch)
+	c.units.Describe(ch)
 	c.users.Describe(ch)
 
 	c.scrapeErrors.Describe(ch)
@@ -121,14 +179,20 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	c.scrapeErrors.Set(0)
 	defer c.scrapeErrors.Collect(ch)
 
+	c.applications.Reset()
 	c.machines.Reset()
 	c.models.Reset()
+	c.pendingMachines.Reset()
+	c.units.Reset()
 	c.users.Reset()
 
 	c.updateMetrics()
 
+	c.applications.Collect(ch)
 	c.machines.Collect(ch)
 	c.models.Collect(ch)
+	c.pendingMachines.Collect(ch)
+	c.units.Collect(ch)
 	c.users.Collect(ch)
 }
 
@@ -202,6 +266,7 @@ func (c *Collector) updateModelMetrics(model Model) {
 		return
 	}
 	defer st.Close()
+	modelUUID := modelTag.Id()
 
 	machines, err := st.AllMachines()
 	if err != nil {
@@ -234,7 +299,25 @@ func (c *Collector) updateModelMetrics(model Model) {
 			agentStatusLabel:   string(agentStatus.Status),
 			lifeLabel:          m.Life().String(),
 			machineStatusLabel: string(machineStatus.Status),
+			modelUUIDLabel:     modelUUID,
 		}).Inc()
+
+		if agentStatus.Status == status.Pending && agentStatus.Since != nil {
+			c.pendingMachines.With(prometheus.Labels{
+				machineLabel:   m.Id(),
+				modelUUIDLabel: modelUUID,
+			}).Set(c.clock.Now().Sub(*agentStatus.Since).Seconds())
+		}
+	}
+
+	applications, err := st.AllApplications()
+	if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting applications: %v", err)
+		applications = nil
+	}
+	for _, a := range applications {
+		c.updateApplicationMetrics(modelUUID, a)
 	}
 
 	c.models.With(prometheus.Labels{
@@ -242,3 +325,50 @@ func (c *Collector) updateModelMetrics(model Model) {
 		statusLabel: string(modelStatus.Status),
 	}).Inc()
 }
+
+func (c *Collector) updateApplicationMetrics(modelUUID string, application Application) {
+	applicationStatus, err := application.Status()
+	if errors.IsNotFound(err) {
+		return // Application removed
+	} else if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting application status: %v", err)
+		return
+	}
+	c.applications.With(prometheus.Labels{
+		lifeLabel:      application.Life().String(),
+		modelUUIDLabel: modelUUID,
+		statusLabel:    string(applicationStatus.Status),
+	}).Inc()
+
+	units, err := application.AllUnits()
+	if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting units: %v", err)
+		return
+	}
+	for _, u := range units {
+		agentStatus, err := u.AgentStatus()
+		if errors.IsNotFound(err) {
+			continue // Unit removed
+		} else if err != nil {
+			c.scrapeErrors.Inc()
+			logger.Debugf("error getting unit agent status: %v", err)
+			continue
+		}
+		workloadStatus, err := u.Status()
+		if errors.IsNotFound(err) {
+			continue // Unit removed
+		} else if err != nil {
+			c.scrapeErrors.Inc()
+			logger.Debugf("error getting unit workload status: %v", err)
+			continue
+		}
+		c.units.With(prometheus.Labels{
+			agentStatusLabel:    string(agentStatus.Status),
+			lifeLabel:           u.Life().String(),
+			modelUUIDLabel:      modelUUID,
+			workloadStatusLabel: string(workloadStatus.Status),
+		}).Inc()
+	}
+}
@@ -6,6 +6,7 @@ package statemetrics_test
 import (
 	"errors"
 	"reflect"
+	"time"
 
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -30,6 +31,8 @@ var _ = gc.Suite(&collectorSuite{})
 
 func (s *collectorSuite) SetUpTest(c *gc.C) {
 	s.IsolationSuite.SetUpTest(c)
+	now := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	pendingSince := now.Add(-5 * time.Minute)
 
 	users := []*mockUser{{
 		tag:              names.NewUserTag("alice"),
@@ -52,15 +55,43 @@ func (s *collectorSuite) SetUpTest(c *gc.C) {
 		life:   state.Alive,
 		status: status.StatusInfo{Status: status.Available},
 		machines: []*mockMachine{{
+			id:             "0",
 			life:           state.Alive,
 			agentStatus:    status.StatusInfo{Status: status.Started},
 			instanceStatus: status.StatusInfo{Status: status.Running},
+		}, {
+			id:             "1",
+			life:           state.Alive,
+			agentStatus:    status.StatusInfo{Status: status.Pending, Since: &pendingSince},
+			instanceStatus: status.StatusInfo{Status: status.Provisioning},
+		}},
+		applications: []*mockApplication{{
+			life:   state.Alive,
+			status: status.StatusInfo{Status: status.Active},
+			units: []*mockUnit{{
+				life:           state.Alive,
+				agentStatus:    status.StatusInfo{Status: status.Idle},
+				workloadStatus: status.StatusInfo{Status: status.Active},
+			}, {
+				life:           state.Alive,
+				agentStatus:    status.StatusInfo{Status: status.Idle},
+				workloadStatus: status.StatusInfo{Status: status.Active},
+			}},
+		}, {
+			life:   state.Dying,
+			status: status.StatusInfo{Status: status.Error},
+			units: []*mockUnit{{
+				life:           state.Dying,
+				agentStatus:    status.StatusInfo{Status: status.Executing},
+				workloadStatus: status.StatusInfo{Status: status.Error},
+			}},
 		}},
 	}, {
 		tag:    names.NewModelTag("1ab5799e-e72d-4de7-b70d-499edfab0e5c"),
 		life:   state.Dying,
 		status: status.StatusInfo{Status: status.Destroying},
 		machines: []*mockMachine{{
+			id:             "0",
 			life:           state.Alive,
 			agentStatus:    status.StatusInfo{Status: status.Error},
 			instanceStatus: status.StatusInfo{Status: status.ProvisioningError},
@@ -71,7 +102,7 @@ func (s *collectorSuite) SetUpTest(c *gc.C) {
 		users:  users,
 		models: models,
 	}
-	s.collector = statemetrics.New(&s.st)
+	s.collector = statemetrics.New(&s.st, testing.NewClock(now))
 }
 
 func (s *collectorSuite) TestDescribe(c *gc.C) {
@@ -85,8 +116,11 @@ func (s *collectorSuite) TestDescribe(c *gc.C) {
 		descStrings = append(descStrings, desc.String())
 	}
 	expect := []string{
+		`.*fqName: "juju_state_applications".*`,
 		`.*fqName: "juju_state_machines".*`,
 		`.*fqName: "juju_state_models".*`,
+		`.*fqName: "juju_state_machine_pending_seconds".*`,
+		`.*fqName: "juju_state_units".*`,
 		`.*fqName: "juju_state_users".*`,
 		`.*fqName: "juju_state_scrape_errors".*`,
 		`.*fqName: "juju_state_scrape_duration_seconds".*`,
@@ -149,7 +183,29 @@ func (s *collectorSuite) TestCollect(c *gc.C) {
 	labelpair := func(n, v string) *dto.LabelPair {
 		return &dto.LabelPair{Name: &n, Value: &v}
 	}
+	const (
+		model1 = "b266dff7-eee8-4297-b03a-4692796ec193"
+		model2 = "1ab5799e-e72d-4de7-b70d-499edfab0e5c"
+	)
 	s.checkExpected(c, dtoMetrics, []dto.Metric{
+		// juju_state_applications
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(1)},
+			Label: []*dto.LabelPair{
+				labelpair("life", "alive"),
+				labelpair("model_uuid", model1),
+				labelpair("status", "active"),
+			},
+		},
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(1)},
+			Label: []*dto.LabelPair{
+				labelpair("life", "dying"),
+				labelpair("model_uuid", model1),
+				labelpair("status", "error"),
+			},
+		},
+
 		// juju_state_machines
 		{
 			Gauge: &dto.Gauge{Value: float64ptr(1)},
@@ -157,6 +213,16 @@ func (s *collectorSuite) TestCollect(c *gc.C) {
 				labelpair("agent_status", "started"),
 				labelpair("life", "alive"),
 				labelpair("machine_status", "running"),
+				labelpair("model_uuid", model1),
+			},
+		},
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(1)},
+			Label: []*dto.LabelPair{
+				labelpair("agent_status", "pending"),
+				labelpair("life", "alive"),
+				labelpair("machine_status", "allocating"),
+				labelpair("model_uuid", model1),
 			},
 		},
 		{
@@ -165,6 +231,36 @@ func (s *collectorSuite) TestCollect(c *gc.C) {
 				labelpair("agent_status", "error"),
 				labelpair("life", "alive"),
 				labelpair("machine_status", "provisioning error"),
+				labelpair("model_uuid", model2),
+			},
+		},
+
+		// juju_state_machine_pending_seconds
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(300)},
+			Label: []*dto.LabelPair{
+				labelpair("machine", "1"),
+				labelpair("model_uuid", model1),
+			},
+		},
+
+		// juju_state_units
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(2)},
+			Label: []*dto.LabelPair{
+				labelpair("agent_status", "idle"),
+				labelpair("life", "alive"),
+				labelpair("model_uuid", model1),
+				labelpair("workload_status", "active"),
+			},
+		},
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(1)},
+			Label: []*dto.LabelPair{
+				labelpair("agent_status", "executing"),
+				labelpair("life", "dying"),
+				labelpair("model_uuid", model1),
+				labelpair("workload_status", "error"),
 			},
 		},
 
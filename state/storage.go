@@ -660,13 +660,147 @@ func createStorageOps(
 		}
 	}
 
-	// TODO(axw) create storage attachments for each shared storage
-	// instance owned by the service.
-	//
-	// TODO(axw) prevent creation of shared storage after service
-	// creation, because the only sane time to add storage attachments
-	// is when units are added to said service.
+	return ops, numStorageAttachments, nil
+}
+
+// createSharedStorageOps returns txn.Ops for creating the shared storage
+// instances owned by a new application, and a model-scoped filesystem for
+// each of them. The storage instances are returned so that the units added
+// along with the application may be attached to them; numUnits is the number
+// of such units.
+//
+// Shared storage instances are created only along with the application,
+// because the only sane time to add storage attachments is when units are
+// added to said application.
+func createSharedStorageOps(
+	st *State,
+	applicationTag names.ApplicationTag,
+	charmMeta *charm.Meta,
+	cons map[string]StorageConstraints,
+	numUnits int,
+) (ops []txn.Op, _ []*storageInstance, err error) {
+	// Create storage instances in order of name, to simplify testing.
+	storageNames := set.NewStrings()
+	for name, c := range cons {
+		charmStorage, ok := charmMeta.Storage[name]
+		if !ok {
+			return nil, nil, errors.NotFoundf("charm storage %q", name)
+		}
+		if charmStorage.Shared && c.Count > 0 {
+			storageNames.Add(name)
+		}
+	}
+
+	// The result is non-nil even if there is no shared storage, so
+	// that the units added along with the application do not look
+	// for shared storage that does not yet exist.
+	storageInstances := []*storageInstance{}
+	for _, name := range storageNames.SortedValues() {
+		cons := cons[name]
+		if storageKind(charmMeta.Storage[name].Type) != storage.StorageKindFilesystem {
+			return nil, nil, errors.NotSupportedf("shared block storage %q", name)
+		}
+		incRefOp, err := increfEntityStorageOp(st, applicationTag, name, int(cons.Count))
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		ops = append(ops, incRefOp)
+
+		for i := uint64(0); i < cons.Count; i++ {
+			id, err := newStorageInstanceId(st, name)
+			if err != nil {
+				return nil, nil, errors.Annotate(err, "cannot generate storage instance name")
+			}
+			doc := storageInstanceDoc{
+				Id:              id,
+				Kind:            StorageKindFilesystem,
+				Owner:           applicationTag.String(),
+				StorageName:     name,
+				AttachmentCount: numUnits,
+			}
+			filesystemOps, _, err := st.addSharedFilesystemOps(FilesystemParams{
+				storage: names.NewStorageTag(id),
+				Pool:    cons.Pool,
+				Size:    cons.Size,
+			})
+			if err != nil {
+				return nil, nil, errors.Annotatef(err, "creating filesystem for storage %s", id)
+			}
+			ops = append(ops, txn.Op{
+				C:      storageInstancesC,
+				Id:     id,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			})
+			ops = append(ops, filesystemOps...)
+			storageInstances = append(storageInstances, &storageInstance{st, doc})
+		}
+	}
+	return ops, storageInstances, nil
+}
+
+// sharedStorageAttachmentOps returns txn.Ops for attaching a new unit to
+// each of its application's shared storage instances, and the number of
+// storage attachments created.
+//
+// If newStorage is non-nil, it holds the shared storage instances being
+// created along with the application, whose attachment counts already
+// account for the unit. Otherwise, the unit is attached to the existing
+// shared storage instances owned by the application; if the unit is
+// assigned to a machine, then the storage's filesystems will be attached
+// to the machine too.
+func sharedStorageAttachmentOps(
+	st *State,
+	applicationTag names.ApplicationTag,
+	unitTag names.UnitTag,
+	charmMeta *charm.Meta,
+	cons map[string]StorageConstraints,
+	series string,
+	newStorage []*storageInstance,
+	maybeMachineAssignable machineAssignable,
+) (ops []txn.Op, numStorageAttachments int, err error) {
+	if newStorage != nil {
+		for _, si := range newStorage {
+			ops = append(ops, createStorageAttachmentOp(si.StorageTag(), unitTag))
+		}
+		return ops, len(newStorage), nil
+	}
 
+	coll, closer := st.db().GetCollection(storageInstancesC)
+	defer closer()
+	var docs []storageInstanceDoc
+	if err := coll.Find(bson.D{
+		{"owner", applicationTag.String()},
+		{"life", Alive},
+	}).Sort("id").All(&docs); err != nil {
+		return nil, -1, errors.Annotatef(err, "cannot get storage instances for %s", applicationTag)
+	}
+	for _, doc := range docs {
+		si := &storageInstance{st, doc}
+		if charmStorage, ok := charmMeta.Storage[si.StorageName()]; !ok || !charmStorage.Shared {
+			continue
+		}
+		ops = append(ops, createStorageAttachmentOp(si.StorageTag(), unitTag), txn.Op{
+			C:      storageInstancesC,
+			Id:     si.doc.Id,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+		})
+		numStorageAttachments++
+
+		if maybeMachineAssignable != nil {
+			machineOps, err := unitAssignedMachineStorageOps(
+				st, unitTag, charmMeta, cons, series, si,
+				maybeMachineAssignable,
+			)
+			if err != nil {
+				return nil, -1, errors.Annotatef(
+					err, "creating machine storage for storage %s", si.doc.Id,
+				)
+			}
+			ops = append(ops, machineOps...)
+		}
+	}
 	return ops, numStorageAttachments, nil
 }
 
@@ -821,6 +955,122 @@ func (st *State) DestroyUnitStorageAttachments(unit names.UnitTag) (err error) {
 	return st.run(buildTxn)
 }
 
+// AttachStorage attaches the storage instance to the specified unit,
+// which then becomes the storage's owner. Only storage that has no
+// owner, such as storage imported into the model, may be attached.
+// If the unit is assigned to a machine, the storage's volume or
+// filesystem will be attached to that machine.
+func (st *State) AttachStorage(storage names.StorageTag, unit names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot attach %s to %s",
+		names.ReadableString(storage), names.ReadableString(unit),
+	)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		si, err := st.storageInstance(storage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if si.doc.Life != Alive {
+			return nil, errors.New("storage is being removed")
+		}
+		if owner := si.maybeOwner(); owner == unit {
+			if _, err := st.storageAttachment(storage, unit); err == nil {
+				// The storage is already attached to the unit.
+				return nil, jujutxn.ErrNoOperations
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Trace(err)
+			}
+		} else if owner != nil {
+			return nil, errors.Errorf("storage is owned by %s", names.ReadableString(owner))
+		}
+		u, err := st.Unit(unit.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if u.Life() != Alive {
+			return nil, unitNotAliveErr
+		}
+		return st.attachStorageOps(si, u)
+	}
+	return st.run(buildTxn)
+}
+
+// attachStorageOps returns txn.Ops to attach the unowned storage
+// instance to the unit, making the unit its owner, and to attach the
+// storage's volume or filesystem to the unit's machine if assigned.
+func (st *State) attachStorageOps(si *storageInstance, u *Unit) ([]txn.Op, error) {
+	// u.charm() returns txn.Ops that ensure the charm URL
+	// does not change during the transaction.
+	ch, err := u.charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	charmMeta := ch.Meta()
+	charmStorage, ok := charmMeta.Storage[si.StorageName()]
+	if !ok {
+		return nil, errors.NotFoundf("charm storage %q", si.StorageName())
+	}
+	if charmStorage.Shared {
+		return nil, errors.NotSupportedf("attaching shared storage")
+	}
+	kind := StorageKindUnknown
+	switch charmStorage.Type {
+	case charm.StorageBlock:
+		kind = StorageKindBlock
+	case charm.StorageFilesystem:
+		kind = StorageKindFilesystem
+	}
+	if kind != si.Kind() {
+		return nil, errors.Errorf(
+			"charm storage %q is %s storage, not %s",
+			si.StorageName(), kind, si.Kind(),
+		)
+	}
+	_, currentCountOp, err := validateStorageCountChange(
+		st, u.Tag(), si.StorageName(), 1, charmMeta,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	incRefOp, err := increfEntityStorageOp(st, u.Tag(), si.StorageName(), 1)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	ops := u.assertCharmOps(ch)
+	ops = append(ops, currentCountOp, incRefOp, txn.Op{
+		C:  storageInstancesC,
+		Id: si.doc.Id,
+		Assert: bson.D{
+			{"life", Alive},
+			{"owner", bson.D{{"$exists", false}}},
+		},
+		Update: bson.D{
+			{"$set", bson.D{{"owner", u.Tag().String()}}},
+			{"$inc", bson.D{{"attachmentcount", 1}}},
+		},
+	}, txn.Op{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$inc", bson.D{{"storageattachmentcount", 1}}}},
+	}, createStorageAttachmentOp(si.StorageTag(), u.UnitTag()))
+
+	// The storage is now owned by the unit, so any existing volume
+	// or filesystem will be attached to the unit's machine.
+	si.doc.Owner = u.Tag().String()
+	allCons, err := u.StorageConstraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineOps, err := unitAssignedMachineStorageOps(
+		st, u.UnitTag(), charmMeta, allCons, u.Series(), si, u,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, machineOps...), nil
+}
+
 // DetachStorage ensures that the storage attachment will be
 // removed at some point.
 func (st *State) DetachStorage(storage names.StorageTag, unit names.UnitTag) (err error) {
@@ -1009,6 +1259,23 @@ func (st *State) detachStorageMachineAttachmentOps(si *storageInstance, unitTag
 	}
 	machineTag := names.NewMachineTag(machineId)
 
+	if owner := si.maybeOwner(); owner != nil && owner.Kind() == names.ApplicationTagKind {
+		// Shared storage is attached to the machines of each of the
+		// application's units. Leave the filesystem attached while
+		// any other unit on the machine is attached to the storage.
+		inUse, err := st.machineHasOtherStorageAttachment(si, unitTag, machineId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		} else if inUse {
+			logger.Debugf(
+				"%s is in use by another unit on %s",
+				names.ReadableString(si.StorageTag()),
+				names.ReadableString(machineTag),
+			)
+			return nil, nil
+		}
+	}
+
 	switch si.Kind() {
 	case StorageKindBlock:
 		volume, err := st.storageInstanceVolume(si.StorageTag())
@@ -1113,6 +1380,39 @@ func (st *State) detachStorageMachineAttachmentOps(si *storageInstance, unitTag
 	}
 }
 
+// machineHasOtherStorageAttachment reports whether or not a unit other than
+// the one specified, and assigned to the specified machine, is attached to
+// the storage instance.
+func (st *State) machineHasOtherStorageAttachment(
+	si *storageInstance, unitTag names.UnitTag, machineId string,
+) (bool, error) {
+	attachments, err := st.StorageAttachments(si.StorageTag())
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, attachment := range attachments {
+		if attachment.Unit() == unitTag {
+			continue
+		}
+		unit, err := st.Unit(attachment.Unit().Id())
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		unitMachineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		if unitMachineId == machineId {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // removeStorageInstancesOps returns the transaction operations to remove all
 // storage instances owned by the specified entity.
 func removeStorageInstancesOps(st *State, owner names.Tag) ([]txn.Op, error) {
@@ -1155,6 +1455,11 @@ type StorageConstraints struct {
 
 	// Count is the required number of storage instances.
 	Count uint64 `bson:"count"`
+
+	// fromSnapshotId, if non-empty, is the provider ID of the volume
+	// snapshot from which the storage instances' volumes should be
+	// created. It is never persisted.
+	fromSnapshotId string
 }
 
 func createStorageConstraintsOp(key string, cons map[string]StorageConstraints) txn.Op {
@@ -1236,13 +1541,6 @@ func validateStorageConstraintsAgainstCharm(
 		if !ok {
 			return errors.Errorf("charm %q has no store called %q", charmMeta.Name, name)
 		}
-		if charmStorage.Shared {
-			// TODO(axw) implement shared storage support.
-			return errors.Errorf(
-				"charm %q store %q: shared storage support not implemented",
-				charmMeta.Name, name,
-			)
-		}
 		if err := validateCharmStorageCount(charmStorage, cons.Count); err != nil {
 			return errors.Annotatef(err, "charm %q store %q", charmMeta.Name, name)
 		}
@@ -1258,6 +1556,28 @@ func validateStorageConstraintsAgainstCharm(
 		if err := validateStoragePool(st, cons.Pool, kind, nil); err != nil {
 			return err
 		}
+		if charmStorage.Shared {
+			if err := validateSharedStoragePool(st, cons.Pool, kind); err != nil {
+				return errors.Annotatef(err, "charm %q store %q", charmMeta.Name, name)
+			}
+		}
+	}
+	return nil
+}
+
+// validateSharedStoragePool validates that the storage pool may be
+// used for shared charm storage. Shared storage must be filesystem
+// storage, provisioned by a shared filesystem provider.
+func validateSharedStoragePool(st *State, poolName string, kind storage.StorageKind) error {
+	if kind != storage.StorageKindFilesystem {
+		return errors.NotSupportedf("shared %q storage", kind)
+	}
+	shared, err := isSharedFilesystemPool(st, poolName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !shared {
+		return errors.Errorf("pool %q does not support shared filesystems", poolName)
 	}
 	return nil
 }
@@ -0,0 +1,160 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
+)
+
+// ImportVolume records an existing volume, already provisioned by the
+// storage provider managing the pool specified in the volume info, as
+// block storage named storageName. The new storage instance has no
+// owner; it may be attached to a unit with AttachStorage.
+func (st *State) ImportVolume(info VolumeInfo, storageName string) (_ names.StorageTag, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot import volume %q", info.VolumeId)
+	if info.VolumeId == "" {
+		return names.StorageTag{}, errors.NotValidf("empty volume ID")
+	}
+	if err := validateImportStoragePool(st, info.Pool, storage.StorageKindBlock); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	if err := st.checkNotImported(volumesC, bson.D{
+		{"info.pool", info.Pool},
+		{"info.volumeid", info.VolumeId},
+	}); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	storageTag, storageOp, err := st.importStorageInstanceOp(storageName, StorageKindBlock)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	name, err := newVolumeName(st, "")
+	if err != nil {
+		return names.StorageTag{}, errors.Annotate(err, "cannot generate volume name")
+	}
+	doc := volumeDoc{
+		Name:      name,
+		StorageId: storageTag.Id(),
+		Info:      &info,
+	}
+	ops := []txn.Op{storageOp}
+	ops = append(ops, st.newVolumeOps(doc, st.importedStorageStatus())...)
+	if err := st.runTransaction(ops); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	return storageTag, nil
+}
+
+// ImportFilesystem records an existing filesystem, already provisioned
+// by the storage provider managing the pool specified in the filesystem
+// info, as filesystem storage named storageName. The new storage
+// instance has no owner; it may be attached to a unit with
+// AttachStorage.
+func (st *State) ImportFilesystem(info FilesystemInfo, storageName string) (_ names.StorageTag, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot import filesystem %q", info.FilesystemId)
+	if info.FilesystemId == "" {
+		return names.StorageTag{}, errors.NotValidf("empty filesystem ID")
+	}
+	if err := validateImportStoragePool(st, info.Pool, storage.StorageKindFilesystem); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	if err := st.checkNotImported(filesystemsC, bson.D{
+		{"info.pool", info.Pool},
+		{"info.filesystemid", info.FilesystemId},
+	}); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	storageTag, storageOp, err := st.importStorageInstanceOp(storageName, StorageKindFilesystem)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	filesystemId, err := newFilesystemId(st, "")
+	if err != nil {
+		return names.StorageTag{}, errors.Annotate(err, "cannot generate filesystem name")
+	}
+	doc := filesystemDoc{
+		FilesystemId: filesystemId,
+		StorageId:    storageTag.Id(),
+		Info:         &info,
+	}
+	ops := []txn.Op{storageOp}
+	ops = append(ops, st.newFilesystemOps(doc, st.importedStorageStatus())...)
+	if err := st.runTransaction(ops); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	return storageTag, nil
+}
+
+// validateImportStoragePool checks that storage of the given kind can
+// be imported into the pool. Imported storage must be managed by the
+// model, so the pool's provider must natively support the kind, and
+// must not be machine-scoped.
+func validateImportStoragePool(st *State, poolName string, kind storage.StorageKind) error {
+	if poolName == "" {
+		return errors.NotValidf("empty pool name")
+	}
+	providerType, provider, err := poolStorageProvider(st, poolName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !provider.Supports(kind) {
+		return errors.NotSupportedf("importing %s storage with %q provider", kind, providerType)
+	}
+	if provider.Scope() == storage.ScopeMachine {
+		return errors.NotSupportedf("importing machine-scoped %q storage", providerType)
+	}
+	return nil
+}
+
+// checkNotImported returns an error if a volume or filesystem in the
+// given collection matches the query, i.e. the provider resource has
+// already been recorded in the model.
+func (st *State) checkNotImported(collection string, query bson.D) error {
+	coll, closer := st.db().GetCollection(collection)
+	defer closer()
+	n, err := coll.Find(query).Count()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if n > 0 {
+		return errors.AlreadyExistsf("storage")
+	}
+	return nil
+}
+
+// importStorageInstanceOp returns the tag of a new storage instance
+// for imported storage, and a txn.Op to create the storage instance.
+// The storage instance has no owner and no attachments.
+func (st *State) importStorageInstanceOp(storageName string, kind StorageKind) (names.StorageTag, txn.Op, error) {
+	if !names.IsValidStorage(storageName + "/0") {
+		return names.StorageTag{}, txn.Op{}, errors.NotValidf("storage name %q", storageName)
+	}
+	id, err := newStorageInstanceId(st, storageName)
+	if err != nil {
+		return names.StorageTag{}, txn.Op{}, errors.Annotate(err, "cannot generate storage instance name")
+	}
+	return names.NewStorageTag(id), txn.Op{
+		C:      storageInstancesC,
+		Id:     id,
+		Assert: txn.DocMissing,
+		Insert: &storageInstanceDoc{
+			Id:          id,
+			Kind:        kind,
+			StorageName: storageName,
+		},
+	}, nil
+}
+
+func (st *State) importedStorageStatus() statusDoc {
+	return statusDoc{
+		Status:  status.Detached,
+		Updated: st.clock.Now().UnixNano(),
+	}
+}
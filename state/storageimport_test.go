@@ -0,0 +1,183 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type StorageImportSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StorageImportSuite{})
+
+func (s *StorageImportSuite) importVolume(c *gc.C, storageName string) names.StorageTag {
+	storageTag, err := s.State.ImportVolume(state.VolumeInfo{
+		Pool:       "modelscoped",
+		VolumeId:   "vol-1234",
+		Size:       4096,
+		Persistent: true,
+	}, storageName)
+	c.Assert(err, jc.ErrorIsNil)
+	return storageTag
+}
+
+func (s *StorageImportSuite) TestImportVolume(c *gc.C) {
+	storageTag := s.importVolume(c, "allecto")
+	c.Assert(storageTag, gc.Equals, names.NewStorageTag("allecto/0"))
+
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Kind(), gc.Equals, state.StorageKindBlock)
+	c.Assert(si.StorageName(), gc.Equals, "allecto")
+	_, hasOwner := si.Owner()
+	c.Assert(hasOwner, jc.IsFalse)
+
+	volume := s.storageInstanceVolume(c, storageTag)
+	_, ok := volume.Params()
+	c.Assert(ok, jc.IsFalse)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.VolumeInfo{
+		Pool:       "modelscoped",
+		VolumeId:   "vol-1234",
+		Size:       4096,
+		Persistent: true,
+	})
+	volumeStatus, err := volume.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeStatus.Status, gc.Equals, status.Detached)
+}
+
+func (s *StorageImportSuite) TestImportVolumeAlreadyImported(c *gc.C) {
+	s.importVolume(c, "allecto")
+	_, err := s.State.ImportVolume(state.VolumeInfo{
+		Pool:     "modelscoped",
+		VolumeId: "vol-1234",
+		Size:     4096,
+	}, "allecto")
+	c.Assert(err, gc.ErrorMatches, `cannot import volume "vol-1234": storage already exists`)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *StorageImportSuite) TestImportVolumeMachineScoped(c *gc.C) {
+	_, err := s.State.ImportVolume(state.VolumeInfo{
+		Pool:     "machinescoped",
+		VolumeId: "vol-1234",
+		Size:     4096,
+	}, "allecto")
+	c.Assert(err, gc.ErrorMatches, `cannot import volume "vol-1234": importing machine-scoped "machinescoped" storage not supported`)
+}
+
+func (s *StorageImportSuite) TestImportVolumeInvalidStorageName(c *gc.C) {
+	_, err := s.State.ImportVolume(state.VolumeInfo{
+		Pool:     "modelscoped",
+		VolumeId: "vol-1234",
+		Size:     4096,
+	}, "0")
+	c.Assert(err, gc.ErrorMatches, `cannot import volume "vol-1234": storage name "0" not valid`)
+}
+
+func (s *StorageImportSuite) TestImportFilesystem(c *gc.C) {
+	storageTag, err := s.State.ImportFilesystem(state.FilesystemInfo{
+		Pool:         "modelscoped",
+		FilesystemId: "fs-1234",
+		Size:         1024,
+	}, "data")
+	c.Assert(err, jc.ErrorIsNil)
+
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Kind(), gc.Equals, state.StorageKindFilesystem)
+
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	info, err := filesystem.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.FilesystemId, gc.Equals, "fs-1234")
+	_, err = filesystem.Volume()
+	c.Assert(err, gc.Equals, state.ErrNoBackingVolume)
+}
+
+func (s *StorageImportSuite) TestImportFilesystemUnsupported(c *gc.C) {
+	_, err := s.State.ImportFilesystem(state.FilesystemInfo{
+		Pool:         "persistent-block",
+		FilesystemId: "fs-1234",
+	}, "data")
+	c.Assert(err, gc.ErrorMatches, `cannot import filesystem "fs-1234": importing filesystem storage with "modelscoped-block" provider not supported`)
+}
+
+func (s *StorageImportSuite) TestAttachStorageUnassigned(c *gc.C) {
+	_, u, _ := s.setupSingleStorage(c, "block", "modelscoped")
+	storageTag := s.importVolume(c, "allecto")
+	volume := s.storageInstanceVolume(c, storageTag)
+
+	err := s.State.AttachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, _ := si.Owner()
+	c.Assert(owner, gc.Equals, u.Tag())
+	_, err = s.State.StorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// When the unit is assigned, the existing volume is attached
+	// to the machine rather than a new volume being created.
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	s.volumeAttachment(c, names.NewMachineTag(machineId), volume.VolumeTag())
+	c.Assert(s.storageInstanceVolume(c, storageTag).VolumeTag(), gc.Equals, volume.VolumeTag())
+}
+
+func (s *StorageImportSuite) TestAttachStorageAssigned(c *gc.C) {
+	_, u, _ := s.setupSingleStorage(c, "block", "modelscoped")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	storageTag := s.importVolume(c, "allecto")
+	volume := s.storageInstanceVolume(c, storageTag)
+
+	err = s.State.AttachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	s.volumeAttachment(c, names.NewMachineTag(machineId), volume.VolumeTag())
+
+	// Attaching again is a no-op.
+	err = s.State.AttachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageImportSuite) TestAttachStorageOwned(c *gc.C) {
+	app, _, storageTag := s.setupSingleStorage(c, "block", "modelscoped")
+	u2, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot attach storage data/0 to unit storage-block/1: storage is owned by unit storage-block/0`)
+}
+
+func (s *StorageImportSuite) TestAttachStorageSingular(c *gc.C) {
+	_, u, _ := s.setupSingleStorage(c, "block", "modelscoped")
+	storageTag := s.importVolume(c, "data")
+	err := s.State.AttachStorage(storageTag, u.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot attach storage data/1 to unit storage-block/0: cannot attach, storage is singular`)
+}
+
+func (s *StorageImportSuite) TestAttachStorageKindMismatch(c *gc.C) {
+	_, u, _ := s.setupSingleStorage(c, "block", "modelscoped")
+	storageTag, err := s.State.ImportFilesystem(state.FilesystemInfo{
+		Pool:         "modelscoped",
+		FilesystemId: "fs-1234",
+	}, "allecto")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AttachStorage(storageTag, u.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot attach storage allecto/1 to unit storage-block/0: charm storage "allecto" is block storage, not filesystem`)
+}
@@ -917,6 +917,30 @@ func (u *Unit) OpenPortsOnSubnet(subnetID, protocol string, fromPort, toPort int
 	if err != nil {
 		return errors.Annotatef(err, "invalid port range %v-%v/%v", fromPort, toPort, protocol)
 	}
+	return u.openPortsOnSubnet(subnetID, ports)
+}
+
+// OpenEndpointPorts opens the given port range and protocol for the unit,
+// for the named endpoint of the unit's charm. When the application is
+// exposed, the range may only be accessed from the sources the endpoint
+// is exposed to.
+func (u *Unit) OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	ports, err := NewPortRange(u.Name(), fromPort, toPort, protocol)
+	if err != nil {
+		return errors.Annotatef(err, "invalid port range %v-%v/%v", fromPort, toPort, protocol)
+	}
+	app, err := u.Application()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := app.Endpoint(endpoint); err != nil {
+		return errors.Annotatef(err, "cannot open ports %v for unit %q", ports, u)
+	}
+	ports.Endpoint = endpoint
+	return u.openPortsOnSubnet("", ports)
+}
+
+func (u *Unit) openPortsOnSubnet(subnetID string, ports PortRange) (err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot open ports %v for unit %q on subnet %q", ports, u, subnetID)
 
 	machineID, err := u.AssignedMachineId()
@@ -1867,13 +1891,23 @@ func machineStorageParamsForStorageInstance(
 			charmStorage.ReadOnly,
 		}
 		if unit == storage.maybeOwner() {
-			// The storage instance is owned by the unit, so we'll need
-			// to create a volume.
+			// The storage instance is owned by the unit. If the
+			// storage was imported, there will be a volume already,
+			// which we just attach; otherwise we'll need to create
+			// a volume.
+			volume, err := st.storageInstanceVolume(storage.StorageTag())
+			if err == nil {
+				volumeAttachments[volume.VolumeTag()] = volumeAttachmentParams
+				break
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Annotatef(err, "getting volume for storage %q", storage.Tag().Id())
+			}
 			cons := allCons[storage.StorageName()]
 			volumeParams := VolumeParams{
-				storage: storage.StorageTag(),
-				Pool:    cons.Pool,
-				Size:    cons.Size,
+				storage:    storage.StorageTag(),
+				Pool:       cons.Pool,
+				Size:       cons.Size,
+				SnapshotId: cons.fromSnapshotId,
 			}
 			volumes = append(volumes, MachineVolumeParams{
 				volumeParams, volumeAttachmentParams,
@@ -1902,8 +1936,17 @@ func machineStorageParamsForStorageInstance(
 			charmStorage.ReadOnly,
 		}
 		if unit == storage.maybeOwner() {
-			// The storage instance is owned by the unit, so we'll need
+			// The storage instance is owned by the unit. If the
+			// storage was imported, there will be a filesystem
+			// already, which we just attach; otherwise we'll need
 			// to create a filesystem.
+			filesystem, err := st.storageInstanceFilesystem(storage.StorageTag())
+			if err == nil {
+				filesystemAttachments[filesystem.FilesystemTag()] = filesystemAttachmentParams
+				break
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Annotatef(err, "getting filesystem for storage %q", storage.Tag().Id())
+			}
 			cons := allCons[storage.StorageName()]
 			filesystemParams := FilesystemParams{
 				storage: storage.StorageTag(),
@@ -2242,6 +2285,12 @@ type ActionSpecsByName map[string]charm.ActionSpec
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
 // mutates payload.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, error) {
+	return u.AddActionWithOptions(name, payload, ActionOptions{})
+}
+
+// AddActionWithOptions adds a new Action of type name and using arguments
+// payload to this Unit, with the given options.
+func (u *Unit) AddActionWithOptions(name string, payload map[string]interface{}, options ActionOptions) (Action, error) {
 	if len(name) == 0 {
 		return nil, errors.New("no action name given")
 	}
@@ -2267,7 +2316,7 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 	if err != nil {
 		return nil, err
 	}
-	return u.st.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+	return u.st.EnqueueActionWithOptions(u.Tag(), name, payloadWithDefaults, options)
 }
 
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
@@ -1247,7 +1247,7 @@ func (s *UnitSuite) TestRemoveLastUnitOnMachineRemovesAllPorts(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(ports, gc.HasLen, 1)
 	c.Assert(ports[0].PortsForUnit(s.unit.Name()), jc.DeepEquals, []state.PortRange{
-		{s.unit.Name(), 100, 200, "tcp"},
+		{s.unit.Name(), 100, 200, "tcp", ""},
 	})
 
 	// Now remove the unit and check again.
@@ -1289,10 +1289,10 @@ func (s *UnitSuite) TestRemoveUnitRemovesItsPortsOnly(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(ports, gc.HasLen, 1)
 	c.Assert(ports[0].PortsForUnit(s.unit.Name()), jc.DeepEquals, []state.PortRange{
-		{s.unit.Name(), 100, 200, "tcp"},
+		{s.unit.Name(), 100, 200, "tcp", ""},
 	})
 	c.Assert(ports[0].PortsForUnit(otherUnit.Name()), jc.DeepEquals, []state.PortRange{
-		{otherUnit.Name(), 300, 400, "udp"},
+		{otherUnit.Name(), 300, 400, "udp", ""},
 	})
 
 	// Now remove the first unit and check again.
@@ -1309,10 +1309,54 @@ func (s *UnitSuite) TestRemoveUnitRemovesItsPortsOnly(c *gc.C) {
 	c.Assert(ports, gc.HasLen, 1)
 	c.Assert(ports[0].PortsForUnit(s.unit.Name()), gc.HasLen, 0)
 	c.Assert(ports[0].PortsForUnit(otherUnit.Name()), jc.DeepEquals, []state.PortRange{
-		{otherUnit.Name(), 300, 400, "udp"},
+		{otherUnit.Name(), 300, 400, "udp", ""},
 	})
 }
 
+func (s *UnitSuite) TestOpenEndpointPorts(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.OpenEndpointPorts("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.OpenPorts("tcp", 443, 443)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ports, err := machine.AllPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, gc.HasLen, 1)
+	c.Assert(ports[0].PortsForUnit(s.unit.Name()), jc.DeepEquals, []state.PortRange{
+		{s.unit.Name(), 80, 80, "tcp", "url"},
+		{s.unit.Name(), 443, 443, "tcp", ""},
+	})
+	c.Assert(ports[0].AllPortRangeEndpoints(), jc.DeepEquals, map[network.PortRange]string{
+		{80, 80, "tcp"}: "url",
+	})
+
+	// The same range cannot be opened for another endpoint.
+	err = s.unit.OpenEndpointPorts("db", "tcp", 80, 80)
+	c.Assert(err, gc.ErrorMatches, `cannot open ports 80-80/tcp \("wordpress/0", endpoint "db"\) for unit "wordpress/0" on subnet "": .* conflict`)
+
+	// Closing the range does not need the endpoint.
+	err = s.unit.ClosePorts("tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	open, err := s.unit.OpenedPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(open, jc.DeepEquals, []network.PortRange{{443, 443, "tcp"}})
+}
+
+func (s *UnitSuite) TestOpenEndpointPortsUnknownEndpoint(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.OpenEndpointPorts("bogus", "tcp", 80, 80)
+	c.Assert(err, gc.ErrorMatches, `cannot open ports 80-80/tcp \("wordpress/0"\) for unit "wordpress/0": application "wordpress" has no "bogus" relation`)
+}
+
 func (s *UnitSuite) TestSetClearResolvedWhenNotAlive(c *gc.C) {
 	preventUnitDestroyRemove(c, s.unit)
 	err := s.unit.Destroy()
@@ -46,6 +46,11 @@ type Volume interface {
 	// if it has not already been provisioned. Params returns true if the
 	// returned parameters are usable for provisioning, otherwise false.
 	Params() (VolumeParams, bool)
+
+	// RequestedSize returns the size in MiB that the volume has been
+	// requested to grow to, if a resize is pending. RequestedSize
+	// returns true if there is a pending resize, otherwise false.
+	RequestedSize() (uint64, bool)
 }
 
 // VolumeAttachment describes an attachment of a volume to a machine.
@@ -91,6 +96,11 @@ type volumeDoc struct {
 	Info            *VolumeInfo   `bson:"info,omitempty"`
 	Params          *VolumeParams `bson:"params,omitempty"`
 
+	// RequestedSize is the size in MiB that the volume has been
+	// requested to grow to. It is unset once the volume's info
+	// records a size at least as large.
+	RequestedSize uint64 `bson:"requestedsize,omitempty"`
+
 	// MachineId is the ID of the machine that a non-detachable
 	// volume is initially attached to. We use this to identify
 	// the volume as being non-detachable, and to determine
@@ -118,6 +128,10 @@ type VolumeParams struct {
 
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
+
+	// SnapshotId, if non-empty, is the provider ID of the snapshot
+	// from which the volume is to be created.
+	SnapshotId string `bson:"snapshotid,omitempty"`
 }
 
 // VolumeInfo describes information about a volume.
@@ -194,6 +208,11 @@ func (v *volume) Params() (VolumeParams, bool) {
 	return *v.doc.Params, true
 }
 
+// RequestedSize is required to implement Volume.
+func (v *volume) RequestedSize() (uint64, bool) {
+	return v.doc.RequestedSize, v.doc.RequestedSize != 0
+}
+
 // Status is required to implement StatusGetter.
 func (v *volume) Status() (status.StatusInfo, error) {
 	return v.st.VolumeStatus(v.VolumeTag())
@@ -620,6 +639,18 @@ func machineStorageDecrefOp(
 	return op
 }
 
+// increfMachineStorageOp returns a txn.Op that will increment the
+// attachment count for a given machine storage entity (volume or
+// filesystem), which must be Alive.
+func increfMachineStorageOp(collection, id string) txn.Op {
+	return txn.Op{
+		C:      collection,
+		Id:     id,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+	}
+}
+
 // DestroyVolume ensures that the volume and any attachments to it will be
 // destroyed and removed from state at some point in the future. DestroyVolume
 // will fail with an IsContainsFilesystem error if the volume contains a
@@ -989,7 +1020,7 @@ func (st *State) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (err error)
 		// If the volume has parameters, unset them when
 		// we set info for the first time, ensuring that
 		// params and info are mutually exclusive.
-		var unsetParams bool
+		var unsetParams, unsetRequestedSize bool
 		var ops []txn.Op
 		if params, ok := v.Params(); ok {
 			info.Pool = params.Pool
@@ -1003,8 +1034,13 @@ func (st *State) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (err error)
 			if err := validateVolumeInfoChange(info, oldInfo); err != nil {
 				return nil, err
 			}
+			// A pending resize is complete once the volume
+			// is at least as large as was requested.
+			if size, ok := v.RequestedSize(); ok && info.Size >= size {
+				unsetRequestedSize = true
+			}
 		}
-		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams)...)
+		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams, unsetRequestedSize)...)
 		return ops, nil
 	}
 	return st.run(buildTxn)
@@ -1026,15 +1062,22 @@ func validateVolumeInfoChange(newInfo, oldInfo VolumeInfo) error {
 	return nil
 }
 
-func setVolumeInfoOps(tag names.VolumeTag, info VolumeInfo, unsetParams bool) []txn.Op {
+func setVolumeInfoOps(tag names.VolumeTag, info VolumeInfo, unsetParams, unsetRequestedSize bool) []txn.Op {
 	asserts := isAliveDoc
 	update := bson.D{
 		{"$set", bson.D{{"info", &info}}},
 	}
+	var unset bson.D
 	if unsetParams {
 		asserts = append(asserts, bson.DocElem{"info", bson.D{{"$exists", false}}})
 		asserts = append(asserts, bson.DocElem{"params", bson.D{{"$exists", true}}})
-		update = append(update, bson.DocElem{"$unset", bson.D{{"params", nil}}})
+		unset = append(unset, bson.DocElem{"params", nil})
+	}
+	if unsetRequestedSize {
+		unset = append(unset, bson.DocElem{"requestedsize", nil})
+	}
+	if len(unset) > 0 {
+		update = append(update, bson.DocElem{"$unset", unset})
 	}
 	return []txn.Op{{
 		C:      volumesC,
@@ -1044,6 +1087,78 @@ func setVolumeInfoOps(tag names.VolumeTag, info VolumeInfo, unsetParams bool) []
 	}}
 }
 
+// ResizeVolume records a request to grow the specified volume to the
+// given size in MiB. The volume must be alive and provisioned, and the
+// requested size must be larger than the volume's current size. The
+// storage provisioner responsible for the volume will carry out the
+// resize, and record the new size with SetVolumeInfo.
+func (st *State) ResizeVolume(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize volume %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if size <= info.Size {
+			return nil, errors.Errorf(
+				"requested size %dMiB is not larger than current size %dMiB",
+				size, info.Size,
+			)
+		}
+		if requested, ok := v.RequestedSize(); ok && requested == size {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: append(isAliveDoc, bson.DocElem{"info", bson.D{{"$exists", true}}}),
+			Update: bson.D{{"$set", bson.D{{"requestedsize", size}}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// ResizeStorageInstance records a request to grow the specified storage
+// instance to the given size in MiB. Block storage is resized by
+// resizing its volume; filesystem storage is resized by resizing the
+// volume backing its filesystem, which must exist.
+func (st *State) ResizeStorageInstance(tag names.StorageTag, size uint64) error {
+	s, err := st.storageInstance(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var volumeTag names.VolumeTag
+	switch s.Kind() {
+	case StorageKindBlock:
+		v, err := st.storageInstanceVolume(tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		volumeTag = v.VolumeTag()
+	case StorageKindFilesystem:
+		f, err := st.storageInstanceFilesystem(tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		volumeTag, err = f.Volume()
+		if errors.Cause(err) == ErrNoBackingVolume {
+			return errors.NotSupportedf("resizing storage %q without a backing volume", tag.Id())
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+	default:
+		return errors.NotSupportedf("resizing %s storage", s.Kind())
+	}
+	return errors.Trace(st.ResizeVolume(volumeTag, size))
+}
+
 // AllVolumes returns all Volumes scoped to the model.
 func (st *State) AllVolumes() ([]Volume, error) {
 	volumes, err := st.volumes(nil)
@@ -0,0 +1,237 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/storage"
+)
+
+// VolumeSnapshot describes a point-in-time snapshot of a volume, held
+// by the storage provider that manages the volume.
+type VolumeSnapshot interface {
+	// Id returns the Juju-assigned ID of the snapshot.
+	Id() string
+
+	// Volume returns the tag of the volume that the snapshot was
+	// taken of.
+	Volume() names.VolumeTag
+
+	// Pool returns the name of the storage pool of the snapshotted
+	// volume. Volumes created from the snapshot are created in the
+	// same pool.
+	Pool() string
+
+	// SnapshotId returns the provider-supplied ID of the snapshot.
+	SnapshotId() string
+
+	// Size returns the size of the snapshotted volume in MiB.
+	Size() uint64
+
+	// Created returns the time at which the snapshot was recorded.
+	Created() time.Time
+}
+
+type volumeSnapshot struct {
+	doc volumeSnapshotDoc
+}
+
+// volumeSnapshotDoc records information about a volume snapshot.
+type volumeSnapshotDoc struct {
+	DocID      string `bson:"_id"`
+	Id         string `bson:"id"`
+	ModelUUID  string `bson:"model-uuid"`
+	Volume     string `bson:"volumeid"`
+	Pool       string `bson:"pool"`
+	SnapshotId string `bson:"snapshotid"`
+	Size       uint64 `bson:"size"`
+	Created    int64  `bson:"created"`
+}
+
+// VolumeSnapshotInfo describes a snapshot created by a storage
+// provider.
+type VolumeSnapshotInfo struct {
+	// SnapshotId is the provider-supplied ID of the snapshot.
+	SnapshotId string
+
+	// Size is the size of the snapshotted volume in MiB.
+	Size uint64
+}
+
+// Id is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Id() string {
+	return s.doc.Id
+}
+
+// Volume is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Volume() names.VolumeTag {
+	return names.NewVolumeTag(s.doc.Volume)
+}
+
+// Pool is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Pool() string {
+	return s.doc.Pool
+}
+
+// SnapshotId is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) SnapshotId() string {
+	return s.doc.SnapshotId
+}
+
+// Size is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Size() uint64 {
+	return s.doc.Size
+}
+
+// Created is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Created() time.Time {
+	return time.Unix(0, s.doc.Created).UTC()
+}
+
+// AddVolumeSnapshot records a snapshot of the specified volume that
+// has been created by the volume's storage provider.
+func (st *State) AddVolumeSnapshot(tag names.VolumeTag, info VolumeSnapshotInfo) (VolumeSnapshot, error) {
+	if info.SnapshotId == "" {
+		return nil, errors.NotValidf("empty snapshot ID")
+	}
+	volume, err := st.volumeByTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeInfo, err := volume.Info()
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot snapshot volume %q", tag.Id())
+	}
+	seq, err := st.sequence("volumesnapshot")
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot generate snapshot ID")
+	}
+	id := fmt.Sprint(seq)
+	doc := volumeSnapshotDoc{
+		DocID:      st.docID(id),
+		Id:         id,
+		ModelUUID:  st.ModelUUID(),
+		Volume:     tag.Id(),
+		Pool:       volumeInfo.Pool,
+		SnapshotId: info.SnapshotId,
+		Size:       info.Size,
+		Created:    st.clock.Now().UnixNano(),
+	}
+	ops := []txn.Op{{
+		C:      volumesC,
+		Id:     tag.Id(),
+		Assert: txn.DocExists,
+	}, {
+		C:      volumeSnapshotsC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.runTransaction(ops); err != nil {
+		return nil, errors.Annotatef(err, "cannot add snapshot of volume %q", tag.Id())
+	}
+	return &volumeSnapshot{doc}, nil
+}
+
+// VolumeSnapshot returns the volume snapshot with the given ID.
+func (st *State) VolumeSnapshot(id string) (VolumeSnapshot, error) {
+	coll, closer := st.db().GetCollection(volumeSnapshotsC)
+	defer closer()
+
+	var doc volumeSnapshotDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("volume snapshot %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get volume snapshot %q", id)
+	}
+	return &volumeSnapshot{doc}, nil
+}
+
+// VolumeSnapshots returns all of the volume snapshots in the model, in
+// the order they were added.
+func (st *State) VolumeSnapshots() ([]VolumeSnapshot, error) {
+	coll, closer := st.db().GetCollection(volumeSnapshotsC)
+	defer closer()
+
+	var docs []volumeSnapshotDoc
+	if err := coll.Find(nil).Sort("created").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get volume snapshots")
+	}
+	snapshots := make([]VolumeSnapshot, len(docs))
+	for i, doc := range docs {
+		snapshots[i] = &volumeSnapshot{doc}
+	}
+	return snapshots, nil
+}
+
+// RemoveVolumeSnapshot removes the record of the volume snapshot with
+// the given ID. It is the caller's responsibility to delete the
+// snapshot from the storage provider. Removing a snapshot that does not
+// exist is not an error.
+func (st *State) RemoveVolumeSnapshot(id string) error {
+	ops := []txn.Op{{
+		C:      volumeSnapshotsC,
+		Id:     st.docID(id),
+		Remove: true,
+	}}
+	return errors.Annotatef(st.runTransaction(ops), "cannot remove volume snapshot %q", id)
+}
+
+// AddStorageFromSnapshot adds a storage instance named storageName to
+// the specified unit, backed by a new volume created from the volume
+// snapshot with the given ID. The storage must be block storage, and
+// the unit must be assigned to a machine.
+func (st *State) AddStorageFromSnapshot(tag names.UnitTag, storageName, snapshotId string) error {
+	snapshot, err := st.VolumeSnapshot(snapshotId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	u, err := st.Unit(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cons := StorageConstraints{
+		Pool:           snapshot.Pool(),
+		Size:           snapshot.Size(),
+		Count:          1,
+		fromSnapshotId: snapshot.SnapshotId(),
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		ch, err := u.charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		charmStorage, ok := ch.Meta().Storage[storageName]
+		if !ok {
+			return nil, errors.NotFoundf("charm storage %q", storageName)
+		}
+		if storageKind(charmStorage.Type) != storage.StorageKindBlock {
+			return nil, errors.NotSupportedf("restoring %s storage from a snapshot", charmStorage.Type)
+		}
+		// The snapshot is recorded only in the parameters of the
+		// volume created for the unit's machine, so the unit must
+		// already be assigned.
+		if _, err := u.AssignedMachineId(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return st.addStorageForUnitOps(u, storageName, cons)
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "adding %q storage to %s from snapshot %s", storageName, u, snapshotId)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type VolumeSnapshotSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&VolumeSnapshotSuite{})
+
+// provisionedVolume returns the tag of a provisioned, model-scoped
+// volume backing the "allecto" storage of a storage-block unit.
+func (s *VolumeSnapshotSuite) provisionedVolume(c *gc.C) (*state.Unit, names.VolumeTag) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	app := s.AddTestingServiceWithStorage(c, "storage-block", ch, map[string]state.StorageConstraints{
+		"data":    makeStorageCons("loop-pool", 1024, 1),
+		"allecto": makeStorageCons("modelscoped", 2048, 1),
+	})
+	unit, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(unit, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	volume := s.storageInstanceVolume(c, names.NewStorageTag("allecto/0"))
+	err = s.State.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{
+		Size:     2048,
+		VolumeId: "vol-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return unit, volume.VolumeTag()
+}
+
+func (s *VolumeSnapshotSuite) TestAddVolumeSnapshot(c *gc.C) {
+	_, volumeTag := s.provisionedVolume(c)
+	snapshot, err := s.State.AddVolumeSnapshot(volumeTag, state.VolumeSnapshotInfo{
+		SnapshotId: "snap-0",
+		Size:       2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(snapshot.Id(), gc.Equals, "0")
+	c.Check(snapshot.Volume(), gc.Equals, volumeTag)
+	c.Check(snapshot.Pool(), gc.Equals, "modelscoped")
+	c.Check(snapshot.SnapshotId(), gc.Equals, "snap-0")
+	c.Check(snapshot.Size(), gc.Equals, uint64(2048))
+
+	snapshot, err = s.State.VolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(snapshot.SnapshotId(), gc.Equals, "snap-0")
+
+	snapshots, err := s.State.VolumeSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 1)
+	c.Check(snapshots[0].Id(), gc.Equals, "0")
+}
+
+func (s *VolumeSnapshotSuite) TestAddVolumeSnapshotUnprovisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "modelscoped")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+
+	_, err = s.State.AddVolumeSnapshot(volume.VolumeTag(), state.VolumeSnapshotInfo{
+		SnapshotId: "snap-0",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot snapshot volume "0": volume "0" not provisioned`)
+}
+
+func (s *VolumeSnapshotSuite) TestRemoveVolumeSnapshot(c *gc.C) {
+	_, volumeTag := s.provisionedVolume(c)
+	snapshot, err := s.State.AddVolumeSnapshot(volumeTag, state.VolumeSnapshotInfo{
+		SnapshotId: "snap-0",
+		Size:       2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveVolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.VolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing a snapshot again is not an error.
+	err = s.State.RemoveVolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageFromSnapshot(c *gc.C) {
+	unit, volumeTag := s.provisionedVolume(c)
+	snapshot, err := s.State.AddVolumeSnapshot(volumeTag, state.VolumeSnapshotInfo{
+		SnapshotId: "snap-0",
+		Size:       3072,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AddStorageFromSnapshot(unit.UnitTag(), "allecto", snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	volume := s.storageInstanceVolume(c, names.NewStorageTag("allecto/2"))
+	params, ok := volume.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(params, jc.DeepEquals, state.VolumeParams{
+		Pool:       "modelscoped",
+		Size:       3072,
+		SnapshotId: "snap-0",
+	})
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageFromSnapshotNotFound(c *gc.C) {
+	_, u, _ := s.setupSingleStorage(c, "block", "modelscoped")
+	err := s.State.AddStorageFromSnapshot(u.UnitTag(), "allecto", "42")
+	c.Assert(err, gc.ErrorMatches, `volume snapshot "42" not found`)
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageFromSnapshotFilesystem(c *gc.C) {
+	_, volumeTag := s.provisionedVolume(c)
+	snapshot, err := s.State.AddVolumeSnapshot(volumeTag, state.VolumeSnapshotInfo{
+		SnapshotId: "snap-0",
+		Size:       2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, u, _ := s.setupSingleStorage(c, "filesystem", "modelscoped")
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddStorageFromSnapshot(u.UnitTag(), "data", snapshot.Id())
+	c.Assert(err, gc.ErrorMatches, `adding "data" storage to storage-filesystem/0 from snapshot 0: restoring filesystem storage from a snapshot not supported`)
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageFromSnapshotUnassigned(c *gc.C) {
+	_, volumeTag := s.provisionedVolume(c)
+	snapshot, err := s.State.AddVolumeSnapshot(volumeTag, state.VolumeSnapshotInfo{
+		SnapshotId: "snap-0",
+		Size:       2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	app, err := s.State.Application("storage-block")
+	c.Assert(err, jc.ErrorIsNil)
+	u, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddStorageFromSnapshot(u.UnitTag(), "allecto", snapshot.Id())
+	c.Assert(err, gc.ErrorMatches, `adding "allecto" storage to storage-block/1 from snapshot 0: unit "storage-block/1" is not assigned to a machine`)
+}
@@ -4,10 +4,12 @@
 package state
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -232,14 +234,17 @@ func (st *State) WatchModelFilesystems() StringsWatcher {
 func (st *State) watchModelMachinestorage(collection string) StringsWatcher {
 	pattern := fmt.Sprintf("^%s$", st.docID(names.NumberSnippet))
 	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
-	filter := func(id interface{}) bool {
+	return newLifecycleWatcher(st, collection, members, st.modelMachinestorageFilter(), nil)
+}
+
+func (st *State) modelMachinestorageFilter() func(interface{}) bool {
+	return func(id interface{}) bool {
 		k, err := st.strictLocalID(id.(string))
 		if err != nil {
 			return false
 		}
 		return !strings.Contains(k, "/")
 	}
-	return newLifecycleWatcher(st, collection, members, filter, nil)
 }
 
 // WatchMachineVolumes returns a StringsWatcher that notifies of changes to
@@ -257,34 +262,66 @@ func (st *State) WatchMachineFilesystems(m names.MachineTag) StringsWatcher {
 func (st *State) watchMachineStorage(m names.MachineTag, collection string) StringsWatcher {
 	pattern := fmt.Sprintf("^%s/%s$", st.docID(m.Id()), names.NumberSnippet)
 	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
+	return newLifecycleWatcher(st, collection, members, st.machineStorageFilter(m), nil)
+}
+
+func (st *State) machineStorageFilter(m names.MachineTag) func(interface{}) bool {
 	prefix := m.Id() + "/"
-	filter := func(id interface{}) bool {
+	return func(id interface{}) bool {
 		k, err := st.strictLocalID(id.(string))
 		if err != nil {
 			return false
 		}
 		return strings.HasPrefix(k, prefix)
 	}
-	return newLifecycleWatcher(st, collection, members, filter, nil)
+}
+
+// WatchModelVolumeResizes returns a StringsWatcher that notifies of
+// changes to all model-scoped volumes, so that pending resizes may be
+// identified. Unlike WatchModelVolumes, changes other than to the
+// lifecycle are reported.
+func (st *State) WatchModelVolumeResizes() StringsWatcher {
+	return newCollectionWatcher(st, colWCfg{
+		col:    volumesC,
+		filter: st.modelMachinestorageFilter(),
+	})
+}
+
+// WatchMachineVolumeResizes returns a StringsWatcher that notifies of
+// changes to all volumes scoped to the specified machine, so that
+// pending resizes may be identified.
+func (st *State) WatchMachineVolumeResizes(m names.MachineTag) StringsWatcher {
+	return newCollectionWatcher(st, colWCfg{
+		col:    volumesC,
+		filter: st.machineStorageFilter(m),
+	})
 }
 
 // WatchEnvironVolumeAttachments returns a StringsWatcher that notifies of
 // changes to the lifecycles of all volume attachments related to environ-
 // scoped volumes.
 func (st *State) WatchEnvironVolumeAttachments() StringsWatcher {
-	return st.watchModelMachinestorageAttachments(volumeAttachmentsC)
+	return st.watchModelMachinestorageAttachments(volumeAttachmentsC, nil)
 }
 
 // WatchEnvironFilesystemAttachments returns a StringsWatcher that notifies
 // of changes to the lifecycles of all filesystem attachments related to
-// environ-scoped filesystems.
+// environ-scoped filesystems. Attachments of shared filesystems are not
+// reported; they are handled by the attached machines' storage provisioners.
 func (st *State) WatchEnvironFilesystemAttachments() StringsWatcher {
-	return st.watchModelMachinestorageAttachments(filesystemAttachmentsC)
+	return st.watchModelMachinestorageAttachments(filesystemAttachmentsC, st.sharedFilesystemFunc())
 }
 
-func (st *State) watchModelMachinestorageAttachments(collection string) StringsWatcher {
-	pattern := fmt.Sprintf("^%s.*:%s$", st.docID(""), names.NumberSnippet)
-	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
+// watchModelMachinestorageAttachments returns a StringsWatcher that notifies
+// of changes to the lifecycles of all attachments related to environ-scoped
+// storage in the specified collection. If exclude is non-nil, then it is
+// used to exclude attachments of the storage with the given ID.
+func (st *State) watchModelMachinestorageAttachments(collection string, exclude func(string) bool) StringsWatcher {
+	var members bson.D
+	if exclude == nil {
+		pattern := fmt.Sprintf("^%s.*:%s$", st.docID(""), names.NumberSnippet)
+		members = bson.D{{"_id", bson.D{{"$regex", pattern}}}}
+	}
 	filter := func(id interface{}) bool {
 		k, err := st.strictLocalID(id.(string))
 		if err != nil {
@@ -294,7 +331,11 @@ func (st *State) watchModelMachinestorageAttachments(collection string) StringsW
 		if colon == -1 {
 			return false
 		}
-		return !strings.Contains(k[colon+1:], "/")
+		storageId := k[colon+1:]
+		if strings.Contains(storageId, "/") {
+			return false
+		}
+		return exclude == nil || !exclude(storageId)
 	}
 	return newLifecycleWatcher(st, collection, members, filter, nil)
 }
@@ -303,30 +344,68 @@ func (st *State) watchModelMachinestorageAttachments(collection string) StringsW
 // changes to the lifecycles of all volume attachments related to the specified
 // machine, for volumes scoped to the machine.
 func (st *State) WatchMachineVolumeAttachments(m names.MachineTag) StringsWatcher {
-	return st.watchMachineStorageAttachments(m, volumeAttachmentsC)
+	return st.watchMachineStorageAttachments(m, volumeAttachmentsC, nil)
 }
 
 // WatchMachineFilesystemAttachments returns a StringsWatcher that notifies of
 // changes to the lifecycles of all filesystem attachments related to the specified
-// machine, for filesystems scoped to the machine.
+// machine, for filesystems scoped to the machine, and for shared filesystems.
 func (st *State) WatchMachineFilesystemAttachments(m names.MachineTag) StringsWatcher {
-	return st.watchMachineStorageAttachments(m, filesystemAttachmentsC)
-}
-
-func (st *State) watchMachineStorageAttachments(m names.MachineTag, collection string) StringsWatcher {
-	pattern := fmt.Sprintf("^%s:%s/.*", st.docID(m.Id()), m.Id())
-	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
-	prefix := m.Id() + fmt.Sprintf(":%s/", m.Id())
+	return st.watchMachineStorageAttachments(m, filesystemAttachmentsC, st.sharedFilesystemFunc())
+}
+
+// watchMachineStorageAttachments returns a StringsWatcher that notifies of
+// changes to the lifecycles of all attachments related to the specified
+// machine in the specified collection, for storage scoped to the machine.
+// If include is non-nil, then it is used to include attachments of the
+// environ-scoped storage with the given ID.
+func (st *State) watchMachineStorageAttachments(m names.MachineTag, collection string, include func(string) bool) StringsWatcher {
+	var members bson.D
+	if include == nil {
+		pattern := fmt.Sprintf("^%s:%s/.*", st.docID(m.Id()), m.Id())
+		members = bson.D{{"_id", bson.D{{"$regex", pattern}}}}
+	}
+	attachmentPrefix := m.Id() + ":"
+	prefix := attachmentPrefix + m.Id() + "/"
 	filter := func(id interface{}) bool {
 		k, err := st.strictLocalID(id.(string))
 		if err != nil {
 			return false
 		}
-		return strings.HasPrefix(k, prefix)
+		if strings.HasPrefix(k, prefix) {
+			return true
+		}
+		if include == nil || !strings.HasPrefix(k, attachmentPrefix) {
+			return false
+		}
+		storageId := k[len(attachmentPrefix):]
+		return !strings.Contains(storageId, "/") && include(storageId)
 	}
 	return newLifecycleWatcher(st, collection, members, filter, nil)
 }
 
+// sharedFilesystemFunc returns a function that reports whether or not
+// the filesystem with the given ID is shared. Shared filesystems are
+// remembered, so that the attachments of a filesystem may still be
+// identified once the filesystem has been removed.
+func (st *State) sharedFilesystemFunc() func(string) bool {
+	var mu sync.Mutex
+	shared := make(set.Strings)
+	return func(filesystemId string) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		if shared.Contains(filesystemId) {
+			return true
+		}
+		f, err := st.filesystemByTag(names.NewFilesystemTag(filesystemId))
+		if err != nil || !f.shared() {
+			return false
+		}
+		shared.Add(filesystemId)
+		return true
+	}
+}
+
 // WatchServices returns a StringsWatcher that notifies of changes to
 // the lifecycles of the services in the model.
 func (st *State) WatchServices() StringsWatcher {
@@ -1755,6 +1834,81 @@ func (w *machineAddressesWatcher) loop() error {
 	}
 }
 
+// machineEgressRulesWatcher notifies about changes to the egress
+// rules to be enforced on a machine.
+//
+// The first event is emitted immediately. From then on, a new event is
+// emitted whenever the union of the egress policies of the applications
+// with units on the machine changes.
+type machineEgressRulesWatcher struct {
+	commonWatcher
+	machine *Machine
+	out     chan struct{}
+}
+
+var _ Watcher = (*machineEgressRulesWatcher)(nil)
+
+// WatchEgressRules returns a new NotifyWatcher watching the egress
+// rules to be enforced on m. See Machine.EgressRules.
+func (m *Machine) WatchEgressRules() NotifyWatcher {
+	w := &machineEgressRulesWatcher{
+		commonWatcher: newCommonWatcher(m.st),
+		out:           make(chan struct{}),
+		machine:       &Machine{st: m.st, doc: m.doc},
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for w.
+func (w *machineEgressRulesWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+func (w *machineEgressRulesWatcher) loop() error {
+	// The units assigned to the machine, and the egress policies of
+	// their applications, may change; watch both collections and
+	// compare the resulting rules.
+	in := make(chan watcher.Change)
+	filter := isLocalID(w.backend)
+	w.watcher.WatchCollectionWithFilter(unitsC, in, filter)
+	defer w.watcher.UnwatchCollection(unitsC, in)
+	w.watcher.WatchCollectionWithFilter(applicationsC, in, filter)
+	defer w.watcher.UnwatchCollection(applicationsC, in)
+
+	rules, err := w.machine.EgressRules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case change := <-in:
+			if _, ok := collect(change, in, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			newRules, err := w.machine.EgressRules()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if !reflect.DeepEqual(newRules, rules) {
+				rules = newRules
+				out = w.out
+			}
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
+
 // WatchCleanups starts and returns a CleanupWatcher.
 func (st *State) WatchCleanups() NotifyWatcher {
 	return newNotifyCollWatcher(st, cleanupsC, isLocalID(st))
@@ -2265,6 +2419,111 @@ func (st *State) WatchActionResultsFilteredBy(receivers ...ActionReceiver) Strin
 	return newActionStatusWatcher(st, receivers, []ActionStatus{ActionCompleted, ActionCancelled, ActionFailed}...)
 }
 
+// actionMessagesWatcher notifies of progress messages logged by an
+// action.
+type actionMessagesWatcher struct {
+	commonWatcher
+	actionId string
+	out      chan []string
+}
+
+var _ StringsWatcher = (*actionMessagesWatcher)(nil)
+
+// WatchActionMessages starts and returns a StringsWatcher that notifies
+// of the progress messages logged by the action with the given id. The
+// first event holds the messages logged so far, and each subsequent
+// event the messages logged since the previous one. Each message is
+// reported as a JSON-encoded params.ActionMessage.
+func (st *State) WatchActionMessages(actionId string) StringsWatcher {
+	return newActionMessagesWatcher(st, actionId)
+}
+
+func newActionMessagesWatcher(backend modelBackend, actionId string) StringsWatcher {
+	w := &actionMessagesWatcher{
+		commonWatcher: newCommonWatcher(backend),
+		actionId:      actionId,
+		out:           make(chan []string),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for w.
+func (w *actionMessagesWatcher) Changes() <-chan []string {
+	return w.out
+}
+
+func (w *actionMessagesWatcher) loop() error {
+	docID := w.backend.docID(w.actionId)
+	coll, closer := w.db.GetCollection(actionsC)
+	revno, err := getTxnRevno(coll, docID)
+	closer()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	in := make(chan watcher.Change)
+	w.watcher.Watch(actionsC, docID, revno, in)
+	defer w.watcher.Unwatch(actionsC, docID, in)
+
+	changes, err := w.messages(0)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sent := len(changes)
+	out := w.out
+	for {
+		select {
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-in:
+			messages, err := w.messages(sent)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if len(messages) > 0 {
+				changes = append(changes, messages...)
+				sent += len(messages)
+				out = w.out
+			}
+		case out <- changes:
+			changes = nil
+			out = nil
+		}
+	}
+}
+
+// messages returns the JSON-encoded progress messages of the action,
+// skipping the first skip messages.
+func (w *actionMessagesWatcher) messages(skip int) ([]string, error) {
+	coll, closer := w.db.GetCollection(actionsC)
+	defer closer()
+
+	var doc actionDoc
+	if err := coll.FindId(w.actionId).One(&doc); err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action %q", w.actionId)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	messages := []string{}
+	for i := skip; i < len(doc.Logs); i++ {
+		data, err := json.Marshal(params.ActionMessage{
+			Message:   doc.Logs[i].Message(),
+			Timestamp: doc.Logs[i].Timestamp(),
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		messages = append(messages, string(data))
+	}
+	return messages, nil
+}
+
 // openedPortsWatcher notifies of changes in the openedPorts
 // collection
 type openedPortsWatcher struct {
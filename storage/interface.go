@@ -81,6 +81,20 @@ type Provider interface {
 	ValidateConfig(*Config) error
 }
 
+// SharedFilesystemProvider is an optional interface that may be
+// implemented by a model-scoped Provider whose filesystems may be
+// attached to many machines concurrently, such as network filesystems.
+// Filesystems created by a shared filesystem provider are attached and
+// detached by the storage provisioner of each machine, rather than the
+// model's storage provisioner.
+type SharedFilesystemProvider interface {
+	Provider
+
+	// SharedFilesystems reports whether or not the provider's
+	// filesystems are shared.
+	SharedFilesystems() bool
+}
+
 // VolumeSource provides an interface for creating, destroying, describing,
 // attaching and detaching volumes in the environment. A VolumeSource is
 // configured in a particular way, and corresponds to a storage "pool".
@@ -127,6 +141,52 @@ type VolumeSource interface {
 	DetachVolumes(params []VolumeAttachmentParams) ([]error, error)
 }
 
+// VolumeSnapshotter is an optional interface that may be implemented by
+// a VolumeSource that supports taking point-in-time snapshots of its
+// volumes. A VolumeSource that implements VolumeSnapshotter must also
+// support creating volumes from its snapshots, by way of the SnapshotId
+// field of VolumeParams.
+type VolumeSnapshotter interface {
+	// CreateSnapshots creates snapshots of the volumes with the
+	// specified parameters.
+	CreateSnapshots(params []SnapshotParams) ([]CreateSnapshotsResult, error)
+
+	// ListSnapshots lists the provider snapshot IDs for every snapshot
+	// created by this volume source. The snapshots listed are deleted
+	// when the model is destroyed.
+	ListSnapshots() ([]string, error)
+
+	// DeleteSnapshots deletes the snapshots with the specified provider
+	// snapshot IDs.
+	DeleteSnapshots(snapshotIds []string) ([]error, error)
+}
+
+// VolumeResizer is an optional interface that may be implemented by a
+// VolumeSource that supports growing its volumes while they are in use.
+type VolumeResizer interface {
+	// ResizeVolumes grows the volumes with the specified parameters.
+	// Shrinking a volume is not supported.
+	//
+	// ResizeVolumes must be idempotent; it may be called even if the
+	// volume has already been resized.
+	ResizeVolumes(params []ResizeVolumeParams) ([]ResizeVolumesResult, error)
+}
+
+// VolumeImporter is an optional interface that may be implemented by a
+// VolumeSource that supports bringing existing volumes, created outside
+// of Juju, under the management of a model.
+type VolumeImporter interface {
+	// ImportVolume checks that the volume with the specified provider
+	// volume ID exists and may be managed by the model, and returns
+	// its details. The resource tags should be set on the volume, if
+	// the storage provider supports tags.
+	//
+	// A volume tagged as belonging to another model is refused, unless
+	// force is true; in that case the volume is imported anyway, and
+	// re-tagged if the storage provider supports it.
+	ImportVolume(volumeId string, resourceTags map[string]string, force bool) (VolumeInfo, error)
+}
+
 // FilesystemSource provides an interface for creating, destroying and
 // describing filesystems in the environment. A FilesystemSource is
 // configured in a particular way, and corresponds to a storage "pool".
@@ -160,6 +220,21 @@ type FilesystemSource interface {
 	DetachFilesystems(params []FilesystemAttachmentParams) ([]error, error)
 }
 
+// FilesystemImporter is an optional interface that may be implemented
+// by a FilesystemSource that supports bringing existing filesystems,
+// created outside of Juju, under the management of a model.
+type FilesystemImporter interface {
+	// ImportFilesystem checks that the filesystem with the specified
+	// provider filesystem ID exists and may be managed by the model,
+	// and returns its details. The resource tags should be set on the
+	// filesystem, if the storage provider supports tags.
+	//
+	// A filesystem tagged as belonging to another model is refused,
+	// unless force is true; in that case the filesystem is imported
+	// anyway, and re-tagged if the storage provider supports it.
+	ImportFilesystem(filesystemId string, resourceTags map[string]string, force bool) (FilesystemInfo, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -194,6 +269,53 @@ type VolumeParams struct {
 	// once the instance is created there are still unprovisioned volumes,
 	// the dynamic storage provisioner will take care of creating them.
 	Attachment *VolumeAttachmentParams
+
+	// SnapshotId is the provider-supplied ID of the snapshot from which
+	// the volume should be created, or empty if the volume should be
+	// created empty. A non-empty SnapshotId is only valid for volume
+	// sources that implement VolumeSnapshotter.
+	SnapshotId string
+}
+
+// SnapshotParams is a set of parameters for creating a volume snapshot.
+type SnapshotParams struct {
+	// Volume is the tag of the volume to snapshot.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume to
+	// snapshot.
+	VolumeId string
+
+	// ResourceTags is a set of tags to set on the created snapshot, if
+	// the storage provider supports tags.
+	ResourceTags map[string]string
+}
+
+// Snapshot describes a volume snapshot created by a VolumeSnapshotter.
+type Snapshot struct {
+	// SnapshotId is the unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// Volume is the tag of the volume that the snapshot was taken of.
+	Volume names.VolumeTag
+
+	// Size is the size of the snapshotted volume in MiB. A volume
+	// created from the snapshot must be at least this large.
+	Size uint64
+}
+
+// ResizeVolumeParams is a set of parameters for growing a volume.
+type ResizeVolumeParams struct {
+	// Volume is the tag of the volume to resize.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume to
+	// resize.
+	VolumeId string
+
+	// Size is the requested size of the volume in MiB. The resized
+	// volume may be larger, if the provider rounds sizes up.
+	Size uint64
 }
 
 // VolumeAttachmentParams is a set of parameters for volume attachment or
@@ -285,6 +407,22 @@ type CreateVolumesResult struct {
 	Error            error
 }
 
+// CreateSnapshotsResult contains the result of a
+// VolumeSnapshotter.CreateSnapshots call for one snapshot. Snapshot
+// should only be used if Error is nil.
+type CreateSnapshotsResult struct {
+	Snapshot *Snapshot
+	Error    error
+}
+
+// ResizeVolumesResult contains the result of a VolumeResizer.ResizeVolumes
+// call for one volume. Size should only be used if Error is nil.
+type ResizeVolumesResult struct {
+	// Size is the size of the resized volume in MiB.
+	Size  uint64
+	Error error
+}
+
 // DescribeVolumesResult contains the result of a VolumeSource.DescribeVolumes call
 // for one volume. Volume should only be used if Error is nil.
 type DescribeVolumesResult struct {
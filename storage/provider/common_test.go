@@ -31,6 +31,7 @@ func (s *providerCommonSuite) TestCommonProvidersExported(c *gc.C) {
 	}
 	c.Assert(common, jc.SameContents, []storage.ProviderType{
 		provider.LoopProviderType,
+		provider.NFSProviderType,
 		provider.RootfsProviderType,
 		provider.TmpfsProviderType,
 	})
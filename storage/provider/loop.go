@@ -98,6 +98,7 @@ type loopVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*loopVolumeSource)(nil)
+var _ storage.VolumeResizer = (*loopVolumeSource)(nil)
 
 // CreateVolumes is defined on the VolumeSource interface.
 func (lvs *loopVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -209,6 +210,37 @@ func (lvs *loopVolumeSource) attachVolume(arg storage.VolumeAttachmentParams) (*
 	}, nil
 }
 
+// ResizeVolumes is defined on the VolumeResizer interface.
+func (lvs *loopVolumeSource) ResizeVolumes(args []storage.ResizeVolumeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		if err := lvs.resizeVolume(arg); err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %s", arg.Volume.Id())
+			continue
+		}
+		results[i].Size = arg.Size
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) resizeVolume(arg storage.ResizeVolumeParams) error {
+	loopFilePath := lvs.volumeFilePath(arg.Volume)
+	// fallocate extends the file, leaving existing content intact.
+	if err := createBlockFile(lvs.run, loopFilePath, arg.Size); err != nil {
+		return errors.Annotate(err, "could not grow block file")
+	}
+	deviceNames, err := associatedLoopDevices(lvs.run, loopFilePath)
+	if err != nil {
+		return errors.Annotate(err, "locating loop device")
+	}
+	for _, deviceName := range deviceNames {
+		if err := refreshLoopDeviceSize(lvs.run, deviceName); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 // DetachVolumes is defined on the VolumeSource interface.
 func (lvs *loopVolumeSource) DetachVolumes(args []storage.VolumeAttachmentParams) ([]error, error) {
 	results := make([]error, len(args))
@@ -278,6 +310,16 @@ func attachLoopDevice(run runCommandFunc, filePath string, readOnly bool) (loopD
 	return loopDeviceName, nil
 }
 
+// refreshLoopDeviceSize updates the size of the loop device with the
+// specified name to match the size of its backing file.
+func refreshLoopDeviceSize(run runCommandFunc, deviceName string) error {
+	_, err := run("losetup", "-c", path.Join("/dev", deviceName))
+	if err != nil {
+		return errors.Annotatef(err, "refreshing size of loop device %q", deviceName)
+	}
+	return nil
+}
+
 // detachLoopDevice detaches the loop device with the specified name.
 func detachLoopDevice(run runCommandFunc, deviceName string) error {
 	_, err := run("losetup", "-d", path.Join("/dev", deviceName))
@@ -245,6 +245,39 @@ func (s *loopSuite) TestDetachVolumes(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *loopSuite) TestResizeVolumes(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	s.commands.expect("fallocate", "-l", "2048MiB", fileName)
+	cmd := s.commands.expect("losetup", "-j", fileName)
+	cmd.respond("/dev/loop0: foo\n", nil)
+	s.commands.expect("losetup", "-c", "/dev/loop0")
+
+	results, err := source.(storage.VolumeResizer).ResizeVolumes([]storage.ResizeVolumeParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 2048}})
+}
+
+func (s *loopSuite) TestResizeVolumesFallocateFails(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	cmd := s.commands.expect("fallocate", "-l", "2048MiB", fileName)
+	cmd.respond("", errors.New("no space left on device"))
+
+	results, err := source.(storage.VolumeResizer).ResizeVolumes([]storage.ResizeVolumeParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `resizing volume 0: could not grow block file: allocating loop backing file ".*": no space left on device`)
+}
+
 func (s *loopSuite) TestDetachVolumesDetachFails(c *gc.C) {
 	source, _ := s.loopVolumeSource(c)
 	fileName := filepath.Join(s.storageDir, "volume-0")
@@ -0,0 +1,246 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/storage"
+)
+
+const (
+	NFSProviderType = storage.ProviderType("nfs")
+
+	// NFSServer is the pool config attribute that specifies
+	// the host name or address of the NFS server.
+	NFSServer = "server"
+
+	// NFSPath is the pool config attribute that specifies
+	// the absolute path of the export on the NFS server.
+	NFSPath = "path"
+)
+
+// nfsProvider creates storage sources which provide access to
+// directories exported by an existing NFS server. Each filesystem
+// is shared: it may be mounted by many machines concurrently.
+//
+// Every filesystem created from a pool is backed by the pool's
+// export, so all storage instances drawn from the same pool see
+// the same files; separate pools must be created to give storage
+// instances separate exports.
+type nfsProvider struct {
+	// run is a function type used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var (
+	_ storage.Provider                 = (*nfsProvider)(nil)
+	_ storage.SharedFilesystemProvider = (*nfsProvider)(nil)
+)
+
+// ValidateConfig is defined on the Provider interface.
+func (p *nfsProvider) ValidateConfig(cfg *storage.Config) error {
+	server, _ := cfg.ValueString(NFSServer)
+	if server == "" {
+		return errors.Errorf("%q must be specified", NFSServer)
+	}
+	exportPath, _ := cfg.ValueString(NFSPath)
+	if exportPath == "" {
+		return errors.Errorf("%q must be specified", NFSPath)
+	}
+	if !path.IsAbs(exportPath) {
+		return errors.Errorf("%q must be an absolute path, got %q", NFSPath, exportPath)
+	}
+	return nil
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *nfsProvider) VolumeSource(providerConfig *storage.Config) (storage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the Provider interface.
+//
+// The filesystem source is obtained with the pool config by the model's
+// storage provisioner, and without it by the machine storage provisioners;
+// the NFS export is recorded in the filesystem ID, so attaching filesystems
+// requires no configuration.
+func (p *nfsProvider) FilesystemSource(sourceConfig *storage.Config) (storage.FilesystemSource, error) {
+	return &nfsFilesystemSource{
+		&osDirFuncs{p.run},
+		p.run,
+	}, nil
+}
+
+// Supports is defined on the Provider interface.
+func (*nfsProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem
+}
+
+// Scope is defined on the Provider interface.
+func (*nfsProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is defined on the Provider interface.
+func (*nfsProvider) Dynamic() bool {
+	return true
+}
+
+// DefaultPools is defined on the Provider interface.
+func (*nfsProvider) DefaultPools() []*storage.Config {
+	// The NFS server must be specified, so there are no default pools.
+	return nil
+}
+
+// SharedFilesystems is defined on the SharedFilesystemProvider interface.
+func (*nfsProvider) SharedFilesystems() bool {
+	return true
+}
+
+type nfsFilesystemSource struct {
+	dirFuncs dirFuncs
+	run      runCommandFunc
+}
+
+var _ storage.FilesystemSource = (*nfsFilesystemSource)(nil)
+
+// ValidateFilesystemParams is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
+	_, err := nfsExport(params)
+	return errors.Trace(err)
+}
+
+// CreateFilesystems is defined on the FilesystemSource interface.
+//
+// The export must already exist on the NFS server; creating a
+// filesystem just records the export in the filesystem ID.
+func (s *nfsFilesystemSource) CreateFilesystems(args []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
+	results := make([]storage.CreateFilesystemsResult, len(args))
+	for i, arg := range args {
+		export, err := nfsExport(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Filesystem = &storage.Filesystem{
+			Tag:    arg.Tag,
+			Volume: arg.Volume,
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: export,
+				Size:         arg.Size,
+			},
+		}
+	}
+	return results, nil
+}
+
+// DestroyFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) DestroyFilesystems(filesystemIds []string) ([]error, error) {
+	// DestroyFilesystems is a no-op; the export is managed outside
+	// of Juju, and its contents are left intact.
+	return make([]error, len(filesystemIds)), nil
+}
+
+// AttachFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) AttachFilesystems(args []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
+	results := make([]storage.AttachFilesystemsResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].FilesystemAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *nfsFilesystemSource) attachFilesystem(arg storage.FilesystemAttachmentParams) (*storage.FilesystemAttachment, error) {
+	mountPoint := arg.Path
+	if mountPoint == "" {
+		return nil, errNoMountPoint
+	}
+	if arg.FilesystemId == "" {
+		return nil, errors.Errorf("%s has no NFS export", arg.Filesystem.String())
+	}
+	if err := ensureDir(s.dirFuncs, mountPoint); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Check if the export is already mounted.
+	source, err := s.dirFuncs.mountPointSource(mountPoint)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if source != arg.FilesystemId {
+		if err := ensureNFSClient(s.run); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := ensureEmptyDir(s.dirFuncs, mountPoint); err != nil {
+			return nil, err
+		}
+		args := []string{"-t", "nfs", arg.FilesystemId, mountPoint}
+		if arg.ReadOnly {
+			args = append(args, "-o", "ro")
+		}
+		if _, err := s.run("mount", args...); err != nil {
+			os.Remove(mountPoint)
+			return nil, errors.Annotatef(err, "cannot mount %s", arg.FilesystemId)
+		}
+	}
+
+	return &storage.FilesystemAttachment{
+		arg.Filesystem,
+		arg.Machine,
+		storage.FilesystemAttachmentInfo{
+			Path:     mountPoint,
+			ReadOnly: arg.ReadOnly,
+		},
+	}, nil
+}
+
+// DetachFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) DetachFilesystems(args []storage.FilesystemAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		if err := maybeUnmount(s.run, s.dirFuncs, arg.Path); err != nil {
+			results[i] = err
+		}
+	}
+	return results, nil
+}
+
+// ensureNFSClient returns an error if the NFS mount helper is not
+// installed on the machine, as "mount -t nfs" would otherwise fail
+// with an unhelpful error.
+func ensureNFSClient(run runCommandFunc) error {
+	if _, err := run("which", "mount.nfs"); err != nil {
+		return errors.New(`cannot mount NFS exports: "mount.nfs" not found, install the nfs-common package`)
+	}
+	return nil
+}
+
+// nfsExport returns the NFS export, in the form "server:/path",
+// specified by the filesystem parameters' pool attributes.
+func nfsExport(params storage.FilesystemParams) (string, error) {
+	server, _ := params.Attributes[NFSServer].(string)
+	if server == "" {
+		return "", errors.Errorf("%q must be specified", NFSServer)
+	}
+	exportPath, _ := params.Attributes[NFSPath].(string)
+	if !path.IsAbs(exportPath) {
+		return "", errors.Errorf("%q must be an absolute path, got %q", NFSPath, exportPath)
+	}
+	if strings.Contains(server, ":") {
+		// IPv6 addresses must be enclosed in brackets.
+		server = "[" + strings.Trim(server, "[]") + "]"
+	}
+	return fmt.Sprintf("%s:%s", server, exportPath), nil
+}
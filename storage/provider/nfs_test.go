@@ -0,0 +1,235 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"errors"
+	"runtime"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&nfsSuite{})
+
+type nfsSuite struct {
+	testing.BaseSuite
+	commands *mockRunCommand
+}
+
+func (s *nfsSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("Tests relevant only on *nix systems")
+	}
+	s.BaseSuite.SetUpTest(c)
+}
+
+func (s *nfsSuite) TearDownTest(c *gc.C) {
+	if s.commands != nil {
+		s.commands.assertDrained()
+	}
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *nfsSuite) nfsProvider(c *gc.C) storage.Provider {
+	s.commands = &mockRunCommand{c: c}
+	return provider.NFSProvider(s.commands.run)
+}
+
+func (s *nfsSuite) nfsFilesystemSource(c *gc.C) (storage.FilesystemSource, *provider.MockDirFuncs) {
+	s.commands = &mockRunCommand{c: c}
+	return provider.NFSFilesystemSource(s.commands.run)
+}
+
+func (s *nfsSuite) TestValidateConfig(c *gc.C) {
+	p := s.nfsProvider(c)
+	for _, test := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{"path": "/srv/data"},
+		err:   `"server" must be specified`,
+	}, {
+		attrs: map[string]interface{}{"server": "10.0.0.1"},
+		err:   `"path" must be specified`,
+	}, {
+		attrs: map[string]interface{}{"server": "10.0.0.1", "path": "srv/data"},
+		err:   `"path" must be an absolute path, got "srv/data"`,
+	}, {
+		attrs: map[string]interface{}{"server": "10.0.0.1", "path": "/srv/data"},
+	}} {
+		cfg, err := storage.NewConfig("name", provider.NFSProviderType, test.attrs)
+		c.Assert(err, jc.ErrorIsNil)
+		err = p.ValidateConfig(cfg)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *nfsSuite) TestSupports(c *gc.C) {
+	p := s.nfsProvider(c)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsFalse)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+}
+
+func (s *nfsSuite) TestScope(c *gc.C) {
+	p := s.nfsProvider(c)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeEnviron)
+	c.Assert(p.Dynamic(), jc.IsTrue)
+}
+
+func (s *nfsSuite) TestSharedFilesystems(c *gc.C) {
+	p := s.nfsProvider(c)
+	shared, ok := p.(storage.SharedFilesystemProvider)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(shared.SharedFilesystems(), jc.IsTrue)
+}
+
+func (s *nfsSuite) TestCreateFilesystems(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("0"),
+		Size: 1024,
+		Attributes: map[string]interface{}{
+			"server": "10.0.0.1",
+			"path":   "/srv/data",
+		},
+	}, {
+		Tag:  names.NewFilesystemTag("1"),
+		Size: 1024,
+		Attributes: map[string]interface{}{
+			"server": "2001:db8::1",
+			"path":   "/srv/data",
+		},
+	}, {
+		Tag:        names.NewFilesystemTag("2"),
+		Size:       1024,
+		Attributes: map[string]interface{}{"path": "/srv/data"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0], jc.DeepEquals, storage.CreateFilesystemsResult{
+		Filesystem: &storage.Filesystem{
+			Tag: names.NewFilesystemTag("0"),
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: "10.0.0.1:/srv/data",
+				Size:         1024,
+			},
+		},
+	})
+	c.Assert(results[1].Error, jc.ErrorIsNil)
+	c.Assert(results[1].Filesystem.FilesystemId, gc.Equals, "[2001:db8::1]:/srv/data")
+	c.Assert(results[2].Error, gc.ErrorMatches, `"server" must be specified`)
+}
+
+func (s *nfsSuite) TestAttachFilesystems(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=source", "/var/lib/juju/storage/data/0")
+	cmd.respond("header\n/dev/sda1", nil)
+	s.commands.expect("which", "mount.nfs")
+	s.commands.expect("mount", "-t", "nfs", "10.0.0.1:/srv/data", "/var/lib/juju/storage/data/0", "-o", "ro")
+
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "10.0.0.1:/srv/data",
+		Path:         "/var/lib/juju/storage/data/0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:  names.NewMachineTag("2"),
+			ReadOnly: true,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			Filesystem: names.NewFilesystemTag("0"),
+			Machine:    names.NewMachineTag("2"),
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+				Path:     "/var/lib/juju/storage/data/0",
+				ReadOnly: true,
+			},
+		},
+	}})
+}
+
+func (s *nfsSuite) TestAttachFilesystemsAlreadyMounted(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=source", "exists")
+	cmd.respond("header\n10.0.0.1:/srv/data", nil)
+
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "10.0.0.1:/srv/data",
+		Path:         "exists",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			Filesystem: names.NewFilesystemTag("0"),
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+				Path: "exists",
+			},
+		},
+	}})
+}
+
+func (s *nfsSuite) TestAttachFilesystemsMountFails(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=source", "/var/lib/juju/storage/data/0")
+	cmd.respond("header\n/dev/sda1", nil)
+	s.commands.expect("which", "mount.nfs")
+	cmd = s.commands.expect("mount", "-t", "nfs", "10.0.0.1:/srv/data", "/var/lib/juju/storage/data/0")
+	cmd.respond("", errors.New("access denied"))
+
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "10.0.0.1:/srv/data",
+		Path:         "/var/lib/juju/storage/data/0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, "cannot mount 10.0.0.1:/srv/data: access denied")
+}
+
+func (s *nfsSuite) TestAttachFilesystemsNoNFSClient(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=source", "/var/lib/juju/storage/data/0")
+	cmd.respond("header\n/dev/sda1", nil)
+	cmd = s.commands.expect("which", "mount.nfs")
+	cmd.respond("", errors.New("exit status 1"))
+
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "10.0.0.1:/srv/data",
+		Path:         "/var/lib/juju/storage/data/0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, `cannot mount NFS exports: "mount.nfs" not found, install the nfs-common package`)
+}
+
+func (s *nfsSuite) TestAttachFilesystemsNoExport(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("0"),
+		Path:       "/var/lib/juju/storage/data/0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, "filesystem-0 has no NFS export")
+}
+
+func (s *nfsSuite) TestDetachFilesystems(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	testDetachFilesystems(c, s.commands, source, true)
+}
+
+func (s *nfsSuite) TestDetachFilesystemsUnattached(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	testDetachFilesystems(c, s.commands, source, false)
+}
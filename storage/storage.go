@@ -47,4 +47,9 @@ type StorageAttachmentInfo struct {
 	// for a filesystem-kind storage attachment, and the device path
 	// for a block-kind.
 	Location string
+
+	// Size is the size of the storage attachment in MiB, as seen
+	// by the machine it is attached to. Size may be zero if the
+	// size is not known.
+	Size uint64
 }
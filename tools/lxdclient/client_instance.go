@@ -317,6 +317,16 @@ func (client *instanceClient) Addresses(name string) ([]network.Address, error)
 	return addrs, nil
 }
 
+// InstanceState returns the runtime state of the instance,
+// including the state of its network interfaces.
+func (client *instanceClient) InstanceState(name string) (*api.ContainerState, error) {
+	state, err := client.raw.ContainerState(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return state, nil
+}
+
 // AttachDisk attaches a disk to an instance.
 func (client *instanceClient) AttachDisk(instanceName, deviceName string, disk DiskDevice) error {
 	props := []string{"path=" + disk.Path, "source=" + disk.Source}
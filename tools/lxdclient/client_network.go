@@ -17,6 +17,7 @@ import (
 type rawNetworkClient interface {
 	NetworkCreate(name string, config map[string]string) error
 	NetworkGet(name string) (api.Network, error)
+	ListNetworks() ([]api.Network, error)
 }
 
 type networkClient struct {
@@ -42,27 +43,28 @@ func (c *networkClient) NetworkGet(name string) (api.Network, error) {
 	return c.raw.NetworkGet(name)
 }
 
+// NetworkList returns the configuration of all of the networks
+// known to LXD, including networks not managed by LXD.
+func (c *networkClient) NetworkList() ([]api.Network, error) {
+	if !c.supported {
+		return nil, errors.NotSupportedf("network API not supported on this remote")
+	}
+
+	return c.raw.ListNetworks()
+}
+
 type creator interface {
 	rawNetworkClient
 	ProfileDeviceAdd(profile, devname, devtype string, props []string) (*api.Response, error)
 	ProfileConfig(profile string) (*api.Profile, error)
 }
 
+// checkBridgeConfig checks that the bridge exists. Bridges with IPv6
+// addresses are accepted, since containers may be IPv6-only or
+// dual-stack.
 func checkBridgeConfig(client rawNetworkClient, bridge string) error {
-	n, err := client.NetworkGet(bridge)
-	if err != nil {
-		return err
-	}
-	ipv6AddressConfig := n.Config["ipv6.address"]
-	if n.Managed && ipv6AddressConfig != "none" && ipv6AddressConfig != "" {
-		return errors.Errorf(`juju doesn't support ipv6. Please disable LXD's IPV6:
-
-	$ lxc network set %s ipv6.address none
-
-and rebootstrap`, bridge)
-	}
-
-	return nil
+	_, err := client.NetworkGet(bridge)
+	return err
 }
 
 // CreateDefaultBridgeInDefaultProfile creates a default bridge if it doesn't
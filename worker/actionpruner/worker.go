@@ -0,0 +1,283 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionpruner
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/actionpruner"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/watcher"
+	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.actionpruner")
+
+// Facade represents an API that implements action pruning.
+type Facade interface {
+	ActionsToPrune(time.Duration, int) ([]params.ActionResult, error)
+	RemoveActions([]names.ActionTag) error
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+	ModelConfig() (*config.Config, error)
+}
+
+// Config holds all necessary attributes to start a pruner worker.
+type Config struct {
+	Facade        Facade
+	PruneInterval time.Duration
+	// ArchiveDir is the directory to which the results of pruned
+	// actions are written, when the model is configured to archive
+	// them. The archive files are pruned to the same age and size
+	// as the actions collection.
+	ArchiveDir string
+	Clock      clock.Clock
+	NewTimer   jworker.NewTimerFunc
+}
+
+// Validate will err unless basic requirements for a valid
+// config are met.
+func (c *Config) Validate() error {
+	if c.Facade == nil {
+		return errors.New("missing Facade")
+	}
+	if c.ArchiveDir == "" {
+		return errors.New("missing ArchiveDir")
+	}
+	if c.Clock == nil {
+		return errors.New("missing Clock")
+	}
+	if c.NewTimer == nil {
+		return errors.New("missing Timer")
+	}
+	return nil
+}
+
+// New returns a worker.Worker that prunes finished actions.
+func New(conf Config) (worker.Worker, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	w := &Worker{
+		config: conf,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, errors.Trace(err)
+}
+
+// NewFacade returns a new action pruner facade.
+func NewFacade(caller base.APICaller) Facade {
+	return actionpruner.NewFacade(caller)
+}
+
+// Worker prunes finished actions at regular intervals, archiving
+// their results first if the model is configured to do so.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is defined on worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is defined on worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	modelConfigWatcher, err := w.config.Facade.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = w.catacomb.Add(modelConfigWatcher)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		maxAge             time.Duration
+		maxCollectionMB    uint
+		archive            bool
+		modelConfigChanges = modelConfigWatcher.Changes()
+		// We will also get an initial event, but need to ensure that event is
+		// received before doing any pruning.
+		haveConfig = false
+	)
+
+	timer := w.config.NewTimer(0)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-modelConfigChanges:
+			if !ok {
+				return errors.New("model configuration watcher closed")
+			}
+			modelConfig, err := w.config.Facade.ModelConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot load model configuration")
+			}
+			haveConfig = true
+			newMaxAge := modelConfig.MaxActionResultsAge()
+			newMaxCollectionMB := modelConfig.MaxActionResultsSizeMB()
+			newArchive := modelConfig.ArchiveActionResults()
+			if newMaxAge != maxAge || newMaxCollectionMB != maxCollectionMB || newArchive != archive {
+				logger.Infof("action results config: max age: %v, max collection size %dM, archive %v for %s (%s)",
+					newMaxAge, newMaxCollectionMB, newArchive, modelConfig.Name(), modelConfig.UUID())
+				maxAge = newMaxAge
+				maxCollectionMB = newMaxCollectionMB
+				archive = newArchive
+			}
+			continue
+		case <-timer.CountDown():
+			if !haveConfig {
+				continue
+			}
+			if err := w.prune(maxAge, int(maxCollectionMB), archive); err != nil {
+				return errors.Trace(err)
+			}
+			timer.Reset(w.config.PruneInterval)
+		}
+	}
+}
+
+// prune removes the actions that no longer fit within the configured
+// age and size, having first archived them if requested, and then
+// removes the archive files that no longer fit within them either.
+func (w *Worker) prune(maxAge time.Duration, maxCollectionMB int, archive bool) error {
+	if err := w.pruneActions(maxAge, maxCollectionMB, archive); err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.pruneArchive(maxAge, maxCollectionMB); err != nil {
+		return errors.Annotate(err, "cannot prune action results archive")
+	}
+	return nil
+}
+
+func (w *Worker) pruneActions(maxAge time.Duration, maxCollectionMB int, archive bool) error {
+	results, err := w.config.Facade.ActionsToPrune(maxAge, maxCollectionMB)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	tags := make([]names.ActionTag, 0, len(results))
+	for _, result := range results {
+		if result.Action == nil {
+			continue
+		}
+		tag, err := names.ParseActionTag(result.Action.Tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		tags = append(tags, tag)
+	}
+	if archive {
+		if err := w.archive(results); err != nil {
+			return errors.Annotate(err, "cannot archive action results")
+		}
+	}
+	if err := w.config.Facade.RemoveActions(tags); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Debugf("pruned %d actions", len(tags))
+	return nil
+}
+
+// archive writes the results of the actions to a new file in the
+// archive directory.
+func (w *Worker) archive(results []params.ActionResult) error {
+	if err := os.MkdirAll(w.config.ArchiveDir, 0700); err != nil {
+		return errors.Trace(err)
+	}
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	now := w.config.Clock.Now().UTC()
+	filename := filepath.Join(w.config.ArchiveDir, archivePrefix+now.Format(archiveTimeFormat)+archiveSuffix)
+	return errors.Trace(utils.AtomicWriteFile(filename, data, 0600))
+}
+
+const (
+	archivePrefix     = "actions-"
+	archiveSuffix     = ".json"
+	archiveTimeFormat = "20060102T150405.000000000Z"
+)
+
+// pruneArchive removes the archive files written longer than maxAge
+// ago, and then the oldest archive files until the archive directory
+// is no larger than maxSizeMB. A zero maxAge or maxSizeMB is ignored.
+func (w *Worker) pruneArchive(maxAge time.Duration, maxSizeMB int) error {
+	infos, err := ioutil.ReadDir(w.config.ArchiveDir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+
+	// ReadDir sorts the files by name, and so the archive files
+	// from oldest to newest.
+	type archiveFile struct {
+		name    string
+		written time.Time
+		size    int64
+	}
+	var files []archiveFile
+	var totalSize int64
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !strings.HasPrefix(name, archivePrefix) || !strings.HasSuffix(name, archiveSuffix) {
+			continue
+		}
+		written, err := time.Parse(archiveTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, archivePrefix), archiveSuffix))
+		if err != nil {
+			continue
+		}
+		files = append(files, archiveFile{name, written, info.Size()})
+		totalSize += info.Size()
+	}
+
+	now := w.config.Clock.Now()
+	maxSize := int64(maxSizeMB) * 1024 * 1024
+	var removed int
+	for _, file := range files {
+		expired := maxAge > 0 && now.Sub(file.written) > maxAge
+		oversized := maxSizeMB > 0 && totalSize > maxSize
+		if !expired && !oversized {
+			break
+		}
+		if err := os.Remove(filepath.Join(w.config.ArchiveDir, file.name)); err != nil && !os.IsNotExist(err) {
+			return errors.Trace(err)
+		}
+		totalSize -= file.size
+		removed++
+	}
+	if removed > 0 {
+		logger.Debugf("removed %d action results archive files", removed)
+	}
+	return nil
+}
@@ -0,0 +1,315 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionpruner_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/actionpruner"
+)
+
+const (
+	actionId1 = "2e1ba5a6-bc5b-4aa8-8e5f-0a0a9ab19e2b"
+	actionId2 = "7d5c0c4f-6b2e-4b1e-9d5f-3b8c4e2d1a0f"
+)
+
+type actionPrunerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&actionPrunerSuite{})
+
+func (s *actionPrunerSuite) setupPruner(c *gc.C, archive bool) (*fakeFacade, *mockTimer, string) {
+	fakeTimer := newMockTimer()
+
+	fakeTimerFunc := func(d time.Duration) jworker.PeriodicTimer {
+		// construction of timer should be with 0 because we intend it to
+		// run once before waiting.
+		c.Assert(d, gc.Equals, 0*time.Nanosecond)
+		return fakeTimer
+	}
+	facade := newFakeFacade()
+	attrs := coretesting.FakeConfig()
+	attrs["max-action-results-age"] = "1h"
+	attrs["max-action-results-size"] = "3M"
+	attrs["archive-action-results"] = archive
+	cfg, err := config.New(config.UseDefaults, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	facade.modelConfig = cfg
+	facade.toPrune = []params.ActionResult{{
+		Action: &params.Action{Tag: names.NewActionTag(actionId1).String(), Receiver: "unit-mysql-0", Name: "backup"},
+		Status: params.ActionCompleted,
+		Output: map[string]interface{}{"path": "/tmp/backup"},
+	}, {
+		Action:  &params.Action{Tag: names.NewActionTag(actionId2).String(), Receiver: "unit-mysql-1", Name: "backup"},
+		Status:  params.ActionFailed,
+		Message: "disk full",
+	}}
+
+	archiveDir := filepath.Join(c.MkDir(), "action-archive")
+	conf := actionpruner.Config{
+		Facade:        facade,
+		PruneInterval: coretesting.ShortWait,
+		ArchiveDir:    archiveDir,
+		Clock:         testing.NewClock(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)),
+		NewTimer:      fakeTimerFunc,
+	}
+
+	pruner, err := actionpruner.New(conf)
+	c.Check(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) {
+		c.Assert(worker.Stop(pruner), jc.ErrorIsNil)
+	})
+
+	facade.changesWatcher.changes <- struct{}{}
+	select {
+	case <-facade.gotConfig:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for model config")
+	}
+	return facade, fakeTimer, archiveDir
+}
+
+func (s *actionPrunerSuite) assertWorkerPrunes(c *gc.C, facade *fakeFacade, fakeTimer *mockTimer) {
+	err := fakeTimer.fire()
+	c.Check(err, jc.ErrorIsNil)
+
+	var removed []names.ActionTag
+	select {
+	case removed = <-facade.removed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for actions to be removed")
+	}
+	c.Assert(removed, jc.DeepEquals, []names.ActionTag{
+		names.NewActionTag(actionId1),
+		names.NewActionTag(actionId2),
+	})
+	facade.mu.Lock()
+	c.Check(facade.maxHistoryTime, gc.Equals, time.Hour)
+	c.Check(facade.maxHistoryMB, gc.Equals, 3)
+	facade.mu.Unlock()
+
+	// Reset will have been called with the actual PruneInterval
+	var period time.Duration
+	select {
+	case period = <-fakeTimer.period:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for period reset by pruner")
+	}
+	c.Assert(period, gc.Equals, coretesting.ShortWait)
+}
+
+func (s *actionPrunerSuite) TestWorkerPrunes(c *gc.C) {
+	facade, fakeTimer, archiveDir := s.setupPruner(c, false)
+	s.assertWorkerPrunes(c, facade, fakeTimer)
+
+	_, err := ioutil.ReadDir(archiveDir)
+	c.Assert(err, gc.ErrorMatches, ".*no such file or directory")
+}
+
+func (s *actionPrunerSuite) TestWorkerArchivesBeforePruning(c *gc.C) {
+	facade, fakeTimer, archiveDir := s.setupPruner(c, true)
+	s.assertWorkerPrunes(c, facade, fakeTimer)
+
+	data, err := ioutil.ReadFile(filepath.Join(archiveDir, "actions-20170601T120000.000000000Z.json"))
+	c.Assert(err, jc.ErrorIsNil)
+	var archived []params.ActionResult
+	err = json.Unmarshal(data, &archived)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(archived, gc.HasLen, 2)
+	c.Check(archived[0].Action.Tag, gc.Equals, names.NewActionTag(actionId1).String())
+	c.Check(archived[0].Output, jc.DeepEquals, map[string]interface{}{"path": "/tmp/backup"})
+	c.Check(archived[1].Action.Tag, gc.Equals, names.NewActionTag(actionId2).String())
+	c.Check(archived[1].Message, gc.Equals, "disk full")
+}
+
+func (s *actionPrunerSuite) TestWorkerPrunesArchive(c *gc.C) {
+	facade, fakeTimer, archiveDir := s.setupPruner(c, true)
+	err := os.MkdirAll(archiveDir, 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	for name, size := range map[string]int{
+		// Older than max-action-results-age.
+		"actions-20170601T105959.000000000Z.json": 1,
+		// Within max-action-results-age, but beyond
+		// max-action-results-size.
+		"actions-20170601T113000.000000000Z.json": 3 * 1024 * 1024,
+		"actions-20170601T115000.000000000Z.json": 1,
+		"unrelated.txt": 1,
+	} {
+		err := ioutil.WriteFile(filepath.Join(archiveDir, name), make([]byte, size), 0600)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	s.assertWorkerPrunes(c, facade, fakeTimer)
+
+	infos, err := ioutil.ReadDir(archiveDir)
+	c.Assert(err, jc.ErrorIsNil)
+	var files []string
+	for _, info := range infos {
+		files = append(files, info.Name())
+	}
+	c.Assert(files, jc.DeepEquals, []string{
+		"actions-20170601T115000.000000000Z.json",
+		"actions-20170601T120000.000000000Z.json",
+		"unrelated.txt",
+	})
+}
+
+func (s *actionPrunerSuite) TestWorkerWontPruneBeforeFiringTimer(c *gc.C) {
+	facade, _, _ := s.setupPruner(c, false)
+
+	select {
+	case <-facade.removed:
+		c.Fatal("called before firing timer.")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type mockTimer struct {
+	period chan time.Duration
+	c      chan time.Time
+}
+
+func (t *mockTimer) Reset(d time.Duration) bool {
+	select {
+	case t.period <- d:
+	case <-time.After(coretesting.LongWait):
+		panic("timed out waiting for timer to reset")
+	}
+	return true
+}
+
+func (t *mockTimer) CountDown() <-chan time.Time {
+	return t.c
+}
+
+func (t *mockTimer) fire() error {
+	select {
+	case t.c <- time.Time{}:
+	case <-time.After(coretesting.LongWait):
+		return errors.New("timed out waiting for pruner to run")
+	}
+	return nil
+}
+
+func newMockTimer() *mockTimer {
+	return &mockTimer{period: make(chan time.Duration, 1),
+		c: make(chan time.Time),
+	}
+}
+
+type fakeFacade struct {
+	mu             sync.Mutex
+	maxHistoryTime time.Duration
+	maxHistoryMB   int
+	toPrune        []params.ActionResult
+	removed        chan []names.ActionTag
+	changesWatcher *mockNotifyWatcher
+	modelConfig    *config.Config
+	gotConfig      chan struct{}
+}
+
+func newFakeFacade() *fakeFacade {
+	return &fakeFacade{
+		removed:        make(chan []names.ActionTag, 1),
+		gotConfig:      make(chan struct{}, 1),
+		changesWatcher: newMockNotifyWatcher(),
+	}
+}
+
+// ActionsToPrune implements Facade
+func (f *fakeFacade) ActionsToPrune(maxHistoryTime time.Duration, maxHistoryMB int) ([]params.ActionResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.maxHistoryTime = maxHistoryTime
+	f.maxHistoryMB = maxHistoryMB
+	return f.toPrune, nil
+}
+
+// RemoveActions implements Facade
+func (f *fakeFacade) RemoveActions(actions []names.ActionTag) error {
+	select {
+	case f.removed <- actions:
+	case <-time.After(coretesting.LongWait):
+		return errors.New("timed out waiting for facade call RemoveActions to run")
+	}
+	return nil
+}
+
+// WatchForModelConfigChanges implements Facade
+func (f *fakeFacade) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	return f.changesWatcher, nil
+}
+
+// ModelConfig implements Facade
+func (f *fakeFacade) ModelConfig() (*config.Config, error) {
+	f.gotConfig <- struct{}{}
+	return f.modelConfig, nil
+}
+
+func newMockWatcher() *mockWatcher {
+	return &mockWatcher{
+		stopped: make(chan struct{}),
+	}
+}
+
+type mockWatcher struct {
+	mu      sync.Mutex
+	stopped chan struct{}
+}
+
+func (w *mockWatcher) Kill() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.Stopped() {
+		close(w.stopped)
+	}
+}
+
+func (w *mockWatcher) Wait() error {
+	<-w.stopped
+	return nil
+}
+
+func (w *mockWatcher) Stopped() bool {
+	select {
+	case <-w.stopped:
+		return true
+	default:
+		return false
+	}
+}
+
+func newMockNotifyWatcher() *mockNotifyWatcher {
+	return &mockNotifyWatcher{
+		mockWatcher: newMockWatcher(),
+		changes:     make(chan struct{}, 1),
+	}
+}
+
+type mockNotifyWatcher struct {
+	*mockWatcher
+	changes chan struct{}
+}
+
+func (w *mockNotifyWatcher) Changes() watcher.NotifyChannel {
+	return w.changes
+}
@@ -0,0 +1,103 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+// NewStateBackups returns a Backups that stores backups of the
+// controller running st, taken on the machine with the given ID.
+func NewStateBackups(st *state.State, paths backups.Paths, machineID string) Backups {
+	return &stateBackups{
+		st:        st,
+		paths:     paths,
+		machineID: machineID,
+	}
+}
+
+type stateBackups struct {
+	st        *state.State
+	paths     backups.Paths
+	machineID string
+}
+
+// Create is part of the Backups interface.
+func (b *stateBackups) Create(notes string) (Backup, error) {
+	session := b.st.MongoSession().Copy()
+	defer session.Close()
+
+	// Don't go if HA isn't ready.
+	if err := replicaset.WaitUntilReady(session, 60); err != nil {
+		return Backup{}, errors.Annotatef(err, "HA not ready")
+	}
+	v, err := b.st.MongoVersion()
+	if err != nil {
+		return Backup{}, errors.Annotatef(err, "discovering mongo version")
+	}
+	mongoVersion, err := mongo.NewVersion(v)
+	if err != nil {
+		return Backup{}, errors.Trace(err)
+	}
+	dbInfo, err := backups.NewDBInfo(b.st.MongoConnectionInfo(), session, mongoVersion)
+	if err != nil {
+		return Backup{}, errors.Trace(err)
+	}
+	machine, err := b.st.Machine(b.machineID)
+	if err != nil {
+		return Backup{}, errors.Trace(err)
+	}
+	meta, err := backups.NewMetadataState(b.st, b.machineID, machine.Series())
+	if err != nil {
+		return Backup{}, errors.Trace(err)
+	}
+	meta.Notes = notes
+
+	stor := backups.NewStorage(b.st)
+	defer stor.Close()
+	if err := backups.NewBackups(stor).Create(meta, &b.paths, dbInfo); err != nil {
+		return Backup{}, errors.Trace(err)
+	}
+	return fromMetadata(meta), nil
+}
+
+// List is part of the Backups interface.
+func (b *stateBackups) List() ([]Backup, error) {
+	stor := backups.NewStorage(b.st)
+	defer stor.Close()
+	metaList, err := backups.NewBackups(stor).List()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Backup, len(metaList))
+	for i, meta := range metaList {
+		result[i] = fromMetadata(meta)
+	}
+	return result, nil
+}
+
+// Remove is part of the Backups interface.
+func (b *stateBackups) Remove(id string) error {
+	stor := backups.NewStorage(b.st)
+	defer stor.Close()
+	return errors.Trace(backups.NewBackups(stor).Remove(id))
+}
+
+func fromMetadata(meta *backups.Metadata) Backup {
+	return Backup{
+		ID:      meta.ID(),
+		Started: meta.Started,
+		Notes:   meta.Notes,
+	}
+}
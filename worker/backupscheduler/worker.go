@@ -0,0 +1,245 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package backupscheduler provides a worker that creates backups of
+// the controller on the schedule set in the controller configuration,
+// and removes scheduled backups that fall outside the configured
+// retention limits.
+package backupscheduler
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/cron"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.backupscheduler")
+
+// ScheduledNotes is the note attached to the backups created by the
+// worker. Only backups with this note are ever removed by the worker,
+// so backups created by users are left alone.
+const ScheduledNotes = "scheduled backup"
+
+// Backup describes a stored backup.
+type Backup struct {
+	ID      string
+	Started time.Time
+	Notes   string
+}
+
+// Backups creates, lists and removes controller backups.
+type Backups interface {
+	// Create creates and stores a new backup with the given notes.
+	Create(notes string) (Backup, error)
+
+	// List returns all stored backups.
+	List() ([]Backup, error)
+
+	// Remove removes the backup with the given ID from storage.
+	Remove(id string) error
+}
+
+// ConfigSource exposes the controller configuration and a way to
+// watch it for changes.
+type ConfigSource interface {
+	WatchControllerConfig() state.NotifyWatcher
+	ControllerConfig() (controller.Config, error)
+}
+
+// StatusRecorder records the outcome of scheduled backups.
+type StatusRecorder interface {
+	RecordBackupSuccess(id string, when time.Time) error
+	RecordBackupFailure(message string, when time.Time) error
+}
+
+// Config holds the dependencies and configuration for a Worker.
+type Config struct {
+	Clock   clock.Clock
+	Source  ConfigSource
+	Backups Backups
+	Status  StatusRecorder
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional Worker.
+func (config Config) Validate() error {
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Source == nil {
+		return errors.NotValidf("nil Source")
+	}
+	if config.Backups == nil {
+		return errors.NotValidf("nil Backups")
+	}
+	if config.Status == nil {
+		return errors.NotValidf("nil Status")
+	}
+	return nil
+}
+
+// New returns a Worker that backs up the controller on the configured
+// schedule.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config: config,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker implements worker.Worker, and creates and prunes scheduled
+// controller backups.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+// retention describes which scheduled backups should be kept.
+type retention struct {
+	count int
+	age   time.Duration
+}
+
+func (w *Worker) loop() error {
+	source := w.config.Source
+	watcher := source.WatchControllerConfig()
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		schedule *cron.Schedule
+		keep     retention
+		timer    <-chan time.Time
+	)
+	// Schedules are interpreted in UTC, so that all controllers
+	// agree on when a backup is due.
+	reschedule := func() {
+		timer = nil
+		if schedule == nil {
+			return
+		}
+		now := w.config.Clock.Now().UTC()
+		next := schedule.Next(now)
+		if next.IsZero() {
+			logger.Warningf("backup schedule never fires")
+			return
+		}
+		logger.Debugf("next scheduled backup at %v", next)
+		timer = w.config.Clock.After(next.Sub(now))
+	}
+
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("controller configuration watcher closed")
+			}
+			controllerConfig, err := source.ControllerConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot load controller configuration")
+			}
+			schedule, _ = controllerConfig.BackupSchedule()
+			keep = retention{
+				count: controllerConfig.BackupRetentionCount(),
+				age:   controllerConfig.BackupRetentionAge(),
+			}
+			reschedule()
+		case <-timer:
+			if err := w.backup(keep); err != nil {
+				return errors.Trace(err)
+			}
+			reschedule()
+		}
+	}
+}
+
+// backup creates a new scheduled backup, records the outcome, and
+// removes any scheduled backups that are no longer to be kept. A
+// failure to create or prune backups is recorded and logged rather
+// than stopping the worker; only a failure to record the outcome is
+// returned.
+func (w *Worker) backup(keep retention) error {
+	logger.Infof("creating scheduled backup")
+	backup, err := w.config.Backups.Create(ScheduledNotes)
+	now := w.config.Clock.Now().UTC()
+	if err != nil {
+		logger.Errorf("scheduled backup failed: %v", err)
+		return errors.Trace(w.config.Status.RecordBackupFailure(err.Error(), now))
+	}
+	logger.Infof("created scheduled backup %q", backup.ID)
+	if err := w.config.Status.RecordBackupSuccess(backup.ID, now); err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.prune(keep, now); err != nil {
+		logger.Errorf("pruning scheduled backups: %v", err)
+		return errors.Trace(w.config.Status.RecordBackupFailure(
+			errors.Annotate(err, "pruning scheduled backups").Error(), now,
+		))
+	}
+	return nil
+}
+
+// prune removes the scheduled backups that fall outside the retention
+// limits.
+func (w *Worker) prune(keep retention, now time.Time) error {
+	all, err := w.config.Backups.List()
+	if err != nil {
+		return errors.Annotate(err, "listing backups")
+	}
+	var scheduled []Backup
+	for _, backup := range all {
+		if backup.Notes == ScheduledNotes {
+			scheduled = append(scheduled, backup)
+		}
+	}
+	sort.Sort(newestFirst(scheduled))
+	for i, backup := range scheduled {
+		tooMany := keep.count > 0 && i >= keep.count
+		tooOld := keep.age > 0 && now.Sub(backup.Started) > keep.age
+		if !tooMany && !tooOld {
+			continue
+		}
+		logger.Infof("removing scheduled backup %q", backup.ID)
+		if err := w.config.Backups.Remove(backup.ID); err != nil {
+			return errors.Annotatef(err, "removing backup %q", backup.ID)
+		}
+	}
+	return nil
+}
+
+type newestFirst []Backup
+
+func (b newestFirst) Len() int           { return len(b) }
+func (b newestFirst) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b newestFirst) Less(i, j int) bool { return b[i].Started.After(b[j].Started) }
@@ -0,0 +1,250 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/backupscheduler"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+	stub    *testing.Stub
+	clock   *testing.Clock
+	source  *mockSource
+	backups *mockBackups
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+// now is a Wednesday, half an hour before the daily backup is due.
+var now = time.Date(2017, 3, 15, 2, 30, 0, 0, time.UTC)
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = &testing.Stub{}
+	s.clock = testing.NewClock(now)
+	s.source = &mockSource{
+		watcher: workertest.NewFakeWatcher(1, 1),
+		config: controller.Config{
+			controller.BackupSchedule: "0 3 * * *",
+		},
+	}
+	s.backups = &mockBackups{
+		stub:  s.stub,
+		clock: s.clock,
+		existing: []backupscheduler.Backup{
+			{ID: "manual", Started: day(1), Notes: "before upgrade"},
+			{ID: "day-12", Started: day(12), Notes: backupscheduler.ScheduledNotes},
+			{ID: "day-14", Started: day(14), Notes: backupscheduler.ScheduledNotes},
+			{ID: "day-13", Started: day(13), Notes: backupscheduler.ScheduledNotes},
+		},
+	}
+}
+
+func day(d int) time.Time {
+	return time.Date(2017, 3, d, 3, 0, 0, 0, time.UTC)
+}
+
+func (s *WorkerSuite) config() backupscheduler.Config {
+	return backupscheduler.Config{
+		Clock:   s.clock,
+		Source:  s.source,
+		Backups: s.backups,
+		Status:  &mockStatus{s.stub},
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		mutate func(*backupscheduler.Config)
+		err    string
+	}{{
+		func(cfg *backupscheduler.Config) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *backupscheduler.Config) { cfg.Source = nil },
+		"nil Source not valid",
+	}, {
+		func(cfg *backupscheduler.Config) { cfg.Backups = nil },
+		"nil Backups not valid",
+	}, {
+		func(cfg *backupscheduler.Config) { cfg.Status = nil },
+		"nil Status not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config()
+		test.mutate(&config)
+		_, err := backupscheduler.New(config)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+// runOnce starts the worker, fires the first scheduled backup, and
+// waits for the worker to schedule the next one.
+func (s *WorkerSuite) runOnce(c *gc.C) {
+	w, err := backupscheduler.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	err = s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) TestNoSchedule(c *gc.C) {
+	s.source.config = controller.Config{}
+	w, err := backupscheduler.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.CheckAlive(c, w)
+	workertest.CleanKill(c, w)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *WorkerSuite) TestBackupPrunesByCount(c *gc.C) {
+	s.source.config[controller.BackupRetentionCount] = 2
+	s.runOnce(c)
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{"Create", []interface{}{backupscheduler.ScheduledNotes}},
+		{"RecordBackupSuccess", []interface{}{"new", day(15)}},
+		{"List", nil},
+		{"Remove", []interface{}{"day-13"}},
+		{"Remove", []interface{}{"day-12"}},
+	})
+}
+
+func (s *WorkerSuite) TestBackupPrunesByAge(c *gc.C) {
+	s.source.config[controller.BackupRetentionCount] = 0
+	s.source.config[controller.BackupRetentionAge] = "48h"
+	s.runOnce(c)
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{"Create", []interface{}{backupscheduler.ScheduledNotes}},
+		{"RecordBackupSuccess", []interface{}{"new", day(15)}},
+		{"List", nil},
+		{"Remove", []interface{}{"day-12"}},
+	})
+}
+
+func (s *WorkerSuite) TestBackupKeepsDefaultCount(c *gc.C) {
+	s.runOnce(c)
+	s.stub.CheckCallNames(c, "Create", "RecordBackupSuccess", "List")
+}
+
+func (s *WorkerSuite) TestBackupFailureRecorded(c *gc.C) {
+	s.stub.SetErrors(errors.New("disk full"))
+	s.runOnce(c)
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{"Create", []interface{}{backupscheduler.ScheduledNotes}},
+		{"RecordBackupFailure", []interface{}{"disk full", day(15)}},
+	})
+}
+
+func (s *WorkerSuite) TestPruneFailureRecorded(c *gc.C) {
+	s.source.config[controller.BackupRetentionCount] = 1
+	s.stub.SetErrors(nil, nil, nil, errors.New("boom"))
+	s.runOnce(c)
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{"Create", []interface{}{backupscheduler.ScheduledNotes}},
+		{"RecordBackupSuccess", []interface{}{"new", day(15)}},
+		{"List", nil},
+		{"Remove", []interface{}{"day-14"}},
+		{"RecordBackupFailure", []interface{}{`pruning scheduled backups: removing backup "day-14": boom`, day(15)}},
+	})
+}
+
+func (s *WorkerSuite) TestStatusError(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("no mongo"))
+	w, err := backupscheduler.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	err = s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "no mongo")
+}
+
+func (s *WorkerSuite) TestControllerConfigError(c *gc.C) {
+	s.source.err = errors.New("splat")
+	w, err := backupscheduler.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "cannot load controller configuration: splat")
+}
+
+type mockSource struct {
+	sync.Mutex
+	watcher workertest.NotAWatcher
+	config  controller.Config
+	err     error
+}
+
+func (s *mockSource) WatchControllerConfig() state.NotifyWatcher {
+	return s.watcher
+}
+
+func (s *mockSource) ControllerConfig() (controller.Config, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.config, s.err
+}
+
+type mockBackups struct {
+	stub     *testing.Stub
+	clock    *testing.Clock
+	existing []backupscheduler.Backup
+}
+
+func (b *mockBackups) Create(notes string) (backupscheduler.Backup, error) {
+	b.stub.AddCall("Create", notes)
+	if err := b.stub.NextErr(); err != nil {
+		return backupscheduler.Backup{}, err
+	}
+	backup := backupscheduler.Backup{
+		ID:      "new",
+		Started: b.clock.Now(),
+		Notes:   notes,
+	}
+	b.existing = append(b.existing, backup)
+	return backup, nil
+}
+
+func (b *mockBackups) List() ([]backupscheduler.Backup, error) {
+	b.stub.AddCall("List")
+	if err := b.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.existing, nil
+}
+
+func (b *mockBackups) Remove(id string) error {
+	b.stub.AddCall("Remove", id)
+	return b.stub.NextErr()
+}
+
+type mockStatus struct {
+	stub *testing.Stub
+}
+
+func (s *mockStatus) RecordBackupSuccess(id string, when time.Time) error {
+	s.stub.AddCall("RecordBackupSuccess", id, when)
+	return s.stub.NextErr()
+}
+
+func (s *mockStatus) RecordBackupFailure(message string, when time.Time) error {
+	s.stub.AddCall("RecordBackupFailure", message, when)
+	return s.stub.NextErr()
+}
@@ -0,0 +1,107 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package controllerconfigupdater provides a worker that reports
+// changes to the controller configuration, so that the parts of a
+// controller agent that depend on it can be updated without
+// restarting the agent.
+package controllerconfigupdater
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+// ConfigSource exposes the controller configuration and a way to
+// watch it for changes.
+type ConfigSource interface {
+	WatchControllerConfig() state.NotifyWatcher
+	ControllerConfig() (controller.Config, error)
+}
+
+// Config holds the dependencies and configuration for a Worker.
+type Config struct {
+	// Source provides the controller configuration.
+	Source ConfigSource
+
+	// Changed is called with the controller configuration when the
+	// worker starts, and again each time the configuration changes.
+	// If it returns an error, the worker stops with that error.
+	Changed func(controller.Config) error
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional Worker.
+func (config Config) Validate() error {
+	if config.Source == nil {
+		return errors.NotValidf("nil Source")
+	}
+	if config.Changed == nil {
+		return errors.NotValidf("nil Changed")
+	}
+	return nil
+}
+
+// New returns a Worker that calls the configured Changed func with
+// the controller configuration each time it changes.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config: config,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker implements worker.Worker, and reports changes to the
+// controller configuration.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	source := w.config.Source
+	watcher := source.WatchControllerConfig()
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("controller configuration watcher closed")
+			}
+			controllerConfig, err := source.ControllerConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot load controller configuration")
+			}
+			if err := w.config.Changed(controllerConfig); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
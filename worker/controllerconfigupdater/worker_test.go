@@ -0,0 +1,125 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerconfigupdater_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/controllerconfigupdater"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (*WorkerSuite) TestValidate(c *gc.C) {
+	_, err := controllerconfigupdater.New(controllerconfigupdater.Config{
+		Changed: func(controller.Config) error { return nil },
+	})
+	c.Check(err, gc.ErrorMatches, "nil Source not valid")
+
+	_, err = controllerconfigupdater.New(controllerconfigupdater.Config{
+		Source: &mockSource{},
+	})
+	c.Check(err, gc.ErrorMatches, "nil Changed not valid")
+}
+
+func (*WorkerSuite) TestChanged(c *gc.C) {
+	source := &mockSource{
+		watcher: workertest.NewFakeWatcher(2, 1),
+		config:  controller.Config{controller.AuditingEnabled: true},
+	}
+	changed := make(chan controller.Config)
+	w, err := controllerconfigupdater.New(controllerconfigupdater.Config{
+		Source: source,
+		Changed: func(cfg controller.Config) error {
+			changed <- cfg
+			return nil
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	assertChanged := func(expect bool) {
+		select {
+		case cfg := <-changed:
+			c.Assert(cfg.AuditingEnabled(), gc.Equals, expect)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for config change")
+		}
+	}
+	assertChanged(true)
+
+	source.setConfig(controller.Config{controller.AuditingEnabled: false})
+	source.watcher.Ping()
+	assertChanged(false)
+}
+
+func (*WorkerSuite) TestChangedError(c *gc.C) {
+	source := &mockSource{
+		watcher: workertest.NewFakeWatcher(1, 1),
+		config:  controller.Config{},
+	}
+	w, err := controllerconfigupdater.New(controllerconfigupdater.Config{
+		Source: source,
+		Changed: func(controller.Config) error {
+			return errors.New("boom")
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (*WorkerSuite) TestControllerConfigError(c *gc.C) {
+	source := &mockSource{
+		watcher: workertest.NewFakeWatcher(1, 1),
+		err:     errors.New("splat"),
+	}
+	w, err := controllerconfigupdater.New(controllerconfigupdater.Config{
+		Source: source,
+		Changed: func(controller.Config) error {
+			c.Fatalf("unexpected call to Changed")
+			return nil
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "cannot load controller configuration: splat")
+}
+
+type mockSource struct {
+	sync.Mutex
+	watcher workertest.NotAWatcher
+	config  controller.Config
+	err     error
+}
+
+func (s *mockSource) WatchControllerConfig() state.NotifyWatcher {
+	return s.watcher
+}
+
+func (s *mockSource) ControllerConfig() (controller.Config, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.config, s.err
+}
+
+func (s *mockSource) setConfig(cfg controller.Config) {
+	s.Lock()
+	defer s.Unlock()
+	s.config = cfg
+}
@@ -160,6 +160,9 @@ func (ctx *SimpleContext) DeployUnit(unitName, initialPassword string) (err erro
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if family := ctx.agentConfig.Value(agent.PreferredAddressFamily); family != "" {
+		conf.SetValue(agent.PreferredAddressFamily, family)
+	}
 	if err := conf.Write(); err != nil {
 		return err
 	}
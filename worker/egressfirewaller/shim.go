@@ -0,0 +1,39 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package egressfirewaller
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/exec"
+	"gopkg.in/juju/names.v2"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/base"
+	apiegressfirewaller "github.com/juju/juju/api/egressfirewaller"
+)
+
+func NewFacade(apiCaller base.APICaller, tag names.MachineTag) (Facade, error) {
+	return apiegressfirewaller.NewAPI(apiCaller, tag), nil
+}
+
+func NewWorker(config Config) (worker.Worker, error) {
+	worker, err := New(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return worker, nil
+}
+
+// RunScript runs the script with the shell, returning
+// an error if it does not complete successfully.
+func RunScript(script string) error {
+	result, err := exec.RunCommands(exec.RunParams{Commands: script})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Code != 0 {
+		return errors.Errorf("script failed with exit code %d: %s", result.Code, result.Stderr)
+	}
+	return nil
+}
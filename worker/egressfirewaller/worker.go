@@ -0,0 +1,190 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package egressfirewaller
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/watcher"
+)
+
+var logger = loggo.GetLogger("juju.worker.egressfirewaller")
+
+// chainName is the name of the iptables chain, jumped to from the
+// OUTPUT chain, that holds the egress rules of the machine.
+const chainName = "juju-egress"
+
+// Facade exposes the machine's egress rules to a Worker.
+type Facade interface {
+	WatchEgressRules() (watcher.NotifyWatcher, error)
+	EgressRules() ([]network.EgressRule, bool, error)
+}
+
+// Config defines the parameters of the egressfirewaller worker.
+type Config struct {
+	Facade Facade
+
+	// RunScript runs the given shell script on the machine.
+	RunScript func(script string) error
+}
+
+// Validate returns an error if Config cannot drive an egressfirewaller.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.RunScript == nil {
+		return errors.NotValidf("nil RunScript")
+	}
+	return nil
+}
+
+// New returns a Worker which enforces the machine's egress rules with
+// iptables, unless they are enforced by the provider.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: &egressFirewaller{config: config},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// egressFirewaller implements watcher.NotifyHandler.
+type egressFirewaller struct {
+	config Config
+	script string
+}
+
+// SetUp is defined on the watcher.NotifyHandler interface.
+func (w *egressFirewaller) SetUp() (watcher.NotifyWatcher, error) {
+	return w.config.Facade.WatchEgressRules()
+}
+
+// Handle is defined on the watcher.NotifyHandler interface.
+func (w *egressFirewaller) Handle(_ <-chan struct{}) error {
+	rules, providerManaged, err := w.config.Facade.EgressRules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if providerManaged {
+		// The rules are enforced by the provider's firewall;
+		// outgoing traffic must not be restricted twice.
+		rules = nil
+	}
+	script := egressScript(rules)
+	if script == w.script {
+		return nil
+	}
+	if err := w.config.RunScript(script); err != nil {
+		return errors.Annotate(err, "cannot apply egress rules")
+	}
+	w.script = script
+	if len(rules) == 0 {
+		logger.Infof("outgoing traffic is unrestricted")
+	} else {
+		logger.Infof("restricted outgoing traffic to %v", rules)
+	}
+	return nil
+}
+
+// TearDown is defined on the watcher.NotifyHandler interface.
+func (w *egressFirewaller) TearDown() error {
+	return nil
+}
+
+// egressScript returns a shell script which replaces the contents of
+// the egress chain with the rules. If any rules are specified, all
+// other outgoing traffic that is not to the loopback interface or
+// part of an established connection is rejected.
+//
+// IPv6 traffic is only restricted if a rule specifies an IPv6
+// destination; otherwise any previously applied IPv6 rules are
+// flushed, and ip6tables need not be installed.
+func egressScript(rules []network.EgressRule) string {
+	lines := []string{"set -e"}
+	lines = append(lines, chainCommands("iptables", rules)...)
+	if hasIPv6Rules(rules) {
+		lines = append(lines, chainCommands("ip6tables", rules)...)
+	} else {
+		lines = append(lines, fmt.Sprintf("ip6tables -F %s 2>/dev/null || true", chainName))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// chainCommands returns the commands, run with the given iptables
+// command, which replace the contents of the egress chain with the
+// rules.
+func chainCommands(cmd string, rules []network.EgressRule) []string {
+	ipv6 := cmd == "ip6tables"
+	lines := []string{
+		fmt.Sprintf("%s -N %s 2>/dev/null || true", cmd, chainName),
+		fmt.Sprintf("%s -F %s", cmd, chainName),
+		fmt.Sprintf("%s -C OUTPUT -j %s 2>/dev/null || %s -I OUTPUT -j %s", cmd, chainName, cmd, chainName),
+	}
+	if len(rules) == 0 {
+		return lines
+	}
+	lines = append(lines,
+		fmt.Sprintf("%s -A %s -o lo -j RETURN", cmd, chainName),
+		fmt.Sprintf("%s -A %s -m state --state ESTABLISHED,RELATED -j RETURN", cmd, chainName),
+	)
+	for _, rule := range rules {
+		match := ruleMatch(rule, ipv6)
+		if len(rule.DestinationCIDRs) == 0 {
+			lines = append(lines, fmt.Sprintf("%s -A %s %s -j RETURN", cmd, chainName, match))
+			continue
+		}
+		for _, cidr := range rule.DestinationCIDRs {
+			if isIPv6CIDR(cidr) != ipv6 {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("%s -A %s -d %s %s -j RETURN", cmd, chainName, cidr, match))
+		}
+	}
+	return append(lines, fmt.Sprintf("%s -A %s -j REJECT", cmd, chainName))
+}
+
+// ruleMatch returns the iptables match for the protocol and ports of
+// the rule. ICMP has no ports, so all ICMP traffic is matched.
+func ruleMatch(rule network.EgressRule, ipv6 bool) string {
+	if rule.Protocol == "icmp" {
+		if ipv6 {
+			return "-p ipv6-icmp"
+		}
+		return "-p icmp"
+	}
+	ports := fmt.Sprint(rule.FromPort)
+	if rule.ToPort != rule.FromPort {
+		ports = fmt.Sprintf("%d:%d", rule.FromPort, rule.ToPort)
+	}
+	return fmt.Sprintf("-p %s --dport %s", rule.Protocol, ports)
+}
+
+// hasIPv6Rules reports whether any of the rules specifies an IPv6
+// destination.
+func hasIPv6Rules(rules []network.EgressRule) bool {
+	for _, rule := range rules {
+		for _, cidr := range rule.DestinationCIDRs {
+			if isIPv6CIDR(cidr) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isIPv6CIDR(cidr string) bool {
+	return strings.Contains(cidr, ":")
+}
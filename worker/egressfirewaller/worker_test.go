@@ -0,0 +1,243 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package egressfirewaller_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/egressfirewaller"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type Suite struct {
+	jujutesting.IsolationSuite
+
+	facade  *stubFacade
+	scripts chan string
+	config  egressfirewaller.Config
+}
+
+var _ = gc.Suite(&Suite{})
+
+func (s *Suite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.facade = &stubFacade{
+		Stub:    &jujutesting.Stub{},
+		watcher: newMockNotifyWatcher(),
+	}
+	s.scripts = make(chan string, 10)
+	s.config = egressfirewaller.Config{
+		Facade: s.facade,
+		RunScript: func(script string) error {
+			s.scripts <- script
+			return s.facade.NextErr()
+		},
+	}
+}
+
+func (s *Suite) nextScript(c *gc.C) string {
+	select {
+	case script := <-s.scripts:
+		return script
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for script")
+	}
+	panic("unreachable")
+}
+
+func (s *Suite) assertNoScript(c *gc.C) {
+	select {
+	case script := <-s.scripts:
+		c.Fatalf("unexpected script: %s", script)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *Suite) TestInvalidConfig(c *gc.C) {
+	s.config.RunScript = nil
+	_, err := egressfirewaller.New(s.config)
+	c.Check(err, gc.ErrorMatches, "nil RunScript not valid")
+}
+
+func (s *Suite) TestUnrestricted(c *gc.C) {
+	w, err := egressfirewaller.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.nextScript(c), gc.Equals, `
+set -e
+iptables -N juju-egress 2>/dev/null || true
+iptables -F juju-egress
+iptables -C OUTPUT -j juju-egress 2>/dev/null || iptables -I OUTPUT -j juju-egress
+ip6tables -F juju-egress 2>/dev/null || true
+`[1:])
+}
+
+func (s *Suite) TestRestricted(c *gc.C) {
+	s.facade.setRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8", "2001:db8::/32"),
+		network.MustNewEgressRule("udp", 5000, 5010),
+	}, false)
+	w, err := egressfirewaller.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.nextScript(c), gc.Equals, `
+set -e
+iptables -N juju-egress 2>/dev/null || true
+iptables -F juju-egress
+iptables -C OUTPUT -j juju-egress 2>/dev/null || iptables -I OUTPUT -j juju-egress
+iptables -A juju-egress -o lo -j RETURN
+iptables -A juju-egress -m state --state ESTABLISHED,RELATED -j RETURN
+iptables -A juju-egress -d 10.0.0.0/8 -p tcp --dport 443 -j RETURN
+iptables -A juju-egress -p udp --dport 5000:5010 -j RETURN
+iptables -A juju-egress -j REJECT
+ip6tables -N juju-egress 2>/dev/null || true
+ip6tables -F juju-egress
+ip6tables -C OUTPUT -j juju-egress 2>/dev/null || ip6tables -I OUTPUT -j juju-egress
+ip6tables -A juju-egress -o lo -j RETURN
+ip6tables -A juju-egress -m state --state ESTABLISHED,RELATED -j RETURN
+ip6tables -A juju-egress -d 2001:db8::/32 -p tcp --dport 443 -j RETURN
+ip6tables -A juju-egress -p udp --dport 5000:5010 -j RETURN
+ip6tables -A juju-egress -j REJECT
+`[1:])
+}
+
+func (s *Suite) TestRestrictedIPv4Only(c *gc.C) {
+	s.facade.setRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("icmp", -1, -1),
+	}, false)
+	w, err := egressfirewaller.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.nextScript(c), gc.Equals, `
+set -e
+iptables -N juju-egress 2>/dev/null || true
+iptables -F juju-egress
+iptables -C OUTPUT -j juju-egress 2>/dev/null || iptables -I OUTPUT -j juju-egress
+iptables -A juju-egress -o lo -j RETURN
+iptables -A juju-egress -m state --state ESTABLISHED,RELATED -j RETURN
+iptables -A juju-egress -d 10.0.0.0/8 -p tcp --dport 443 -j RETURN
+iptables -A juju-egress -p icmp -j RETURN
+iptables -A juju-egress -j REJECT
+ip6tables -F juju-egress 2>/dev/null || true
+`[1:])
+}
+
+func (s *Suite) TestRestrictedICMPv6(c *gc.C) {
+	s.facade.setRules([]network.EgressRule{
+		network.MustNewEgressRule("icmp", -1, -1, "2001:db8::/32"),
+	}, false)
+	w, err := egressfirewaller.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.nextScript(c), jc.Contains,
+		"ip6tables -A juju-egress -d 2001:db8::/32 -p ipv6-icmp -j RETURN\n",
+	)
+}
+
+func (s *Suite) TestChanges(c *gc.C) {
+	w, err := egressfirewaller.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	unrestricted := s.nextScript(c)
+
+	// Unchanged rules are not applied again.
+	s.facade.watcher.change()
+	s.assertNoScript(c)
+
+	s.facade.setRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 80, 80),
+	}, false)
+	s.facade.watcher.change()
+	c.Assert(s.nextScript(c), jc.Contains, "iptables -A juju-egress -p tcp --dport 80 -j RETURN\n")
+
+	// Rules enforced by the provider are not enforced on the machine.
+	s.facade.setRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 80, 80),
+	}, true)
+	s.facade.watcher.change()
+	c.Assert(s.nextScript(c), gc.Equals, unrestricted)
+}
+
+func (s *Suite) TestRunScriptError(c *gc.C) {
+	s.facade.SetErrors(nil, errors.New("iptables: command not found"))
+	w, err := egressfirewaller.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.nextScript(c)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "cannot apply egress rules: iptables: command not found")
+}
+
+type stubFacade struct {
+	*jujutesting.Stub
+	watcher *mockNotifyWatcher
+
+	mu              sync.Mutex
+	rules           []network.EgressRule
+	providerManaged bool
+}
+
+func (f *stubFacade) setRules(rules []network.EgressRule, providerManaged bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rules = rules
+	f.providerManaged = providerManaged
+}
+
+func (f *stubFacade) WatchEgressRules() (watcher.NotifyWatcher, error) {
+	f.AddCall("WatchEgressRules")
+	return f.watcher, nil
+}
+
+func (f *stubFacade) EgressRules() ([]network.EgressRule, bool, error) {
+	f.AddCall("EgressRules")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rules, f.providerManaged, f.NextErr()
+}
+
+type mockNotifyWatcher struct {
+	tomb    tomb.Tomb
+	changes chan struct{}
+}
+
+func newMockNotifyWatcher() *mockNotifyWatcher {
+	w := &mockNotifyWatcher{changes: make(chan struct{}, 1)}
+	w.changes <- struct{}{}
+	go func() {
+		defer w.tomb.Done()
+		<-w.tomb.Dying()
+	}()
+	return w
+}
+
+func (w *mockNotifyWatcher) Kill() {
+	w.tomb.Kill(nil)
+}
+
+func (w *mockNotifyWatcher) Wait() error {
+	return w.tomb.Wait()
+}
+
+func (w *mockNotifyWatcher) Changes() watcher.NotifyChannel {
+	return w.changes
+}
+
+func (w *mockNotifyWatcher) change() {
+	w.changes <- struct{}{}
+}
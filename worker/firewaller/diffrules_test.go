@@ -8,6 +8,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/network"
 )
 
@@ -129,3 +130,33 @@ func (s *DiffRulesSuite) TestDiffRangesClosesPortsIfRulesAreDisjoint(c *gc.C) {
 	c.Assert(toOpen, gc.DeepEquals, wanted)
 	c.Assert(toClose, gc.DeepEquals, current)
 }
+
+func (s *DiffRulesSuite) TestExposedCIDRs(c *gc.C) {
+	c.Assert(exposedCIDRs(nil, "", false).SortedValues(), jc.DeepEquals, []string{"0.0.0.0/0"})
+	c.Assert(exposedCIDRs(nil, "admin", true).SortedValues(), jc.DeepEquals, []string{"0.0.0.0/0", "::/0"})
+
+	exposed := map[string]params.ExposedEndpoint{
+		"admin": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		"db":    {ExposeToCIDRs: []string{"192.168.1.0/24", "10.0.0.0/24"}},
+	}
+	c.Assert(exposedCIDRs(exposed, "admin", true).SortedValues(), jc.DeepEquals, []string{"10.0.0.0/24"})
+	c.Assert(exposedCIDRs(exposed, "db", true).SortedValues(), jc.DeepEquals, []string{"10.0.0.0/24", "192.168.1.0/24"})
+	// Neither the endpoint nor all endpoints are exposed.
+	c.Assert(exposedCIDRs(exposed, "website", true).SortedValues(), gc.HasLen, 0)
+	c.Assert(exposedCIDRs(exposed, "", true).SortedValues(), gc.HasLen, 0)
+
+	exposed = map[string]params.ExposedEndpoint{
+		"admin": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		"":      {ExposeToCIDRs: []string{"192.168.1.0/24"}},
+	}
+	c.Assert(exposedCIDRs(exposed, "admin", false).SortedValues(), jc.DeepEquals, []string{"10.0.0.0/24"})
+	c.Assert(exposedCIDRs(exposed, "website", false).SortedValues(), jc.DeepEquals, []string{"192.168.1.0/24"})
+	c.Assert(exposedCIDRs(exposed, "", false).SortedValues(), jc.DeepEquals, []string{"192.168.1.0/24"})
+
+	exposed = map[string]params.ExposedEndpoint{
+		"admin": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		"":      {},
+	}
+	c.Assert(exposedCIDRs(exposed, "admin", false).SortedValues(), jc.DeepEquals, []string{"10.0.0.0/24"})
+	c.Assert(exposedCIDRs(exposed, "", false).SortedValues(), jc.DeepEquals, []string{"0.0.0.0/0"})
+}
@@ -5,6 +5,7 @@ package firewaller
 
 import (
 	"io"
+	"reflect"
 	"strings"
 	"time"
 
@@ -69,6 +70,10 @@ type Config struct {
 	EnvironFirewaller  EnvironFirewaller
 	EnvironInstances   EnvironInstances
 
+	// ExposeIPv6 indicates whether applications exposed to all
+	// sources are opened to IPv6 sources, as well as IPv4 sources.
+	ExposeIPv6 bool
+
 	NewRemoteFirewallerAPIFunc func(modelUUID string) (RemoteFirewallerAPICloser, error)
 
 	Clock clock.Clock
@@ -97,7 +102,10 @@ func (config Config) Validate() error {
 	return nil
 }
 
-type portRanges map[network.PortRange]bool
+// portRanges maps the port ranges opened by a unit to the names of the
+// endpoints they are opened for. Port ranges that are not opened for an
+// endpoint map to the empty string.
+type portRanges map[network.PortRange]string
 
 // Firewaller watches the state for port ranges opened or closed on
 // machines and reflects those changes onto the backing environment.
@@ -108,6 +116,7 @@ type Firewaller struct {
 	remoteRelationsApi *remoterelations.Client
 	environFirewaller  EnvironFirewaller
 	environInstances   EnvironInstances
+	egressFirewaller   environs.EgressFirewaller
 
 	machinesWatcher      watcher.StringsWatcher
 	portsWatcher         watcher.StringsWatcher
@@ -118,6 +127,7 @@ type Firewaller struct {
 	exposedChange        chan *exposedChange
 	globalMode           bool
 	globalIngressRuleRef map[string]int // map of rule names to count of occurrences
+	exposeIPv6           bool
 
 	modelUUID                  string
 	newRemoteFirewallerAPIFunc func(modelUUID string) (RemoteFirewallerAPICloser, error)
@@ -143,6 +153,7 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 		environInstances:           cfg.EnvironInstances,
 		newRemoteFirewallerAPIFunc: cfg.NewRemoteFirewallerAPIFunc,
 		modelUUID:                  cfg.ModelUUID,
+		exposeIPv6:                 cfg.ExposeIPv6,
 		machineds:                  make(map[names.MachineTag]*machineData),
 		unitsChange:                make(chan *unitsChange),
 		unitds:                     make(map[names.UnitTag]*unitData),
@@ -155,6 +166,17 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 
 	switch cfg.Mode {
 	case config.FwInstance:
+		// Egress rules are enforced by the machines themselves
+		// unless the environment supports them.
+		if egressFirewaller, ok := cfg.EnvironFirewaller.(environs.EgressFirewaller); ok {
+			supported, err := egressFirewaller.SupportsEgressRules()
+			if err != nil && !errors.IsNotSupported(err) {
+				return nil, errors.Annotate(err, "checking egress rules support")
+			}
+			if supported {
+				fw.egressFirewaller = egressFirewaller
+			}
+		}
 	case config.FwGlobal:
 		fw.globalMode = true
 		fw.globalIngressRuleRef = make(map[string]int)
@@ -283,6 +305,7 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.applicationd.exposed = change.exposed
+			change.applicationd.exposedEndpoints = change.exposedEndpoints
 			unitds := []*unitData{}
 			for _, unitd := range change.applicationd.unitds {
 				unitds = append(unitds, unitd)
@@ -372,11 +395,35 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 		delete(fw.machineds, tag)
 		return errors.Trace(err)
 	}
+	if fw.egressFirewaller != nil {
+		if err := fw.startMachineEgress(machined); err != nil {
+			worker.Stop(machined)
+			delete(fw.machineds, tag)
+			return errors.Trace(err)
+		}
+	}
 
 	// register the machined with the firewaller's catacomb.
 	return fw.catacomb.Add(machined)
 }
 
+// startMachineEgress starts a worker, tied to the lifetime of the
+// machined, which applies the machine's egress rules to its instance.
+func (fw *Firewaller) startMachineEgress(machined *machineData) error {
+	egressd := &machineEgressData{
+		fw:  fw,
+		tag: machined.tag,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &egressd.catacomb,
+		Work: egressd.watchLoop,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return machined.catacomb.Add(egressd)
+}
+
 // startUnit creates a new data value for tracking details of the unit
 // The provided machineTag must be the tag for the machine the unit was last
 // observed to be assigned to.
@@ -433,22 +480,23 @@ func (fw *Firewaller) startUnit(unit *firewaller.Unit, machineTag names.MachineT
 // startApplication creates a new data value for tracking details of the
 // application and starts watching the application for exposure changes.
 func (fw *Firewaller) startApplication(app *firewaller.Application) error {
-	exposed, err := app.IsExposed()
+	exposed, exposedEndpoints, err := app.ExposeInfo()
 	if err != nil {
 		return err
 	}
 	applicationd := &applicationData{
-		fw:          fw,
-		application: app,
-		exposed:     exposed,
-		unitds:      make(map[names.UnitTag]*unitData),
+		fw:               fw,
+		application:      app,
+		exposed:          exposed,
+		exposedEndpoints: exposedEndpoints,
+		unitds:           make(map[names.UnitTag]*unitData),
 	}
 	fw.applicationids[app.Tag()] = applicationd
 
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &applicationd.catacomb,
 		Work: func() error {
-			return applicationd.watchLoop(exposed)
+			return applicationd.watchLoop(exposed, exposedEndpoints)
 		},
 	})
 	if err != nil {
@@ -607,13 +655,14 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 		return err
 	}
 
-	ports, err := m.OpenedPorts(subnetTag)
+	ports, err := m.OpenedPortRanges(subnetTag)
 	if err != nil {
 		return err
 	}
 
 	newPortRanges := make(map[names.UnitTag]portRanges)
-	for portRange, unitTag := range ports {
+	for portRange, opened := range ports {
+		unitTag := opened.Unit
 		unitd, ok := machined.unitds[unitTag]
 		if !ok {
 			// It is common to receive port change notification before
@@ -627,7 +676,7 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 			ranges = make(portRanges)
 			newPortRanges[unitd.tag] = ranges
 		}
-		ranges[portRange] = true
+		ranges[portRange] = opened.Endpoint
 	}
 
 	if !unitPortsEqual(machined.definedPorts, newPortRanges) {
@@ -709,32 +758,66 @@ func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.In
 				continue
 			}
 
-			cidrs := set.NewStrings()
-			// If the unit is exposed, allow access from everywhere.
-			if unitd.applicationd.exposed {
-				cidrs.Add("0.0.0.0/0")
-			} else {
+			applicationd := unitd.applicationd
+			remoteCidrs := set.NewStrings()
+			if !applicationd.exposed {
 				// Not exposed, so add any ingress rules required by remote relations.
-				if err := fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), cidrs); err != nil {
+				if err := fw.updateForRemoteRelationIngress(applicationd.application.Tag(), remoteCidrs); err != nil {
 					return nil, errors.Trace(err)
 				}
-				logger.Debugf("CIDRS for %v: %v", unitTag, cidrs.Values())
+				logger.Debugf("CIDRS for %v: %v", unitTag, remoteCidrs.Values())
 			}
-			if cidrs.Size() > 0 {
-				for portRange := range portRanges {
-					sourceCidrs := cidrs.SortedValues()
-					rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, sourceCidrs...)
-					if err != nil {
-						return nil, errors.Trace(err)
-					}
-					want = append(want, rule)
+			for portRange, endpoint := range portRanges {
+				cidrs := remoteCidrs
+				// If the unit is exposed, allow access from the sources
+				// the port range's endpoint is exposed to.
+				if applicationd.exposed {
+					cidrs = exposedCIDRs(applicationd.exposedEndpoints, endpoint, fw.exposeIPv6)
+				}
+				if cidrs.Size() == 0 {
+					continue
 				}
+				rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, cidrs.SortedValues()...)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				want = append(want, rule)
 			}
 		}
 	}
 	return want, nil
 }
 
+// exposedCIDRs returns the CIDRs from which the ports opened for the
+// given endpoint of an exposed application with the given expose settings
+// may be accessed. Ports opened for an endpoint without expose settings,
+// or not opened for an endpoint at all, use the settings for all endpoints
+// (the empty endpoint name), if any. An application exposed without any
+// expose settings may be accessed from all sources. If ipv6 is true, ports
+// accessible from all sources may be accessed from "::/0", as well as from
+// "0.0.0.0/0".
+func exposedCIDRs(exposedEndpoints map[string]params.ExposedEndpoint, endpoint string, ipv6 bool) set.Strings {
+	if len(exposedEndpoints) > 0 {
+		settings, ok := exposedEndpoints[endpoint]
+		if !ok {
+			settings, ok = exposedEndpoints[""]
+		}
+		if !ok {
+			// Neither the endpoint nor all endpoints are exposed.
+			return set.NewStrings()
+		}
+		cidrs := set.NewStrings(settings.ExposeToCIDRs...)
+		if !cidrs.IsEmpty() && !cidrs.Contains("0.0.0.0/0") {
+			return cidrs
+		}
+	}
+	// Exposed to all sources.
+	if ipv6 {
+		return set.NewStrings("0.0.0.0/0", "::/0")
+	}
+	return set.NewStrings("0.0.0.0/0")
+}
+
 func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag, cidrs set.Strings) error {
 	logger.Debugf("finding ingress rules for %v", appTag)
 	// Now create the rules for any remote relations of which the
@@ -975,6 +1058,89 @@ func (md *machineData) Wait() error {
 	return md.catacomb.Wait()
 }
 
+// machineEgressData watches the egress rules of a machine and
+// applies them to the machine's instance.
+type machineEgressData struct {
+	catacomb catacomb.Catacomb
+	fw       *Firewaller
+	tag      names.MachineTag
+}
+
+// watchLoop watches the machine's egress rules for changes. Rules
+// cannot be applied until the machine is provisioned, so until then
+// the machine is polled for its instance.
+func (ed *machineEgressData) watchLoop() error {
+	m, err := ed.fw.firewallerApi.Machine(ed.tag)
+	if params.IsCodeNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	egressw, err := m.WatchEgressRules()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("egress rules not supported by the API server")
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if err := ed.catacomb.Add(egressw); err != nil {
+		return errors.Trace(err)
+	}
+	var retry <-chan time.Time
+	for {
+		select {
+		case <-ed.catacomb.Dying():
+			return ed.catacomb.ErrDying()
+		case _, ok := <-egressw.Changes():
+			if !ok {
+				return errors.New("machine egress rules watcher closed")
+			}
+		case <-retry:
+		}
+		retry = nil
+		applied, err := ed.applyEgressRules(m)
+		if err != nil {
+			return errors.Annotatef(err, "cannot apply egress rules for %q", ed.tag)
+		}
+		if !applied {
+			retry = ed.fw.pollClock.After(3 * time.Second)
+		}
+	}
+}
+
+// applyEgressRules applies the machine's egress rules to its instance,
+// reporting whether the machine was provisioned.
+func (ed *machineEgressData) applyEgressRules(m *firewaller.Machine) (bool, error) {
+	if _, err := m.InstanceId(); params.IsCodeNotProvisioned(err) {
+		return false, nil
+	} else if params.IsCodeNotFound(err) {
+		return true, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	rules, err := m.EgressRules()
+	if params.IsCodeNotFound(err) {
+		return true, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	if err := ed.fw.egressFirewaller.SetEgressRules(ed.tag.Id(), rules); err != nil {
+		return false, errors.Trace(err)
+	}
+	logger.Infof("applied egress rules %v to %q", rules, ed.tag)
+	return true, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (ed *machineEgressData) Kill() {
+	ed.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (ed *machineEgressData) Wait() error {
+	return ed.catacomb.Wait()
+}
+
 // unitData holds unit details.
 type unitData struct {
 	fw           *Firewaller
@@ -984,23 +1150,27 @@ type unitData struct {
 	machined     *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific application.
+// exposedChange contains the changed exposed flag and expose
+// settings for one specific application.
 type exposedChange struct {
-	applicationd *applicationData
-	exposed      bool
+	applicationd     *applicationData
+	exposed          bool
+	exposedEndpoints map[string]params.ExposedEndpoint
 }
 
 // applicationData holds application details and watches exposure changes.
 type applicationData struct {
-	catacomb    catacomb.Catacomb
-	fw          *Firewaller
-	application *firewaller.Application
-	exposed     bool
-	unitds      map[names.UnitTag]*unitData
+	catacomb         catacomb.Catacomb
+	fw               *Firewaller
+	application      *firewaller.Application
+	exposed          bool
+	exposedEndpoints map[string]params.ExposedEndpoint
+	unitds           map[names.UnitTag]*unitData
 }
 
-// watchLoop watches the application's exposed flag for changes.
-func (ad *applicationData) watchLoop(exposed bool) error {
+// watchLoop watches the application's exposed flag and
+// expose settings for changes.
+func (ad *applicationData) watchLoop(exposed bool, exposedEndpoints map[string]params.ExposedEndpoint) error {
 	appWatcher, err := ad.application.Watch()
 	if err != nil {
 		if params.IsCodeNotFound(err) {
@@ -1025,19 +1195,20 @@ func (ad *applicationData) watchLoop(exposed bool) error {
 				}
 				return nil
 			}
-			change, err := ad.application.IsExposed()
+			change, changedEndpoints, err := ad.application.ExposeInfo()
 			if err != nil {
 				return errors.Trace(err)
 			}
-			if change == exposed {
+			if change == exposed && reflect.DeepEqual(changedEndpoints, exposedEndpoints) {
 				continue
 			}
 
 			exposed = change
+			exposedEndpoints = changedEndpoints
 			select {
 			case <-ad.catacomb.Dying():
 				return ad.catacomb.ErrDying()
-			case ad.fw.exposedChange <- &exposedChange{ad, change}:
+			case ad.fw.exposedChange <- &exposedChange{ad, change, changedEndpoints}:
 			}
 		}
 	}
@@ -5,8 +5,10 @@ package firewaller_test
 
 import (
 	"reflect"
+	"sync"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -19,6 +21,7 @@ import (
 	apifirewaller "github.com/juju/juju/api/firewaller"
 	"github.com/juju/juju/api/remotefirewaller"
 	"github.com/juju/juju/api/remoterelations"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/instance"
@@ -341,6 +344,91 @@ func (s *InstanceModeSuite) TestMachineWithoutInstanceId(c *gc.C) {
 	})
 }
 
+// egressEnviron is an Environ which records
+// the egress rules applied to its machines.
+type egressEnviron struct {
+	environs.Environ
+
+	mu    sync.Mutex
+	rules map[string][]network.EgressRule
+}
+
+func (e *egressEnviron) SupportsEgressRules() (bool, error) {
+	return true, nil
+}
+
+func (e *egressEnviron) SetEgressRules(machineId string, rules []network.EgressRule) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rules[machineId] = rules
+	return nil
+}
+
+func (e *egressEnviron) EgressRules(machineId string) ([]network.EgressRule, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	rules, ok := e.rules[machineId]
+	if !ok {
+		return nil, errors.NotFoundf("machine %q", machineId)
+	}
+	return rules, nil
+}
+
+func (s *InstanceModeSuite) TestEgressRules(c *gc.C) {
+	env := &egressEnviron{
+		Environ: s.Environ,
+		rules:   make(map[string][]network.EgressRule),
+	}
+	s.mockClock = &mockClock{c: c}
+	fw, err := firewaller.NewFirewaller(firewaller.Config{
+		ModelUUID:          s.State.ModelUUID(),
+		Mode:               config.FwInstance,
+		EnvironFirewaller:  env,
+		EnvironInstances:   s.Environ,
+		FirewallerAPI:      s.firewaller,
+		RemoteRelationsApi: s.remoteRelations,
+		NewRemoteFirewallerAPIFunc: func(modelUUID string) (firewaller.RemoteFirewallerAPICloser, error) {
+			return s.remotefirewaller, nil
+		},
+		Clock: s.mockClock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingService(c, "wordpress", s.charm)
+	rule := network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8")
+	err = app.SetEgressRules([]network.EgressRule{rule})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The rules are applied once the machine is provisioned.
+	_, m := s.addUnit(c, app)
+	s.startInstance(c, m)
+	assertEgressRule := func(expected bool) {
+		for a := coretesting.LongAttempt.Start(); a.Next(); {
+			s.BackingState.StartSync()
+			rules, err := env.EgressRules(m.Id())
+			if errors.IsNotFound(err) {
+				continue
+			}
+			c.Assert(err, jc.ErrorIsNil)
+			found := false
+			for _, r := range rules {
+				found = found || reflect.DeepEqual(r, rule)
+			}
+			if found == expected {
+				return
+			}
+		}
+		c.Fatalf("timed out waiting for egress rules of machine %q", m.Id())
+	}
+	assertEgressRule(true)
+
+	// Removing the policy lifts the restriction.
+	err = app.SetEgressRules(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	assertEgressRule(false)
+}
+
 func (s *InstanceModeSuite) TestMultipleUnits(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
@@ -490,6 +578,64 @@ func (s *InstanceModeSuite) TestSetClearExposedApplication(c *gc.C) {
 	s.assertPorts(c, inst, m.Id(), nil)
 }
 
+func (s *InstanceModeSuite) TestExposedApplicationToCIDRs(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingService(c, "wordpress", s.charm)
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err := u.OpenEndpointPorts("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenEndpointPorts("monitoring-port", "tcp", 8080, 8080)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPort("tcp", 22)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the ports opened for the exposed endpoint are opened.
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"url": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24"),
+	})
+
+	// Each port is exposed to the CIDRs of its own endpoint.
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"monitoring-port": {ExposeToCIDRs: []string{"192.168.1.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "192.168.1.0/24"),
+	})
+
+	// Ports of endpoints without settings, and ports not opened for
+	// an endpoint, use the settings for all endpoints.
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"172.16.0.0/16"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.UnsetExposeSettings([]string{"url"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "172.16.0.0/16"),
+		network.MustNewIngressRule("tcp", 80, 80, "172.16.0.0/16"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "192.168.1.0/24"),
+	})
+
+	// Exposing the application to all sources replaces the settings.
+	err = app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+	})
+}
+
 func (s *InstanceModeSuite) TestRemoveUnit(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
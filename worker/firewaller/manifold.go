@@ -111,12 +111,13 @@ func (cfg ManifoldConfig) start(context dependency.Context) (worker.Worker, erro
 	}
 
 	w, err := cfg.NewFirewallerWorker(Config{
-		ModelUUID:          agent.CurrentConfig().Model().Id(),
-		RemoteRelationsApi: remoteRelationsAPI,
-		FirewallerAPI:      firewallerAPI,
-		EnvironFirewaller:  environ,
-		EnvironInstances:   environ,
-		Mode:               mode,
+		ModelUUID:                  agent.CurrentConfig().Model().Id(),
+		RemoteRelationsApi:         remoteRelationsAPI,
+		FirewallerAPI:              firewallerAPI,
+		EnvironFirewaller:          environ,
+		EnvironInstances:           environ,
+		Mode:                       mode,
+		ExposeIPv6:                 environ.Config().PreferredAddressFamily() == "ipv6",
 		NewRemoteFirewallerAPIFunc: remoteFirewallerAPIFunc(apiConnForModelFunc),
 	})
 	if err != nil {
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/logfwd"
+	logfwdhttp "github.com/juju/juju/logfwd/http"
 	"github.com/juju/juju/logfwd/syslog"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/version"
@@ -86,8 +87,12 @@ func (s *LogForwarderSuite) newLogForwarderArgsWithAPI(
 		LogForwardConfig: configAPI,
 		AllModels:        true,
 		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
-		OpenSink: func(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-			sender.host = cfg.Host
+		SinkConfig: func(api logforwarder.LogForwardConfig) (logforwarder.SinkConfig, bool, error) {
+			cfg, ok, err := api.LogForwardConfig()
+			return cfg, ok && cfg.Enabled, err
+		},
+		OpenSink: func(cfg logforwarder.SinkConfig) (*logforwarder.LogSink, error) {
+			sender.host = cfg.(*syslog.RawConfig).Host
 			sink := &logforwarder.LogSink{
 				sender,
 			}
@@ -259,6 +264,10 @@ func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, erro
 	}, true, nil
 }
 
+func (c *mockLogForwardConfig) LogForwardHTTPConfig() (*logfwdhttp.RawConfig, bool, error) {
+	return nil, false, nil
+}
+
 type stubStream struct {
 	stub     *testing.Stub
 	nextRecs chan logfwd.Record
@@ -0,0 +1,43 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	logfwdhttp "github.com/juju/juju/logfwd/http"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// HTTPConfig returns the current HTTP log forwarding config, and
+// whether forwarding over HTTP is enabled.
+func HTTPConfig(api logforwarder.LogForwardConfig) (logforwarder.SinkConfig, bool, error) {
+	cfg, ok, err := api.LogForwardHTTPConfig()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if !ok {
+		return nil, false, nil
+	}
+	return cfg, cfg.Enabled && cfg.URL != "", nil
+}
+
+// OpenHTTP returns a sink that POSTs batches of log records, encoded
+// as JSON, to the configured URL.
+func OpenHTTP(sinkCfg logforwarder.SinkConfig) (*logforwarder.LogSink, error) {
+	cfg, ok := sinkCfg.(*logfwdhttp.RawConfig)
+	if !ok {
+		return nil, errors.Errorf("expected HTTP log forwarding config, got %T", sinkCfg)
+	}
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := logfwdhttp.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{
+		SendCloser: client,
+	}, nil
+}
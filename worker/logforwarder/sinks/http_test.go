@@ -0,0 +1,89 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	logfwdhttp "github.com/juju/juju/logfwd/http"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/logforwarder/sinks"
+)
+
+type HTTPSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&HTTPSuite{})
+
+func (s *HTTPSuite) TestHTTPConfigEnabled(c *gc.C) {
+	api := &fakeLogForwardConfig{httpCfg: &logfwdhttp.RawConfig{
+		Enabled: true,
+		URL:     "https://logs.example.com",
+	}}
+	cfg, enabled, err := sinks.HTTPConfig(api)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(enabled, jc.IsTrue)
+	c.Check(cfg, gc.Equals, api.httpCfg)
+}
+
+func (s *HTTPSuite) TestHTTPConfigNotEnabled(c *gc.C) {
+	for i, httpCfg := range []*logfwdhttp.RawConfig{
+		nil,
+		{URL: "https://logs.example.com"},
+		{Enabled: true},
+	} {
+		c.Logf("test %d: %#v", i, httpCfg)
+		api := &fakeLogForwardConfig{httpCfg: httpCfg}
+		_, enabled, err := sinks.HTTPConfig(api)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(enabled, jc.IsFalse)
+	}
+}
+
+func (s *HTTPSuite) TestHTTPConfigError(c *gc.C) {
+	api := &fakeLogForwardConfig{err: errors.New("boom")}
+	_, _, err := sinks.HTTPConfig(api)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *HTTPSuite) TestOpenHTTPWrongConfig(c *gc.C) {
+	_, err := sinks.OpenHTTP(&syslog.RawConfig{Enabled: true})
+	c.Assert(err, gc.ErrorMatches, `expected HTTP log forwarding config, got \*syslog.RawConfig`)
+}
+
+func (s *HTTPSuite) TestOpenHTTPNotEnabled(c *gc.C) {
+	_, err := sinks.OpenHTTP(&logfwdhttp.RawConfig{URL: "https://logs.example.com"})
+	c.Assert(err, gc.ErrorMatches, "log forwarding not enabled")
+}
+
+func (s *HTTPSuite) TestOpenHTTP(c *gc.C) {
+	sink, err := sinks.OpenHTTP(&logfwdhttp.RawConfig{
+		Enabled: true,
+		URL:     "https://logs.example.com",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sink.Close(), jc.ErrorIsNil)
+}
+
+type fakeLogForwardConfig struct {
+	httpCfg *logfwdhttp.RawConfig
+	err     error
+}
+
+func (*fakeLogForwardConfig) WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error) {
+	return nil, errors.NotImplementedf("WatchForLogForwardConfigChanges")
+}
+
+func (*fakeLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, error) {
+	return nil, false, nil
+}
+
+func (f *fakeLogForwardConfig) LogForwardHTTPConfig() (*logfwdhttp.RawConfig, bool, error) {
+	return f.httpCfg, f.httpCfg != nil, f.err
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/juju/juju/api/base"
 	logfwdapi "github.com/juju/juju/api/logfwd"
 	"github.com/juju/juju/logfwd"
-	"github.com/juju/juju/logfwd/syslog"
 )
 
 // TrackingSinkArgs holds the args to OpenTrackingSender.
@@ -19,7 +18,7 @@ type TrackingSinkArgs struct {
 	AllModels bool
 
 	// Config is the logging config that will be used.
-	Config *syslog.RawConfig
+	Config SinkConfig
 
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
@@ -6,6 +6,7 @@ package logsender
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -23,6 +24,10 @@ type LogRecord struct {
 	Level    loggo.Level
 	Message  string
 
+	// Labels are attached to the record when it is written, so that
+	// debug-log can select records by label.
+	Labels []string
+
 	// Number of messages dropped after this one due to buffer limit.
 	DroppedAfter int
 }
@@ -153,9 +158,27 @@ func (w *BufferedLogWriter) Write(entry loggo.Entry) {
 		Location: fmt.Sprintf("%s:%d", filepath.Base(entry.Filename), entry.Line),
 		Level:    entry.Level,
 		Message:  entry.Message,
+		Labels:   moduleLabels(entry.Module),
 	}
 }
 
+// charmModulePrefix is the prefix of the loggers used by the uniter
+// for hook output and juju-log messages.
+const charmModulePrefix = "unit."
+
+// CharmLabel is attached to log records written by charms, so that
+// their output can be selected with debug-log --include-label.
+const CharmLabel = "charm"
+
+// moduleLabels returns the labels for records logged by the named
+// module.
+func moduleLabels(module string) []string {
+	if strings.HasPrefix(module, charmModulePrefix) {
+		return []string{CharmLabel}
+	}
+	return nil
+}
+
 // Logs returns a channel which emits log messages that have been sent
 // to the BufferedLogWriter instance.
 func (w *BufferedLogWriter) Logs() LogRecordCh {
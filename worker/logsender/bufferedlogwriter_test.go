@@ -77,6 +77,26 @@ func (s *bufferedLogWriterSuite) TestBuffering(c *gc.C) {
 	}
 }
 
+func (s *bufferedLogWriterSuite) TestCharmLabel(c *gc.C) {
+	now := time.Now()
+	s.writer.Write(loggo.Entry{
+		Level:     loggo.INFO,
+		Module:    "unit.mysql/0.juju-log",
+		Filename:  "juju-log.go",
+		Line:      42,
+		Timestamp: now,
+		Message:   "hello",
+	})
+	c.Assert(*s.receiveOne(c), gc.DeepEquals, logsender.LogRecord{
+		Time:     now,
+		Module:   "unit.mysql/0.juju-log",
+		Location: "juju-log.go:42",
+		Level:    loggo.INFO,
+		Message:  "hello",
+		Labels:   []string{logsender.CharmLabel},
+	})
+}
+
 func (s *bufferedLogWriterSuite) TestLimiting(c *gc.C) {
 	write := func(msgNum int) {
 		s.writer.Write(
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/api"
 	apilogsender "github.com/juju/juju/api/logsender"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
 	"github.com/juju/juju/version"
@@ -184,3 +185,50 @@ func (s *workerSuite) TestDroppedLogs(c *gc.C) {
 	})
 	c.Assert(docs[2]["x"], gc.Equals, "message1")
 }
+
+func (s *workerSuite) TestLabelledLogs(c *gc.C) {
+	writer := logsender.NewBufferedLogWriter(10)
+	defer writer.Close()
+
+	// Start the logsender worker.
+	worker := logsender.New(writer.Logs(), s.logSenderAPI())
+	defer func() {
+		worker.Kill()
+		c.Check(worker.Wait(), jc.ErrorIsNil)
+	}()
+
+	// Log a charm message and an agent message through the writer
+	// installed in the agents.
+	ts := time.Now()
+	writer.Write(loggo.Entry{
+		Level:     loggo.INFO,
+		Module:    "juju.worker.uniter",
+		Filename:  "uniter.go",
+		Line:      1,
+		Timestamp: ts,
+		Message:   "agent message",
+	})
+	writer.Write(loggo.Entry{
+		Level:     loggo.INFO,
+		Module:    "unit.mysql/0.juju-log",
+		Filename:  "juju-log.go",
+		Line:      2,
+		Timestamp: ts,
+		Message:   "charm message",
+	})
+
+	// Only the charm message is selected by its label.
+	tailer, err := state.NewLogTailer(s.State, &state.LogTailerParams{
+		IncludeLabel: []string{logsender.CharmLabel},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	select {
+	case rec := <-tailer.Logs():
+		c.Check(rec.Module, gc.Equals, "unit.mysql/0.juju-log")
+		c.Check(rec.Message, gc.Equals, "charm message")
+		c.Check(rec.Labels, jc.DeepEquals, []string{logsender.CharmLabel})
+	case <-time.After(testing.LongWait):
+		c.Fatal("timed out waiting for labelled log record")
+	}
+}
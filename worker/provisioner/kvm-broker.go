@@ -128,6 +128,9 @@ func (broker *kvmBroker) StartInstance(args environs.StartInstanceParams) (*envi
 		kvmLogger.Errorf("failed to populate machine config: %v", err)
 		return nil, err
 	}
+	if config.PreferredAddressFamily != "" {
+		args.InstanceConfig.AgentEnvironment[agent.PreferredAddressFamily] = config.PreferredAddressFamily
+	}
 
 	storageConfig := &container.StorageConfig{
 		AllowMount: true,
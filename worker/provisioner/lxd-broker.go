@@ -118,6 +118,9 @@ func (broker *lxdBroker) StartInstance(args environs.StartInstanceParams) (*envi
 		lxdLogger.Errorf("failed to populate machine config: %v", err)
 		return nil, err
 	}
+	if config.PreferredAddressFamily != "" {
+		args.InstanceConfig.AgentEnvironment[agent.PreferredAddressFamily] = config.PreferredAddressFamily
+	}
 
 	storageConfig := &container.StorageConfig{}
 	inst, hardware, err := broker.manager.CreateContainer(
@@ -589,6 +589,7 @@ func constructStartInstanceParams(
 				},
 				Volume: volumeTag,
 			},
+			v.SnapshotId,
 		}
 	}
 
@@ -158,9 +158,15 @@ func updatePendingFilesystemAttachment(
 	params storage.FilesystemAttachmentParams,
 ) {
 	var incomplete bool
+	// Shared filesystems are provisioned by the model's storage
+	// provisioner, and attached by each machine's; they are never
+	// recorded in ctx.filesystems here. The attachment is attempted
+	// regardless, and the attachment parameters obtained again if the
+	// filesystem has not yet been provisioned.
+	shared := isSharedFilesystem(ctx, params.Filesystem)
 	filesystem, ok := ctx.filesystems[params.Filesystem]
 	if !ok {
-		incomplete = true
+		incomplete = !shared
 	} else {
 		params.FilesystemId = filesystem.FilesystemId
 		if filesystem.Volume != (names.VolumeTag{}) {
@@ -177,7 +183,7 @@ func updatePendingFilesystemAttachment(
 		watchMachine(ctx, params.Machine)
 		incomplete = true
 	}
-	if params.FilesystemId == "" {
+	if params.FilesystemId == "" && !shared {
 		incomplete = true
 	}
 	if incomplete {
@@ -188,6 +194,18 @@ func updatePendingFilesystemAttachment(
 	scheduleOperations(ctx, &attachFilesystemOp{args: params})
 }
 
+// isSharedFilesystem reports whether or not the specified filesystem
+// is a shared filesystem that is attached, but not provisioned, by this
+// storage provisioner. Machine-scoped storage provisioners are only told
+// about the attachments of model-scoped filesystems if they are shared.
+func isSharedFilesystem(ctx *context, tag names.FilesystemTag) bool {
+	if _, ok := ctx.config.Scope.(names.MachineTag); !ok {
+		return false
+	}
+	_, machineScoped := names.FilesystemMachine(tag)
+	return !machineScoped
+}
+
 // removePendingFilesystemAttachment removes the specified pending filesystem
 // attachment from the incomplete set and/or the schedule if it exists
 // there.
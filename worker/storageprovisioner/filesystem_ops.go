@@ -116,9 +116,24 @@ func createFilesystems(ctx *context, ops map[names.FilesystemTag]*createFilesyst
 
 // attachFilesystems creates filesystem attachments with the specified parameters.
 func attachFilesystems(ctx *context, ops map[params.MachineStorageId]*attachFilesystemOp) error {
+	var reschedule []scheduleOp
+	pending, err := refreshSharedFilesystemAttachmentParams(ctx, ops)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, id := range pending {
+		// The shared filesystem has not yet been provisioned
+		// by the model's storage provisioner; try again later.
+		logger.Debugf("waiting for %s to be provisioned", id.AttachmentTag)
+		reschedule = append(reschedule, ops[id])
+	}
+
 	filesystemAttachmentParams := make([]storage.FilesystemAttachmentParams, 0, len(ops))
 	for _, op := range ops {
 		args := op.args
+		if args.FilesystemId == "" {
+			continue
+		}
 		if args.Path == "" {
 			args.Path = filepath.Join(ctx.config.StorageDir, args.Filesystem.Id())
 		}
@@ -134,7 +149,6 @@ func attachFilesystems(ctx *context, ops map[params.MachineStorageId]*attachFile
 	if err != nil {
 		return errors.Trace(err)
 	}
-	var reschedule []scheduleOp
 	var filesystemAttachments []storage.FilesystemAttachment
 	var statuses []params.EntityStatusArgs
 	for sourceName, filesystemAttachmentParams := range paramsBySource {
@@ -146,11 +160,10 @@ func attachFilesystems(ctx *context, ops map[params.MachineStorageId]*attachFile
 		}
 		for i, result := range results {
 			p := filesystemAttachmentParams[i]
-			statuses = append(statuses, params.EntityStatusArgs{
+			entityStatus := params.EntityStatusArgs{
 				Tag:    p.Filesystem.String(),
 				Status: status.Attached.String(),
-			})
-			entityStatus := &statuses[len(statuses)-1]
+			}
 			if result.Error != nil {
 				// Reschedule the filesystem attachment.
 				id := params.MachineStorageId{
@@ -171,9 +184,15 @@ func attachFilesystems(ctx *context, ops map[params.MachineStorageId]*attachFile
 					names.ReadableString(p.Machine),
 					result.Error,
 				)
-				continue
+			} else {
+				filesystemAttachments = append(filesystemAttachments, *result.FilesystemAttachment)
+			}
+			// The status of a shared filesystem is managed by
+			// the model's storage provisioner, and not by the
+			// storage provisioner of each attached machine.
+			if !isSharedFilesystem(ctx, p.Filesystem) {
+				statuses = append(statuses, entityStatus)
 			}
-			filesystemAttachments = append(filesystemAttachments, *result.FilesystemAttachment)
 		}
 	}
 	scheduleOperations(ctx, reschedule...)
@@ -184,6 +203,38 @@ func attachFilesystems(ctx *context, ops map[params.MachineStorageId]*attachFile
 	return nil
 }
 
+// refreshSharedFilesystemAttachmentParams obtains the attachment parameters
+// again for attachments of shared filesystems whose provider IDs were unknown
+// when the operations were scheduled, updating the operations' arguments.
+// The IDs of attachments whose filesystems are still not provisioned are
+// returned.
+func refreshSharedFilesystemAttachmentParams(
+	ctx *context, ops map[params.MachineStorageId]*attachFilesystemOp,
+) ([]params.MachineStorageId, error) {
+	var ids []params.MachineStorageId
+	for id, op := range ops {
+		if op.args.FilesystemId == "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	attachmentParams, err := filesystemAttachmentParams(ctx, ids)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var pending []params.MachineStorageId
+	for i, id := range ids {
+		op := ops[id]
+		op.args.FilesystemId = attachmentParams[i].FilesystemId
+		if op.args.FilesystemId == "" {
+			pending = append(pending, id)
+		}
+	}
+	return pending, nil
+}
+
 // destroyFilesystems destroys filesystems with the specified parameters.
 func destroyFilesystems(ctx *context, ops map[names.FilesystemTag]*destroyFilesystemOp) error {
 	tags := make([]names.FilesystemTag, 0, len(ops))
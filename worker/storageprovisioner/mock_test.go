@@ -91,11 +91,13 @@ func (w *mockAttachmentsWatcher) Changes() watcher.MachineStorageIdsChannel {
 type mockVolumeAccessor struct {
 	volumesWatcher         *mockStringsWatcher
 	attachmentsWatcher     *mockAttachmentsWatcher
+	resizesWatcher         *mockStringsWatcher
 	blockDevicesWatcher    *mockNotifyWatcher
 	provisionedMachines    map[string]instance.Id
 	provisionedVolumes     map[string]params.Volume
 	provisionedAttachments map[params.MachineStorageId]params.VolumeAttachment
 	blockDevices           map[params.MachineStorageId]storage.BlockDevice
+	resizedVolumes         map[string]params.VolumeResizeParams
 
 	setVolumeInfo           func([]params.Volume) ([]params.ErrorResult, error)
 	setVolumeAttachmentInfo func([]params.VolumeAttachment) ([]params.ErrorResult, error)
@@ -124,6 +126,24 @@ func (w *mockVolumeAccessor) WatchBlockDevices(tag names.MachineTag) (watcher.No
 	return w.blockDevicesWatcher, nil
 }
 
+func (w *mockVolumeAccessor) WatchVolumeResizes() (watcher.StringsWatcher, error) {
+	return w.resizesWatcher, nil
+}
+
+func (v *mockVolumeAccessor) VolumeResizeParams(volumes []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	var result []params.VolumeResizeParamsResult
+	for _, tag := range volumes {
+		if resizeParams, ok := v.resizedVolumes[tag.String()]; ok {
+			result = append(result, params.VolumeResizeParamsResult{Result: resizeParams})
+		} else {
+			result = append(result, params.VolumeResizeParamsResult{
+				Error: common.ServerError(errors.NotFoundf("pending resize of volume %q", tag.Id())),
+			})
+		}
+	}
+	return result, nil
+}
+
 func (v *mockVolumeAccessor) Volumes(volumes []names.VolumeTag) ([]params.VolumeResult, error) {
 	var result []params.VolumeResult
 	for _, tag := range volumes {
@@ -229,11 +249,13 @@ func newMockVolumeAccessor() *mockVolumeAccessor {
 	return &mockVolumeAccessor{
 		volumesWatcher:         newMockStringsWatcher(),
 		attachmentsWatcher:     newMockAttachmentsWatcher(),
+		resizesWatcher:         newMockStringsWatcher(),
 		blockDevicesWatcher:    newMockNotifyWatcher(),
 		provisionedMachines:    make(map[string]instance.Id),
 		provisionedVolumes:     make(map[string]params.Volume),
 		provisionedAttachments: make(map[params.MachineStorageId]params.VolumeAttachment),
 		blockDevices:           make(map[params.MachineStorageId]storage.BlockDevice),
+		resizedVolumes:         make(map[string]params.VolumeResizeParams),
 	}
 }
 
@@ -324,9 +346,11 @@ func (f *mockFilesystemAccessor) FilesystemAttachmentParams(ids []params.Machine
 		// Parameters are returned regardless of whether the attachment
 		// exists; this is to support reattachment.
 		instanceId := f.provisionedMachines[id.MachineTag]
+		filesystem := f.provisionedFilesystems[id.AttachmentTag]
 		result = append(result, params.FilesystemAttachmentParamsResult{Result: params.FilesystemAttachmentParams{
 			MachineTag:    id.MachineTag,
 			FilesystemTag: id.AttachmentTag,
+			FilesystemId:  filesystem.Info.FilesystemId,
 			InstanceId:    string(instanceId),
 			Provider:      "dummy",
 			ReadOnly:      true,
@@ -430,6 +454,7 @@ type dummyProvider struct {
 	detachVolumesFunc            func([]storage.VolumeAttachmentParams) ([]error, error)
 	detachFilesystemsFunc        func([]storage.FilesystemAttachmentParams) ([]error, error)
 	destroyVolumesFunc           func([]string) ([]error, error)
+	resizeVolumesFunc            func([]storage.ResizeVolumeParams) ([]storage.ResizeVolumesResult, error)
 	destroyFilesystemsFunc       func([]string) ([]error, error)
 	validateVolumeParamsFunc     func(storage.VolumeParams) error
 	validateFilesystemParamsFunc func(storage.FilesystemParams) error
@@ -540,6 +565,17 @@ func (s *dummyVolumeSource) DetachVolumes(params []storage.VolumeAttachmentParam
 	return make([]error, len(params)), nil
 }
 
+func (s *dummyVolumeSource) ResizeVolumes(params []storage.ResizeVolumeParams) ([]storage.ResizeVolumesResult, error) {
+	if s.provider.resizeVolumesFunc != nil {
+		return s.provider.resizeVolumesFunc(params)
+	}
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		results[i].Size = p.Size
+	}
+	return results, nil
+}
+
 func (s *dummyFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
 	if s.provider != nil && s.provider.validateFilesystemParamsFunc != nil {
 		return s.provider.validateFilesystemParamsFunc(params)
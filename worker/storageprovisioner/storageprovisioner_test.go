@@ -980,6 +980,52 @@ func (s *storageProvisionerSuite) TestFilesystemAttachmentAdded(c *gc.C) {
 	assertNoEvent(c, filesystemAttachmentInfoSet, "filesystem attachment info set")
 }
 
+func (s *storageProvisionerSuite) TestSharedFilesystemAttachmentAdded(c *gc.C) {
+	// The model-scoped filesystem-1 is shared, so it is attached by the
+	// machine's storage provisioner even though the provisioner does not
+	// manage the filesystem itself.
+	filesystemAttachmentInfoSet := make(chan interface{})
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.setFilesystemAttachmentInfo = func(filesystemAttachments []params.FilesystemAttachment) ([]params.ErrorResult, error) {
+		filesystemAttachmentInfoSet <- filesystemAttachments
+		return make([]params.ErrorResult, len(filesystemAttachments)), nil
+	}
+	filesystemAccessor.provisionedFilesystems["filesystem-1"] = params.Filesystem{
+		FilesystemTag: "filesystem-1",
+		Info: params.FilesystemInfo{
+			FilesystemId: "10.0.0.1:/srv/data",
+		},
+	}
+	filesystemAccessor.provisionedMachines["machine-0"] = instance.Id("already-provisioned-0")
+	statusSetter := &mockStatusSetter{}
+
+	args := &workerArgs{
+		scope:        names.NewMachineTag("0"),
+		filesystems:  filesystemAccessor,
+		registry:     s.registry,
+		statusSetter: statusSetter,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	filesystemAccessor.attachmentsWatcher.changes <- []watcher.MachineStorageId{{
+		MachineTag: "machine-0", AttachmentTag: "filesystem-1",
+	}}
+	info := waitChannel(c, filesystemAttachmentInfoSet, "waiting for filesystem attachments to be set")
+	c.Assert(info, jc.DeepEquals, []params.FilesystemAttachment{{
+		FilesystemTag: "filesystem-1",
+		MachineTag:    "machine-0",
+		Info: params.FilesystemAttachmentInfo{
+			MountPoint: "/srv/10.0.0.1:/srv/data",
+		},
+	}})
+
+	// The status of the shared filesystem is not set by the
+	// machine's storage provisioner.
+	c.Assert(statusSetter.args, gc.HasLen, 0)
+}
+
 func (s *storageProvisionerSuite) TestCreateVolumeBackedFilesystem(c *gc.C) {
 	filesystemInfoSet := make(chan interface{})
 	filesystemAccessor := newMockFilesystemAccessor()
@@ -1574,6 +1620,88 @@ func (s *storageProvisionerSuite) TestDestroyVolumes(c *gc.C) {
 	assertNoEvent(c, removedChan, "volumes removed")
 }
 
+func (s *storageProvisionerSuite) TestResizeVolumes(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	provisioned := volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	provisioned.Info.HardwareId = "abc"
+	provisioned.Info.Size = 1024
+	volumeAccessor.provisionedVolumes["volume-1"] = provisioned
+	volumeAccessor.resizedVolumes["volume-1"] = params.VolumeResizeParams{
+		VolumeTag: "volume-1",
+		VolumeId:  "vol-1",
+		Size:      2048,
+		Provider:  "dummy",
+	}
+
+	resizedChan := make(chan interface{}, 1)
+	s.provider.resizeVolumesFunc = func(args []storage.ResizeVolumeParams) ([]storage.ResizeVolumesResult, error) {
+		resizedChan <- args
+		return []storage.ResizeVolumesResult{{Size: 2560}}, nil
+	}
+	volumeInfoSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		volumeInfoSet <- volumes
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Volume "2" has no pending resize, so it is ignored.
+	volumeAccessor.resizesWatcher.changes <- []string{"1", "2"}
+	resized := waitChannel(c, resizedChan, "waiting for volume to be resized")
+	c.Assert(resized, jc.DeepEquals, []storage.ResizeVolumeParams{{
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Size:     2048,
+	}})
+
+	// The new size is recorded, leaving the other details intact.
+	volumes := waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(volumes, jc.DeepEquals, []params.Volume{{
+		VolumeTag: "volume-1",
+		Info: params.VolumeInfo{
+			VolumeId:   "vol-1",
+			HardwareId: "abc",
+			Size:       2560,
+		},
+	}})
+	assertNoEvent(c, resizedChan, "volume resized again")
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumesRetry(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.resizedVolumes["volume-1"] = params.VolumeResizeParams{
+		VolumeTag: "volume-1",
+		VolumeId:  "vol-1",
+		Size:      2048,
+		Provider:  "dummy",
+	}
+
+	var resizeCalls int
+	resizedChan := make(chan interface{}, 1)
+	s.provider.resizeVolumesFunc = func(args []storage.ResizeVolumeParams) ([]storage.ResizeVolumesResult, error) {
+		resizeCalls++
+		if resizeCalls == 1 {
+			return []storage.ResizeVolumesResult{{Error: errors.New("badness")}}, nil
+		}
+		resizedChan <- args
+		return []storage.ResizeVolumesResult{{Size: 2048}}, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{"1"}
+	waitChannel(c, resizedChan, "waiting for volume to be resized")
+	c.Assert(resizeCalls, gc.Equals, 2)
+}
+
 func (s *storageProvisionerSuite) TestDestroyVolumesRetry(c *gc.C) {
 	volume := names.NewVolumeTag("1")
 	volumeAccessor := newMockVolumeAccessor()
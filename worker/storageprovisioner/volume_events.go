@@ -54,6 +54,42 @@ func volumesChanged(ctx *context, changes []string) error {
 	return nil
 }
 
+// volumeResizesChanged is called when the volumes with the provided IDs
+// have been seen to have changed, and may have a pending resize.
+func volumeResizesChanged(ctx *context, changes []string) error {
+	tags := make([]names.VolumeTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewVolumeTag(change)
+	}
+	resizeParams, err := ctx.config.Volumes.VolumeResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize parameters")
+	}
+	for i, result := range resizeParams {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) {
+				// There is no pending resize for the volume,
+				// or the volume has been removed.
+				ctx.schedule.Remove(resizeVolumeKey{tags[i]})
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting resize parameters for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		op, err := resizeVolumeOpFromParams(result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// Replace any previously scheduled resize, as the
+		// requested size may have changed.
+		ctx.schedule.Remove(op.key())
+		scheduleOperations(ctx, op)
+	}
+	return nil
+}
+
 // volumeAttachmentsChanged is called when the lifecycle states of the volume
 // attachments with the provided IDs have been seen to have changed.
 func volumeAttachmentsChanged(ctx *context, watcherIds []watcher.MachineStorageId) error {
@@ -478,6 +514,22 @@ func volumeParamsFromParams(in params.VolumeParams) (storage.VolumeParams, error
 		in.Attributes,
 		in.Tags,
 		attachment,
+		in.SnapshotId,
+	}, nil
+}
+
+func resizeVolumeOpFromParams(in params.VolumeResizeParams) (*resizeVolumeOp, error) {
+	volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &resizeVolumeOp{
+		provider: storage.ProviderType(in.Provider),
+		args: storage.ResizeVolumeParams{
+			Volume:   volumeTag,
+			VolumeId: in.VolumeId,
+			Size:     in.Size,
+		},
 	}, nil
 }
 
@@ -334,6 +334,102 @@ func detachVolumes(ctx *context, ops map[params.MachineStorageId]*detachVolumeOp
 	return nil
 }
 
+// resizeVolumes grows volumes with the specified parameters.
+func resizeVolumes(ctx *context, ops map[names.VolumeTag]*resizeVolumeOp) error {
+	paramsBySource := make(map[storage.ProviderType][]storage.ResizeVolumeParams)
+	for _, op := range ops {
+		paramsBySource[op.provider] = append(paramsBySource[op.provider], op.args)
+	}
+	var reschedule []scheduleOp
+	sizes := make(map[names.VolumeTag]uint64)
+	for providerType, resizeParams := range paramsBySource {
+		logger.Debugf("resizing volumes from %q: %v", providerType, resizeParams)
+		source, err := volumeSource(
+			ctx.config.StorageDir, string(providerType), providerType, ctx.config.Registry,
+		)
+		if errors.Cause(err) == errNonDynamic {
+			source = nil
+		} else if err != nil {
+			return errors.Annotate(err, "getting volume source")
+		}
+		resizer, ok := source.(storage.VolumeResizer)
+		if !ok {
+			// There's no point rescheduling, as the
+			// provider will never be able to resize.
+			for _, p := range resizeParams {
+				logger.Errorf(
+					"cannot resize %s: resizing %q volumes not supported",
+					names.ReadableString(p.Volume), providerType,
+				)
+			}
+			continue
+		}
+		results, err := resizer.ResizeVolumes(resizeParams)
+		if err != nil {
+			return errors.Annotatef(err, "resizing volumes from source %q", providerType)
+		}
+		for i, result := range results {
+			tag := resizeParams[i].Volume
+			if result.Error != nil {
+				reschedule = append(reschedule, ops[tag])
+				logger.Warningf(
+					"failed to resize %s: %v",
+					names.ReadableString(tag), result.Error,
+				)
+				continue
+			}
+			sizes[tag] = result.Size
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(sizes) == 0 {
+		return nil
+	}
+
+	// Update the recorded size of each resized volume, leaving the
+	// remaining details intact. Recording a size at least as large
+	// as requested completes the resize.
+	tags := make([]names.VolumeTag, 0, len(sizes))
+	for tag := range sizes {
+		tags = append(tags, tag)
+	}
+	volumeResults, err := ctx.config.Volumes.Volumes(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume information")
+	}
+	var volumes []storage.Volume
+	for i, result := range volumeResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "getting information for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		volume, err := volumeFromParams(result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		volume.Size = sizes[tags[i]]
+		volumes = append(volumes, volume)
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeInfo(volumesFromStorage(volumes))
+	if err != nil {
+		return errors.Annotate(err, "publishing volumes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing volume %s to state: %v",
+				volumes[i].Tag.Id(),
+				result.Error,
+			)
+			continue
+		}
+		updateVolume(ctx, volumes[i])
+	}
+	return nil
+}
+
 // volumeParamsBySource separates the volume parameters by volume source.
 func volumeParamsBySource(
 	baseStorageDir string,
@@ -495,3 +591,20 @@ func (op *detachVolumeOp) key() interface{} {
 		AttachmentTag: op.args.Volume.String(),
 	}
 }
+
+// resizeVolumeKey is the schedule key for a resizeVolumeOp, distinct
+// from the keys of the other volume operations so that a volume may
+// be resized while other operations are pending.
+type resizeVolumeKey struct {
+	tag names.VolumeTag
+}
+
+type resizeVolumeOp struct {
+	exponentialBackoff
+	provider storage.ProviderType
+	args     storage.ResizeVolumeParams
+}
+
+func (op *resizeVolumeOp) key() interface{} {
+	return resizeVolumeKey{op.args.Volume}
+}
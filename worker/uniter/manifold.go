@@ -6,6 +6,7 @@ package uniter
 import (
 	"github.com/juju/errors"
 	"github.com/juju/utils/clock"
+	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/juju/names.v2"
 	worker "gopkg.in/juju/worker.v1"
 
@@ -31,6 +32,10 @@ type ManifoldConfig struct {
 	CharmDirName          string
 	HookRetryStrategyName string
 	TranslateResolverErr  func(error) error
+
+	// PrometheusRegisterer, if non-nil, is used to register the
+	// uniter's hook metrics.
+	PrometheusRegisterer prometheus.Registerer
 }
 
 // Manifold returns a dependency manifold that runs a uniter worker,
@@ -102,6 +107,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				NewOperationExecutor: operation.NewExecutor,
 				TranslateResolverErr: config.TranslateResolverErr,
 				Clock:                manifoldConfig.Clock,
+				PrometheusRegisterer: manifoldConfig.PrometheusRegisterer,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
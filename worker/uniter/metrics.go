@@ -0,0 +1,73 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	hookLabel   = "hook"
+	resultLabel = "result"
+
+	hookSucceeded = "succeeded"
+	hookFailed    = "failed"
+)
+
+// HookMetrics is a prometheus.Collector that records the duration and
+// outcome of the hooks run by a uniter. The number of hooks run, and
+// so the hook failure rate, is available from the histogram's count.
+type HookMetrics struct {
+	durations *prometheus.HistogramVec
+}
+
+// NewHookMetrics returns a new HookMetrics.
+func NewHookMetrics() *HookMetrics {
+	return &HookMetrics{
+		durations: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "juju",
+				Subsystem: "uniter",
+				Name:      "hook_duration_seconds",
+				Help:      "Time taken to run charm hooks, by hook name and result.",
+				Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300, 600, 1800},
+			},
+			[]string{hookLabel, resultLabel},
+		),
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (m *HookMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.durations.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (m *HookMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.durations.Collect(ch)
+}
+
+// Observe records that the named hook ran for the given duration,
+// and whether it failed.
+func (m *HookMetrics) Observe(hookName string, failed bool, duration time.Duration) {
+	result := hookSucceeded
+	if failed {
+		result = hookFailed
+	}
+	m.durations.With(prometheus.Labels{
+		hookLabel:   hookName,
+		resultLabel: result,
+	}).Observe(duration.Seconds())
+}
+
+// observeHook records the duration and outcome of the hook that has
+// just finished running, if hook metrics are being collected.
+func (u *Uniter) observeHook(hookName string, failed bool) {
+	if u.hookMetrics == nil {
+		return
+	}
+	u.hookMetrics.Observe(hookName, failed, u.clock.Now().Sub(u.hookStarted))
+}
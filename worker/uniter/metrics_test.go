@@ -0,0 +1,64 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter"
+)
+
+type HookMetricsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&HookMetricsSuite{})
+
+func (s *HookMetricsSuite) TestObserve(c *gc.C) {
+	metrics := uniter.NewHookMetrics()
+	metrics.Observe("install", false, 3*time.Second)
+	metrics.Observe("config-changed", false, time.Second)
+	metrics.Observe("config-changed", true, 2*time.Second)
+	metrics.Observe("config-changed", false, 5*time.Second)
+
+	registry := prometheus.NewPedanticRegistry()
+	err := registry.Register(metrics)
+	c.Assert(err, jc.ErrorIsNil)
+	families, err := registry.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(families, gc.HasLen, 1)
+	c.Assert(families[0].GetName(), gc.Equals, "juju_uniter_hook_duration_seconds")
+
+	type sample struct {
+		count uint64
+		sum   float64
+	}
+	samples := make(map[string]sample)
+	for _, m := range families[0].GetMetric() {
+		samples[labelValue(m, "hook")+" "+labelValue(m, "result")] = sample{
+			count: m.GetHistogram().GetSampleCount(),
+			sum:   m.GetHistogram().GetSampleSum(),
+		}
+	}
+	c.Assert(samples, jc.DeepEquals, map[string]sample{
+		"install succeeded":        {1, 3},
+		"config-changed succeeded": {2, 6},
+		"config-changed failed":    {1, 2},
+	})
+}
+
+func labelValue(m *dto.Metric, name string) string {
+	for _, pair := range m.GetLabel() {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return ""
+}
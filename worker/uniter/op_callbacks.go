@@ -34,7 +34,7 @@ func (opc *operationCallbacks) PrepareHook(hi hook.Info) (string, error) {
 		if err != nil {
 			return "", err
 		}
-	case hi.Kind.IsStorage():
+	case hook.IsStorage(hi.Kind):
 		if err := opc.u.storage.ValidateHook(hi); err != nil {
 			return "", err
 		}
@@ -60,7 +60,7 @@ func (opc *operationCallbacks) CommitHook(hi hook.Info) error {
 	switch {
 	case hi.Kind.IsRelation():
 		return opc.u.relations.CommitHook(hi)
-	case hi.Kind.IsStorage():
+	case hook.IsStorage(hi.Kind):
 		return opc.u.storage.CommitHook(hi)
 	}
 	return nil
@@ -77,8 +77,14 @@ func notifyHook(hook string, ctx runner.Context, method func(string)) {
 	method(hook)
 }
 
+// NotifyHookStarted is part of the operation.Callbacks interface.
+func (opc *operationCallbacks) NotifyHookStarted(hook string, ctx runner.Context) {
+	opc.u.hookStarted = opc.u.clock.Now()
+}
+
 // NotifyHookCompleted is part of the operation.Callbacks interface.
 func (opc *operationCallbacks) NotifyHookCompleted(hook string, ctx runner.Context) {
+	opc.u.observeHook(hook, false)
 	if opc.u.observer != nil {
 		notifyHook(hook, ctx, opc.u.observer.HookCompleted)
 	}
@@ -86,6 +92,7 @@ func (opc *operationCallbacks) NotifyHookCompleted(hook string, ctx runner.Conte
 
 // NotifyHookFailed is part of the operation.Callbacks interface.
 func (opc *operationCallbacks) NotifyHookFailed(hook string, ctx runner.Context) {
+	opc.u.observeHook(hook, true)
 	if opc.u.observer != nil {
 		notifyHook(hook, ctx, opc.u.observer.HookFailed)
 	}
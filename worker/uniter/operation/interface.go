@@ -138,6 +138,7 @@ type Callbacks interface {
 
 	// NotifyHook* exist so that we can defer worrying about how to untangle the
 	// callbacks inserted for uniter_test. They're only used by RunHook operations.
+	NotifyHookStarted(string, runner.Context)
 	NotifyHookCompleted(string, runner.Context)
 	NotifyHookFailed(string, runner.Context)
 
@@ -182,6 +182,7 @@ func (s *RunHookSuite) getExecuteRunnerTest(c *gc.C, newHook newHook, kind hooks
 	runnerFactory := NewRunHookRunnerFactory(runErr)
 	callbacks := &ExecuteHookCallbacks{
 		PrepareHookCallbacks:    NewPrepareHookCallbacks(),
+		MockNotifyHookStarted:   &MockNotify{},
 		MockNotifyHookCompleted: &MockNotify{},
 		MockNotifyHookFailed:    &MockNotify{},
 	}
@@ -267,6 +268,7 @@ func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(err, gc.Equals, operation.ErrHookFailed)
 	c.Assert(newState, gc.IsNil)
 	c.Assert(*runnerFactory.MockNewHookRunner.runner.MockRunHook.gotName, gc.Equals, "some-hook-name")
+	c.Assert(*callbacks.MockNotifyHookStarted.gotName, gc.Equals, "some-hook-name")
 	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
 	c.Assert(*callbacks.MockNotifyHookFailed.gotContext, gc.Equals, runnerFactory.MockNewHookRunner.runner.context)
 	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
@@ -175,10 +175,15 @@ func (mock *MockNotify) Call(hookName string, ctx runner.Context) {
 
 type ExecuteHookCallbacks struct {
 	*PrepareHookCallbacks
+	MockNotifyHookStarted   *MockNotify
 	MockNotifyHookCompleted *MockNotify
 	MockNotifyHookFailed    *MockNotify
 }
 
+func (cb *ExecuteHookCallbacks) NotifyHookStarted(hookName string, ctx runner.Context) {
+	cb.MockNotifyHookStarted.Call(hookName, ctx)
+}
+
 func (cb *ExecuteHookCallbacks) NotifyHookCompleted(hookName string, ctx runner.Context) {
 	cb.MockNotifyHookCompleted.Call(hookName, ctx)
 }
@@ -0,0 +1,106 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujuos "github.com/juju/utils/os"
+)
+
+const (
+	// defaultProbeTimeout is the time after which a probe is considered
+	// to have failed, if the juju-check-network action has no timeout.
+	defaultProbeTimeout = 5 * time.Second
+
+	probeReachable   = "reachable"
+	probeUnreachable = "unreachable"
+)
+
+// probeAddress checks whether the address can be reached from the
+// local machine, returning an error if there is no reply within the
+// timeout. It's a variable so it can be patched out in tests.
+var probeAddress = func(address string, timeout time.Duration) error {
+	var command string
+	var args []string
+	if jujuos.HostOS() == jujuos.Windows {
+		command = "ping"
+		args = []string{"-n", "1", "-w", fmt.Sprint(int64(timeout / time.Millisecond))}
+	} else {
+		command = "ping"
+		if ip := net.ParseIP(address); ip != nil && ip.To4() == nil {
+			command = "ping6"
+		}
+		// ping's deadline is in whole seconds.
+		seconds := int64((timeout + time.Second - 1) / time.Second)
+		args = []string{"-c", "1", "-W", fmt.Sprint(seconds)}
+	}
+	out, err := exec.Command(command, append(args, address)...).CombinedOutput()
+	if err != nil {
+		if output := strings.TrimSpace(string(out)); output != "" {
+			lines := strings.Split(output, "\n")
+			return errors.Errorf("%v: %s", err, lines[len(lines)-1])
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// runJujuCheckNetworkAction is the function that executes when a
+// juju-check-network action is ran. It probes the address of each
+// target, and records the outcome in the action results under the
+// key "target-<n>". The action fails only if the parameters are
+// invalid; unreachable targets are reported in the results.
+func (runner *runner) runJujuCheckNetworkAction() error {
+	params, err := runner.context.ActionParams()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	targets, ok := params["targets"].([]interface{})
+	if !ok {
+		return runner.context.Flush("juju-check-network", errors.New("no targets parameter to juju-check-network action"))
+	}
+
+	// The timeout is passed in in nanoseconds, but due to
+	// serialization it comes out as float64.
+	timeout := defaultProbeTimeout
+	if value, ok := params["timeout"].(float64); ok && value > 0 {
+		timeout = time.Duration(value)
+	}
+
+	for i, target := range targets {
+		target, ok := target.(map[string]interface{})
+		if !ok {
+			return runner.context.Flush("juju-check-network", errors.Errorf("invalid target %d", i))
+		}
+		key := fmt.Sprintf("target-%d", i)
+		for _, attr := range []string{"unit", "relation", "address"} {
+			value, _ := target[attr].(string)
+			if err := runner.context.UpdateActionResults([]string{key, attr}, value); err != nil {
+				return runner.context.Flush("juju-check-network", errors.Trace(err))
+			}
+		}
+		address, _ := target["address"].(string)
+		status, message := probeReachable, ""
+		if err := probeAddress(address, timeout); err != nil {
+			logger.Debugf("cannot reach %v: %v", target["unit"], err)
+			status, message = probeUnreachable, err.Error()
+		}
+		if err := runner.context.UpdateActionResults([]string{key, "status"}, status); err != nil {
+			return runner.context.Flush("juju-check-network", errors.Trace(err))
+		}
+		if message == "" {
+			continue
+		}
+		if err := runner.context.UpdateActionResults([]string{key, "message"}, message); err != nil {
+			return runner.context.Flush("juju-check-network", errors.Trace(err))
+		}
+	}
+	return runner.context.Flush("juju-check-network", nil)
+}
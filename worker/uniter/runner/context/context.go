@@ -440,8 +440,12 @@ func (ctx *HookContext) AddUnitStorage(cons map[string]params.StorageConstraints
 }
 
 func (ctx *HookContext) OpenPorts(protocol string, fromPort, toPort int) error {
+	return ctx.OpenEndpointPorts("", protocol, fromPort, toPort)
+}
+
+func (ctx *HookContext) OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
 	return tryOpenPorts(
-		protocol, fromPort, toPort,
+		protocol, fromPort, toPort, endpoint,
 		ctx.unit.Tag(),
 		ctx.machinePorts, ctx.pendingPorts,
 	)
@@ -506,6 +510,14 @@ func (ctx *HookContext) SetActionMessage(message string) error {
 	return nil
 }
 
+// LogActionMessage records a progress message for the Action.
+func (ctx *HookContext) LogActionMessage(message string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	return ctx.state.LogActionMessage(ctx.actionData.Tag, message)
+}
+
 // SetActionFailed sets the fail state of the action.
 func (ctx *HookContext) SetActionFailed() error {
 	if ctx.actionData == nil {
@@ -684,7 +696,15 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 		if writeChanges {
 			var e error
 			var op string
-			if rangeInfo.ShouldOpen {
+			if rangeInfo.ShouldOpen && rangeInfo.Endpoint != "" {
+				e = ctx.unit.OpenEndpointPorts(
+					rangeInfo.Endpoint,
+					rangeKey.Ports.Protocol,
+					rangeKey.Ports.FromPort,
+					rangeKey.Ports.ToPort,
+				)
+				op = "open"
+			} else if rangeInfo.ShouldOpen {
 				e = ctx.unit.OpenPorts(
 					rangeKey.Ports.Protocol,
 					rangeKey.Ports.FromPort,
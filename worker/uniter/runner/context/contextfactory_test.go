@@ -256,6 +256,32 @@ func (s *ContextFactorySuite) TestActionContext(c *gc.C) {
 	s.AssertNotStorageContext(c, ctx)
 }
 
+func (s *ContextFactorySuite) TestActionContextLogActionMessage(c *gc.C) {
+	s.SetCharm(c, "dummy")
+	action, err := s.State.EnqueueAction(s.unit.Tag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	actionData := &context.ActionData{
+		Name:       action.Name(),
+		Tag:        names.NewActionTag(action.Id()),
+		Params:     action.Parameters(),
+		ResultsMap: map[string]interface{}{},
+	}
+	ctx, err := s.factory.ActionContext(actionData)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.Prepare()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = ctx.LogActionMessage("halfway there")
+	c.Assert(err, jc.ErrorIsNil)
+
+	action, err = s.State.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message(), gc.Equals, "halfway there")
+}
+
 func (s *ContextFactorySuite) TestCommandContext(c *gc.C) {
 	ctx, err := s.factory.CommandContext(context.CommandInfo{RelationId: -1})
 	c.Assert(err, jc.ErrorIsNil)
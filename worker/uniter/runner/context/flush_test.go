@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/metrics/spool"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	runnertesting "github.com/juju/juju/worker/uniter/runner/testing"
@@ -168,6 +169,26 @@ func (s *FlushContextSuite) TestRunHookOpensAndClosesPendingPorts(c *gc.C) {
 	c.Assert(unitRanges, jc.DeepEquals, expectUnitRanges)
 }
 
+func (s *FlushContextSuite) TestRunHookOpensEndpointPortsOnSuccess(c *gc.C) {
+	ctx := s.context(c)
+
+	err := ctx.OpenEndpointPorts("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.OpenPorts("tcp", 443, 443)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Flush the context with a success.
+	err = ctx.Flush("some badge", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ports, err := s.machine.OpenedPorts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.PortsForUnit(s.unit.Name()), jc.SameContents, []state.PortRange{
+		{UnitName: "u/0", FromPort: 80, ToPort: 80, Protocol: "tcp", Endpoint: "url"},
+		{UnitName: "u/0", FromPort: 443, ToPort: 443, Protocol: "tcp"},
+	})
+}
+
 func (s *FlushContextSuite) TestRunHookAddStorageOnFailure(c *gc.C) {
 	ctx := s.context(c)
 	c.Assert(ctx.UnitName(), gc.Equals, "u/0")
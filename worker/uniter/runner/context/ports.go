@@ -19,6 +19,7 @@ import (
 type PortRangeInfo struct {
 	ShouldOpen  bool
 	RelationTag names.RelationTag
+	Endpoint    string
 }
 
 // PortRange contains a port range and a relation id. Used as key to
@@ -44,6 +45,7 @@ func validatePortRange(protocol string, fromPort, toPort int) (network.PortRange
 func tryOpenPorts(
 	protocol string,
 	fromPort, toPort int,
+	endpoint string,
 	unitTag names.UnitTag,
 	machinePorts map[network.PortRange]params.RelationUnit,
 	pendingPorts map[PortRange]PortRangeInfo,
@@ -65,12 +67,12 @@ func tryOpenPorts(
 
 	rangeInfo, isKnown := pendingPorts[rangeKey]
 	if isKnown {
-		if !rangeInfo.ShouldOpen {
-			// If the same range is already pending to be closed, just
-			// mark is pending to be opened.
-			rangeInfo.ShouldOpen = true
-			pendingPorts[rangeKey] = rangeInfo
-		}
+		// If the same range is already pending to be closed, or to
+		// be opened for another endpoint, just mark it pending to be
+		// opened for this one.
+		rangeInfo.ShouldOpen = true
+		rangeInfo.Endpoint = endpoint
+		pendingPorts[rangeKey] = rangeInfo
 		return nil
 	}
 
@@ -87,9 +89,14 @@ func tryOpenPorts(
 		}
 		if newRange.ConflictsWith(portRange) {
 			if portRange == newRange && relUnitTag == unitTag {
-				// The same unit trying to open the same range is just
-				// ignored.
-				return nil
+				if endpoint == "" {
+					// The same unit trying to open the same range
+					// is just ignored.
+					return nil
+				}
+				// The range may be opened for another endpoint;
+				// the controller will reject it if so.
+				continue
 			}
 			return errors.Errorf(
 				"cannot open %v (unit %q): conflicts with existing %v (unit %q)",
@@ -109,6 +116,7 @@ func tryOpenPorts(
 
 	rangeInfo = pendingPorts[rangeKey]
 	rangeInfo.ShouldOpen = true
+	rangeInfo.Endpoint = endpoint
 	pendingPorts[rangeKey] = rangeInfo
 	return nil
 }
@@ -133,10 +133,20 @@ func makePendingPorts(
 	return result
 }
 
+func makeEndpointPendingPorts(endpoint, proto string, fromPort, toPort int) map[context.PortRange]context.PortRangeInfo {
+	result := makePendingPorts(proto, fromPort, toPort, true)
+	for key, info := range result {
+		info.Endpoint = endpoint
+		result[key] = info
+	}
+	return result
+}
+
 type portsTest struct {
 	about         string
 	proto         string
 	ports         []int
+	endpoint      string
 	machinePorts  map[network.PortRange]params.RelationUnit
 	pendingPorts  map[context.PortRange]context.PortRangeInfo
 	expectErr     string
@@ -196,6 +206,25 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 		about:        "try opening a range conflicting with another pending range",
 		pendingPorts: makePendingPorts("tcp", 5, 25, true),
 		expectErr:    `cannot open 10-20/tcp \(unit "u/0"\): conflicts with 5-25/tcp requested earlier`,
+	}, {
+		about:         "open a new range for an endpoint",
+		endpoint:      "website",
+		expectPending: makeEndpointPendingPorts("website", "tcp", 10, 20),
+	}, {
+		about:         "open an existing range for an endpoint",
+		endpoint:      "website",
+		machinePorts:  makeMachinePorts("u/0", "tcp", 10, 20),
+		expectPending: makeEndpointPendingPorts("website", "tcp", 10, 20),
+	}, {
+		about:         "open a range pending to be opened for another endpoint",
+		endpoint:      "website",
+		pendingPorts:  makeEndpointPendingPorts("admin", "tcp", 10, 20),
+		expectPending: makeEndpointPendingPorts("website", "tcp", 10, 20),
+	}, {
+		about:        "try opening a range for an endpoint conflicting with another unit",
+		endpoint:     "website",
+		machinePorts: makeMachinePorts("u/1", "tcp", 10, 20),
+		expectErr:    `cannot open 10-20/tcp \(unit "u/0"\): conflicts with existing 10-20/tcp \(unit "u/1"\)`,
 	}}
 	for i, test := range tests {
 		c.Logf("test %d: %s", i, test.about)
@@ -205,6 +234,7 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 			test.proto,
 			test.ports[0],
 			test.ports[1],
+			test.endpoint,
 			names.NewUnitTag("u/0"),
 			test.machinePorts,
 			test.pendingPorts,
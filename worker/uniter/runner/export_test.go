@@ -12,6 +12,7 @@ var (
 	SearchHook              = searchHook
 	HookCommand             = hookCommand
 	LookPath                = lookPath
+	ProbeAddress            = &probeAddress
 )
 
 func RunnerPaths(rnr Runner) context.Paths {
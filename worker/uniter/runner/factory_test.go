@@ -252,6 +252,19 @@ func (s *FactorySuite) TestNewActionRunnerGood(c *gc.C) {
 	}
 }
 
+func (s *FactorySuite) TestNewActionRunnerTimeout(c *gc.C) {
+	s.SetCharm(c, "dummy")
+	action, err := s.unit.AddActionWithOptions("snapshot", nil, state.ActionOptions{
+		Timeout: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rnr, err := s.factory.NewActionRunner(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := rnr.Context().ActionData()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.Timeout, gc.Equals, time.Minute)
+}
+
 func (s *FactorySuite) TestNewActionRunnerBadCharm(c *gc.C) {
 	rnr, err := s.factory.NewActionRunner("irrelevant")
 	c.Assert(rnr, gc.IsNil)
@@ -95,6 +95,9 @@ type actionHookContext interface {
 	// SetActionMessage sets a message for the Action.
 	SetActionMessage(string) error
 
+	// LogActionMessage records a progress message for the Action.
+	LogActionMessage(string) error
+
 	// SetActionFailed sets a failure state for the Action.
 	SetActionFailed() error
 }
@@ -149,6 +152,11 @@ type ContextNetworking interface {
 	// executing unit's service is exposed.
 	OpenPorts(protocol string, fromPort, toPort int) error
 
+	// OpenEndpointPorts marks the supplied port range for opening
+	// for the named endpoint when the executing unit's service is
+	// exposed, to the sources the endpoint is exposed to.
+	OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error
+
 	// ClosePorts ensures the supplied port range is closed even when
 	// the executing unit's service is exposed (unless it is opened
 	// separately by a co- located unit).
@@ -280,6 +288,10 @@ type ContextStorageAttachment interface {
 	// Location returns the location of the storage: the mount point for
 	// filesystem-kind stores, and the device path for block-kind stores.
 	Location() string
+
+	// Size returns the size of the storage in MiB, or zero if the size
+	// is not known.
+	Size() uint64
 }
 
 // ContextVersion expresses the parts of a hook context related to
@@ -18,6 +18,7 @@ type NetworkGetCommand struct {
 
 	bindingName    string
 	primaryAddress bool
+	ingressAddress bool
 
 	out cmd.Output
 }
@@ -29,13 +30,16 @@ func NewNetworkGetCommand(ctx Context) (cmd.Command, error) {
 
 // Info is part of the cmd.Command interface.
 func (c *NetworkGetCommand) Info() *cmd.Info {
-	args := "<binding-name> --primary-address"
+	args := "<binding-name> [--primary-address | --ingress-address]"
 	doc := `
 network-get returns the network config for a given binding name. By default
 it returns the list of interfaces and associated addresses in the space for
-the binding.
+the binding, and any ingress addresses and port overriding them.
 If --primary-address flag is specified then only single IP address is
 returned that the local unit should advertise as its endpoint to its peers.
+If --ingress-address flag is specified then the address related units should
+use to reach the local unit is returned; this is the overriding ingress
+address of the application's endpoint, if any, or else the primary address.
 `
 	return &cmd.Info{
 		Name:    "network-get",
@@ -49,6 +53,7 @@ returned that the local unit should advertise as its endpoint to its peers.
 func (c *NetworkGetCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.BoolVar(&c.primaryAddress, "primary-address", false, "get the primary address for the binding")
+	f.BoolVar(&c.ingressAddress, "ingress-address", false, "get the ingress address for the binding")
 }
 
 // Init is part of the cmd.Command interface.
@@ -60,6 +65,9 @@ func (c *NetworkGetCommand) Init(args []string) error {
 	if c.bindingName == "" {
 		return fmt.Errorf("no binding name specified")
 	}
+	if c.primaryAddress && c.ingressAddress {
+		return fmt.Errorf("--primary-address and --ingress-address are mutually exclusive")
+	}
 
 	return cmd.CheckEmpty(args[1:])
 }
@@ -78,7 +86,10 @@ func (c *NetworkGetCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(ni.Error)
 	}
 
-	if c.primaryAddress {
+	if c.ingressAddress && len(ni.IngressAddresses) > 0 {
+		return c.out.Write(ctx, ni.IngressAddresses[0])
+	}
+	if c.primaryAddress || c.ingressAddress {
 		if len(ni.Info[0].Addresses) == 0 {
 			return fmt.Errorf("No addresses attached to space for binding %q", c.bindingName)
 		}
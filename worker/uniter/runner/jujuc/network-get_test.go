@@ -73,6 +73,21 @@ func (s *NetworkGetSuite) createCommand(c *gc.C) cmd.Command {
 			},
 		},
 	}
+	presetBindings["known-ingress"] = params.NetworkInfoResult{
+		Info: []params.NetworkInfo{
+			{MACAddress: "00:11:22:33:44:44",
+				InterfaceName: "eth4",
+				Addresses: []params.InterfaceAddress{
+					{
+						Address: "10.44.1.8",
+						CIDR:    "10.44.1.0/24",
+					},
+				},
+			},
+		},
+		IngressAddresses: []string{"10.0.0.100"},
+		IngressPort:      3306,
+	}
 	presetBindings["valid-no-config"] = params.NetworkInfoResult{}
 	// Simulate known but unspecified bindings.
 	presetBindings["known-unbound"] = params.NetworkInfoResult{
@@ -170,6 +185,36 @@ info:
     cidr: 10.10.1.0/24
   - address: 192.168.2.111
     cidr: 192.168.2.0/24`[1:],
+	}, {
+		summary: "both --primary-address and --ingress-address",
+		args:    []string{"known-ingress", "--primary-address", "--ingress-address"},
+		code:    2,
+		out:     `--primary-address and --ingress-address are mutually exclusive`,
+	}, {
+		summary: "overridden ingress address given with --ingress-address",
+		args:    []string{"known-ingress", "--ingress-address"},
+		out:     "10.0.0.100",
+	}, {
+		summary: "overridden ingress address given with --primary-address",
+		args:    []string{"known-ingress", "--primary-address"},
+		out:     "10.44.1.8",
+	}, {
+		summary: "overridden ingress address given without flags",
+		args:    []string{"known-ingress"},
+		out: `
+info:
+- macaddress: "00:11:22:33:44:44"
+  interfacename: eth4
+  addresses:
+  - address: 10.44.1.8
+    cidr: 10.44.1.0/24
+ingress-addresses:
+- 10.0.0.100
+ingress-port: 3306`[1:],
+	}, {
+		summary: "no overridden ingress address falls back to primary address, with --ingress-address",
+		args:    []string{"known-relation", "--ingress-address"},
+		out:     "10.10.0.23",
 	}, {
 		summary: "no user requested binding falls back to primary address, with --primary-address",
 		args:    []string{"known-unbound", "--primary-address"},
@@ -110,6 +110,7 @@ type portCommand struct {
 	Protocol   string
 	FromPort   int
 	ToPort     int
+	Endpoint   string
 	formatFlag string // deprecated
 }
 
@@ -148,16 +149,33 @@ var openPortInfo = &cmd.Info{
 	Name:    "open-port",
 	Args:    portFormat,
 	Purpose: "register a port or range to open",
-	Doc:     "The port range will only be open while the application is exposed.",
+	Doc: `
+The port range will only be open while the application is exposed.
+If --endpoint is specified, the port range is opened for that endpoint,
+and may only be accessed from the sources the endpoint is exposed to.
+`[1:],
+}
+
+// openPortCommand implements the open-port command.
+type openPortCommand struct {
+	portCommand
+}
+
+func (c *openPortCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.portCommand.SetFlags(f)
+	f.StringVar(&c.Endpoint, "endpoint", "", "the endpoint to open the port range for")
 }
 
 func NewOpenPortCommand(ctx Context) (cmd.Command, error) {
-	return &portCommand{
+	return &openPortCommand{portCommand{
 		info: openPortInfo,
 		action: func(c *portCommand) error {
+			if c.Endpoint != "" {
+				return ctx.OpenEndpointPorts(c.Endpoint, c.Protocol, c.FromPort, c.ToPort)
+			}
 			return ctx.OpenPorts(c.Protocol, c.FromPort, c.ToPort)
 		},
-	}, nil
+	}}, nil
 }
 
 var closePortInfo = &cmd.Info{
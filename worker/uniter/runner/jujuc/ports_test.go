@@ -82,6 +82,18 @@ func (s *PortsSuite) TestOpenClose(c *gc.C) {
 	}
 }
 
+func (s *PortsSuite) TestOpenForEndpoint(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("open-port"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--endpoint", "website", "80"})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	s.Stub.CheckCall(c, 0, "OpenEndpointPorts", "website", "tcp", 80, 80)
+	hctx.info.CheckPorts(c, makeRanges("80/tcp"))
+}
+
 var badPortsTests = []struct {
 	args []string
 	err  string
@@ -126,6 +138,8 @@ register a port or range to open
 
 Details:
 The port range will only be open while the application is exposed.
+If --endpoint is specified, the port range is opened for that endpoint,
+and may only be accessed from the sources the endpoint is exposed to.
 `[1:])
 
 	close, err := jujuc.NewCommand(hctx, cmdString("close-port"))
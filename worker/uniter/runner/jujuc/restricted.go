@@ -56,6 +56,11 @@ func (*RestrictedContext) OpenPorts(protocol string, fromPort, toPort int) error
 	return ErrRestrictedContext
 }
 
+// OpenEndpointPorts implements jujuc.Context.
+func (*RestrictedContext) OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	return ErrRestrictedContext
+}
+
 // ClosePorts implements jujuc.Context.
 func (*RestrictedContext) ClosePorts(protocol string, fromPort, toPort int) error {
 	return ErrRestrictedContext
@@ -135,6 +140,9 @@ func (*RestrictedContext) UpdateActionResults(keys []string, value string) error
 // SetActionMessage implements jujuc.Context.
 func (*RestrictedContext) SetActionMessage(string) error { return ErrRestrictedContext }
 
+// LogActionMessage implements jujuc.Context.
+func (*RestrictedContext) LogActionMessage(string) error { return ErrRestrictedContext }
+
 // SetActionFailed implements jujuc.Context.
 func (*RestrictedContext) SetActionFailed() error { return ErrRestrictedContext }
 
@@ -68,6 +68,9 @@ func (c *StorageGetCommand) Run(ctx *cmd.Context) error {
 		"kind":     storage.Kind().String(),
 		"location": storage.Location(),
 	}
+	if size := storage.Size(); size > 0 {
+		values["size"] = size
+	}
 	if c.key == "" {
 		return c.out.Write(ctx, values)
 	}
@@ -61,6 +61,17 @@ func (s *storageGetSuite) TestOutputFormatKey(c *gc.C) {
 	}
 }
 
+func (s *storageGetSuite) TestOutputSize(c *gc.C) {
+	hctx, info := s.newHookContext()
+	info.SetStorageSize(s.storageName, 2048)
+	com, err := jujuc.NewCommand(hctx, cmdString("storage-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"size"})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, "2048\n")
+}
+
 func (s *storageGetSuite) TestHelp(c *gc.C) {
 	hctx, _ := s.newHookContext()
 	com, err := jujuc.NewCommand(hctx, cmdString("storage-get"))
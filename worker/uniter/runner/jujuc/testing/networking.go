@@ -84,6 +84,17 @@ func (c *ContextNetworking) OpenPorts(protocol string, from, to int) error {
 	return nil
 }
 
+// OpenEndpointPorts implements jujuc.ContextNetworking.
+func (c *ContextNetworking) OpenEndpointPorts(endpoint, protocol string, from, to int) error {
+	c.stub.AddCall("OpenEndpointPorts", endpoint, protocol, from, to)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.info.AddPorts(protocol, from, to)
+	return nil
+}
+
 // ClosePorts implements jujuc.ContextNetworking.
 func (c *ContextNetworking) ClosePorts(protocol string, from, to int) error {
 	c.stub.AddCall("ClosePorts", protocol, from, to)
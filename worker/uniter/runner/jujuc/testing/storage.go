@@ -38,7 +38,7 @@ func (s *Storage) SetAttachment(attach jujuc.ContextStorageAttachment) {
 func (s *Storage) SetNewAttachment(name, location string, kind storage.StorageKind, stub *testing.Stub) {
 	tag := names.NewStorageTag(name)
 	attachment := &ContextStorageAttachment{
-		info: &StorageAttachment{tag, kind, location},
+		info: &StorageAttachment{Tag: tag, Kind: kind, Location: location},
 	}
 	attachment.stub = stub
 	s.SetAttachment(attachment)
@@ -49,6 +49,16 @@ func (s *Storage) SetBlockStorage(name, location string, stub *testing.Stub) {
 	s.SetNewAttachment(name, location, storage.StorageKindBlock, stub)
 }
 
+// SetStorageSize sets the size of the storage with the given ID.
+func (s *Storage) SetStorageSize(id string, size uint64) {
+	tag := names.NewStorageTag(id)
+	attachment, ok := s.Storage[tag].(*ContextStorageAttachment)
+	if !ok {
+		panic(fmt.Sprintf("storage %q not added yet", id))
+	}
+	attachment.info.Size = size
+}
+
 // SetStorageTag sets the storage tag to the given ID.
 func (s *Storage) SetStorageTag(id string) {
 	tag := names.NewStorageTag(id)
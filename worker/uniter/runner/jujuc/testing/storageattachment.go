@@ -14,6 +14,7 @@ type StorageAttachment struct {
 	Tag      names.StorageTag
 	Kind     storage.StorageKind
 	Location string
+	Size     uint64
 }
 
 // ContextStorageAttachment is a test double for jujuc.ContextStorageAttachment.
@@ -45,3 +46,11 @@ func (c *ContextStorageAttachment) Location() string {
 
 	return c.info.Location
 }
+
+// Size implements jujuc.StorageAttachement.
+func (c *ContextStorageAttachment) Size() uint64 {
+	c.stub.AddCall("Size")
+	c.stub.NextErr()
+
+	return c.info.Size
+}
@@ -0,0 +1,18 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+)
+
+// setHookProcessGroup does nothing on Windows, which has no process
+// groups to kill.
+func setHookProcessGroup(ps *exec.Cmd) {}
+
+// killHookProcess kills the hook's process.
+func killHookProcess(p *os.Process) error {
+	return p.Kill()
+}
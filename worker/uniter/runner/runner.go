@@ -189,21 +189,27 @@ func (runner *runner) updateActionResults(results *utilexec.ExecResponse) error
 
 // RunAction exists to satisfy the Runner interface.
 func (runner *runner) RunAction(actionName string) error {
-	if _, err := runner.context.ActionData(); err != nil {
+	data, err := runner.context.ActionData()
+	if err != nil {
 		return errors.Trace(err)
 	}
-	if actionName == actions.JujuRunActionName {
+	switch actionName {
+	case actions.JujuRunActionName:
 		return runner.runJujuRunAction()
+	case actions.JujuCheckNetworkActionName:
+		return runner.runJujuCheckNetworkAction()
 	}
-	return runner.runCharmHookWithLocation(actionName, "actions")
+	return runner.runCharmHookWithLocation(actionName, "actions", data.Timeout)
 }
 
 // RunHook exists to satisfy the Runner interface.
 func (runner *runner) RunHook(hookName string) error {
-	return runner.runCharmHookWithLocation(hookName, "hooks")
+	return runner.runCharmHookWithLocation(hookName, "hooks", 0)
 }
 
-func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) error {
+// runCharmHookWithLocation runs the named hook or action of the charm,
+// killing it if it is still running after the timeout, if one is given.
+func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string, timeout time.Duration) error {
 	srv, err := runner.startJujucServer()
 	if err != nil {
 		return err
@@ -226,12 +232,12 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 		logger.Infof("executing %s via debug-hooks", hookName)
 		err = session.RunHook(hookName, runner.paths.GetCharmDir(), env)
 	} else {
-		err = runner.runCharmHook(hookName, env, charmLocation)
+		err = runner.runCharmHook(hookName, env, charmLocation, timeout, clock.WallClock)
 	}
 	return runner.context.Flush(hookName, err)
 }
 
-func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string) error {
+func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string, timeout time.Duration, clock clock.Clock) error {
 	charmDir := runner.paths.GetCharmDir()
 	hook, err := searchHook(charmDir, filepath.Join(charmLocation, hookName))
 	if err != nil {
@@ -253,18 +259,45 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 		logger: runner.getLogger(hookName),
 	}
 	go hookLogger.run()
+	if timeout > 0 {
+		setHookProcessGroup(ps)
+	}
 	err = ps.Start()
 	outWriter.Close()
 	if err == nil {
 		// Record the *os.Process of the hook
 		runner.context.SetProcess(hookProcess{ps.Process})
 		// Block until execution finishes
-		err = ps.Wait()
+		err = waitHookProcess(ps, timeout, clock)
 	}
 	hookLogger.stop()
 	return errors.Trace(err)
 }
 
+// waitHookProcess blocks until the hook process finishes. If a timeout
+// is given and the process is still running when it expires, the
+// process is killed, along with any processes it started, and an error
+// is returned.
+func waitHookProcess(ps *exec.Cmd, timeout time.Duration, clock clock.Clock) error {
+	if timeout == 0 {
+		return ps.Wait()
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-clock.After(timeout):
+	}
+	if err := killHookProcess(ps.Process); err != nil {
+		logger.Warningf("cannot kill timed out process %d: %v", ps.Process.Pid, err)
+	}
+	<-done
+	return errors.Errorf("timed out after %v", timeout)
+}
+
 func (runner *runner) startJujucServer() (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
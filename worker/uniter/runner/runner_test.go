@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
@@ -191,9 +193,7 @@ func (ctx *MockContext) ActionParams() (map[string]interface{}, error) {
 }
 
 func (ctx *MockContext) UpdateActionResults(keys []string, value string) error {
-	for _, key := range keys {
-		ctx.actionResults[key] = value
-	}
+	ctx.actionResults[strings.Join(keys, ".")] = value
 	return nil
 }
 
@@ -289,6 +289,59 @@ func (s *RunMockContextSuite) TestRunActionFlushFailure(c *gc.C) {
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
+func (s *RunMockContextSuite) TestRunActionTimeout(c *gc.C) {
+	ctx := &MockContext{
+		actionData: &context.ActionData{Timeout: 100 * time.Millisecond},
+	}
+	makeCharm(c, hookSpec{
+		dir:   "actions",
+		name:  hookName,
+		perm:  0700,
+		sleep: 10,
+	}, s.paths.GetCharmDir())
+	start := time.Now()
+	err := runner.NewRunner(ctx, s.paths).RunAction("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	// The action is killed long before it would finish.
+	c.Assert(time.Since(start) < 5*time.Second, jc.IsTrue)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "timed out after 100ms")
+	s.assertRecordedPid(c, ctx.expectPid)
+}
+
+func (s *RunMockContextSuite) TestRunActionTimeoutKillsChildren(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("process groups are not supported on windows")
+	}
+	ctx := &MockContext{
+		actionData: &context.ActionData{Timeout: 100 * time.Millisecond},
+	}
+	childPidFile := filepath.Join(c.MkDir(), "child.pid")
+	makeCharm(c, hookSpec{
+		dir:          "actions",
+		name:         hookName,
+		perm:         0700,
+		childPidFile: childPidFile,
+		sleep:        10,
+	}, s.paths.GetCharmDir())
+	err := runner.NewRunner(ctx, s.paths).RunAction("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "timed out after 100ms")
+
+	data, err := ioutil.ReadFile(childPidFile)
+	c.Assert(err, jc.ErrorIsNil)
+	childPid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	c.Assert(err, jc.ErrorIsNil)
+	// The child is killed with the action, though it may take a
+	// moment to be reaped.
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if !processExists(childPid) {
+			return
+		}
+	}
+	c.Fatalf("child process %d of timed out action still running", childPid)
+}
+
 func (s *RunMockContextSuite) TestRunActionParamsFailure(c *gc.C) {
 	expectErr := errors.New("stork")
 	ctx := &MockContext{
@@ -336,6 +389,57 @@ func (s *RunMockContextSuite) TestRunActionCancelled(c *gc.C) {
 	c.Assert(ctx.actionResults["Stderr"], gc.Equals, nil)
 }
 
+func (s *RunMockContextSuite) TestRunCheckNetworkAction(c *gc.C) {
+	var probed []string
+	s.PatchValue(runner.ProbeAddress, func(address string, timeout time.Duration) error {
+		c.Check(timeout, gc.Equals, 2*time.Second)
+		probed = append(probed, address)
+		if address == "10.0.0.2" {
+			return errors.New("exit status 1")
+		}
+		return nil
+	})
+	ctx := &MockContext{
+		actionData: &context.ActionData{},
+		actionParams: map[string]interface{}{
+			"targets": []interface{}{
+				map[string]interface{}{"unit": "mysql/0", "relation": "wordpress:db mysql:server", "address": "10.0.0.1"},
+				map[string]interface{}{"unit": "mysql/1", "relation": "wordpress:db mysql:server", "address": "10.0.0.2"},
+			},
+			"timeout": float64((2 * time.Second).Nanoseconds()),
+		},
+		actionResults: map[string]interface{}{},
+	}
+	err := runner.NewRunner(ctx, s.paths).RunAction("juju-check-network")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, "juju-check-network")
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	c.Assert(probed, jc.DeepEquals, []string{"10.0.0.1", "10.0.0.2"})
+	c.Assert(ctx.actionResults, jc.DeepEquals, map[string]interface{}{
+		"target-0.unit":     "mysql/0",
+		"target-0.relation": "wordpress:db mysql:server",
+		"target-0.address":  "10.0.0.1",
+		"target-0.status":   "reachable",
+		"target-1.unit":     "mysql/1",
+		"target-1.relation": "wordpress:db mysql:server",
+		"target-1.address":  "10.0.0.2",
+		"target-1.status":   "unreachable",
+		"target-1.message":  "exit status 1",
+	})
+}
+
+func (s *RunMockContextSuite) TestRunCheckNetworkActionNoTargets(c *gc.C) {
+	ctx := &MockContext{
+		actionData:    &context.ActionData{},
+		actionParams:  map[string]interface{}{},
+		actionResults: map[string]interface{}{},
+	}
+	err := runner.NewRunner(ctx, s.paths).RunAction("juju-check-network")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, "juju-check-network")
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "no targets parameter to juju-check-network action")
+}
+
 func (s *RunMockContextSuite) TestRunCommandsFlushSuccess(c *gc.C) {
 	expectErr := errors.New("pew pew pew")
 	ctx := &MockContext{
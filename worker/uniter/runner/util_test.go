@@ -199,6 +199,11 @@ type hookSpec struct {
 	stderr string
 	// background holds a string to print in the background after 0.2s.
 	background string
+	// sleep holds the number of seconds to sleep for before exiting.
+	sleep int
+	// childPidFile, if set, is the file to which the pid of a child
+	// process that sleeps for a long time is written.
+	childPidFile string
 }
 
 // makeCharm constructs a fake charm dir containing a single named hook
@@ -243,5 +248,12 @@ func makeCharm(c *gc.C, spec hookSpec, charmDir string) {
 		// expected.
 		printf("(sleep 0.2; echo %s; sleep 10) &", spec.background)
 	}
+	if spec.childPidFile != "" {
+		printf("sleep 100 &")
+		printf("echo $! > %s", spec.childPidFile)
+	}
+	if spec.sleep != 0 {
+		printf("sleep %d", spec.sleep)
+	}
 	printf("exit %d", spec.code)
 }
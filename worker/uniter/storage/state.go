@@ -25,6 +25,11 @@ type state struct {
 	// attached records the uniter's knowledge of the
 	// storage attachment state.
 	attached bool
+
+	// size records the size of the storage in MiB, as last
+	// reported to the charm by a storage-attached or
+	// storage-resized hook.
+	size uint64
 }
 
 // ValidateHook returns an error if the supplied hook.Info does not represent
@@ -41,7 +46,7 @@ func (s *state) ValidateHook(hi hook.Info) (err error) {
 		if s.attached {
 			return errors.New("storage already attached")
 		}
-	case hooks.StorageDetaching:
+	case hooks.StorageDetaching, hook.StorageResized:
 		if !s.attached {
 			return errors.New("storage not attached")
 		}
@@ -85,6 +90,7 @@ func readStateFile(dirPath string, tag names.StorageTag) (d *stateFile, err erro
 		return nil, errors.Errorf("invalid storage state file %q: missing 'attached'", d.path)
 	}
 	d.state.attached = *info.Attached
+	d.state.size = info.Size
 	return d, nil
 }
 
@@ -130,12 +136,27 @@ func (d *stateFile) CommitHook(hi hook.Info) (err error) {
 		return d.Remove()
 	}
 	attached := true
-	di := diskInfo{&attached}
+	di := diskInfo{Attached: &attached, Size: hi.StorageSize}
 	if err := utils.WriteYaml(d.path, &di); err != nil {
 		return err
 	}
 	// If write was successful, update own state.
 	d.state.attached = true
+	d.state.size = hi.StorageSize
+	return nil
+}
+
+// recordSize atomically writes to disk the size of attached storage,
+// without a hook having been run. It is used to fill in the size for
+// state files written before sizes were recorded.
+func (d *stateFile) recordSize(size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "failed to record size of %q on state directory", d.storage.Id())
+	attached := true
+	di := diskInfo{Attached: &attached, Size: size}
+	if err := utils.WriteYaml(d.path, &di); err != nil {
+		return err
+	}
+	d.state.size = size
 	return nil
 }
 
@@ -151,5 +172,6 @@ func (d *stateFile) Remove() error {
 
 // diskInfo defines the storage attachment data serialization.
 type diskInfo struct {
-	Attached *bool `yaml:"attached,omitempty"`
+	Attached *bool  `yaml:"attached,omitempty"`
+	Size     uint64 `yaml:"size,omitempty"`
 }
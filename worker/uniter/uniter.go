@@ -16,6 +16,7 @@ import (
 	"github.com/juju/utils/clock"
 	"github.com/juju/utils/exec"
 	jujuos "github.com/juju/utils/os"
+	"github.com/prometheus/client_golang/prometheus"
 	corecharm "gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
@@ -92,6 +93,12 @@ type Uniter struct {
 	// need to be extended, perhaps a list of observers would be needed.
 	observer UniterExecutionObserver
 
+	// prometheusRegisterer, if non-nil, is used to register the
+	// hookMetrics collector while the uniter is running.
+	prometheusRegisterer prometheus.Registerer
+	hookMetrics          *HookMetrics
+	hookStarted          time.Time
+
 	// updateStatusAt defines a function that will be used to generate signals for
 	// the update-status hook
 	updateStatusAt func() <-chan time.Time
@@ -118,6 +125,9 @@ type UniterParams struct {
 	NewOperationExecutor NewExecutorFunc
 	TranslateResolverErr func(error) error
 	Clock                clock.Clock
+	// PrometheusRegisterer, if non-nil, is used to register metrics
+	// describing the hooks run by the uniter.
+	PrometheusRegisterer prometheus.Registerer
 	// TODO (mattyw, wallyworld, fwereade) Having the observer here make this approach a bit more legitimate, but it isn't.
 	// the observer is only a stop gap to be used in tests. A better approach would be to have the uniter tests start hooks
 	// that write to files, and have the tests watch the output to know that hooks have finished.
@@ -146,6 +156,7 @@ func NewUniter(uniterParams *UniterParams) (*Uniter, error) {
 		newOperationExecutor: uniterParams.NewOperationExecutor,
 		translateResolverErr: translateResolverErr,
 		observer:             uniterParams.Observer,
+		prometheusRegisterer: uniterParams.PrometheusRegisterer,
 		clock:                uniterParams.Clock,
 		downloader:           uniterParams.Downloader,
 	}
@@ -159,6 +170,14 @@ func NewUniter(uniterParams *UniterParams) (*Uniter, error) {
 }
 
 func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
+	if u.prometheusRegisterer != nil {
+		hookMetrics := NewHookMetrics()
+		if err := u.prometheusRegisterer.Register(hookMetrics); err != nil {
+			return errors.Annotate(err, "registering hook metrics")
+		}
+		defer u.prometheusRegisterer.Unregister(hookMetrics)
+		u.hookMetrics = hookMetrics
+	}
 	if err := u.init(unitTag); err != nil {
 		if err == jworker.ErrTerminateAgent {
 			return err